@@ -0,0 +1,49 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"gophkeeper/config"
+	"gophkeeper/internal/admin"
+	"gophkeeper/internal/server/repositories"
+	aserv "gophkeeper/internal/server/services/admin_service"
+	cserv "gophkeeper/internal/server/services/crypto_service"
+	"os"
+)
+
+func main() {
+	if err := runAdmin(os.Args[1:]); err != nil {
+		fmt.Fprintf(os.Stderr, "admin error: %v\n", err)
+		if errors.Is(err, admin.ErrUsage) {
+			admin.Usage(os.Stderr)
+			os.Exit(2)
+		}
+		os.Exit(1)
+	}
+}
+
+func runAdmin(args []string) error {
+	cnfg, err := config.NewServerConfig()
+	if err != nil {
+		return fmt.Errorf("get server config error: %w", err)
+	}
+
+	cs, err := cserv.NewCryptoService(cnfg)
+	if err != nil {
+		return fmt.Errorf("failed to create crypto service: %w", err)
+	}
+
+	repo, err := repositories.OpenStorage(cnfg)
+	if err != nil {
+		return fmt.Errorf("failed to create storage: %w", err)
+	}
+	defer repo.Close()
+
+	as, err := aserv.NewAdminService(repo, cs)
+	if err != nil {
+		return fmt.Errorf("failed to create admin service: %w", err)
+	}
+
+	return admin.NewCLI(as, os.Stdout).Run(context.Background(), args)
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"gophkeeper/config"
+	"gophkeeper/internal/agent/cli"
+	"gophkeeper/internal/agent/services"
+	"gophkeeper/models"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// runCLI runs a scripting command and returns the exit code for it.
+func runCLI(args []string, in io.Reader, out, errOut io.Writer) int {
+	cnfg, err := config.NewAgentConfig()
+	if err != nil {
+		fmt.Fprintf(errOut, "get agent config error: %v\n", err)
+		return cli.ExitError
+	}
+	stateFile := ""
+	if dir, err := os.UserConfigDir(); err == nil {
+		stateFile = filepath.Join(dir, "gophkeeper", "cli.json")
+	}
+	open := func(ctx context.Context, login, password, masterPassword string) (cli.Vault, error) {
+		return openVault(ctx, cnfg, login, password, masterPassword)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	c := cli.NewCLI(open, in, out, stateFile)
+	c.SetBreachData(cnfg.GetBreachData())
+	err = c.Run(ctx, args)
+	if err != nil {
+		fmt.Fprintf(errOut, "%s error: %v\n", args[0], err)
+		if errors.Is(err, cli.ErrUsage) {
+			cli.Usage(errOut)
+		}
+	}
+	return cli.ExitCode(err)
+}
+
+// openVault signs in without the UI and unlocks the vault of login.
+func openVault(ctx context.Context, cnfg *config.Config, login, password, masterPassword string) (*services.ItemService, error) {
+	us, is, err := newServices(cnfg, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := us.SignInUser(ctx, &models.User{Login: login, Password: []byte(password)}); err != nil {
+		return nil, err
+	}
+	if err := us.SetMasterKey(ctx, masterPassword); err != nil {
+		return nil, err
+	}
+	if err := us.EnsureUserKeys(ctx); err != nil {
+		return nil, err
+	}
+	return is, nil
+}
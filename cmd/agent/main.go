@@ -1,16 +1,28 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"gophkeeper/config"
+	"gophkeeper/internal/agent/cli"
 	"gophkeeper/internal/agent/client"
+	"gophkeeper/internal/agent/offline"
 	"gophkeeper/internal/agent/services"
+	"gophkeeper/internal/agent/sshagent"
 	"gophkeeper/internal/agent/ui"
+	"gophkeeper/internal/hibp"
 	"gophkeeper/internal/logger"
+	"gophkeeper/internal/telemetry"
 	"os"
+
+	"go.uber.org/zap"
 )
 
 func main() {
+	if len(os.Args) > 1 && cli.IsCommand(os.Args[1]) {
+		os.Exit(runCLI(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+	}
+
 	if err := runAgent(); err != nil {
 		fmt.Printf("run agent error: %v\n", err)
 		os.Exit(1)
@@ -27,31 +39,79 @@ func runAgent() error {
 		return fmt.Errorf("get agent config error: %w\n", err)
 	}
 
-	clnt, err := client.NewGRPCClient(cnfg)
+	shutdownTracing, err := telemetry.Init(cnfg.GetTraceFile(), "gophkeeper-agent")
 	if err != nil {
-		return fmt.Errorf("new grpc client error: %w\n", err)
+		return fmt.Errorf("init tracing error: %w\n", err)
 	}
+	defer shutdownTracing(context.Background())
 
-	cs, err := services.NewCryptoService(cnfg, clnt)
+	var cache *offline.Store
+	if cnfg.GetCacheFile() != "" {
+		cache, err = offline.Open(cnfg.GetCacheFile())
+		if err != nil {
+			logger.Log.Warn("offline cache is off", zap.Error(err))
+		} else {
+			defer cache.Close()
+		}
+	}
+
+	us, is, err := newServices(cnfg, cache)
 	if err != nil {
-		return fmt.Errorf("new crypto service error: %w\n", err)
+		return err
 	}
+	clnt, cs := is.Client, is.Crypto
+	oc, _ := clnt.(*offline.Client)
 
-	us, err := services.NewUserService(cnfg, clnt, cs)
+	if cnfg.GetSessionFile() != "" {
+		sessions, err := services.NewSessionStore(cnfg.GetSessionFile(), cnfg.GetSessionKeyfile())
+		if err != nil {
+			return fmt.Errorf("new session store error: %w\n", err)
+		}
+		us.SetSessionStore(sessions)
+	}
+
+	ors, err := services.NewOrgService(clnt, cs)
 	if err != nil {
-		return fmt.Errorf("new user service error: %w\n", err)
+		return fmt.Errorf("new org service error: %w\n", err)
 	}
 
-	is, err := services.NewItemService(clnt, cs)
+	es, err := services.NewEmergencyService(clnt, cs)
 	if err != nil {
-		return fmt.Errorf("new item service error: %w\n", err)
+		return fmt.Errorf("new emergency service error: %w\n", err)
 	}
 
-	if err = cs.SetPublicKey(); err != nil {
-		return fmt.Errorf("set public key error: %w\n", err)
+	if cnfg.GetKnownKeysFile() != "" {
+		pins, err := services.LoadKeyPins(cnfg.GetKnownKeysFile())
+		if err != nil {
+			return fmt.Errorf("load known keys error: %w\n", err)
+		}
+		is.SetKeyPins(pins)
+		ors.SetKeyPins(pins)
+		es.SetKeyPins(pins)
+	}
+
+	if cnfg.GetBreachData() != "" {
+		idx, err := hibp.Open(cnfg.GetBreachData())
+		if err != nil {
+			return fmt.Errorf("open breach dataset error: %w\n", err)
+		}
+		defer idx.Close()
+		is.SetBreachIndex(idx)
+	}
+
+	var sshAgent *sshagent.Agent
+	if socket := cnfg.GetSSHAgentSocket(); socket != "" {
+		sshAgent = sshagent.New()
+		ctx, stopAgent := context.WithCancel(context.Background())
+		defer stopAgent()
+		go func() {
+			if err := sshAgent.ListenAndServe(ctx, socket); err != nil {
+				logger.Log.Error("ssh agent stopped", zap.Error(err))
+			}
+		}()
 	}
 
-	uiContr, err := ui.NewUIController(us, is)
+	uiContr, err := ui.NewUIController(us, is, ors, es, sshAgent, oc, cnfg.GetIdleTimeout())
 	if err != nil {
 		return fmt.Errorf("new ui controller error: %w\n", err)
 	}
@@ -60,3 +120,50 @@ func runAgent() error {
 	}
 	return nil
 }
+
+// newServices connects to the server and creates the user and item
+// services every command needs. With a cache the services keep working
+// while the server is unreachable.
+func newServices(cnfg *config.Config, cache *offline.Store) (*services.UserService, *services.ItemService, error) {
+	grpcClient, err := client.NewGRPCClient(cnfg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("new grpc client error: %w\n", err)
+	}
+	var clnt client.Client = grpcClient
+	var offlineClient *offline.Client
+	if cache != nil {
+		offlineClient = offline.NewClient(grpcClient, cache, cnfg)
+		clnt = offlineClient
+	}
+
+	cs, err := services.NewCryptoService(cnfg, clnt)
+	if err != nil {
+		return nil, nil, fmt.Errorf("new crypto service error: %w\n", err)
+	}
+
+	us, err := services.NewUserService(cnfg, clnt, cs)
+	if err != nil {
+		return nil, nil, fmt.Errorf("new user service error: %w\n", err)
+	}
+
+	if cnfg.GetDeviceFile() != "" {
+		device, err := services.LoadDeviceIdentity(cnfg.GetDeviceFile(), cnfg.GetDeviceName())
+		if err != nil {
+			return nil, nil, fmt.Errorf("load device identity error: %w\n", err)
+		}
+		us.SetDevice(device)
+		if offlineClient != nil {
+			offlineClient.SetDeviceSigner(device.SignIn)
+		}
+	}
+
+	is, err := services.NewItemService(clnt, cs)
+	if err != nil {
+		return nil, nil, fmt.Errorf("new item service error: %w\n", err)
+	}
+
+	if err = cs.SetPublicKey(); err != nil {
+		return nil, nil, fmt.Errorf("set public key error: %w\n", err)
+	}
+	return us, is, nil
+}
@@ -1,15 +1,23 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"gophkeeper/config"
+	"gophkeeper/internal/lifecycle"
 	"gophkeeper/internal/logger"
 	"gophkeeper/internal/server"
 	"gophkeeper/internal/server/repositories"
 	cserv "gophkeeper/internal/server/services/crypto_service"
+	eserv "gophkeeper/internal/server/services/emergency_service"
 	iserv "gophkeeper/internal/server/services/item_service"
+	oserv "gophkeeper/internal/server/services/org_service"
+	sdserv "gophkeeper/internal/server/services/send_service"
+	sserv "gophkeeper/internal/server/services/share_service"
 	userv "gophkeeper/internal/server/services/user_service"
+	"gophkeeper/internal/telemetry"
 	"os"
+	"syscall"
 )
 
 func main() {
@@ -28,6 +36,12 @@ func runServer() error {
 		return fmt.Errorf("get agent config error: %w\n", err)
 	}
 
+	shutdownTracing, err := telemetry.Init(cnfg.GetTraceFile(), "gophkeeper-server")
+	if err != nil {
+		return fmt.Errorf("init tracing error: %w\n", err)
+	}
+	defer shutdownTracing(context.Background())
+
 	cs, err := cserv.NewCryptoService(cnfg)
 	if err != nil {
 		return fmt.Errorf("failed to create crypto service: %w\n", err)
@@ -37,6 +51,10 @@ func runServer() error {
 		return fmt.Errorf("failed to load RSA keys: %w\n", err)
 	}
 
+	if err := cs.LoadJWTSecret(); err != nil {
+		return fmt.Errorf("failed to load JWT secret: %w\n", err)
+	}
+
 	repo, err := repositories.NewStorage(cnfg)
 	if err != nil {
 		return fmt.Errorf("failed to create storage: %w\n", err)
@@ -52,7 +70,31 @@ func runServer() error {
 		return fmt.Errorf("failed to create item service: %w\n", err)
 	}
 
-	if err := server.CreateAndRun(cnfg, us, cs, ic); err != nil {
+	ss, err := sserv.NewShareService(repo)
+	if err != nil {
+		return fmt.Errorf("failed to create share service: %w\n", err)
+	}
+
+	ors, err := oserv.NewOrgService(repo)
+	if err != nil {
+		return fmt.Errorf("failed to create org service: %w\n", err)
+	}
+
+	es, err := eserv.NewEmergencyService(repo, us)
+	if err != nil {
+		return fmt.Errorf("failed to create emergency service: %w\n", err)
+	}
+
+	sds, err := sdserv.NewSendService(repo)
+	if err != nil {
+		return fmt.Errorf("failed to create send service: %w\n", err)
+	}
+
+	m := lifecycle.NewManager(cnfg.GetShutdownTimeout(), syscall.SIGTERM, syscall.SIGINT, syscall.SIGQUIT)
+	m.Add("storage", repo)
+	m.Add("item reaper", ic.NewReaper(cnfg.GetReaperInterval()))
+
+	if err := server.CreateAndRun(cnfg, m, us, cs, ic, ss, ors, es, sds); err != nil {
 		return fmt.Errorf("create server error: %w\n", err)
 	}
 
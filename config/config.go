@@ -7,6 +7,7 @@ import (
 	"gophkeeper/internal/logger"
 	"os"
 	"path/filepath"
+	"time"
 )
 
 type Config struct {
@@ -15,9 +16,17 @@ type Config struct {
 	serverConfig
 }
 
+type TelemetryConfig interface {
+	GetTraceFile() string
+}
+
 type commonConfig struct {
 	Addr      string
 	SecretKey string
+
+	// TraceFile is where spans are written, "stdout" or a file path.
+	// Tracing is off when it is empty.
+	TraceFile string
 }
 
 func (c *Config) GetConnectionString() string    { return c.DBConnStr }
@@ -25,6 +34,16 @@ func (c *Config) GetPrivateKey() *rsa.PrivateKey { return c.PrivateKey }
 func (c *Config) GetSecretKey() string           { return c.SecretKey }
 func (c *Config) GetPublicKeyPEM() []byte        { return c.PublicKeyPEM }
 func (c *Config) GetAddress() string             { return c.Addr }
+func (c *Config) GetTraceFile() string           { return c.TraceFile }
+func (c *Config) GetReaperInterval() time.Duration {
+	return c.ReaperInterval
+}
+func (c *Config) GetShutdownTimeout() time.Duration {
+	return c.ShutdownTimeout
+}
+func (c *Config) GetSendAddress() string    { return c.SendAddr }
+func (c *Config) GetSendPublicURL() string  { return c.SendPublicURL }
+func (c *Config) GetGatewayAddress() string { return c.GatewayAddr }
 func (c *Config) SetPrivateKey(pk *rsa.PrivateKey) error {
 	if pk == nil {
 		return fmt.Errorf("private key is nil")
@@ -32,6 +51,13 @@ func (c *Config) SetPrivateKey(pk *rsa.PrivateKey) error {
 	c.PrivateKey = pk
 	return nil
 }
+func (c *Config) SetSecretKey(key string) error {
+	if key == "" {
+		return fmt.Errorf("secret key is empty")
+	}
+	c.SecretKey = key
+	return nil
+}
 func (c *Config) SetPublicKeyPEM(pk []byte) error {
 	if pk == nil {
 		return fmt.Errorf("public key is nil")
@@ -3,6 +3,9 @@ package config
 import (
 	"crypto/rsa"
 	"fmt"
+	"os"
+	"path/filepath"
+	"time"
 )
 
 type AgentClientConfig interface {
@@ -16,8 +19,8 @@ type AgentCryptoServiceConfig interface {
 	GetSalt() ([]byte, error)
 	SetMasterKey(key []byte) error
 	GetMasterKey() ([]byte, error)
-	SetMasterPassword(masterPassword string) error
-	GetMasterPassword() (string, error)
+	SetMasterPassword(masterPassword []byte) error
+	GetMasterPassword() ([]byte, error)
 }
 
 func (c *Config) SetPublicKey(key *rsa.PublicKey) error {
@@ -53,14 +56,17 @@ func (c *Config) GetMasterKey() ([]byte, error) {
 	return c.MasterKey, nil
 }
 
-func (c *Config) SetMasterPassword(masterPassword string) error {
+// SetMasterPassword keeps masterPassword, the config owns it from then on.
+// The one it replaces is zeroed, so setting nil wipes it from memory.
+func (c *Config) SetMasterPassword(masterPassword []byte) error {
+	clear(c.MasterPassword)
 	c.MasterPassword = masterPassword
 	return nil
 }
 
-func (c *Config) GetMasterPassword() (string, error) {
-	if c.MasterPassword == "" {
-		return "", fmt.Errorf("master password is empty")
+func (c *Config) GetMasterPassword() ([]byte, error) {
+	if len(c.MasterPassword) == 0 {
+		return nil, fmt.Errorf("master password is empty")
 	}
 	return c.MasterPassword, nil
 }
@@ -68,15 +74,96 @@ func (c *Config) GetMasterPassword() (string, error) {
 type AgentUserServiceConfig interface {
 	SetSalt(salt []byte) error
 	SetMasterKey(key []byte) error
-	SetMasterPassword(masterPassword string) error
+	GetMasterKey() ([]byte, error)
+	SetMasterPassword(masterPassword []byte) error
+}
+type AgentDeviceConfig interface {
+	GetDeviceFile() string
+	GetDeviceName() string
+}
+
+func (c *Config) GetDeviceFile() string { return c.DeviceFile }
+func (c *Config) GetDeviceName() string { return c.DeviceName }
+
+type AgentKnownKeysConfig interface {
+	GetKnownKeysFile() string
+}
+
+func (c *Config) GetKnownKeysFile() string { return c.KnownKeysFile }
+
+type AgentBreachConfig interface {
+	GetBreachData() string
+}
+
+func (c *Config) GetBreachData() string { return c.BreachData }
+
+type AgentSSHConfig interface {
+	GetSSHAgentSocket() string
+}
+
+func (c *Config) GetSSHAgentSocket() string { return c.SSHAgentSocket }
+
+type AgentCacheConfig interface {
+	GetCacheFile() string
 }
+
+func (c *Config) GetCacheFile() string { return c.CacheFile }
+
+type AgentSessionConfig interface {
+	GetSessionFile() string
+	GetSessionKeyfile() string
+}
+
+func (c *Config) GetSessionFile() string    { return c.SessionFile }
+func (c *Config) GetSessionKeyfile() string { return c.SessionKeyfile }
+
+type AgentLockConfig interface {
+	GetIdleTimeout() time.Duration
+}
+
+func (c *Config) GetIdleTimeout() time.Duration { return c.IdleTimeout }
+
 type agentConfig struct {
 	PublicKey      *rsa.PublicKey
-	MasterPassword string
+	MasterPassword []byte
 	MasterKey      []byte
 	Salt           []byte
+
+	// DeviceFile keeps the identity the agent registers with the server
+	// and DeviceName is how the device is shown in the device list.
+	DeviceFile string
+	DeviceName string
+
+	// KnownKeysFile keeps the verified sharing keys of share recipients.
+	// Any key the server returns is used when empty.
+	KnownKeysFile string
+
+	// BreachData is the local Pwned Passwords dataset, a range directory
+	// or a filter file. Breach checks are off when empty.
+	BreachData string
+
+	// SSHAgentSocket is where the built-in ssh-agent listens. The agent
+	// is off when empty.
+	SSHAgentSocket string
+
+	// CacheFile is the encrypted offline copy of the vault. The cache is
+	// off when empty.
+	CacheFile string
+
+	// SessionFile keeps the sign in across restarts, sealed with a PIN or
+	// with SessionKeyfile when set. Saved sessions are off when empty. A
+	// PIN only keeps out casual access, anyone who can read the file can
+	// guess it offline.
+	SessionFile    string
+	SessionKeyfile string
+
+	// IdleTimeout is how long the unlocked vault waits for a key press
+	// before it locks. Zero turns the auto-lock off.
+	IdleTimeout time.Duration
 }
 
+const defaultIdleTimeout = 15 * time.Minute
+
 func NewAgentConfig() (*Config, error) {
 	envPath := getEnvPath()
 	if err := loadEnvFile(envPath); err != nil {
@@ -84,6 +171,18 @@ func NewAgentConfig() (*Config, error) {
 	}
 
 	c := &Config{}
+	c.IdleTimeout = defaultIdleTimeout
+	if dir, err := os.UserConfigDir(); err == nil {
+		c.DeviceFile = filepath.Join(dir, "gophkeeper", "device.json")
+		c.SessionFile = filepath.Join(dir, "gophkeeper", "session.json")
+		c.KnownKeysFile = filepath.Join(dir, "gophkeeper", "known_keys.json")
+	}
+	if dir, err := os.UserCacheDir(); err == nil {
+		c.CacheFile = filepath.Join(dir, "gophkeeper", "cache.db")
+	}
+	if host, err := os.Hostname(); err == nil {
+		c.DeviceName = host
+	}
 
 	c.parseCommonEnvs()
 	c.parseAgentEnvs()
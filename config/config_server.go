@@ -3,11 +3,14 @@ package config
 import (
 	"crypto/rsa"
 	"fmt"
+	"time"
 )
 
 type ServerCryptoConfig interface {
 	SetPrivateKey(*rsa.PrivateKey) error
 	SetPublicKeyPEM([]byte) error
+	SetSecretKey(string) error
+	GetSecretKey() string
 }
 
 type DatabaseConfig interface {
@@ -28,9 +31,29 @@ type ServerInterceptorsConfig interface {
 	GetSecretKey() string
 }
 
+type ServerWorkersConfig interface {
+	GetReaperInterval() time.Duration
+}
+
+type ServerLifecycleConfig interface {
+	GetShutdownTimeout() time.Duration
+}
+
+type ServerSendConfig interface {
+	GetSendAddress() string
+	GetSendPublicURL() string
+}
+
+type ServerGatewayConfig interface {
+	GetGatewayAddress() string
+}
+
 type ServerConfig interface {
 	ServerInterceptorsConfig
 	ServerControllersConfig
+	ServerSendConfig
+	ServerGatewayConfig
+	ServerLifecycleConfig
 
 	GetAddress() string
 }
@@ -39,8 +62,31 @@ type serverConfig struct {
 	DBConnStr    string
 	PrivateKey   *rsa.PrivateKey
 	PublicKeyPEM []byte
+
+	// ReaperInterval is how often expired items are deleted.
+	ReaperInterval time.Duration
+
+	// ShutdownTimeout bounds how long the server drains RPCs, stops
+	// workers and closes storage after a stop signal.
+	ShutdownTimeout time.Duration
+
+	// SendAddr is where one-time secret links are served over HTTP and
+	// SendPublicURL is how agents reach it from outside.
+	SendAddr      string
+	SendPublicURL string
+
+	// GatewayAddr is where the HTTP/JSON API is served.
+	GatewayAddr string
 }
 
+const (
+	defaultReaperInterval  = time.Minute
+	defaultShutdownTimeout = 10 * time.Second
+	defaultSendAddress     = ":8081"
+	defaultSendPublicURL   = "http://localhost:8081"
+	defaultGatewayAddress  = ":8082"
+)
+
 func NewServerConfig() (*Config, error) {
 	envPath := getEnvPath()
 	if err := loadEnvFile(envPath); err != nil {
@@ -48,6 +94,11 @@ func NewServerConfig() (*Config, error) {
 	}
 
 	c := &Config{}
+	c.ReaperInterval = defaultReaperInterval
+	c.ShutdownTimeout = defaultShutdownTimeout
+	c.SendAddr = defaultSendAddress
+	c.SendPublicURL = defaultSendPublicURL
+	c.GatewayAddr = defaultGatewayAddress
 
 	c.parseCommonEnvs()
 	c.parseServerEnvs()
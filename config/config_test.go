@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -134,6 +135,154 @@ func TestNewAgentConfig_WithMockedEnv(t *testing.T) {
 	assert.NotNil(t, config)
 }
 
+func TestNewAgentConfig_Device(t *testing.T) {
+	originalGetEnvPath := getEnvPath
+	getEnvPath = func() string { return "/nonexistent/.env" }
+	defer func() {
+		getEnvPath = originalGetEnvPath
+	}()
+
+	t.Setenv("XDG_CONFIG_HOME", "/tmp/xdg")
+	config, err := NewAgentConfig()
+	require.NoError(t, err)
+	assert.Equal(t, "/tmp/xdg/gophkeeper/device.json", config.GetDeviceFile())
+	host, _ := os.Hostname()
+	assert.Equal(t, host, config.GetDeviceName())
+
+	t.Setenv("DEVICE_FILE", "/etc/gophkeeper/ci.json")
+	t.Setenv("DEVICE_NAME", "ci-runner-1")
+	config, err = NewAgentConfig()
+	require.NoError(t, err)
+	assert.Equal(t, "/etc/gophkeeper/ci.json", config.GetDeviceFile())
+	assert.Equal(t, "ci-runner-1", config.GetDeviceName())
+}
+
+func TestNewAgentConfig_BreachData(t *testing.T) {
+	originalGetEnvPath := getEnvPath
+	getEnvPath = func() string { return "/nonexistent/.env" }
+	defer func() {
+		getEnvPath = originalGetEnvPath
+	}()
+
+	config, err := NewAgentConfig()
+	require.NoError(t, err)
+	assert.Empty(t, config.GetBreachData())
+
+	t.Setenv("HIBP_DATA", "/var/lib/hibp/pwned.filter")
+	config, err = NewAgentConfig()
+	require.NoError(t, err)
+	assert.Equal(t, "/var/lib/hibp/pwned.filter", config.GetBreachData())
+}
+
+func TestNewAgentConfig_SSHAgentSocket(t *testing.T) {
+	originalGetEnvPath := getEnvPath
+	getEnvPath = func() string { return "/nonexistent/.env" }
+	defer func() {
+		getEnvPath = originalGetEnvPath
+	}()
+
+	config, err := NewAgentConfig()
+	require.NoError(t, err)
+	assert.Empty(t, config.GetSSHAgentSocket())
+
+	t.Setenv("SSH_AGENT_SOCKET", "/run/user/1000/gophkeeper.sock")
+	config, err = NewAgentConfig()
+	require.NoError(t, err)
+	assert.Equal(t, "/run/user/1000/gophkeeper.sock", config.GetSSHAgentSocket())
+}
+
+func TestNewAgentConfig_CacheFile(t *testing.T) {
+	originalGetEnvPath := getEnvPath
+	getEnvPath = func() string { return "/nonexistent/.env" }
+	defer func() {
+		getEnvPath = originalGetEnvPath
+	}()
+
+	t.Setenv("XDG_CACHE_HOME", "/home/user/.cache")
+	config, err := NewAgentConfig()
+	require.NoError(t, err)
+	assert.Equal(t, "/home/user/.cache/gophkeeper/cache.db", config.GetCacheFile())
+
+	t.Setenv("OFFLINE_CACHE", "/tmp/vault.db")
+	config, err = NewAgentConfig()
+	require.NoError(t, err)
+	assert.Equal(t, "/tmp/vault.db", config.GetCacheFile())
+
+	t.Setenv("OFFLINE_CACHE", "off")
+	config, err = NewAgentConfig()
+	require.NoError(t, err)
+	assert.Empty(t, config.GetCacheFile())
+}
+
+func TestNewAgentConfig_SessionFile(t *testing.T) {
+	originalGetEnvPath := getEnvPath
+	getEnvPath = func() string { return "/nonexistent/.env" }
+	defer func() {
+		getEnvPath = originalGetEnvPath
+	}()
+
+	t.Setenv("XDG_CONFIG_HOME", "/home/user/.config")
+	config, err := NewAgentConfig()
+	require.NoError(t, err)
+	assert.Equal(t, "/home/user/.config/gophkeeper/session.json", config.GetSessionFile())
+	assert.Empty(t, config.GetSessionKeyfile())
+
+	t.Setenv("SESSION_KEYFILE", "/media/usb/gophkeeper.key")
+	config, err = NewAgentConfig()
+	require.NoError(t, err)
+	assert.Equal(t, "/media/usb/gophkeeper.key", config.GetSessionKeyfile())
+
+	t.Setenv("SESSION_FILE", "off")
+	config, err = NewAgentConfig()
+	require.NoError(t, err)
+	assert.Empty(t, config.GetSessionFile())
+}
+
+func TestNewAgentConfig_KnownKeysFile(t *testing.T) {
+	originalGetEnvPath := getEnvPath
+	getEnvPath = func() string { return "/nonexistent/.env" }
+	defer func() {
+		getEnvPath = originalGetEnvPath
+	}()
+
+	t.Setenv("XDG_CONFIG_HOME", "/home/user/.config")
+	config, err := NewAgentConfig()
+	require.NoError(t, err)
+	assert.Equal(t, "/home/user/.config/gophkeeper/known_keys.json", config.GetKnownKeysFile())
+
+	t.Setenv("KNOWN_KEYS_FILE", "off")
+	config, err = NewAgentConfig()
+	require.NoError(t, err)
+	assert.Empty(t, config.GetKnownKeysFile())
+}
+
+func TestNewAgentConfig_IdleTimeout(t *testing.T) {
+	originalGetEnvPath := getEnvPath
+	getEnvPath = func() string { return "/nonexistent/.env" }
+	defer func() {
+		getEnvPath = originalGetEnvPath
+	}()
+
+	config, err := NewAgentConfig()
+	require.NoError(t, err)
+	assert.Equal(t, defaultIdleTimeout, config.GetIdleTimeout())
+
+	t.Setenv("IDLE_TIMEOUT", "5m")
+	config, err = NewAgentConfig()
+	require.NoError(t, err)
+	assert.Equal(t, 5*time.Minute, config.GetIdleTimeout())
+
+	t.Setenv("IDLE_TIMEOUT", "off")
+	config, err = NewAgentConfig()
+	require.NoError(t, err)
+	assert.Zero(t, config.GetIdleTimeout())
+
+	t.Setenv("IDLE_TIMEOUT", "soon")
+	config, err = NewAgentConfig()
+	require.NoError(t, err)
+	assert.Equal(t, defaultIdleTimeout, config.GetIdleTimeout())
+}
+
 func TestConfig_Methods(t *testing.T) {
 	config := &Config{}
 
@@ -145,11 +294,14 @@ func TestConfig_Methods(t *testing.T) {
 	assert.Equal(t, "secret", config.GetSecretKey())
 
 	// Test agent config methods
-	err := config.SetMasterPassword("master")
+	password := []byte("master")
+	err := config.SetMasterPassword(password)
 	assert.NoError(t, err)
 	masterPass, err := config.GetMasterPassword()
 	assert.NoError(t, err)
-	assert.Equal(t, "master", masterPass)
+	assert.Equal(t, []byte("master"), masterPass)
+	assert.NoError(t, config.SetMasterPassword(nil))
+	assert.Equal(t, make([]byte, 6), password)
 
 	err = config.SetMasterKey([]byte("key"))
 	assert.NoError(t, err)
@@ -189,3 +341,101 @@ func TestConfig_ZeroValues(t *testing.T) {
 	_, err = config.GetSalt()
 	assert.Error(t, err)
 }
+
+func TestNewServerConfig_ReaperInterval(t *testing.T) {
+	originalGetEnvPath := getEnvPath
+	getEnvPath = func() string { return "/nonexistent/.env" }
+	defer func() {
+		getEnvPath = originalGetEnvPath
+	}()
+
+	config, err := NewServerConfig()
+	require.NoError(t, err)
+	assert.Equal(t, time.Minute, config.GetReaperInterval())
+
+	t.Setenv("ITEM_REAPER_INTERVAL", "30s")
+	config, err = NewServerConfig()
+	require.NoError(t, err)
+	assert.Equal(t, 30*time.Second, config.GetReaperInterval())
+
+	t.Setenv("ITEM_REAPER_INTERVAL", "soon")
+	config, err = NewServerConfig()
+	require.NoError(t, err)
+	assert.Equal(t, time.Minute, config.GetReaperInterval())
+}
+
+func TestNewServerConfig_Send(t *testing.T) {
+	originalGetEnvPath := getEnvPath
+	getEnvPath = func() string { return "/nonexistent/.env" }
+	defer func() {
+		getEnvPath = originalGetEnvPath
+	}()
+
+	config, err := NewServerConfig()
+	require.NoError(t, err)
+	assert.Equal(t, ":8081", config.GetSendAddress())
+	assert.Equal(t, "http://localhost:8081", config.GetSendPublicURL())
+
+	t.Setenv("SEND_ADDRESS", "0.0.0.0:9090")
+	t.Setenv("SEND_PUBLIC_URL", "https://keeper.example.com/")
+	config, err = NewServerConfig()
+	require.NoError(t, err)
+	assert.Equal(t, "0.0.0.0:9090", config.GetSendAddress())
+	assert.Equal(t, "https://keeper.example.com", config.GetSendPublicURL())
+}
+
+func TestNewServerConfig_ShutdownTimeout(t *testing.T) {
+	originalGetEnvPath := getEnvPath
+	getEnvPath = func() string { return "/nonexistent/.env" }
+	defer func() {
+		getEnvPath = originalGetEnvPath
+	}()
+
+	config, err := NewServerConfig()
+	require.NoError(t, err)
+	assert.Equal(t, 10*time.Second, config.GetShutdownTimeout())
+
+	t.Setenv("SHUTDOWN_TIMEOUT", "3s")
+	config, err = NewServerConfig()
+	require.NoError(t, err)
+	assert.Equal(t, 3*time.Second, config.GetShutdownTimeout())
+
+	t.Setenv("SHUTDOWN_TIMEOUT", "-1s")
+	config, err = NewServerConfig()
+	require.NoError(t, err)
+	assert.Equal(t, 10*time.Second, config.GetShutdownTimeout())
+}
+
+func TestNewServerConfig_Gateway(t *testing.T) {
+	originalGetEnvPath := getEnvPath
+	getEnvPath = func() string { return "/nonexistent/.env" }
+	defer func() {
+		getEnvPath = originalGetEnvPath
+	}()
+
+	config, err := NewServerConfig()
+	require.NoError(t, err)
+	assert.Equal(t, ":8082", config.GetGatewayAddress())
+
+	t.Setenv("GATEWAY_ADDRESS", "0.0.0.0:9091")
+	config, err = NewServerConfig()
+	require.NoError(t, err)
+	assert.Equal(t, "0.0.0.0:9091", config.GetGatewayAddress())
+}
+
+func TestNewServerConfig_TraceFile(t *testing.T) {
+	originalGetEnvPath := getEnvPath
+	getEnvPath = func() string { return "/nonexistent/.env" }
+	defer func() {
+		getEnvPath = originalGetEnvPath
+	}()
+
+	config, err := NewServerConfig()
+	require.NoError(t, err)
+	assert.Empty(t, config.GetTraceFile())
+
+	t.Setenv("TRACE_FILE", "stdout")
+	config, err = NewServerConfig()
+	require.NoError(t, err)
+	assert.Equal(t, "stdout", config.GetTraceFile())
+}
@@ -3,6 +3,8 @@ package config
 import (
 	"fmt"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -16,15 +18,98 @@ func (c *Config) parseCommonEnvs() {
 	if err == nil {
 		c.Addr = addr
 	}
+	traceFile, err := getEnvString("TRACE_FILE")
+	if err == nil {
+		c.TraceFile = traceFile
+	}
 }
 
-func (c *Config) parseAgentEnvs() {}
+func (c *Config) parseAgentEnvs() {
+	deviceFile, err := getEnvString("DEVICE_FILE")
+	if err == nil {
+		c.DeviceFile = deviceFile
+	}
+	deviceName, err := getEnvString("DEVICE_NAME")
+	if err == nil {
+		c.DeviceName = deviceName
+	}
+	breachData, err := getEnvString("HIBP_DATA")
+	if err == nil {
+		c.BreachData = breachData
+	}
+	sshSocket, err := getEnvString("SSH_AGENT_SOCKET")
+	if err == nil {
+		c.SSHAgentSocket = sshSocket
+	}
+	cacheFile, err := getEnvString("OFFLINE_CACHE")
+	if err == nil {
+		if cacheFile == "off" {
+			cacheFile = ""
+		}
+		c.CacheFile = cacheFile
+	}
+	sessionFile, err := getEnvString("SESSION_FILE")
+	if err == nil {
+		if sessionFile == "off" {
+			sessionFile = ""
+		}
+		c.SessionFile = sessionFile
+	}
+	knownKeysFile, err := getEnvString("KNOWN_KEYS_FILE")
+	if err == nil {
+		if knownKeysFile == "off" {
+			knownKeysFile = ""
+		}
+		c.KnownKeysFile = knownKeysFile
+	}
+	sessionKeyfile, err := getEnvString("SESSION_KEYFILE")
+	if err == nil {
+		c.SessionKeyfile = sessionKeyfile
+	}
+	idle, err := getEnvString("IDLE_TIMEOUT")
+	if err == nil {
+		if idle == "off" {
+			idle = "0s"
+		}
+		if d, err := time.ParseDuration(idle); err == nil && d >= 0 {
+			c.IdleTimeout = d
+		}
+	}
+}
 
 func (c *Config) parseServerEnvs() {
 	db, err := getEnvString("DATABASE_URI")
 	if err == nil {
 		c.DBConnStr = db
 	}
+	secret, err := getEnvString("SECRET_KEY")
+	if err == nil {
+		c.SecretKey = secret
+	}
+	interval, err := getEnvString("ITEM_REAPER_INTERVAL")
+	if err == nil {
+		if d, err := time.ParseDuration(interval); err == nil && d > 0 {
+			c.ReaperInterval = d
+		}
+	}
+	shutdown, err := getEnvString("SHUTDOWN_TIMEOUT")
+	if err == nil {
+		if d, err := time.ParseDuration(shutdown); err == nil && d > 0 {
+			c.ShutdownTimeout = d
+		}
+	}
+	sendAddr, err := getEnvString("SEND_ADDRESS")
+	if err == nil {
+		c.SendAddr = sendAddr
+	}
+	sendURL, err := getEnvString("SEND_PUBLIC_URL")
+	if err == nil {
+		c.SendPublicURL = strings.TrimRight(sendURL, "/")
+	}
+	gatewayAddr, err := getEnvString("GATEWAY_ADDRESS")
+	if err == nil {
+		c.GatewayAddr = gatewayAddr
+	}
 }
 
 func getEnvString(key string) (string, error) {
@@ -0,0 +1,347 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"gophkeeper/models"
+	"io"
+	"text/tabwriter"
+	"time"
+)
+
+// Service is the set of administrative operations used by the CLI.
+type Service interface {
+	ListUsers(ctx context.Context) ([]models.UserStats, error)
+	LockUser(ctx context.Context, login string) error
+	UnlockUser(ctx context.Context, login string) error
+	DeleteUser(ctx context.Context, login string) error
+	RevokeSessions(ctx context.Context, login string) error
+	RevokeAllSessions(ctx context.Context) (int64, error)
+	RotateJWTSecret(ctx context.Context) error
+	RotateRSAKeys() error
+	Migrations(ctx context.Context) ([]models.Migration, error)
+	Migrate(ctx context.Context) ([]string, error)
+	Purge(ctx context.Context) ([]models.PurgeResult, error)
+	Stats(ctx context.Context) (*models.ServerStats, error)
+}
+
+var ErrUsage = errors.New("invalid usage")
+
+type command struct {
+	name  string
+	args  string
+	usage string
+	run   func(c *CLI, ctx context.Context, fs *flag.FlagSet) error
+	flags func(fs *flag.FlagSet)
+}
+
+var commands = []command{
+	{name: "users", usage: "list users with item counts and storage use", run: (*CLI).users},
+	{name: "lock", args: "<login>", usage: "lock an account", run: (*CLI).lock},
+	{name: "unlock", args: "<login>", usage: "unlock an account", run: (*CLI).unlock},
+	{name: "delete", args: "<login>", usage: "delete an account; data is removed by purge", run: (*CLI).delete},
+	{
+		name:  "revoke-sessions",
+		args:  "<login> | -all",
+		usage: "force sign out of a user or everyone",
+		run:   (*CLI).revokeSessions,
+		flags: func(fs *flag.FlagSet) { fs.Bool("all", false, "revoke sessions of every user") },
+	},
+	{name: "rotate-jwt", usage: "generate a new JWT secret and revoke all sessions", run: (*CLI).rotateJWT},
+	{name: "rotate-rsa", usage: "generate a new RSA key pair", run: (*CLI).rotateRSA},
+	{
+		name:  "migrate",
+		args:  "[-status]",
+		usage: "apply pending schema migrations",
+		run:   (*CLI).migrate,
+		flags: func(fs *flag.FlagSet) { fs.Bool("status", false, "only show migration status") },
+	},
+	{name: "purge", usage: "run cleanup jobs", run: (*CLI).purge},
+	{name: "stats", usage: "print server statistics", run: (*CLI).stats},
+}
+
+type CLI struct {
+	svc  Service
+	out  io.Writer
+	json bool
+}
+
+func NewCLI(svc Service, out io.Writer) *CLI {
+	return &CLI{svc: svc, out: out}
+}
+
+// Run parses global flags, then dispatches to the command named by the first argument.
+func (c *CLI) Run(ctx context.Context, args []string) error {
+	global := flag.NewFlagSet("admin", flag.ContinueOnError)
+	global.SetOutput(io.Discard)
+	global.BoolVar(&c.json, "json", false, "print output as JSON")
+	if err := global.Parse(args); err != nil {
+		return fmt.Errorf("%w: %v", ErrUsage, err)
+	}
+
+	rest := global.Args()
+	if len(rest) == 0 {
+		return fmt.Errorf("%w: command is required", ErrUsage)
+	}
+
+	for _, cmd := range commands {
+		if cmd.name != rest[0] {
+			continue
+		}
+		fs := flag.NewFlagSet(cmd.name, flag.ContinueOnError)
+		fs.SetOutput(io.Discard)
+		fs.BoolVar(&c.json, "json", c.json, "print output as JSON")
+		if cmd.flags != nil {
+			cmd.flags(fs)
+		}
+		if err := fs.Parse(rest[1:]); err != nil {
+			return fmt.Errorf("%w: %v", ErrUsage, err)
+		}
+		return cmd.run(c, ctx, fs)
+	}
+	return fmt.Errorf("%w: unknown command %q", ErrUsage, rest[0])
+}
+
+// Usage writes the list of commands.
+func Usage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: admin [-json] <command> [args]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	for _, cmd := range commands {
+		fmt.Fprintf(tw, "  %s %s\t%s\n", cmd.name, cmd.args, cmd.usage)
+	}
+	tw.Flush()
+}
+
+func loginArg(fs *flag.FlagSet) (string, error) {
+	if fs.NArg() != 1 {
+		return "", fmt.Errorf("%w: %s requires exactly one login", ErrUsage, fs.Name())
+	}
+	return fs.Arg(0), nil
+}
+
+func (c *CLI) printJSON(v any) error {
+	enc := json.NewEncoder(c.out)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// done reports a completed action on a single account.
+func (c *CLI) done(action, login string) error {
+	if c.json {
+		return c.printJSON(map[string]string{"login": login, "action": action})
+	}
+	_, err := fmt.Fprintf(c.out, "%s: %s\n", login, action)
+	return err
+}
+
+func (c *CLI) users(ctx context.Context, fs *flag.FlagSet) error {
+	users, err := c.svc.ListUsers(ctx)
+	if err != nil {
+		return err
+	}
+	if c.json {
+		if users == nil {
+			users = []models.UserStats{}
+		}
+		return c.printJSON(users)
+	}
+
+	tw := tabwriter.NewWriter(c.out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "LOGIN\tSTATUS\tITEMS\tSTORAGE\tCREATED")
+	for _, u := range users {
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%s\t%s\n",
+			u.Login, userStatus(u), u.ItemCount, formatBytes(u.StorageBytes), formatTime(u.CreatedAt))
+	}
+	return tw.Flush()
+}
+
+func (c *CLI) lock(ctx context.Context, fs *flag.FlagSet) error {
+	login, err := loginArg(fs)
+	if err != nil {
+		return err
+	}
+	if err := c.svc.LockUser(ctx, login); err != nil {
+		return err
+	}
+	return c.done("locked", login)
+}
+
+func (c *CLI) unlock(ctx context.Context, fs *flag.FlagSet) error {
+	login, err := loginArg(fs)
+	if err != nil {
+		return err
+	}
+	if err := c.svc.UnlockUser(ctx, login); err != nil {
+		return err
+	}
+	return c.done("unlocked", login)
+}
+
+func (c *CLI) delete(ctx context.Context, fs *flag.FlagSet) error {
+	login, err := loginArg(fs)
+	if err != nil {
+		return err
+	}
+	if err := c.svc.DeleteUser(ctx, login); err != nil {
+		return err
+	}
+	return c.done("deleted", login)
+}
+
+func (c *CLI) revokeSessions(ctx context.Context, fs *flag.FlagSet) error {
+	if fs.Lookup("all").Value.String() == "true" {
+		if fs.NArg() != 0 {
+			return fmt.Errorf("%w: -all does not take a login", ErrUsage)
+		}
+		n, err := c.svc.RevokeAllSessions(ctx)
+		if err != nil {
+			return err
+		}
+		if c.json {
+			return c.printJSON(map[string]int64{"revoked_users": n})
+		}
+		_, err = fmt.Fprintf(c.out, "sessions revoked for %d users\n", n)
+		return err
+	}
+
+	login, err := loginArg(fs)
+	if err != nil {
+		return err
+	}
+	if err := c.svc.RevokeSessions(ctx, login); err != nil {
+		return err
+	}
+	return c.done("sessions revoked", login)
+}
+
+func (c *CLI) rotateJWT(ctx context.Context, fs *flag.FlagSet) error {
+	if err := c.svc.RotateJWTSecret(ctx); err != nil {
+		return err
+	}
+	return c.rotated("jwt")
+}
+
+func (c *CLI) rotateRSA(ctx context.Context, fs *flag.FlagSet) error {
+	if err := c.svc.RotateRSAKeys(); err != nil {
+		return err
+	}
+	return c.rotated("rsa")
+}
+
+func (c *CLI) rotated(key string) error {
+	if c.json {
+		return c.printJSON(map[string]any{"rotated": key, "restart_required": true})
+	}
+	_, err := fmt.Fprintf(c.out, "%s key rotated, restart the server to apply it\n", key)
+	return err
+}
+
+func (c *CLI) migrate(ctx context.Context, fs *flag.FlagSet) error {
+	if fs.Lookup("status").Value.String() == "true" {
+		migrations, err := c.svc.Migrations(ctx)
+		if err != nil {
+			return err
+		}
+		if c.json {
+			return c.printJSON(migrations)
+		}
+		tw := tabwriter.NewWriter(c.out, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, "VERSION\tAPPLIED")
+		for _, m := range migrations {
+			applied := "pending"
+			if m.Applied {
+				applied = formatTime(m.AppliedAt)
+			}
+			fmt.Fprintf(tw, "%s\t%s\n", m.Version, applied)
+		}
+		return tw.Flush()
+	}
+
+	applied, err := c.svc.Migrate(ctx)
+	if err != nil {
+		return err
+	}
+	if c.json {
+		if applied == nil {
+			applied = []string{}
+		}
+		return c.printJSON(map[string][]string{"applied": applied})
+	}
+	if len(applied) == 0 {
+		_, err = fmt.Fprintln(c.out, "schema is up to date")
+		return err
+	}
+	for _, v := range applied {
+		fmt.Fprintf(c.out, "applied %s\n", v)
+	}
+	return nil
+}
+
+func (c *CLI) purge(ctx context.Context, fs *flag.FlagSet) error {
+	results, err := c.svc.Purge(ctx)
+	if err != nil {
+		return err
+	}
+	if c.json {
+		return c.printJSON(results)
+	}
+	tw := tabwriter.NewWriter(c.out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "JOB\tREMOVED")
+	for _, r := range results {
+		fmt.Fprintf(tw, "%s\t%d\n", r.Job, r.Removed)
+	}
+	return tw.Flush()
+}
+
+func (c *CLI) stats(ctx context.Context, fs *flag.FlagSet) error {
+	stats, err := c.svc.Stats(ctx)
+	if err != nil {
+		return err
+	}
+	if c.json {
+		return c.printJSON(stats)
+	}
+	tw := tabwriter.NewWriter(c.out, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(tw, "Users:\t%d\n", stats.UsersCount)
+	fmt.Fprintf(tw, "Locked users:\t%d\n", stats.LockedUsersCount)
+	fmt.Fprintf(tw, "Deleted users:\t%d\n", stats.DeletedUsersCount)
+	fmt.Fprintf(tw, "Items:\t%d\n", stats.ItemsCount)
+	fmt.Fprintf(tw, "Storage:\t%s\n", formatBytes(stats.StorageBytes))
+	fmt.Fprintf(tw, "Pending migrations:\t%d\n", stats.PendingMigrations)
+	return tw.Flush()
+}
+
+func userStatus(u models.UserStats) string {
+	switch {
+	case u.Deleted:
+		return "deleted"
+	case u.Locked:
+		return "locked"
+	}
+	return "active"
+}
+
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.Format(time.DateTime)
+}
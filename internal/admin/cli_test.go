@@ -0,0 +1,194 @@
+package admin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"gophkeeper/internal/errs"
+	"gophkeeper/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type MockService struct {
+	calls      []string
+	users      []models.UserStats
+	migrations []models.Migration
+	applied    []string
+	purged     []models.PurgeResult
+	stats      models.ServerStats
+}
+
+func (m *MockService) ListUsers(ctx context.Context) ([]models.UserStats, error) {
+	m.calls = append(m.calls, "users")
+	return m.users, nil
+}
+
+func (m *MockService) LockUser(ctx context.Context, login string) error {
+	m.calls = append(m.calls, "lock "+login)
+	return m.known(login)
+}
+
+func (m *MockService) UnlockUser(ctx context.Context, login string) error {
+	m.calls = append(m.calls, "unlock "+login)
+	return m.known(login)
+}
+
+func (m *MockService) DeleteUser(ctx context.Context, login string) error {
+	m.calls = append(m.calls, "delete "+login)
+	return m.known(login)
+}
+
+func (m *MockService) RevokeSessions(ctx context.Context, login string) error {
+	m.calls = append(m.calls, "revoke "+login)
+	return m.known(login)
+}
+
+func (m *MockService) RevokeAllSessions(ctx context.Context) (int64, error) {
+	m.calls = append(m.calls, "revoke all")
+	return int64(len(m.users)), nil
+}
+
+func (m *MockService) RotateJWTSecret(ctx context.Context) error {
+	m.calls = append(m.calls, "rotate jwt")
+	return nil
+}
+
+func (m *MockService) RotateRSAKeys() error {
+	m.calls = append(m.calls, "rotate rsa")
+	return nil
+}
+
+func (m *MockService) Migrations(ctx context.Context) ([]models.Migration, error) {
+	m.calls = append(m.calls, "migrations")
+	return m.migrations, nil
+}
+
+func (m *MockService) Migrate(ctx context.Context) ([]string, error) {
+	m.calls = append(m.calls, "migrate")
+	return m.applied, nil
+}
+
+func (m *MockService) Purge(ctx context.Context) ([]models.PurgeResult, error) {
+	m.calls = append(m.calls, "purge")
+	return m.purged, nil
+}
+
+func (m *MockService) Stats(ctx context.Context) (*models.ServerStats, error) {
+	m.calls = append(m.calls, "stats")
+	return &m.stats, nil
+}
+
+func (m *MockService) known(login string) error {
+	for _, u := range m.users {
+		if u.Login == login {
+			return nil
+		}
+	}
+	return errs.ErrUserNotFound
+}
+
+func newTestCLI() (*CLI, *MockService, *bytes.Buffer) {
+	svc := &MockService{
+		users: []models.UserStats{
+			{Login: "alice", ItemCount: 3, StorageBytes: 2048, CreatedAt: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)},
+			{Login: "bob", Locked: true},
+		},
+	}
+	out := &bytes.Buffer{}
+	return NewCLI(svc, out), svc, out
+}
+
+func TestCLI_Run_Dispatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		wantCall string
+		wantOut  string
+		wantErr  error
+	}{
+		{name: "users", args: []string{"users"}, wantCall: "users", wantOut: "2.0 KiB"},
+		{name: "lock", args: []string{"lock", "alice"}, wantCall: "lock alice", wantOut: "alice: locked"},
+		{name: "unlock", args: []string{"unlock", "bob"}, wantCall: "unlock bob", wantOut: "bob: unlocked"},
+		{name: "delete", args: []string{"delete", "bob"}, wantCall: "delete bob", wantOut: "bob: deleted"},
+		{name: "revoke one", args: []string{"revoke-sessions", "alice"}, wantCall: "revoke alice"},
+		{name: "revoke all", args: []string{"revoke-sessions", "-all"}, wantCall: "revoke all", wantOut: "2 users"},
+		{name: "rotate jwt", args: []string{"rotate-jwt"}, wantCall: "rotate jwt", wantOut: "restart"},
+		{name: "rotate rsa", args: []string{"rotate-rsa"}, wantCall: "rotate rsa"},
+		{name: "migrate", args: []string{"migrate"}, wantCall: "migrate", wantOut: "up to date"},
+		{name: "migrate status", args: []string{"migrate", "-status"}, wantCall: "migrations"},
+		{name: "purge", args: []string{"purge"}, wantCall: "purge"},
+		{name: "stats", args: []string{"stats"}, wantCall: "stats", wantOut: "Pending migrations"},
+		{name: "no command", args: nil, wantErr: ErrUsage},
+		{name: "unknown command", args: []string{"reboot"}, wantErr: ErrUsage},
+		{name: "missing login", args: []string{"lock"}, wantErr: ErrUsage},
+		{name: "all with login", args: []string{"revoke-sessions", "-all", "alice"}, wantErr: ErrUsage},
+		{name: "unknown user", args: []string{"lock", "carol"}, wantCall: "lock carol", wantErr: errs.ErrUserNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cli, svc, out := newTestCLI()
+			err := cli.Run(context.Background(), tt.args)
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr), "got %v", err)
+			} else {
+				require.NoError(t, err)
+			}
+			if tt.wantCall != "" {
+				assert.Equal(t, []string{tt.wantCall}, svc.calls)
+			} else {
+				assert.Empty(t, svc.calls)
+			}
+			assert.Contains(t, out.String(), tt.wantOut)
+		})
+	}
+}
+
+func TestCLI_Run_JSON(t *testing.T) {
+	t.Run("global flag", func(t *testing.T) {
+		cli, _, out := newTestCLI()
+		require.NoError(t, cli.Run(context.Background(), []string{"-json", "users"}))
+
+		var users []models.UserStats
+		require.NoError(t, json.Unmarshal(out.Bytes(), &users))
+		assert.Len(t, users, 2)
+		assert.Equal(t, "alice", users[0].Login)
+		assert.Equal(t, int64(2048), users[0].StorageBytes)
+	})
+
+	t.Run("command flag", func(t *testing.T) {
+		cli, svc, out := newTestCLI()
+		svc.stats = models.ServerStats{UsersCount: 2, PendingMigrations: 1}
+		require.NoError(t, cli.Run(context.Background(), []string{"stats", "--json"}))
+
+		var stats models.ServerStats
+		require.NoError(t, json.Unmarshal(out.Bytes(), &stats))
+		assert.Equal(t, svc.stats, stats)
+	})
+
+	t.Run("empty migrate", func(t *testing.T) {
+		cli, _, out := newTestCLI()
+		require.NoError(t, cli.Run(context.Background(), []string{"-json", "migrate"}))
+		assert.JSONEq(t, `{"applied": []}`, out.String())
+	})
+}
+
+func TestUsage(t *testing.T) {
+	out := &bytes.Buffer{}
+	Usage(out)
+	for _, cmd := range commands {
+		assert.Contains(t, out.String(), cmd.name)
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	assert.Equal(t, "512 B", formatBytes(512))
+	assert.Equal(t, "1.5 KiB", formatBytes(1536))
+	assert.Equal(t, "2.0 MiB", formatBytes(2*1024*1024))
+}
@@ -0,0 +1,110 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"gophkeeper/internal/hibp"
+	"io"
+	"os"
+)
+
+// breach checks passwords read from stdin against the local breach dataset,
+// or builds a filter file. The passwords are read from stdin so they stay
+// out of the shell history. No sign in is needed.
+func (c *CLI) breach(ctx context.Context, fs *flag.FlagSet, args []string) error {
+	if len(args) > 0 && args[0] == "build" {
+		if len(args) != 3 {
+			return fmt.Errorf("%w: breach build requires a range directory and a filter path", ErrUsage)
+		}
+		return buildBreachFilter(args[1], args[2], c.out)
+	}
+	if len(args) != 0 {
+		return fmt.Errorf("%w: breach takes no arguments", ErrUsage)
+	}
+
+	data := fs.Lookup("data").Value.String()
+	if data == "" {
+		data = c.breachData
+	}
+	if data == "" {
+		return fmt.Errorf("%w: no breach dataset, pass -data or set HIBP_DATA", ErrUsage)
+	}
+	idx, err := hibp.Open(data)
+	if err != nil {
+		return err
+	}
+	defer idx.Close()
+
+	type result struct {
+		Line  int  `json:"line"`
+		Found bool `json:"found"`
+		Count int  `json:"count,omitempty"`
+	}
+	var results []result
+	checked, breached := 0, 0
+	for line := 1; ; line++ {
+		password, ok, err := c.readLine()
+		if err != nil {
+			return err
+		}
+		if !ok {
+			break
+		}
+		if password == "" {
+			continue
+		}
+		checked++
+		found, count, err := idx.Lookup(hibp.Hash(password))
+		if err != nil {
+			return err
+		}
+		if found {
+			breached++
+		}
+		results = append(results, result{Line: line, Found: found, Count: count})
+	}
+
+	if c.json {
+		if err := c.printJSON(results); err != nil {
+			return err
+		}
+	} else {
+		for _, r := range results {
+			switch {
+			case !r.Found:
+				fmt.Fprintf(c.out, "line %d: not found\n", r.Line)
+			case r.Count > 0:
+				fmt.Fprintf(c.out, "line %d: found in breaches %d times\n", r.Line, r.Count)
+			default:
+				fmt.Fprintf(c.out, "line %d: found in breaches\n", r.Line)
+			}
+		}
+	}
+	if breached > 0 {
+		return fmt.Errorf("%d of %d passwords found in breaches", breached, checked)
+	}
+	return nil
+}
+
+func buildBreachFilter(rangeDir, path string, out io.Writer) error {
+	ranges, err := hibp.OpenRanges(rangeDir)
+	if err != nil {
+		return err
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	n, err := hibp.BuildFilter(ranges, f)
+	if err != nil {
+		f.Close()
+		os.Remove(path)
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(out, "filter of %d hashes written to %s\n", n, path)
+	return err
+}
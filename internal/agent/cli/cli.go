@@ -0,0 +1,666 @@
+// Package cli runs the agent commands meant for scripts: they take every
+// input from flags, the environment or stdin and print plain or JSON
+// output instead of starting the UI.
+package cli
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"gophkeeper/internal/agent/services"
+	"gophkeeper/internal/errs"
+	"gophkeeper/models"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Vault is the set of item operations used by the CLI.
+type Vault interface {
+	GetItems(ctx context.Context, login string, typ models.ItemType) ([]models.EncryptedItem, error)
+	FindItem(ctx context.Context, login string, typ models.ItemType, ref string) (*models.Item, error)
+	AddItem(ctx context.Context, item *models.Item) error
+	EditItem(ctx context.Context, item *models.Item) error
+	DeleteItem(ctx context.Context, login string, itemID [16]byte) error
+}
+
+// Opener signs in and unlocks the vault of the account.
+type Opener func(ctx context.Context, login, password, masterPassword string) (Vault, error)
+
+var ErrUsage = errors.New("invalid usage")
+
+// Exit codes of the commands, so scripts can tell failures apart.
+const (
+	ExitOK = iota
+	ExitError
+	ExitUsage
+	ExitAuth
+	ExitNotFound
+	ExitExists
+	ExitLocked
+)
+
+var exitCodes = []struct {
+	err  error
+	code int
+}{
+	{errs.ErrIncorrectCredentials, ExitAuth},
+	{errs.ErrIncorrectMasterPassword, ExitAuth},
+	{errs.ErrUserNotFound, ExitAuth},
+	{errs.ErrSessionRevoked, ExitAuth},
+	{errs.ErrDeviceNotApproved, ExitAuth},
+	{errs.ErrItemNotFound, ExitNotFound},
+	{errs.ErrItemAlreadyExists, ExitExists},
+	{errs.ErrUserLocked, ExitLocked},
+}
+
+// ExitCode returns the exit code for the error of Run. The server sends
+// errors as text, so they are also matched by their message.
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitOK
+	}
+	if errors.Is(err, ErrUsage) {
+		return ExitUsage
+	}
+	for _, e := range exitCodes {
+		if errors.Is(err, e.err) || strings.HasSuffix(err.Error(), e.err.Error()) {
+			return e.code
+		}
+	}
+	return ExitError
+}
+
+type command struct {
+	name  string
+	args  string
+	usage string
+	run   func(c *CLI, ctx context.Context, fs *flag.FlagSet, args []string) error
+	flags func(fs *flag.FlagSet)
+}
+
+var commands = []command{
+	{name: "login", usage: "check the credentials and remember the login", run: (*CLI).login},
+	{name: "logout", usage: "forget the remembered login", run: (*CLI).logout},
+	{
+		name:  "list",
+		args:  "[-type type]",
+		usage: "list items",
+		run:   (*CLI).list,
+		flags: func(fs *flag.FlagSet) { fs.String("type", "", "only list items of this type") },
+	},
+	{
+		name:  "get",
+		args:  "<name|id> [-field name]",
+		usage: "print an item or one of its fields",
+		run:   (*CLI).get,
+		flags: func(fs *flag.FlagSet) { fs.String("field", "", "print only the value of this field") },
+	},
+	{
+		name:  "add",
+		args:  "-type type -name name [field=value...]",
+		usage: "add an item",
+		run:   (*CLI).add,
+		flags: func(fs *flag.FlagSet) {
+			fs.String("type", "", "item type")
+			fs.String("name", "", "item name")
+		},
+	},
+	{
+		name:  "edit",
+		args:  "<name|id> [-name name] [field=value...]",
+		usage: "change the name or fields of an item",
+		run:   (*CLI).edit,
+		flags: func(fs *flag.FlagSet) { fs.String("name", "", "new item name") },
+	},
+	{name: "rm", args: "<name|id>", usage: "delete an item", run: (*CLI).rm},
+	{name: "meta", args: "set <name|id> key=value... | unset <name|id> key...", usage: "change item metadata", run: (*CLI).meta},
+	{name: "totp", args: "<name|id>", usage: "print the current code of a TOTP item", run: (*CLI).totp},
+	{
+		name:  "conn",
+		args:  "<name|id> [-format dsn|url|env | -field name]",
+		usage: "print a connection string, env exports or one field",
+		run:   (*CLI).conn,
+		flags: func(fs *flag.FlagSet) {
+			fs.String("format", "", "dsn, url or env")
+			fs.String("field", "", "print only this field")
+		},
+	},
+	{
+		name:  "breach",
+		args:  "[-data path] | build <range dir> <filter>",
+		usage: "check passwords from stdin against the breach dataset, or build a filter file",
+		run:   (*CLI).breach,
+		flags: func(fs *flag.FlagSet) { fs.String("data", "", "range directory or filter file") },
+	},
+	{name: "receive", args: "<url>", usage: "open a one-time secret link", run: (*CLI).receive},
+}
+
+// IsCommand reports whether name is a CLI command.
+func IsCommand(name string) bool {
+	for _, cmd := range commands {
+		if cmd.name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// State is what the CLI keeps between runs.
+type State struct {
+	Login string `json:"login"`
+}
+
+type CLI struct {
+	open      Opener
+	in        io.Reader
+	out       io.Writer
+	stateFile string
+
+	breachData string
+
+	lines   *bufio.Scanner
+	account string
+	json    bool
+}
+
+// NewCLI creates a CLI that reads passwords and "-" values from in. The
+// login saved by the login command is kept in stateFile.
+func NewCLI(open Opener, in io.Reader, out io.Writer, stateFile string) *CLI {
+	return &CLI{open: open, in: in, out: out, stateFile: stateFile}
+}
+
+// SetBreachData sets the breach dataset the breach command uses without
+// -data.
+func (c *CLI) SetBreachData(path string) {
+	c.breachData = path
+}
+
+// Run dispatches to the command named by the first argument. Flags may
+// follow the positional arguments.
+func (c *CLI) Run(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("%w: command is required", ErrUsage)
+	}
+	for _, cmd := range commands {
+		if cmd.name != args[0] {
+			continue
+		}
+		fs := flag.NewFlagSet(cmd.name, flag.ContinueOnError)
+		fs.SetOutput(io.Discard)
+		fs.BoolVar(&c.json, "json", false, "print output as JSON")
+		fs.StringVar(&c.account, "login", os.Getenv("GOPHKEEPER_LOGIN"), "account login")
+		if cmd.flags != nil {
+			cmd.flags(fs)
+		}
+		rest, err := parseArgs(fs, args[1:])
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrUsage, err)
+		}
+		return cmd.run(c, ctx, fs, rest)
+	}
+	return fmt.Errorf("%w: unknown command %q", ErrUsage, args[0])
+}
+
+// Usage writes the list of commands.
+func Usage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: gophkeeper <command> [-login name] [-json] [args]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	for _, cmd := range commands {
+		fmt.Fprintf(tw, "  %s %s\t%s\n", cmd.name, cmd.args, cmd.usage)
+	}
+	tw.Flush()
+	fmt.Fprint(w, `
+The login is taken from -login, GOPHKEEPER_LOGIN or the login command. The
+account and master passwords are read from GOPHKEEPER_PASSWORD and
+GOPHKEEPER_MASTER_PASSWORD, or from the first two lines of stdin. A field
+value of - is read from the next line of stdin, @path from the file.
+
+The conn format defaults to dsn for databases and url for APIs, env prints
+export lines for eval. Fields are host, port, database, user, password and
+tls, or url and token for APIs. breach reads the passwords to check from
+stdin, one per line, and takes the dataset from -data or HIBP_DATA. receive
+needs no login.
+
+Exit codes: 1 error, 2 usage, 3 authentication, 4 not found, 5 already
+exists, 6 account locked.
+`)
+}
+
+// parseArgs parses flags placed before or after the positional arguments.
+func parseArgs(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		if fs.NArg() == 0 {
+			return positional, nil
+		}
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+}
+
+// readLine returns the next line of stdin.
+func (c *CLI) readLine() (string, bool, error) {
+	if c.lines == nil {
+		c.lines = bufio.NewScanner(c.in)
+	}
+	if c.lines.Scan() {
+		return c.lines.Text(), true, nil
+	}
+	return "", false, c.lines.Err()
+}
+
+// unlock signs in with the credentials from the environment or stdin.
+func (c *CLI) unlock(ctx context.Context) (Vault, error) {
+	if c.account == "" {
+		state, err := c.loadState()
+		if err != nil {
+			return nil, err
+		}
+		c.account = state.Login
+	}
+	if c.account == "" {
+		return nil, errors.New("no login, pass -login, set GOPHKEEPER_LOGIN or run login")
+	}
+
+	password := os.Getenv("GOPHKEEPER_PASSWORD")
+	masterPassword := os.Getenv("GOPHKEEPER_MASTER_PASSWORD")
+	for _, p := range []*string{&password, &masterPassword} {
+		if *p != "" {
+			continue
+		}
+		line, _, err := c.readLine()
+		if err != nil {
+			return nil, err
+		}
+		*p = line
+	}
+	if password == "" || masterPassword == "" {
+		return nil, errors.New("account and master password are required")
+	}
+	return c.open(ctx, c.account, password, masterPassword)
+}
+
+func (c *CLI) loadState() (State, error) {
+	var state State
+	data, err := os.ReadFile(c.stateFile)
+	if errors.Is(err, os.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return state, err
+	}
+	return state, json.Unmarshal(data, &state)
+}
+
+func (c *CLI) saveState(state State) error {
+	if err := os.MkdirAll(filepath.Dir(c.stateFile), 0o700); err != nil {
+		return err
+	}
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(c.stateFile, data, 0o600)
+}
+
+func (c *CLI) printJSON(v any) error {
+	enc := json.NewEncoder(c.out)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// done reports a completed action on an item.
+func (c *CLI) done(action string, item *models.Item) error {
+	if c.json {
+		return c.printJSON(map[string]string{"id": uuid.UUID(item.ID).String(), "name": item.Name, "action": action})
+	}
+	_, err := fmt.Fprintf(c.out, "%s: %s\n", item.Name, action)
+	return err
+}
+
+func (c *CLI) login(ctx context.Context, fs *flag.FlagSet, args []string) error {
+	if len(args) != 0 {
+		return fmt.Errorf("%w: login takes no arguments", ErrUsage)
+	}
+	if _, err := c.unlock(ctx); err != nil {
+		return err
+	}
+	if err := c.saveState(State{Login: c.account}); err != nil {
+		return err
+	}
+	if c.json {
+		return c.printJSON(map[string]string{"login": c.account, "action": "signed in"})
+	}
+	_, err := fmt.Fprintf(c.out, "%s: signed in\n", c.account)
+	return err
+}
+
+func (c *CLI) logout(ctx context.Context, fs *flag.FlagSet, args []string) error {
+	if len(args) != 0 {
+		return fmt.Errorf("%w: logout takes no arguments", ErrUsage)
+	}
+	if err := os.Remove(c.stateFile); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	if c.json {
+		return c.printJSON(map[string]string{"action": "signed out"})
+	}
+	_, err := fmt.Fprintln(c.out, "signed out")
+	return err
+}
+
+// itemJSON is the JSON form of an item.
+type itemJSON struct {
+	ID        string            `json:"id"`
+	Name      string            `json:"name"`
+	Type      models.ItemType   `json:"type"`
+	Meta      map[string]string `json:"meta,omitempty"`
+	Data      map[string]string `json:"data,omitempty"`
+	CreatedAt time.Time         `json:"created_at"`
+	UpdatedAt time.Time         `json:"updated_at"`
+	ExpiresAt *time.Time        `json:"expires_at,omitempty"`
+	Error     string            `json:"error,omitempty"`
+}
+
+func (c *CLI) list(ctx context.Context, fs *flag.FlagSet, args []string) (err error) {
+	if len(args) != 0 {
+		return fmt.Errorf("%w: list takes no arguments", ErrUsage)
+	}
+	typ, err := parseType(fs.Lookup("type").Value.String(), models.ItemTypeUNSPECIFIED)
+	if err != nil {
+		return err
+	}
+	vault, err := c.unlock(ctx)
+	if err != nil {
+		return err
+	}
+	// Items that cannot be decrypted are listed with the error, and the
+	// command still fails once the list is printed.
+	items, err := vault.GetItems(ctx, c.account, typ)
+	failed, err := services.SplitItemErrors(err)
+	if err != nil {
+		return err
+	}
+	if len(failed) > 0 {
+		defer func() {
+			if err == nil {
+				err = failed
+			}
+		}()
+	}
+
+	if c.json {
+		list := make([]itemJSON, len(items))
+		for i, item := range items {
+			list[i] = itemJSON{
+				ID:        uuid.UUID(item.ID).String(),
+				Name:      item.Name,
+				Type:      item.Type,
+				Meta:      item.Meta.Map,
+				CreatedAt: item.CreatedAt,
+				UpdatedAt: item.UpdatedAt,
+			}
+			if err := failed[item.ID]; err != nil {
+				list[i].Error = err.Error()
+			}
+		}
+		return c.printJSON(list)
+	}
+	tw := tabwriter.NewWriter(c.out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tTYPE\tNAME\tUPDATED")
+	for _, item := range items {
+		name := item.Name
+		if _, ok := failed[item.ID]; ok {
+			name += " (cannot decrypt)"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", uuid.UUID(item.ID), item.Type, name, item.UpdatedAt.Format(time.DateTime))
+	}
+	return tw.Flush()
+}
+
+func (c *CLI) get(ctx context.Context, fs *flag.FlagSet, args []string) error {
+	item, _, err := c.findItem(ctx, "get", models.ItemTypeUNSPECIFIED, args)
+	if err != nil {
+		return err
+	}
+	fields := dataFields(item.Data)
+
+	if name := fs.Lookup("field").Value.String(); name != "" {
+		for _, f := range fields {
+			if normalize(f.name) == normalize(name) {
+				_, err := fmt.Fprintln(c.out, f.value)
+				return err
+			}
+		}
+		return fmt.Errorf("%s item has no %s field", item.Type, name)
+	}
+
+	if c.json {
+		out := itemJSON{
+			ID:        uuid.UUID(item.ID).String(),
+			Name:      item.Name,
+			Type:      item.Type,
+			Meta:      item.Meta.Map,
+			Data:      map[string]string{},
+			CreatedAt: item.CreatedAt,
+			UpdatedAt: item.UpdatedAt,
+		}
+		if !item.ExpiresAt.IsZero() {
+			out.ExpiresAt = &item.ExpiresAt
+		}
+		for _, f := range fields {
+			if f.value != "" {
+				out.Data[f.name] = f.printable()
+			}
+		}
+		return c.printJSON(out)
+	}
+
+	tw := tabwriter.NewWriter(c.out, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(tw, "id:\t%s\n", uuid.UUID(item.ID))
+	fmt.Fprintf(tw, "name:\t%s\n", item.Name)
+	fmt.Fprintf(tw, "type:\t%s\n", item.Type)
+	for _, f := range fields {
+		if f.value == "" {
+			continue
+		}
+		fmt.Fprintf(tw, "%s:\t%s\n", f.name, strings.ReplaceAll(f.printable(), "\n", `\n`))
+	}
+	for _, key := range sortedKeys(item.Meta.Map) {
+		fmt.Fprintf(tw, "meta.%s:\t%s\n", key, item.Meta.Map[key])
+	}
+	return tw.Flush()
+}
+
+func (c *CLI) add(ctx context.Context, fs *flag.FlagSet, args []string) error {
+	typ, err := parseType(fs.Lookup("type").Value.String(), "")
+	if err != nil {
+		return err
+	}
+	name := fs.Lookup("name").Value.String()
+	if typ == "" || name == "" {
+		return fmt.Errorf("%w: add requires -type and -name", ErrUsage)
+	}
+	data, err := typ.CreateDataByType()
+	if err != nil {
+		return err
+	}
+
+	// The passwords come first on stdin, then the values read with -.
+	vault, err := c.unlock(ctx)
+	if err != nil {
+		return err
+	}
+	if err := c.setFields(data, args); err != nil {
+		return err
+	}
+	if err := models.ValidateData(data); err != nil {
+		return err
+	}
+	item := &models.Item{UserLogin: c.account, Name: name, Type: typ, Data: data}
+	if err := vault.AddItem(ctx, item); err != nil {
+		return err
+	}
+	return c.done("added", item)
+}
+
+func (c *CLI) edit(ctx context.Context, fs *flag.FlagSet, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("%w: edit requires an item name or id", ErrUsage)
+	}
+	item, vault, err := c.findItem(ctx, "edit", models.ItemTypeUNSPECIFIED, args[:1])
+	if err != nil {
+		return err
+	}
+	if name := fs.Lookup("name").Value.String(); name != "" {
+		item.Name = name
+	}
+	if err := c.setFields(item.Data, args[1:]); err != nil {
+		return err
+	}
+	if err := models.ValidateData(item.Data); err != nil {
+		return err
+	}
+	if err := vault.EditItem(ctx, item); err != nil {
+		return err
+	}
+	return c.done("edited", item)
+}
+
+func (c *CLI) rm(ctx context.Context, fs *flag.FlagSet, args []string) error {
+	item, vault, err := c.findItem(ctx, "rm", models.ItemTypeUNSPECIFIED, args)
+	if err != nil {
+		return err
+	}
+	if err := vault.DeleteItem(ctx, c.account, item.ID); err != nil {
+		return err
+	}
+	return c.done("deleted", item)
+}
+
+func (c *CLI) meta(ctx context.Context, fs *flag.FlagSet, args []string) error {
+	if len(args) < 3 || (args[0] != "set" && args[0] != "unset") {
+		return fmt.Errorf("%w: meta requires set or unset, an item and keys", ErrUsage)
+	}
+	action, ref, keys := args[0], args[1], args[2:]
+
+	values := map[string]string{}
+	if action == "set" {
+		for _, arg := range keys {
+			key, value, ok := strings.Cut(arg, "=")
+			if !ok || key == "" {
+				return fmt.Errorf("%w: %q is not key=value", ErrUsage, arg)
+			}
+			values[key] = value
+		}
+	}
+
+	item, vault, err := c.findItem(ctx, "meta", models.ItemTypeUNSPECIFIED, []string{ref})
+	if err != nil {
+		return err
+	}
+	if item.Meta.Map == nil {
+		item.Meta.Map = map[string]string{}
+	}
+	if action == "set" {
+		for key, value := range values {
+			item.Meta.Map[key] = value
+		}
+	} else {
+		for _, key := range keys {
+			delete(item.Meta.Map, key)
+		}
+	}
+	if err := vault.EditItem(ctx, item); err != nil {
+		return err
+	}
+	return c.done("metadata updated", item)
+}
+
+// findItem unlocks the vault and finds the item of typ named by the only
+// argument.
+func (c *CLI) findItem(ctx context.Context, cmd string, typ models.ItemType, args []string) (*models.Item, Vault, error) {
+	if len(args) != 1 {
+		return nil, nil, fmt.Errorf("%w: %s requires exactly one item name or id", ErrUsage, cmd)
+	}
+	vault, err := c.unlock(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	item, err := vault.FindItem(ctx, c.account, typ, args[0])
+	if err != nil {
+		return nil, nil, err
+	}
+	return item, vault, nil
+}
+
+// setFields applies field=value arguments to data. A value of - is read
+// from stdin and @path from a file, so secrets stay out of the command
+// line.
+func (c *CLI) setFields(data models.Data, args []string) error {
+	for _, arg := range args {
+		name, value, ok := strings.Cut(arg, "=")
+		if !ok || name == "" {
+			return fmt.Errorf("%w: %q is not field=value", ErrUsage, arg)
+		}
+		switch {
+		case value == "-":
+			line, ok, err := c.readLine()
+			if err != nil {
+				return err
+			}
+			if !ok {
+				return fmt.Errorf("no value for %s on stdin", name)
+			}
+			value = line
+		case strings.HasPrefix(value, "@"):
+			content, err := os.ReadFile(value[1:])
+			if err != nil {
+				return err
+			}
+			value = string(content)
+		}
+		if err := setField(data, name, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseType returns the item type named by s, ignoring case, dashes and
+// underscores. An empty s gives def.
+func parseType(s string, def models.ItemType) (models.ItemType, error) {
+	if s == "" {
+		return def, nil
+	}
+	for _, typ := range models.ItemTypes {
+		if normalize(string(typ)) == normalize(s) {
+			return typ, nil
+		}
+	}
+	return "", fmt.Errorf("%w: unknown item type %q", ErrUsage, s)
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
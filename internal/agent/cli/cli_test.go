@@ -0,0 +1,416 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"gophkeeper/internal/agent/client"
+	"gophkeeper/internal/agent/services"
+	"gophkeeper/internal/errs"
+	"gophkeeper/internal/hibp"
+	"gophkeeper/internal/totp"
+	"gophkeeper/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type MockVault struct {
+	calls  []string
+	items  []*models.Item
+	failed services.ItemErrors
+}
+
+func (m *MockVault) GetItems(ctx context.Context, login string, typ models.ItemType) ([]models.EncryptedItem, error) {
+	m.calls = append(m.calls, "list "+login)
+	var items []models.EncryptedItem
+	for _, item := range m.items {
+		if typ == models.ItemTypeUNSPECIFIED || item.Type == typ {
+			items = append(items, models.EncryptedItem{ID: item.ID, Name: item.Name, Type: item.Type, Meta: item.Meta})
+		}
+	}
+	if len(m.failed) > 0 {
+		return items, m.failed
+	}
+	return items, nil
+}
+
+func (m *MockVault) FindItem(ctx context.Context, login string, typ models.ItemType, ref string) (*models.Item, error) {
+	for _, item := range m.items {
+		if typ != models.ItemTypeUNSPECIFIED && item.Type != typ {
+			continue
+		}
+		if strings.EqualFold(item.Name, ref) || uuid.UUID(item.ID).String() == ref {
+			return item, nil
+		}
+	}
+	return nil, fmt.Errorf("%q: %w", ref, errs.ErrItemNotFound)
+}
+
+func (m *MockVault) AddItem(ctx context.Context, item *models.Item) error {
+	m.calls = append(m.calls, "add "+item.Name)
+	m.items = append(m.items, item)
+	return nil
+}
+
+func (m *MockVault) EditItem(ctx context.Context, item *models.Item) error {
+	m.calls = append(m.calls, "edit "+item.Name)
+	return nil
+}
+
+func (m *MockVault) DeleteItem(ctx context.Context, login string, itemID [16]byte) error {
+	m.calls = append(m.calls, "delete "+uuid.UUID(itemID).String())
+	return nil
+}
+
+func newTestCLI(t *testing.T, vault *MockVault, stdin string) (*CLI, *bytes.Buffer) {
+	t.Setenv("GOPHKEEPER_LOGIN", "")
+	t.Setenv("GOPHKEEPER_PASSWORD", "")
+	t.Setenv("GOPHKEEPER_MASTER_PASSWORD", "")
+
+	open := func(ctx context.Context, login, password, masterPassword string) (Vault, error) {
+		if password != "pass" || masterPassword != "master" {
+			return nil, errors.New("server failed to sign in user: " + errs.ErrIncorrectCredentials.Error())
+		}
+		vault.calls = append(vault.calls, "open "+login)
+		return vault, nil
+	}
+	out := &bytes.Buffer{}
+	return NewCLI(open, strings.NewReader(stdin), out, filepath.Join(t.TempDir(), "cli.json")), out
+}
+
+func testItems() []*models.Item {
+	return []*models.Item{
+		{
+			ID:   uuid.MustParse("11111111-1111-1111-1111-111111111111"),
+			Name: "mail",
+			Type: models.ItemTypeCREDENTIALS,
+			Data: &models.Credentials{Login: "jane", Password: "secret"},
+			Meta: models.Meta{Map: map[string]string{"url": "https://mail.example.com"}},
+		},
+		{
+			ID:   uuid.MustParse("22222222-2222-2222-2222-222222222222"),
+			Name: "db",
+			Type: models.ItemTypeCONNECTION,
+			Data: &models.Connection{Kind: models.ConnectionPostgres, Host: "db.example.com", TLSMode: "require"},
+		},
+	}
+}
+
+func TestCLI_List(t *testing.T) {
+	vault := &MockVault{items: testItems()}
+	c, out := newTestCLI(t, vault, "pass\nmaster\n")
+
+	require.NoError(t, c.Run(context.Background(), []string{"list", "-login", "jane", "-type", "connection", "-json"}))
+	var items []itemJSON
+	require.NoError(t, json.Unmarshal(out.Bytes(), &items))
+	require.Len(t, items, 1)
+	assert.Equal(t, "db", items[0].Name)
+	assert.Equal(t, "22222222-2222-2222-2222-222222222222", items[0].ID)
+	assert.Equal(t, []string{"open jane", "list jane"}, vault.calls)
+}
+
+func TestCLI_List_Undecryptable(t *testing.T) {
+	vault := &MockVault{items: testItems()}
+	vault.failed = services.ItemErrors{vault.items[1].ID: errors.New("decrypt item error")}
+	c, out := newTestCLI(t, vault, "pass\nmaster\n")
+
+	err := c.Run(context.Background(), []string{"list", "-login", "jane", "-json"})
+	assert.ErrorContains(t, err, "1 item(s) could not be decrypted")
+	var items []itemJSON
+	require.NoError(t, json.Unmarshal(out.Bytes(), &items))
+	require.Len(t, items, 2)
+	assert.Empty(t, items[0].Error)
+	assert.Equal(t, "decrypt item error", items[1].Error)
+}
+
+func TestCLI_Get(t *testing.T) {
+	vault := &MockVault{items: testItems()}
+	c, out := newTestCLI(t, vault, "")
+	t.Setenv("GOPHKEEPER_LOGIN", "jane")
+	t.Setenv("GOPHKEEPER_PASSWORD", "pass")
+	t.Setenv("GOPHKEEPER_MASTER_PASSWORD", "master")
+
+	require.NoError(t, c.Run(context.Background(), []string{"get", "MAIL", "-field", "password"}))
+	assert.Equal(t, "secret\n", out.String())
+
+	out.Reset()
+	require.NoError(t, c.Run(context.Background(), []string{"get", "db"}))
+	assert.Contains(t, out.String(), "host:")
+	assert.Contains(t, out.String(), "tls_mode:")
+	assert.NotContains(t, out.String(), "password:")
+
+	out.Reset()
+	require.NoError(t, c.Run(context.Background(), []string{"get", "-json", "11111111-1111-1111-1111-111111111111"}))
+	var item itemJSON
+	require.NoError(t, json.Unmarshal(out.Bytes(), &item))
+	assert.Equal(t, map[string]string{"login": "jane", "password": "secret"}, item.Data)
+	assert.Equal(t, "https://mail.example.com", item.Meta["url"])
+
+	err := c.Run(context.Background(), []string{"get", "mail", "-field", "pin"})
+	assert.ErrorContains(t, err, "no pin field")
+
+	err = c.Run(context.Background(), []string{"get", "bank"})
+	assert.Equal(t, ExitNotFound, ExitCode(err))
+}
+
+func TestCLI_Add(t *testing.T) {
+	vault := &MockVault{}
+	c, out := newTestCLI(t, vault, "pass\nmaster\ns3cret\n")
+
+	keyFile := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(keyFile, []byte("tok"), 0o600))
+
+	err := c.Run(context.Background(), []string{
+		"add", "-login", "jane", "-type", "credentials", "-name", "mail", "login=jane", "password=-",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "mail: added\n", out.String())
+	require.Len(t, vault.items, 1)
+	assert.Equal(t, "jane", vault.items[0].UserLogin)
+	assert.Equal(t, &models.Credentials{Login: "jane", Password: "s3cret"}, vault.items[0].Data)
+
+	c, _ = newTestCLI(t, vault, "pass\nmaster\n")
+	err = c.Run(context.Background(), []string{
+		"add", "-login", "jane", "-type", "connection", "-name", "api", "kind=http", "base-url=https://api.example.com", "token=@" + keyFile,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, &models.Connection{Kind: models.ConnectionHTTP, BaseURL: "https://api.example.com", Token: "tok"}, vault.items[1].Data)
+
+	// Data that does not validate is not added.
+	c, _ = newTestCLI(t, vault, "pass\nmaster\n")
+	err = c.Run(context.Background(), []string{"add", "-login", "jane", "-type", "connection", "-name", "x", "kind=postgres"})
+	assert.ErrorContains(t, err, "Host is required")
+	assert.Len(t, vault.items, 2)
+
+	c, _ = newTestCLI(t, vault, "pass\nmaster\n")
+	err = c.Run(context.Background(), []string{"add", "-login", "jane", "-type", "card", "-name", "visa", "pin=1234"})
+	assert.ErrorContains(t, err, "no pin field")
+
+	err = c.Run(context.Background(), []string{"add", "-login", "jane", "-type", "bogus", "-name", "x"})
+	assert.Equal(t, ExitUsage, ExitCode(err))
+}
+
+func TestCLI_EditAndRemove(t *testing.T) {
+	vault := &MockVault{items: testItems()}
+	c, out := newTestCLI(t, vault, "pass\nmaster\n")
+
+	err := c.Run(context.Background(), []string{"edit", "-login", "jane", "db", "-name", "orders", "port=5433"})
+	require.NoError(t, err)
+	assert.Equal(t, "orders: edited\n", out.String())
+	assert.Equal(t, 5433, vault.items[1].Data.(*models.Connection).Port)
+
+	c, _ = newTestCLI(t, vault, "pass\nmaster\n")
+	err = c.Run(context.Background(), []string{"edit", "-login", "jane", "orders", "port=http"})
+	assert.ErrorContains(t, err, "whole number")
+
+	c, out = newTestCLI(t, vault, "pass\nmaster\n")
+	require.NoError(t, c.Run(context.Background(), []string{"rm", "-login", "jane", "-json", "mail"}))
+	assert.Contains(t, out.String(), `"action": "deleted"`)
+	assert.Contains(t, vault.calls, "delete 11111111-1111-1111-1111-111111111111")
+}
+
+func TestCLI_Meta(t *testing.T) {
+	vault := &MockVault{items: testItems()}
+	c, _ := newTestCLI(t, vault, "pass\nmaster\n")
+
+	require.NoError(t, c.Run(context.Background(), []string{"meta", "-login", "jane", "set", "db", "env=prod", "team=core"}))
+	assert.Equal(t, map[string]string{"env": "prod", "team": "core"}, vault.items[1].Meta.Map)
+
+	c, _ = newTestCLI(t, vault, "pass\nmaster\n")
+	require.NoError(t, c.Run(context.Background(), []string{"meta", "-login", "jane", "unset", "db", "team"}))
+	assert.Equal(t, map[string]string{"env": "prod"}, vault.items[1].Meta.Map)
+
+	err := c.Run(context.Background(), []string{"meta", "set", "db", "env"})
+	assert.ErrorIs(t, err, ErrUsage)
+	err = c.Run(context.Background(), []string{"meta", "drop", "db", "env"})
+	assert.ErrorIs(t, err, ErrUsage)
+}
+
+func TestCLI_LoginLogout(t *testing.T) {
+	vault := &MockVault{items: testItems()}
+	c, out := newTestCLI(t, vault, "pass\nmaster\n")
+
+	require.NoError(t, c.Run(context.Background(), []string{"login", "-login", "jane"}))
+	assert.Equal(t, "jane: signed in\n", out.String())
+
+	// The remembered login is used without -login.
+	c = NewCLI(c.open, strings.NewReader("pass\nmaster\n"), out, c.stateFile)
+	require.NoError(t, c.Run(context.Background(), []string{"list"}))
+	assert.Equal(t, "list jane", vault.calls[len(vault.calls)-1])
+
+	require.NoError(t, c.Run(context.Background(), []string{"logout"}))
+	_, err := os.Stat(c.stateFile)
+	assert.True(t, os.IsNotExist(err))
+
+	c = NewCLI(c.open, strings.NewReader("pass\nmaster\n"), out, c.stateFile)
+	assert.ErrorContains(t, c.Run(context.Background(), []string{"list"}), "no login")
+
+	c = NewCLI(c.open, strings.NewReader("pass\nwrong\n"), out, c.stateFile)
+	err = c.Run(context.Background(), []string{"login", "-login", "jane"})
+	assert.Equal(t, ExitAuth, ExitCode(err))
+}
+
+func TestCLI_TOTP(t *testing.T) {
+	item := &models.Item{
+		ID:   uuid.MustParse("33333333-3333-3333-3333-333333333333"),
+		Name: "github",
+		Type: models.ItemTypeTOTP,
+		Data: &models.TOTP{Secret: "JBSWY3DPEHPK3PXP"},
+	}
+	vault := &MockVault{items: append(testItems(), item)}
+	c, out := newTestCLI(t, vault, "pass\nmaster\n")
+	require.NoError(t, c.Run(context.Background(), []string{"login", "-login", "jane"}))
+
+	// The remembered login is used without -login.
+	out.Reset()
+	c = NewCLI(c.open, strings.NewReader("pass\nmaster\n"), out, c.stateFile)
+	before, err := totp.Code(item.Data.(*models.TOTP), time.Now())
+	require.NoError(t, err)
+	require.NoError(t, c.Run(context.Background(), []string{"totp", "github"}))
+	after, err := totp.Code(item.Data.(*models.TOTP), time.Now())
+	require.NoError(t, err)
+	assert.Contains(t, []string{before + "\n", after + "\n"}, out.String())
+
+	c = NewCLI(c.open, strings.NewReader("pass\nmaster\n"), out, c.stateFile)
+	err = c.Run(context.Background(), []string{"totp", "mail"})
+	assert.Equal(t, ExitNotFound, ExitCode(err))
+}
+
+func TestCLI_Conn(t *testing.T) {
+	vault := &MockVault{items: testItems()}
+	vault.items[1].Data.(*models.Connection).Password = "it's"
+
+	c, out := newTestCLI(t, vault, "pass\nmaster\n")
+	require.NoError(t, c.Run(context.Background(), []string{"conn", "-login", "jane", "db", "-field", "host"}))
+	assert.Equal(t, "db.example.com\n", out.String())
+
+	c, out = newTestCLI(t, vault, "pass\nmaster\n")
+	require.NoError(t, c.Run(context.Background(), []string{"conn", "-login", "jane", "db", "-format", "env"}))
+	assert.Contains(t, out.String(), `export PGPASSWORD='it'\''s'`)
+
+	c, _ = newTestCLI(t, vault, "pass\nmaster\n")
+	err := c.Run(context.Background(), []string{"conn", "-login", "jane", "db", "-format", "dsn", "-field", "host"})
+	assert.ErrorIs(t, err, ErrUsage)
+
+	c, _ = newTestCLI(t, vault, "pass\nmaster\n")
+	err = c.Run(context.Background(), []string{"conn", "-login", "jane", "mail"})
+	assert.Equal(t, ExitNotFound, ExitCode(err))
+}
+
+func TestCLI_Breach(t *testing.T) {
+	dir := t.TempDir()
+	hash := hibp.Hash("password")
+	full := strings.ToUpper(hex.EncodeToString(hash[:]))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, full[:5]), []byte(full[5:]+":42\r\n"), 0o600))
+
+	c, out := newTestCLI(t, &MockVault{}, "password\n\ncorrect horse battery staple\n")
+	c.SetBreachData(dir)
+	err := c.Run(context.Background(), []string{"breach"})
+	assert.EqualError(t, err, "1 of 2 passwords found in breaches")
+	assert.Equal(t, "line 1: found in breaches 42 times\nline 3: not found\n", out.String())
+
+	c, _ = newTestCLI(t, &MockVault{}, "")
+	assert.ErrorIs(t, c.Run(context.Background(), []string{"breach"}), ErrUsage)
+}
+
+// sendStore keeps the uploaded send and serves it once like the server
+// send handler.
+type sendStore struct {
+	client.Client
+	baseURL string
+	send    *models.Send
+}
+
+func (s *sendStore) CreateSend(ctx context.Context, send *models.Send) (string, error) {
+	s.send = send
+	return s.baseURL + "/send/abcd", nil
+}
+
+func (s *sendStore) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if s.send == nil {
+		http.NotFound(w, r)
+		return
+	}
+	json.NewEncoder(w).Encode(map[string]any{
+		"encrypted_content": s.send.EncryptedData.EncryptedContent,
+		"nonce":             s.send.EncryptedData.Nonce,
+	})
+	s.send = nil
+}
+
+func TestCLI_Receive(t *testing.T) {
+	store := &sendStore{}
+	srv := httptest.NewServer(store)
+	defer srv.Close()
+	store.baseURL = srv.URL
+
+	is, err := services.NewItemService(store, nil)
+	require.NoError(t, err)
+	link, err := is.CreateSend(context.Background(), services.SendPayload{Name: "wifi", Content: "hunter2"}, 1, time.Hour)
+	require.NoError(t, err)
+
+	c, out := newTestCLI(t, &MockVault{}, "")
+	require.NoError(t, c.Run(context.Background(), []string{"receive", link}))
+	assert.Equal(t, "wifi\n\nhunter2\n", out.String())
+
+	c, _ = newTestCLI(t, &MockVault{}, "")
+	assert.Error(t, c.Run(context.Background(), []string{"receive", link}))
+	assert.ErrorIs(t, c.Run(context.Background(), []string{"receive"}), ErrUsage)
+}
+
+func TestCLI_Usage(t *testing.T) {
+	c, _ := newTestCLI(t, &MockVault{}, "")
+	for _, args := range [][]string{nil, {"sync"}, {"get"}, {"list", "-bogus"}, {"rm", "a", "b"}} {
+		assert.ErrorIs(t, c.Run(context.Background(), args), ErrUsage, args)
+	}
+
+	out := &bytes.Buffer{}
+	Usage(out)
+	assert.Contains(t, out.String(), "meta set <name|id>")
+	assert.True(t, IsCommand("list"))
+	assert.True(t, IsCommand("totp"))
+	assert.True(t, IsCommand("receive"))
+}
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		err  error
+		want int
+	}{
+		{nil, ExitOK},
+		{errors.New("boom"), ExitError},
+		{fmt.Errorf("%w: unknown command", ErrUsage), ExitUsage},
+		{errs.ErrIncorrectMasterPassword, ExitAuth},
+		{errors.New("rpc error: code = Unauthenticated desc = session has been revoked"), ExitAuth},
+		{fmt.Errorf("%q: %w", "mail", errs.ErrItemNotFound), ExitNotFound},
+		{errors.New("rpc error: code = AlreadyExists desc = item already exists"), ExitExists},
+		{errors.New(errs.ErrUserLocked.Error()), ExitLocked},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, ExitCode(tt.err), tt.err)
+	}
+}
+
+func TestSnakeCase(t *testing.T) {
+	for in, want := range map[string]string{
+		"Password":       "password",
+		"TLSMode":        "tls_mode",
+		"BaseURL":        "base_url",
+		"CardholderName": "cardholder_name",
+	} {
+		assert.Equal(t, want, snakeCase(in))
+	}
+}
@@ -0,0 +1,57 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"gophkeeper/models"
+	"strings"
+)
+
+// conn prints a CONNECTION item as a connection string, environment
+// variables or a single field, so scripts do not assemble them by hand.
+func (c *CLI) conn(ctx context.Context, fs *flag.FlagSet, args []string) error {
+	format := fs.Lookup("format").Value.String()
+	field := fs.Lookup("field").Value.String()
+	if format != "" && field != "" {
+		return fmt.Errorf("%w: conn takes -format or -field, not both", ErrUsage)
+	}
+	item, _, err := c.findItem(ctx, "conn", models.ItemTypeCONNECTION, args)
+	if err != nil {
+		return err
+	}
+	conn := item.Data.(*models.Connection)
+
+	if field != "" {
+		value, err := conn.Field(field)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(c.out, value)
+		return err
+	}
+
+	switch format {
+	case "":
+		format = models.FormatDSN
+		if conn.Kind == models.ConnectionHTTP {
+			format = models.FormatURL
+		}
+	case models.FormatEnv:
+		for _, kv := range conn.Env() {
+			name, value, _ := strings.Cut(kv, "=")
+			fmt.Fprintf(c.out, "export %s=%s\n", name, shellQuote(value))
+		}
+		return nil
+	}
+	rendered, err := conn.Render(format)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(c.out, rendered)
+	return err
+}
+
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
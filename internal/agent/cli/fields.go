@@ -0,0 +1,127 @@
+package cli
+
+import (
+	"encoding/base64"
+	"fmt"
+	"gophkeeper/models"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// field is a value of item data addressed by name on the command line.
+type field struct {
+	name   string
+	value  string
+	binary bool
+}
+
+// printable returns the value, with binary content in base64.
+func (f field) printable() string {
+	if f.binary {
+		return base64.StdEncoding.EncodeToString([]byte(f.value))
+	}
+	return f.value
+}
+
+// dataFields lists the values of data. Fields of CUSTOM items come from
+// their template, other data lists its text, number and byte fields under
+// snake_case names.
+func dataFields(data models.Data) []field {
+	var fields []field
+	if custom, ok := data.(*models.Custom); ok {
+		for _, f := range custom.Fields {
+			fields = append(fields, field{name: f.Name, value: f.Value})
+		}
+		return fields
+	}
+
+	v := reflect.ValueOf(data).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		f := field{name: snakeCase(v.Type().Field(i).Name)}
+		value := v.Field(i)
+		switch {
+		case value.Kind() == reflect.String:
+			f.value = value.String()
+		case value.CanInt():
+			if value.Int() != 0 {
+				f.value = strconv.FormatInt(value.Int(), 10)
+			}
+		case value.Kind() == reflect.Slice && value.Type().Elem().Kind() == reflect.Uint8:
+			f.value, f.binary = string(value.Bytes()), true
+		default:
+			continue
+		}
+		fields = append(fields, f)
+	}
+	return fields
+}
+
+// setField sets the named field of data. CUSTOM items get a text field
+// when the template has no field of that name.
+func setField(data models.Data, name, value string) error {
+	if custom, ok := data.(*models.Custom); ok {
+		for i := range custom.Fields {
+			if normalize(custom.Fields[i].Name) == normalize(name) {
+				custom.Fields[i].Value = value
+				return nil
+			}
+		}
+		custom.Fields = append(custom.Fields, models.CustomField{Name: name, Type: models.FieldText, Value: value})
+		return nil
+	}
+
+	v := reflect.ValueOf(data).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		if normalize(v.Type().Field(i).Name) != normalize(name) {
+			continue
+		}
+		f := v.Field(i)
+		switch {
+		case f.Kind() == reflect.String:
+			f.SetString(value)
+		case f.CanInt():
+			n := 0
+			if value != "" {
+				var err error
+				if n, err = strconv.Atoi(value); err != nil {
+					return fmt.Errorf("%s must be a whole number", name)
+				}
+			}
+			f.SetInt(int64(n))
+		case f.Kind() == reflect.Slice && f.Type().Elem().Kind() == reflect.Uint8:
+			f.SetBytes([]byte(value))
+		default:
+			return fmt.Errorf("%s field %s cannot be set", data.GetType(), name)
+		}
+		return nil
+	}
+	return fmt.Errorf("%s item has no %s field", data.GetType(), name)
+}
+
+// normalize makes field and type names match regardless of case, dashes,
+// underscores and spaces.
+func normalize(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '-', '_', ' ':
+			return -1
+		}
+		return unicode.ToLower(r)
+	}, s)
+}
+
+// snakeCase turns a Go field name like TLSMode into tls_mode.
+func snakeCase(s string) string {
+	runes := []rune(s)
+	var b strings.Builder
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) &&
+			(unicode.IsLower(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+			b.WriteByte('_')
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
@@ -0,0 +1,31 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"gophkeeper/internal/agent/services"
+	"net/http"
+)
+
+// receive opens a one-time secret link. It needs neither an account nor a
+// server connection besides the link itself.
+func (c *CLI) receive(ctx context.Context, fs *flag.FlagSet, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("%w: receive requires a link", ErrUsage)
+	}
+	payload, err := services.ReceiveSend(ctx, http.DefaultClient, args[0])
+	if err != nil {
+		return err
+	}
+	if c.json {
+		return c.printJSON(payload)
+	}
+	if payload.Name != "" {
+		if _, err := fmt.Fprintf(c.out, "%s\n\n", payload.Name); err != nil {
+			return err
+		}
+	}
+	_, err = fmt.Fprintln(c.out, payload.Content)
+	return err
+}
@@ -0,0 +1,27 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"gophkeeper/internal/totp"
+	"gophkeeper/models"
+	"time"
+)
+
+// totp prints the current code of a TOTP item.
+func (c *CLI) totp(ctx context.Context, fs *flag.FlagSet, args []string) error {
+	item, _, err := c.findItem(ctx, "totp", models.ItemTypeTOTP, args)
+	if err != nil {
+		return err
+	}
+	code, err := totp.Code(item.Data.(*models.TOTP), time.Now())
+	if err != nil {
+		return err
+	}
+	if c.json {
+		return c.printJSON(map[string]string{"name": item.Name, "code": code})
+	}
+	_, err = fmt.Fprintln(c.out, code)
+	return err
+}
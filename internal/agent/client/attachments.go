@@ -0,0 +1,62 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"gophkeeper/models"
+
+	pbit "gophkeeper/internal/protos/items"
+)
+
+func (g *GRPCClient) UploadAttachment(ctx context.Context, a *models.Attachment) ([16]byte, error) {
+	resp, err := g.Item.UploadAttachment(ctx, &pbit.UploadAttachmentRequest{
+		Attachment: a.ToPb(),
+		Content:    a.Content,
+		Nonce:      a.Nonce,
+	})
+	if err != nil {
+		return [16]byte{}, fmt.Errorf("upload attachment server error: %w", err)
+	}
+	return models.ItemIdPbToModels(resp.Id), nil
+}
+
+func (g *GRPCClient) ListAttachments(ctx context.Context, itemID [16]byte) ([]models.Attachment, error) {
+	resp, err := g.Item.ListAttachments(ctx, &pbit.ListAttachmentsRequest{ItemId: itemID[:]})
+	if err != nil {
+		return nil, fmt.Errorf("list attachments server error: %w", err)
+	}
+
+	attachments := make([]models.Attachment, len(resp.Attachments))
+	for i, a := range resp.Attachments {
+		attachments[i] = *models.AttachmentPbToModels(a)
+	}
+	return attachments, nil
+}
+
+func (g *GRPCClient) DownloadAttachment(ctx context.Context, itemID, attachmentID [16]byte) (*models.Attachment, error) {
+	resp, err := g.Item.DownloadAttachment(ctx, &pbit.DownloadAttachmentRequest{
+		ItemId:       itemID[:],
+		AttachmentId: attachmentID[:],
+	})
+	if err != nil {
+		return nil, fmt.Errorf("download attachment server error: %w", err)
+	}
+	if resp.Attachment == nil {
+		return nil, fmt.Errorf("download attachment server error: empty response")
+	}
+
+	a := models.AttachmentPbToModels(resp.Attachment)
+	a.Content, a.Nonce = resp.Content, resp.Nonce
+	return a, nil
+}
+
+func (g *GRPCClient) DeleteAttachment(ctx context.Context, itemID, attachmentID [16]byte) error {
+	resp, err := g.Item.DeleteAttachment(ctx, &pbit.DeleteAttachmentRequest{
+		ItemId:       itemID[:],
+		AttachmentId: attachmentID[:],
+	})
+	if err != nil || !resp.Success {
+		return fmt.Errorf("delete attachment server error: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"gophkeeper/models"
+
+	pb "gophkeeper/internal/protos/users"
+)
+
+func (g *GRPCClient) ListDevices(ctx context.Context) (devices []models.Device, approvalRequired bool, err error) {
+	resp, err := g.Device.ListDevices(ctx, &pb.ListDevicesRequest{})
+	if err != nil {
+		return nil, false, fmt.Errorf("list devices server error: %w", err)
+	}
+
+	devices = make([]models.Device, len(resp.Devices))
+	for i, d := range resp.Devices {
+		devices[i] = *models.DevicePbToModels(d)
+	}
+	return devices, resp.ApprovalRequired, nil
+}
+
+func (g *GRPCClient) RemoveDevice(ctx context.Context, id [16]byte) error {
+	resp, err := g.Device.RemoveDevice(ctx, &pb.RemoveDeviceRequest{DeviceId: id[:]})
+	if err != nil || !resp.Success {
+		return fmt.Errorf("remove device server error: %w", err)
+	}
+	return nil
+}
+
+func (g *GRPCClient) ApproveDevice(ctx context.Context, id [16]byte) error {
+	resp, err := g.Device.ApproveDevice(ctx, &pb.ApproveDeviceRequest{DeviceId: id[:]})
+	if err != nil || !resp.Success {
+		return fmt.Errorf("approve device server error: %w", err)
+	}
+	return nil
+}
+
+func (g *GRPCClient) SetDeviceApproval(ctx context.Context, required bool) error {
+	resp, err := g.Device.SetDeviceApproval(ctx, &pb.SetDeviceApprovalRequest{Required: required})
+	if err != nil || !resp.Success {
+		return fmt.Errorf("set device approval server error: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"gophkeeper/models"
+
+	pbit "gophkeeper/internal/protos/items"
+)
+
+func (g *GRPCClient) GrantEmergencyAccess(ctx context.Context, access *models.EmergencyAccess) error {
+	resp, err := g.Emergency.GrantEmergencyAccess(ctx, &pbit.GrantEmergencyAccessRequest{Access: access.ToPb()})
+	if err != nil || !resp.Success {
+		return fmt.Errorf("grant emergency access server error: %w", err)
+	}
+	return nil
+}
+
+func (g *GRPCClient) RevokeEmergencyAccess(ctx context.Context, grantee string) error {
+	resp, err := g.Emergency.RevokeEmergencyAccess(ctx, &pbit.RevokeEmergencyAccessRequest{Grantee: grantee})
+	if err != nil || !resp.Success {
+		return fmt.Errorf("revoke emergency access server error: %w", err)
+	}
+	return nil
+}
+
+func (g *GRPCClient) ListEmergencyAccess(ctx context.Context) (granted, trustedBy []models.EmergencyAccess, err error) {
+	resp, err := g.Emergency.ListEmergencyAccess(ctx, &pbit.ListEmergencyAccessRequest{})
+	if err != nil {
+		return nil, nil, fmt.Errorf("list emergency access server error: %w", err)
+	}
+
+	granted = make([]models.EmergencyAccess, len(resp.Granted))
+	for i, a := range resp.Granted {
+		granted[i] = *models.EmergencyAccessPbToModels(a)
+	}
+	trustedBy = make([]models.EmergencyAccess, len(resp.TrustedBy))
+	for i, a := range resp.TrustedBy {
+		trustedBy[i] = *models.EmergencyAccessPbToModels(a)
+	}
+	return granted, trustedBy, nil
+}
+
+func (g *GRPCClient) RequestEmergencyAccess(ctx context.Context, grantor string) error {
+	resp, err := g.Emergency.RequestEmergencyAccess(ctx, &pbit.RequestEmergencyAccessRequest{Grantor: grantor})
+	if err != nil || !resp.Success {
+		return fmt.Errorf("request emergency access server error: %w", err)
+	}
+	return nil
+}
+
+func (g *GRPCClient) ApproveEmergencyAccess(ctx context.Context, grantee string) error {
+	resp, err := g.Emergency.ApproveEmergencyAccess(ctx, &pbit.ApproveEmergencyAccessRequest{Grantee: grantee})
+	if err != nil || !resp.Success {
+		return fmt.Errorf("approve emergency access server error: %w", err)
+	}
+	return nil
+}
+
+func (g *GRPCClient) DenyEmergencyAccess(ctx context.Context, grantee string) error {
+	resp, err := g.Emergency.DenyEmergencyAccess(ctx, &pbit.DenyEmergencyAccessRequest{Grantee: grantee})
+	if err != nil || !resp.Success {
+		return fmt.Errorf("deny emergency access server error: %w", err)
+	}
+	return nil
+}
+
+func (g *GRPCClient) GetEmergencyVault(ctx context.Context, grantor string) (*models.EmergencyVault, error) {
+	resp, err := g.Emergency.GetEmergencyVault(ctx, &pbit.GetEmergencyVaultRequest{Grantor: grantor})
+	if err != nil {
+		return nil, fmt.Errorf("get emergency vault server error: %w", err)
+	}
+
+	vault := &models.EmergencyVault{
+		Access:    *models.EmergencyAccessPbToModels(resp.Access),
+		Salt:      resp.Salt,
+		Keys:      models.UserKeysPbToModels(resp.Keys),
+		Items:     make([]models.EncryptedItem, len(resp.Items)),
+		Templates: make([]models.Template, len(resp.Templates)),
+	}
+	for i, item := range resp.Items {
+		vault.Items[i] = *models.EncryptedItemPbToModels(item)
+	}
+	for i, template := range resp.Templates {
+		vault.Templates[i] = *models.TemplatePbToModels(template)
+	}
+	return vault, nil
+}
+
+func (g *GRPCClient) TakeoverAccount(ctx context.Context, t *models.EmergencyTakeover) error {
+	req := &pbit.TakeoverAccountRequest{
+		Grantor:             t.Grantor,
+		Password:            t.Password,
+		EncryptedPrivateKey: t.EncryptedPrivateKey,
+		Items:               make([]*pbit.EncryptedItem, len(t.Items)),
+		Templates:           make([]*pbit.ItemTemplate, len(t.Templates)),
+	}
+	for i := range t.Items {
+		pbItem, err := t.Items[i].ToPb()
+		if err != nil {
+			return fmt.Errorf("convert model item to pb error: %w", err)
+		}
+		req.Items[i] = pbItem
+	}
+	for i := range t.Templates {
+		req.Templates[i] = t.Templates[i].ToPb()
+	}
+
+	resp, err := g.Emergency.TakeoverAccount(ctx, req)
+	if err != nil || !resp.Success {
+		return fmt.Errorf("takeover account server error: %w", err)
+	}
+	return nil
+}
@@ -7,9 +7,11 @@ import (
 	"gophkeeper/internal/logger"
 	pbcr "gophkeeper/internal/protos/crypto"
 	pbit "gophkeeper/internal/protos/items"
+	pbor "gophkeeper/internal/protos/orgs"
 	pbus "gophkeeper/internal/protos/users"
 	"gophkeeper/models"
 
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
@@ -17,11 +19,17 @@ import (
 
 type Client interface {
 	//User
-	SignUpUser(ctx context.Context, user *models.User) (token string, salt string, err error)
-	SignInUser(ctx context.Context, user *models.User) (token string, salt string, err error)
+	SignUpUser(ctx context.Context, user *models.User, device *models.DeviceSignIn) (token string, salt string, err error)
+	SignInUser(ctx context.Context, user *models.User, device *models.DeviceSignIn) (token string, salt string, err error)
 	SetJWTToken(token string) error
 	GetJWTToken() (string, error)
 
+	//Devices
+	ListDevices(ctx context.Context) (devices []models.Device, approvalRequired bool, err error)
+	RemoveDevice(ctx context.Context, id [16]byte) error
+	ApproveDevice(ctx context.Context, id [16]byte) error
+	SetDeviceApproval(ctx context.Context, required bool) error
+
 	//Crypto
 	GetPublicKeyPEM(ctx context.Context) (string, error)
 
@@ -31,6 +39,56 @@ type Client interface {
 	DeleteItem(ctx context.Context, login string, itemID [16]byte) error
 	GetItems(ctx context.Context, login string, typ models.ItemType) ([]models.EncryptedItem, error)
 	GetTypesCounts(ctx context.Context, login string) (map[string]int32, error)
+	SearchItems(ctx context.Context, tokens []string) ([]models.EncryptedItem, error)
+	GetCollectionItems(ctx context.Context, collectionID [16]byte, typ models.ItemType) ([]models.EncryptedItem, error)
+	GetCollectionTypesCounts(ctx context.Context, collectionID [16]byte) (map[string]int32, error)
+	TouchItem(ctx context.Context, itemID [16]byte) error
+	SetItemFavorite(ctx context.Context, itemID [16]byte, favorite bool) error
+
+	//Attachments
+	UploadAttachment(ctx context.Context, a *models.Attachment) ([16]byte, error)
+	ListAttachments(ctx context.Context, itemID [16]byte) ([]models.Attachment, error)
+	DownloadAttachment(ctx context.Context, itemID, attachmentID [16]byte) (*models.Attachment, error)
+	DeleteAttachment(ctx context.Context, itemID, attachmentID [16]byte) error
+
+	//Templates
+	SaveTemplate(ctx context.Context, t *models.Template) error
+	ListTemplates(ctx context.Context) ([]models.Template, error)
+	DeleteTemplate(ctx context.Context, id [16]byte) error
+
+	//Shares
+	SetUserKeys(ctx context.Context, keys *models.UserKeys) error
+	GetUserKeys(ctx context.Context) (*models.UserKeys, error)
+	GetPublicKey(ctx context.Context, login string) ([]byte, error)
+	ShareItem(ctx context.Context, share *models.ItemShare) error
+	ListItemShares(ctx context.Context, itemID [16]byte) ([]models.ItemShare, error)
+	ListSharedWithMe(ctx context.Context) ([]models.SharedItem, error)
+	RevokeShare(ctx context.Context, rev *models.ShareRevocation) error
+
+	//Organizations
+	CreateOrg(ctx context.Context, org *models.Organization, coll *models.Collection) (*models.Organization, error)
+	ListOrgs(ctx context.Context) ([]models.Organization, error)
+	InviteMember(ctx context.Context, member *models.Membership) error
+	AcceptInvite(ctx context.Context, orgID [16]byte) error
+	RemoveMember(ctx context.Context, rot *models.OrgKeyRotation) error
+	RotateOrgKey(ctx context.Context, rot *models.OrgKeyRotation) error
+	ChangeRole(ctx context.Context, orgID [16]byte, login string, role models.OrgRole) error
+	ListMembers(ctx context.Context, orgID [16]byte) ([]models.Membership, error)
+	CreateCollection(ctx context.Context, coll *models.Collection) (*models.Collection, error)
+	ListCollections(ctx context.Context, orgID [16]byte) ([]models.Collection, error)
+
+	//Emergency access
+	GrantEmergencyAccess(ctx context.Context, access *models.EmergencyAccess) error
+	RevokeEmergencyAccess(ctx context.Context, grantee string) error
+	ListEmergencyAccess(ctx context.Context) (granted, trustedBy []models.EmergencyAccess, err error)
+	RequestEmergencyAccess(ctx context.Context, grantor string) error
+	ApproveEmergencyAccess(ctx context.Context, grantee string) error
+	DenyEmergencyAccess(ctx context.Context, grantee string) error
+	GetEmergencyVault(ctx context.Context, grantor string) (*models.EmergencyVault, error)
+	TakeoverAccount(ctx context.Context, t *models.EmergencyTakeover) error
+
+	//Sends
+	CreateSend(ctx context.Context, send *models.Send) (url string, err error)
 }
 
 var _ Client = (*GRPCClient)(nil)
@@ -40,9 +98,14 @@ type GRPCClient struct {
 	conn  *grpc.ClientConn
 	cnfg  config.AgentClientConfig
 
-	User   pbus.UserControllerClient
-	Crypto pbcr.CryptoControllerClient
-	Item   pbit.ItemsControllerClient
+	User      pbus.UserControllerClient
+	Device    pbus.DeviceControllerClient
+	Crypto    pbcr.CryptoControllerClient
+	Item      pbit.ItemsControllerClient
+	Share     pbit.SharesControllerClient
+	Org       pbor.OrgControllerClient
+	Emergency pbit.EmergencyControllerClient
+	Send      pbit.SendsControllerClient
 }
 
 func NewGRPCClient(cnfg config.AgentClientConfig) (*GRPCClient, error) {
@@ -52,6 +115,7 @@ func NewGRPCClient(cnfg config.AgentClientConfig) (*GRPCClient, error) {
 
 	conn, err := grpc.NewClient(cnfg.GetAddress(),
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
 		grpc.WithUnaryInterceptor(client.authInterceptor),
 	)
 	if err != nil {
@@ -63,6 +127,10 @@ func NewGRPCClient(cnfg config.AgentClientConfig) (*GRPCClient, error) {
 	if err != nil {
 		return nil, fmt.Errorf("create grpc client error: %w", err)
 	}
+	client.Device, err = pbus.NewDeviceControllerClient(conn)
+	if err != nil {
+		return nil, fmt.Errorf("create grpc client error: %w", err)
+	}
 	client.Crypto, err = pbcr.NewCryptoControllerClient(conn)
 	if err != nil {
 		return nil, fmt.Errorf("create grpc client error: %w", err)
@@ -71,6 +139,22 @@ func NewGRPCClient(cnfg config.AgentClientConfig) (*GRPCClient, error) {
 	if err != nil {
 		return nil, fmt.Errorf("create grpc client error: %w", err)
 	}
+	client.Share, err = pbit.NewSharesControllerClient(conn)
+	if err != nil {
+		return nil, fmt.Errorf("create grpc client error: %w", err)
+	}
+	client.Org, err = pbor.NewOrgControllerClient(conn)
+	if err != nil {
+		return nil, fmt.Errorf("create grpc client error: %w", err)
+	}
+	client.Emergency, err = pbit.NewEmergencyControllerClient(conn)
+	if err != nil {
+		return nil, fmt.Errorf("create grpc client error: %w", err)
+	}
+	client.Send, err = pbit.NewSendsControllerClient(conn)
+	if err != nil {
+		return nil, fmt.Errorf("create grpc client error: %w", err)
+	}
 
 	return client, nil
 
@@ -71,3 +71,56 @@ func (g *GRPCClient) GetTypesCounts(ctx context.Context, login string) (map[stri
 	}
 	return resp.GetTypes(), nil
 }
+
+func (g *GRPCClient) SearchItems(ctx context.Context, tokens []string) ([]models.EncryptedItem, error) {
+	resp, err := g.Item.SearchItems(ctx, &pbit.SearchItemsRequest{Tokens: tokens})
+	if err != nil {
+		return nil, fmt.Errorf("search items server error: %w", err)
+	}
+
+	items := make([]models.EncryptedItem, len(resp.Items))
+	for i, pbItem := range resp.Items {
+		items[i] = *models.EncryptedItemPbToModels(pbItem)
+	}
+	return items, nil
+}
+
+func (g *GRPCClient) GetCollectionItems(ctx context.Context, collectionID [16]byte, typ models.ItemType) ([]models.EncryptedItem, error) {
+	resp, err := g.Item.GetUserItems(ctx, &pbit.GetUserItemsRequest{
+		Type:         typ.ToPb(),
+		CollectionId: collectionID[:],
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get collection items server error: %w", err)
+	}
+
+	items := make([]models.EncryptedItem, len(resp.Items))
+	for i, pbItem := range resp.Items {
+		items[i] = *models.EncryptedItemPbToModels(pbItem)
+	}
+	return items, nil
+}
+
+func (g *GRPCClient) GetCollectionTypesCounts(ctx context.Context, collectionID [16]byte) (map[string]int32, error) {
+	resp, err := g.Item.TypesCounts(ctx, &pbit.TypesCountsRequest{CollectionId: collectionID[:]})
+	if err != nil {
+		return nil, fmt.Errorf("get collection item type counters error: %w", err)
+	}
+	return resp.GetTypes(), nil
+}
+
+func (g *GRPCClient) TouchItem(ctx context.Context, itemID [16]byte) error {
+	resp, err := g.Item.TouchItem(ctx, &pbit.TouchItemRequest{ItemId: itemID[:]})
+	if err != nil || !resp.Success {
+		return fmt.Errorf("touch item server error: %w", err)
+	}
+	return nil
+}
+
+func (g *GRPCClient) SetItemFavorite(ctx context.Context, itemID [16]byte, favorite bool) error {
+	resp, err := g.Item.SetItemFavorite(ctx, &pbit.SetItemFavoriteRequest{ItemId: itemID[:], Favorite: favorite})
+	if err != nil || !resp.Success {
+		return fmt.Errorf("set item favorite server error: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"gophkeeper/models"
+
+	pbor "gophkeeper/internal/protos/orgs"
+)
+
+func (g *GRPCClient) CreateOrg(ctx context.Context, org *models.Organization, coll *models.Collection) (*models.Organization, error) {
+	req := &pbor.CreateOrgRequest{
+		Name:       org.Name,
+		WrappedKey: org.WrappedKey,
+	}
+	if coll != nil {
+		req.Collection = coll.ToPb()
+	}
+
+	resp, err := g.Org.CreateOrg(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("create organization server error: %w", err)
+	}
+	return models.OrganizationPbToModels(resp.Org), nil
+}
+
+func (g *GRPCClient) ListOrgs(ctx context.Context) ([]models.Organization, error) {
+	resp, err := g.Org.ListOrgs(ctx, &pbor.ListOrgsRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("list organizations server error: %w", err)
+	}
+
+	orgs := make([]models.Organization, len(resp.Orgs))
+	for i, o := range resp.Orgs {
+		orgs[i] = *models.OrganizationPbToModels(o)
+	}
+	return orgs, nil
+}
+
+func (g *GRPCClient) InviteMember(ctx context.Context, member *models.Membership) error {
+	resp, err := g.Org.InviteMember(ctx, &pbor.InviteMemberRequest{Member: member.ToPb()})
+	if err != nil || !resp.Success {
+		return fmt.Errorf("invite member server error: %w", err)
+	}
+	return nil
+}
+
+func (g *GRPCClient) AcceptInvite(ctx context.Context, orgID [16]byte) error {
+	resp, err := g.Org.AcceptInvite(ctx, &pbor.AcceptInviteRequest{OrgId: orgID[:]})
+	if err != nil || !resp.Success {
+		return fmt.Errorf("accept invite server error: %w", err)
+	}
+	return nil
+}
+
+func (g *GRPCClient) RemoveMember(ctx context.Context, rot *models.OrgKeyRotation) error {
+	req := &pbor.RemoveMemberRequest{
+		OrgId:       rot.OrgID[:],
+		Login:       rot.Login,
+		Members:     make([]*pbor.Membership, len(rot.Members)),
+		Collections: make([]*pbor.Collection, len(rot.Collections)),
+		Items:       make([]*pbor.RekeyedItem, len(rot.Items)),
+		Attachments: make([]*pbor.RekeyedAttachment, len(rot.Attachments)),
+	}
+	rotationToPb(rot, req.Members, req.Collections, req.Items, req.Attachments)
+
+	resp, err := g.Org.RemoveMember(ctx, req)
+	if err != nil || !resp.Success {
+		return fmt.Errorf("remove member server error: %w", err)
+	}
+	return nil
+}
+
+func (g *GRPCClient) RotateOrgKey(ctx context.Context, rot *models.OrgKeyRotation) error {
+	req := &pbor.RotateOrgKeyRequest{
+		OrgId:       rot.OrgID[:],
+		Members:     make([]*pbor.Membership, len(rot.Members)),
+		Collections: make([]*pbor.Collection, len(rot.Collections)),
+		Items:       make([]*pbor.RekeyedItem, len(rot.Items)),
+		Attachments: make([]*pbor.RekeyedAttachment, len(rot.Attachments)),
+	}
+	rotationToPb(rot, req.Members, req.Collections, req.Items, req.Attachments)
+
+	resp, err := g.Org.RotateOrgKey(ctx, req)
+	if err != nil || !resp.Success {
+		return fmt.Errorf("rotate org key server error: %w", err)
+	}
+	return nil
+}
+
+// rotationToPb fills the request slices, sized by the caller, from rot.
+func rotationToPb(rot *models.OrgKeyRotation, members []*pbor.Membership, collections []*pbor.Collection,
+	items []*pbor.RekeyedItem, attachments []*pbor.RekeyedAttachment) {
+	for i := range rot.Members {
+		members[i] = rot.Members[i].ToPb()
+	}
+	for i := range rot.Collections {
+		collections[i] = rot.Collections[i].ToPb()
+	}
+	for i := range rot.Items {
+		items[i] = models.RekeyedItemToPb(&rot.Items[i])
+	}
+	for i := range rot.Attachments {
+		attachments[i] = models.RekeyedAttachmentToPb(&rot.Attachments[i])
+	}
+}
+
+func (g *GRPCClient) ChangeRole(ctx context.Context, orgID [16]byte, login string, role models.OrgRole) error {
+	resp, err := g.Org.ChangeRole(ctx, &pbor.ChangeRoleRequest{
+		OrgId: orgID[:],
+		Login: login,
+		Role:  role.ToPb(),
+	})
+	if err != nil || !resp.Success {
+		return fmt.Errorf("change role server error: %w", err)
+	}
+	return nil
+}
+
+func (g *GRPCClient) ListMembers(ctx context.Context, orgID [16]byte) ([]models.Membership, error) {
+	resp, err := g.Org.ListMembers(ctx, &pbor.ListMembersRequest{OrgId: orgID[:]})
+	if err != nil {
+		return nil, fmt.Errorf("list members server error: %w", err)
+	}
+
+	members := make([]models.Membership, len(resp.Members))
+	for i, m := range resp.Members {
+		members[i] = *models.MembershipPbToModels(m)
+	}
+	return members, nil
+}
+
+func (g *GRPCClient) CreateCollection(ctx context.Context, coll *models.Collection) (*models.Collection, error) {
+	resp, err := g.Org.CreateCollection(ctx, &pbor.CreateCollectionRequest{Collection: coll.ToPb()})
+	if err != nil {
+		return nil, fmt.Errorf("create collection server error: %w", err)
+	}
+	return models.CollectionPbToModels(resp.Collection), nil
+}
+
+func (g *GRPCClient) ListCollections(ctx context.Context, orgID [16]byte) ([]models.Collection, error) {
+	resp, err := g.Org.ListCollections(ctx, &pbor.ListCollectionsRequest{OrgId: orgID[:]})
+	if err != nil {
+		return nil, fmt.Errorf("list collections server error: %w", err)
+	}
+
+	colls := make([]models.Collection, len(resp.Collections))
+	for i, c := range resp.Collections {
+		colls[i] = *models.CollectionPbToModels(c)
+	}
+	return colls, nil
+}
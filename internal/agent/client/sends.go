@@ -0,0 +1,15 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"gophkeeper/models"
+)
+
+func (g *GRPCClient) CreateSend(ctx context.Context, send *models.Send) (string, error) {
+	resp, err := g.Send.CreateSend(ctx, send.ToCreatePb())
+	if err != nil {
+		return "", fmt.Errorf("create send server error: %w", err)
+	}
+	return resp.Url, nil
+}
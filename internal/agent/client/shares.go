@@ -0,0 +1,101 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"gophkeeper/internal/errs"
+	"gophkeeper/models"
+
+	pbit "gophkeeper/internal/protos/items"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (g *GRPCClient) SetUserKeys(ctx context.Context, keys *models.UserKeys) error {
+	resp, err := g.Share.SetUserKeys(ctx, &pbit.SetUserKeysRequest{Keys: keys.ToPb()})
+	if err != nil || !resp.Success {
+		return fmt.Errorf("set user keys server error: %w", err)
+	}
+	return nil
+}
+
+func (g *GRPCClient) GetUserKeys(ctx context.Context) (*models.UserKeys, error) {
+	resp, err := g.Share.GetUserKeys(ctx, &pbit.GetUserKeysRequest{})
+	if status.Code(err) == codes.NotFound {
+		return nil, errs.ErrKeysNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get user keys server error: %w", err)
+	}
+	return models.UserKeysPbToModels(resp.Keys), nil
+}
+
+func (g *GRPCClient) GetPublicKey(ctx context.Context, login string) ([]byte, error) {
+	resp, err := g.Share.GetPublicKey(ctx, &pbit.GetPublicKeyRequest{Login: login})
+	if err != nil {
+		return nil, fmt.Errorf("get public key server error: %w", err)
+	}
+	return resp.PublicKey, nil
+}
+
+func (g *GRPCClient) ShareItem(ctx context.Context, share *models.ItemShare) error {
+	resp, err := g.Share.ShareItem(ctx, &pbit.ShareItemRequest{Share: share.ToPb()})
+	if err != nil || !resp.Success {
+		return fmt.Errorf("share item server error: %w", err)
+	}
+	return nil
+}
+
+func (g *GRPCClient) ListItemShares(ctx context.Context, itemID [16]byte) ([]models.ItemShare, error) {
+	resp, err := g.Share.ListItemShares(ctx, &pbit.ListItemSharesRequest{ItemId: itemID[:]})
+	if err != nil {
+		return nil, fmt.Errorf("list item shares server error: %w", err)
+	}
+
+	shares := make([]models.ItemShare, len(resp.Shares))
+	for i, s := range resp.Shares {
+		shares[i] = *models.ItemSharePbToModels(s)
+	}
+	return shares, nil
+}
+
+func (g *GRPCClient) ListSharedWithMe(ctx context.Context) ([]models.SharedItem, error) {
+	resp, err := g.Share.ListSharedWithMe(ctx, &pbit.ListSharedWithMeRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("list shared items server error: %w", err)
+	}
+
+	items := make([]models.SharedItem, len(resp.Items))
+	for i, item := range resp.Items {
+		items[i] = *models.SharedItemPbToModels(item)
+	}
+	return items, nil
+}
+
+func (g *GRPCClient) RevokeShare(ctx context.Context, rev *models.ShareRevocation) error {
+	pbItem, err := rev.Item.ToPb()
+	if err != nil {
+		return fmt.Errorf("convert model item to pb error: %w", err)
+	}
+
+	req := &pbit.RevokeShareRequest{
+		ItemId:         rev.ItemID[:],
+		RecipientLogin: rev.RecipientLogin,
+		Item:           pbItem,
+		Shares:         make([]*pbit.ItemShare, len(rev.Shares)),
+		Attachments:    make([]*pbit.Attachment, len(rev.Attachments)),
+	}
+	for i := range rev.Shares {
+		req.Shares[i] = rev.Shares[i].ToPb()
+	}
+	for i := range rev.Attachments {
+		req.Attachments[i] = rev.Attachments[i].ToPb()
+	}
+
+	resp, err := g.Share.RevokeShare(ctx, req)
+	if err != nil || !resp.Success {
+		return fmt.Errorf("revoke share server error: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"gophkeeper/models"
+
+	pbit "gophkeeper/internal/protos/items"
+)
+
+func (g *GRPCClient) SaveTemplate(ctx context.Context, t *models.Template) error {
+	resp, err := g.Item.SaveTemplate(ctx, &pbit.SaveTemplateRequest{Template: t.ToPb()})
+	if err != nil || !resp.Success {
+		return fmt.Errorf("save template server error: %w", err)
+	}
+	return nil
+}
+
+func (g *GRPCClient) ListTemplates(ctx context.Context) ([]models.Template, error) {
+	resp, err := g.Item.ListTemplates(ctx, &pbit.ListTemplatesRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("list templates server error: %w", err)
+	}
+
+	templates := make([]models.Template, len(resp.Templates))
+	for i, t := range resp.Templates {
+		templates[i] = *models.TemplatePbToModels(t)
+	}
+	return templates, nil
+}
+
+func (g *GRPCClient) DeleteTemplate(ctx context.Context, id [16]byte) error {
+	resp, err := g.Item.DeleteTemplate(ctx, &pbit.DeleteTemplateRequest{Id: id[:]})
+	if err != nil || !resp.Success {
+		return fmt.Errorf("delete template server error: %w", err)
+	}
+	return nil
+}
@@ -7,12 +7,13 @@ import (
 	"gophkeeper/models"
 )
 
-func (g *GRPCClient) SignUpUser(ctx context.Context, user *models.User) (token string, salt string, err error) {
+func (g *GRPCClient) SignUpUser(ctx context.Context, user *models.User, device *models.DeviceSignIn) (token string, salt string, err error) {
 	req := &pb.SignUpUserRequest{
 		User: &pb.User{
 			Login:    user.Login,
 			Password: string(user.Password),
 		},
+		Device: device.ToPb(),
 	}
 
 	resp, err := g.User.SignUpUser(ctx, req)
@@ -26,12 +27,13 @@ func (g *GRPCClient) SignUpUser(ctx context.Context, user *models.User) (token s
 	return resp.Token, resp.Salt, nil
 }
 
-func (g *GRPCClient) SignInUser(ctx context.Context, user *models.User) (token string, salt string, err error) {
+func (g *GRPCClient) SignInUser(ctx context.Context, user *models.User, device *models.DeviceSignIn) (token string, salt string, err error) {
 	req := &pb.SignInUserRequest{
 		User: &pb.User{
 			Login:    user.Login,
 			Password: string(user.Password),
 		},
+		Device: device.ToPb(),
 	}
 
 	resp, err := g.User.SignInUser(ctx, req)
@@ -18,7 +18,7 @@ func TestGRPCClient_SignUpUser_NilClient(t *testing.T) {
 	}
 
 	assert.Panics(t, func() {
-		client.SignUpUser(context.Background(), user)
+		client.SignUpUser(context.Background(), user, nil)
 	})
 }
 
@@ -33,7 +33,7 @@ func TestGRPCClient_SignUpUser_NilUserClient(t *testing.T) {
 	}
 
 	assert.Panics(t, func() {
-		client.SignUpUser(context.Background(), user)
+		client.SignUpUser(context.Background(), user, nil)
 	})
 }
 
@@ -46,7 +46,7 @@ func TestGRPCClient_SignInUser_NilClient(t *testing.T) {
 	}
 
 	assert.Panics(t, func() {
-		client.SignInUser(context.Background(), user)
+		client.SignInUser(context.Background(), user, nil)
 	})
 }
 
@@ -61,7 +61,7 @@ func TestGRPCClient_SignInUser_NilUserClient(t *testing.T) {
 	}
 
 	assert.Panics(t, func() {
-		client.SignInUser(context.Background(), user)
+		client.SignInUser(context.Background(), user, nil)
 	})
 }
 
@@ -0,0 +1,849 @@
+package offline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"gophkeeper/internal/agent/client"
+	"gophkeeper/internal/errs"
+	"gophkeeper/internal/logger"
+	"gophkeeper/models"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	bolt "go.etcd.io/bbolt"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// offlineToken stands in for the JWT of a sign in made offline. The real
+// token comes when the sign in is replayed.
+const offlineToken = "offline"
+
+// Reasons of conflicts found when the outbox is replayed.
+const (
+	ReasonChanged = "changed on the server"
+	ReasonDeleted = "deleted on the server"
+)
+
+var errLocked = errors.New("vault is locked")
+
+type OpKind string
+
+const (
+	OpAdd    OpKind = "add"
+	OpEdit   OpKind = "edit"
+	OpDelete OpKind = "delete"
+)
+
+// Op is a change made offline. Items added offline get a local id, the
+// server assigns the real one when the add is replayed.
+type Op struct {
+	Kind OpKind `json:"kind"`
+
+	// Item is the changed item, for deletes the item as it was.
+	Item models.EncryptedItem `json:"item"`
+
+	// Base is the server version of the item the change was made to.
+	Base time.Time `json:"base"`
+}
+
+// Conflict is a change the server did not take when it was replayed.
+type Conflict struct {
+	ID     uint64 `json:"-"`
+	Op     Op     `json:"op"`
+	Reason string `json:"reason"`
+}
+
+// KeySource gives the master key of the unlocked vault.
+type KeySource interface {
+	GetMasterKey() ([]byte, error)
+}
+
+var _ client.Client = (*Client)(nil)
+
+// Client serves personal items from the cache while the server is
+// unreachable and queues adds, edits and deletes in an outbox. Everything
+// else goes to the wrapped client.
+type Client struct {
+	client.Client
+	store *Store
+	keys  KeySource
+
+	mu      sync.Mutex
+	login   string
+	offline bool
+
+	// signIn is a sign in made offline, it is replayed when the server is
+	// back.
+	signIn *signInRequest
+	// signDevice signs the device part of a replayed sign in again, the
+	// server refuses the one made offline once it is stale.
+	signDevice func(login, encryptedPassword string) *models.DeviceSignIn
+}
+
+type signInRequest struct {
+	user   models.User
+	device *models.DeviceSignIn
+}
+
+func NewClient(inner client.Client, store *Store, keys KeySource) *Client {
+	return &Client{Client: inner, store: store, keys: keys}
+}
+
+// SetDeviceSigner makes replayed sign ins carry a device part signed with
+// sign at replay time.
+func (c *Client) SetDeviceSigner(sign func(login, encryptedPassword string) *models.DeviceSignIn) {
+	c.mu.Lock()
+	c.signDevice = sign
+	c.mu.Unlock()
+}
+
+// Offline reports whether the server did not answer the last request.
+func (c *Client) Offline() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.offline
+}
+
+func isUnavailable(err error) bool {
+	return status.Code(err) == codes.Unavailable
+}
+
+func warn(err error) {
+	if err != nil {
+		logger.Log.Warn("offline cache", zap.Error(err))
+	}
+}
+
+func (c *Client) setOffline(offline bool) {
+	c.mu.Lock()
+	c.offline = offline
+	c.mu.Unlock()
+}
+
+func (c *Client) currentLogin() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.login
+}
+
+// cacheKey returns the key of the cache of the signed in account.
+func (c *Client) cacheKey() ([]byte, error) {
+	mk, err := c.keys.GetMasterKey()
+	if err != nil {
+		return nil, err
+	}
+	if len(mk) == 0 || c.currentLogin() == "" {
+		return nil, errLocked
+	}
+	return deriveCacheKey(mk), nil
+}
+
+// call runs fn against the server, replaying an offline sign in first.
+func (c *Client) call(ctx context.Context, fn func() error) error {
+	err := c.reconnect(ctx)
+	if err == nil {
+		err = fn()
+	}
+	c.setOffline(isUnavailable(err))
+	return err
+}
+
+func (c *Client) reconnect(ctx context.Context) error {
+	c.mu.Lock()
+	req, sign := c.signIn, c.signDevice
+	c.mu.Unlock()
+	if req == nil {
+		return nil
+	}
+
+	user := req.user
+	device := req.device
+	if device != nil && sign != nil {
+		device = sign(user.Login, string(user.Password))
+	}
+	token, _, err := c.Client.SignInUser(ctx, &user, device)
+	if err != nil {
+		if isUnavailable(err) {
+			return err
+		}
+		return fmt.Errorf("server refused the sign in made offline: %w", err)
+	}
+	if err := c.Client.SetJWTToken(token); err != nil {
+		return err
+	}
+	c.mu.Lock()
+	c.signIn = nil
+	c.mu.Unlock()
+	return nil
+}
+
+func (c *Client) GetPublicKeyPEM(ctx context.Context) (string, error) {
+	pem, err := c.Client.GetPublicKeyPEM(ctx)
+	c.setOffline(isUnavailable(err))
+	if err == nil {
+		warn(c.store.setPublicKey(pem))
+		return pem, nil
+	}
+	if !isUnavailable(err) {
+		return "", err
+	}
+	if cached, cacheErr := c.store.publicKey(); cacheErr == nil {
+		return cached, nil
+	}
+	return "", err
+}
+
+func (c *Client) SignUpUser(ctx context.Context, user *models.User, device *models.DeviceSignIn) (string, string, error) {
+	token, salt, err := c.Client.SignUpUser(ctx, user, device)
+	c.setOffline(isUnavailable(err))
+	if err == nil {
+		c.signedIn(user.Login, nil)
+		warn(c.store.setSalt(user.Login, salt))
+	}
+	return token, salt, err
+}
+
+// SignInUser signs in with the cached salt when the server is unreachable.
+// The account password is checked when the sign in is replayed, the master
+// password when the cache is opened.
+func (c *Client) SignInUser(ctx context.Context, user *models.User, device *models.DeviceSignIn) (string, string, error) {
+	token, salt, err := c.Client.SignInUser(ctx, user, device)
+	c.setOffline(isUnavailable(err))
+	if err == nil {
+		c.signedIn(user.Login, nil)
+		warn(c.store.setSalt(user.Login, salt))
+		return token, salt, nil
+	}
+	if !isUnavailable(err) {
+		return "", "", err
+	}
+
+	salt, cacheErr := c.store.salt(user.Login)
+	if cacheErr != nil {
+		return "", "", err
+	}
+	c.signedIn(user.Login, &signInRequest{user: *user, device: device})
+	return offlineToken, salt, nil
+}
+
+// Resume opens the cache of login for a session signed in before the
+// agent started.
+func (c *Client) Resume(login string) {
+	c.signedIn(login, nil)
+}
+
+func (c *Client) signedIn(login string, offline *signInRequest) {
+	c.mu.Lock()
+	c.login = login
+	c.signIn = offline
+	c.mu.Unlock()
+}
+
+func (c *Client) SetJWTToken(token string) error {
+	if token == offlineToken {
+		return nil
+	}
+	return c.Client.SetJWTToken(token)
+}
+
+func (c *Client) GetJWTToken() (string, error) {
+	c.mu.Lock()
+	offline := c.signIn != nil
+	c.mu.Unlock()
+	if offline {
+		return offlineToken, nil
+	}
+	return c.Client.GetJWTToken()
+}
+
+// GetUserKeys returns the cached keys offline. Keys that do not unseal
+// mean a wrong master password.
+func (c *Client) GetUserKeys(ctx context.Context) (*models.UserKeys, error) {
+	var keys *models.UserKeys
+	err := c.call(ctx, func() (err error) {
+		keys, err = c.Client.GetUserKeys(ctx)
+		return err
+	})
+	key, keyErr := c.cacheKey()
+	if err == nil {
+		if keyErr == nil {
+			warn(c.store.setValue(c.currentLogin(), keyUserKeys, key, keys))
+		}
+		return keys, nil
+	}
+	if !isUnavailable(err) || keyErr != nil {
+		return nil, err
+	}
+
+	var cached models.UserKeys
+	if cacheErr := c.store.value(c.currentLogin(), keyUserKeys, key, &cached); cacheErr != nil {
+		if errors.Is(cacheErr, errNoCache) {
+			return nil, err
+		}
+		return nil, errs.ErrIncorrectMasterPassword
+	}
+	return &cached, nil
+}
+
+// GetItems replays the outbox before listing, so the list has the changes
+// made offline.
+func (c *Client) GetItems(ctx context.Context, login string, typ models.ItemType) ([]models.EncryptedItem, error) {
+	if _, err := c.Sync(ctx); err != nil && !isUnavailable(err) {
+		return nil, err
+	}
+
+	var items []models.EncryptedItem
+	err := c.call(ctx, func() (err error) {
+		items, err = c.Client.GetItems(ctx, login, typ)
+		return err
+	})
+	if err == nil {
+		warn(c.replaceItems(typ, items))
+		return items, nil
+	}
+	if !isUnavailable(err) {
+		return nil, err
+	}
+
+	cached, cacheErr := c.cachedItems(func(item *models.EncryptedItem) bool {
+		return typ == models.ItemTypeUNSPECIFIED || item.Type == typ
+	})
+	if cacheErr != nil {
+		return nil, err
+	}
+	return cached, nil
+}
+
+func (c *Client) GetTypesCounts(ctx context.Context, login string) (map[string]int32, error) {
+	var counts map[string]int32
+	err := c.call(ctx, func() (err error) {
+		counts, err = c.Client.GetTypesCounts(ctx, login)
+		return err
+	})
+	if !isUnavailable(err) {
+		return counts, err
+	}
+
+	cached, cacheErr := c.cachedItems(func(*models.EncryptedItem) bool { return true })
+	if cacheErr != nil {
+		return nil, err
+	}
+	counts = map[string]int32{}
+	for _, item := range cached {
+		counts[string(item.Type)]++
+	}
+	return counts, nil
+}
+
+// SearchItems matches the blind index tokens of cached items offline, like
+// the server does.
+func (c *Client) SearchItems(ctx context.Context, tokens []string) ([]models.EncryptedItem, error) {
+	var items []models.EncryptedItem
+	err := c.call(ctx, func() (err error) {
+		items, err = c.Client.SearchItems(ctx, tokens)
+		return err
+	})
+	if !isUnavailable(err) {
+		return items, err
+	}
+
+	cached, cacheErr := c.cachedItems(func(item *models.EncryptedItem) bool {
+		for _, token := range tokens {
+			if !slices.Contains(item.SearchTokens, token) {
+				return false
+			}
+		}
+		return true
+	})
+	if cacheErr != nil {
+		return nil, err
+	}
+	return cached, nil
+}
+
+// TouchItem only orders the item list, it is dropped offline.
+func (c *Client) TouchItem(ctx context.Context, itemID [16]byte) error {
+	err := c.call(ctx, func() error { return c.Client.TouchItem(ctx, itemID) })
+	if isUnavailable(err) {
+		return nil
+	}
+	return err
+}
+
+func (c *Client) AddItem(ctx context.Context, item *models.EncryptedItem) error {
+	if item.CollectionID != [16]byte{} {
+		return c.call(ctx, func() error { return c.Client.AddItem(ctx, item) })
+	}
+	return c.write(ctx, Op{Kind: OpAdd, Item: *item}, func() error { return c.Client.AddItem(ctx, item) })
+}
+
+func (c *Client) EditItem(ctx context.Context, item *models.EncryptedItem) error {
+	if item.CollectionID != [16]byte{} {
+		return c.call(ctx, func() error { return c.Client.EditItem(ctx, item) })
+	}
+	return c.write(ctx, Op{Kind: OpEdit, Item: *item}, func() error { return c.Client.EditItem(ctx, item) })
+}
+
+// DeleteItem queues deletes of cached personal items only, other items
+// are in collection vaults, which need the server.
+func (c *Client) DeleteItem(ctx context.Context, login string, itemID [16]byte) error {
+	send := func() error { return c.Client.DeleteItem(ctx, login, itemID) }
+	cached, err := c.cachedItem(itemID)
+	if err != nil || cached == nil {
+		return c.call(ctx, send)
+	}
+	return c.write(ctx, Op{Kind: OpDelete, Item: *cached}, send)
+}
+
+// write sends a change to the server, or queues it when the server is
+// unreachable. Changes also queue behind older ones, so they reach the
+// server in order.
+func (c *Client) write(ctx context.Context, op Op, send func() error) error {
+	pending, err := c.Pending()
+	if err != nil {
+		return err
+	}
+	if pending == 0 {
+		err := c.call(ctx, send)
+		if !isUnavailable(err) {
+			if err == nil {
+				warn(c.cacheChange(op))
+			}
+			return err
+		}
+		if _, keyErr := c.cacheKey(); keyErr != nil {
+			return err
+		}
+	}
+
+	if err := c.enqueue(op); err != nil {
+		return err
+	}
+	if pending > 0 {
+		if _, err := c.Sync(ctx); err != nil && !isUnavailable(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// Pending returns the number of changes waiting in the outbox.
+func (c *Client) Pending() (int, error) {
+	login := c.currentLogin()
+	if login == "" {
+		return 0, nil
+	}
+	n := 0
+	err := c.store.db.View(func(tx *bolt.Tx) error {
+		b, err := childBucket(tx, login, bucketOutbox)
+		if err != nil || b == nil {
+			return err
+		}
+		return b.ForEach(func(k, v []byte) error {
+			n++
+			return nil
+		})
+	})
+	return n, err
+}
+
+// Conflicts lists the changes the server did not take.
+func (c *Client) Conflicts() ([]Conflict, error) {
+	key, err := c.cacheKey()
+	if errors.Is(err, errLocked) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var conflicts []Conflict
+	err = forEach(c.store, c.currentLogin(), bucketConflicts, key, func(k []byte, conflict Conflict) error {
+		conflict.ID = keySeq(k)
+		conflicts = append(conflicts, conflict)
+		return nil
+	})
+	return conflicts, err
+}
+
+type queuedOp struct {
+	seq uint64
+	op  Op
+}
+
+// Sync replays the outbox and returns the number of changes sent. Changes
+// to items that were changed or deleted on the server since become
+// conflicts, so they do not overwrite the other change.
+func (c *Client) Sync(ctx context.Context) (int, error) {
+	key, err := c.cacheKey()
+	if err != nil {
+		return 0, nil
+	}
+	login := c.currentLogin()
+
+	var queue []queuedOp
+	err = forEach(c.store, login, bucketOutbox, key, func(k []byte, op Op) error {
+		queue = append(queue, queuedOp{seq: keySeq(k), op: op})
+		return nil
+	})
+	if err != nil || len(queue) == 0 {
+		return 0, err
+	}
+
+	var server []models.EncryptedItem
+	if err := c.call(ctx, func() (err error) {
+		server, err = c.Client.GetItems(ctx, login, models.ItemTypeUNSPECIFIED)
+		return err
+	}); err != nil {
+		return 0, err
+	}
+	versions := make(map[[16]byte]time.Time, len(server))
+	for _, item := range server {
+		versions[item.ID] = item.UpdatedAt
+	}
+
+	sent := 0
+	for _, q := range queue {
+		reason, err := c.replay(ctx, login, q.op, versions)
+		if isUnavailable(err) {
+			c.setOffline(true)
+			return sent, err
+		}
+		if err != nil {
+			reason = err.Error()
+		}
+		if err := c.finish(q.seq, q.op, reason); err != nil {
+			return sent, err
+		}
+		sent++
+	}
+
+	if err := c.refresh(ctx); err != nil && !isUnavailable(err) {
+		return sent, err
+	}
+	return sent, nil
+}
+
+// replay sends a queued change and returns why it conflicts, if it does.
+func (c *Client) replay(ctx context.Context, login string, op Op, versions map[[16]byte]time.Time) (string, error) {
+	version, exists := versions[op.Item.ID]
+	switch op.Kind {
+	case OpAdd:
+		item := op.Item
+		item.ID = [16]byte{}
+		return "", c.Client.AddItem(ctx, &item)
+	case OpEdit:
+		if !exists {
+			return ReasonDeleted, nil
+		}
+		if !version.Equal(op.Base) {
+			return ReasonChanged, nil
+		}
+		return "", c.Client.EditItem(ctx, &op.Item)
+	case OpDelete:
+		if !exists {
+			return "", nil
+		}
+		if !version.Equal(op.Base) {
+			return ReasonChanged, nil
+		}
+		return "", c.Client.DeleteItem(ctx, login, op.Item.ID)
+	}
+	return "", fmt.Errorf("unknown change %q", op.Kind)
+}
+
+// finish takes a replayed change off the outbox and keeps it as a
+// conflict when there is a reason.
+func (c *Client) finish(seq uint64, op Op, reason string) error {
+	key, err := c.cacheKey()
+	if err != nil {
+		return err
+	}
+	return c.store.db.Update(func(tx *bolt.Tx) error {
+		outbox, err := childBucket(tx, c.currentLogin(), bucketOutbox)
+		if err != nil {
+			return err
+		}
+		if err := outbox.Delete(seqKey(seq)); err != nil {
+			return err
+		}
+		if reason == "" {
+			return nil
+		}
+		conflicts, err := childBucket(tx, c.currentLogin(), bucketConflicts)
+		if err != nil {
+			return err
+		}
+		id, err := conflicts.NextSequence()
+		if err != nil {
+			return err
+		}
+		return put(conflicts, key, seqKey(id), Conflict{Op: op, Reason: reason})
+	})
+}
+
+// ResolveConflict keeps the local change, sending it over the server
+// version, or drops it.
+func (c *Client) ResolveConflict(ctx context.Context, id uint64, keepLocal bool) error {
+	key, err := c.cacheKey()
+	if err != nil {
+		return err
+	}
+	login := c.currentLogin()
+
+	var conflict Conflict
+	err = c.store.db.View(func(tx *bolt.Tx) error {
+		b, err := childBucket(tx, login, bucketConflicts)
+		if err != nil {
+			return err
+		}
+		found := false
+		if b != nil {
+			found, err = get(b, key, seqKey(id), &conflict)
+		}
+		if err == nil && !found {
+			err = fmt.Errorf("conflict %d not found", id)
+		}
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	if keepLocal {
+		if err := c.call(ctx, func() error { return c.force(ctx, login, conflict) }); err != nil {
+			return err
+		}
+	}
+	if err := c.store.update(login, bucketConflicts, func(b *bolt.Bucket) error {
+		return b.Delete(seqKey(id))
+	}); err != nil {
+		return err
+	}
+	warn(c.refresh(ctx))
+	return nil
+}
+
+func (c *Client) force(ctx context.Context, login string, conflict Conflict) error {
+	item := conflict.Op.Item
+	switch {
+	case conflict.Op.Kind == OpDelete:
+		err := c.Client.DeleteItem(ctx, login, item.ID)
+		if errors.Is(err, errs.ErrItemNotFound) {
+			return nil
+		}
+		return err
+	case conflict.Op.Kind == OpEdit && conflict.Reason != ReasonDeleted:
+		return c.Client.EditItem(ctx, &item)
+	default:
+		item.ID = [16]byte{}
+		return c.Client.AddItem(ctx, &item)
+	}
+}
+
+// refresh replaces the cached items with the server ones.
+func (c *Client) refresh(ctx context.Context) error {
+	var items []models.EncryptedItem
+	err := c.call(ctx, func() (err error) {
+		items, err = c.Client.GetItems(ctx, c.currentLogin(), models.ItemTypeUNSPECIFIED)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	return c.replaceItems(models.ItemTypeUNSPECIFIED, items)
+}
+
+// cachedItems returns the cached items that have not expired and match.
+func (c *Client) cachedItems(match func(*models.EncryptedItem) bool) ([]models.EncryptedItem, error) {
+	key, err := c.cacheKey()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	items := []models.EncryptedItem{}
+	err = forEach(c.store, c.currentLogin(), bucketItems, key, func(k []byte, item models.EncryptedItem) error {
+		if (item.ExpiresAt.IsZero() || item.ExpiresAt.After(now)) && match(&item) {
+			items = append(items, item)
+		}
+		return nil
+	})
+	return items, err
+}
+
+// cachedItem returns the cached item, nil when it is not cached.
+func (c *Client) cachedItem(id [16]byte) (*models.EncryptedItem, error) {
+	key, err := c.cacheKey()
+	if err != nil {
+		return nil, err
+	}
+	var item models.EncryptedItem
+	found := false
+	err = c.store.db.View(func(tx *bolt.Tx) error {
+		b, err := childBucket(tx, c.currentLogin(), bucketItems)
+		if err != nil || b == nil {
+			return err
+		}
+		found, err = get(b, key, id[:], &item)
+		return err
+	})
+	if err != nil || !found {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// replaceItems caches the items the server listed for typ. The changes
+// still in the outbox are applied on top.
+func (c *Client) replaceItems(typ models.ItemType, items []models.EncryptedItem) error {
+	key, err := c.cacheKey()
+	if err != nil {
+		return nil
+	}
+	return c.store.db.Update(func(tx *bolt.Tx) error {
+		b, err := childBucket(tx, c.currentLogin(), bucketItems)
+		if err != nil {
+			return err
+		}
+
+		var stale [][]byte
+		err = b.ForEach(func(k, sealed []byte) error {
+			var item models.EncryptedItem
+			if err := unseal(key, k, sealed, &item); err != nil || typ == models.ItemTypeUNSPECIFIED || item.Type == typ {
+				stale = append(stale, k)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		for _, k := range stale {
+			if err := b.Delete(k); err != nil {
+				return err
+			}
+		}
+		for _, item := range items {
+			if err := put(b, key, item.ID[:], item); err != nil {
+				return err
+			}
+		}
+
+		outbox, err := childBucket(tx, c.currentLogin(), bucketOutbox)
+		if err != nil {
+			return err
+		}
+		return outbox.ForEach(func(k, sealed []byte) error {
+			var op Op
+			if err := unseal(key, k, sealed, &op); err != nil {
+				return err
+			}
+			return applyOp(b, key, op)
+		})
+	})
+}
+
+// cacheChange applies a change the server took. Added items are cached
+// when the list is loaded, as only the server knows their id.
+func (c *Client) cacheChange(op Op) error {
+	key, err := c.cacheKey()
+	if err != nil || op.Kind == OpAdd {
+		return nil
+	}
+	return c.store.update(c.currentLogin(), bucketItems, func(b *bolt.Bucket) error {
+		return applyOp(b, key, op)
+	})
+}
+
+// enqueue caches the change and adds it to the outbox. A change to an item
+// with a queued change is folded into it: the item keeps one change with
+// the server version it started from.
+func (c *Client) enqueue(op Op) error {
+	key, err := c.cacheKey()
+	if err != nil {
+		return err
+	}
+	if op.Kind == OpAdd {
+		op.Item.ID = uuid.New()
+	}
+
+	return c.store.db.Update(func(tx *bolt.Tx) error {
+		items, err := childBucket(tx, c.currentLogin(), bucketItems)
+		if err != nil {
+			return err
+		}
+		outbox, err := childBucket(tx, c.currentLogin(), bucketOutbox)
+		if err != nil {
+			return err
+		}
+
+		if op.Kind != OpAdd {
+			op.Base = op.Item.UpdatedAt
+			var cached models.EncryptedItem
+			if found, err := get(items, key, op.Item.ID[:], &cached); err != nil {
+				return err
+			} else if found {
+				op.Base = cached.UpdatedAt
+			}
+
+			seq, prev, err := findOp(outbox, key, op.Item.ID)
+			if err != nil {
+				return err
+			}
+			switch {
+			case prev == nil:
+			case prev.Kind == OpAdd && op.Kind == OpEdit:
+				prev.Item = op.Item
+				if err := put(outbox, key, seqKey(seq), prev); err != nil {
+					return err
+				}
+				return applyOp(items, key, op)
+			case prev.Kind == OpAdd:
+				if err := outbox.Delete(seqKey(seq)); err != nil {
+					return err
+				}
+				return applyOp(items, key, op)
+			default:
+				op.Base = prev.Base
+				if err := outbox.Delete(seqKey(seq)); err != nil {
+					return err
+				}
+			}
+		}
+
+		seq, err := outbox.NextSequence()
+		if err != nil {
+			return err
+		}
+		if err := put(outbox, key, seqKey(seq), op); err != nil {
+			return err
+		}
+		return applyOp(items, key, op)
+	})
+}
+
+// findOp returns the queued change of the item, if there is one.
+func findOp(outbox *bolt.Bucket, key []byte, id [16]byte) (uint64, *Op, error) {
+	c := outbox.Cursor()
+	for k, sealed := c.First(); k != nil; k, sealed = c.Next() {
+		var op Op
+		if err := unseal(key, k, sealed, &op); err != nil {
+			return 0, nil, err
+		}
+		if op.Item.ID == id {
+			return keySeq(k), &op, nil
+		}
+	}
+	return 0, nil, nil
+}
+
+func applyOp(items *bolt.Bucket, key []byte, op Op) error {
+	if op.Kind == OpDelete {
+		return items.Delete(op.Item.ID[:])
+	}
+	return put(items, key, op.Item.ID[:], op.Item)
+}
@@ -0,0 +1,403 @@
+package offline
+
+import (
+	"context"
+	"gophkeeper/internal/agent/client"
+	"gophkeeper/internal/errs"
+	"gophkeeper/models"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var errDown = status.Error(codes.Unavailable, "connection refused")
+
+// serverClient is a server keeping personal items in memory. It answers
+// nothing while down.
+type serverClient struct {
+	client.Client
+
+	down    bool
+	token   string
+	signIns int
+	device  *models.DeviceSignIn
+	items   map[[16]byte]models.EncryptedItem
+	nextID  byte
+	now     time.Time
+}
+
+func newServerClient() *serverClient {
+	return &serverClient{
+		items: map[[16]byte]models.EncryptedItem{},
+		now:   time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC),
+	}
+}
+
+func (s *serverClient) tick() time.Time {
+	s.now = s.now.Add(time.Minute)
+	return s.now
+}
+
+func (s *serverClient) GetPublicKeyPEM(context.Context) (string, error) {
+	if s.down {
+		return "", errDown
+	}
+	return "server key", nil
+}
+
+func (s *serverClient) SignInUser(_ context.Context, user *models.User, device *models.DeviceSignIn) (string, string, error) {
+	if s.down {
+		return "", "", errDown
+	}
+	if string(user.Password) != "password" {
+		return "", "", errs.ErrIncorrectCredentials
+	}
+	s.signIns++
+	s.device = device
+	return "jwt", "salt", nil
+}
+
+func (s *serverClient) SetJWTToken(token string) error {
+	s.token = token
+	return nil
+}
+
+func (s *serverClient) GetJWTToken() (string, error) {
+	return s.token, nil
+}
+
+func (s *serverClient) GetUserKeys(context.Context) (*models.UserKeys, error) {
+	if s.down {
+		return nil, errDown
+	}
+	return &models.UserKeys{Login: "alice", EncryptedPrivateKey: "private"}, nil
+}
+
+func (s *serverClient) GetItems(_ context.Context, _ string, typ models.ItemType) ([]models.EncryptedItem, error) {
+	if s.down {
+		return nil, errDown
+	}
+	var items []models.EncryptedItem
+	for _, item := range s.items {
+		if typ == models.ItemTypeUNSPECIFIED || item.Type == typ {
+			items = append(items, item)
+		}
+	}
+	return items, nil
+}
+
+func (s *serverClient) AddItem(_ context.Context, item *models.EncryptedItem) error {
+	if s.down {
+		return errDown
+	}
+	s.nextID++
+	added := *item
+	added.ID = [16]byte{s.nextID}
+	added.UpdatedAt = s.tick()
+	s.items[added.ID] = added
+	return nil
+}
+
+func (s *serverClient) EditItem(_ context.Context, item *models.EncryptedItem) error {
+	if s.down {
+		return errDown
+	}
+	if _, ok := s.items[item.ID]; !ok {
+		return errs.ErrItemNotFound
+	}
+	edited := *item
+	edited.UpdatedAt = s.tick()
+	s.items[item.ID] = edited
+	return nil
+}
+
+func (s *serverClient) DeleteItem(_ context.Context, _ string, id [16]byte) error {
+	if s.down {
+		return errDown
+	}
+	if _, ok := s.items[id]; !ok {
+		return errs.ErrItemNotFound
+	}
+	delete(s.items, id)
+	return nil
+}
+
+func (s *serverClient) itemNamed(name string) *models.EncryptedItem {
+	for _, item := range s.items {
+		if item.Name == name {
+			return &item
+		}
+	}
+	return nil
+}
+
+type masterKey []byte
+
+func (k *masterKey) GetMasterKey() ([]byte, error) {
+	return *k, nil
+}
+
+func newTestClient(t *testing.T) (*Client, *serverClient, *masterKey) {
+	store, err := Open(filepath.Join(t.TempDir(), "gophkeeper", "cache.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { store.Close() })
+
+	server := newServerClient()
+	key := masterKey("0123456789abcdef0123456789abcdef")
+	return NewClient(server, store, &key), server, &key
+}
+
+// signIn starts the agent, signs in and loads the items, which fills the
+// cache.
+func signIn(t *testing.T, c *Client) {
+	ctx := context.Background()
+	_, err := c.GetPublicKeyPEM(ctx)
+	require.NoError(t, err)
+	token, _, err := c.SignInUser(ctx, &models.User{Login: "alice", Password: []byte("password")}, nil)
+	require.NoError(t, err)
+	require.NoError(t, c.SetJWTToken(token))
+	_, err = c.GetUserKeys(ctx)
+	require.NoError(t, err)
+	_, err = c.GetItems(ctx, "alice", models.ItemTypeUNSPECIFIED)
+	require.NoError(t, err)
+}
+
+func names(items []models.EncryptedItem) []string {
+	var names []string
+	for _, item := range items {
+		names = append(names, item.Name)
+	}
+	return names
+}
+
+func TestClient_OfflineSignIn(t *testing.T) {
+	c, server, _ := newTestClient(t)
+	ctx := context.Background()
+	require.NoError(t, server.AddItem(ctx, &models.EncryptedItem{Name: "mail", Type: models.ItemTypeCREDENTIALS}))
+	signIn(t, c)
+	assert.False(t, c.Offline())
+
+	server.down = true
+	token, salt, err := c.SignInUser(ctx, &models.User{Login: "alice", Password: []byte("password")}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "salt", salt)
+	assert.True(t, c.Offline())
+	require.NoError(t, c.SetJWTToken(token))
+	jwt, err := c.GetJWTToken()
+	require.NoError(t, err)
+	assert.NotEmpty(t, jwt)
+
+	keys, err := c.GetUserKeys(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "private", keys.EncryptedPrivateKey)
+
+	items, err := c.GetItems(ctx, "alice", models.ItemTypeUNSPECIFIED)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"mail"}, names(items))
+
+	counts, err := c.GetTypesCounts(ctx, "alice")
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), counts[string(models.ItemTypeCREDENTIALS)])
+
+	pem, err := c.GetPublicKeyPEM(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "server key", pem)
+
+	// The sign in is replayed when the server is back.
+	server.down = false
+	_, err = c.GetItems(ctx, "alice", models.ItemTypeUNSPECIFIED)
+	require.NoError(t, err)
+	assert.Equal(t, 2, server.signIns)
+	assert.Equal(t, "jwt", server.token)
+	assert.False(t, c.Offline())
+}
+
+func TestClient_OfflineSignIn_ResignsDevice(t *testing.T) {
+	c, server, _ := newTestClient(t)
+	ctx := context.Background()
+	signIn(t, c)
+	c.SetDeviceSigner(func(login, encryptedPassword string) *models.DeviceSignIn {
+		return &models.DeviceSignIn{Device: models.Device{ID: [16]byte{1}}, SignedAt: server.tick()}
+	})
+
+	server.down = true
+	offlineSignIn := &models.DeviceSignIn{Device: models.Device{ID: [16]byte{1}}, SignedAt: server.now}
+	_, _, err := c.SignInUser(ctx, &models.User{Login: "alice", Password: []byte("password")}, offlineSignIn)
+	require.NoError(t, err)
+
+	// The replay carries a device part signed when it is sent.
+	server.down = false
+	_, err = c.GetItems(ctx, "alice", models.ItemTypeUNSPECIFIED)
+	require.NoError(t, err)
+	require.NotNil(t, server.device)
+	assert.True(t, server.device.SignedAt.After(offlineSignIn.SignedAt))
+}
+
+func TestClient_OfflineSignIn_UnknownAccount(t *testing.T) {
+	c, server, _ := newTestClient(t)
+	server.down = true
+
+	_, _, err := c.SignInUser(context.Background(), &models.User{Login: "bob", Password: []byte("password")}, nil)
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+}
+
+func TestClient_GetUserKeys_WrongMasterPassword(t *testing.T) {
+	c, server, key := newTestClient(t)
+	signIn(t, c)
+
+	server.down = true
+	*key = masterKey("another master key, not the one")
+	_, err := c.GetUserKeys(context.Background())
+	assert.ErrorIs(t, err, errs.ErrIncorrectMasterPassword)
+}
+
+func TestClient_QueuedWrites(t *testing.T) {
+	c, server, _ := newTestClient(t)
+	ctx := context.Background()
+	require.NoError(t, server.AddItem(ctx, &models.EncryptedItem{Name: "mail"}))
+	require.NoError(t, server.AddItem(ctx, &models.EncryptedItem{Name: "bank"}))
+	signIn(t, c)
+
+	server.down = true
+	require.NoError(t, c.AddItem(ctx, &models.EncryptedItem{Name: "new"}))
+	items, err := c.GetItems(ctx, "alice", models.ItemTypeUNSPECIFIED)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"mail", "bank", "new"}, names(items))
+
+	// An edit of an item added offline folds into the add.
+	var added models.EncryptedItem
+	for _, item := range items {
+		if item.Name == "new" {
+			added = item
+		}
+	}
+	added.Name = "newer"
+	require.NoError(t, c.EditItem(ctx, &added))
+
+	mail := *server.itemNamed("mail")
+	mail.Name = "mail 1"
+	require.NoError(t, c.EditItem(ctx, &mail))
+	mail.Name = "mail 2"
+	require.NoError(t, c.EditItem(ctx, &mail))
+	require.NoError(t, c.DeleteItem(ctx, "alice", server.itemNamed("bank").ID))
+
+	pending, err := c.Pending()
+	require.NoError(t, err)
+	assert.Equal(t, 3, pending)
+
+	items, err = c.GetItems(ctx, "alice", models.ItemTypeUNSPECIFIED)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"mail 2", "newer"}, names(items))
+
+	server.down = false
+	sent, err := c.Sync(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 3, sent)
+	assert.Len(t, server.items, 2)
+	assert.NotNil(t, server.itemNamed("mail 2"))
+	assert.NotNil(t, server.itemNamed("newer"))
+
+	pending, err = c.Pending()
+	require.NoError(t, err)
+	assert.Zero(t, pending)
+	conflicts, err := c.Conflicts()
+	require.NoError(t, err)
+	assert.Empty(t, conflicts)
+}
+
+func TestClient_QueuedWrites_DeleteAdded(t *testing.T) {
+	c, server, _ := newTestClient(t)
+	ctx := context.Background()
+	signIn(t, c)
+
+	server.down = true
+	require.NoError(t, c.AddItem(ctx, &models.EncryptedItem{Name: "draft"}))
+	items, err := c.GetItems(ctx, "alice", models.ItemTypeUNSPECIFIED)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	require.NoError(t, c.DeleteItem(ctx, "alice", items[0].ID))
+
+	pending, err := c.Pending()
+	require.NoError(t, err)
+	assert.Zero(t, pending)
+}
+
+func TestClient_SyncConflicts(t *testing.T) {
+	c, server, _ := newTestClient(t)
+	ctx := context.Background()
+	require.NoError(t, server.AddItem(ctx, &models.EncryptedItem{Name: "mail"}))
+	require.NoError(t, server.AddItem(ctx, &models.EncryptedItem{Name: "bank"}))
+	signIn(t, c)
+	mail, bank := *server.itemNamed("mail"), *server.itemNamed("bank")
+
+	server.down = true
+	local := mail
+	local.Name = "mail offline"
+	require.NoError(t, c.EditItem(ctx, &local))
+	localBank := bank
+	localBank.Name = "bank offline"
+	require.NoError(t, c.EditItem(ctx, &localBank))
+
+	// Another device changes mail and deletes bank meanwhile.
+	server.down = false
+	remote := mail
+	remote.Name = "mail elsewhere"
+	require.NoError(t, server.EditItem(ctx, &remote))
+	require.NoError(t, server.DeleteItem(ctx, "alice", bank.ID))
+
+	sent, err := c.Sync(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, sent)
+	assert.NotNil(t, server.itemNamed("mail elsewhere"))
+
+	conflicts, err := c.Conflicts()
+	require.NoError(t, err)
+	require.Len(t, conflicts, 2)
+	assert.Equal(t, ReasonChanged, conflicts[0].Reason)
+	assert.Equal(t, "mail offline", conflicts[0].Op.Item.Name)
+	assert.Equal(t, ReasonDeleted, conflicts[1].Reason)
+
+	// The cache follows the server until a conflict is resolved.
+	items, err := c.GetItems(ctx, "alice", models.ItemTypeUNSPECIFIED)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"mail elsewhere"}, names(items))
+
+	require.NoError(t, c.ResolveConflict(ctx, conflicts[0].ID, true))
+	assert.NotNil(t, server.itemNamed("mail offline"))
+	require.NoError(t, c.ResolveConflict(ctx, conflicts[1].ID, false))
+	assert.Nil(t, server.itemNamed("bank offline"))
+
+	conflicts, err = c.Conflicts()
+	require.NoError(t, err)
+	assert.Empty(t, conflicts)
+	assert.Error(t, c.ResolveConflict(ctx, 1, true))
+}
+
+func TestClient_WriteOnline(t *testing.T) {
+	c, server, _ := newTestClient(t)
+	ctx := context.Background()
+	require.NoError(t, server.AddItem(ctx, &models.EncryptedItem{Name: "mail"}))
+	signIn(t, c)
+
+	mail := *server.itemNamed("mail")
+	mail.Name = "mail 1"
+	require.NoError(t, c.EditItem(ctx, &mail))
+	assert.NotNil(t, server.itemNamed("mail 1"))
+
+	// Server errors are not queued.
+	err := c.EditItem(ctx, &models.EncryptedItem{ID: [16]byte{9}, Name: "ghost"})
+	assert.ErrorIs(t, err, errs.ErrItemNotFound)
+	pending, err := c.Pending()
+	require.NoError(t, err)
+	assert.Zero(t, pending)
+
+	server.down = true
+	items, err := c.GetItems(ctx, "alice", models.ItemTypeUNSPECIFIED)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"mail 1"}, names(items))
+}
@@ -0,0 +1,268 @@
+// Package offline keeps an encrypted copy of the vault on disk, so the
+// agent can read items and queue changes while the server is unreachable.
+package offline
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+const cacheKeyInfo = "gophkeeper offline cache"
+
+var (
+	bucketServer = []byte("server")
+	bucketUsers  = []byte("users")
+
+	bucketItems     = []byte("items")
+	bucketOutbox    = []byte("outbox")
+	bucketConflicts = []byte("conflicts")
+
+	keyPublicKey = []byte("public_key")
+	keySalt      = []byte("salt")
+	keyUserKeys  = []byte("user_keys")
+)
+
+var errNoCache = errors.New("nothing is cached for this account")
+
+// Store is the cache file. Every value but the server public key and the
+// salts is sealed with a key derived from the master key of its account.
+type Store struct {
+	db *bolt.DB
+}
+
+// Open opens or creates the cache file. It fails when another agent has it
+// open.
+func Open(path string) (*Store, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, err
+	}
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("open offline cache error: %w", err)
+	}
+	return &Store{db: db}, nil
+}
+
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// deriveCacheKey derives the cache key from the master key, so the cache
+// opens only with the master password.
+func deriveCacheKey(masterKey []byte) []byte {
+	mac := hmac.New(sha256.New, masterKey)
+	mac.Write([]byte(cacheKeyInfo))
+	return mac.Sum(nil)
+}
+
+// seal encrypts value with AES-GCM. The record key is authenticated too, so
+// sealed values cannot be swapped between records.
+func seal(key, recordKey []byte, value any) ([]byte, error) {
+	plaintext, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return gcm.Seal(nonce, nonce, plaintext, recordKey), nil
+}
+
+func unseal(key, recordKey, sealed []byte, value any) error {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return err
+	}
+	if len(sealed) < gcm.NonceSize() {
+		return errors.New("sealed value is too short")
+	}
+	plaintext, err := gcm.Open(nil, sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():], recordKey)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(plaintext, value)
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func seqKey(seq uint64) []byte {
+	return binary.BigEndian.AppendUint64(nil, seq)
+}
+
+func keySeq(key []byte) uint64 {
+	return binary.BigEndian.Uint64(key)
+}
+
+// userBucket returns the bucket of login, creating it in writable
+// transactions. It is nil when a read finds nothing for login.
+func userBucket(tx *bolt.Tx, login string) (*bolt.Bucket, error) {
+	if !tx.Writable() {
+		users := tx.Bucket(bucketUsers)
+		if users == nil {
+			return nil, nil
+		}
+		return users.Bucket([]byte(login)), nil
+	}
+	users, err := tx.CreateBucketIfNotExists(bucketUsers)
+	if err != nil {
+		return nil, err
+	}
+	return users.CreateBucketIfNotExists([]byte(login))
+}
+
+// childBucket returns a bucket of the user bucket, like userBucket does.
+func childBucket(tx *bolt.Tx, login string, name []byte) (*bolt.Bucket, error) {
+	user, err := userBucket(tx, login)
+	if err != nil || user == nil {
+		return nil, err
+	}
+	if !tx.Writable() {
+		return user.Bucket(name), nil
+	}
+	return user.CreateBucketIfNotExists(name)
+}
+
+func (s *Store) publicKey() (string, error) {
+	var pem string
+	err := s.db.View(func(tx *bolt.Tx) error {
+		if b := tx.Bucket(bucketServer); b != nil {
+			pem = string(b.Get(keyPublicKey))
+		}
+		return nil
+	})
+	if err == nil && pem == "" {
+		err = errNoCache
+	}
+	return pem, err
+}
+
+func (s *Store) setPublicKey(pem string) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b, err := tx.CreateBucketIfNotExists(bucketServer)
+		if err != nil {
+			return err
+		}
+		return b.Put(keyPublicKey, []byte(pem))
+	})
+}
+
+func (s *Store) salt(login string) (string, error) {
+	var salt string
+	err := s.db.View(func(tx *bolt.Tx) error {
+		b, err := userBucket(tx, login)
+		if b != nil {
+			salt = string(b.Get(keySalt))
+		}
+		return err
+	})
+	if err == nil && salt == "" {
+		err = errNoCache
+	}
+	return salt, err
+}
+
+func (s *Store) setSalt(login, salt string) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b, err := userBucket(tx, login)
+		if err != nil {
+			return err
+		}
+		return b.Put(keySalt, []byte(salt))
+	})
+}
+
+// value reads the sealed value stored under key in the user bucket.
+func (s *Store) value(login string, key, cacheKey []byte, value any) error {
+	return s.db.View(func(tx *bolt.Tx) error {
+		b, err := userBucket(tx, login)
+		if err != nil {
+			return err
+		}
+		if b == nil || b.Get(key) == nil {
+			return errNoCache
+		}
+		return unseal(cacheKey, key, b.Get(key), value)
+	})
+}
+
+func (s *Store) setValue(login string, key, cacheKey []byte, value any) error {
+	sealed, err := seal(cacheKey, key, value)
+	if err != nil {
+		return err
+	}
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b, err := userBucket(tx, login)
+		if err != nil {
+			return err
+		}
+		return b.Put(key, sealed)
+	})
+}
+
+// forEach unseals every record of a user bucket in key order.
+func forEach[T any](s *Store, login string, bucket, cacheKey []byte, fn func(k []byte, v T) error) error {
+	return s.db.View(func(tx *bolt.Tx) error {
+		b, err := childBucket(tx, login, bucket)
+		if err != nil || b == nil {
+			return err
+		}
+		return b.ForEach(func(k, sealed []byte) error {
+			var v T
+			if err := unseal(cacheKey, k, sealed, &v); err != nil {
+				return err
+			}
+			return fn(k, v)
+		})
+	})
+}
+
+// update runs fn with a user bucket in a write transaction.
+func (s *Store) update(login string, bucket []byte, fn func(b *bolt.Bucket) error) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b, err := childBucket(tx, login, bucket)
+		if err != nil {
+			return err
+		}
+		return fn(b)
+	})
+}
+
+func put(b *bolt.Bucket, cacheKey, key []byte, value any) error {
+	sealed, err := seal(cacheKey, key, value)
+	if err != nil {
+		return err
+	}
+	return b.Put(key, sealed)
+}
+
+// get unseals the record under key, it reports false when there is none.
+func get(b *bolt.Bucket, cacheKey, key []byte, value any) (bool, error) {
+	sealed := b.Get(key)
+	if sealed == nil {
+		return false, nil
+	}
+	return true, unseal(cacheKey, key, sealed, value)
+}
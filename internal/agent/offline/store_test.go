@@ -0,0 +1,62 @@
+package offline
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSeal(t *testing.T) {
+	key := deriveCacheKey([]byte("master key"))
+
+	sealed, err := seal(key, []byte("record"), "secret")
+	require.NoError(t, err)
+	assert.NotContains(t, string(sealed), "secret")
+
+	var value string
+	require.NoError(t, unseal(key, []byte("record"), sealed, &value))
+	assert.Equal(t, "secret", value)
+
+	// Values do not open under another key or record.
+	assert.Error(t, unseal(deriveCacheKey([]byte("other key")), []byte("record"), sealed, &value))
+	assert.Error(t, unseal(key, []byte("other record"), sealed, &value))
+
+	sealed[len(sealed)-1] ^= 1
+	assert.Error(t, unseal(key, []byte("record"), sealed, &value))
+	assert.Error(t, unseal(key, []byte("record"), sealed[:4], &value))
+}
+
+func TestStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache.db")
+	store, err := Open(path)
+	require.NoError(t, err)
+
+	_, err = store.salt("alice")
+	assert.ErrorIs(t, err, errNoCache)
+	_, err = store.publicKey()
+	assert.ErrorIs(t, err, errNoCache)
+
+	key := deriveCacheKey([]byte("master key"))
+	require.NoError(t, store.setSalt("alice", "salt"))
+	require.NoError(t, store.setPublicKey("server key"))
+	require.NoError(t, store.setValue("alice", keyUserKeys, key, "keys"))
+	require.NoError(t, store.Close())
+
+	store, err = Open(path)
+	require.NoError(t, err)
+	defer store.Close()
+
+	salt, err := store.salt("alice")
+	require.NoError(t, err)
+	assert.Equal(t, "salt", salt)
+	pem, err := store.publicKey()
+	require.NoError(t, err)
+	assert.Equal(t, "server key", pem)
+
+	var keys string
+	require.NoError(t, store.value("alice", keyUserKeys, key, &keys))
+	assert.Equal(t, "keys", keys)
+	assert.ErrorIs(t, store.value("bob", keyUserKeys, key, &keys), errNoCache)
+}
@@ -0,0 +1,265 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"gophkeeper/internal/agent/client"
+	"gophkeeper/internal/telemetry"
+	"gophkeeper/models"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var (
+	errAttachmentTooLarge = fmt.Errorf("attachment is larger than %d MiB", models.MaxAttachmentSize>>20)
+	errItemKeyMissing     = errors.New("item has no data key yet, save it once before attaching files")
+	errAttachmentCorrupt  = errors.New("attachment size does not match its content")
+)
+
+// AttachmentFile is a decrypted attachment entry, without its content.
+type AttachmentFile struct {
+	ID     [16]byte
+	ItemID [16]byte
+	models.AttachmentInfo
+	Size      int64
+	CreatedAt time.Time
+}
+
+// AttachFile encrypts the file at path with a new attachment key and
+// uploads it as an attachment of the item.
+func (is *ItemService) AttachFile(ctx context.Context, item *models.Item, path string) (err error) {
+	ctx, span := tracer.Start(ctx, "ItemService.AttachFile")
+	defer telemetry.End(span, &err)
+
+	content, err := readAttachmentFile(path)
+	if err != nil {
+		return err
+	}
+	return is.AddAttachment(ctx, item, filepath.Base(path), "", content)
+}
+
+func readAttachmentFile(path string) ([]byte, error) {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if fi.IsDir() {
+		return nil, fmt.Errorf("%s is a directory", path)
+	}
+	if fi.Size() > models.MaxAttachmentSize {
+		return nil, errAttachmentTooLarge
+	}
+	return os.ReadFile(path)
+}
+
+// AddAttachment uploads content as an attachment of the item. The MIME type
+// is guessed from the filename or the content when empty.
+func (is *ItemService) AddAttachment(ctx context.Context, item *models.Item, filename, mimeType string, content []byte) (err error) {
+	ctx, span := tracer.Start(ctx, "ItemService.AddAttachment", trace.WithAttributes(attribute.Int("attachment.size", len(content))))
+	defer telemetry.End(span, &err)
+
+	_, err = is.addAttachment(ctx, item, filename, mimeType, content)
+	return err
+}
+
+func (is *ItemService) addAttachment(ctx context.Context, item *models.Item, filename, mimeType string, content []byte) ([16]byte, error) {
+	if len(content) > models.MaxAttachmentSize {
+		return [16]byte{}, errAttachmentTooLarge
+	}
+	if mimeType == "" {
+		mimeType = detectMimeType(filename, content)
+	}
+	key, err := is.itemKey(ctx, item)
+	if err != nil {
+		return [16]byte{}, err
+	}
+	attachmentKey, err := newRandomKey()
+	if err != nil {
+		return [16]byte{}, err
+	}
+
+	info, err := encryptWithKey(key, models.AttachmentInfo{Filename: filename, MimeType: mimeType, Key: attachmentKey})
+	if err != nil {
+		return [16]byte{}, fmt.Errorf("failed to encrypt attachment info: %w", err)
+	}
+	sealed, nonce, err := seal(attachmentKey, content)
+	if err != nil {
+		return [16]byte{}, fmt.Errorf("failed to encrypt attachment: %w", err)
+	}
+
+	return is.Client.UploadAttachment(ctx, &models.Attachment{
+		ItemID:        item.ID,
+		EncryptedInfo: *info,
+		Size:          int64(len(content)),
+		Content:       sealed,
+		Nonce:         nonce,
+	})
+}
+
+func (is *ItemService) ListAttachments(ctx context.Context, item *models.Item) (_ []AttachmentFile, err error) {
+	ctx, span := tracer.Start(ctx, "ItemService.ListAttachments")
+	defer telemetry.End(span, &err)
+
+	attachments, err := is.Client.ListAttachments(ctx, item.ID)
+	if err != nil || len(attachments) == 0 {
+		return nil, err
+	}
+	key, err := is.itemKey(ctx, item)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]AttachmentFile, len(attachments))
+	for i := range attachments {
+		files[i], err = openAttachmentInfo(key, &attachments[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return files, nil
+}
+
+// DownloadAttachment returns the decrypted attachment and its content.
+func (is *ItemService) DownloadAttachment(ctx context.Context, item *models.Item, attachmentID [16]byte) (_ *AttachmentFile, _ []byte, err error) {
+	ctx, span := tracer.Start(ctx, "ItemService.DownloadAttachment")
+	defer telemetry.End(span, &err)
+
+	a, err := is.Client.DownloadAttachment(ctx, item.ID, attachmentID)
+	if err != nil {
+		return nil, nil, err
+	}
+	key, err := is.itemKey(ctx, item)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	file, err := openAttachmentInfo(key, a)
+	if err != nil {
+		return nil, nil, err
+	}
+	content, err := open(file.Key, a.Nonce, a.Content)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to decrypt attachment: %w", err)
+	}
+	if int64(len(content)) != file.Size {
+		return nil, nil, errAttachmentCorrupt
+	}
+	return &file, content, nil
+}
+
+// SaveAttachment downloads the attachment into path. An existing file is
+// never overwritten, a free name is picked next to it instead. It returns
+// the path the file was written to.
+func (is *ItemService) SaveAttachment(ctx context.Context, item *models.Item, attachmentID [16]byte, path string) (string, error) {
+	_, content, err := is.DownloadAttachment(ctx, item, attachmentID)
+	if err != nil {
+		return "", err
+	}
+
+	ext := filepath.Ext(path)
+	base := strings.TrimSuffix(path, ext)
+	for i := 0; ; i++ {
+		name := path
+		if i > 0 {
+			name = fmt.Sprintf("%s (%d)%s", base, i, ext)
+		}
+		f, err := os.OpenFile(name, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+		if errors.Is(err, os.ErrExist) && i < 100 {
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+		if _, err := f.Write(content); err != nil {
+			f.Close()
+			return "", err
+		}
+		return name, f.Close()
+	}
+}
+
+func (is *ItemService) DeleteAttachment(ctx context.Context, item *models.Item, attachmentID [16]byte) (err error) {
+	ctx, span := tracer.Start(ctx, "ItemService.DeleteAttachment")
+	defer telemetry.End(span, &err)
+
+	return is.Client.DeleteAttachment(ctx, item.ID, attachmentID)
+}
+
+// itemKey returns the data key of the item, attachment infos and so the
+// attachment keys are sealed with it so everyone who can open the item can
+// open its files.
+func (is *ItemService) itemKey(ctx context.Context, item *models.Item) ([]byte, error) {
+	if item.EncryptedKey == "" {
+		return nil, errItemKeyMissing
+	}
+	if item.CollectionID == [16]byte{} {
+		return is.Crypto.openItemKey(ctx, item.EncryptedKey)
+	}
+	collectionKey, err := is.collectionKey(item.CollectionID)
+	if err != nil {
+		return nil, err
+	}
+	return openKey(collectionKey, item.EncryptedKey)
+}
+
+// resealAttachments re-encrypts the info of every attachment of the item
+// from oldKey to newKey. The attachment keys inside stay the same, so the
+// content does not have to be uploaded again.
+func resealAttachments(ctx context.Context, c client.Client, itemID [16]byte, oldKey, newKey []byte) ([]models.Attachment, error) {
+	attachments, err := c.ListAttachments(ctx, itemID)
+	if err != nil {
+		return nil, err
+	}
+
+	resealed := make([]models.Attachment, len(attachments))
+	for i := range attachments {
+		var info models.AttachmentInfo
+		if err := decryptWithKey(oldKey, &attachments[i].EncryptedInfo, &info); err != nil {
+			return nil, fmt.Errorf("failed to decrypt attachment info: %w", err)
+		}
+		encInfo, err := encryptWithKey(newKey, info)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encrypt attachment info: %w", err)
+		}
+		resealed[i] = models.Attachment{
+			ID:            attachments[i].ID,
+			ItemID:        itemID,
+			EncryptedInfo: *encInfo,
+		}
+	}
+	return resealed, nil
+}
+
+func openAttachmentInfo(key []byte, a *models.Attachment) (AttachmentFile, error) {
+	var info models.AttachmentInfo
+	if err := decryptWithKey(key, &a.EncryptedInfo, &info); err != nil {
+		return AttachmentFile{}, fmt.Errorf("failed to decrypt attachment info: %w", err)
+	}
+	// The filename comes from another client, keep it to a single name.
+	info.Filename = filepath.Base(filepath.Clean("/" + info.Filename))
+	if info.Filename == "/" || info.Filename == "." {
+		info.Filename = "attachment"
+	}
+	return AttachmentFile{
+		ID:             a.ID,
+		ItemID:         a.ItemID,
+		AttachmentInfo: info,
+		Size:           a.Size,
+		CreatedAt:      a.CreatedAt,
+	}, nil
+}
+
+func detectMimeType(filename string, content []byte) string {
+	if t := mime.TypeByExtension(filepath.Ext(filename)); t != "" {
+		return t
+	}
+	return http.DetectContentType(content)
+}
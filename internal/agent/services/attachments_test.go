@@ -0,0 +1,164 @@
+package services
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gophkeeper/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// attachmentClient keeps uploaded attachments like the server.
+type attachmentClient struct {
+	searchClient
+	attachments []models.Attachment
+}
+
+func (c *attachmentClient) UploadAttachment(ctx context.Context, a *models.Attachment) ([16]byte, error) {
+	a.ID = [16]byte{byte(len(c.attachments) + 1)}
+	c.attachments = append(c.attachments, *a)
+	return a.ID, nil
+}
+
+func (c *attachmentClient) ListAttachments(ctx context.Context, itemID [16]byte) ([]models.Attachment, error) {
+	var list []models.Attachment
+	for _, a := range c.attachments {
+		if a.ItemID == itemID {
+			a.Content, a.Nonce = nil, nil
+			list = append(list, a)
+		}
+	}
+	return list, nil
+}
+
+func (c *attachmentClient) DownloadAttachment(ctx context.Context, itemID, attachmentID [16]byte) (*models.Attachment, error) {
+	for _, a := range c.attachments {
+		if a.ItemID == itemID && a.ID == attachmentID {
+			return &a, nil
+		}
+	}
+	return nil, os.ErrNotExist
+}
+
+func (c *attachmentClient) RevokeShare(ctx context.Context, rev *models.ShareRevocation) error {
+	for _, resealed := range rev.Attachments {
+		for i := range c.attachments {
+			if c.attachments[i].ID == resealed.ID {
+				c.attachments[i].EncryptedInfo = resealed.EncryptedInfo
+			}
+		}
+	}
+	return c.shareClient.RevokeShare(ctx, rev)
+}
+
+func newAttachmentItem(t *testing.T) (*ItemService, *attachmentClient, *models.Item) {
+	t.Helper()
+	server := &shareServer{keys: map[string]*models.UserKeys{}}
+	_, is := newShareUser(t, server, "alice", "alice-master")
+	client := &attachmentClient{searchClient: searchClient{shareClient: shareClient{login: "alice", server: server}}}
+	is.Client = client
+	ctx := context.Background()
+
+	require.NoError(t, is.AddItem(ctx, &models.Item{
+		ID: [16]byte{7}, Name: "Mail", Type: models.ItemTypeTEXT, Data: &models.Text{Content: "codes below"},
+	}))
+	item, err := is.DecryptItem(ctx, &client.stored[0])
+	require.NoError(t, err)
+	return is, client, item
+}
+
+func TestItemService_Attachments(t *testing.T) {
+	is, client, item := newAttachmentItem(t)
+	ctx := context.Background()
+
+	dir := t.TempDir()
+	src := filepath.Join(dir, "recovery-codes.txt")
+	require.NoError(t, os.WriteFile(src, []byte("1111 2222 3333"), 0o600))
+	require.NoError(t, is.AttachFile(ctx, item, src))
+
+	// The server only sees ciphertext.
+	require.Len(t, client.attachments, 1)
+	assert.NotContains(t, string(client.attachments[0].Content), "2222")
+	assert.NotContains(t, client.attachments[0].EncryptedInfo.EncryptedContent, "recovery")
+
+	files, err := is.ListAttachments(ctx, item)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	assert.Equal(t, "recovery-codes.txt", files[0].Filename)
+	assert.Equal(t, "text/plain; charset=utf-8", files[0].MimeType)
+	assert.Equal(t, int64(14), files[0].Size)
+
+	dst := filepath.Join(dir, "out.txt")
+	saved, err := is.SaveAttachment(ctx, item, files[0].ID, dst)
+	require.NoError(t, err)
+	assert.Equal(t, dst, saved)
+	saved, err = is.SaveAttachment(ctx, item, files[0].ID, dst)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "out (1).txt"), saved)
+	content, err := os.ReadFile(saved)
+	require.NoError(t, err)
+	assert.Equal(t, "1111 2222 3333", string(content))
+}
+
+func TestItemService_AttachmentsAfterRevoke(t *testing.T) {
+	is, client, item := newAttachmentItem(t)
+	server := client.server
+	server.shares = map[string]models.ItemShare{}
+	newShareUser(t, server, "bob", "bob-master")
+	newShareUser(t, server, "carol", "carol-master")
+	ctx := context.Background()
+
+	require.NoError(t, is.AddAttachment(ctx, item, "codes.txt", "", []byte("1111 2222 3333")))
+	content := client.attachments[0].Content
+	require.NoError(t, is.ShareItem(ctx, item, "bob"))
+	require.NoError(t, is.ShareItem(ctx, item, "carol"))
+	oldKey := item.EncryptedKey
+
+	require.NoError(t, is.RevokeShare(ctx, item, "bob"))
+	require.NotEqual(t, oldKey, item.EncryptedKey)
+	require.Len(t, server.revoked.Attachments, 1)
+	// Only the info is re-sealed, the content is kept.
+	assert.Nil(t, server.revoked.Attachments[0].Content)
+	assert.Equal(t, content, client.attachments[0].Content)
+
+	files, err := is.ListAttachments(ctx, item)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	assert.Equal(t, "codes.txt", files[0].Filename)
+
+	_, got, err := is.DownloadAttachment(ctx, item, files[0].ID)
+	require.NoError(t, err)
+	assert.Equal(t, "1111 2222 3333", string(got))
+}
+
+func TestItemService_AddAttachment_Errors(t *testing.T) {
+	is, _, item := newAttachmentItem(t)
+	ctx := context.Background()
+
+	err := is.AddAttachment(ctx, item, "big.bin", "", make([]byte, models.MaxAttachmentSize+1))
+	assert.ErrorIs(t, err, errAttachmentTooLarge)
+
+	legacy := *item
+	legacy.EncryptedKey = ""
+	assert.ErrorIs(t, is.AddAttachment(ctx, &legacy, "a.txt", "", []byte("a")), errItemKeyMissing)
+	assert.Error(t, is.AttachFile(ctx, item, t.TempDir()))
+}
+
+func TestOpenAttachmentInfo_Filename(t *testing.T) {
+	key := make([]byte, 32)
+	for name, want := range map[string]string{
+		"../../.bashrc": ".bashrc",
+		"/etc/passwd":   "passwd",
+		"":              "attachment",
+	} {
+		info, err := encryptWithKey(key, models.AttachmentInfo{Filename: name})
+		require.NoError(t, err)
+		file, err := openAttachmentInfo(key, &models.Attachment{EncryptedInfo: *info})
+		require.NoError(t, err)
+		assert.Equal(t, want, file.Filename)
+	}
+}
@@ -0,0 +1,79 @@
+package services
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"gophkeeper/internal/hibp"
+	"gophkeeper/models"
+	"slices"
+)
+
+var errNoBreachData = errors.New("no breach dataset configured, set HIBP_DATA to a range directory or filter file")
+
+// BreachReport is a credential whose password is in the breach dataset.
+type BreachReport struct {
+	Item  models.EncryptedItem
+	Login string
+
+	// Count is how often the password was seen in breaches, 0 when the
+	// dataset is a filter that keeps no counts.
+	Count int
+}
+
+// SetBreachIndex sets the local dataset CheckBreaches looks passwords up in.
+func (is *ItemService) SetBreachIndex(idx hibp.Index) {
+	is.breaches = idx
+}
+
+// CheckBreaches looks up the passwords of all credentials in the breach
+// dataset. It returns the compromised ones, most often breached first, and
+// the number of credentials checked. Passwords are hashed locally and never
+// sent anywhere.
+func (is *ItemService) CheckBreaches(ctx context.Context, login string) ([]BreachReport, int, error) {
+	if is.breaches == nil {
+		return nil, 0, errNoBreachData
+	}
+
+	items, err := is.GetItems(ctx, login, models.ItemTypeCREDENTIALS)
+	failed, err := SplitItemErrors(err)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var reports []BreachReport
+	checked := 0
+	for i := range items {
+		// Items that cannot be decrypted are not counted as checked.
+		if failed[items[i].ID] != nil {
+			continue
+		}
+		item, err := is.DecryptItem(ctx, &items[i])
+		if err != nil {
+			return nil, 0, fmt.Errorf("decrypt item error: %w", err)
+		}
+		creds, ok := item.Data.(*models.Credentials)
+		if !ok || creds.Password == "" {
+			continue
+		}
+		checked++
+
+		found, count, err := is.breaches.Lookup(hibp.Hash(creds.Password))
+		if err != nil {
+			return nil, 0, fmt.Errorf("breach lookup error: %w", err)
+		}
+		if found {
+			reports = append(reports, BreachReport{
+				Item:  items[i],
+				Login: creds.Login,
+				Count: count,
+			})
+		}
+	}
+
+	slices.SortStableFunc(reports, func(a, b BreachReport) int {
+		return cmp.Compare(b.Count, a.Count)
+	})
+	return reports, checked, nil
+}
@@ -0,0 +1,72 @@
+package services
+
+import (
+	"context"
+	"crypto/sha1"
+	"gophkeeper/internal/hibp"
+	"gophkeeper/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mapIndex is a breach dataset of password hashes and counts.
+type mapIndex map[[sha1.Size]byte]int
+
+func (m mapIndex) Lookup(hash [sha1.Size]byte) (bool, int, error) {
+	count, ok := m[hash]
+	return ok, count, nil
+}
+
+func (m mapIndex) Close() error {
+	return nil
+}
+
+// breachClient returns the stored items of the requested type.
+type breachClient struct {
+	searchClient
+}
+
+func (c *breachClient) GetItems(ctx context.Context, login string, typ models.ItemType) ([]models.EncryptedItem, error) {
+	var items []models.EncryptedItem
+	for _, item := range c.stored {
+		if typ == models.ItemTypeUNSPECIFIED || item.Type == typ {
+			items = append(items, item)
+		}
+	}
+	return items, nil
+}
+
+func TestItemService_CheckBreaches(t *testing.T) {
+	server := &shareServer{keys: map[string]*models.UserKeys{}}
+	_, is := newShareUser(t, server, "alice", "alice-master")
+	is.Client = &breachClient{searchClient{shareClient: shareClient{login: "alice", server: server}}}
+	ctx := context.Background()
+
+	_, _, err := is.CheckBreaches(ctx, "alice")
+	assert.ErrorIs(t, err, errNoBreachData)
+
+	for _, item := range []*models.Item{
+		{Name: "Mail", Type: models.ItemTypeCREDENTIALS, Data: &models.Credentials{Login: "alice", Password: "password"}},
+		{Name: "Forum", Type: models.ItemTypeCREDENTIALS, Data: &models.Credentials{Login: "al", Password: "hunter2"}},
+		{Name: "Bank", Type: models.ItemTypeCREDENTIALS, Data: &models.Credentials{Login: "a.l", Password: "Xq7#long-and-unique"}},
+		{Name: "Empty", Type: models.ItemTypeCREDENTIALS, Data: &models.Credentials{Login: "nobody"}},
+		{Name: "Note", Type: models.ItemTypeTEXT, Data: &models.Text{Content: "password"}},
+	} {
+		require.NoError(t, is.AddItem(ctx, item))
+	}
+
+	is.SetBreachIndex(mapIndex{
+		hibp.Hash("hunter2"):  17,
+		hibp.Hash("password"): 9659365,
+	})
+	reports, checked, err := is.CheckBreaches(ctx, "alice")
+	require.NoError(t, err)
+	assert.Equal(t, 3, checked)
+	require.Len(t, reports, 2)
+	assert.Equal(t, "Mail", reports[0].Item.Name)
+	assert.Equal(t, 9659365, reports[0].Count)
+	assert.Equal(t, "Forum", reports[1].Item.Name)
+	assert.Equal(t, "al", reports[1].Login)
+}
@@ -0,0 +1,112 @@
+package services
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"gophkeeper/models"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"time"
+)
+
+// DeviceIdentity is the key pair this agent signs sign ins with. The server
+// keeps the public key from the first sign in, so a copied device ID alone
+// cannot pass for this device.
+type DeviceIdentity struct {
+	ID         [16]byte           `json:"id"`
+	Name       string             `json:"name"`
+	OS         string             `json:"os"`
+	PrivateKey ed25519.PrivateKey `json:"private_key"`
+}
+
+// LoadDeviceIdentity reads the identity from path and creates a new one on
+// first run. A non-empty name replaces the stored one.
+func LoadDeviceIdentity(path, name string) (*DeviceIdentity, error) {
+	var identity DeviceIdentity
+	data, err := os.ReadFile(path)
+	switch {
+	case err == nil:
+		if err := json.Unmarshal(data, &identity); err != nil {
+			return nil, fmt.Errorf("parse device file error: %w", err)
+		}
+		if len(identity.PrivateKey) != ed25519.PrivateKeySize {
+			return nil, errors.New("device file has no valid key")
+		}
+	case errors.Is(err, fs.ErrNotExist):
+		if _, err := rand.Read(identity.ID[:]); err != nil {
+			return nil, fmt.Errorf("generate device id error: %w", err)
+		}
+		_, identity.PrivateKey, err = ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, fmt.Errorf("generate device key error: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("read device file error: %w", err)
+	}
+
+	identity.OS = runtime.GOOS
+	if name != "" {
+		identity.Name = name
+	}
+
+	data, err = json.Marshal(identity)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, fmt.Errorf("create device dir error: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return nil, fmt.Errorf("write device file error: %w", err)
+	}
+	return &identity, nil
+}
+
+// SignIn builds the device part of a sign in request. encryptedPassword is
+// the password exactly as sent, so the signature cannot be moved to another
+// sign in. The signature covers the current time: the server takes each
+// signature once and only for a short while, so build it right before
+// sending.
+func (d *DeviceIdentity) SignIn(login, encryptedPassword string) *models.DeviceSignIn {
+	if d == nil {
+		return nil
+	}
+	signedAt := time.Now()
+	return &models.DeviceSignIn{
+		Device: models.Device{
+			ID:        d.ID,
+			Name:      d.Name,
+			OS:        d.OS,
+			PublicKey: d.PrivateKey.Public().(ed25519.PublicKey),
+		},
+		SignedAt:  signedAt,
+		Signature: ed25519.Sign(d.PrivateKey, models.DeviceSignInMessage(login, d.ID, encryptedPassword, signedAt)),
+	}
+}
+
+// SetDevice makes sign ins identify as device.
+func (us *UserService) SetDevice(device *DeviceIdentity) {
+	us.device = device
+}
+
+func (us *UserService) ListDevices(ctx context.Context) ([]models.Device, bool, error) {
+	return us.Client.ListDevices(ctx)
+}
+
+func (us *UserService) RemoveDevice(ctx context.Context, id [16]byte) error {
+	return us.Client.RemoveDevice(ctx, id)
+}
+
+func (us *UserService) ApproveDevice(ctx context.Context, id [16]byte) error {
+	return us.Client.ApproveDevice(ctx, id)
+}
+
+func (us *UserService) SetDeviceApproval(ctx context.Context, required bool) error {
+	return us.Client.SetDeviceApproval(ctx, required)
+}
@@ -0,0 +1,51 @@
+package services
+
+import (
+	"crypto/ed25519"
+	"gophkeeper/models"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadDeviceIdentity(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "gophkeeper", "device.json")
+
+	created, err := LoadDeviceIdentity(path, "laptop")
+	require.NoError(t, err)
+	assert.NotEqual(t, [16]byte{}, created.ID)
+	assert.Equal(t, "laptop", created.Name)
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	loaded, err := LoadDeviceIdentity(path, "")
+	require.NoError(t, err)
+	assert.Equal(t, created.ID, loaded.ID)
+	assert.Equal(t, "laptop", loaded.Name)
+	assert.Equal(t, created.PrivateKey, loaded.PrivateKey)
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"id":[]}`), 0o600))
+	_, err = LoadDeviceIdentity(path, "")
+	assert.Error(t, err)
+}
+
+func TestDeviceIdentity_SignIn(t *testing.T) {
+	var none *DeviceIdentity
+	assert.Nil(t, none.SignIn("alice", "pw"))
+
+	identity, err := LoadDeviceIdentity(filepath.Join(t.TempDir(), "device.json"), "laptop")
+	require.NoError(t, err)
+
+	signIn := identity.SignIn("alice", "pw")
+	require.NotNil(t, signIn)
+	assert.Equal(t, identity.ID, signIn.Device.ID)
+	assert.True(t, signIn.Device.VerifySignature(models.DeviceSignInMessage("alice", identity.ID, "pw", signIn.SignedAt), signIn.Signature))
+	assert.False(t, ed25519.Verify(signIn.Device.PublicKey, models.DeviceSignInMessage("alice", identity.ID, "other", signIn.SignedAt), signIn.Signature))
+	assert.False(t, ed25519.Verify(signIn.Device.PublicKey, models.DeviceSignInMessage("alice", identity.ID, "pw", signIn.SignedAt.Add(time.Second)), signIn.Signature))
+}
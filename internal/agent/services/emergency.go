@@ -0,0 +1,227 @@
+package services
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"gophkeeper/internal/agent/client"
+	"gophkeeper/models"
+	"time"
+)
+
+var errEmptyMasterPassword = errors.New("master password is empty")
+
+// EmergencyService manages emergency access. The grantor's master key is
+// wrapped for a trusted contact in advance; the server releases it to the
+// contact only after a request is approved or its wait period expires.
+type EmergencyService struct {
+	Client client.Client
+	Crypto *CryptoService
+	pins   *KeyPins
+}
+
+func NewEmergencyService(client client.Client, cs *CryptoService) (*EmergencyService, error) {
+	return &EmergencyService{
+		Client: client,
+		Crypto: cs,
+	}, nil
+}
+
+// SetKeyPins turns on pinning of grantee keys, the pins are shared with
+// ItemService so a key verified for sharing is trusted here too.
+func (es *EmergencyService) SetKeyPins(pins *KeyPins) {
+	es.pins = pins
+}
+
+// Grant designates grantee as a trusted contact. Granting again updates the
+// settings and cancels a pending request. With key pins the key of grantee
+// must be verified with ItemService.VerifyRecipientKey.
+func (es *EmergencyService) Grant(ctx context.Context, grantee string, typ models.EmergencyAccessType, wait time.Duration) error {
+	mk, err := es.Crypto.masterKey(ctx)
+	if err != nil {
+		return err
+	}
+	wrapped, err := wrapKeyForLogin(ctx, es.Client, es.pins, grantee, mk, false)
+	if err != nil {
+		return err
+	}
+
+	return es.Client.GrantEmergencyAccess(ctx, &models.EmergencyAccess{
+		Grantee:    grantee,
+		Type:       typ,
+		WaitPeriod: wait,
+		WrappedKey: wrapped,
+	})
+}
+
+func (es *EmergencyService) Revoke(ctx context.Context, grantee string) error {
+	return es.Client.RevokeEmergencyAccess(ctx, grantee)
+}
+
+// List returns the contacts trusted by the user and the users who trust them.
+func (es *EmergencyService) List(ctx context.Context) (granted, trustedBy []models.EmergencyAccess, err error) {
+	return es.Client.ListEmergencyAccess(ctx)
+}
+
+func (es *EmergencyService) Request(ctx context.Context, grantor string) error {
+	return es.Client.RequestEmergencyAccess(ctx, grantor)
+}
+
+func (es *EmergencyService) Approve(ctx context.Context, grantee string) error {
+	return es.Client.ApproveEmergencyAccess(ctx, grantee)
+}
+
+func (es *EmergencyService) Deny(ctx context.Context, grantee string) error {
+	return es.Client.DenyEmergencyAccess(ctx, grantee)
+}
+
+// OpenVault decrypts the grantor's personal items with the released master key.
+func (es *EmergencyService) OpenVault(ctx context.Context, grantor string) ([]models.Item, error) {
+	vault, mk, err := es.vault(ctx, grantor)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]models.Item, len(vault.Items))
+	for i := range vault.Items {
+		item, err := decryptWithMasterKey(mk, &vault.Items[i])
+		if err != nil {
+			return nil, fmt.Errorf("decrypt item %s error: %w", vault.Items[i].Name, err)
+		}
+		items[i] = *item
+	}
+	return items, nil
+}
+
+// Takeover resets the grantor's login password and master password. Every
+// item key, template and the private key are re-sealed with the new master
+// key, legacy items get their own data key on the way.
+func (es *EmergencyService) Takeover(ctx context.Context, grantor, password, masterPassword string) error {
+	if masterPassword == "" {
+		return errEmptyMasterPassword
+	}
+	vault, mk, err := es.vault(ctx, grantor)
+	if err != nil {
+		return err
+	}
+
+	salt, err := base64.StdEncoding.DecodeString(vault.Salt)
+	if err != nil {
+		return fmt.Errorf("decode salt error: %w", err)
+	}
+	newMK := deriveMasterKey([]byte(masterPassword), salt)
+
+	takeover := &models.EmergencyTakeover{
+		Grantor: grantor,
+		Items:   make([]models.EncryptedItem, len(vault.Items)),
+	}
+	for i := range vault.Items {
+		item, err := resealItem(mk, newMK, &vault.Items[i])
+		if err != nil {
+			return fmt.Errorf("reseal item %s error: %w", vault.Items[i].Name, err)
+		}
+		takeover.Items[i] = *item
+	}
+
+	takeover.Templates = make([]models.Template, len(vault.Templates))
+	for i := range vault.Templates {
+		var t models.ItemTemplate
+		if err := decryptWithKey(mk, &vault.Templates[i].EncryptedData, &t); err != nil {
+			return fmt.Errorf("decrypt template error: %w", err)
+		}
+		data, err := encryptWithKey(newMK, &t)
+		if err != nil {
+			return fmt.Errorf("encrypt template error: %w", err)
+		}
+		takeover.Templates[i] = models.Template{ID: vault.Templates[i].ID, EncryptedData: *data}
+	}
+
+	if vault.Keys != nil && vault.Keys.EncryptedPrivateKey != "" {
+		priv, err := openKey(mk, vault.Keys.EncryptedPrivateKey)
+		if err != nil {
+			return fmt.Errorf("open grantor private key error: %w", err)
+		}
+		takeover.EncryptedPrivateKey, err = sealKey(newMK, priv)
+		if err != nil {
+			return err
+		}
+	}
+
+	encryptedPassword, err := es.Crypto.encryptData([]byte(password))
+	if err != nil {
+		return fmt.Errorf("encrypt password error: %w", err)
+	}
+	takeover.Password = base64.StdEncoding.EncodeToString(encryptedPassword)
+
+	return es.Client.TakeoverAccount(ctx, takeover)
+}
+
+// vault fetches the grantor's vault and unwraps their master key.
+func (es *EmergencyService) vault(ctx context.Context, grantor string) (*models.EmergencyVault, []byte, error) {
+	vault, err := es.Client.GetEmergencyVault(ctx, grantor)
+	if err != nil {
+		return nil, nil, err
+	}
+	priv, err := es.Crypto.privateKey(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	mk, err := unwrapKey(priv, vault.Access.WrappedKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unwrap grantor master key error: %w", err)
+	}
+	return vault, mk, nil
+}
+
+func decryptWithMasterKey(mk []byte, encryptedItem *models.EncryptedItem) (*models.Item, error) {
+	if encryptedItem.EncryptedKey == "" {
+		return decryptItemWithKey(mk, encryptedItem)
+	}
+	dataKey, err := openKey(mk, encryptedItem.EncryptedKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get item key: %w", err)
+	}
+	return decryptItemWithKey(dataKey, encryptedItem)
+}
+
+// resealItem moves an item from the old master key to the new one. Only the
+// data key is re-sealed unless the item is a legacy one without a data key,
+// those are re-encrypted in the current format. Search tokens depend on the
+// master key and are always recomputed.
+func resealItem(oldMK, newMK []byte, encryptedItem *models.EncryptedItem) (*models.EncryptedItem, error) {
+	item, err := decryptWithMasterKey(oldMK, encryptedItem)
+	if err != nil {
+		return nil, err
+	}
+
+	var resealed *models.EncryptedItem
+	if encryptedItem.EncryptedKey == "" {
+		dataKey, err := newRandomKey()
+		if err != nil {
+			return nil, err
+		}
+		sealedKey, err := sealKey(newMK, dataKey)
+		if err != nil {
+			return nil, err
+		}
+		resealed, err = sealItem(dataKey, sealedKey, item)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		dataKey, err := openKey(oldMK, encryptedItem.EncryptedKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get item key: %w", err)
+		}
+		copied := *encryptedItem
+		resealed = &copied
+		resealed.EncryptedKey, err = sealKey(newMK, dataKey)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	resealed.SearchTokens = blindTokens(deriveSearchKey(newMK), searchTerms(item))
+	return resealed, nil
+}
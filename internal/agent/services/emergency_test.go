@@ -0,0 +1,184 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"gophkeeper/internal/errs"
+	"gophkeeper/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// emergencyServer keeps one grant and the grantor's vault in memory on top of shareServer.
+type emergencyServer struct {
+	shareServer
+	access    models.EmergencyAccess
+	items     []models.EncryptedItem
+	templates []models.Template
+	takeover  *models.EmergencyTakeover
+}
+
+type emergencyClient struct {
+	shareClient
+	emergency *emergencyServer
+}
+
+func (c *emergencyClient) GrantEmergencyAccess(ctx context.Context, access *models.EmergencyAccess) error {
+	access.Grantor = c.login
+	c.emergency.access = *access
+	return nil
+}
+
+func (c *emergencyClient) GetEmergencyVault(ctx context.Context, grantor string) (*models.EmergencyVault, error) {
+	return &models.EmergencyVault{
+		Access:    c.emergency.access,
+		Salt:      base64.StdEncoding.EncodeToString([]byte("salt-" + grantor)),
+		Keys:      c.server.keys[grantor],
+		Items:     c.emergency.items,
+		Templates: c.emergency.templates,
+	}, nil
+}
+
+func (c *emergencyClient) TakeoverAccount(ctx context.Context, t *models.EmergencyTakeover) error {
+	c.emergency.takeover = t
+	c.emergency.items = t.Items
+	c.emergency.templates = t.Templates
+	return nil
+}
+
+func (c *emergencyClient) SaveTemplate(ctx context.Context, t *models.Template) error {
+	c.emergency.templates = append(c.emergency.templates, *t)
+	return nil
+}
+
+func (c *emergencyClient) ListTemplates(ctx context.Context) ([]models.Template, error) {
+	return c.emergency.templates, nil
+}
+
+func newEmergencyUser(t *testing.T, server *emergencyServer, login, masterPassword string) (*EmergencyService, *ItemService) {
+	t.Helper()
+	_, is := newShareUser(t, &server.shareServer, login, masterPassword)
+
+	client := &emergencyClient{shareClient: shareClient{login: login, server: &server.shareServer}, emergency: server}
+	is.Client = client
+	is.Crypto.Client = client
+	es, err := NewEmergencyService(client, is.Crypto)
+	require.NoError(t, err)
+	return es, is
+}
+
+func TestEmergencyService_OpenVaultAndTakeover(t *testing.T) {
+	server := &emergencyServer{
+		shareServer: shareServer{keys: map[string]*models.UserKeys{}, shares: map[string]models.ItemShare{}},
+	}
+	aliceEmergency, alice := newEmergencyUser(t, server, "alice", "alice-master")
+	bobEmergency, bob := newEmergencyUser(t, server, "bob", "bob-master")
+	ctx := context.Background()
+
+	// One item with a data key and one legacy item sealed with the master key.
+	withKey, err := alice.Crypto.encryptItem(ctx, &models.Item{ID: [16]byte{1}, Name: "bank", Type: models.ItemTypeTEXT, Data: &models.Text{Content: "pin 1234"}})
+	require.NoError(t, err)
+	legacyData, err := alice.Crypto.encryptItemData(ctx, &models.Text{Content: "old note"})
+	require.NoError(t, err)
+	server.items = []models.EncryptedItem{
+		*withKey,
+		{ID: [16]byte{2}, Name: "note", Type: models.ItemTypeTEXT, EncryptedData: *legacyData},
+	}
+
+	require.NoError(t, alice.SaveTemplate(ctx, &models.ItemTemplate{Name: "Wi-Fi", Fields: []models.TemplateField{
+		{Name: "SSID", Type: models.FieldText},
+	}}))
+
+	require.NoError(t, aliceEmergency.Grant(ctx, "bob", models.EmergencyAccessTAKEOVER, 48*time.Hour))
+	assert.Equal(t, "bob", server.access.Grantee)
+	assert.Equal(t, 48*time.Hour, server.access.WaitPeriod)
+
+	items, err := bobEmergency.OpenVault(ctx, "alice")
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+	assert.Equal(t, "pin 1234", items[0].Data.(*models.Text).Content)
+	assert.Equal(t, "old note", items[1].Data.(*models.Text).Content)
+
+	serverKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	require.NoError(t, bob.Crypto.cnfg.SetPublicKey(&serverKey.PublicKey))
+
+	assert.ErrorIs(t, bobEmergency.Takeover(ctx, "alice", "new-pass", ""), errEmptyMasterPassword)
+	require.NoError(t, bobEmergency.Takeover(ctx, "alice", "new-pass", "new-master"))
+	takeover := server.takeover
+	require.NotNil(t, takeover)
+	require.Len(t, takeover.Items, 2)
+	for _, item := range takeover.Items {
+		assert.NotEmpty(t, item.EncryptedKey)
+	}
+
+	// Everything opens with the key derived from the new master password.
+	newMK := deriveMasterKey([]byte("new-master"), []byte("salt-alice"))
+	for i, want := range []string{"pin 1234", "old note"} {
+		got, err := decryptWithMasterKey(newMK, &takeover.Items[i])
+		require.NoError(t, err)
+		assert.Equal(t, want, got.Data.(*models.Text).Content)
+	}
+	priv, err := openKey(newMK, takeover.EncryptedPrivateKey)
+	require.NoError(t, err)
+	oldPriv, err := alice.Crypto.privateKey(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, oldPriv.Bytes(), priv)
+
+	password, err := base64.StdEncoding.DecodeString(takeover.Password)
+	require.NoError(t, err)
+	plain, err := rsa.DecryptOAEP(sha256.New(), rand.Reader, serverKey, password, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "new-pass", string(plain))
+
+	// The grantor unlocks with the new master password and keeps the templates.
+	require.Len(t, takeover.Templates, 1)
+	us, err := NewUserService(alice.Crypto.cnfg, alice.Client, alice.Crypto)
+	require.NoError(t, err)
+	require.NoError(t, us.SetMasterKey(ctx, "new-master"))
+	templates, err := alice.ListTemplates(ctx)
+	require.NoError(t, err)
+	require.Len(t, templates, 1)
+	assert.Equal(t, "Wi-Fi", templates[0].Name)
+}
+
+func TestEmergencyService_GrantKeyPins(t *testing.T) {
+	server := &emergencyServer{
+		shareServer: shareServer{keys: map[string]*models.UserKeys{}, shares: map[string]models.ItemShare{}},
+	}
+	aliceEmergency, alice := newEmergencyUser(t, server, "alice", "alice-master")
+	newEmergencyUser(t, server, "bob", "bob-master")
+	newEmergencyUser(t, server, "carol", "carol-master")
+	ctx := context.Background()
+
+	pins, err := LoadKeyPins(filepath.Join(t.TempDir(), "known_keys.json"))
+	require.NoError(t, err)
+	alice.SetKeyPins(pins)
+	aliceEmergency.SetKeyPins(pins)
+
+	// The master key is wrapped only for a confirmed key.
+	err = aliceEmergency.Grant(ctx, "bob", models.EmergencyAccessVIEW, time.Hour)
+	assert.ErrorIs(t, err, errs.ErrKeyNotVerified)
+	assert.Empty(t, server.access.WrappedKey)
+
+	fingerprint, err := alice.RecipientKey(ctx, "bob")
+	assert.ErrorIs(t, err, errs.ErrKeyNotVerified)
+	require.NoError(t, alice.VerifyRecipientKey(ctx, "bob", fingerprint))
+	require.NoError(t, aliceEmergency.Grant(ctx, "bob", models.EmergencyAccessVIEW, time.Hour))
+	assert.NotEmpty(t, server.access.WrappedKey)
+
+	// A key the server swaps in is refused.
+	server.access = models.EmergencyAccess{}
+	server.keys["bob"] = server.keys["carol"]
+	err = aliceEmergency.Grant(ctx, "bob", models.EmergencyAccessTAKEOVER, time.Hour)
+	assert.ErrorIs(t, err, errs.ErrKeyChanged)
+	assert.Empty(t, server.access.WrappedKey)
+}
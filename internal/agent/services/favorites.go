@@ -0,0 +1,111 @@
+package services
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"gophkeeper/internal/telemetry"
+	"gophkeeper/models"
+	"slices"
+	"strings"
+	"time"
+)
+
+var errFavoriteCollectionItem = errors.New("favorites are only kept for personal items")
+
+// SortMode orders the item list. Favorites always come first.
+type SortMode int
+
+const (
+	SortRecent SortMode = iota
+	SortName
+	SortMostUsed
+	SortCreated
+)
+
+var SortModes = []SortMode{SortRecent, SortName, SortMostUsed, SortCreated}
+
+func (m SortMode) String() string {
+	switch m {
+	case SortName:
+		return "name"
+	case SortMostUsed:
+		return "most used"
+	case SortCreated:
+		return "created"
+	default:
+		return "recent"
+	}
+}
+
+// Next returns the mode after m, wrapping around.
+func (m SortMode) Next() SortMode {
+	return SortModes[(int(m)+1)%len(SortModes)]
+}
+
+// SortItems orders items in place, favorites first and then by the mode.
+// Names are encrypted on the server, so this is done locally.
+func SortItems(items []models.EncryptedItem, mode SortMode) {
+	slices.SortStableFunc(items, func(a, b models.EncryptedItem) int {
+		if a.Favorite != b.Favorite {
+			if a.Favorite {
+				return -1
+			}
+			return 1
+		}
+		switch mode {
+		case SortName:
+			return cmp.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+		case SortMostUsed:
+			if c := cmp.Compare(b.UseCount, a.UseCount); c != 0 {
+				return c
+			}
+			return compareRecent(a, b)
+		case SortCreated:
+			return b.CreatedAt.Compare(a.CreatedAt)
+		default:
+			return compareRecent(a, b)
+		}
+	})
+}
+
+// compareRecent puts recently used items first and never used ones after
+// them, newest first.
+func compareRecent(a, b models.EncryptedItem) int {
+	if c := b.LastUsedAt.Compare(a.LastUsedAt); c != 0 {
+		return c
+	}
+	return b.CreatedAt.Compare(a.CreatedAt)
+}
+
+// TouchItem records that the item was used. Usage is only tracked for
+// personal items, collection items are shared by the organization.
+func (is *ItemService) TouchItem(ctx context.Context, item *models.EncryptedItem) (err error) {
+	if item.CollectionID != [16]byte{} {
+		return nil
+	}
+	ctx, span := tracer.Start(ctx, "ItemService.TouchItem")
+	defer telemetry.End(span, &err)
+
+	if err := is.Client.TouchItem(ctx, item.ID); err != nil {
+		return err
+	}
+	item.LastUsedAt = time.Now()
+	item.UseCount++
+	return nil
+}
+
+// SetItemFavorite pins the personal item to the top of the list or unpins it.
+func (is *ItemService) SetItemFavorite(ctx context.Context, item *models.EncryptedItem, favorite bool) (err error) {
+	if item.CollectionID != [16]byte{} {
+		return errFavoriteCollectionItem
+	}
+	ctx, span := tracer.Start(ctx, "ItemService.SetItemFavorite")
+	defer telemetry.End(span, &err)
+
+	if err := is.Client.SetItemFavorite(ctx, item.ID, favorite); err != nil {
+		return err
+	}
+	item.Favorite = favorite
+	return nil
+}
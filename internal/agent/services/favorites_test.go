@@ -0,0 +1,82 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"gophkeeper/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type usageClient struct {
+	MockClient
+	touched   [][16]byte
+	favorites map[[16]byte]bool
+}
+
+func (c *usageClient) TouchItem(ctx context.Context, itemID [16]byte) error {
+	c.touched = append(c.touched, itemID)
+	return nil
+}
+
+func (c *usageClient) SetItemFavorite(ctx context.Context, itemID [16]byte, favorite bool) error {
+	c.favorites[itemID] = favorite
+	return nil
+}
+
+func TestSortItems(t *testing.T) {
+	now := time.Now()
+	items := []models.EncryptedItem{
+		{Name: "bank", CreatedAt: now.Add(-3 * time.Hour), LastUsedAt: now.Add(-time.Hour), UseCount: 9},
+		{Name: "Mail", CreatedAt: now.Add(-time.Hour)},
+		{Name: "wifi", CreatedAt: now.Add(-2 * time.Hour), LastUsedAt: now, UseCount: 1},
+		{Name: "vpn", CreatedAt: now.Add(-4 * time.Hour), Favorite: true},
+	}
+	names := func() []string {
+		var n []string
+		for _, item := range items {
+			n = append(n, item.Name)
+		}
+		return n
+	}
+
+	SortItems(items, SortRecent)
+	assert.Equal(t, []string{"vpn", "wifi", "bank", "Mail"}, names())
+	SortItems(items, SortName)
+	assert.Equal(t, []string{"vpn", "bank", "Mail", "wifi"}, names())
+	SortItems(items, SortMostUsed)
+	assert.Equal(t, []string{"vpn", "bank", "wifi", "Mail"}, names())
+	SortItems(items, SortCreated)
+	assert.Equal(t, []string{"vpn", "Mail", "wifi", "bank"}, names())
+}
+
+func TestSortMode_Next(t *testing.T) {
+	mode := SortRecent
+	for range SortModes {
+		mode = mode.Next()
+	}
+	assert.Equal(t, SortRecent, mode)
+	assert.Equal(t, "most used", SortName.Next().String())
+}
+
+func TestItemService_ItemUsage(t *testing.T) {
+	client := &usageClient{favorites: map[[16]byte]bool{}}
+	is := &ItemService{Client: client}
+	ctx := context.Background()
+
+	item := &models.EncryptedItem{ID: [16]byte{1}}
+	assert.NoError(t, is.TouchItem(ctx, item))
+	assert.Equal(t, int32(1), item.UseCount)
+	assert.False(t, item.LastUsedAt.IsZero())
+	assert.NoError(t, is.SetItemFavorite(ctx, item, true))
+	assert.True(t, item.Favorite)
+	assert.True(t, client.favorites[item.ID])
+
+	// Collection items are shared, their usage is not tracked.
+	shared := &models.EncryptedItem{ID: [16]byte{2}, CollectionID: [16]byte{9}}
+	assert.NoError(t, is.TouchItem(ctx, shared))
+	assert.ErrorIs(t, is.SetItemFavorite(ctx, shared, true), errFavoriteCollectionItem)
+	assert.Equal(t, [][16]byte{item.ID}, client.touched)
+}
@@ -0,0 +1,63 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"gophkeeper/internal/errs"
+	"gophkeeper/internal/telemetry"
+	"gophkeeper/models"
+	"path/filepath"
+	"strings"
+)
+
+var errNotAnImage = errors.New("document scans must be images")
+
+// SetIdentityImages uploads the front and back scans of an identity
+// document as attachments of the item and links them from its data. An
+// empty path keeps the current image, a replaced image is deleted.
+func (is *ItemService) SetIdentityImages(ctx context.Context, item *models.Item, front, back string) (err error) {
+	ctx, span := tracer.Start(ctx, "ItemService.SetIdentityImages")
+	defer telemetry.End(span, &err)
+
+	data, ok := item.Data.(*models.Identity)
+	if !ok {
+		return fmt.Errorf("%s items have no document images", item.Type)
+	}
+
+	var replaced [][16]byte
+	for _, side := range []struct {
+		path string
+		id   *[16]byte
+	}{{front, &data.FrontImage}, {back, &data.BackImage}} {
+		if side.path == "" {
+			continue
+		}
+		content, err := readAttachmentFile(side.path)
+		if err != nil {
+			return err
+		}
+		mimeType := detectMimeType(side.path, content)
+		if !strings.HasPrefix(mimeType, "image/") {
+			return fmt.Errorf("%s: %w", filepath.Base(side.path), errNotAnImage)
+		}
+		id, err := is.addAttachment(ctx, item, filepath.Base(side.path), mimeType, content)
+		if err != nil {
+			return err
+		}
+		if *side.id != ([16]byte{}) {
+			replaced = append(replaced, *side.id)
+		}
+		*side.id = id
+	}
+
+	if err := is.EditItem(ctx, item); err != nil {
+		return err
+	}
+	for _, id := range replaced {
+		if err := is.Client.DeleteAttachment(ctx, item.ID, id); err != nil && !errors.Is(err, errs.ErrAttachmentNotFound) {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package services
+
+import (
+	"context"
+	"gophkeeper/models"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pngHeader is enough for the content type to be detected as an image.
+var pngHeader = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+func TestItemService_SetIdentityImages(t *testing.T) {
+	is, client, item := newAttachmentItem(t)
+	ctx := context.Background()
+	item.Type = models.ItemTypeIDENTITY
+	item.Data = &models.Identity{DocumentType: "passport", Number: "X1234567"}
+
+	dir := t.TempDir()
+	front := filepath.Join(dir, "front.png")
+	back := filepath.Join(dir, "back.scan")
+	notes := filepath.Join(dir, "notes.txt")
+	require.NoError(t, os.WriteFile(front, pngHeader, 0o600))
+	require.NoError(t, os.WriteFile(back, pngHeader, 0o600))
+	require.NoError(t, os.WriteFile(notes, []byte("not an image"), 0o600))
+
+	assert.ErrorIs(t, is.SetIdentityImages(ctx, item, notes, ""), errNotAnImage)
+
+	require.NoError(t, is.SetIdentityImages(ctx, item, front, back))
+	data := item.Data.(*models.Identity)
+	assert.NotEqual(t, [16]byte{}, data.FrontImage)
+	assert.NotEqual(t, [16]byte{}, data.BackImage)
+	require.NotNil(t, client.server.edited)
+
+	files, err := is.ListAttachments(ctx, item)
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+	assert.Equal(t, "image/png", files[1].MimeType)
+
+	assert.Error(t, is.SetIdentityImages(ctx, &models.Item{Type: models.ItemTypeTEXT, Data: &models.Text{}}, front, ""))
+}
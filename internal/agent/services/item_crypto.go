@@ -1,6 +1,7 @@
 package services
 
 import (
+	"context"
 	"crypto/aes"
 	"crypto/cipher"
 	"crypto/rand"
@@ -8,8 +9,12 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"gophkeeper/internal/telemetry"
 	"gophkeeper/models"
 	"io"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const (
@@ -17,7 +22,10 @@ const (
 )
 
 // EncryptItem encrypts client Item into EncryptedItem
-func (cs *CryptoService) encryptItem(item *models.Item) (*models.EncryptedItem, error) {
+func (cs *CryptoService) encryptItem(ctx context.Context, item *models.Item) (_ *models.EncryptedItem, err error) {
+	ctx, span := tracer.Start(ctx, "CryptoService.encryptItem", trace.WithAttributes(attribute.String("item.type", string(item.Type))))
+	defer telemetry.End(span, &err)
+
 	mp, err := cs.cnfg.GetMasterPassword()
 	if err != nil {
 		return nil, err
@@ -34,24 +42,127 @@ func (cs *CryptoService) encryptItem(item *models.Item) (*models.EncryptedItem,
 		return nil, errors.New("user salt not set")
 	}
 
-	encryptedData, err := cs.encryptItemData(item.Data)
+	// Every item is encrypted with its own data key so it can be shared.
+	// Legacy items without one get it on the next save.
+	encryptedKey := item.EncryptedKey
+	var dataKey []byte
+	if encryptedKey == "" {
+		dataKey, encryptedKey, err = cs.newItemKey(ctx)
+	} else {
+		dataKey, err = cs.openItemKey(ctx, encryptedKey)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get item key: %w", err)
+	}
+
+	encItem, err := sealItem(dataKey, encryptedKey, item)
+	if err != nil {
+		return nil, err
+	}
+	encItem.SearchTokens, err = cs.searchTokens(ctx, item)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute search tokens: %w", err)
+	}
+	return encItem, nil
+}
+
+// encryptCollectionItem encrypts an organization item. Its data key is sealed
+// with the collection key, so every member of the organization can open it.
+func encryptCollectionItem(collectionKey []byte, item *models.Item) (*models.EncryptedItem, error) {
+	encryptedKey := item.EncryptedKey
+	var dataKey []byte
+	var err error
+	if encryptedKey == "" {
+		dataKey, err = newRandomKey()
+		if err == nil {
+			encryptedKey, err = sealKey(collectionKey, dataKey)
+		}
+	} else {
+		dataKey, err = openKey(collectionKey, encryptedKey)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get item key: %w", err)
+	}
+
+	return sealItem(dataKey, encryptedKey, item)
+}
+
+// itemPayload is the plaintext of the data of V2 and V3 items, so the server
+// only stores opaque blobs. V2 items keep the name and metadata in it, V3
+// items in an itemHeader.
+type itemPayload struct {
+	Name string          `json:"name,omitempty"`
+	Meta *models.Meta    `json:"meta,omitempty"`
+	Data json.RawMessage `json:"data"`
+
+	// Version is the schema version of Data, items written before it was
+	// recorded have none and are version 1.
+	Version int `json:"version,omitempty"`
+}
+
+// itemHeader is the plaintext of the header of V3 items, it is all a list
+// of items opens.
+type itemHeader struct {
+	Name string      `json:"name"`
+	Meta models.Meta `json:"meta"`
+}
+
+// sealItem encrypts the item with its data key in the V3 format.
+func sealItem(dataKey []byte, encryptedKey string, item *models.Item) (*models.EncryptedItem, error) {
+	data, err := json.Marshal(item.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal item data: %w", err)
+	}
+
+	info, ok := models.LookupType(item.Type)
+	if !ok {
+		return nil, fmt.Errorf("unknown item type: %s", item.Type)
+	}
+
+	encryptedHeader, err := encryptWithKey(dataKey, itemHeader{Name: item.Name, Meta: item.Meta})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt item header: %w", err)
+	}
+	encryptedData, err := encryptWithKey(dataKey, itemPayload{Data: data, Version: info.Version})
 	if err != nil {
 		return nil, fmt.Errorf("failed to encrypt item data: %w", err)
 	}
 
 	return &models.EncryptedItem{
-		ID:            item.ID,
-		UserLogin:     item.UserLogin,
-		Name:          item.Name,
-		Type:          item.Type,
-		EncryptedData: *encryptedData,
-		Meta:          item.Meta,
-		CreatedAt:     item.CreatedAt,
-		UpdatedAt:     item.UpdatedAt,
+		ID:              item.ID,
+		UserLogin:       item.UserLogin,
+		Type:            item.Type,
+		EncryptedData:   *encryptedData,
+		EncryptedKey:    encryptedKey,
+		CollectionID:    item.CollectionID,
+		CreatedAt:       item.CreatedAt,
+		UpdatedAt:       item.UpdatedAt,
+		Format:          models.ItemFormatV3,
+		EncryptedHeader: *encryptedHeader,
+		ExpiresAt:       item.ExpiresAt,
 	}, nil
 }
 
-func (cs *CryptoService) decryptItem(encryptedItem *models.EncryptedItem) (*models.Item, error) {
+func openHeader(key []byte, encryptedItem *models.EncryptedItem) (*itemHeader, error) {
+	var header itemHeader
+	if err := decryptWithKey(key, &encryptedItem.EncryptedHeader, &header); err != nil {
+		return nil, fmt.Errorf("failed to decrypt item header: %w", err)
+	}
+	return &header, nil
+}
+
+func decryptCollectionItem(collectionKey []byte, encryptedItem *models.EncryptedItem) (*models.Item, error) {
+	dataKey, err := openKey(collectionKey, encryptedItem.EncryptedKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get item key: %w", err)
+	}
+	return decryptItemWithKey(dataKey, encryptedItem)
+}
+
+func (cs *CryptoService) decryptItem(ctx context.Context, encryptedItem *models.EncryptedItem) (_ *models.Item, err error) {
+	ctx, span := tracer.Start(ctx, "CryptoService.decryptItem", trace.WithAttributes(attribute.String("item.type", string(encryptedItem.Type))))
+	defer telemetry.End(span, &err)
+
 	mp, err := cs.cnfg.GetMasterPassword()
 	if err != nil {
 		return nil, err
@@ -67,68 +178,119 @@ func (cs *CryptoService) decryptItem(encryptedItem *models.EncryptedItem) (*mode
 		return nil, errors.New("user salt not set")
 	}
 
+	if encryptedItem.EncryptedKey == "" {
+		mk, err := cs.masterKey(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return decryptItemWithKey(mk, encryptedItem)
+	}
+
+	dataKey, err := cs.openItemKey(ctx, encryptedItem.EncryptedKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get item key: %w", err)
+	}
+	return decryptItemWithKey(dataKey, encryptedItem)
+}
+
+func decryptItemWithKey(key []byte, encryptedItem *models.EncryptedItem) (*models.Item, error) {
 	// Create instance of correct data type
 	data, err := encryptedItem.Type.CreateDataByType()
 	if err != nil {
 		return nil, err
 	}
 
-	// Decrypt data
-	err = cs.decryptData(&encryptedItem.EncryptedData, data)
-	if err != nil {
-		return nil, fmt.Errorf("failed to decrypt item data: %w", err)
+	var payload itemPayload
+	switch encryptedItem.Format {
+	case models.ItemFormatV2, models.ItemFormatV3:
+		if err := decryptWithKey(key, &encryptedItem.EncryptedData, &payload); err != nil {
+			return nil, fmt.Errorf("failed to decrypt item data: %w", err)
+		}
+		if info, _ := models.LookupType(encryptedItem.Type); payload.Version > info.Version {
+			return nil, fmt.Errorf("%s item has data version %d, this agent reads up to %d, please update it", encryptedItem.Type, payload.Version, info.Version)
+		}
+		if err := json.Unmarshal(payload.Data, data); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal item data: %w", err)
+		}
+	default:
+		if err := decryptWithKey(key, &encryptedItem.EncryptedData, data); err != nil {
+			return nil, fmt.Errorf("failed to decrypt item data: %w", err)
+		}
+	}
+
+	name, meta := encryptedItem.Name, encryptedItem.Meta
+	switch encryptedItem.Format {
+	case models.ItemFormatV2:
+		name = payload.Name
+		if payload.Meta != nil {
+			meta = *payload.Meta
+		}
+	case models.ItemFormatV3:
+		header, err := openHeader(key, encryptedItem)
+		if err != nil {
+			return nil, err
+		}
+		name, meta = header.Name, header.Meta
 	}
 
 	return &models.Item{
-		ID:        encryptedItem.ID,
-		UserLogin: encryptedItem.UserLogin,
-		Name:      encryptedItem.Name,
-		Type:      encryptedItem.Type,
-		Data:      data,
-		Meta:      encryptedItem.Meta,
-		CreatedAt: encryptedItem.CreatedAt,
-		UpdatedAt: encryptedItem.UpdatedAt,
+		ID:           encryptedItem.ID,
+		UserLogin:    encryptedItem.UserLogin,
+		Name:         name,
+		Type:         encryptedItem.Type,
+		Data:         data,
+		Meta:         meta,
+		EncryptedKey: encryptedItem.EncryptedKey,
+		CollectionID: encryptedItem.CollectionID,
+		CreatedAt:    encryptedItem.CreatedAt,
+		UpdatedAt:    encryptedItem.UpdatedAt,
+		ExpiresAt:    encryptedItem.ExpiresAt,
 	}, nil
 }
 
-func (cs *CryptoService) encryptItemData(data models.Data) (*models.EncryptedData, error) {
-	// Serialize data to JSON
-	jsonData, err := json.Marshal(data)
+func (cs *CryptoService) encryptItemData(ctx context.Context, data models.Data) (*models.EncryptedData, error) {
+	mk, err := cs.masterKey(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal data: %w", err)
+		return nil, err
+	}
+	return encryptWithKey(mk, data)
+}
+
+func (cs *CryptoService) decryptData(ctx context.Context, encryptedData *models.EncryptedData, result models.Data) error {
+	mk, err := cs.masterKey(ctx)
+	if err != nil {
+		return err
 	}
+	return decryptWithKey(mk, encryptedData, result)
+}
 
+// masterKey returns the cached master key, deriving it on first use.
+func (cs *CryptoService) masterKey(ctx context.Context) ([]byte, error) {
 	mk, err := cs.cnfg.GetMasterKey()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get master key: %w", err)
 	}
 	if len(mk) == 0 {
-		if _, err := cs.generateMasterKey(); err != nil {
+		mk, err = cs.generateMasterKey(ctx)
+		if err != nil {
 			return nil, fmt.Errorf("failed to generate master key: %w", err)
 		}
 	}
+	return mk, nil
+}
 
-	// Create AES cipher
-	block, err := aes.NewCipher(mk)
+func encryptWithKey(key []byte, data any) (*models.EncryptedData, error) {
+	// Serialize data to JSON
+	jsonData, err := json.Marshal(data)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create cipher: %w", err)
+		return nil, fmt.Errorf("failed to marshal data: %w", err)
 	}
 
-	// Create GCM mode
-	gcm, err := cipher.NewGCM(block)
+	ciphertext, nonce, err := seal(key, jsonData)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create GCM: %w", err)
-	}
-
-	// Generate random nonce
-	nonce := make([]byte, nonceSize)
-	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
-		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+		return nil, err
 	}
 
-	// Encrypt data
-	ciphertext := gcm.Seal(nil, nonce, jsonData, nil)
-
 	// Return EncryptedData with Base64 encoding
 	return &models.EncryptedData{
 		EncryptedContent: base64.StdEncoding.EncodeToString(ciphertext),
@@ -136,7 +298,7 @@ func (cs *CryptoService) encryptItemData(data models.Data) (*models.EncryptedDat
 	}, nil
 }
 
-func (cs *CryptoService) decryptData(encryptedData *models.EncryptedData, result models.Data) error {
+func decryptWithKey(key []byte, encryptedData *models.EncryptedData, result any) error {
 	// Decode Base64
 	ciphertext, err := base64.StdEncoding.DecodeString(encryptedData.EncryptedContent)
 	if err != nil {
@@ -148,40 +310,59 @@ func (cs *CryptoService) decryptData(encryptedData *models.EncryptedData, result
 		return fmt.Errorf("failed to decode nonce: %w", err)
 	}
 
-	// Use cached key
-	mk, err := cs.cnfg.GetMasterKey()
+	plaintext, err := open(key, nonce, ciphertext)
 	if err != nil {
-		return fmt.Errorf("failed to get master key: %w", err)
+		return err
 	}
-	if len(mk) == 0 {
-		_, err := cs.generateMasterKey()
-		if err != nil {
-			return fmt.Errorf("failed to generate master key: %w", err)
-		}
+
+	// Deserialize JSON into result object
+	if err := json.Unmarshal(plaintext, result); err != nil {
+		return fmt.Errorf("failed to unmarshal decrypted data: %w", err)
 	}
 
-	// Create AES cipher
-	block, err := aes.NewCipher(mk)
+	return nil
+}
+
+// seal encrypts plaintext with AES-GCM under a random nonce.
+func seal(key, plaintext []byte) (ciphertext, nonce []byte, err error) {
+	gcm, err := newGCM(key)
 	if err != nil {
-		return fmt.Errorf("failed to create cipher: %w", err)
+		return nil, nil, err
 	}
 
-	// Create GCM mode
-	gcm, err := cipher.NewGCM(block)
+	// Generate random nonce
+	nonce = make([]byte, nonceSize)
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	return gcm.Seal(nil, nonce, plaintext, nil), nonce, nil
+}
+
+func open(key, nonce, ciphertext []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
 	if err != nil {
-		return fmt.Errorf("failed to create GCM: %w", err)
+		return nil, err
 	}
 
-	// Decrypt data
 	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
 	if err != nil {
-		return fmt.Errorf("failed to decrypt data: %w", err)
+		return nil, fmt.Errorf("failed to decrypt data: %w", err)
 	}
+	return plaintext, nil
+}
 
-	// Deserialize JSON into result object
-	if err := json.Unmarshal(plaintext, result); err != nil {
-		return fmt.Errorf("failed to unmarshal decrypted data: %w", err)
+func newGCM(key []byte) (cipher.AEAD, error) {
+	// Create AES cipher
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
 	}
 
-	return nil
+	// Create GCM mode
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GCM: %w", err)
+	}
+	return gcm, nil
 }
@@ -1,11 +1,13 @@
 package services
 
 import (
+	"context"
 	"gophkeeper/config"
 	"gophkeeper/models"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestCryptoService_EncryptItem_EmptyMasterPassword(t *testing.T) {
@@ -20,7 +22,7 @@ func TestCryptoService_EncryptItem_EmptyMasterPassword(t *testing.T) {
 
 	item := &models.Item{}
 
-	err = itemService.AddItem(nil, item)
+	err = itemService.AddItem(context.Background(), item)
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "master password")
@@ -46,3 +48,220 @@ func TestNewItemService(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, itemService)
 }
+
+func TestCryptoService_EncryptItem_HidesNameAndMeta(t *testing.T) {
+	ctx := context.Background()
+	_, is := newShareUser(t, &shareServer{keys: map[string]*models.UserKeys{}}, "alice", "alice-master")
+
+	item := &models.Item{
+		ID:   [16]byte{1},
+		Name: "bank",
+		Type: models.ItemTypeTEXT,
+		Data: &models.Text{Content: "pin 1234"},
+		Meta: models.Meta{Map: map[string]string{"url": "bank.example"}},
+	}
+	encItem, err := is.Crypto.encryptItem(ctx, item)
+	require.NoError(t, err)
+	assert.Equal(t, models.ItemFormatV3, encItem.Format)
+	assert.Empty(t, encItem.Name)
+	assert.Empty(t, encItem.Meta.Map)
+	assert.NotEmpty(t, encItem.EncryptedHeader.EncryptedContent)
+
+	got, err := is.Crypto.decryptItem(ctx, encItem)
+	require.NoError(t, err)
+	assert.Equal(t, "bank", got.Name)
+	assert.Equal(t, item.Meta, got.Meta)
+	assert.Equal(t, "pin 1234", got.Data.(*models.Text).Content)
+}
+
+func TestDecryptItemWithKey_NewerVersion(t *testing.T) {
+	key := make([]byte, 32)
+	item := &models.Item{Type: models.ItemTypeTEXT, Data: &models.Text{Content: "x"}}
+	encItem, err := sealItem(key, "", item)
+	require.NoError(t, err)
+	_, err = decryptItemWithKey(key, encItem)
+	require.NoError(t, err)
+
+	payload := itemPayload{Name: "future", Data: []byte(`{"Content":"x"}`), Version: 2}
+	encData, err := encryptWithKey(key, payload)
+	require.NoError(t, err)
+	encItem.EncryptedData = *encData
+	_, err = decryptItemWithKey(key, encItem)
+	assert.ErrorContains(t, err, "data version 2")
+}
+
+// itemsClient serves a fixed item list on top of shareClient.
+type itemsClient struct {
+	shareClient
+	items []models.EncryptedItem
+}
+
+func (c *itemsClient) GetItems(ctx context.Context, login string, typ models.ItemType) ([]models.EncryptedItem, error) {
+	var items []models.EncryptedItem
+	for _, item := range c.items {
+		if item.CollectionID == ([16]byte{}) {
+			items = append(items, item)
+		}
+	}
+	return items, nil
+}
+
+func (c *itemsClient) GetCollectionItems(ctx context.Context, collectionID [16]byte, typ models.ItemType) ([]models.EncryptedItem, error) {
+	var items []models.EncryptedItem
+	for _, item := range c.items {
+		if item.CollectionID == collectionID {
+			items = append(items, item)
+		}
+	}
+	return items, nil
+}
+
+func TestItemService_UpgradeItems(t *testing.T) {
+	server := &shareServer{keys: map[string]*models.UserKeys{}}
+	_, is := newShareUser(t, server, "alice", "alice-master")
+	ctx := context.Background()
+
+	current, err := is.Crypto.encryptItem(ctx, &models.Item{ID: [16]byte{1}, Name: "bank", Type: models.ItemTypeTEXT, Data: &models.Text{Content: "pin"}})
+	require.NoError(t, err)
+	legacyData, err := is.Crypto.encryptItemData(ctx, &models.Text{Content: "old note"})
+	require.NoError(t, err)
+	legacy := models.EncryptedItem{
+		ID: [16]byte{2}, UserLogin: "alice", Name: "note", Type: models.ItemTypeTEXT,
+		EncryptedData: *legacyData, Meta: models.Meta{Map: map[string]string{"tag": "x"}}, Format: models.ItemFormatV1,
+	}
+
+	client := &itemsClient{shareClient: shareClient{login: "alice", server: server}, items: []models.EncryptedItem{*current, legacy}}
+	is.Client = client
+
+	items, err := is.GetItems(ctx, "alice", models.ItemTypeUNSPECIFIED)
+	require.NoError(t, err)
+	assert.Equal(t, "bank", items[0].Name)
+	assert.Equal(t, "note", items[1].Name)
+
+	n, err := is.UpgradeItems(ctx, "alice")
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+
+	upgraded := server.edited
+	require.NotNil(t, upgraded)
+	assert.Equal(t, models.ItemFormatV3, upgraded.Format)
+	assert.Empty(t, upgraded.Name)
+	assert.NotEmpty(t, upgraded.EncryptedKey)
+
+	got, err := is.DecryptItem(ctx, upgraded)
+	require.NoError(t, err)
+	assert.Equal(t, "note", got.Name)
+	assert.Equal(t, "x", got.Meta.Map["tag"])
+	assert.Equal(t, "old note", got.Data.(*models.Text).Content)
+}
+
+func TestItemService_GetItems_OpensOnlyHeaders(t *testing.T) {
+	server := &shareServer{keys: map[string]*models.UserKeys{}}
+	_, is := newShareUser(t, server, "alice", "alice-master")
+	ctx := context.Background()
+
+	current, err := is.Crypto.encryptItem(ctx, &models.Item{ID: [16]byte{1}, Name: "bank", Type: models.ItemTypeTEXT, Data: &models.Text{Content: "pin"}})
+	require.NoError(t, err)
+	// The data is not opened, so a damaged one does not fail the list.
+	current.EncryptedData.EncryptedContent = "damaged"
+
+	// V2 items have no header, they are still decrypted whole.
+	dataKey, sealedKey, err := is.Crypto.newItemKey(ctx)
+	require.NoError(t, err)
+	v2Data, err := encryptWithKey(dataKey, itemPayload{Name: "note", Meta: &models.Meta{Map: map[string]string{"tag": "x"}}, Data: []byte(`{"Content":"old"}`)})
+	require.NoError(t, err)
+	v2 := models.EncryptedItem{
+		ID: [16]byte{2}, UserLogin: "alice", Type: models.ItemTypeTEXT,
+		EncryptedData: *v2Data, EncryptedKey: sealedKey, Format: models.ItemFormatV2,
+	}
+
+	is.Client = &itemsClient{shareClient: shareClient{login: "alice", server: server}, items: []models.EncryptedItem{*current, v2}}
+	items, err := is.GetItems(ctx, "alice", models.ItemTypeUNSPECIFIED)
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+	assert.Equal(t, "bank", items[0].Name)
+	assert.Equal(t, "note", items[1].Name)
+	assert.Equal(t, "x", items[1].Meta.Map["tag"])
+
+	_, err = is.DecryptItem(ctx, &items[0])
+	assert.Error(t, err)
+}
+
+func TestItemService_UpgradeItems_Partial(t *testing.T) {
+	server := &shareServer{keys: map[string]*models.UserKeys{}}
+	_, is := newShareUser(t, server, "alice", "alice-master")
+	ctx := context.Background()
+
+	broken := models.EncryptedItem{
+		ID: [16]byte{1}, UserLogin: "alice", Type: models.ItemTypeTEXT, Format: models.ItemFormatV2,
+		EncryptedKey: "garbage", EncryptedData: models.EncryptedData{EncryptedContent: "x", Nonce: "y"},
+	}
+	// A legacy item that was shared already has a data key.
+	dataKey, sealedKey, err := is.Crypto.newItemKey(ctx)
+	require.NoError(t, err)
+	sharedData, err := encryptWithKey(dataKey, &models.Text{Content: "shared note"})
+	require.NoError(t, err)
+	shared := models.EncryptedItem{
+		ID: [16]byte{2}, UserLogin: "alice", Name: "note", Type: models.ItemTypeTEXT,
+		EncryptedData: *sharedData, EncryptedKey: sealedKey, Format: models.ItemFormatV1,
+	}
+
+	is.Client = &itemsClient{shareClient: shareClient{login: "alice", server: server}, items: []models.EncryptedItem{broken, shared}}
+
+	items, err := is.GetItems(ctx, "alice", models.ItemTypeUNSPECIFIED)
+	failed, err := SplitItemErrors(err)
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+	assert.Equal(t, "note", items[1].Name)
+	assert.Len(t, failed, 1)
+	assert.Error(t, failed[broken.ID])
+
+	n, err := is.UpgradeItems(ctx, "alice")
+	assert.Equal(t, 1, n)
+	failed, err = SplitItemErrors(err)
+	require.NoError(t, err)
+	assert.Contains(t, failed, broken.ID)
+
+	upgraded := server.edited
+	require.NotNil(t, upgraded)
+	assert.Equal(t, shared.ID, upgraded.ID)
+	assert.Equal(t, models.ItemFormatV3, upgraded.Format)
+	assert.Equal(t, sealedKey, upgraded.EncryptedKey)
+	got, err := decryptItemWithKey(dataKey, upgraded)
+	require.NoError(t, err)
+	assert.Equal(t, "note", got.Name)
+}
+
+func TestItemService_UpgradeItems_Collection(t *testing.T) {
+	server := &shareServer{keys: map[string]*models.UserKeys{}}
+	_, is := newShareUser(t, server, "alice", "alice-master")
+	ctx := context.Background()
+
+	collKey, err := newRandomKey()
+	require.NoError(t, err)
+	vault := &Vault{Collection: models.Collection{ID: [16]byte{9}, Name: "Shared"}, key: collKey}
+	dataKey, err := newRandomKey()
+	require.NoError(t, err)
+	sealedKey, err := sealKey(collKey, dataKey)
+	require.NoError(t, err)
+	data, err := encryptWithKey(dataKey, &models.Text{Content: "runbook"})
+	require.NoError(t, err)
+	legacy := models.EncryptedItem{
+		ID: [16]byte{3}, UserLogin: "bob", Name: "ops", Type: models.ItemTypeTEXT, CollectionID: vault.Collection.ID,
+		EncryptedData: *data, EncryptedKey: sealedKey, Format: models.ItemFormatV1,
+	}
+	is.Client = &itemsClient{shareClient: shareClient{login: "alice", server: server}, items: []models.EncryptedItem{legacy}}
+
+	n, err := is.UpgradeItems(ctx, "alice", vault)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+
+	upgraded := server.edited
+	require.NotNil(t, upgraded)
+	assert.Equal(t, models.ItemFormatV3, upgraded.Format)
+	assert.Empty(t, upgraded.Name)
+	got, err := decryptCollectionItem(collKey, upgraded)
+	require.NoError(t, err)
+	assert.Equal(t, "ops", got.Name)
+	assert.Equal(t, "runbook", got.Data.(*models.Text).Content)
+}
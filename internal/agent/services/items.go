@@ -2,14 +2,75 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"gophkeeper/internal/agent/client"
+	"gophkeeper/internal/errs"
+	"gophkeeper/internal/hibp"
+	"gophkeeper/internal/telemetry"
 	"gophkeeper/models"
+	"strings"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = telemetry.Tracer("gophkeeper/internal/agent/services")
+
+var (
+	errVaultNotOpen     = errors.New("collection vault is not open")
+	errEmptySearchQuery = errors.New("search query is empty")
+	errAmbiguousItem    = errors.New("several items have this name, use the item id")
 )
 
+// ItemErrors is returned with a list of items some of which could not be
+// decrypted, by item id. The list still holds them, with the name and
+// metadata the server has.
+type ItemErrors map[[16]byte]error
+
+func (e ItemErrors) Error() string {
+	return fmt.Sprintf("%d item(s) could not be decrypted", len(e))
+}
+
+func (e ItemErrors) err() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
+
+// SplitItemErrors separates the items that could not be decrypted from the
+// error of a list. The error it returns failed the whole list.
+func SplitItemErrors(err error) (ItemErrors, error) {
+	var failed ItemErrors
+	if errors.As(err, &failed) {
+		return failed, nil
+	}
+	return nil, err
+}
+
 type ItemService struct {
 	Client client.Client
 	Crypto *CryptoService
+
+	vault    *Vault
+	breaches hibp.Index
+	pins     *KeyPins
+}
+
+// Vault is an organization collection the item operations are bound to.
+// A nil vault means the user's personal items.
+type Vault struct {
+	Org        models.Organization
+	Collection models.Collection
+	key        []byte
+}
+
+// Wipe zeroes the collection key, OrgService.OpenVault opens the vault
+// again.
+func (v *Vault) Wipe() {
+	clear(v.key)
 }
 
 func NewItemService(client client.Client, cs *CryptoService) (*ItemService, error) {
@@ -19,34 +80,270 @@ func NewItemService(client client.Client, cs *CryptoService) (*ItemService, erro
 	}, nil
 }
 
-func (is *ItemService) AddItem(ctx context.Context, item *models.Item) error {
-	encItem, err := is.Crypto.encryptItem(item)
+// SetVault switches item operations to the collection vault, nil switches
+// back to personal items.
+func (is *ItemService) SetVault(v *Vault) {
+	is.vault = v
+}
+
+func (is *ItemService) Vault() *Vault {
+	return is.vault
+}
+
+func (is *ItemService) AddItem(ctx context.Context, item *models.Item) (err error) {
+	ctx, span := tracer.Start(ctx, "ItemService.AddItem", trace.WithAttributes(attribute.String("item.type", string(item.Type))))
+	defer telemetry.End(span, &err)
+
+	if is.vault != nil {
+		item.CollectionID = is.vault.Collection.ID
+	}
+	encItem, err := is.encryptItem(ctx, item)
 	if err != nil {
 		return fmt.Errorf("encrypt item error: %w", err)
 	}
 	return is.Client.AddItem(ctx, encItem)
 }
 
-func (is *ItemService) EditItem(ctx context.Context, item *models.Item) error {
-	encItem, err := is.Crypto.encryptItem(item)
+func (is *ItemService) EditItem(ctx context.Context, item *models.Item) (err error) {
+	ctx, span := tracer.Start(ctx, "ItemService.EditItem", trace.WithAttributes(attribute.String("item.type", string(item.Type))))
+	defer telemetry.End(span, &err)
+
+	encItem, err := is.encryptItem(ctx, item)
 	if err != nil {
 		return fmt.Errorf("encrypt item error: %w", err)
 	}
 	return is.Client.EditItem(ctx, encItem)
 }
 
-func (is *ItemService) DeleteItem(ctx context.Context, login string, itemID [16]byte) error {
+func (is *ItemService) DeleteItem(ctx context.Context, login string, itemID [16]byte) (err error) {
+	ctx, span := tracer.Start(ctx, "ItemService.DeleteItem")
+	defer telemetry.End(span, &err)
+
 	return is.Client.DeleteItem(ctx, login, itemID)
 }
 
-func (is *ItemService) GetItems(ctx context.Context, login string, typ models.ItemType) ([]models.EncryptedItem, error) {
-	return is.Client.GetItems(ctx, login, typ)
+func (is *ItemService) GetItems(ctx context.Context, login string, typ models.ItemType) (_ []models.EncryptedItem, err error) {
+	ctx, span := tracer.Start(ctx, "ItemService.GetItems", trace.WithAttributes(attribute.String("item.type", string(typ))))
+	defer telemetry.End(span, &err)
+
+	var items []models.EncryptedItem
+	if is.vault != nil {
+		items, err = is.Client.GetCollectionItems(ctx, is.vault.Collection.ID, typ)
+	} else {
+		items, err = is.Client.GetItems(ctx, login, typ)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return items, is.revealItems(ctx, items)
+}
+
+// FindItem returns the decrypted item of the type whose id or name, ignoring
+// case, matches ref.
+func (is *ItemService) FindItem(ctx context.Context, login string, typ models.ItemType, ref string) (*models.Item, error) {
+	items, err := is.GetItems(ctx, login, typ)
+	if _, err := SplitItemErrors(err); err != nil {
+		return nil, err
+	}
+
+	var found *models.EncryptedItem
+	id, idErr := uuid.Parse(ref)
+	for i := range items {
+		if idErr == nil && items[i].ID == id {
+			found = &items[i]
+			break
+		}
+		if strings.EqualFold(items[i].Name, ref) {
+			if found != nil {
+				return nil, errAmbiguousItem
+			}
+			found = &items[i]
+		}
+	}
+	if found == nil {
+		return nil, fmt.Errorf("%q: %w", ref, errs.ErrItemNotFound)
+	}
+	return is.DecryptItem(ctx, found)
+}
+
+// SearchItems returns items that match every word of the query. Personal
+// items are matched by the server on blind index tokens, vault items are
+// filtered locally.
+func (is *ItemService) SearchItems(ctx context.Context, login, query string) (_ []models.EncryptedItem, err error) {
+	ctx, span := tracer.Start(ctx, "ItemService.SearchItems")
+	defer telemetry.End(span, &err)
+
+	terms := queryTerms(query)
+	if len(terms) == 0 {
+		return nil, errEmptySearchQuery
+	}
+
+	if is.vault != nil {
+		items, err := is.GetItems(ctx, login, models.ItemTypeUNSPECIFIED)
+		failed, err := SplitItemErrors(err)
+		if err != nil {
+			return nil, err
+		}
+		var found []models.EncryptedItem
+		for _, item := range items {
+			if matchesTerms(&item, terms) {
+				found = append(found, item)
+			}
+		}
+		return found, failed.err()
+	}
+
+	tokens, err := is.Crypto.queryTokens(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	items, err := is.Client.SearchItems(ctx, tokens)
+	if err != nil {
+		return nil, err
+	}
+	return items, is.revealItems(ctx, items)
+}
+
+// revealItems fills in names and metadata of V2 and V3 items, the data stays
+// encrypted until the item is opened. An item that cannot be decrypted does
+// not hide the others, it is reported in ItemErrors.
+func (is *ItemService) revealItems(ctx context.Context, items []models.EncryptedItem) error {
+	failed := ItemErrors{}
+	for i := range items {
+		if err := is.revealItem(ctx, &items[i]); err != nil {
+			failed[items[i].ID] = fmt.Errorf("decrypt item error: %w", err)
+		}
+	}
+	return failed.err()
+}
+
+// revealItem opens only the header of a V3 item. V2 items keep the name in
+// the data, they are decrypted whole until UpgradeItems rewrites them.
+func (is *ItemService) revealItem(ctx context.Context, encItem *models.EncryptedItem) error {
+	switch encItem.Format {
+	case models.ItemFormatV2:
+		item, err := is.DecryptItem(ctx, encItem)
+		if err != nil {
+			return err
+		}
+		encItem.Name, encItem.Meta = item.Name, item.Meta
+	case models.ItemFormatV3:
+		key, err := is.itemKey(ctx, &models.Item{EncryptedKey: encItem.EncryptedKey, CollectionID: encItem.CollectionID})
+		if err != nil {
+			return fmt.Errorf("failed to get item key: %w", err)
+		}
+		header, err := openHeader(key, encItem)
+		if err != nil {
+			return err
+		}
+		encItem.Name, encItem.Meta = header.Name, header.Meta
+	}
+	return nil
+}
+
+// UpgradeItems re-encrypts items stored in an older format in the current
+// one: personal items, which also get search tokens, and the items of the
+// collection vaults. Shared items keep their data key, so
+// the recipients can still open them. An item that fails is skipped and
+// reported in ItemErrors. It returns the number of upgraded items.
+func (is *ItemService) UpgradeItems(ctx context.Context, login string, vaults ...*Vault) (int, error) {
+	items, err := is.Client.GetItems(ctx, login, models.ItemTypeUNSPECIFIED)
+	if err != nil {
+		return 0, err
+	}
+
+	upgraded := 0
+	failed := ItemErrors{}
+	for i := range items {
+		if items[i].Format == models.ItemFormatV3 && len(items[i].SearchTokens) > 0 {
+			continue
+		}
+		if err := is.upgradeItem(ctx, &items[i], nil); err != nil {
+			failed[items[i].ID] = err
+			continue
+		}
+		upgraded++
+	}
+
+	for _, vault := range vaults {
+		items, err := is.Client.GetCollectionItems(ctx, vault.Collection.ID, models.ItemTypeUNSPECIFIED)
+		if err != nil {
+			return upgraded, fmt.Errorf("get items of collection %s error: %w", vault.Collection.Name, err)
+		}
+		for i := range items {
+			if items[i].Format == models.ItemFormatV3 {
+				continue
+			}
+			if err := is.upgradeItem(ctx, &items[i], vault); err != nil {
+				failed[items[i].ID] = err
+				continue
+			}
+			upgraded++
+		}
+	}
+	return upgraded, failed.err()
+}
+
+// upgradeItem re-encrypts a personal item, or an item of vault.
+func (is *ItemService) upgradeItem(ctx context.Context, encItem *models.EncryptedItem, vault *Vault) error {
+	var item *models.Item
+	var err error
+	if vault == nil {
+		item, err = is.Crypto.decryptItem(ctx, encItem)
+	} else {
+		item, err = decryptCollectionItem(vault.key, encItem)
+	}
+	if err != nil {
+		return fmt.Errorf("decrypt item error: %w", err)
+	}
+
+	var upgraded *models.EncryptedItem
+	if vault == nil {
+		upgraded, err = is.Crypto.encryptItem(ctx, item)
+	} else {
+		upgraded, err = encryptCollectionItem(vault.key, item)
+	}
+	if err != nil {
+		return fmt.Errorf("encrypt item error: %w", err)
+	}
+	return is.Client.EditItem(ctx, upgraded)
 }
 
 func (is *ItemService) GetTypesCounts(ctx context.Context, login string) (map[string]int32, error) {
+	if is.vault != nil {
+		return is.Client.GetCollectionTypesCounts(ctx, is.vault.Collection.ID)
+	}
 	return is.Client.GetTypesCounts(ctx, login)
 }
 
-func (is *ItemService) DecryptItem(encItem *models.EncryptedItem) (*models.Item, error) {
-	return is.Crypto.decryptItem(encItem)
+func (is *ItemService) DecryptItem(ctx context.Context, encItem *models.EncryptedItem) (_ *models.Item, err error) {
+	ctx, span := tracer.Start(ctx, "ItemService.DecryptItem", trace.WithAttributes(attribute.String("item.type", string(encItem.Type))))
+	defer telemetry.End(span, &err)
+
+	if encItem.CollectionID == [16]byte{} {
+		return is.Crypto.decryptItem(ctx, encItem)
+	}
+	key, err := is.collectionKey(encItem.CollectionID)
+	if err != nil {
+		return nil, err
+	}
+	return decryptCollectionItem(key, encItem)
+}
+
+func (is *ItemService) encryptItem(ctx context.Context, item *models.Item) (*models.EncryptedItem, error) {
+	if item.CollectionID == [16]byte{} {
+		return is.Crypto.encryptItem(ctx, item)
+	}
+	key, err := is.collectionKey(item.CollectionID)
+	if err != nil {
+		return nil, err
+	}
+	return encryptCollectionItem(key, item)
+}
+
+func (is *ItemService) collectionKey(collectionID [16]byte) ([]byte, error) {
+	if is.vault == nil || is.vault.Collection.ID != collectionID {
+		return nil, errVaultNotOpen
+	}
+	return is.vault.key, nil
 }
@@ -2,10 +2,13 @@ package services
 
 import (
 	"context"
+	"gophkeeper/internal/errs"
 	"gophkeeper/models"
 	"testing"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestItemService_AddItem_NilService(t *testing.T) {
@@ -116,16 +119,18 @@ func TestItemService_GetTypesCounts_NilClient(t *testing.T) {
 }
 
 func TestItemService_DecryptItem_NilService(t *testing.T) {
+	ctx := context.Background()
 	var service *ItemService = nil
 
 	encItem := &models.EncryptedItem{}
 
 	assert.Panics(t, func() {
-		service.DecryptItem(encItem)
+		service.DecryptItem(ctx, encItem)
 	})
 }
 
 func TestItemService_DecryptItem_NilCrypto(t *testing.T) {
+	ctx := context.Background()
 	service := &ItemService{
 		Client: &MockClient{},
 		Crypto: nil,
@@ -134,21 +139,37 @@ func TestItemService_DecryptItem_NilCrypto(t *testing.T) {
 	encItem := &models.EncryptedItem{}
 
 	assert.Panics(t, func() {
-		service.DecryptItem(encItem)
+		service.DecryptItem(ctx, encItem)
 	})
 }
 
 // MockClient for testing
 type MockClient struct{}
 
-func (m *MockClient) SignUpUser(ctx context.Context, user *models.User) (token string, salt string, err error) {
+func (m *MockClient) SignUpUser(ctx context.Context, user *models.User, device *models.DeviceSignIn) (token string, salt string, err error) {
 	return "", "", nil
 }
 
-func (m *MockClient) SignInUser(ctx context.Context, user *models.User) (token string, salt string, err error) {
+func (m *MockClient) SignInUser(ctx context.Context, user *models.User, device *models.DeviceSignIn) (token string, salt string, err error) {
 	return "", "", nil
 }
 
+func (m *MockClient) ListDevices(ctx context.Context) ([]models.Device, bool, error) {
+	return nil, false, nil
+}
+
+func (m *MockClient) RemoveDevice(ctx context.Context, id [16]byte) error {
+	return nil
+}
+
+func (m *MockClient) ApproveDevice(ctx context.Context, id [16]byte) error {
+	return nil
+}
+
+func (m *MockClient) SetDeviceApproval(ctx context.Context, required bool) error {
+	return nil
+}
+
 func (m *MockClient) SetJWTToken(token string) error {
 	return nil
 }
@@ -180,3 +201,186 @@ func (m *MockClient) GetItems(ctx context.Context, login string, typ models.Item
 func (m *MockClient) GetTypesCounts(ctx context.Context, login string) (map[string]int32, error) {
 	return nil, nil
 }
+
+func (m *MockClient) SearchItems(ctx context.Context, tokens []string) ([]models.EncryptedItem, error) {
+	return nil, nil
+}
+
+func (m *MockClient) SetUserKeys(ctx context.Context, keys *models.UserKeys) error {
+	return nil
+}
+
+func (m *MockClient) GetUserKeys(ctx context.Context) (*models.UserKeys, error) {
+	return nil, nil
+}
+
+func (m *MockClient) GetPublicKey(ctx context.Context, login string) ([]byte, error) {
+	return nil, nil
+}
+
+func (m *MockClient) ShareItem(ctx context.Context, share *models.ItemShare) error {
+	return nil
+}
+
+func (m *MockClient) ListItemShares(ctx context.Context, itemID [16]byte) ([]models.ItemShare, error) {
+	return nil, nil
+}
+
+func (m *MockClient) ListSharedWithMe(ctx context.Context) ([]models.SharedItem, error) {
+	return nil, nil
+}
+
+func (m *MockClient) RevokeShare(ctx context.Context, rev *models.ShareRevocation) error {
+	return nil
+}
+
+func (m *MockClient) GetCollectionItems(ctx context.Context, collectionID [16]byte, typ models.ItemType) ([]models.EncryptedItem, error) {
+	return nil, nil
+}
+
+func (m *MockClient) GetCollectionTypesCounts(ctx context.Context, collectionID [16]byte) (map[string]int32, error) {
+	return nil, nil
+}
+
+func (m *MockClient) TouchItem(ctx context.Context, itemID [16]byte) error {
+	return nil
+}
+
+func (m *MockClient) SetItemFavorite(ctx context.Context, itemID [16]byte, favorite bool) error {
+	return nil
+}
+
+func (m *MockClient) UploadAttachment(ctx context.Context, a *models.Attachment) ([16]byte, error) {
+	return [16]byte{}, nil
+}
+
+func (m *MockClient) ListAttachments(ctx context.Context, itemID [16]byte) ([]models.Attachment, error) {
+	return nil, nil
+}
+
+func (m *MockClient) DownloadAttachment(ctx context.Context, itemID, attachmentID [16]byte) (*models.Attachment, error) {
+	return nil, nil
+}
+
+func (m *MockClient) DeleteAttachment(ctx context.Context, itemID, attachmentID [16]byte) error {
+	return nil
+}
+
+func (m *MockClient) SaveTemplate(ctx context.Context, t *models.Template) error {
+	return nil
+}
+
+func (m *MockClient) ListTemplates(ctx context.Context) ([]models.Template, error) {
+	return nil, nil
+}
+
+func (m *MockClient) DeleteTemplate(ctx context.Context, id [16]byte) error {
+	return nil
+}
+
+func (m *MockClient) CreateOrg(ctx context.Context, org *models.Organization, coll *models.Collection) (*models.Organization, error) {
+	return org, nil
+}
+
+func (m *MockClient) ListOrgs(ctx context.Context) ([]models.Organization, error) {
+	return nil, nil
+}
+
+func (m *MockClient) InviteMember(ctx context.Context, member *models.Membership) error {
+	return nil
+}
+
+func (m *MockClient) AcceptInvite(ctx context.Context, orgID [16]byte) error {
+	return nil
+}
+
+func (m *MockClient) RemoveMember(ctx context.Context, rot *models.OrgKeyRotation) error {
+	return nil
+}
+
+func (m *MockClient) RotateOrgKey(ctx context.Context, rot *models.OrgKeyRotation) error {
+	return nil
+}
+
+func (m *MockClient) ChangeRole(ctx context.Context, orgID [16]byte, login string, role models.OrgRole) error {
+	return nil
+}
+
+func (m *MockClient) ListMembers(ctx context.Context, orgID [16]byte) ([]models.Membership, error) {
+	return nil, nil
+}
+
+func (m *MockClient) CreateCollection(ctx context.Context, coll *models.Collection) (*models.Collection, error) {
+	return coll, nil
+}
+
+func (m *MockClient) ListCollections(ctx context.Context, orgID [16]byte) ([]models.Collection, error) {
+	return nil, nil
+}
+
+func (m *MockClient) GrantEmergencyAccess(ctx context.Context, access *models.EmergencyAccess) error {
+	return nil
+}
+
+func (m *MockClient) RevokeEmergencyAccess(ctx context.Context, grantee string) error {
+	return nil
+}
+
+func (m *MockClient) ListEmergencyAccess(ctx context.Context) (granted, trustedBy []models.EmergencyAccess, err error) {
+	return nil, nil, nil
+}
+
+func (m *MockClient) RequestEmergencyAccess(ctx context.Context, grantor string) error {
+	return nil
+}
+
+func (m *MockClient) ApproveEmergencyAccess(ctx context.Context, grantee string) error {
+	return nil
+}
+
+func (m *MockClient) DenyEmergencyAccess(ctx context.Context, grantee string) error {
+	return nil
+}
+
+func (m *MockClient) GetEmergencyVault(ctx context.Context, grantor string) (*models.EmergencyVault, error) {
+	return nil, nil
+}
+
+func (m *MockClient) TakeoverAccount(ctx context.Context, t *models.EmergencyTakeover) error {
+	return nil
+}
+
+func (m *MockClient) CreateSend(ctx context.Context, send *models.Send) (string, error) {
+	return "", nil
+}
+
+func TestItemService_FindItem(t *testing.T) {
+	server := &shareServer{keys: map[string]*models.UserKeys{}}
+	_, is := newShareUser(t, server, "alice", "alice-master")
+	client := &breachClient{searchClient{shareClient: shareClient{login: "alice", server: server}}}
+	is.Client = client
+	ctx := context.Background()
+
+	for _, item := range []*models.Item{
+		{ID: [16]byte{1}, Name: "GitHub", Type: models.ItemTypeTOTP, Data: &models.TOTP{Secret: "JBSWY3DPEHPK3PXP"}},
+		{ID: [16]byte{2}, Name: "Mail", Type: models.ItemTypeTOTP, Data: &models.TOTP{Secret: "AAAA"}},
+		{ID: [16]byte{3}, Name: "mail", Type: models.ItemTypeTOTP, Data: &models.TOTP{Secret: "BBBB"}},
+		{ID: [16]byte{4}, Name: "GitHub", Type: models.ItemTypeCREDENTIALS, Data: &models.Credentials{Login: "octocat"}},
+	} {
+		require.NoError(t, is.AddItem(ctx, item))
+	}
+
+	item, err := is.FindItem(ctx, "alice", models.ItemTypeTOTP, "github")
+	require.NoError(t, err)
+	assert.Equal(t, "JBSWY3DPEHPK3PXP", item.Data.(*models.TOTP).Secret)
+
+	_, err = is.FindItem(ctx, "alice", models.ItemTypeTOTP, "MAIL")
+	assert.ErrorIs(t, err, errAmbiguousItem)
+
+	item, err = is.FindItem(ctx, "alice", models.ItemTypeTOTP, uuid.UUID{3}.String())
+	require.NoError(t, err)
+	assert.Equal(t, "BBBB", item.Data.(*models.TOTP).Secret)
+
+	_, err = is.FindItem(ctx, "alice", models.ItemTypeTOTP, "Bank")
+	assert.ErrorIs(t, err, errs.ErrItemNotFound)
+}
@@ -0,0 +1,116 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"gophkeeper/internal/agent/client"
+	"gophkeeper/internal/errs"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// KeyPins remembers the sharing keys of the recipients the user verified,
+// by login. A key is trusted on first use once its fingerprint is
+// confirmed, a different key served later is refused, so the server cannot
+// swap in its own key to read what is shared.
+type KeyPins struct {
+	path string
+
+	mu   sync.Mutex
+	keys map[string][]byte
+}
+
+// LoadKeyPins reads the pinned keys from path, a missing file has none.
+func LoadKeyPins(path string) (*KeyPins, error) {
+	p := &KeyPins{path: path, keys: map[string][]byte{}}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return p, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read known keys file error: %w", err)
+	}
+	if err := json.Unmarshal(data, &p.keys); err != nil {
+		return nil, fmt.Errorf("parse known keys file error: %w", err)
+	}
+	return p, nil
+}
+
+// check reports whether pub is the key pinned for login.
+func (p *KeyPins) check(login string, pub []byte) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	pinned, ok := p.keys[login]
+	switch {
+	case !ok:
+		return fmt.Errorf("%s: %w", login, errs.ErrKeyNotVerified)
+	case !bytes.Equal(pinned, pub):
+		return fmt.Errorf("%s: %w", login, errs.ErrKeyChanged)
+	}
+	return nil
+}
+
+// pin trusts pub as the key of login from now on.
+func (p *KeyPins) pin(login string, pub []byte) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.keys[login] = pub
+
+	data, err := json.Marshal(p.keys)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(p.path), 0o700); err != nil {
+		return fmt.Errorf("create known keys dir error: %w", err)
+	}
+	if err := os.WriteFile(p.path, data, 0o600); err != nil {
+		return fmt.Errorf("write known keys file error: %w", err)
+	}
+	return nil
+}
+
+// wrapKeyForLogin wraps key for the public key of login the server returns.
+// With pins the key must be the pinned one; with pinUnknown a key not pinned
+// yet is trusted on first use, otherwise it wraps errs.ErrKeyNotVerified
+// until the user confirms its fingerprint.
+func wrapKeyForLogin(ctx context.Context, c client.Client, pins *KeyPins, login string, key []byte, pinUnknown bool) (string, error) {
+	pub, err := c.GetPublicKey(ctx, login)
+	if err != nil {
+		return "", fmt.Errorf("get public key of %s error: %w", login, err)
+	}
+	if pins != nil {
+		err := pins.check(login, pub)
+		if errors.Is(err, errs.ErrKeyNotVerified) && pinUnknown {
+			err = pins.pin(login, pub)
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+
+	wrapped, err := wrapKeyFor(pub, key)
+	if err != nil {
+		return "", fmt.Errorf("wrap key for %s error: %w", login, err)
+	}
+	return wrapped, nil
+}
+
+// keyFingerprint is how a sharing key is shown to be compared out of band:
+// the first 16 bytes of its SHA-256 in groups of four hex digits.
+func keyFingerprint(pub []byte) string {
+	sum := sha256.Sum256(pub)
+	digits := hex.EncodeToString(sum[:16])
+	groups := make([]string, 0, len(digits)/4)
+	for i := 0; i < len(digits); i += 4 {
+		groups = append(groups, digits[i:i+4])
+	}
+	return strings.Join(groups, " ")
+}
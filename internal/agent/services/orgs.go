@@ -0,0 +1,320 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"gophkeeper/internal/agent/client"
+	"gophkeeper/models"
+)
+
+const defaultCollectionName = "Shared"
+
+// OrgService manages organizations. Every organization has an org key wrapped
+// for each member's public key; collection keys are sealed with the org key.
+type OrgService struct {
+	Client client.Client
+	Crypto *CryptoService
+	pins   *KeyPins
+}
+
+func NewOrgService(client client.Client, cs *CryptoService) (*OrgService, error) {
+	return &OrgService{
+		Client: client,
+		Crypto: cs,
+	}, nil
+}
+
+// CreateOrg creates an organization with a default collection.
+func (ors *OrgService) CreateOrg(ctx context.Context, name string) (*models.Organization, error) {
+	keys, err := ors.Client.GetUserKeys(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get user keys error: %w", err)
+	}
+
+	orgKey, err := newRandomKey()
+	if err != nil {
+		return nil, err
+	}
+	wrapped, err := wrapKeyFor(keys.PublicKey, orgKey)
+	if err != nil {
+		return nil, err
+	}
+
+	coll, err := newCollection(orgKey, defaultCollectionName)
+	if err != nil {
+		return nil, err
+	}
+
+	return ors.Client.CreateOrg(ctx, &models.Organization{Name: name, WrappedKey: wrapped}, coll)
+}
+
+func (ors *OrgService) ListOrgs(ctx context.Context) ([]models.Organization, error) {
+	return ors.Client.ListOrgs(ctx)
+}
+
+// SetKeyPins turns on pinning of member keys, the pins are shared with
+// ItemService so a key verified for sharing is trusted here too.
+func (ors *OrgService) SetKeyPins(pins *KeyPins) {
+	ors.pins = pins
+}
+
+// InviteMember wraps the org key for the invitee's public key. With key pins
+// the key of login must be verified with ItemService.VerifyRecipientKey.
+func (ors *OrgService) InviteMember(ctx context.Context, org *models.Organization, login string, role models.OrgRole) error {
+	orgKey, err := ors.orgKey(ctx, org)
+	if err != nil {
+		return err
+	}
+
+	wrapped, err := wrapKeyForLogin(ctx, ors.Client, ors.pins, login, orgKey, false)
+	if err != nil {
+		return err
+	}
+
+	return ors.Client.InviteMember(ctx, &models.Membership{
+		OrgID:      org.ID,
+		Login:      login,
+		Role:       role,
+		WrappedKey: wrapped,
+	})
+}
+
+func (ors *OrgService) AcceptInvite(ctx context.Context, orgID [16]byte) error {
+	return ors.Client.AcceptInvite(ctx, orgID)
+}
+
+// RemoveMember removes the member and rotates the org key. The removed member
+// may have kept the collection keys and item data keys, so every collection
+// gets a new key and every collection item is re-encrypted with a new data key.
+func (ors *OrgService) RemoveMember(ctx context.Context, org *models.Organization, login string) error {
+	rot, err := ors.rotation(ctx, org, login)
+	if err != nil {
+		return err
+	}
+	return ors.Client.RemoveMember(ctx, rot)
+}
+
+// LeaveOrg removes the current user, login, from the organization. Only the
+// membership is deleted, the owner or an admin rotates the keys afterwards.
+func (ors *OrgService) LeaveOrg(ctx context.Context, org *models.Organization, login string) error {
+	return ors.Client.RemoveMember(ctx, &models.OrgKeyRotation{OrgID: org.ID, Login: login})
+}
+
+// RotateOrgKey replaces the org key, the collection keys and the item data
+// keys without removing anyone, after a member left on their own.
+func (ors *OrgService) RotateOrgKey(ctx context.Context, org *models.Organization) error {
+	rot, err := ors.rotation(ctx, org, "")
+	if err != nil {
+		return err
+	}
+	return ors.Client.RotateOrgKey(ctx, rot)
+}
+
+// RotateDueKeys rotates the keys of every organization the user manages that
+// a member left since the last rotation. It returns the number of rotated
+// organizations.
+func (ors *OrgService) RotateDueKeys(ctx context.Context) (int, error) {
+	orgs, err := ors.Client.ListOrgs(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	rotated := 0
+	for i := range orgs {
+		if !orgs[i].KeyRotationDue || !orgs[i].Accepted || !orgs[i].Role.CanManageMembers() {
+			continue
+		}
+		if err := ors.RotateOrgKey(ctx, &orgs[i]); err != nil {
+			return rotated, fmt.Errorf("rotate %s key error: %w", orgs[i].Name, err)
+		}
+		rotated++
+	}
+	return rotated, nil
+}
+
+// rotation builds a rotation of every key of the organization without login,
+// empty login keeps every member.
+func (ors *OrgService) rotation(ctx context.Context, org *models.Organization, login string) (*models.OrgKeyRotation, error) {
+	oldKey, err := ors.orgKey(ctx, org)
+	if err != nil {
+		return nil, err
+	}
+	members, err := ors.Client.ListMembers(ctx, org.ID)
+	if err != nil {
+		return nil, err
+	}
+	collections, err := ors.Client.ListCollections(ctx, org.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	newKey, err := newRandomKey()
+	if err != nil {
+		return nil, err
+	}
+
+	rot := &models.OrgKeyRotation{OrgID: org.ID, Login: login}
+	for _, m := range members {
+		if m.Login == login {
+			continue
+		}
+		// Members already hold the org key, their keys are trusted on
+		// first use like share recipients on revoke.
+		if m.WrappedKey, err = wrapKeyForLogin(ctx, ors.Client, ors.pins, m.Login, newKey, true); err != nil {
+			return nil, err
+		}
+		rot.Members = append(rot.Members, m)
+	}
+
+	for _, c := range collections {
+		oldCollKey, err := openKey(oldKey, c.EncryptedKey)
+		if err != nil {
+			return nil, fmt.Errorf("open collection %s key error: %w", c.Name, err)
+		}
+		newCollKey, err := newRandomKey()
+		if err != nil {
+			return nil, err
+		}
+		if c.EncryptedKey, err = sealKey(newKey, newCollKey); err != nil {
+			return nil, err
+		}
+		rot.Collections = append(rot.Collections, c)
+
+		if err := ors.rekeyCollectionItems(ctx, rot, &c, oldCollKey, newCollKey); err != nil {
+			return nil, err
+		}
+	}
+	return rot, nil
+}
+
+// rekeyCollectionItems re-encrypts every item of the collection with a new
+// data key sealed with newCollKey and re-seals their attachment infos.
+func (ors *OrgService) rekeyCollectionItems(ctx context.Context, rot *models.OrgKeyRotation, c *models.Collection, oldCollKey, newCollKey []byte) error {
+	items, err := ors.Client.GetCollectionItems(ctx, c.ID, models.ItemTypeUNSPECIFIED)
+	if err != nil {
+		return fmt.Errorf("get items of collection %s error: %w", c.Name, err)
+	}
+
+	for i := range items {
+		oldDataKey, err := openKey(oldCollKey, items[i].EncryptedKey)
+		if err != nil {
+			return fmt.Errorf("open item key error: %w", err)
+		}
+		item, err := decryptItemWithKey(oldDataKey, &items[i])
+		if err != nil {
+			return fmt.Errorf("decrypt item error: %w", err)
+		}
+
+		dataKey, err := newRandomKey()
+		if err != nil {
+			return err
+		}
+		sealedKey, err := sealKey(newCollKey, dataKey)
+		if err != nil {
+			return err
+		}
+		rekeyed, err := sealItem(dataKey, sealedKey, item)
+		if err != nil {
+			return fmt.Errorf("encrypt item error: %w", err)
+		}
+		rekeyed.ID, rekeyed.CollectionID = items[i].ID, c.ID
+		rot.Items = append(rot.Items, *rekeyed)
+
+		attachments, err := resealAttachments(ctx, ors.Client, items[i].ID, oldDataKey, dataKey)
+		if err != nil {
+			return err
+		}
+		rot.Attachments = append(rot.Attachments, attachments...)
+	}
+	return nil
+}
+
+func (ors *OrgService) ChangeRole(ctx context.Context, orgID [16]byte, login string, role models.OrgRole) error {
+	return ors.Client.ChangeRole(ctx, orgID, login, role)
+}
+
+func (ors *OrgService) ListMembers(ctx context.Context, orgID [16]byte) ([]models.Membership, error) {
+	return ors.Client.ListMembers(ctx, orgID)
+}
+
+func (ors *OrgService) CreateCollection(ctx context.Context, org *models.Organization, name string) (*models.Collection, error) {
+	orgKey, err := ors.orgKey(ctx, org)
+	if err != nil {
+		return nil, err
+	}
+	coll, err := newCollection(orgKey, name)
+	if err != nil {
+		return nil, err
+	}
+	coll.OrgID = org.ID
+	return ors.Client.CreateCollection(ctx, coll)
+}
+
+func (ors *OrgService) ListCollections(ctx context.Context, orgID [16]byte) ([]models.Collection, error) {
+	return ors.Client.ListCollections(ctx, orgID)
+}
+
+// OpenVault opens the collection key for use with ItemService.SetVault.
+func (ors *OrgService) OpenVault(ctx context.Context, org *models.Organization, coll *models.Collection) (*Vault, error) {
+	orgKey, err := ors.orgKey(ctx, org)
+	if err != nil {
+		return nil, err
+	}
+	key, err := openKey(orgKey, coll.EncryptedKey)
+	if err != nil {
+		return nil, fmt.Errorf("open collection key error: %w", err)
+	}
+	return &Vault{Org: *org, Collection: *coll, key: key}, nil
+}
+
+// WritableVaults opens every collection the user can edit items of, for
+// ItemService.UpgradeItems.
+func (ors *OrgService) WritableVaults(ctx context.Context) ([]*Vault, error) {
+	orgs, err := ors.Client.ListOrgs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var vaults []*Vault
+	for i := range orgs {
+		if !orgs[i].Accepted || !orgs[i].Role.CanWriteItems() {
+			continue
+		}
+		colls, err := ors.Client.ListCollections(ctx, orgs[i].ID)
+		if err != nil {
+			return nil, err
+		}
+		for j := range colls {
+			vault, err := ors.OpenVault(ctx, &orgs[i], &colls[j])
+			if err != nil {
+				return nil, err
+			}
+			vaults = append(vaults, vault)
+		}
+	}
+	return vaults, nil
+}
+
+func (ors *OrgService) orgKey(ctx context.Context, org *models.Organization) ([]byte, error) {
+	priv, err := ors.Crypto.privateKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+	key, err := unwrapKey(priv, org.WrappedKey)
+	if err != nil {
+		return nil, fmt.Errorf("open organization key error: %w", err)
+	}
+	return key, nil
+}
+
+func newCollection(orgKey []byte, name string) (*models.Collection, error) {
+	collKey, err := newRandomKey()
+	if err != nil {
+		return nil, err
+	}
+	sealed, err := sealKey(orgKey, collKey)
+	if err != nil {
+		return nil, err
+	}
+	return &models.Collection{Name: name, EncryptedKey: sealed}, nil
+}
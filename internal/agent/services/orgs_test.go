@@ -0,0 +1,293 @@
+package services
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"gophkeeper/internal/errs"
+	"gophkeeper/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// orgServer keeps one organization in memory on top of shareServer.
+type orgServer struct {
+	shareServer
+	org         models.Organization
+	members     map[string]models.Membership
+	collections []models.Collection
+	items       []models.EncryptedItem
+	attachments []models.Attachment
+}
+
+type orgClient struct {
+	shareClient
+	orgs *orgServer
+}
+
+func (c *orgClient) CreateOrg(ctx context.Context, org *models.Organization, coll *models.Collection) (*models.Organization, error) {
+	org.ID = [16]byte{7}
+	org.Role = models.OrgRoleOWNER
+	org.Accepted = true
+	c.orgs.org = *org
+	c.orgs.members[c.login] = models.Membership{OrgID: org.ID, Login: c.login, Role: org.Role, Accepted: true, WrappedKey: org.WrappedKey}
+	coll.ID = [16]byte{8}
+	coll.OrgID = org.ID
+	c.orgs.collections = []models.Collection{*coll}
+	return org, nil
+}
+
+func (c *orgClient) InviteMember(ctx context.Context, member *models.Membership) error {
+	c.orgs.members[member.Login] = *member
+	return nil
+}
+
+func (c *orgClient) ListMembers(ctx context.Context, orgID [16]byte) ([]models.Membership, error) {
+	var members []models.Membership
+	for _, m := range c.orgs.members {
+		members = append(members, m)
+	}
+	return members, nil
+}
+
+func (c *orgClient) ListCollections(ctx context.Context, orgID [16]byte) ([]models.Collection, error) {
+	return c.orgs.collections, nil
+}
+
+func (c *orgClient) ListOrgs(ctx context.Context) ([]models.Organization, error) {
+	return []models.Organization{*c.orgs.membership(c.login)}, nil
+}
+
+func (c *orgClient) RemoveMember(ctx context.Context, rot *models.OrgKeyRotation) error {
+	if rot.Login == c.login {
+		delete(c.orgs.members, c.login)
+		c.orgs.org.KeyRotationDue = true
+		return nil
+	}
+	return c.RotateOrgKey(ctx, rot)
+}
+
+func (c *orgClient) RotateOrgKey(ctx context.Context, rot *models.OrgKeyRotation) error {
+	c.orgs.members = map[string]models.Membership{}
+	for _, m := range rot.Members {
+		c.orgs.members[m.Login] = m
+	}
+	c.orgs.collections = rot.Collections
+	c.orgs.items = rot.Items
+	for _, resealed := range rot.Attachments {
+		for i := range c.orgs.attachments {
+			if c.orgs.attachments[i].ID == resealed.ID {
+				c.orgs.attachments[i].EncryptedInfo = resealed.EncryptedInfo
+			}
+		}
+	}
+	c.orgs.org.KeyRotationDue = false
+	return nil
+}
+
+func (c *orgClient) GetCollectionItems(ctx context.Context, collectionID [16]byte, typ models.ItemType) ([]models.EncryptedItem, error) {
+	var items []models.EncryptedItem
+	for _, item := range c.orgs.items {
+		if item.CollectionID == collectionID {
+			items = append(items, item)
+		}
+	}
+	return items, nil
+}
+
+func (c *orgClient) UploadAttachment(ctx context.Context, a *models.Attachment) ([16]byte, error) {
+	a.ID = [16]byte{byte(len(c.orgs.attachments) + 1)}
+	c.orgs.attachments = append(c.orgs.attachments, *a)
+	return a.ID, nil
+}
+
+func (c *orgClient) ListAttachments(ctx context.Context, itemID [16]byte) ([]models.Attachment, error) {
+	var list []models.Attachment
+	for _, a := range c.orgs.attachments {
+		if a.ItemID == itemID {
+			list = append(list, a)
+		}
+	}
+	return list, nil
+}
+
+func (c *orgClient) DownloadAttachment(ctx context.Context, itemID, attachmentID [16]byte) (*models.Attachment, error) {
+	for _, a := range c.orgs.attachments {
+		if a.ItemID == itemID && a.ID == attachmentID {
+			return &a, nil
+		}
+	}
+	return nil, errs.ErrAttachmentNotFound
+}
+
+func (c *orgClient) AddItem(ctx context.Context, item *models.EncryptedItem) error {
+	item.ID = [16]byte{0x10, byte(len(c.orgs.items) + 1)}
+	c.orgs.items = append(c.orgs.items, *item)
+	return nil
+}
+
+// membership returns the organization as seen by login.
+func (s *orgServer) membership(login string) *models.Organization {
+	org := s.org
+	org.WrappedKey = s.members[login].WrappedKey
+	return &org
+}
+
+func newOrgUser(t *testing.T, server *orgServer, login, masterPassword string) (*OrgService, *ItemService) {
+	t.Helper()
+	_, is := newShareUser(t, &server.shareServer, login, masterPassword)
+
+	client := &orgClient{shareClient: shareClient{login: login, server: &server.shareServer}, orgs: server}
+	is.Client = client
+	is.Crypto.Client = client
+	ors, err := NewOrgService(client, is.Crypto)
+	require.NoError(t, err)
+	return ors, is
+}
+
+func TestOrgService_CollectionItemsAndRotation(t *testing.T) {
+	server := &orgServer{
+		shareServer: shareServer{keys: map[string]*models.UserKeys{}, shares: map[string]models.ItemShare{}},
+		members:     map[string]models.Membership{},
+	}
+	aliceOrgs, alice := newOrgUser(t, server, "alice", "alice-master")
+	bobOrgs, bob := newOrgUser(t, server, "bob", "bob-master")
+	carolOrgs, carol := newOrgUser(t, server, "carol", "carol-master")
+	ctx := context.Background()
+
+	org, err := aliceOrgs.CreateOrg(ctx, "team")
+	require.NoError(t, err)
+	require.NoError(t, aliceOrgs.InviteMember(ctx, org, "bob", models.OrgRoleEDITOR))
+	require.NoError(t, aliceOrgs.InviteMember(ctx, org, "carol", models.OrgRoleVIEWER))
+
+	coll := server.collections[0]
+	vault, err := aliceOrgs.OpenVault(ctx, org, &coll)
+	require.NoError(t, err)
+	alice.SetVault(vault)
+	require.NoError(t, alice.AddItem(ctx, &models.Item{Name: "db", Type: models.ItemTypeTEXT, Data: &models.Text{Content: "secret"}}))
+	require.Len(t, server.items, 1)
+	assert.Equal(t, coll.ID, server.items[0].CollectionID)
+
+	// Members decrypt collection items with their own copy of the org key.
+	bobVault, err := bobOrgs.OpenVault(ctx, server.membership("bob"), &coll)
+	require.NoError(t, err)
+	bob.SetVault(bobVault)
+	got, err := bob.DecryptItem(ctx, &server.items[0])
+	require.NoError(t, err)
+	assert.Equal(t, "secret", got.Data.(*models.Text).Content)
+
+	// Personal vault can't open collection items.
+	_, err = carol.DecryptItem(ctx, &server.items[0])
+	assert.ErrorIs(t, err, errVaultNotOpen)
+	assert.ErrorIs(t, alice.ShareItem(ctx, got, "carol"), errCollectionItemShare)
+
+	require.NoError(t, alice.AddAttachment(ctx, got, "dump.sql", "", []byte("select 1")))
+
+	bobCopy := server.membership("bob")
+	oldItem := server.items[0]
+	require.NoError(t, aliceOrgs.RemoveMember(ctx, org, "bob"))
+	require.NotContains(t, server.members, "bob")
+	rotated := server.collections[0]
+	assert.NotEqual(t, coll.EncryptedKey, rotated.EncryptedKey)
+	require.Len(t, server.items, 1)
+	assert.NotEqual(t, oldItem.EncryptedKey, server.items[0].EncryptedKey)
+
+	// Remaining members open the new collection key, the item and its files.
+	carolVault, err := carolOrgs.OpenVault(ctx, server.membership("carol"), &rotated)
+	require.NoError(t, err)
+	carol.SetVault(carolVault)
+	got, err = carol.DecryptItem(ctx, &server.items[0])
+	require.NoError(t, err)
+	assert.Equal(t, "secret", got.Data.(*models.Text).Content)
+	files, err := carol.ListAttachments(ctx, got)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	_, content, err := carol.DownloadAttachment(ctx, got, files[0].ID)
+	require.NoError(t, err)
+	assert.Equal(t, "select 1", string(content))
+
+	// The removed member can't open the new keys, neither with the old org
+	// key nor with the collection key they kept.
+	_, err = bobOrgs.OpenVault(ctx, bobCopy, &rotated)
+	assert.Error(t, err)
+	_, err = bob.DecryptItem(ctx, &server.items[0])
+	assert.Error(t, err)
+}
+
+func TestOrgService_LeaveAndRotate(t *testing.T) {
+	server := &orgServer{
+		shareServer: shareServer{keys: map[string]*models.UserKeys{}, shares: map[string]models.ItemShare{}},
+		members:     map[string]models.Membership{},
+	}
+	aliceOrgs, _ := newOrgUser(t, server, "alice", "alice-master")
+	bobOrgs, _ := newOrgUser(t, server, "bob", "bob-master")
+	ctx := context.Background()
+
+	org, err := aliceOrgs.CreateOrg(ctx, "team")
+	require.NoError(t, err)
+	require.NoError(t, aliceOrgs.InviteMember(ctx, org, "bob", models.OrgRoleEDITOR))
+
+	// Nothing is due until somebody leaves.
+	rotated, err := aliceOrgs.RotateDueKeys(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, rotated)
+
+	bobCopy := server.membership("bob")
+	require.NoError(t, bobOrgs.LeaveOrg(ctx, bobCopy, "bob"))
+	require.NotContains(t, server.members, "bob")
+	assert.True(t, server.org.KeyRotationDue)
+	coll := server.collections[0]
+
+	rotated, err = aliceOrgs.RotateDueKeys(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, rotated)
+	assert.False(t, server.org.KeyRotationDue)
+	assert.NotEqual(t, coll.EncryptedKey, server.collections[0].EncryptedKey)
+
+	_, err = aliceOrgs.OpenVault(ctx, server.membership("alice"), &server.collections[0])
+	require.NoError(t, err)
+	_, err = bobOrgs.OpenVault(ctx, bobCopy, &server.collections[0])
+	assert.Error(t, err)
+}
+
+func TestOrgService_KeyPins(t *testing.T) {
+	server := &orgServer{
+		shareServer: shareServer{keys: map[string]*models.UserKeys{}, shares: map[string]models.ItemShare{}},
+		members:     map[string]models.Membership{},
+	}
+	aliceOrgs, alice := newOrgUser(t, server, "alice", "alice-master")
+	newOrgUser(t, server, "bob", "bob-master")
+	newOrgUser(t, server, "carol", "carol-master")
+	newOrgUser(t, server, "dave", "dave-master")
+	ctx := context.Background()
+
+	pins, err := LoadKeyPins(filepath.Join(t.TempDir(), "known_keys.json"))
+	require.NoError(t, err)
+	alice.SetKeyPins(pins)
+	aliceOrgs.SetKeyPins(pins)
+
+	org, err := aliceOrgs.CreateOrg(ctx, "team")
+	require.NoError(t, err)
+
+	// The org key is wrapped only for a confirmed key.
+	assert.ErrorIs(t, aliceOrgs.InviteMember(ctx, org, "bob", models.OrgRoleEDITOR), errs.ErrKeyNotVerified)
+	require.NotContains(t, server.members, "bob")
+	fingerprint, err := alice.RecipientKey(ctx, "bob")
+	assert.ErrorIs(t, err, errs.ErrKeyNotVerified)
+	require.NoError(t, alice.VerifyRecipientKey(ctx, "bob", fingerprint))
+	require.NoError(t, aliceOrgs.InviteMember(ctx, org, "bob", models.OrgRoleEDITOR))
+	fingerprint, err = alice.RecipientKey(ctx, "carol")
+	assert.ErrorIs(t, err, errs.ErrKeyNotVerified)
+	require.NoError(t, alice.VerifyRecipientKey(ctx, "carol", fingerprint))
+	require.NoError(t, aliceOrgs.InviteMember(ctx, org, "carol", models.OrgRoleVIEWER))
+
+	// A rotation refuses a member key the server swaps in and changes
+	// nothing.
+	coll := server.collections[0]
+	server.keys["bob"] = server.keys["dave"]
+	assert.ErrorIs(t, aliceOrgs.RemoveMember(ctx, org, "carol"), errs.ErrKeyChanged)
+	assert.Contains(t, server.members, "carol")
+	assert.Equal(t, coll.EncryptedKey, server.collections[0].EncryptedKey)
+}
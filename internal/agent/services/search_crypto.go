@@ -0,0 +1,131 @@
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"gophkeeper/models"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+const searchKeyInfo = "gophkeeper search key"
+
+// Meta keys that are indexed besides the item name.
+const (
+	metaTags = "tags"
+	metaURL  = "url"
+)
+
+// deriveSearchKey derives the blind index key from the master key, so the
+// index changes together with the master password.
+func deriveSearchKey(masterKey []byte) []byte {
+	mac := hmac.New(sha256.New, masterKey)
+	mac.Write([]byte(searchKeyInfo))
+	return mac.Sum(nil)
+}
+
+func (cs *CryptoService) searchKey(ctx context.Context) ([]byte, error) {
+	mk, err := cs.masterKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return deriveSearchKey(mk), nil
+}
+
+// searchTokens returns blind index tokens of the item name words, tags and
+// URL host.
+func (cs *CryptoService) searchTokens(ctx context.Context, item *models.Item) ([]string, error) {
+	key, err := cs.searchKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return blindTokens(key, searchTerms(item)), nil
+}
+
+// queryTokens returns the tokens an item must have to match every word of the query.
+func (cs *CryptoService) queryTokens(ctx context.Context, query string) ([]string, error) {
+	key, err := cs.searchKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return blindTokens(key, queryTerms(query)), nil
+}
+
+func blindTokens(key []byte, terms []string) []string {
+	tokens := make([]string, len(terms))
+	for i, term := range terms {
+		mac := hmac.New(sha256.New, key)
+		mac.Write([]byte(term))
+		tokens[i] = hex.EncodeToString(mac.Sum(nil))
+	}
+	return tokens
+}
+
+func searchTerms(item *models.Item) []string {
+	seen := make(map[string]bool)
+	var terms []string
+	add := func(term string) {
+		term = normalizeTerm(term)
+		if term != "" && !seen[term] {
+			seen[term] = true
+			terms = append(terms, term)
+		}
+	}
+
+	for _, word := range strings.Fields(item.Name) {
+		add(word)
+	}
+	for _, tag := range strings.Split(item.Meta.Map[metaTags], ",") {
+		add(tag)
+	}
+	if host := urlHost(item.Meta.Map[metaURL]); host != "" {
+		add(host)
+		add(strings.TrimPrefix(host, "www."))
+	}
+	return terms
+}
+
+func queryTerms(query string) []string {
+	var terms []string
+	for _, word := range strings.Fields(query) {
+		if term := normalizeTerm(word); term != "" {
+			terms = append(terms, term)
+		}
+	}
+	return terms
+}
+
+func normalizeTerm(term string) string {
+	return strings.ToLower(strings.TrimSpace(term))
+}
+
+// urlHost accepts URLs with or without a scheme.
+func urlHost(raw string) string {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return ""
+	}
+	if !strings.Contains(raw, "://") {
+		raw = "https://" + raw
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return ""
+	}
+	return u.Hostname()
+}
+
+// matchesTerms reports whether the item has every term, used for vaults
+// without a server side index.
+func matchesTerms(item *models.EncryptedItem, terms []string) bool {
+	have := searchTerms(&models.Item{Name: item.Name, Meta: item.Meta})
+	for _, term := range terms {
+		if !slices.Contains(have, term) {
+			return false
+		}
+	}
+	return true
+}
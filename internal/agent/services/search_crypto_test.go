@@ -0,0 +1,95 @@
+package services
+
+import (
+	"context"
+	"gophkeeper/models"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchTerms(t *testing.T) {
+	item := &models.Item{
+		Name: "My  Bank Account",
+		Meta: models.Meta{Map: map[string]string{
+			"tags": "Finance, work,,",
+			"url":  "https://www.Bank.example/login?x=1",
+		}},
+	}
+
+	assert.Equal(t, []string{"my", "bank", "account", "finance", "work", "www.bank.example", "bank.example"}, searchTerms(item))
+	assert.Equal(t, []string{"bank", "work"}, queryTerms("  Bank WORK "))
+}
+
+func TestURLHost(t *testing.T) {
+	assert.Equal(t, "mail.example", urlHost("mail.example/inbox"))
+	assert.Equal(t, "db.local", urlHost("postgres://user@db.local:5432/app"))
+	assert.Empty(t, urlHost(""))
+}
+
+func TestBlindTokens(t *testing.T) {
+	key := deriveSearchKey([]byte("master key one"))
+	other := deriveSearchKey([]byte("master key two"))
+
+	tokens := blindTokens(key, []string{"bank", "work"})
+	assert.Equal(t, tokens, blindTokens(key, []string{"bank", "work"}))
+	assert.NotEqual(t, tokens[0], tokens[1])
+	assert.NotEqual(t, tokens, blindTokens(other, []string{"bank", "work"}))
+	assert.NotContains(t, tokens[0], "bank")
+}
+
+// searchClient stores edited items and matches them on tokens like the server.
+type searchClient struct {
+	shareClient
+	stored []models.EncryptedItem
+}
+
+func (c *searchClient) AddItem(ctx context.Context, item *models.EncryptedItem) error {
+	c.stored = append(c.stored, *item)
+	return nil
+}
+
+func (c *searchClient) SearchItems(ctx context.Context, tokens []string) ([]models.EncryptedItem, error) {
+	var found []models.EncryptedItem
+	for _, item := range c.stored {
+		if !slices.ContainsFunc(tokens, func(t string) bool { return !slices.Contains(item.SearchTokens, t) }) {
+			found = append(found, item)
+		}
+	}
+	return found, nil
+}
+
+func TestItemService_SearchItems(t *testing.T) {
+	server := &shareServer{keys: map[string]*models.UserKeys{}}
+	_, is := newShareUser(t, server, "alice", "alice-master")
+	client := &searchClient{shareClient: shareClient{login: "alice", server: server}}
+	is.Client = client
+	ctx := context.Background()
+
+	require.NoError(t, is.AddItem(ctx, &models.Item{
+		Name: "Bank", Type: models.ItemTypeTEXT, Data: &models.Text{Content: "pin"},
+		Meta: models.Meta{Map: map[string]string{"tags": "finance"}},
+	}))
+	require.NoError(t, is.AddItem(ctx, &models.Item{
+		Name: "Mail", Type: models.ItemTypeTEXT, Data: &models.Text{Content: "pass"},
+		Meta: models.Meta{Map: map[string]string{"tags": "work", "url": "mail.example"}},
+	}))
+	for _, item := range client.stored {
+		assert.NotEmpty(t, item.SearchTokens)
+		assert.Empty(t, item.Name)
+	}
+
+	found, err := is.SearchItems(ctx, "alice", "mail.example WORK")
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	assert.Equal(t, "Mail", found[0].Name)
+
+	found, err = is.SearchItems(ctx, "alice", "bank work")
+	require.NoError(t, err)
+	assert.Empty(t, found)
+
+	_, err = is.SearchItems(ctx, "alice", "  ")
+	assert.ErrorIs(t, err, errEmptySearchQuery)
+}
@@ -0,0 +1,103 @@
+package services
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"gophkeeper/models"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+var (
+	errSendKeyMissing = errors.New("send link has no key")
+	errSendBurned     = errors.New("send not found or already burned")
+)
+
+// SendPayload is what the recipient of a one-time link gets after decryption.
+type SendPayload struct {
+	Name    string `json:"name"`
+	Content string `json:"content"`
+}
+
+// sendView mirrors the JSON served by the server send handler.
+type sendView struct {
+	EncryptedContent string `json:"encrypted_content"`
+	Nonce            string `json:"nonce"`
+	ViewsLeft        int32  `json:"views_left"`
+}
+
+// CreateSend encrypts payload with a fresh key and uploads the ciphertext.
+// The key is only put into the URL fragment, which browsers and HTTP
+// clients never send to the server.
+func (is *ItemService) CreateSend(ctx context.Context, payload SendPayload, maxViews int32, ttl time.Duration) (string, error) {
+	key, err := newRandomKey()
+	if err != nil {
+		return "", err
+	}
+
+	enc, err := encryptWithKey(key, payload)
+	if err != nil {
+		return "", fmt.Errorf("encrypt send error: %w", err)
+	}
+
+	link, err := is.Client.CreateSend(ctx, &models.Send{
+		EncryptedData: *enc,
+		MaxViews:      maxViews,
+		ExpiresAt:     time.Now().Add(ttl),
+	})
+	if err != nil {
+		return "", err
+	}
+	return link + "#" + base64.RawURLEncoding.EncodeToString(key), nil
+}
+
+// ReceiveSend downloads the send behind link and decrypts it locally. Each
+// call spends one of its views.
+func ReceiveSend(ctx context.Context, hc *http.Client, link string) (*SendPayload, error) {
+	u, err := url.Parse(link)
+	if err != nil {
+		return nil, fmt.Errorf("parse send link error: %w", err)
+	}
+	if u.Fragment == "" {
+		return nil, errSendKeyMissing
+	}
+	key, err := base64.RawURLEncoding.DecodeString(u.Fragment)
+	if err != nil || len(key) != itemKeySize {
+		return nil, fmt.Errorf("decode send key error: invalid key")
+	}
+	u.Fragment = ""
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("create send request error: %w", err)
+	}
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("get send error: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, errSendBurned
+	default:
+		return nil, fmt.Errorf("get send error: %s", resp.Status)
+	}
+
+	var view sendView
+	if err := json.NewDecoder(resp.Body).Decode(&view); err != nil {
+		return nil, fmt.Errorf("decode send error: %w", err)
+	}
+
+	var payload SendPayload
+	enc := &models.EncryptedData{EncryptedContent: view.EncryptedContent, Nonce: view.Nonce}
+	if err := decryptWithKey(key, enc, &payload); err != nil {
+		return nil, fmt.Errorf("decrypt send error: %w", err)
+	}
+	return &payload, nil
+}
@@ -0,0 +1,100 @@
+package services
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"gophkeeper/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sendClient stores the uploaded send and serves it like the server
+// send handler, burning it after the last view.
+type sendClient struct {
+	MockClient
+	baseURL string
+	send    *models.Send
+}
+
+func (c *sendClient) CreateSend(ctx context.Context, send *models.Send) (string, error) {
+	c.send = send
+	return c.baseURL + "/send/abcd", nil
+}
+
+func (c *sendClient) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if c.send == nil || r.URL.Path != "/send/abcd" {
+		http.NotFound(w, r)
+		return
+	}
+	c.send.Views++
+	view := sendView{
+		EncryptedContent: c.send.EncryptedData.EncryptedContent,
+		Nonce:            c.send.EncryptedData.Nonce,
+		ViewsLeft:        c.send.ViewsLeft(),
+	}
+	if view.ViewsLeft == 0 {
+		c.send = nil
+	}
+	json.NewEncoder(w).Encode(view)
+}
+
+func TestCreateAndReceiveSend(t *testing.T) {
+	client := &sendClient{}
+	srv := httptest.NewServer(client)
+	defer srv.Close()
+	client.baseURL = srv.URL
+
+	is, err := NewItemService(client, nil)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	link, err := is.CreateSend(ctx, SendPayload{Name: "wifi", Content: "hunter2"}, 1, time.Hour)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(link, srv.URL+"/send/abcd#"))
+	assert.Equal(t, int32(1), client.send.MaxViews)
+	assert.NotContains(t, client.send.EncryptedData.EncryptedContent, "hunter2")
+
+	payload, err := ReceiveSend(ctx, srv.Client(), link)
+	require.NoError(t, err)
+	assert.Equal(t, &SendPayload{Name: "wifi", Content: "hunter2"}, payload)
+
+	_, err = ReceiveSend(ctx, srv.Client(), link)
+	assert.ErrorIs(t, err, errSendBurned)
+}
+
+func TestReceiveSend_BadLink(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := ReceiveSend(ctx, http.DefaultClient, "http://localhost/send/abcd")
+	assert.ErrorIs(t, err, errSendKeyMissing)
+
+	_, err = ReceiveSend(ctx, http.DefaultClient, "http://localhost/send/abcd#short")
+	assert.Error(t, err)
+}
+
+func TestReceiveSend_WrongKey(t *testing.T) {
+	client := &sendClient{}
+	srv := httptest.NewServer(client)
+	defer srv.Close()
+	client.baseURL = srv.URL
+
+	is, err := NewItemService(client, nil)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	_, err = is.CreateSend(ctx, SendPayload{Content: "secret"}, 1, time.Hour)
+	require.NoError(t, err)
+
+	other, err := newRandomKey()
+	require.NoError(t, err)
+	_, err = ReceiveSend(ctx, srv.Client(), srv.URL+"/send/abcd#"+base64.RawURLEncoding.EncodeToString(other))
+	assert.Error(t, err)
+}
@@ -0,0 +1,196 @@
+package services
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"gophkeeper/internal/errs"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/crypto/argon2"
+)
+
+// maxSessionAttempts is how many wrong PINs the session file takes before
+// it is removed.
+const maxSessionAttempts = 5
+
+// The session key is derived with argon2id, with the parameters RFC 9106
+// recommends where memory is constrained.
+const (
+	sessionKDF     = "argon2id"
+	argon2Time     = 3
+	argon2Memory   = 64 * 1024
+	argon2Threads  = 4
+	sessionKeySize = 32
+)
+
+// Session is what the agent needs to skip sign in: the token and the salt
+// the master key is derived with.
+type Session struct {
+	Login string `json:"login"`
+	Token string `json:"token"`
+	Salt  string `json:"salt"`
+}
+
+// expired reports whether the token is past its expiry. The token is not
+// verified, the server does that on every call.
+func (s *Session) expired(now time.Time) bool {
+	claims := jwt.MapClaims{}
+	if _, _, err := jwt.NewParser().ParseUnverified(s.Token, claims); err != nil {
+		return true
+	}
+	exp, err := claims.GetExpirationTime()
+	return err != nil || exp == nil || !exp.After(now)
+}
+
+type sessionFile struct {
+	KDF      string `json:"kdf,omitempty"`
+	KDFSalt  []byte `json:"kdf_salt"`
+	Sealed   string `json:"sealed"`
+	Attempts int    `json:"attempts"`
+}
+
+// SessionStore keeps the session in a file sealed with a key derived from a
+// PIN, or from a keyfile when there is one.
+//
+// A PIN only keeps out casual access. Whoever can read the file can guess
+// the PIN offline, and a copy of the file resets the attempt count, so
+// argon2id only makes each guess slow. A keyfile kept off the device, or not
+// saving the session, is what protects a stolen disk.
+type SessionStore struct {
+	path    string
+	keyfile []byte
+}
+
+// NewSessionStore creates the store of the session file at path. With a
+// keyfile the session opens without asking for a PIN.
+func NewSessionStore(path, keyfilePath string) (*SessionStore, error) {
+	s := &SessionStore{path: path}
+	if keyfilePath != "" {
+		keyfile, err := os.ReadFile(keyfilePath)
+		if err != nil {
+			return nil, fmt.Errorf("read session keyfile error: %w", err)
+		}
+		if len(keyfile) == 0 {
+			return nil, errors.New("session keyfile is empty")
+		}
+		s.keyfile = keyfile
+	}
+	return s, nil
+}
+
+// NeedsPIN reports whether the session is sealed with a PIN.
+func (s *SessionStore) NeedsPIN() bool {
+	return s.keyfile == nil
+}
+
+// Exists reports whether a session is saved.
+func (s *SessionStore) Exists() bool {
+	_, err := os.Stat(s.path)
+	return err == nil
+}
+
+func (s *SessionStore) key(kdf, pin string, salt []byte) ([]byte, error) {
+	secret := s.keyfile
+	if secret == nil {
+		secret = []byte(pin)
+	}
+	switch kdf {
+	case sessionKDF:
+		return argon2.IDKey(secret, salt, argon2Time, argon2Memory, argon2Threads, sessionKeySize), nil
+	default:
+		return nil, fmt.Errorf("unknown session KDF %q", kdf)
+	}
+}
+
+// Save seals the session and writes it, replacing a saved one.
+func (s *SessionStore) Save(pin string, session *Session) error {
+	if s.NeedsPIN() && pin == "" {
+		return errors.New("PIN is empty")
+	}
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return fmt.Errorf("generate session salt error: %w", err)
+	}
+	plaintext, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+	key, err := s.key(sessionKDF, pin, salt)
+	if err != nil {
+		return err
+	}
+	sealed, err := sealKey(key, plaintext)
+	if err != nil {
+		return err
+	}
+	return s.write(&sessionFile{KDF: sessionKDF, KDFSalt: salt, Sealed: sealed})
+}
+
+// Load opens the saved session. A wrong PIN counts as an attempt, the file
+// is removed after maxSessionAttempts of them and when the token expired.
+func (s *SessionStore) Load(pin string) (*Session, error) {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		return nil, fmt.Errorf("read session file error: %w", err)
+	}
+	var file sessionFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("parse session file error: %w", err)
+	}
+
+	key, err := s.key(file.KDF, pin, file.KDFSalt)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := openKey(key, file.Sealed)
+	if err != nil {
+		file.Attempts++
+		if file.Attempts >= maxSessionAttempts {
+			return nil, errors.Join(errs.ErrIncorrectPIN, s.Forget())
+		}
+		return nil, errors.Join(errs.ErrIncorrectPIN, s.write(&file))
+	}
+
+	var session Session
+	if err := json.Unmarshal(plaintext, &session); err != nil {
+		return nil, fmt.Errorf("parse session error: %w", err)
+	}
+	if session.expired(time.Now()) {
+		return nil, errors.Join(errs.ErrSessionExpired, s.Forget())
+	}
+	if file.Attempts > 0 {
+		file.Attempts = 0
+		if err := s.write(&file); err != nil {
+			return nil, err
+		}
+	}
+	return &session, nil
+}
+
+// Forget removes the saved session.
+func (s *SessionStore) Forget() error {
+	if err := os.Remove(s.path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("remove session file error: %w", err)
+	}
+	return nil
+}
+
+func (s *SessionStore) write(file *sessionFile) error {
+	data, err := json.Marshal(file)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o700); err != nil {
+		return fmt.Errorf("create session dir error: %w", err)
+	}
+	if err := os.WriteFile(s.path, data, 0o600); err != nil {
+		return fmt.Errorf("write session file error: %w", err)
+	}
+	return nil
+}
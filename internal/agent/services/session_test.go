@@ -0,0 +1,181 @@
+package services
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"gophkeeper/config"
+	"gophkeeper/internal/errs"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testToken(t *testing.T, exp time.Time) string {
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"login": "alice",
+		"exp":   exp.Unix(),
+	}).SignedString([]byte("secret"))
+	require.NoError(t, err)
+	return token
+}
+
+func testSession(t *testing.T) *Session {
+	return &Session{
+		Login: "alice",
+		Token: testToken(t, time.Now().Add(time.Hour)),
+		Salt:  base64.StdEncoding.EncodeToString([]byte("salt")),
+	}
+}
+
+func TestSessionStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "gophkeeper", "session.json")
+	store, err := NewSessionStore(path, "")
+	require.NoError(t, err)
+	assert.True(t, store.NeedsPIN())
+	assert.False(t, store.Exists())
+
+	session := testSession(t)
+	assert.Error(t, store.Save("", session))
+	require.NoError(t, store.Save("1234", session))
+	assert.True(t, store.Exists())
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), session.Token)
+
+	loaded, err := store.Load("1234")
+	require.NoError(t, err)
+	assert.Equal(t, session, loaded)
+
+	require.NoError(t, store.Forget())
+	assert.False(t, store.Exists())
+	require.NoError(t, store.Forget())
+}
+
+func TestSessionStore_WrongPIN(t *testing.T) {
+	store, err := NewSessionStore(filepath.Join(t.TempDir(), "session.json"), "")
+	require.NoError(t, err)
+	require.NoError(t, store.Save("1234", testSession(t)))
+
+	// A right PIN resets the count.
+	for i := 0; i < maxSessionAttempts-1; i++ {
+		_, err = store.Load("0000")
+		assert.ErrorIs(t, err, errs.ErrIncorrectPIN)
+	}
+	_, err = store.Load("1234")
+	require.NoError(t, err)
+
+	for i := 0; i < maxSessionAttempts; i++ {
+		assert.True(t, store.Exists())
+		_, err = store.Load("0000")
+		assert.ErrorIs(t, err, errs.ErrIncorrectPIN)
+	}
+	assert.False(t, store.Exists())
+}
+
+func TestSessionStore_Expired(t *testing.T) {
+	store, err := NewSessionStore(filepath.Join(t.TempDir(), "session.json"), "")
+	require.NoError(t, err)
+
+	session := testSession(t)
+	session.Token = testToken(t, time.Now().Add(-time.Minute))
+	require.NoError(t, store.Save("1234", session))
+
+	_, err = store.Load("1234")
+	assert.ErrorIs(t, err, errs.ErrSessionExpired)
+	assert.False(t, store.Exists())
+}
+
+func TestSessionStore_UnknownKDF(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session.json")
+	store, err := NewSessionStore(path, "")
+	require.NoError(t, err)
+	require.NoError(t, store.Save("1234", testSession(t)))
+
+	var file sessionFile
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(data, &file))
+	assert.Equal(t, sessionKDF, file.KDF)
+
+	for _, kdf := range []string{"", "scrypt"} {
+		file.KDF = kdf
+		require.NoError(t, store.write(&file))
+		_, err = store.Load("1234")
+		assert.ErrorContains(t, err, "unknown session KDF")
+	}
+}
+
+func TestSessionStore_Keyfile(t *testing.T) {
+	dir := t.TempDir()
+	_, err := NewSessionStore(filepath.Join(dir, "session.json"), filepath.Join(dir, "missing.key"))
+	assert.Error(t, err)
+
+	keyfile := filepath.Join(dir, "session.key")
+	require.NoError(t, os.WriteFile(keyfile, []byte("random key material"), 0o600))
+	store, err := NewSessionStore(filepath.Join(dir, "session.json"), keyfile)
+	require.NoError(t, err)
+	assert.False(t, store.NeedsPIN())
+
+	require.NoError(t, store.Save("", testSession(t)))
+	loaded, err := store.Load("")
+	require.NoError(t, err)
+	assert.Equal(t, "alice", loaded.Login)
+
+	require.NoError(t, os.WriteFile(keyfile, []byte("another key"), 0o600))
+	other, err := NewSessionStore(filepath.Join(dir, "session.json"), keyfile)
+	require.NoError(t, err)
+	_, err = other.Load("")
+	assert.ErrorIs(t, err, errs.ErrIncorrectPIN)
+}
+
+func TestUserService_ResumeSession(t *testing.T) {
+	ctx := context.Background()
+	cnfg, err := config.NewAgentConfig()
+	require.NoError(t, err)
+	client := &MockClient{}
+	cs, err := NewCryptoService(cnfg, client)
+	require.NoError(t, err)
+	us, err := NewUserService(cnfg, client, cs)
+	require.NoError(t, err)
+
+	assert.False(t, us.HasSavedSession())
+	assert.False(t, us.CanRememberSession())
+	_, err = us.ResumeSession(ctx, "1234")
+	assert.Error(t, err)
+
+	store, err := NewSessionStore(filepath.Join(t.TempDir(), "session.json"), "")
+	require.NoError(t, err)
+	us.SetSessionStore(store)
+	assert.True(t, us.SessionNeedsPIN())
+	assert.Error(t, us.RememberSession("1234"))
+
+	// Offline sign ins have no token to save.
+	us.session = &Session{Login: "alice", Token: "offline"}
+	assert.False(t, us.CanRememberSession())
+
+	us.session = testSession(t)
+	require.True(t, us.CanRememberSession())
+	require.NoError(t, us.RememberSession("1234"))
+	assert.True(t, us.HasSavedSession())
+
+	us.session = nil
+	login, err := us.ResumeSession(ctx, "1234")
+	require.NoError(t, err)
+	assert.Equal(t, "alice", login)
+	salt, err := cnfg.GetSalt()
+	require.NoError(t, err)
+	assert.Equal(t, []byte("salt"), salt)
+
+	require.NoError(t, us.Logout())
+	assert.False(t, us.HasSavedSession())
+}
@@ -0,0 +1,183 @@
+package services
+
+import (
+	"context"
+	"crypto/ecdh"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"gophkeeper/models"
+	"io"
+
+	"golang.org/x/crypto/hkdf"
+)
+
+const (
+	itemKeySize    = 32
+	wrapKeyInfo    = "gophkeeper item key"
+	x25519KeySize  = 32
+	wrappedMinSize = x25519KeySize + nonceSize
+)
+
+var errMalformedKey = errors.New("malformed encrypted key")
+
+// newItemKey generates a random item data key and returns it together with
+// its copy sealed by the master key.
+func (cs *CryptoService) newItemKey(ctx context.Context) ([]byte, string, error) {
+	key, err := newRandomKey()
+	if err != nil {
+		return nil, "", err
+	}
+
+	sealed, err := cs.sealWithMasterKey(ctx, key)
+	if err != nil {
+		return nil, "", err
+	}
+	return key, sealed, nil
+}
+
+func (cs *CryptoService) openItemKey(ctx context.Context, encryptedKey string) ([]byte, error) {
+	return cs.openWithMasterKey(ctx, encryptedKey)
+}
+
+func newRandomKey() ([]byte, error) {
+	key := make([]byte, itemKeySize)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return nil, fmt.Errorf("failed to generate key: %w", err)
+	}
+	return key, nil
+}
+
+// sealWithMasterKey encrypts a secret with the master key as base64(nonce|ciphertext).
+func (cs *CryptoService) sealWithMasterKey(ctx context.Context, secret []byte) (string, error) {
+	mk, err := cs.masterKey(ctx)
+	if err != nil {
+		return "", err
+	}
+	return sealKey(mk, secret)
+}
+
+func (cs *CryptoService) openWithMasterKey(ctx context.Context, sealed string) ([]byte, error) {
+	mk, err := cs.masterKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return openKey(mk, sealed)
+}
+
+// sealKey encrypts a secret with key as base64(nonce|ciphertext).
+func sealKey(key, secret []byte) (string, error) {
+	ciphertext, nonce, err := seal(key, secret)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(append(nonce, ciphertext...)), nil
+}
+
+func openKey(key []byte, sealed string) ([]byte, error) {
+	raw, err := base64.StdEncoding.DecodeString(sealed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode key: %w", err)
+	}
+	if len(raw) < nonceSize {
+		return nil, errMalformedKey
+	}
+	return open(key, raw[:nonceSize], raw[nonceSize:])
+}
+
+// generateUserKeys creates a new X25519 key pair with the private key sealed by the master key.
+func (cs *CryptoService) generateUserKeys(ctx context.Context) (*models.UserKeys, error) {
+	priv, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate key pair: %w", err)
+	}
+
+	sealed, err := cs.sealWithMasterKey(ctx, priv.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	return &models.UserKeys{
+		PublicKey:           priv.PublicKey().Bytes(),
+		EncryptedPrivateKey: sealed,
+	}, nil
+}
+
+// privateKey fetches the user's key pair from the server and opens the private key.
+func (cs *CryptoService) privateKey(ctx context.Context) (*ecdh.PrivateKey, error) {
+	keys, err := cs.Client.GetUserKeys(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get user keys error: %w", err)
+	}
+
+	raw, err := cs.openWithMasterKey(ctx, keys.EncryptedPrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("open private key error: %w", err)
+	}
+	return ecdh.X25519().NewPrivateKey(raw)
+}
+
+// wrapKeyFor encrypts an item data key for the owner of recipientPub.
+// An ephemeral X25519 key agreement is run through HKDF-SHA256 to get an
+// AES-GCM key; the result is base64(ephemeral public key|nonce|ciphertext).
+func wrapKeyFor(recipientPub, dataKey []byte) (string, error) {
+	pub, err := ecdh.X25519().NewPublicKey(recipientPub)
+	if err != nil {
+		return "", fmt.Errorf("invalid recipient public key: %w", err)
+	}
+
+	eph, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate ephemeral key: %w", err)
+	}
+
+	kek, err := deriveWrapKey(eph, pub, eph.PublicKey().Bytes(), recipientPub)
+	if err != nil {
+		return "", err
+	}
+
+	ciphertext, nonce, err := seal(kek, dataKey)
+	if err != nil {
+		return "", err
+	}
+
+	out := append(eph.PublicKey().Bytes(), nonce...)
+	return base64.StdEncoding.EncodeToString(append(out, ciphertext...)), nil
+}
+
+func unwrapKey(priv *ecdh.PrivateKey, wrapped string) ([]byte, error) {
+	raw, err := base64.StdEncoding.DecodeString(wrapped)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode wrapped key: %w", err)
+	}
+	if len(raw) < wrappedMinSize {
+		return nil, errMalformedKey
+	}
+
+	ephPub, err := ecdh.X25519().NewPublicKey(raw[:x25519KeySize])
+	if err != nil {
+		return nil, fmt.Errorf("invalid ephemeral key: %w", err)
+	}
+
+	kek, err := deriveWrapKey(priv, ephPub, raw[:x25519KeySize], priv.PublicKey().Bytes())
+	if err != nil {
+		return nil, err
+	}
+	return open(kek, raw[x25519KeySize:wrappedMinSize], raw[wrappedMinSize:])
+}
+
+// deriveWrapKey binds the key agreement result to both public keys.
+func deriveWrapKey(priv *ecdh.PrivateKey, peer *ecdh.PublicKey, ephPub, recipientPub []byte) ([]byte, error) {
+	shared, err := priv.ECDH(peer)
+	if err != nil {
+		return nil, fmt.Errorf("key agreement error: %w", err)
+	}
+
+	salt := append(append([]byte{}, ephPub...), recipientPub...)
+	kek := make([]byte, itemKeySize)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, shared, salt, []byte(wrapKeyInfo)), kek); err != nil {
+		return nil, fmt.Errorf("failed to derive wrap key: %w", err)
+	}
+	return kek, nil
+}
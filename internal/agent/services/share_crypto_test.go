@@ -0,0 +1,230 @@
+package services
+
+import (
+	"context"
+	"gophkeeper/config"
+	"gophkeeper/internal/errs"
+	"gophkeeper/models"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// shareServer keeps sharing state of several users in memory.
+type shareServer struct {
+	keys    map[string]*models.UserKeys
+	shares  map[string]models.ItemShare
+	edited  *models.EncryptedItem
+	revoked *models.ShareRevocation
+}
+
+// shareClient is a MockClient bound to one user of shareServer.
+type shareClient struct {
+	MockClient
+	login  string
+	server *shareServer
+}
+
+func (c *shareClient) SetUserKeys(ctx context.Context, keys *models.UserKeys) error {
+	c.server.keys[c.login] = keys
+	return nil
+}
+
+func (c *shareClient) GetUserKeys(ctx context.Context) (*models.UserKeys, error) {
+	keys, ok := c.server.keys[c.login]
+	if !ok {
+		return nil, errs.ErrKeysNotFound
+	}
+	return keys, nil
+}
+
+func (c *shareClient) GetPublicKey(ctx context.Context, login string) ([]byte, error) {
+	keys, ok := c.server.keys[login]
+	if !ok {
+		return nil, errs.ErrKeysNotFound
+	}
+	return keys.PublicKey, nil
+}
+
+func (c *shareClient) EditItem(ctx context.Context, item *models.EncryptedItem) error {
+	c.server.edited = item
+	return nil
+}
+
+func (c *shareClient) ShareItem(ctx context.Context, share *models.ItemShare) error {
+	c.server.shares[share.RecipientLogin] = *share
+	return nil
+}
+
+func (c *shareClient) ListItemShares(ctx context.Context, itemID [16]byte) ([]models.ItemShare, error) {
+	var shares []models.ItemShare
+	for _, s := range c.server.shares {
+		shares = append(shares, s)
+	}
+	return shares, nil
+}
+
+func (c *shareClient) RevokeShare(ctx context.Context, rev *models.ShareRevocation) error {
+	c.server.revoked = rev
+	delete(c.server.shares, rev.RecipientLogin)
+	for _, s := range rev.Shares {
+		c.server.shares[s.RecipientLogin] = s
+	}
+	return nil
+}
+
+func newShareUser(t *testing.T, server *shareServer, login, masterPassword string) (*UserService, *ItemService) {
+	ctx := context.Background()
+	t.Helper()
+	cnfg, err := config.NewAgentConfig()
+	require.NoError(t, err)
+	require.NoError(t, cnfg.SetSalt([]byte("salt-"+login)))
+
+	client := &shareClient{login: login, server: server}
+	cs, err := NewCryptoService(cnfg, client)
+	require.NoError(t, err)
+	us, err := NewUserService(cnfg, client, cs)
+	require.NoError(t, err)
+	is, err := NewItemService(client, cs)
+	require.NoError(t, err)
+
+	require.NoError(t, us.SetMasterKey(ctx, masterPassword))
+	require.NoError(t, us.EnsureUserKeys(context.Background()))
+	return us, is
+}
+
+func TestWrapKeyFor(t *testing.T) {
+	server := &shareServer{keys: map[string]*models.UserKeys{}, shares: map[string]models.ItemShare{}}
+	_, bob := newShareUser(t, server, "bob", "bob-master")
+
+	dataKey := []byte("0123456789abcdef0123456789abcdef")
+	wrapped, err := wrapKeyFor(server.keys["bob"].PublicKey, dataKey)
+	require.NoError(t, err)
+
+	priv, err := bob.Crypto.privateKey(context.Background())
+	require.NoError(t, err)
+	unwrapped, err := unwrapKey(priv, wrapped)
+	require.NoError(t, err)
+	assert.Equal(t, dataKey, unwrapped)
+
+	_, err = unwrapKey(priv, "c2hvcnQ=")
+	assert.ErrorIs(t, err, errMalformedKey)
+}
+
+func TestEnsureUserKeys_WrongMasterPassword(t *testing.T) {
+	ctx := context.Background()
+	server := &shareServer{keys: map[string]*models.UserKeys{}, shares: map[string]models.ItemShare{}}
+	us, _ := newShareUser(t, server, "alice", "alice-master")
+
+	require.NoError(t, us.SetMasterKey(ctx, "wrong"))
+	assert.ErrorIs(t, us.EnsureUserKeys(context.Background()), errs.ErrIncorrectMasterPassword)
+}
+
+func TestItemService_ShareAndRevoke(t *testing.T) {
+	server := &shareServer{keys: map[string]*models.UserKeys{}, shares: map[string]models.ItemShare{}}
+	_, alice := newShareUser(t, server, "alice", "alice-master")
+	_, bob := newShareUser(t, server, "bob", "bob-master")
+	_, carol := newShareUser(t, server, "carol", "carol-master")
+	ctx := context.Background()
+
+	// Legacy item encrypted directly with the master key.
+	encData, err := alice.Crypto.encryptItemData(ctx, &models.Text{Content: "secret"})
+	require.NoError(t, err)
+	legacy, err := alice.DecryptItem(ctx, &models.EncryptedItem{
+		ID:            [16]byte{1},
+		UserLogin:     "alice",
+		Name:          "note",
+		Type:          models.ItemTypeTEXT,
+		EncryptedData: *encData,
+	})
+	require.NoError(t, err)
+	require.Empty(t, legacy.EncryptedKey)
+
+	require.NoError(t, alice.ShareItem(ctx, legacy, "bob"))
+	require.NoError(t, alice.ShareItem(ctx, legacy, "carol"))
+	require.NotNil(t, server.edited, "legacy item must be converted to an item key")
+	assert.NotEmpty(t, legacy.EncryptedKey)
+
+	sharedWith := func(login string) *models.SharedItem {
+		return &models.SharedItem{Item: *server.edited, WrappedKey: server.shares[login].WrappedKey}
+	}
+	got, err := bob.DecryptSharedItem(ctx, sharedWith("bob"))
+	require.NoError(t, err)
+	assert.Equal(t, "secret", got.Data.(*models.Text).Content)
+
+	bobKey := server.shares["bob"].WrappedKey
+	require.NoError(t, alice.RevokeShare(ctx, legacy, "bob"))
+	require.NotNil(t, server.revoked)
+	require.Len(t, server.revoked.Shares, 1)
+	assert.Equal(t, "carol", server.revoked.Shares[0].RecipientLogin)
+	assert.NotEqual(t, server.edited.EncryptedKey, server.revoked.Item.EncryptedKey)
+
+	// Carol reads the re-keyed item, Bob's old wrapped key no longer opens it.
+	rekeyed := server.revoked.Item
+	got, err = carol.DecryptSharedItem(ctx, &models.SharedItem{Item: rekeyed, WrappedKey: server.shares["carol"].WrappedKey})
+	require.NoError(t, err)
+	assert.Equal(t, "secret", got.Data.(*models.Text).Content)
+
+	_, err = bob.DecryptSharedItem(ctx, &models.SharedItem{Item: rekeyed, WrappedKey: bobKey})
+	assert.Error(t, err)
+
+	// Owner still decrypts the item with the new key.
+	owned, err := alice.DecryptItem(ctx, &rekeyed)
+	require.NoError(t, err)
+	assert.Equal(t, "secret", owned.Data.(*models.Text).Content)
+}
+
+func TestItemService_KeyPins(t *testing.T) {
+	server := &shareServer{keys: map[string]*models.UserKeys{}, shares: map[string]models.ItemShare{}}
+	_, alice := newShareUser(t, server, "alice", "alice-master")
+	_, bob := newShareUser(t, server, "bob", "bob-master")
+	newShareUser(t, server, "carol", "carol-master")
+	ctx := context.Background()
+
+	path := filepath.Join(t.TempDir(), "known_keys.json")
+	pins, err := LoadKeyPins(path)
+	require.NoError(t, err)
+	alice.SetKeyPins(pins)
+
+	dataKey, sealedKey, err := alice.Crypto.newItemKey(ctx)
+	require.NoError(t, err)
+	encData, err := encryptWithKey(dataKey, &models.Text{Content: "secret"})
+	require.NoError(t, err)
+	item, err := alice.DecryptItem(ctx, &models.EncryptedItem{
+		ID: [16]byte{1}, UserLogin: "alice", Name: "note", Type: models.ItemTypeTEXT,
+		EncryptedData: *encData, EncryptedKey: sealedKey,
+	})
+	require.NoError(t, err)
+
+	// The first share waits for the fingerprint to be confirmed.
+	assert.ErrorIs(t, alice.ShareItem(ctx, item, "bob"), errs.ErrKeyNotVerified)
+	fingerprint, err := alice.RecipientKey(ctx, "bob")
+	assert.ErrorIs(t, err, errs.ErrKeyNotVerified)
+	own, err := bob.OwnKeyFingerprint(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, own, fingerprint)
+	assert.Len(t, fingerprint, 39)
+
+	assert.ErrorIs(t, alice.VerifyRecipientKey(ctx, "bob", "0000"), errs.ErrKeyChanged)
+	require.NoError(t, alice.VerifyRecipientKey(ctx, "bob", fingerprint))
+	require.NoError(t, alice.ShareItem(ctx, item, "bob"))
+	_, err = alice.RecipientKey(ctx, "bob")
+	require.NoError(t, err)
+
+	reloaded, err := LoadKeyPins(path)
+	require.NoError(t, err)
+	assert.NoError(t, reloaded.check("bob", server.keys["bob"].PublicKey))
+
+	// A key the server swaps in is refused.
+	server.keys["bob"] = server.keys["carol"]
+	assert.ErrorIs(t, alice.ShareItem(ctx, item, "bob"), errs.ErrKeyChanged)
+	_, err = alice.RecipientKey(ctx, "bob")
+	assert.ErrorIs(t, err, errs.ErrKeyChanged)
+
+	// Recipients shared with before pinning get pinned on revoke.
+	server.shares["carol"] = models.ItemShare{ItemID: item.ID, RecipientLogin: "carol"}
+	require.NoError(t, alice.RevokeShare(ctx, item, "bob"))
+	assert.NoError(t, pins.check("carol", server.keys["carol"].PublicKey))
+}
@@ -0,0 +1,224 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"gophkeeper/internal/errs"
+	"gophkeeper/models"
+)
+
+var errCollectionItemShare = errors.New("organization items are shared through collection membership")
+
+// SetKeyPins turns on pinning of recipient keys. Without pins any key the
+// server returns is used.
+func (is *ItemService) SetKeyPins(pins *KeyPins) {
+	is.pins = pins
+}
+
+// OwnKeyFingerprint returns the fingerprint of the user's sharing key, the
+// one others compare before their first share. It is taken from the
+// private key, not from what the server says the public key is.
+func (is *ItemService) OwnKeyFingerprint(ctx context.Context) (string, error) {
+	priv, err := is.Crypto.privateKey(ctx)
+	if err != nil {
+		return "", err
+	}
+	return keyFingerprint(priv.PublicKey().Bytes()), nil
+}
+
+// RecipientKey returns the fingerprint of the sharing key of login and
+// whether it is the pinned one. A key that is not pinned yet wraps
+// errs.ErrKeyNotVerified, a key other than the pinned one
+// errs.ErrKeyChanged.
+func (is *ItemService) RecipientKey(ctx context.Context, login string) (string, error) {
+	pub, err := is.Client.GetPublicKey(ctx, login)
+	if err != nil {
+		return "", fmt.Errorf("get public key of %s error: %w", login, err)
+	}
+	fingerprint := keyFingerprint(pub)
+	if is.pins == nil {
+		return fingerprint, nil
+	}
+	return fingerprint, is.pins.check(login, pub)
+}
+
+// VerifyRecipientKey pins the key of login once the user confirmed its
+// fingerprint. The key is fetched again and must still have it.
+func (is *ItemService) VerifyRecipientKey(ctx context.Context, login, fingerprint string) error {
+	pub, err := is.Client.GetPublicKey(ctx, login)
+	if err != nil {
+		return fmt.Errorf("get public key of %s error: %w", login, err)
+	}
+	if keyFingerprint(pub) != fingerprint {
+		return fmt.Errorf("%s: %w", login, errs.ErrKeyChanged)
+	}
+	if is.pins == nil {
+		return nil
+	}
+	return is.pins.pin(login, pub)
+}
+
+// ShareItem gives recipient access to the item. Items saved before item keys
+// were introduced are re-encrypted with a fresh data key first. With key
+// pins the key of recipient must be verified with VerifyRecipientKey.
+func (is *ItemService) ShareItem(ctx context.Context, item *models.Item, recipient string) error {
+	if item.CollectionID != [16]byte{} {
+		return errCollectionItemShare
+	}
+	if item.EncryptedKey == "" {
+		encItem, err := is.Crypto.encryptItem(ctx, item)
+		if err != nil {
+			return fmt.Errorf("encrypt item error: %w", err)
+		}
+		if err := is.Client.EditItem(ctx, encItem); err != nil {
+			return err
+		}
+		item.EncryptedKey = encItem.EncryptedKey
+	}
+
+	dataKey, err := is.Crypto.openItemKey(ctx, item.EncryptedKey)
+	if err != nil {
+		return fmt.Errorf("open item key error: %w", err)
+	}
+
+	wrapped, err := is.wrapKeyForUser(ctx, recipient, dataKey, false)
+	if err != nil {
+		return err
+	}
+
+	return is.Client.ShareItem(ctx, &models.ItemShare{
+		ItemID:         item.ID,
+		RecipientLogin: recipient,
+		WrappedKey:     wrapped,
+	})
+}
+
+func (is *ItemService) ListItemShares(ctx context.Context, itemID [16]byte) ([]models.ItemShare, error) {
+	return is.Client.ListItemShares(ctx, itemID)
+}
+
+func (is *ItemService) ListSharedWithMe(ctx context.Context) ([]models.SharedItem, error) {
+	shared, err := is.Client.ListSharedWithMe(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	failed := ItemErrors{}
+	for i := range shared {
+		if err := is.revealSharedItem(ctx, &shared[i]); err != nil {
+			failed[shared[i].Item.ID] = fmt.Errorf("decrypt shared item error: %w", err)
+		}
+	}
+	return shared, failed.err()
+}
+
+// revealSharedItem fills in the name and metadata of a shared item like
+// revealItem does.
+func (is *ItemService) revealSharedItem(ctx context.Context, shared *models.SharedItem) error {
+	switch shared.Item.Format {
+	case models.ItemFormatV2:
+		item, err := is.DecryptSharedItem(ctx, shared)
+		if err != nil {
+			return err
+		}
+		shared.Item.Name, shared.Item.Meta = item.Name, item.Meta
+	case models.ItemFormatV3:
+		dataKey, err := is.sharedItemKey(ctx, shared)
+		if err != nil {
+			return err
+		}
+		header, err := openHeader(dataKey, &shared.Item)
+		if err != nil {
+			return err
+		}
+		shared.Item.Name, shared.Item.Meta = header.Name, header.Meta
+	}
+	return nil
+}
+
+func (is *ItemService) DecryptSharedItem(ctx context.Context, shared *models.SharedItem) (*models.Item, error) {
+	dataKey, err := is.sharedItemKey(ctx, shared)
+	if err != nil {
+		return nil, err
+	}
+	return decryptItemWithKey(dataKey, &shared.Item)
+}
+
+func (is *ItemService) sharedItemKey(ctx context.Context, shared *models.SharedItem) ([]byte, error) {
+	priv, err := is.Crypto.privateKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+	dataKey, err := unwrapKey(priv, shared.WrappedKey)
+	if err != nil {
+		return nil, fmt.Errorf("unwrap item key error: %w", err)
+	}
+	return dataKey, nil
+}
+
+// RevokeShare removes recipient from the item shares. The item is
+// re-encrypted with a new data key that is wrapped only for the remaining
+// recipients, so the removed one cannot read later edits. Recipients shared
+// with before keys were pinned get their current key pinned. Attachment
+// infos are re-sealed with the new key in the same revocation.
+func (is *ItemService) RevokeShare(ctx context.Context, item *models.Item, recipient string) error {
+	shares, err := is.Client.ListItemShares(ctx, item.ID)
+	if err != nil {
+		return err
+	}
+
+	oldKey, err := is.itemKey(ctx, item)
+	if err != nil {
+		return err
+	}
+	dataKey, sealedKey, err := is.Crypto.newItemKey(ctx)
+	if err != nil {
+		return err
+	}
+	attachments, err := resealAttachments(ctx, is.Client, item.ID, oldKey, dataKey)
+	if err != nil {
+		return err
+	}
+	encItem, err := sealItem(dataKey, sealedKey, item)
+	if err != nil {
+		return fmt.Errorf("encrypt item error: %w", err)
+	}
+	encItem.SearchTokens, err = is.Crypto.searchTokens(ctx, item)
+	if err != nil {
+		return fmt.Errorf("compute search tokens error: %w", err)
+	}
+
+	rev := &models.ShareRevocation{
+		ItemID:         item.ID,
+		RecipientLogin: recipient,
+		Item:           *encItem,
+		Attachments:    attachments,
+	}
+	for _, s := range shares {
+		if s.RecipientLogin == recipient {
+			continue
+		}
+		wrapped, err := is.wrapKeyForUser(ctx, s.RecipientLogin, dataKey, true)
+		if err != nil {
+			return err
+		}
+		rev.Shares = append(rev.Shares, models.ItemShare{
+			ItemID:         item.ID,
+			RecipientLogin: s.RecipientLogin,
+			WrappedKey:     wrapped,
+		})
+	}
+
+	if err := is.Client.RevokeShare(ctx, rev); err != nil {
+		return err
+	}
+	item.EncryptedKey = sealedKey
+	return nil
+}
+
+// wrapKeyForUser wraps dataKey for the pinned key of login. With pinUnknown
+// a key that is not pinned yet is trusted and pinned.
+func (is *ItemService) wrapKeyForUser(ctx context.Context, login string, dataKey []byte, pinUnknown bool) (string, error) {
+	return wrapKeyForLogin(ctx, is.Client, is.pins, login, dataKey, pinUnknown)
+}
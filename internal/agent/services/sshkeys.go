@@ -0,0 +1,32 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"gophkeeper/internal/telemetry"
+	"gophkeeper/models"
+)
+
+// SSHKeys returns the decrypted SSH_KEY items of the user. The ssh-agent
+// serves personal keys only, whichever vault is open in the UI.
+func (is *ItemService) SSHKeys(ctx context.Context, login string) (_ []*models.Item, err error) {
+	ctx, span := tracer.Start(ctx, "ItemService.SSHKeys")
+	defer telemetry.End(span, &err)
+
+	items, err := is.Client.GetItems(ctx, login, models.ItemTypeSSHKEY)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]*models.Item, 0, len(items))
+	for i := range items {
+		item, err := is.Crypto.decryptItem(ctx, &items[i])
+		if err != nil {
+			return nil, fmt.Errorf("decrypt item error: %w", err)
+		}
+		if _, ok := item.Data.(*models.SSHKey); ok {
+			keys = append(keys, item)
+		}
+	}
+	return keys, nil
+}
@@ -0,0 +1,30 @@
+package services
+
+import (
+	"context"
+	"gophkeeper/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestItemService_SSHKeys(t *testing.T) {
+	server := &shareServer{keys: map[string]*models.UserKeys{}}
+	_, is := newShareUser(t, server, "alice", "alice-master")
+	is.Client = &breachClient{searchClient{shareClient: shareClient{login: "alice", server: server}}}
+	ctx := context.Background()
+
+	for _, item := range []*models.Item{
+		{Name: "GitHub", Type: models.ItemTypeSSHKEY, Data: &models.SSHKey{PrivateKey: "key", Fingerprint: "SHA256:abc"}},
+		{Name: "Mail", Type: models.ItemTypeCREDENTIALS, Data: &models.Credentials{Login: "alice", Password: "pw"}},
+	} {
+		require.NoError(t, is.AddItem(ctx, item))
+	}
+
+	keys, err := is.SSHKeys(ctx, "alice")
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	assert.Equal(t, "GitHub", keys[0].Name)
+	assert.Equal(t, "SHA256:abc", keys[0].Data.(*models.SSHKey).Fingerprint)
+}
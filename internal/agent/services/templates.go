@@ -0,0 +1,67 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"gophkeeper/internal/telemetry"
+	"gophkeeper/models"
+)
+
+// SaveTemplate encrypts the template with the master key and stores it.
+// A template without an id gets a new random one.
+func (is *ItemService) SaveTemplate(ctx context.Context, t *models.ItemTemplate) (err error) {
+	ctx, span := tracer.Start(ctx, "ItemService.SaveTemplate")
+	defer telemetry.End(span, &err)
+
+	if err := t.Validate(); err != nil {
+		return err
+	}
+	if t.ID == ([16]byte{}) {
+		if _, err := rand.Read(t.ID[:]); err != nil {
+			return fmt.Errorf("generate template id error: %w", err)
+		}
+	}
+	mk, err := is.Crypto.masterKey(ctx)
+	if err != nil {
+		return err
+	}
+	data, err := encryptWithKey(mk, t)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt template: %w", err)
+	}
+	return is.Client.SaveTemplate(ctx, &models.Template{ID: t.ID, EncryptedData: *data})
+}
+
+// ListTemplates returns the decrypted templates of the user.
+func (is *ItemService) ListTemplates(ctx context.Context) (_ []models.ItemTemplate, err error) {
+	ctx, span := tracer.Start(ctx, "ItemService.ListTemplates")
+	defer telemetry.End(span, &err)
+
+	encrypted, err := is.Client.ListTemplates(ctx)
+	if err != nil {
+		return nil, err
+	}
+	mk, err := is.Crypto.masterKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	templates := make([]models.ItemTemplate, len(encrypted))
+	for i := range encrypted {
+		if err := decryptWithKey(mk, &encrypted[i].EncryptedData, &templates[i]); err != nil {
+			return nil, fmt.Errorf("failed to decrypt template: %w", err)
+		}
+		templates[i].ID = encrypted[i].ID
+		templates[i].CreatedAt = encrypted[i].CreatedAt
+		templates[i].UpdatedAt = encrypted[i].UpdatedAt
+	}
+	return templates, nil
+}
+
+func (is *ItemService) DeleteTemplate(ctx context.Context, id [16]byte) (err error) {
+	ctx, span := tracer.Start(ctx, "ItemService.DeleteTemplate")
+	defer telemetry.End(span, &err)
+
+	return is.Client.DeleteTemplate(ctx, id)
+}
@@ -0,0 +1,50 @@
+package services
+
+import (
+	"context"
+	"gophkeeper/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type templateClient struct {
+	shareClient
+	templates []models.Template
+}
+
+func (c *templateClient) SaveTemplate(ctx context.Context, t *models.Template) error {
+	c.templates = append(c.templates, *t)
+	return nil
+}
+
+func (c *templateClient) ListTemplates(ctx context.Context) ([]models.Template, error) {
+	return c.templates, nil
+}
+
+func TestItemService_Templates(t *testing.T) {
+	server := &shareServer{keys: map[string]*models.UserKeys{}, shares: map[string]models.ItemShare{}}
+	_, is := newShareUser(t, server, "alice", "alice-master")
+	client := &templateClient{shareClient: *is.Client.(*shareClient)}
+	is.Client = client
+	ctx := context.Background()
+
+	assert.ErrorIs(t, is.SaveTemplate(ctx, &models.ItemTemplate{Name: "Wi-Fi"}), models.ErrTemplateNoFields)
+
+	tmpl := &models.ItemTemplate{Name: "Wi-Fi", Fields: []models.TemplateField{
+		{Name: "SSID", Type: models.FieldText, Required: true},
+		{Name: "Password", Type: models.FieldHidden},
+	}}
+	require.NoError(t, is.SaveTemplate(ctx, tmpl))
+	assert.NotEqual(t, [16]byte{}, tmpl.ID)
+	require.Len(t, client.templates, 1)
+	assert.NotContains(t, client.templates[0].EncryptedData.EncryptedContent, "SSID")
+
+	templates, err := is.ListTemplates(ctx)
+	require.NoError(t, err)
+	require.Len(t, templates, 1)
+	assert.Equal(t, tmpl.ID, templates[0].ID)
+	assert.Equal(t, "Wi-Fi", templates[0].Name)
+	assert.Equal(t, tmpl.Fields, templates[0].Fields)
+}
@@ -10,10 +10,15 @@ import (
 	"gophkeeper/config"
 	"gophkeeper/internal/agent/client"
 	cserv "gophkeeper/internal/server/services/crypto_service"
+	"gophkeeper/internal/telemetry"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/crypto/pbkdf2"
 )
 
+const pbkdf2Iterations = 10000
+
 type CryptoService struct {
 	Client client.Client
 	cnfg   config.AgentCryptoServiceConfig
@@ -58,7 +63,7 @@ func (cs *CryptoService) encryptData(data []byte) ([]byte, error) {
 	)
 }
 
-func (cs *CryptoService) setSalt(salt string) error {
+func (cs *CryptoService) setSalt(ctx context.Context, salt string) error {
 	var err error
 	saltEnc, err := base64.StdEncoding.DecodeString(salt)
 	if err != nil {
@@ -73,7 +78,7 @@ func (cs *CryptoService) setSalt(salt string) error {
 		return fmt.Errorf("get master key error: %w", err)
 	}
 	if len(mk) > 0 {
-		mc, err := cs.generateMasterKey()
+		mc, err := cs.generateMasterKey(ctx)
 		if err != nil {
 			return fmt.Errorf("generate master key error: %w", err)
 		}
@@ -85,8 +90,8 @@ func (cs *CryptoService) setSalt(salt string) error {
 	return nil
 }
 
-func (cs *CryptoService) setMasterPassword(masterPassword string) error {
-	if err := cs.cnfg.SetMasterPassword(masterPassword); err != nil {
+func (cs *CryptoService) setMasterPassword(ctx context.Context, masterPassword string) error {
+	if err := cs.cnfg.SetMasterPassword([]byte(masterPassword)); err != nil {
 		return fmt.Errorf("set master password error: %w", err)
 	}
 	mk, err := cs.cnfg.GetMasterKey()
@@ -94,7 +99,7 @@ func (cs *CryptoService) setMasterPassword(masterPassword string) error {
 		return fmt.Errorf("get master key error: %w", err)
 	}
 	if len(mk) > 0 {
-		mc, err := cs.generateMasterKey()
+		mc, err := cs.generateMasterKey(ctx)
 		if err != nil {
 			return fmt.Errorf("generate master key error: %w", err)
 		}
@@ -105,7 +110,13 @@ func (cs *CryptoService) setMasterPassword(masterPassword string) error {
 	return nil
 }
 
-func (cs *CryptoService) generateMasterKey() ([]byte, error) {
+func (cs *CryptoService) generateMasterKey(ctx context.Context) (_ []byte, err error) {
+	_, span := tracer.Start(ctx, "CryptoService.generateMasterKey", trace.WithAttributes(
+		attribute.String("kdf.algorithm", "pbkdf2-sha256"),
+		attribute.Int("kdf.iterations", pbkdf2Iterations),
+	))
+	defer telemetry.End(span, &err)
+
 	mp, err := cs.cnfg.GetMasterPassword()
 	if err != nil {
 		return nil, fmt.Errorf("get master password error: %w", err)
@@ -114,9 +125,13 @@ func (cs *CryptoService) generateMasterKey() ([]byte, error) {
 	if err != nil {
 		return nil, fmt.Errorf("get salt error: %w", err)
 	}
-	mk := pbkdf2.Key([]byte(mp), salt, 10000, 32, sha256.New)
+	mk := deriveMasterKey(mp, salt)
 	if err := cs.cnfg.SetMasterKey(mk); err != nil {
 		return nil, fmt.Errorf("set master key error: %w", err)
 	}
 	return mk, nil
 }
+
+func deriveMasterKey(masterPassword, salt []byte) []byte {
+	return pbkdf2.Key(masterPassword, salt, pbkdf2Iterations, 32, sha256.New)
+}
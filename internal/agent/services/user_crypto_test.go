@@ -1,6 +1,7 @@
 package services
 
 import (
+	"context"
 	"github.com/stretchr/testify/require"
 	"gophkeeper/config"
 	"testing"
@@ -31,6 +32,7 @@ func TestCryptoService_SetPublicKey_NilClient(t *testing.T) {
 }
 
 func TestCryptoService_SetSalt_InvalidBase64(t *testing.T) {
+	ctx := context.Background()
 	cnfg, err := config.NewAgentConfig()
 	require.NoError(t, err)
 
@@ -39,47 +41,51 @@ func TestCryptoService_SetSalt_InvalidBase64(t *testing.T) {
 		cnfg:   cnfg,
 	}
 
-	err = service.setSalt("invalid-base64!")
+	err = service.setSalt(ctx, "invalid-base64!")
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "set salt error")
 }
 
 func TestCryptoService_SetMasterPassword_NilService(t *testing.T) {
+	ctx := context.Background()
 	var service *CryptoService = nil
 
 	assert.Panics(t, func() {
-		service.setMasterPassword("test-password")
+		service.setMasterPassword(ctx, "test-password")
 	})
 }
 
 func TestCryptoService_SetMasterPassword_NilConfig(t *testing.T) {
+	ctx := context.Background()
 	service := &CryptoService{
 		Client: &MockClient{},
 		cnfg:   nil,
 	}
 
 	assert.Panics(t, func() {
-		service.setMasterPassword("test-password")
+		service.setMasterPassword(ctx, "test-password")
 	})
 }
 
 func TestCryptoService_GenerateMasterKey_NilService(t *testing.T) {
+	ctx := context.Background()
 	var service *CryptoService = nil
 
 	assert.Panics(t, func() {
-		service.generateMasterKey()
+		service.generateMasterKey(ctx)
 	})
 }
 
 func TestCryptoService_GenerateMasterKey_NilConfig(t *testing.T) {
+	ctx := context.Background()
 	service := &CryptoService{
 		Client: &MockClient{},
 		cnfg:   nil,
 	}
 
 	assert.Panics(t, func() {
-		service.generateMasterKey()
+		service.generateMasterKey(ctx)
 	})
 }
 
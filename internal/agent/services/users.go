@@ -3,17 +3,24 @@ package services
 import (
 	"context"
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"gophkeeper/config"
 	"gophkeeper/internal/agent/client"
 	"gophkeeper/internal/errs"
 	"gophkeeper/models"
+	"time"
 )
 
 type UserService struct {
 	Client client.Client
 	crypto *CryptoService
 	cnfg   config.AgentUserServiceConfig
+	device *DeviceIdentity
+
+	sessions *SessionStore
+	// session is the one of the last sign in, kept to be saved.
+	session *Session
 }
 
 func NewUserService(cnfg config.AgentUserServiceConfig, client client.Client, cr *CryptoService) (*UserService, error) {
@@ -36,10 +43,11 @@ func (us *UserService) SignUpUser(ctx context.Context, user *models.User) error
 
 	user.Password = []byte(base64.StdEncoding.EncodeToString(encryptedPassword))
 
-	token, salt, err := us.Client.SignUpUser(ctx, user)
+	token, salt, err := us.Client.SignUpUser(ctx, user, us.device.SignIn(user.Login, string(user.Password)))
 	if err != nil {
 		return fmt.Errorf("server failed to sign up user: %w", err)
 	}
+	us.session = &Session{Login: user.Login, Token: token, Salt: salt}
 
 	if err = us.cnfg.SetSalt([]byte(salt)); err != nil {
 		return err
@@ -64,11 +72,12 @@ func (us *UserService) SignInUser(ctx context.Context, user *models.User) error
 
 	user.Password = []byte(base64.StdEncoding.EncodeToString(encryptedPassword))
 
-	token, salt, err := us.Client.SignInUser(ctx, user)
+	token, salt, err := us.Client.SignInUser(ctx, user, us.device.SignIn(user.Login, string(user.Password)))
 	if err != nil {
 		return fmt.Errorf("server failed to sign in user: %w", err)
 	}
-	err = us.crypto.setSalt(salt)
+	us.session = &Session{Login: user.Login, Token: token, Salt: salt}
+	err = us.crypto.setSalt(ctx, salt)
 	if err != nil {
 		return err
 	}
@@ -80,22 +89,121 @@ func (us *UserService) SignInUser(ctx context.Context, user *models.User) error
 	return nil
 }
 
-func (us *UserService) SetMasterKey(masterPassword string) error {
-	if err := us.cnfg.SetMasterPassword(masterPassword); err != nil {
+func (us *UserService) SetMasterKey(ctx context.Context, masterPassword string) error {
+	if err := us.cnfg.SetMasterPassword([]byte(masterPassword)); err != nil {
 		return err
 	}
-	masterKey, err := us.crypto.generateMasterKey()
+	masterKey, err := us.crypto.generateMasterKey(ctx)
 	if err != nil {
 		return err
 	}
 	return us.cnfg.SetMasterKey(masterKey)
 }
 
+// EnsureUserKeys creates the sharing key pair on first unlock. For an
+// existing pair it checks that the master password opens the private key.
+func (us *UserService) EnsureUserKeys(ctx context.Context) error {
+	keys, err := us.Client.GetUserKeys(ctx)
+	if errors.Is(err, errs.ErrKeysNotFound) {
+		keys, err = us.crypto.generateUserKeys(ctx)
+		if err != nil {
+			return err
+		}
+		return us.Client.SetUserKeys(ctx, keys)
+	}
+	if err != nil {
+		return err
+	}
+
+	if _, err := us.crypto.openWithMasterKey(ctx, keys.EncryptedPrivateKey); err != nil {
+		return errs.ErrIncorrectMasterPassword
+	}
+	return nil
+}
+
+// SetSessionStore turns on saved sessions.
+func (us *UserService) SetSessionStore(store *SessionStore) {
+	us.sessions = store
+}
+
+// HasSavedSession reports whether sign in can be skipped with a saved
+// session.
+func (us *UserService) HasSavedSession() bool {
+	return us.sessions != nil && us.sessions.Exists()
+}
+
+// SessionNeedsPIN reports whether saving or resuming the session asks for
+// a PIN, there is none to ask for with a keyfile.
+func (us *UserService) SessionNeedsPIN() bool {
+	return us.sessions != nil && us.sessions.NeedsPIN()
+}
+
+// CanRememberSession reports whether there is a session to save. Sign ins
+// made offline have no token worth saving.
+func (us *UserService) CanRememberSession() bool {
+	return us.sessions != nil && us.session != nil && !us.session.expired(time.Now())
+}
+
+// RememberSession saves the session of the last sign in, sealed with pin.
+func (us *UserService) RememberSession(pin string) error {
+	if !us.CanRememberSession() {
+		return errors.New("no session to remember")
+	}
+	return us.sessions.Save(pin, us.session)
+}
+
+// ResumeSession signs in with the saved session and returns its login. The
+// master password is still needed to unlock the vault.
+func (us *UserService) ResumeSession(ctx context.Context, pin string) (string, error) {
+	if us.sessions == nil {
+		return "", errors.New("saved sessions are off")
+	}
+	session, err := us.sessions.Load(pin)
+	if err != nil {
+		return "", err
+	}
+	if err := us.crypto.setSalt(ctx, session.Salt); err != nil {
+		return "", err
+	}
+	if err := us.Client.SetJWTToken(session.Token); err != nil {
+		return "", err
+	}
+	us.session = session
+	return session.Login, nil
+}
+
+// ForgetDevice removes the saved session, the next start signs in again.
+func (us *UserService) ForgetDevice() error {
+	if us.sessions == nil {
+		return nil
+	}
+	return us.sessions.Forget()
+}
+
+// Lock wipes the master key and password from memory, the account stays
+// signed in. SetMasterKey unlocks the vault again.
+func (us *UserService) Lock() error {
+	mk, err := us.cnfg.GetMasterKey()
+	if err != nil {
+		return err
+	}
+	clear(mk)
+	if err := us.cnfg.SetMasterKey(nil); err != nil {
+		return err
+	}
+	return us.cnfg.SetMasterPassword(nil)
+}
+
+// Logout locks the vault and forgets the saved session.
 func (us *UserService) Logout() error {
+	us.session = nil
+	if err := us.ForgetDevice(); err != nil {
+		return err
+	}
 	if err := us.cnfg.SetMasterKey(nil); err != nil {
 		return err
 	}
-	if err := us.cnfg.SetMasterPassword(""); err != nil {
+	if err := us.cnfg.SetMasterPassword(nil); err != nil {
 		return err
 	}
 	if err := us.cnfg.SetSalt(nil); err != nil {
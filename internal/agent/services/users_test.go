@@ -142,14 +142,16 @@ func TestUserService_SignInUser_NilPassword(t *testing.T) {
 }
 
 func TestUserService_SetMasterKey_NilService(t *testing.T) {
+	ctx := context.Background()
 	var service *UserService = nil
 
 	assert.Panics(t, func() {
-		service.SetMasterKey("test-password")
+		service.SetMasterKey(ctx, "test-password")
 	})
 }
 
 func TestUserService_SetMasterKey_NilCrypto(t *testing.T) {
+	ctx := context.Background()
 	cnfg, err := config.NewAgentConfig()
 	require.NoError(t, err)
 
@@ -160,7 +162,7 @@ func TestUserService_SetMasterKey_NilCrypto(t *testing.T) {
 	}
 
 	assert.Panics(t, func() {
-		service.SetMasterKey("test-password")
+		service.SetMasterKey(ctx, "test-password")
 	})
 }
 
@@ -183,3 +185,24 @@ func TestUserService_Logout_NilConfig(t *testing.T) {
 		service.Logout()
 	})
 }
+
+func TestUserService_Lock(t *testing.T) {
+	cnfg, err := config.NewAgentConfig()
+	require.NoError(t, err)
+	service, err := NewUserService(cnfg, &MockClient{}, &CryptoService{})
+	require.NoError(t, err)
+
+	key := []byte("0123456789abcdef")
+	require.NoError(t, cnfg.SetMasterKey(key))
+	password := []byte("master")
+	require.NoError(t, cnfg.SetMasterPassword(password))
+
+	require.NoError(t, service.Lock())
+	assert.Equal(t, make([]byte, 16), key)
+	assert.Equal(t, make([]byte, 6), password)
+	mk, err := cnfg.GetMasterKey()
+	require.NoError(t, err)
+	assert.Nil(t, mk)
+	_, err = cnfg.GetMasterPassword()
+	assert.Error(t, err)
+}
@@ -0,0 +1,252 @@
+// Package sshagent serves the SSH_KEY items of the vault over the ssh-agent
+// protocol. Keys are read from the vault on every request and never leave
+// the process, each signature has to be confirmed by the user.
+package sshagent
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"gophkeeper/internal/logger"
+	"gophkeeper/internal/sshkeys"
+	"gophkeeper/models"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+)
+
+// DefaultConfirmTimeout is how long a sign request waits for the user.
+const DefaultConfirmTimeout = 30 * time.Second
+
+var (
+	errReadOnly    = errors.New("keys are managed in the gophkeeper vault")
+	errKeyNotFound = errors.New("key not found in the vault")
+	errDenied      = errors.New("signing denied")
+)
+
+// Key is an SSH key item of the vault.
+type Key struct {
+	Name string
+	*models.SSHKey
+}
+
+// Source returns the keys of the unlocked vault.
+type Source func(ctx context.Context) ([]Key, error)
+
+// Request describes a signature the user is asked to allow.
+type Request struct {
+	KeyName     string
+	Fingerprint string
+	Comment     string
+}
+
+// Confirm asks the user whether the request may be signed, it reports
+// false when the context ends first.
+type Confirm func(ctx context.Context, req Request) bool
+
+// Agent implements agent.ExtendedAgent on top of the vault. Without a source
+// the vault is locked and the agent lists no keys.
+type Agent struct {
+	mu      sync.RWMutex
+	source  Source
+	confirm Confirm
+
+	ConfirmTimeout time.Duration
+}
+
+var _ agent.ExtendedAgent = (*Agent)(nil)
+
+func New() *Agent {
+	return &Agent{
+		ConfirmTimeout: DefaultConfirmTimeout,
+	}
+}
+
+// SetSource unlocks the agent with the vault keys, nil locks it again.
+func (a *Agent) SetSource(src Source) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.source = src
+}
+
+// SetConfirm sets how sign requests are confirmed. Without it every request
+// is denied.
+func (a *Agent) SetConfirm(confirm Confirm) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.confirm = confirm
+}
+
+func (a *Agent) keys(ctx context.Context) ([]Key, error) {
+	a.mu.RLock()
+	src := a.source
+	a.mu.RUnlock()
+	if src == nil {
+		return nil, nil
+	}
+	return src(ctx)
+}
+
+func (a *Agent) List() ([]*agent.Key, error) {
+	keys, err := a.keys(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]*agent.Key, 0, len(keys))
+	for _, k := range keys {
+		signer, err := sshkeys.Signer(k.SSHKey)
+		if err != nil {
+			logger.Log.Warn("skip unreadable ssh key", zap.String("name", k.Name), zap.Error(err))
+			continue
+		}
+		pub := signer.PublicKey()
+		comment := k.Comment
+		if comment == "" {
+			comment = k.Name
+		}
+		list = append(list, &agent.Key{
+			Format:  pub.Type(),
+			Blob:    pub.Marshal(),
+			Comment: comment,
+		})
+	}
+	return list, nil
+}
+
+func (a *Agent) Sign(key ssh.PublicKey, data []byte) (*ssh.Signature, error) {
+	return a.SignWithFlags(key, data, 0)
+}
+
+func (a *Agent) SignWithFlags(key ssh.PublicKey, data []byte, flags agent.SignatureFlags) (*ssh.Signature, error) {
+	ctx := context.Background()
+	keys, err := a.keys(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	blob := key.Marshal()
+	for _, k := range keys {
+		signer, err := sshkeys.Signer(k.SSHKey)
+		if err != nil || !bytes.Equal(signer.PublicKey().Marshal(), blob) {
+			continue
+		}
+
+		if !a.allow(ctx, Request{KeyName: k.Name, Fingerprint: ssh.FingerprintSHA256(key), Comment: k.Comment}) {
+			return nil, errDenied
+		}
+		return sign(signer, data, flags)
+	}
+	return nil, errKeyNotFound
+}
+
+func (a *Agent) allow(ctx context.Context, req Request) bool {
+	a.mu.RLock()
+	confirm := a.confirm
+	a.mu.RUnlock()
+	if confirm == nil {
+		return false
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, a.ConfirmTimeout)
+	defer cancel()
+	return confirm(ctx, req)
+}
+
+func sign(signer ssh.Signer, data []byte, flags agent.SignatureFlags) (*ssh.Signature, error) {
+	algorithm := ""
+	switch {
+	case flags&agent.SignatureFlagRsaSha256 != 0:
+		algorithm = ssh.KeyAlgoRSASHA256
+	case flags&agent.SignatureFlagRsaSha512 != 0:
+		algorithm = ssh.KeyAlgoRSASHA512
+	}
+	if algorithm == "" || signer.PublicKey().Type() != ssh.KeyAlgoRSA {
+		return signer.Sign(rand.Reader, data)
+	}
+
+	algSigner, ok := signer.(ssh.AlgorithmSigner)
+	if !ok {
+		return nil, fmt.Errorf("key does not support %s signatures", algorithm)
+	}
+	return algSigner.SignWithAlgorithm(rand.Reader, data, algorithm)
+}
+
+func (a *Agent) Signers() ([]ssh.Signer, error) {
+	return nil, errReadOnly
+}
+
+func (a *Agent) Add(key agent.AddedKey) error   { return errReadOnly }
+func (a *Agent) Remove(key ssh.PublicKey) error { return errReadOnly }
+func (a *Agent) RemoveAll() error               { return errReadOnly }
+func (a *Agent) Lock(passphrase []byte) error   { return errReadOnly }
+func (a *Agent) Unlock(passphrase []byte) error { return errReadOnly }
+func (a *Agent) Extension(string, []byte) ([]byte, error) {
+	return nil, agent.ErrExtensionUnsupported
+}
+
+// ListenAndServe serves the agent on a Unix socket at path until the
+// context ends. A stale socket left by a previous run is replaced.
+func (a *Agent) ListenAndServe(ctx context.Context, path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("create socket dir error: %w", err)
+	}
+	if err := removeStaleSocket(path); err != nil {
+		return err
+	}
+
+	l, err := net.Listen("unix", path)
+	if err != nil {
+		return fmt.Errorf("listen ssh agent socket error: %w", err)
+	}
+	defer os.Remove(path)
+	if err := os.Chmod(path, 0o600); err != nil {
+		l.Close()
+		return fmt.Errorf("chmod ssh agent socket error: %w", err)
+	}
+
+	go func() {
+		<-ctx.Done()
+		l.Close()
+	}()
+
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("accept ssh agent connection error: %w", err)
+		}
+		go func() {
+			defer conn.Close()
+			_ = agent.ServeAgent(a, conn)
+		}()
+	}
+}
+
+func removeStaleSocket(path string) error {
+	fi, err := os.Lstat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if fi.Mode()&os.ModeSocket == 0 {
+		return fmt.Errorf("%s exists and is not a socket", path)
+	}
+	if conn, err := net.Dial("unix", path); err == nil {
+		conn.Close()
+		return fmt.Errorf("another agent is listening on %s", path)
+	}
+	return os.Remove(path)
+}
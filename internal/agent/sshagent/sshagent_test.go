@@ -0,0 +1,142 @@
+package sshagent
+
+import (
+	"context"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"gophkeeper/internal/sshkeys"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+)
+
+func newTestAgent(t *testing.T) (*Agent, agent.ExtendedAgent, Key) {
+	t.Helper()
+	sshKey, err := sshkeys.Generate(sshkeys.TypeEd25519, 0, "")
+	require.NoError(t, err)
+	key := Key{Name: "github", SSHKey: sshKey}
+
+	a := New()
+	client, server := net.Pipe()
+	t.Cleanup(func() { client.Close() })
+	go func() {
+		defer server.Close()
+		_ = agent.ServeAgent(a, server)
+	}()
+	return a, agent.NewClient(client), key
+}
+
+func TestAgent_LockedListsNothing(t *testing.T) {
+	a, client, key := newTestAgent(t)
+
+	keys, err := client.List()
+	require.NoError(t, err)
+	assert.Empty(t, keys)
+
+	a.SetSource(func(ctx context.Context) ([]Key, error) { return []Key{key}, nil })
+	keys, err = client.List()
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	assert.Equal(t, "github", keys[0].Comment)
+
+	a.SetSource(nil)
+	keys, err = client.List()
+	require.NoError(t, err)
+	assert.Empty(t, keys)
+}
+
+func TestAgent_SignConfirm(t *testing.T) {
+	a, client, key := newTestAgent(t)
+	a.SetSource(func(ctx context.Context) ([]Key, error) { return []Key{key}, nil })
+	signer, err := sshkeys.Signer(key.SSHKey)
+	require.NoError(t, err)
+	pub := signer.PublicKey()
+
+	// Without a confirmation callback nothing is signed.
+	_, err = client.Sign(pub, []byte("data"))
+	assert.Error(t, err)
+
+	var asked Request
+	allow := false
+	a.SetConfirm(func(ctx context.Context, req Request) bool {
+		asked = req
+		return allow
+	})
+	_, err = client.Sign(pub, []byte("data"))
+	assert.Error(t, err)
+	assert.Equal(t, "github", asked.KeyName)
+	assert.Equal(t, key.Fingerprint, asked.Fingerprint)
+
+	allow = true
+	sig, err := client.Sign(pub, []byte("data"))
+	require.NoError(t, err)
+	assert.NoError(t, pub.Verify([]byte("data"), sig))
+}
+
+func TestAgent_ConfirmTimeout(t *testing.T) {
+	a, client, key := newTestAgent(t)
+	a.SetSource(func(ctx context.Context) ([]Key, error) { return []Key{key}, nil })
+	a.ConfirmTimeout = 10 * time.Millisecond
+	a.SetConfirm(func(ctx context.Context, req Request) bool {
+		<-ctx.Done()
+		return false
+	})
+
+	signer, err := sshkeys.Signer(key.SSHKey)
+	require.NoError(t, err)
+	_, err = client.Sign(signer.PublicKey(), []byte("data"))
+	assert.Error(t, err)
+}
+
+func TestAgent_SignRSAWithFlags(t *testing.T) {
+	a, client, _ := newTestAgent(t)
+	sshKey, err := sshkeys.Generate(sshkeys.TypeRSA, 2048, "")
+	require.NoError(t, err)
+	a.SetSource(func(ctx context.Context) ([]Key, error) { return []Key{{Name: "rsa", SSHKey: sshKey}}, nil })
+	a.SetConfirm(func(ctx context.Context, req Request) bool { return true })
+
+	signer, err := sshkeys.Signer(sshKey)
+	require.NoError(t, err)
+	sig, err := client.SignWithFlags(signer.PublicKey(), []byte("data"), agent.SignatureFlagRsaSha256)
+	require.NoError(t, err)
+	assert.Equal(t, ssh.KeyAlgoRSASHA256, sig.Format)
+}
+
+func TestAgent_ReadOnly(t *testing.T) {
+	_, client, _ := newTestAgent(t)
+	assert.Error(t, client.RemoveAll())
+	assert.Error(t, client.Lock([]byte("x")))
+}
+
+func TestAgent_ListenAndServe(t *testing.T) {
+	a, _, key := newTestAgent(t)
+	a.SetSource(func(ctx context.Context) ([]Key, error) { return []Key{key}, nil })
+
+	path := filepath.Join(t.TempDir(), "agent.sock")
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- a.ListenAndServe(ctx, path) }()
+
+	var conn net.Conn
+	require.Eventually(t, func() bool {
+		var err error
+		conn, err = net.Dial("unix", path)
+		return err == nil
+	}, time.Second, 10*time.Millisecond)
+	keys, err := agent.NewClient(conn).List()
+	require.NoError(t, err)
+	assert.Len(t, keys, 1)
+	conn.Close()
+
+	// A second agent must not take over a live socket.
+	assert.Error(t, New().ListenAndServe(context.Background(), path))
+
+	cancel()
+	require.NoError(t, <-done)
+	assert.NoFileExists(t, path)
+}
@@ -24,7 +24,7 @@ func TestUIController_handleAddItem(t *testing.T) {
 	assert.Equal(t, stateAddItem, ui.state)
 	assert.Empty(t, ui.input)
 	assert.Equal(t, 0, ui.itemTypeMenu)
-	assert.Equal(t, 3, ui.maxItemTypes)
+	assert.Equal(t, 8, ui.maxItemTypes)
 	assert.Equal(t, "test-user", ui.newItem.UserLogin)
 	assert.Empty(t, ui.addItemErrorMsg)
 	assert.Empty(t, ui.addItemSuccessMsg)
@@ -238,7 +238,7 @@ func TestUIController_handleItemNameInput_Enter_ValidName(t *testing.T) {
 	assert.Nil(t, cmd)
 	assert.Equal(t, "test-item-name", ui.newItem.Name)
 	assert.Empty(t, ui.input)
-	assert.Equal(t, stateAddItemData, ui.state)
+	assert.Equal(t, stateAddItemExpiry, ui.state)
 }
 
 func TestUIController_handleItemNameInput_Enter_EmptyName(t *testing.T) {
@@ -276,92 +276,6 @@ func TestUIController_handleItemNameInput_CharacterInput(t *testing.T) {
 	assert.Equal(t, "testa", ui.input)
 }
 
-// Validation tests
-func TestValidateCardNumber_Valid(t *testing.T) {
-	// Test valid 16-digit card
-	err := validateCardNumber("1234567890123456")
-	assert.NoError(t, err)
-
-	// Test valid 18-digit card
-	err = validateCardNumber("123456789012345678")
-	assert.NoError(t, err)
-
-	// Test with spaces and dashes
-	err = validateCardNumber("1234 5678 9012 3456")
-	assert.NoError(t, err)
-
-	err = validateCardNumber("1234-5678-9012-3456")
-	assert.NoError(t, err)
-}
-
-func TestValidateCardNumber_Invalid(t *testing.T) {
-	// Test invalid length
-	err := validateCardNumber("12345")
-	assert.Error(t, err)
-	assert.Contains(t, err.Error(), "must be 16 or 18 digits")
-
-	// Test non-numeric
-	err = validateCardNumber("abcd5678901234567")
-	assert.Error(t, err)
-	assert.Contains(t, err.Error(), "must contain only digits")
-
-	// Test empty
-	err = validateCardNumber("")
-	assert.Error(t, err)
-}
-
-func TestValidateExpiry_Valid(t *testing.T) {
-	err := validateExpiry("12/25")
-	assert.NoError(t, err)
-
-	err = validateExpiry("01/30")
-	assert.NoError(t, err)
-}
-
-func TestValidateExpiry_Invalid(t *testing.T) {
-	// Test wrong format
-	err := validateExpiry("1225")
-	assert.Error(t, err)
-	assert.Contains(t, err.Error(), "MM/YY format")
-
-	err = validateExpiry("12-25")
-	assert.Error(t, err)
-	assert.Contains(t, err.Error(), "MM/YY format")
-
-	// Test invalid month
-	err = validateExpiry("13/25")
-	assert.Error(t, err)
-	assert.Contains(t, err.Error(), "month must be between 01 and 12")
-
-	err = validateExpiry("00/25")
-	assert.Error(t, err)
-	assert.Contains(t, err.Error(), "month must be between 01 and 12")
-}
-
-func TestValidateCVV_Valid(t *testing.T) {
-	err := validateCVV("123")
-	assert.NoError(t, err)
-
-	err = validateCVV("000")
-	assert.NoError(t, err)
-}
-
-func TestValidateCVV_Invalid(t *testing.T) {
-	// Test wrong length
-	err := validateCVV("12")
-	assert.Error(t, err)
-	assert.Contains(t, err.Error(), "exactly 3 digits")
-
-	err = validateCVV("1234")
-	assert.Error(t, err)
-	assert.Contains(t, err.Error(), "exactly 3 digits")
-
-	// Test non-numeric
-	err = validateCVV("12a")
-	assert.Error(t, err)
-	assert.Contains(t, err.Error(), "exactly 3 digits")
-}
-
 // View tests
 func TestUIController_addItemTypeView(t *testing.T) {
 	ui := &UIController{
@@ -402,39 +316,59 @@ func TestUIController_addItemDataView_Credentials(t *testing.T) {
 			newItem: models.Item{
 				Name: "test-item",
 				Type: models.ItemTypeCREDENTIALS,
+				Data: &models.Credentials{},
 			},
 		},
-		input: "test-login",
 	}
 	ui.messages.init()
+	ui.startDataForm(ui.newItem.Data)
+	ui.input = "test-login"
 
 	view := ui.addItemDataView()
 
 	assert.Contains(t, view, "Add CREDENTIALS")
 	assert.Contains(t, view, "test-item")
-	assert.Contains(t, view, "Login")
+	assert.Contains(t, view, "Login*")
 	assert.Contains(t, view, "test-login")
+	assert.Contains(t, view, "Password")
 }
 
-func TestUIController_addItemDataView_Card(t *testing.T) {
+func TestUIController_addItemData_Card(t *testing.T) {
 	ui := &UIController{
+		state: stateAddItemData,
 		itemCtrl: itemCtrl{
 			newItem: models.Item{
 				Name: "test-card",
 				Type: models.ItemTypeCARD,
+				Data: &models.Card{},
 			},
 		},
-		input: "1234567890123456",
 	}
 	ui.messages.init()
+	ui.startDataForm(ui.newItem.Data)
+
+	typeRunes(ui.handleItemDataInput, "12345")
+	ui.handleItemDataInput(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Contains(t, ui.messages.Get("error"), "Number must be 16 or 18 digits")
+	assert.Contains(t, ui.addItemDataView(), "12345")
+
+	ui.input = "1234567890123456"
+	ui.handleItemDataInput(tea.KeyMsg{Type: tea.KeyEnter})
+	for _, value := range []string{"12/30", "123"} {
+		typeRunes(ui.handleItemDataInput, value)
+		ui.handleItemDataInput(tea.KeyMsg{Type: tea.KeyEnter})
+	}
+	assert.Contains(t, ui.addItemDataView(), "CVV*: ***")
+	typeRunes(ui.handleItemDataInput, "J. Doe")
+	_, cmd := ui.handleItemDataInput(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.NotNil(t, cmd)
 
-	view := ui.addItemDataView()
-
-	assert.Contains(t, view, "Add CARD")
-	assert.Contains(t, view, "test-card")
-	assert.Contains(t, view, "Card Number")
-	assert.Contains(t, view, "16 or 18 digits")
-	assert.Contains(t, view, "1234567890123456")
+	assert.Equal(t, &models.Card{
+		Number:         "1234567890123456",
+		ExpiryDate:     "12/30",
+		SecurityCode:   "123",
+		CardholderName: "J. Doe",
+	}, ui.newItem.Data)
 }
 
 func TestUIController_addItemErrorView(t *testing.T) {
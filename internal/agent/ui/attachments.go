@@ -0,0 +1,268 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"gophkeeper/internal/agent/services"
+	"os"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type attachmentsLoaded struct {
+	attachments []services.AttachmentFile
+	message     string
+}
+
+func attachmentTitle(a services.AttachmentFile) string {
+	return fmt.Sprintf("%s (%s, %s)", a.Filename, a.MimeType, formatSize(a.Size))
+}
+
+func formatSize(n int64) string {
+	switch {
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1f MiB", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%.1f KiB", float64(n)/(1<<10))
+	}
+	return fmt.Sprintf("%d B", n)
+}
+
+func (ui *UIController) handleViewAttachments() (tea.Model, tea.Cmd) {
+	ui.state = stateProcessing
+	ui.currentAttachment = 0
+	return ui, ui.attachmentActionCmd(nil, "")
+}
+
+// attachmentActionCmd runs an optional action and reloads the attachments
+// of the opened item. The action result is shown above the list.
+func (ui *UIController) attachmentActionCmd(action func(ctx context.Context) (string, error), errContext string) tea.Cmd {
+	item := ui.decryptedItem
+	return func() tea.Msg {
+		ctx := context.Background()
+		var message string
+		if action != nil {
+			var err error
+			if message, err = action(ctx); err != nil {
+				return errorMsg{
+					err:     err,
+					context: errContext,
+				}
+			}
+		}
+
+		attachments, err := ui.Item.ListAttachments(ctx, item)
+		if err != nil {
+			return errorMsg{
+				err:     err,
+				context: "load_attachments",
+			}
+		}
+		return attachmentsLoaded{
+			attachments: attachments,
+			message:     message,
+		}
+	}
+}
+
+func (ui *UIController) selectedAttachment() *services.AttachmentFile {
+	if ui.currentAttachment < len(ui.attachments) {
+		return &ui.attachments[ui.currentAttachment]
+	}
+	return nil
+}
+
+func (ui *UIController) handleAttachmentListInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	attachment := ui.selectedAttachment()
+
+	switch msg.String() {
+	case "ctrl+c", "q":
+		return ui, tea.Quit
+	case "esc", "b":
+		ui.state = stateItemDetails
+		return ui, nil
+	case "up", "k":
+		if ui.currentAttachment > 0 {
+			ui.currentAttachment--
+		}
+	case "down", "j":
+		if ui.currentAttachment < len(ui.attachments)-1 {
+			ui.currentAttachment++
+		}
+	case "u":
+		ui.state = stateAttachFile
+		ui.input = ""
+	case "enter", "s":
+		if attachment != nil {
+			ui.state = stateSaveAttachment
+			ui.input = attachment.Filename
+		}
+	case "d", "delete":
+		if attachment != nil {
+			ui.state = stateConfirmDeleteAttachment
+			ui.confirmChoice = 0
+		}
+	}
+	return ui, nil
+}
+
+func (ui *UIController) attachmentListView() string {
+	title := titleStyle.Render(fmt.Sprintf("Attachments: %s", ui.decryptedItem.Name))
+
+	message := ""
+	if ui.attachmentMsg != "" {
+		message = successStyle.Render(ui.attachmentMsg) + "\n\n"
+	}
+
+	if len(ui.attachments) == 0 {
+		return fmt.Sprintf("%s\n\n%sNo attachments yet.\n\nControls: u to upload a file, b/Esc to go back", title, message)
+	}
+
+	list := ""
+	for i, a := range ui.attachments {
+		if i == ui.currentAttachment {
+			list += selectedStyle.Render("→ "+attachmentTitle(a)) + "\n"
+		} else {
+			list += menuStyle.Render("  "+attachmentTitle(a)) + "\n"
+		}
+	}
+
+	controls := "\nControls: ↑/↓ to navigate, Enter/s to save to disk, u to upload a file, d to delete, b/Esc to go back"
+	return fmt.Sprintf("%s\n\n%s%s%s", title, message, list, controls)
+}
+
+// handleAttachmentPathInput reads a file path for uploading or saving.
+func (ui *UIController) handleAttachmentPathInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return ui, tea.Quit
+	case "esc":
+		ui.state = stateAttachmentList
+		ui.input = ""
+		return ui, nil
+	case "enter":
+		path := expandHome(strings.TrimSpace(ui.input))
+		if path == "" {
+			return ui, nil
+		}
+		ui.input = ""
+		return ui.executeAttachmentPath(path)
+	case "backspace":
+		if len(ui.input) > 0 {
+			ui.input = ui.input[:len(ui.input)-1]
+		}
+	default:
+		if len(msg.String()) == 1 {
+			ui.input += msg.String()
+		}
+	}
+	return ui, nil
+}
+
+func (ui *UIController) executeAttachmentPath(path string) (tea.Model, tea.Cmd) {
+	item := ui.decryptedItem
+	if ui.state == stateAttachFile {
+		ui.state = stateProcessing
+		return ui, ui.attachmentActionCmd(func(ctx context.Context) (string, error) {
+			if err := ui.Item.AttachFile(ctx, item, path); err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("Attached %s", filepath.Base(path)), nil
+		}, "attach_file")
+	}
+
+	attachment := ui.selectedAttachment()
+	if attachment == nil {
+		ui.state = stateAttachmentList
+		return ui, nil
+	}
+	id := attachment.ID
+	ui.state = stateProcessing
+	return ui, ui.attachmentActionCmd(func(ctx context.Context) (string, error) {
+		saved, err := ui.Item.SaveAttachment(ctx, item, id, path)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Saved to %s", saved), nil
+	}, "save_attachment")
+}
+
+func (ui *UIController) attachmentPathView() string {
+	title := titleStyle.Render("Upload Attachment")
+	prompt := "Path of the file to attach:"
+	if ui.state == stateSaveAttachment {
+		title = titleStyle.Render("Save Attachment")
+		prompt = "Save to (an existing file is not overwritten):"
+	}
+	controls := "\nControls: Enter to confirm, Esc to cancel"
+	return fmt.Sprintf("%s\n\n%s\n> %s\n%s", title, prompt, ui.input, controls)
+}
+
+func (ui *UIController) handleConfirmDeleteAttachmentInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c", "q":
+		return ui, tea.Quit
+	case "esc", "n":
+		ui.state = stateAttachmentList
+		return ui, nil
+	case "left", "h":
+		ui.confirmChoice = 0
+	case "right", "l":
+		ui.confirmChoice = 1
+	case "y":
+		ui.confirmChoice = 1
+		return ui.executeDeleteAttachment()
+	case "enter":
+		return ui.executeDeleteAttachment()
+	}
+	return ui, nil
+}
+
+func (ui *UIController) executeDeleteAttachment() (*UIController, tea.Cmd) {
+	attachment := ui.selectedAttachment()
+	if ui.confirmChoice == 0 || attachment == nil {
+		ui.state = stateAttachmentList
+		return ui, nil
+	}
+
+	item, id, name := ui.decryptedItem, attachment.ID, attachment.Filename
+	ui.state = stateProcessing
+	return ui, ui.attachmentActionCmd(func(ctx context.Context) (string, error) {
+		if err := ui.Item.DeleteAttachment(ctx, item, id); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Deleted %s", name), nil
+	}, "delete_attachment")
+}
+
+func (ui *UIController) confirmDeleteAttachmentView() string {
+	title := titleStyle.Render("Confirm Delete")
+	warning := fmt.Sprintf("Delete %s? This cannot be undone.", attachmentTitle(ui.attachments[ui.currentAttachment]))
+
+	options := ""
+	if ui.confirmChoice == 0 {
+		options += selectedStyle.Render("[ No ]") + "  "
+		options += menuStyle.Render("[ Yes ]")
+	} else {
+		options += menuStyle.Render("[ No ]") + "  "
+		options += selectedStyle.Render("[ Yes ]")
+	}
+
+	controls := "\nControls: ←/→ to select, Enter to confirm, y/n for quick choice, Esc to cancel"
+	return fmt.Sprintf("%s\n\n%s\n\n%s%s", title, warning, options, controls)
+}
+
+// expandHome resolves a leading ~ to the home directory, paths are typed
+// the way a shell would take them.
+func expandHome(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, path[1:])
+}
@@ -0,0 +1,85 @@
+package ui
+
+import (
+	"gophkeeper/internal/agent/services"
+	"gophkeeper/models"
+	"os"
+	"path/filepath"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUIController_attachmentListView(t *testing.T) {
+	ui := &UIController{itemCtrl: itemCtrl{decryptedItem: &models.Item{Name: "Mail"}}}
+	ui.Update(attachmentsLoaded{})
+	assert.Equal(t, stateAttachmentList, ui.state)
+	assert.Contains(t, ui.attachmentListView(), "No attachments yet")
+
+	ui.Update(attachmentsLoaded{
+		attachments: []services.AttachmentFile{{
+			AttachmentInfo: models.AttachmentInfo{Filename: "codes.pdf", MimeType: "application/pdf"},
+			Size:           2048,
+		}},
+		message: "Attached codes.pdf",
+	})
+	view := ui.attachmentListView()
+	assert.Contains(t, view, "Attachments: Mail")
+	assert.Contains(t, view, "codes.pdf (application/pdf, 2.0 KiB)")
+	assert.Contains(t, view, "Attached codes.pdf")
+}
+
+func TestUIController_handleAttachmentListInput(t *testing.T) {
+	ui := &UIController{
+		state:    stateAttachmentList,
+		itemCtrl: itemCtrl{decryptedItem: &models.Item{Name: "Mail"}},
+		attachmentCtrl: attachmentCtrl{attachments: []services.AttachmentFile{
+			{ID: [16]byte{1}, AttachmentInfo: models.AttachmentInfo{Filename: "a.txt"}},
+			{ID: [16]byte{2}, AttachmentInfo: models.AttachmentInfo{Filename: "b.txt"}},
+		}},
+	}
+
+	ui.handleAttachmentListInput(tea.KeyMsg{Type: tea.KeyDown})
+	ui.handleAttachmentListInput(tea.KeyMsg{Type: tea.KeyDown})
+	assert.Equal(t, 1, ui.currentAttachment)
+
+	ui.handleAttachmentListInput(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Equal(t, stateSaveAttachment, ui.state)
+	assert.Equal(t, "b.txt", ui.input)
+	assert.Contains(t, ui.attachmentPathView(), "Save Attachment")
+	ui.handleAttachmentPathInput(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.Equal(t, stateAttachmentList, ui.state)
+
+	ui.handleAttachmentListInput(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+	assert.Equal(t, stateConfirmDeleteAttachment, ui.state)
+	assert.Contains(t, ui.confirmDeleteAttachmentView(), "Delete b.txt")
+	_, cmd := ui.handleConfirmDeleteAttachmentInput(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Nil(t, cmd)
+	assert.Equal(t, stateAttachmentList, ui.state)
+
+	ui.handleAttachmentListInput(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'u'}})
+	assert.Equal(t, stateAttachFile, ui.state)
+	ui.handleAttachmentPathInput(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'q'}})
+	assert.Equal(t, "q", ui.input)
+
+	ui.handleAttachmentPathInput(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.Equal(t, stateAttachmentList, ui.state)
+	ui.handleAttachmentListInput(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.Equal(t, stateItemDetails, ui.state)
+}
+
+func TestFormatSize(t *testing.T) {
+	assert.Equal(t, "512 B", formatSize(512))
+	assert.Equal(t, "1.5 KiB", formatSize(1536))
+	assert.Equal(t, "3.0 MiB", formatSize(3<<20))
+}
+
+func TestExpandHome(t *testing.T) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		t.Skip("no home directory")
+	}
+	assert.Equal(t, filepath.Join(home, "codes.pdf"), expandHome("~/codes.pdf"))
+	assert.Equal(t, "/tmp/codes.pdf", expandHome("/tmp/codes.pdf"))
+}
@@ -3,13 +3,18 @@ package ui
 import (
 	"context"
 	"fmt"
+	"gophkeeper/internal/agent/services"
+	"gophkeeper/internal/logger"
 	"gophkeeper/models"
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
 )
 
 func (ui *UIController) handleMenuLoggedOutInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	options := ui.loggedOutOptions()
 	switch msg.String() {
 	case "ctrl+c", "q":
 		return ui, tea.Quit
@@ -18,22 +23,48 @@ func (ui *UIController) handleMenuLoggedOutInput(msg tea.KeyMsg) (tea.Model, tea
 			ui.currentMenu--
 		}
 	case "down", "j":
-		if ui.currentMenu < 1 {
+		if ui.currentMenu < len(options)-1 {
 			ui.currentMenu++
 		}
-	case "1":
-		ui.currentMenu = 0
+	case "1", "2", "3":
+		choice := int(msg.String()[0] - '1')
+		if choice < len(options) {
+			ui.currentMenu = choice
+			return ui.selectLoggedOutOption()
+		}
+	case "enter":
+		return ui.selectLoggedOutOption()
+	}
+	return ui, nil
+}
+
+// loggedOutOptions are the entries of the logged out menu, forgetting the
+// device is offered while a session is saved.
+func (ui *UIController) loggedOutOptions() []string {
+	options := []string{"Sign up", "Sign in"}
+	if ui.remembersDevice() {
+		options = append(options, "Forget this device")
+	}
+	return options
+}
+
+func (ui *UIController) selectLoggedOutOption() (tea.Model, tea.Cmd) {
+	switch ui.currentMenu {
+	case 0:
 		return ui.startSignUp(), nil
-	case "2":
-		ui.currentMenu = 1
+	case 1:
 		return ui.startSignIn(), nil
-	case "enter":
-		if ui.currentMenu == 0 {
-			return ui.startSignUp(), nil
-		} else {
-			return ui.startSignIn(), nil
-		}
 	}
+	ui.currentMenu = 0
+	if !ui.remembersDevice() {
+		return ui, nil
+	}
+	ui.messages.Clear("session_error")
+	if err := ui.User.ForgetDevice(); err != nil {
+		ui.messages.Set("session_error", fmt.Sprintf("Forget device: %v", err))
+		return ui, nil
+	}
+	ui.messages.Set("session_info", "The saved session is removed, the next start signs in again")
 	return ui, nil
 }
 
@@ -213,6 +244,10 @@ func (ui *UIController) handleMasterPasswordInput(msg tea.KeyMsg) (tea.Model, te
 	case "ctrl+c":
 		return ui, tea.Quit
 	case "esc":
+		// A locked vault opens with the master password only.
+		if ui.locked {
+			return ui, nil
+		}
 		ui.state = stateMenuLoggedIn
 		ui.input = ""
 		ui.messages.ClearAll()
@@ -222,6 +257,9 @@ func (ui *UIController) handleMasterPasswordInput(msg tea.KeyMsg) (tea.Model, te
 		ui.input = ""
 		ui.state = stateProcessing
 
+		if ui.locked {
+			return ui, ui.unlockCmd(masterPassword)
+		}
 		return ui, ui.setMasterPasswordCmd(masterPassword)
 	case "backspace":
 		if len(ui.input) > 0 {
@@ -237,7 +275,7 @@ func (ui *UIController) handleMasterPasswordInput(msg tea.KeyMsg) (tea.Model, te
 
 func (ui *UIController) setMasterPasswordCmd(masterPassword string) tea.Cmd {
 	return func() tea.Msg {
-		if err := ui.User.SetMasterKey(masterPassword); err != nil {
+		if err := ui.User.SetMasterKey(context.Background(), masterPassword); err != nil {
 			return processComplete{
 				success: false,
 				message: fmt.Sprintf("Set master password: %v", err),
@@ -245,14 +283,61 @@ func (ui *UIController) setMasterPasswordCmd(masterPassword string) tea.Cmd {
 			}
 		}
 
+		if err := ui.User.EnsureUserKeys(context.Background()); err != nil {
+			return processComplete{
+				success: false,
+				message: fmt.Sprintf("Unlock vault: %v", err),
+				context: "master_password",
+			}
+		}
+
 		return processComplete{
 			success: true,
-			message: "Master password set. Welcome!",
+			message: "Master password set. Welcome!" + ui.upgradeItems() + ui.rotateOrgKeys(),
 			context: "master_password",
 		}
 	}
 }
 
+// upgradeItems re-encrypts items saved with a plaintext name once the key is
+// known. It does not keep the vault locked: the items that fail stay as they
+// are and are logged, the next unlock tries them again.
+func (ui *UIController) upgradeItems() string {
+	ctx := context.Background()
+	vaults, err := ui.Org.WritableVaults(ctx)
+	if err != nil {
+		logger.Log.Warn("open vaults to upgrade error", zap.Error(err))
+	}
+	_, err = ui.Item.UpgradeItems(ctx, ui.login, vaults...)
+	failed, err := services.SplitItemErrors(err)
+	if err != nil {
+		logger.Log.Warn("upgrade items error", zap.Error(err))
+		return " Some items could not be upgraded."
+	}
+	for id, err := range failed {
+		logger.Log.Warn("upgrade item error", zap.String("item", uuid.UUID(id).String()), zap.Error(err))
+	}
+	if len(failed) > 0 {
+		return fmt.Sprintf(" %d item(s) could not be upgraded.", len(failed))
+	}
+	return ""
+}
+
+// rotateOrgKeys rotates the keys of the organizations a member left, when
+// the user is their owner or admin. A failure is logged and retried on the
+// next unlock.
+func (ui *UIController) rotateOrgKeys() string {
+	rotated, err := ui.Org.RotateDueKeys(context.Background())
+	if err != nil {
+		logger.Log.Warn("rotate organization keys error", zap.Error(err))
+		return " Organization keys could not be rotated."
+	}
+	if rotated > 0 {
+		return fmt.Sprintf(" Rotated the keys of %d organization(s) a member left.", rotated)
+	}
+	return ""
+}
+
 func (ui *UIController) masterPasswordInputView() string {
 	title := titleStyle.Render("Master Password Required")
 
@@ -261,6 +346,13 @@ func (ui *UIController) masterPasswordInputView() string {
 	controls := "\nControls: Esc to go back, Enter to continue"
 
 	info := "\nEnter your master password to unlock your vault:"
+	if ui.locked {
+		title = titleStyle.Render("Vault Locked")
+		controls = "\nControls: Enter to unlock, Ctrl+C to quit"
+	}
+	if errMsg := ui.messages.Get("master_password_error"); errMsg != "" {
+		info = "\n" + errorStyle.Render(errMsg) + info
+	}
 
 	return fmt.Sprintf("%s%s\n\nMaster Password: %s%s", title, info, input, controls)
 }
@@ -1,10 +1,15 @@
 package ui
 
 import (
+	"gophkeeper/config"
+	"gophkeeper/internal/agent/services"
+	"os"
+	"path/filepath"
 	"testing"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestUIController_handleMenuLoggedOutInput_Quit(t *testing.T) {
@@ -57,6 +62,36 @@ func TestUIController_handleMenuLoggedOutInput_DirectSelection(t *testing.T) {
 	assert.Equal(t, 1, ui.currentMenu)
 }
 
+func TestUIController_handleMenuLoggedOutInput_ForgetDevice(t *testing.T) {
+	us, err := services.NewUserService(&config.Config{}, nil, nil)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "session.json")
+	store, err := services.NewSessionStore(path, "")
+	require.NoError(t, err)
+	us.SetSessionStore(store)
+	ui := &UIController{User: us}
+
+	// Without a saved session there is nothing to forget.
+	ui.handleMenuLoggedOutInput(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'3'}})
+	assert.Equal(t, 0, ui.currentMenu)
+	assert.NotContains(t, ui.menuLoggedOutView(), "Forget this device")
+
+	require.NoError(t, os.WriteFile(path, []byte("{}"), 0o600))
+	assert.Contains(t, ui.menuLoggedOutView(), "3. Forget this device")
+	ui.handleMenuLoggedOutInput(tea.KeyMsg{Type: tea.KeyDown})
+	ui.handleMenuLoggedOutInput(tea.KeyMsg{Type: tea.KeyDown})
+	assert.Equal(t, 2, ui.currentMenu)
+
+	model, cmd := ui.handleMenuLoggedOutInput(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Equal(t, ui, model)
+	assert.Nil(t, cmd)
+	assert.False(t, store.Exists())
+	assert.Equal(t, 0, ui.currentMenu)
+	view := ui.menuLoggedOutView()
+	assert.Contains(t, view, "next start signs in again")
+	assert.NotContains(t, view, "Forget this device")
+}
+
 func TestUIController_handleLoginInput_Quit(t *testing.T) {
 	ui := &UIController{}
 
@@ -8,12 +8,18 @@ import (
 
 func (ui *UIController) menuLoggedInView() string {
 	title := titleStyle.Render(fmt.Sprintf("Welcome, %s!", ui.login))
+	vault := fmt.Sprintf("Vault: %s", ui.currentVaultName())
 	subtitle := "Choose an option - enter number or use arrow keys:"
 
 	options := []string{
 		"View All Items",
 		"View Items With Type",
 		"Add Item",
+		"Shared With Me",
+		"Switch Vault",
+		"Emergency Access",
+		"Devices",
+		"Breach Report",
 		"Logout",
 	}
 
@@ -29,8 +35,12 @@ func (ui *UIController) menuLoggedInView() string {
 		}
 	}
 
-	controls := "\nControls: ↑/↓ to navigate, Enter to select, q to quit"
-	return fmt.Sprintf("%s\n\n%s\n\n%s%s", title, subtitle, menu, controls)
+	controls := "\nControls: ↑/↓ to navigate, Enter to select, Ctrl+L to lock, q to quit"
+	if status := ui.syncStatus(); status != "" {
+		vault += "\n" + status
+		controls = "\nControls: ↑/↓ to navigate, Enter to select, s to sync, c for conflicts, Ctrl+L to lock, q to quit"
+	}
+	return fmt.Sprintf("%s\n\n%s\n%s\n\n%s%s", title, vault, subtitle, menu, controls)
 }
 
 func (ui *UIController) handleMenuLoggedInInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
@@ -45,6 +55,10 @@ func (ui *UIController) handleMenuLoggedInInput(msg tea.KeyMsg) (tea.Model, tea.
 		if ui.loggedInMenu < ui.maxLoggedInMenu {
 			ui.loggedInMenu++
 		}
+	case "s":
+		return ui.handleSync()
+	case "c":
+		return ui.handleConflicts()
 	case "1":
 		ui.loggedInMenu = 0
 		return ui.handleViewAllItems()
@@ -56,6 +70,21 @@ func (ui *UIController) handleMenuLoggedInInput(msg tea.KeyMsg) (tea.Model, tea.
 		return ui.handleAddItem()
 	case "4":
 		ui.loggedInMenu = 3
+		return ui.handleViewSharedWithMe()
+	case "5":
+		ui.loggedInMenu = 4
+		return ui.handleSwitchVault()
+	case "6":
+		ui.loggedInMenu = 5
+		return ui.handleEmergencyAccess()
+	case "7":
+		ui.loggedInMenu = 6
+		return ui.handleDevices()
+	case "8":
+		ui.loggedInMenu = 7
+		return ui.handleBreachReport()
+	case "9":
+		ui.loggedInMenu = 8
 		return ui.handleLogout()
 	case "enter":
 		switch ui.loggedInMenu {
@@ -66,6 +95,16 @@ func (ui *UIController) handleMenuLoggedInInput(msg tea.KeyMsg) (tea.Model, tea.
 		case 2:
 			return ui.handleAddItem()
 		case 3:
+			return ui.handleViewSharedWithMe()
+		case 4:
+			return ui.handleSwitchVault()
+		case 5:
+			return ui.handleEmergencyAccess()
+		case 6:
+			return ui.handleDevices()
+		case 7:
+			return ui.handleBreachReport()
+		case 8:
 			return ui.handleLogout()
 		}
 	}
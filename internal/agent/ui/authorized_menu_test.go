@@ -136,11 +136,66 @@ func TestUIController_handleMenuLoggedInInput_DirectSelection_ViewItemsWithType(
 func TestUIController_handleMenuLoggedInInput_DirectSelection_Logout(t *testing.T) {
 	ui := &UIController{}
 
-	model, cmd := ui.handleMenuLoggedInInput(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'4'}})
+	model, cmd := ui.handleMenuLoggedInInput(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'9'}})
 
 	assert.Equal(t, ui, model)
 	assert.Nil(t, cmd) // handleLogout returns nil command
+	assert.Equal(t, 8, ui.loggedInMenu)
+}
+
+func TestUIController_handleMenuLoggedInInput_DirectSelection_BreachReport(t *testing.T) {
+	ui := &UIController{}
+
+	model, cmd := ui.handleMenuLoggedInInput(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'8'}})
+
+	assert.Equal(t, ui, model)
+	assert.NotNil(t, cmd) // handleBreachReport runs the check
+	assert.Equal(t, 7, ui.loggedInMenu)
+	assert.Equal(t, stateProcessing, ui.state)
+}
+
+func TestUIController_handleMenuLoggedInInput_DirectSelection_Devices(t *testing.T) {
+	ui := &UIController{}
+
+	model, cmd := ui.handleMenuLoggedInInput(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'7'}})
+
+	assert.Equal(t, ui, model)
+	assert.NotNil(t, cmd) // handleDevices loads the devices
+	assert.Equal(t, 6, ui.loggedInMenu)
+	assert.Equal(t, stateProcessing, ui.state)
+}
+
+func TestUIController_handleMenuLoggedInInput_DirectSelection_EmergencyAccess(t *testing.T) {
+	ui := &UIController{}
+
+	model, cmd := ui.handleMenuLoggedInInput(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'6'}})
+
+	assert.Equal(t, ui, model)
+	assert.NotNil(t, cmd) // loads emergency access list
+	assert.Equal(t, 5, ui.loggedInMenu)
+	assert.Equal(t, stateProcessing, ui.state)
+}
+
+func TestUIController_handleMenuLoggedInInput_DirectSelection_SwitchVault(t *testing.T) {
+	ui := &UIController{}
+
+	model, cmd := ui.handleMenuLoggedInInput(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'5'}})
+
+	assert.Equal(t, ui, model)
+	assert.NotNil(t, cmd) // loads vaults
+	assert.Equal(t, 4, ui.loggedInMenu)
+	assert.Equal(t, stateProcessing, ui.state)
+}
+
+func TestUIController_handleMenuLoggedInInput_DirectSelection_SharedWithMe(t *testing.T) {
+	ui := &UIController{}
+
+	model, cmd := ui.handleMenuLoggedInInput(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'4'}})
+
+	assert.Equal(t, ui, model)
+	assert.NotNil(t, cmd) // loads shared items
 	assert.Equal(t, 3, ui.loggedInMenu)
+	assert.Equal(t, stateProcessing, ui.state)
 }
 
 func TestUIController_handleMenuLoggedInInput_Enter_ViewItems(t *testing.T) {
@@ -178,7 +233,7 @@ func TestUIController_handleMenuLoggedInInput_Enter_AddItem(t *testing.T) {
 
 func TestUIController_handleMenuLoggedInInput_Enter_Logout(t *testing.T) {
 	ui := &UIController{
-		loggedInMenu: 3,
+		loggedInMenu: 8,
 	}
 
 	model, cmd := ui.handleMenuLoggedInInput(tea.KeyMsg{Type: tea.KeyEnter})
@@ -211,8 +266,8 @@ func TestUIController_handleMenuLoggedInInput_NumberOutOfRange(t *testing.T) {
 	assert.Nil(t, cmd)
 	assert.Equal(t, 1, ui.loggedInMenu) // Should remain unchanged
 
-	// Test number 5 (should be ignored)
-	model, cmd = ui.handleMenuLoggedInInput(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'5'}})
+	// Test a key past the numbers (should be ignored)
+	model, cmd = ui.handleMenuLoggedInInput(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
 
 	assert.Equal(t, ui, model)
 	assert.Nil(t, cmd)
@@ -248,4 +303,5 @@ func TestUIController_menuLoggedInView_SelectedItemHighlighted(t *testing.T) {
 	assert.Contains(t, view, "Add Item")
 	assert.Contains(t, view, "View Items")
 	assert.Contains(t, view, "Logout")
+	assert.Contains(t, view, "Vault: Personal")
 }
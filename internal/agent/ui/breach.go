@@ -0,0 +1,100 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"gophkeeper/internal/agent/services"
+	"gophkeeper/models"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type breachesChecked struct {
+	reports []services.BreachReport
+	checked int
+}
+
+func breachTitle(r services.BreachReport) string {
+	text := r.Item.Name
+	if r.Login != "" {
+		text += fmt.Sprintf(" (%s)", r.Login)
+	}
+	if r.Count > 0 {
+		return text + fmt.Sprintf(" - seen %d times in breaches", r.Count)
+	}
+	return text + " - found in breaches"
+}
+
+func (ui *UIController) handleBreachReport() (tea.Model, tea.Cmd) {
+	ui.state = stateProcessing
+	return ui, func() tea.Msg {
+		reports, checked, err := ui.Item.CheckBreaches(context.Background(), ui.login)
+		if err != nil {
+			return errorMsg{
+				err:     err,
+				context: "check_breaches",
+			}
+		}
+		return breachesChecked{
+			reports: reports,
+			checked: checked,
+		}
+	}
+}
+
+func (ui *UIController) handleBreachReportInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c", "q":
+		return ui, tea.Quit
+	case "esc", "b":
+		ui.state = stateMenuLoggedIn
+		return ui, nil
+	case "up", "k":
+		if ui.currentBreach > 0 {
+			ui.currentBreach--
+		}
+	case "down", "j":
+		if ui.currentBreach < len(ui.breaches)-1 {
+			ui.currentBreach++
+		}
+	case "enter":
+		if ui.currentBreach >= len(ui.breaches) {
+			return ui, nil
+		}
+		// Continue in an item list of the compromised credentials, where
+		// they can be opened and changed.
+		items := make([]models.EncryptedItem, len(ui.breaches))
+		for i, r := range ui.breaches {
+			items[i] = r.Item
+		}
+		ui.items = items
+		ui.maxItems = len(items) - 1
+		ui.currentItem = ui.currentBreach
+		ui.searchQuery = ""
+		return ui.handleViewItemDetails()
+	}
+	return ui, nil
+}
+
+func (ui *UIController) breachReportView() string {
+	title := titleStyle.Render("Breach Report")
+	summary := fmt.Sprintf("Checked %d credentials against the local breach dataset.", ui.breachesChecked)
+
+	if len(ui.breaches) == 0 {
+		return fmt.Sprintf("%s\n\n%s\n\n%s\n\nControls: b/Esc to go back", title,
+			summary, successStyle.Render("No compromised passwords found."))
+	}
+
+	warning := errorStyle.Render(fmt.Sprintf("%d compromised, change these passwords:", len(ui.breaches)))
+	list := ""
+	for i, r := range ui.breaches {
+		if i == ui.currentBreach {
+			list += selectedStyle.Render("→ "+breachTitle(r)) + "\n"
+		} else {
+			list += menuStyle.Render("  "+breachTitle(r)) + "\n"
+		}
+	}
+
+	controls := "\nControls: ↑/↓ to navigate, Enter to open the item, b/Esc to go back"
+	return fmt.Sprintf("%s\n\n%s\n%s\n\n%s%s", title, summary, warning, list, controls)
+}
@@ -0,0 +1,51 @@
+package ui
+
+import (
+	"gophkeeper/internal/agent/services"
+	"gophkeeper/models"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUIController_breachReportView(t *testing.T) {
+	ui := &UIController{}
+	ui.Update(breachesChecked{checked: 4})
+	assert.Equal(t, stateBreachReport, ui.state)
+	assert.Contains(t, ui.breachReportView(), "Checked 4 credentials")
+	assert.Contains(t, ui.breachReportView(), "No compromised passwords found")
+
+	ui.Update(breachesChecked{checked: 4, reports: []services.BreachReport{
+		{Item: models.EncryptedItem{Name: "Mail"}, Login: "alice", Count: 9659365},
+		{Item: models.EncryptedItem{Name: "Forum"}},
+	}})
+	view := ui.breachReportView()
+	assert.Contains(t, view, "2 compromised")
+	assert.Contains(t, view, "Mail (alice) - seen 9659365 times in breaches")
+	assert.Contains(t, view, "Forum - found in breaches")
+}
+
+func TestUIController_handleBreachReportInput(t *testing.T) {
+	ui := &UIController{
+		state: stateBreachReport,
+		breachCtrl: breachCtrl{breaches: []services.BreachReport{
+			{Item: models.EncryptedItem{ID: [16]byte{1}, Name: "Mail"}},
+			{Item: models.EncryptedItem{ID: [16]byte{2}, Name: "Forum"}},
+		}},
+	}
+
+	ui.handleBreachReportInput(tea.KeyMsg{Type: tea.KeyDown})
+	ui.handleBreachReportInput(tea.KeyMsg{Type: tea.KeyDown})
+	assert.Equal(t, 1, ui.currentBreach)
+
+	_, cmd := ui.handleBreachReportInput(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.NotNil(t, cmd)
+	assert.Equal(t, stateItemDetails, ui.state)
+	assert.Len(t, ui.items, 2)
+	assert.Equal(t, [16]byte{2}, ui.selectedItem.ID)
+
+	ui.state = stateBreachReport
+	ui.handleBreachReportInput(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.Equal(t, stateMenuLoggedIn, ui.state)
+}
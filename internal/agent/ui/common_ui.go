@@ -2,7 +2,9 @@ package ui
 
 import (
 	"fmt"
+	"gophkeeper/internal/agent/services"
 	"gophkeeper/models"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -52,16 +54,31 @@ var (
 func (ui *UIController) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		ui.lastActivity = time.Now()
+		if msg.String() == "ctrl+l" {
+			return ui.lock()
+		}
 		return ui.handleKeyMsg(msg)
+	case idleTick:
+		return ui.handleIdleTick(msg)
+	case vaultUnlocked:
+		return ui.handleVaultUnlocked(msg)
 	case itemDecrypted:
 		ui.decryptedItem = msg.item
-		return ui, nil
+		return ui, ui.startTOTPTicks()
+	case totpTick:
+		return ui.handleTOTPTick(msg)
+	case itemFavoriteSet:
+		return ui.handleItemFavoriteSet(msg)
 	case decryptError:
 		return ui.handleDecryptError(msg)
 	case processComplete:
 		return ui.handleProcessComplete(msg)
 	case itemsLoaded:
+		services.SortItems(msg.items, ui.sortMode)
 		ui.items = msg.items
+		ui.failedItems = msg.failed
+		ui.searchQuery = msg.query
 		ui.currentItem = 0
 		ui.state = stateItemsList
 		return ui, nil
@@ -74,11 +91,112 @@ func (ui *UIController) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return ui, nil
 	case itemsByTypeLoaded:
+		services.SortItems(msg.items, ui.sortMode)
 		ui.items = msg.items
+		ui.failedItems = msg.failed
 		ui.maxItems = len(ui.items) - 1
 		ui.currentItem = 0
 		ui.selectedType = msg.itemType
 		return ui, nil
+	case sharesLoaded:
+		ui.shares = msg.shares
+		ui.state = stateItemShares
+		return ui, nil
+	case recipientKeyLoaded:
+		return ui.handleRecipientKeyLoaded(msg)
+	case sharedItemsLoaded:
+		ui.sharedItems = msg.items
+		ui.failedItems = msg.failed
+		ui.ownFingerprint = msg.fingerprint
+		ui.state = stateSharedWithMe
+		return ui, nil
+	case sharedItemDecrypted:
+		ui.sharedItem = msg.item
+		return ui, nil
+	case vaultsLoaded:
+		ui.vaults = msg.vaults
+		if ui.currentVault >= len(ui.vaults) {
+			ui.currentVault = 0
+		}
+		ui.state = stateVaultList
+		return ui, nil
+	case vaultOpened:
+		ui.Item.SetVault(msg.vault)
+		ui.loggedInMenu = 0
+		ui.state = stateMenuLoggedIn
+		return ui, nil
+	case membersLoaded:
+		ui.members = msg.members
+		if ui.currentMember >= len(ui.members) {
+			ui.currentMember = 0
+		}
+		ui.state = stateOrgMembers
+		return ui, nil
+	case emergencyLoaded:
+		ui.emergency = msg.entries
+		if ui.currentEmergency >= len(ui.emergency) {
+			ui.currentEmergency = 0
+		}
+		ui.state = stateEmergencyList
+		return ui, nil
+	case devicesLoaded:
+		ui.devices = msg.devices
+		ui.approvalRequired = msg.approvalRequired
+		if ui.currentDevice >= len(ui.devices) {
+			ui.currentDevice = 0
+		}
+		ui.state = stateDeviceList
+		return ui, nil
+	case attachmentsLoaded:
+		ui.attachments = msg.attachments
+		ui.attachmentMsg = msg.message
+		if ui.currentAttachment >= len(ui.attachments) {
+			ui.currentAttachment = 0
+		}
+		ui.state = stateAttachmentList
+		return ui, nil
+	case templatesLoaded:
+		return ui.handleTemplatesLoaded(msg)
+	case identityImagesSet:
+		ui.decryptedItem = msg.item
+		ui.state = stateItemDetails
+		return ui, nil
+	case sshKeyLoaded:
+		return ui.handleSSHKeyLoaded(msg)
+	case sshSignRequest:
+		return ui.handleSSHSignRequest(msg)
+	case sshSignExpired:
+		return ui.handleSSHSignExpired(msg)
+	case sessionResumed:
+		ui.login = msg.login
+		ui.isAuthenticated = true
+		ui.input = ""
+		ui.messages.ClearAll()
+		ui.state = stateMasterPassword
+		return ui, nil
+	case synced:
+		ui.syncMsg = msg.message
+		ui.state = stateMenuLoggedIn
+		return ui, nil
+	case conflictsLoaded:
+		ui.conflicts = msg.conflicts
+		if ui.currentConflict >= len(ui.conflicts) {
+			ui.currentConflict = 0
+		}
+		ui.state = stateSyncConflicts
+		return ui, nil
+	case breachesChecked:
+		ui.breaches = msg.reports
+		ui.breachesChecked = msg.checked
+		ui.currentBreach = 0
+		ui.state = stateBreachReport
+		return ui, nil
+	case emergencyVaultOpened:
+		ui.emergencyGrantor = msg.grantor
+		ui.emergencyItems = msg.items
+		ui.currentEmergencyItem = 0
+		ui.state = stateEmergencyVault
+		return ui, nil
 	case errorMsg:
 		ui.messages.Set("error", msg.err.Error())
 		ui.messages.Set("error_context", msg.context)
@@ -114,40 +232,16 @@ func (ui *UIController) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return ui.handleDeleteErrorInput(msg)
 	case ui.state == stateEditItemName:
 		return ui.handleEditItemNameInput(msg)
-	case ui.state == stateEditCredentialLogin:
-		return ui.handleEditCredentialLoginInput(msg)
-	case ui.state == stateEditCredentialPassword:
-		return ui.handleEditCredentialPasswordInput(msg)
-	case ui.state == stateEditItemData:
-		return ui.handleEditItemDataInput(msg)
 	case ui.state == stateEditSuccess:
 		return ui.handleEditSuccessInput(msg)
 	case ui.state == stateEditError:
 		return ui.handleEditErrorInput(msg)
-	case ui.state == stateEditTextContent:
-		return ui.handleEditTextContentInput(msg)
-	case ui.state == stateEditBinaryData:
-		return ui.handleEditBinaryDataInput(msg)
 	case ui.state == stateAddItemError:
 		return ui.handleAddItemErrorInput(msg)
 	case ui.state == stateAddItemSuccess:
 		return ui.handleAddItemSuccessInput(msg)
 	case ui.state.IsAddItemInput():
 		return ui.handleAddItemInput(msg)
-	case ui.state == stateAddCardExpiry:
-		return ui.handleAddCardExpiryInput(msg)
-	case ui.state == stateAddCardCVV:
-		return ui.handleAddCardCVVInput(msg)
-	case ui.state == stateAddCardHolder:
-		return ui.handleAddCardHolderInput(msg)
-	case ui.state == stateEditCardNumber:
-		return ui.handleEditCardNumberInput(msg)
-	case ui.state == stateEditCardExpiry:
-		return ui.handleEditCardExpiryInput(msg)
-	case ui.state == stateEditCardCVV:
-		return ui.handleEditCardCVVInput(msg)
-	case ui.state == stateEditCardHolder:
-		return ui.handleEditCardHolderInput(msg)
 	case ui.state.IsLoginInput():
 		return ui.handleLoginInput(msg)
 	case ui.state.IsPasswordInput():
@@ -176,6 +270,86 @@ func (ui *UIController) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return ui.handleLogoutSuccessInput(msg)
 	case ui.state == stateLogoutError:
 		return ui.handleLogoutErrorInput(msg)
+	case ui.state == stateShareRecipient:
+		return ui.handleShareRecipientInput(msg)
+	case ui.state == stateItemShares:
+		return ui.handleItemSharesInput(msg)
+	case ui.state == stateConfirmRevokeShare:
+		return ui.handleConfirmRevokeShareInput(msg)
+	case ui.state == stateShareVerifyKey:
+		return ui.handleShareVerifyKeyInput(msg)
+	case ui.state == stateShareSuccess || ui.state == stateShareError:
+		return ui.handleShareResultInput(msg)
+	case ui.state == stateSharedWithMe:
+		return ui.handleSharedWithMeInput(msg)
+	case ui.state == stateSharedItemDetails:
+		return ui.handleSharedItemDetailsInput(msg)
+	case ui.state == stateVaultList:
+		return ui.handleVaultListInput(msg)
+	case ui.state == stateNewOrgName:
+		return ui.handleNewOrgNameInput(msg)
+	case ui.state == stateOrgMembers:
+		return ui.handleOrgMembersInput(msg)
+	case ui.state == stateInviteMember:
+		return ui.handleInviteMemberInput(msg)
+	case ui.state == stateConfirmRemoveMember:
+		return ui.handleConfirmRemoveMemberInput(msg)
+	case ui.state == stateEmergencyList:
+		return ui.handleEmergencyListInput(msg)
+	case ui.state == stateEmergencyGrant:
+		return ui.handleEmergencyGrantInput(msg)
+	case ui.state == stateEmergencyVault:
+		return ui.handleEmergencyVaultInput(msg)
+	case ui.state == stateEmergencyItemDetails:
+		return ui.handleEmergencyItemDetailsInput(msg)
+	case ui.state == stateEmergencyTakeover:
+		return ui.handleEmergencyTakeoverInput(msg)
+	case ui.state == stateSearchItems:
+		return ui.handleSearchInput(msg)
+	case ui.state == stateAddItemExpiry:
+		return ui.handleAddItemExpiryInput(msg)
+	case ui.state == stateEditItemExpiry:
+		return ui.handleEditItemExpiryInput(msg)
+	case ui.state == stateSendOptions:
+		return ui.handleSendOptionsInput(msg)
+	case ui.state == stateDeviceList:
+		return ui.handleDeviceListInput(msg)
+	case ui.state == stateConfirmRemoveDevice:
+		return ui.handleConfirmRemoveDeviceInput(msg)
+	case ui.state == stateBreachReport:
+		return ui.handleBreachReportInput(msg)
+	case ui.state == stateAttachmentList:
+		return ui.handleAttachmentListInput(msg)
+	case ui.state == stateAttachFile, ui.state == stateSaveAttachment:
+		return ui.handleAttachmentPathInput(msg)
+	case ui.state == stateConfirmDeleteAttachment:
+		return ui.handleConfirmDeleteAttachmentInput(msg)
+	case ui.state == stateAddSSHKeyPassphrase:
+		return ui.handleSSHKeyPassphraseInput(msg)
+	case ui.state == stateSSHSignConfirm:
+		return ui.handleSSHSignConfirmInput(msg)
+	case ui.state == stateTemplateList:
+		return ui.handleTemplateListInput(msg)
+	case ui.state == stateTemplateName:
+		return ui.handleTemplateNameInput(msg)
+	case ui.state == stateTemplateFieldName:
+		return ui.handleTemplateFieldNameInput(msg)
+	case ui.state == stateTemplateFieldType:
+		return ui.handleTemplateFieldTypeInput(msg)
+	case ui.state == stateEditCustomField:
+		return ui.handleEditCustomFieldInput(msg)
+	case ui.state == stateEditDataForm:
+		return ui.handleEditDataFormInput(msg)
+	case ui.state == stateIdentityFrontImage, ui.state == stateIdentityBackImage:
+		return ui.handleIdentityImageInput(msg)
+	case ui.state == stateConnectionKind:
+		return ui.handleConnectionKindInput(msg)
+	case ui.state == stateConnectionCopy:
+		return ui.handleConnectionCopyInput(msg)
+	case ui.state == stateSyncConflicts:
+		return ui.handleSyncConflictsInput(msg)
+	case ui.state == stateUnlockSession, ui.state == stateRememberDevice:
+		return ui.handleSessionPINInput(msg)
 	}
 	return ui, nil
 }
@@ -207,20 +381,10 @@ func (ui *UIController) View() string {
 		return ui.deleteErrorView()
 	case ui.state == stateEditItemName:
 		return ui.editItemNameView()
-	case ui.state == stateEditCredentialLogin:
-		return ui.editCredentialLoginView()
-	case ui.state == stateEditCredentialPassword:
-		return ui.editCredentialPasswordView()
-	case ui.state == stateEditItemData:
-		return ui.editItemDataView()
 	case ui.state == stateEditSuccess:
 		return ui.editSuccessView()
 	case ui.state == stateEditError:
 		return ui.editErrorView()
-	case ui.state == stateEditTextContent:
-		return ui.editTextContentView()
-	case ui.state == stateEditBinaryData:
-		return ui.editBinaryDataView()
 	case ui.state == stateAddItemError:
 		return ui.addItemErrorView()
 	case ui.state == stateAddItemSuccess:
@@ -241,22 +405,6 @@ func (ui *UIController) View() string {
 		return ui.addItemNameView()
 	case ui.state == stateAddItemData:
 		return ui.addItemDataView()
-	case ui.state == stateAddCredentialPassword:
-		return ui.addItemPasswordView()
-	case ui.state == stateAddCardExpiry:
-		return ui.addCardExpiryView()
-	case ui.state == stateAddCardCVV:
-		return ui.addCardCVVView()
-	case ui.state == stateAddCardHolder:
-		return ui.addCardHolderView()
-	case ui.state == stateEditCardNumber:
-		return ui.editCardNumberView()
-	case ui.state == stateEditCardExpiry:
-		return ui.editCardExpiryView()
-	case ui.state == stateEditCardCVV:
-		return ui.editCardCVVView()
-	case ui.state == stateEditCardHolder:
-		return ui.editCardHolderView()
 	case ui.state == stateMetadataList:
 		return ui.metadataListView()
 	case ui.state == stateAddMetadataKey:
@@ -277,6 +425,88 @@ func (ui *UIController) View() string {
 		return ui.logoutSuccessView()
 	case ui.state == stateLogoutError:
 		return ui.logoutErrorView()
+	case ui.state == stateShareRecipient:
+		return ui.shareRecipientView()
+	case ui.state == stateItemShares:
+		return ui.itemSharesView()
+	case ui.state == stateConfirmRevokeShare:
+		return ui.confirmRevokeShareView()
+	case ui.state == stateShareVerifyKey:
+		return ui.shareVerifyKeyView()
+	case ui.state == stateShareSuccess:
+		return ui.shareSuccessView()
+	case ui.state == stateShareError:
+		return ui.shareErrorView()
+	case ui.state == stateSharedWithMe:
+		return ui.sharedWithMeView()
+	case ui.state == stateSharedItemDetails:
+		return ui.sharedItemDetailsView()
+	case ui.state == stateVaultList:
+		return ui.vaultListView()
+	case ui.state == stateNewOrgName:
+		return ui.newOrgNameView()
+	case ui.state == stateOrgMembers:
+		return ui.orgMembersView()
+	case ui.state == stateInviteMember:
+		return ui.inviteMemberView()
+	case ui.state == stateConfirmRemoveMember:
+		return ui.confirmRemoveMemberView()
+	case ui.state == stateEmergencyList:
+		return ui.emergencyListView()
+	case ui.state == stateEmergencyGrant:
+		return ui.emergencyGrantView()
+	case ui.state == stateEmergencyVault:
+		return ui.emergencyVaultView()
+	case ui.state == stateEmergencyItemDetails:
+		return ui.emergencyItemDetailsView()
+	case ui.state == stateEmergencyTakeover:
+		return ui.emergencyTakeoverView()
+	case ui.state == stateSearchItems:
+		return ui.searchItemsView()
+	case ui.state == stateAddItemExpiry:
+		return ui.addItemExpiryView()
+	case ui.state == stateEditItemExpiry:
+		return ui.editItemExpiryView()
+	case ui.state == stateSendOptions:
+		return ui.sendOptionsView()
+	case ui.state == stateDeviceList:
+		return ui.deviceListView()
+	case ui.state == stateConfirmRemoveDevice:
+		return ui.confirmRemoveDeviceView()
+	case ui.state == stateBreachReport:
+		return ui.breachReportView()
+	case ui.state == stateAttachmentList:
+		return ui.attachmentListView()
+	case ui.state == stateAttachFile, ui.state == stateSaveAttachment:
+		return ui.attachmentPathView()
+	case ui.state == stateConfirmDeleteAttachment:
+		return ui.confirmDeleteAttachmentView()
+	case ui.state == stateAddSSHKeyPassphrase:
+		return ui.sshKeyPassphraseView()
+	case ui.state == stateSSHSignConfirm:
+		return ui.sshSignConfirmView()
+	case ui.state == stateTemplateList:
+		return ui.templateListView()
+	case ui.state == stateTemplateName:
+		return ui.templateNameView()
+	case ui.state == stateTemplateFieldName:
+		return ui.templateFieldNameView()
+	case ui.state == stateTemplateFieldType:
+		return ui.templateFieldTypeView()
+	case ui.state == stateEditCustomField:
+		return ui.editCustomFieldView()
+	case ui.state == stateEditDataForm:
+		return ui.editDataFormView()
+	case ui.state == stateIdentityFrontImage, ui.state == stateIdentityBackImage:
+		return ui.identityImageView()
+	case ui.state == stateConnectionKind:
+		return ui.connectionKindView()
+	case ui.state == stateConnectionCopy:
+		return ui.connectionCopyView()
+	case ui.state == stateSyncConflicts:
+		return ui.syncConflictsView()
+	case ui.state == stateUnlockSession, ui.state == stateRememberDevice:
+		return ui.sessionPINView()
 	}
 	return "View error:" + debug
 }
@@ -346,13 +576,27 @@ func (ui *UIController) handleProcessComplete(msg processComplete) (tea.Model, t
 		switch msg.context {
 		case "auth_to_master":
 			ui.isAuthenticated = true
+			ui.input = ""
+			switch {
+			case !ui.canRememberDevice():
+				ui.state = stateMasterPassword
+			case ui.User.SessionNeedsPIN():
+				ui.state = stateRememberDevice
+			default:
+				ui.state = stateProcessing
+				return ui, ui.rememberDeviceCmd("")
+			}
+			return ui, nil
+		case "remember_device":
 			ui.state = stateMasterPassword
 			ui.input = ""
 			return ui, nil
 		case "master_password":
 			ui.state = stateMenuLoggedIn
 			ui.input = ""
-			return ui, nil
+			ui.messages.Clear("master_password_error")
+			ui.unlockSSHAgent()
+			return ui, ui.startIdleTimer()
 		case "auth":
 			ui.state = stateMenuLoggedIn
 			ui.input = ""
@@ -380,6 +624,14 @@ func (ui *UIController) handleProcessComplete(msg processComplete) (tea.Model, t
 			ui.state = stateLogoutSuccess
 			ui.logoutSuccessMsg = msg.message
 			return ui, nil
+		case "share_item", "revoke_share", "create_send":
+			ui.state = stateShareSuccess
+			ui.shareSuccessMsg = msg.message
+			return ui, nil
+		case "takeover_account":
+			ui.state = stateSuccess
+			ui.messages.Set("success", msg.message)
+			return ui, nil
 		default:
 			ui.state = stateMenuLoggedIn
 			ui.input = ""
@@ -389,8 +641,15 @@ func (ui *UIController) handleProcessComplete(msg processComplete) (tea.Model, t
 		switch msg.context {
 		case "auth":
 			ui.state = stateMenuLoggedOut
-		case "master_password":
+		case "master_password", "remember_device":
 			ui.state = stateMasterPassword
+			ui.messages.Set("master_password_error", msg.message)
+		case "session":
+			ui.state = stateMenuLoggedOut
+			if ui.remembersDevice() {
+				ui.state = stateUnlockSession
+			}
+			ui.messages.Set("session_error", msg.message)
 		case "delete_item":
 			ui.state = stateDeleteError
 			ui.deleteErrorMsg = msg.message
@@ -408,6 +667,10 @@ func (ui *UIController) handleProcessComplete(msg processComplete) (tea.Model, t
 			ui.state = stateLogoutError
 			ui.logoutErrorMsg = msg.message
 			return ui, nil
+		case "share_item", "revoke_share", "create_send":
+			ui.state = stateShareError
+			ui.shareErrorMsg = msg.message
+			return ui, nil
 		default:
 			ui.state = stateMenuLoggedOut
 		}
@@ -0,0 +1,162 @@
+package ui
+
+import (
+	"fmt"
+	"gophkeeper/models"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/muesli/termenv"
+)
+
+// copyToClipboard sets the clipboard of the terminal with an OSC 52
+// sequence, so it also works over SSH.
+var copyToClipboard = termenv.Copy
+
+var connectionKindTitles = map[models.ConnectionKind]string{
+	models.ConnectionPostgres: "PostgreSQL",
+	models.ConnectionMySQL:    "MySQL",
+	models.ConnectionHTTP:     "HTTP API",
+}
+
+func (ui *UIController) startConnectionKind() (tea.Model, tea.Cmd) {
+	ui.connectionKindMenu = 0
+	ui.state = stateConnectionKind
+	return ui, nil
+}
+
+func (ui *UIController) handleConnectionKindInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c", "q":
+		return ui, tea.Quit
+	case "esc", "b":
+		ui.state = stateAddItem
+		return ui, nil
+	case "up", "k":
+		if ui.connectionKindMenu > 0 {
+			ui.connectionKindMenu--
+		}
+	case "down", "j":
+		if ui.connectionKindMenu < len(models.ConnectionKinds)-1 {
+			ui.connectionKindMenu++
+		}
+	case "enter":
+		ui.selectItemType(models.ItemTypeCONNECTION)
+		ui.newItem.Data = &models.Connection{Kind: models.ConnectionKinds[ui.connectionKindMenu]}
+		return ui, nil
+	}
+	return ui, nil
+}
+
+func (ui *UIController) connectionKindView() string {
+	title := titleStyle.Render("Add CONNECTION - Select Kind")
+
+	menu := ""
+	for i, kind := range models.ConnectionKinds {
+		if i == ui.connectionKindMenu {
+			menu += selectedStyle.Render("→ "+connectionKindTitles[kind]) + "\n"
+		} else {
+			menu += menuStyle.Render("  "+connectionKindTitles[kind]) + "\n"
+		}
+	}
+
+	controls := "\nControls: ↑/↓ to navigate, Enter to select, b/Esc to go back"
+	return fmt.Sprintf("%s\n\n%s%s", title, menu, controls)
+}
+
+func connectionDataView(data *models.Connection) string {
+	details := fmt.Sprintf("  Kind: %s\n", connectionKindTitles[data.Kind])
+	for _, f := range data.Fields() {
+		details += fmt.Sprintf("  %s: %s\n", f.Name, f.Value)
+	}
+	return details
+}
+
+// copyEntry is a value of a connection that can be copied.
+type copyEntry struct {
+	label  string
+	value  string
+	secret bool
+}
+
+// connectionCopyEntries lists the fields of the connection and then its
+// renderings.
+func connectionCopyEntries(data *models.Connection) []copyEntry {
+	var entries []copyEntry
+	for _, f := range data.Fields() {
+		entries = append(entries, copyEntry{label: f.Name, value: f.Value, secret: f.Secret})
+	}
+	for _, format := range models.ConnectionFormats {
+		value, err := data.Render(format)
+		if err != nil || value == "" {
+			continue
+		}
+		entries = append(entries, copyEntry{label: format, value: value, secret: true})
+	}
+	return entries
+}
+
+func (ui *UIController) startConnectionCopy() (tea.Model, tea.Cmd) {
+	if _, ok := ui.decryptedItem.Data.(*models.Connection); !ok {
+		return ui, nil
+	}
+	ui.connectionCopyMenu = 0
+	ui.connectionMsg = ""
+	ui.state = stateConnectionCopy
+	return ui, nil
+}
+
+func (ui *UIController) handleConnectionCopyInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	entries := connectionCopyEntries(ui.decryptedItem.Data.(*models.Connection))
+
+	switch msg.String() {
+	case "ctrl+c", "q":
+		return ui, tea.Quit
+	case "esc", "b":
+		ui.state = stateItemDetails
+		return ui, nil
+	case "up", "k":
+		if ui.connectionCopyMenu > 0 {
+			ui.connectionCopyMenu--
+		}
+	case "down", "j":
+		if ui.connectionCopyMenu < len(entries)-1 {
+			ui.connectionCopyMenu++
+		}
+	case "r":
+		ui.revealed = !ui.revealed
+	case "enter", "c":
+		if ui.connectionCopyMenu < len(entries) {
+			entry := entries[ui.connectionCopyMenu]
+			copyToClipboard(entry.value)
+			ui.connectionMsg = fmt.Sprintf("Copied %s to the clipboard", entry.label)
+		}
+	}
+	return ui, nil
+}
+
+func (ui *UIController) connectionCopyView() string {
+	title := titleStyle.Render(fmt.Sprintf("Copy from %s", ui.decryptedItem.Name))
+
+	message := ""
+	if ui.connectionMsg != "" {
+		message = successStyle.Render(ui.connectionMsg) + "\n\n"
+	}
+
+	list := ""
+	for i, entry := range connectionCopyEntries(ui.decryptedItem.Data.(*models.Connection)) {
+		value := entry.value
+		if entry.secret && !ui.revealed {
+			value = "(hidden)"
+		}
+		line := fmt.Sprintf("%s: %s", entry.label, strings.ReplaceAll(value, "\n", " "))
+		if i == ui.connectionCopyMenu {
+			list += selectedStyle.Render("→ "+line) + "\n"
+		} else {
+			list += menuStyle.Render("  "+line) + "\n"
+		}
+	}
+
+	controls := "\nControls: ↑/↓ to navigate, Enter to copy, r to reveal, b/Esc to go back"
+	return fmt.Sprintf("%s\n\n%s%s%s", title, message, list, controls)
+}
@@ -0,0 +1,105 @@
+package ui
+
+import (
+	"gophkeeper/models"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUIController_connectionForm(t *testing.T) {
+	ui := &UIController{}
+	ui.messages.init()
+	ui.handleItemTypeSelection(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'9'}})
+	require.Equal(t, stateConnectionKind, ui.state)
+	assert.Contains(t, ui.connectionKindView(), "MySQL")
+
+	ui.handleConnectionKindInput(tea.KeyMsg{Type: tea.KeyEnter})
+	require.Equal(t, stateAddItemName, ui.state)
+	assert.Equal(t, models.ItemTypeCONNECTION, ui.newItem.Type)
+
+	ui.newItem.Name = "orders db"
+	ui.state = stateAddItemExpiry
+	ui.handleAddItemExpiryInput(tea.KeyMsg{Type: tea.KeyEnter})
+	require.Equal(t, stateAddItemData, ui.state)
+	assert.Contains(t, ui.addItemDataView(), "Host*")
+
+	for _, value := range []string{"db.example.com", "5432", "orders", "app", "secret", "sometimes"} {
+		typeRunes(ui.handleItemDataInput, value)
+		ui.handleItemDataInput(tea.KeyMsg{Type: tea.KeyEnter})
+	}
+	assert.Contains(t, ui.messages.Get("error"), "TLS mode")
+	ui.input = "require"
+	_, cmd := ui.handleItemDataInput(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.NotNil(t, cmd)
+
+	assert.Equal(t, &models.Connection{
+		Kind:     models.ConnectionPostgres,
+		Host:     "db.example.com",
+		Port:     5432,
+		Database: "orders",
+		User:     "app",
+		Password: "secret",
+		TLSMode:  "require",
+	}, ui.newItem.Data)
+}
+
+func TestUIController_dataForm_Connection(t *testing.T) {
+	ui := &UIController{}
+	ui.messages.init()
+	conn := &models.Connection{Kind: models.ConnectionHTTP, BaseURL: "https://api.example.com", Token: "tok"}
+	ui.startDataForm(conn)
+	require.Len(t, ui.dataForm.Fields, 2)
+
+	ui.dataForm.Fields[1].Value = "new"
+	require.NoError(t, ui.applyDataForm(conn))
+	assert.Equal(t, "new", conn.Token)
+
+	ui.dataForm.Fields[0].Value = "ftp://files.example.com"
+	assert.Error(t, ui.applyDataForm(conn))
+	assert.Equal(t, "https://api.example.com", conn.BaseURL)
+	assert.Contains(t, ui.messages.Get("error"), "base URL")
+}
+
+func TestUIController_connectionDetails(t *testing.T) {
+	var copied string
+	orig := copyToClipboard
+	copyToClipboard = func(s string) { copied = s }
+	t.Cleanup(func() { copyToClipboard = orig })
+
+	ui := &UIController{
+		state: stateItemDetails,
+		itemCtrl: itemCtrl{
+			items: []models.EncryptedItem{{Name: "api", Type: models.ItemTypeCONNECTION}},
+			decryptedItem: &models.Item{Name: "api", Type: models.ItemTypeCONNECTION, Data: &models.Connection{
+				Kind:    models.ConnectionHTTP,
+				BaseURL: "https://api.example.com",
+				Token:   "tok123",
+			}},
+		},
+	}
+	ui.messages.init()
+
+	view := ui.itemDetailsView()
+	assert.Contains(t, view, "token: ******")
+	assert.NotContains(t, view, "tok123")
+
+	ui.handleItemDetailsInput(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'c'}})
+	require.Equal(t, stateConnectionCopy, ui.state)
+	assert.Contains(t, ui.connectionCopyView(), "token: (hidden)")
+
+	ui.handleConnectionCopyInput(tea.KeyMsg{Type: tea.KeyDown})
+	ui.handleConnectionCopyInput(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Equal(t, "tok123", copied)
+	assert.Contains(t, ui.connectionCopyView(), "Copied token")
+
+	ui.handleConnectionCopyInput(tea.KeyMsg{Type: tea.KeyDown})
+	ui.handleConnectionCopyInput(tea.KeyMsg{Type: tea.KeyDown})
+	ui.handleConnectionCopyInput(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Equal(t, "API_URL=https://api.example.com\nAPI_TOKEN=tok123", copied)
+
+	ui.handleConnectionCopyInput(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.Equal(t, stateItemDetails, ui.state)
+}
@@ -0,0 +1,556 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"gophkeeper/models"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type templatesLoaded struct {
+	templates []models.ItemTemplate
+	message   string
+}
+
+var fieldTypeHints = map[models.FieldType]string{
+	models.FieldHidden:    "hidden while typing",
+	models.FieldNumber:    "a number",
+	models.FieldDate:      "YYYY-MM-DD",
+	models.FieldURL:       "https://...",
+	models.FieldMultiline: "Alt+Enter for a new line",
+	models.FieldBoolean:   "Space to toggle",
+}
+
+func (ui *UIController) handleTemplates() (tea.Model, tea.Cmd) {
+	ui.state = stateProcessing
+	ui.currentTemplate = 0
+	return ui, ui.templateActionCmd(nil, "")
+}
+
+// templateActionCmd runs an optional action and reloads the templates. The
+// action result is shown above the list.
+func (ui *UIController) templateActionCmd(action func(ctx context.Context) (string, error), errContext string) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		var message string
+		if action != nil {
+			var err error
+			if message, err = action(ctx); err != nil {
+				return errorMsg{
+					err:     err,
+					context: errContext,
+				}
+			}
+		}
+
+		templates, err := ui.Item.ListTemplates(ctx)
+		if err != nil {
+			return errorMsg{
+				err:     err,
+				context: "load_templates",
+			}
+		}
+		return templatesLoaded{
+			templates: templates,
+			message:   message,
+		}
+	}
+}
+
+func (ui *UIController) handleTemplatesLoaded(msg templatesLoaded) (tea.Model, tea.Cmd) {
+	ui.templates = msg.templates
+	ui.templateMsg = msg.message
+	if ui.currentTemplate >= len(ui.templates) {
+		ui.currentTemplate = 0
+	}
+	ui.state = stateTemplateList
+	return ui, nil
+}
+
+func (ui *UIController) handleTemplateListInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c", "q":
+		return ui, tea.Quit
+	case "esc", "b":
+		ui.state = stateAddItem
+		ui.templateMsg = ""
+		return ui, nil
+	case "up", "k":
+		if ui.currentTemplate > 0 {
+			ui.currentTemplate--
+		}
+	case "down", "j":
+		if ui.currentTemplate < len(ui.templates)-1 {
+			ui.currentTemplate++
+		}
+	case "n":
+		ui.newTemplate = models.ItemTemplate{}
+		ui.state = stateTemplateName
+		ui.input = ""
+		ui.messages.Clear("error")
+	case "enter":
+		if ui.currentTemplate < len(ui.templates) {
+			return ui.useTemplate(ui.templates[ui.currentTemplate]), nil
+		}
+	case "d", "delete":
+		if ui.currentTemplate < len(ui.templates) {
+			tmpl := ui.templates[ui.currentTemplate]
+			ui.state = stateProcessing
+			return ui, ui.templateActionCmd(func(ctx context.Context) (string, error) {
+				if err := ui.Item.DeleteTemplate(ctx, tmpl.ID); err != nil {
+					return "", err
+				}
+				return fmt.Sprintf("Template %q deleted, its items keep their fields", tmpl.Name), nil
+			}, "delete_template")
+		}
+	}
+	return ui, nil
+}
+
+// useTemplate starts adding a CUSTOM item with the fields of tmpl.
+func (ui *UIController) useTemplate(tmpl models.ItemTemplate) *UIController {
+	ui.customTemplate = &tmpl
+	ui.newItem.Type = models.ItemTypeCUSTOM
+	ui.newItem.Data = tmpl.NewItemData()
+	ui.templateMsg = ""
+	ui.state = stateAddItemName
+	ui.input = ""
+	return ui
+}
+
+func (ui *UIController) templateListView() string {
+	title := titleStyle.Render("Add CUSTOM - Select Template")
+
+	message := ""
+	if ui.templateMsg != "" {
+		message = successStyle.Render(ui.templateMsg) + "\n\n"
+	}
+
+	if len(ui.templates) == 0 {
+		return fmt.Sprintf("%s\n\n%sNo templates yet.\n\nControls: n to create a template, b/Esc to go back", title, message)
+	}
+
+	list := ""
+	for i, t := range ui.templates {
+		line := fmt.Sprintf("%s (%s)", t.Name, templateFieldsSummary(t))
+		if i == ui.currentTemplate {
+			list += selectedStyle.Render("→ "+line) + "\n"
+		} else {
+			list += menuStyle.Render("  "+line) + "\n"
+		}
+	}
+
+	controls := "\nControls: ↑/↓ to navigate, Enter to use, n to create a template, d to delete, b/Esc to go back"
+	return fmt.Sprintf("%s\n\n%s%s%s", title, message, list, controls)
+}
+
+func templateFieldsSummary(t models.ItemTemplate) string {
+	names := make([]string, len(t.Fields))
+	for i, f := range t.Fields {
+		names[i] = f.Name
+		if f.Required {
+			names[i] += "*"
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
+func (ui *UIController) handleTemplateNameInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return ui, tea.Quit
+	case "esc":
+		ui.state = stateTemplateList
+		ui.input = ""
+		ui.messages.Clear("error")
+		return ui, nil
+	case "enter":
+		name := strings.TrimSpace(ui.input)
+		if name == "" {
+			ui.messages.Set("error", "Name cannot be empty")
+			return ui, nil
+		}
+		ui.newTemplate.Name = name
+		ui.state = stateTemplateFieldName
+		ui.input = ""
+		ui.messages.Clear("error")
+		return ui, nil
+	case "backspace":
+		if len(ui.input) > 0 {
+			ui.input = ui.input[:len(ui.input)-1]
+		}
+	default:
+		if len(msg.String()) == 1 {
+			ui.input += msg.String()
+		}
+	}
+	return ui, nil
+}
+
+// handleTemplateFieldNameInput adds fields until an empty name saves the
+// template.
+func (ui *UIController) handleTemplateFieldNameInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return ui, tea.Quit
+	case "esc":
+		ui.state = stateTemplateName
+		ui.input = ui.newTemplate.Name
+		ui.messages.Clear("error")
+		return ui, nil
+	case "enter":
+		name := strings.TrimSpace(ui.input)
+		if name == "" {
+			return ui.saveTemplate()
+		}
+		if slices.ContainsFunc(ui.newTemplate.Fields, func(f models.TemplateField) bool {
+			return strings.EqualFold(f.Name, name)
+		}) {
+			ui.messages.Set("error", fmt.Sprintf("Field %q already exists", name))
+			return ui, nil
+		}
+		ui.newField = models.TemplateField{Name: name}
+		ui.fieldTypeMenu = 0
+		ui.state = stateTemplateFieldType
+		ui.messages.Clear("error")
+		return ui, nil
+	case "backspace":
+		if len(ui.input) > 0 {
+			ui.input = ui.input[:len(ui.input)-1]
+		}
+	default:
+		if len(msg.String()) == 1 {
+			ui.input += msg.String()
+		}
+	}
+	return ui, nil
+}
+
+func (ui *UIController) saveTemplate() (tea.Model, tea.Cmd) {
+	if err := ui.newTemplate.Validate(); err != nil {
+		ui.messages.Set("error", err.Error())
+		return ui, nil
+	}
+	tmpl := ui.newTemplate
+	ui.state = stateProcessing
+	ui.input = ""
+	ui.messages.Clear("error")
+	return ui, ui.templateActionCmd(func(ctx context.Context) (string, error) {
+		if err := ui.Item.SaveTemplate(ctx, &tmpl); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Template %q saved", tmpl.Name), nil
+	}, "save_template")
+}
+
+func (ui *UIController) handleTemplateFieldTypeInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return ui, tea.Quit
+	case "esc":
+		ui.state = stateTemplateFieldName
+		ui.input = ui.newField.Name
+		return ui, nil
+	case "up", "k":
+		if ui.fieldTypeMenu > 0 {
+			ui.fieldTypeMenu--
+		}
+	case "down", "j":
+		if ui.fieldTypeMenu < len(models.FieldTypes)-1 {
+			ui.fieldTypeMenu++
+		}
+	case " ", "r":
+		ui.newField.Required = !ui.newField.Required
+	case "enter":
+		ui.newField.Type = models.FieldTypes[ui.fieldTypeMenu]
+		ui.newTemplate.Fields = append(ui.newTemplate.Fields, ui.newField)
+		ui.state = stateTemplateFieldName
+		ui.input = ""
+	}
+	return ui, nil
+}
+
+func (ui *UIController) newTemplateFields() string {
+	fields := ""
+	for _, f := range ui.newTemplate.Fields {
+		required := ""
+		if f.Required {
+			required = ", required"
+		}
+		fields += fmt.Sprintf("  %s (%s%s)\n", f.Name, f.Type, required)
+	}
+	return fields
+}
+
+func (ui *UIController) templateNameView() string {
+	title := titleStyle.Render("New Template - Enter Name")
+	input := inputStyle.Render(ui.input + "█")
+
+	errorMsg := ""
+	if err := ui.messages.Get("error"); err != "" {
+		errorMsg = "\n" + errorStyle.Render(err)
+	}
+
+	controls := "\nControls: Esc to go back, Enter to continue"
+	return fmt.Sprintf("%s\n\nName: %s%s%s", title, input, errorMsg, controls)
+}
+
+func (ui *UIController) templateFieldNameView() string {
+	title := titleStyle.Render(fmt.Sprintf("New Template %q - Add Field", ui.newTemplate.Name))
+	input := inputStyle.Render(ui.input + "█")
+
+	errorMsg := ""
+	if err := ui.messages.Get("error"); err != "" {
+		errorMsg = "\n" + errorStyle.Render(err)
+	}
+
+	fields := "No fields yet.\n"
+	if len(ui.newTemplate.Fields) > 0 {
+		fields = "Fields:\n" + ui.newTemplateFields()
+	}
+
+	controls := "\nControls: Enter to add the field, Enter on an empty name to save the template, Esc to go back"
+	return fmt.Sprintf("%s\n\n%s\nField name: %s%s%s", title, fields, input, errorMsg, controls)
+}
+
+func (ui *UIController) templateFieldTypeView() string {
+	title := titleStyle.Render(fmt.Sprintf("New Template %q - Type of %q", ui.newTemplate.Name, ui.newField.Name))
+
+	menu := ""
+	for i, t := range models.FieldTypes {
+		if i == ui.fieldTypeMenu {
+			menu += selectedStyle.Render("→ "+string(t)) + "\n"
+		} else {
+			menu += menuStyle.Render("  "+string(t)) + "\n"
+		}
+	}
+
+	required := "no"
+	if ui.newField.Required {
+		required = "yes"
+	}
+
+	controls := "\nControls: ↑/↓ to choose the type, Space to toggle required, Enter to add, Esc to go back"
+	return fmt.Sprintf("%s\n\n%s\nRequired: %s\n%s", title, menu, required, controls)
+}
+
+// startCustomForm shows the first field of a CUSTOM item form.
+func (ui *UIController) startCustomForm(data *models.Custom) {
+	ui.customField = 0
+	ui.input = ""
+	if len(data.Fields) > 0 {
+		ui.input = data.Fields[0].Value
+	}
+}
+
+// handleCustomFieldInput walks the fields of a CUSTOM item one by one. back
+// is called on Esc at the first field, done after the last one.
+func (ui *UIController) handleCustomFieldInput(msg tea.KeyMsg, data *models.Custom, back, done func() (tea.Model, tea.Cmd)) (tea.Model, tea.Cmd) {
+	if ui.customField >= len(data.Fields) {
+		return done()
+	}
+	field := &data.Fields[ui.customField]
+
+	switch msg.String() {
+	case "ctrl+c":
+		return ui, tea.Quit
+	case "esc":
+		ui.messages.Clear("error")
+		if ui.customField == 0 {
+			return back()
+		}
+		ui.customField--
+		ui.input = data.Fields[ui.customField].Value
+		return ui, nil
+	case "alt+enter":
+		if field.Type == models.FieldMultiline {
+			ui.input += "\n"
+		}
+	case "enter":
+		value := ui.input
+		if field.Type != models.FieldMultiline {
+			value = strings.TrimSpace(value)
+		}
+		if value == "" && ui.customTemplate != nil && ui.customTemplate.Required(field.Name) {
+			ui.messages.Set("error", fmt.Sprintf("%s is required", field.Name))
+			return ui, nil
+		}
+		if err := field.Type.Validate(value); err != nil {
+			ui.messages.Set("error", fmt.Sprintf("%s %s", field.Name, err))
+			return ui, nil
+		}
+		if data == ui.dataForm && ui.customField < len(ui.dataFields) {
+			if err := ui.dataFields[ui.customField].Check(value); err != nil {
+				ui.messages.Set("error", err.Error())
+				return ui, nil
+			}
+		}
+		field.Value = value
+		ui.messages.Clear("error")
+
+		if ui.customField < len(data.Fields)-1 {
+			ui.customField++
+			ui.input = data.Fields[ui.customField].Value
+			return ui, nil
+		}
+		ui.input = ""
+		return done()
+	case "backspace":
+		if field.Type == models.FieldBoolean {
+			ui.input = ""
+		} else if len(ui.input) > 0 {
+			ui.input = ui.input[:len(ui.input)-1]
+		}
+	default:
+		if field.Type == models.FieldBoolean {
+			if msg.String() == " " {
+				ui.input = fmt.Sprint(ui.input != "true")
+			}
+		} else if len(msg.String()) == 1 {
+			ui.input += msg.String()
+		}
+	}
+	return ui, nil
+}
+
+// customFormView lists the fields entered so far and the input of the
+// current one. Hidden values are masked.
+func (ui *UIController) customFormView(title, name string, data *models.Custom) string {
+	form := fmt.Sprintf("Name: %s\n", name)
+	for i, f := range data.Fields {
+		label := f.Name
+		if ui.customTemplate != nil && ui.customTemplate.Required(f.Name) {
+			label += "*"
+		}
+
+		switch {
+		case i == ui.customField:
+			value := ui.input
+			if f.Type == models.FieldHidden {
+				value = strings.Repeat("*", len(value))
+			}
+			hint := ""
+			if h, ok := fieldTypeHints[f.Type]; ok {
+				hint = " (" + h + ")"
+			}
+			form += fmt.Sprintf("%s: %s%s\n", label, inputStyle.Render(value+"█"), hint)
+		case i < ui.customField:
+			form += fmt.Sprintf("%s: %s\n", label, maskedFieldValue(f))
+		default:
+			form += menuStyle.Render(label) + "\n"
+		}
+	}
+
+	errorMsg := ""
+	if err := ui.messages.Get("error"); err != "" {
+		errorMsg = "\n" + errorStyle.Render(err)
+	}
+
+	controls := "\nControls: Esc to go back, Enter to continue"
+	return fmt.Sprintf("%s\n\n%s%s%s", titleStyle.Render(title), form, errorMsg, controls)
+}
+
+func maskedFieldValue(f models.CustomField) string {
+	if f.Type == models.FieldHidden {
+		return strings.Repeat("*", len(f.Value))
+	}
+	return strings.ReplaceAll(f.Value, "\n", "\n  ")
+}
+
+func customDataView(data *models.Custom) string {
+	details := fmt.Sprintf("  Template: %s\n", data.Template)
+	for _, f := range data.Fields {
+		details += fmt.Sprintf("  %s: %s\n", f.Name, strings.ReplaceAll(f.Value, "\n", "\n    "))
+	}
+	return details
+}
+
+// templateOf returns the loaded template the item was made from, nil when
+// it is gone or the templates were not loaded.
+func (ui *UIController) templateOf(data *models.Custom) *models.ItemTemplate {
+	for i := range ui.templates {
+		if ui.templates[i].ID == data.TemplateID {
+			return &ui.templates[i]
+		}
+	}
+	return nil
+}
+
+func (ui *UIController) handleEditCustomFieldInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	return ui.handleCustomFieldInput(msg, ui.editingItem.Data.(*models.Custom),
+		func() (tea.Model, tea.Cmd) {
+			ui.state = stateEditItemExpiry
+			ui.input = formatExpiry(ui.editingItem.ExpiresAt)
+			return ui, nil
+		},
+		func() (tea.Model, tea.Cmd) {
+			ui.state = stateProcessing
+			return ui, ui.saveEditedItemCmd()
+		})
+}
+
+func (ui *UIController) editCustomFieldView() string {
+	return ui.customFormView("Edit Item - Fields", ui.editingItem.Name, ui.editingItem.Data.(*models.Custom))
+}
+
+// startDataForm shows the form of a built-in item type, filled with the
+// current values of data. The form is walked like a CUSTOM item.
+func (ui *UIController) startDataForm(data models.Data) {
+	info, _ := models.LookupType(data.GetType())
+	tmpl := info.Template(data)
+	ui.customTemplate = &tmpl
+	ui.dataForm = info.Form(data)
+	ui.dataFields = info.FieldsOf(data)
+	ui.startCustomForm(ui.dataForm)
+}
+
+// applyDataForm copies the form values to data. When they do not fit
+// together the user stays at the last field.
+func (ui *UIController) applyDataForm(data models.Data) error {
+	info, _ := models.LookupType(data.GetType())
+	err := info.ApplyForm(data, ui.dataForm)
+	if err != nil {
+		ui.messages.Set("error", err.Error())
+		ui.customField = len(ui.dataForm.Fields) - 1
+		ui.input = ui.dataForm.Fields[ui.customField].Value
+	}
+	return err
+}
+
+func (ui *UIController) handleAddDataFormInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	return ui.handleCustomFieldInput(msg, ui.dataForm,
+		func() (tea.Model, tea.Cmd) {
+			ui.state = stateAddItemExpiry
+			ui.input = formatExpiry(ui.newItem.ExpiresAt)
+			return ui, nil
+		},
+		func() (tea.Model, tea.Cmd) {
+			if err := ui.applyDataForm(ui.newItem.Data); err != nil {
+				return ui, nil
+			}
+			return ui, ui.addItemCmd()
+		})
+}
+
+func (ui *UIController) handleEditDataFormInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	return ui.handleCustomFieldInput(msg, ui.dataForm,
+		func() (tea.Model, tea.Cmd) {
+			ui.state = stateEditItemExpiry
+			ui.input = formatExpiry(ui.editingItem.ExpiresAt)
+			return ui, nil
+		},
+		func() (tea.Model, tea.Cmd) {
+			if err := ui.applyDataForm(ui.editingItem.Data); err != nil {
+				return ui, nil
+			}
+			ui.state = stateProcessing
+			return ui, ui.saveEditedItemCmd()
+		})
+}
+
+func (ui *UIController) editDataFormView() string {
+	return ui.customFormView("Edit Item - "+ui.customTemplate.Name, ui.editingItem.Name, ui.dataForm)
+}
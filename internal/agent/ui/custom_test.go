@@ -0,0 +1,158 @@
+package ui
+
+import (
+	"gophkeeper/models"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// typeRunes sends s to handle one key at a time.
+func typeRunes(handle func(tea.KeyMsg) (tea.Model, tea.Cmd), s string) {
+	for _, r := range s {
+		handle(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+}
+
+func TestUIController_newTemplate(t *testing.T) {
+	ui := &UIController{state: stateTemplateList}
+	ui.messages.init()
+
+	ui.handleTemplateListInput(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+	assert.Equal(t, stateTemplateName, ui.state)
+	typeRunes(ui.handleTemplateNameInput, "Wi-Fi")
+	ui.handleTemplateNameInput(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Equal(t, stateTemplateFieldName, ui.state)
+
+	// An empty template cannot be saved.
+	_, cmd := ui.handleTemplateFieldNameInput(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Nil(t, cmd)
+	assert.NotEmpty(t, ui.messages.Get("error"))
+
+	typeRunes(ui.handleTemplateFieldNameInput, "SSID")
+	ui.handleTemplateFieldNameInput(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Equal(t, stateTemplateFieldType, ui.state)
+	ui.handleTemplateFieldTypeInput(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}})
+	ui.handleTemplateFieldTypeInput(tea.KeyMsg{Type: tea.KeyEnter})
+
+	typeRunes(ui.handleTemplateFieldNameInput, "ssid")
+	ui.handleTemplateFieldNameInput(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Contains(t, ui.messages.Get("error"), "already exists")
+	ui.input = "Password"
+	ui.handleTemplateFieldNameInput(tea.KeyMsg{Type: tea.KeyEnter})
+	ui.handleTemplateFieldTypeInput(tea.KeyMsg{Type: tea.KeyDown})
+	ui.handleTemplateFieldTypeInput(tea.KeyMsg{Type: tea.KeyEnter})
+
+	assert.Equal(t, []models.TemplateField{
+		{Name: "SSID", Type: models.FieldText, Required: true},
+		{Name: "Password", Type: models.FieldHidden},
+	}, ui.newTemplate.Fields)
+	assert.Contains(t, ui.templateFieldNameView(), "SSID (text, required)")
+
+	_, cmd = ui.handleTemplateFieldNameInput(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.NotNil(t, cmd)
+	assert.Equal(t, stateProcessing, ui.state)
+}
+
+func TestUIController_customForm(t *testing.T) {
+	ui := &UIController{}
+	ui.messages.init()
+	ui.Update(templatesLoaded{templates: []models.ItemTemplate{{
+		ID:   [16]byte{1},
+		Name: "Server",
+		Fields: []models.TemplateField{
+			{Name: "Host", Type: models.FieldURL, Required: true},
+			{Name: "Root password", Type: models.FieldHidden},
+			{Name: "Notes", Type: models.FieldMultiline},
+			{Name: "Backups", Type: models.FieldBoolean},
+		},
+	}}})
+	require.Equal(t, stateTemplateList, ui.state)
+	assert.Contains(t, ui.templateListView(), "Server (Host*, Root password, Notes, Backups)")
+
+	ui.handleTemplateListInput(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Equal(t, stateAddItemName, ui.state)
+	assert.Equal(t, models.ItemTypeCUSTOM, ui.newItem.Type)
+	ui.newItem.Name = "db1"
+	ui.state = stateAddItemExpiry
+	ui.handleAddItemExpiryInput(tea.KeyMsg{Type: tea.KeyEnter})
+	require.Equal(t, stateAddItemData, ui.state)
+
+	_, cmd := ui.handleItemDataInput(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Nil(t, cmd)
+	assert.Contains(t, ui.messages.Get("error"), "Host is required")
+	ui.input = "db.example.com"
+	ui.handleItemDataInput(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Contains(t, ui.messages.Get("error"), "must be a URL")
+	ui.input = "https://db.example.com"
+	ui.handleItemDataInput(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Equal(t, 1, ui.customField)
+
+	typeRunes(ui.handleItemDataInput, "s3cret")
+	assert.Contains(t, ui.addItemDataView(), "******")
+	assert.NotContains(t, ui.addItemDataView(), "s3cret")
+	ui.handleItemDataInput(tea.KeyMsg{Type: tea.KeyEnter})
+
+	typeRunes(ui.handleItemDataInput, "line 1")
+	ui.handleItemDataInput(tea.KeyMsg{Type: tea.KeyEnter, Alt: true})
+	typeRunes(ui.handleItemDataInput, "line 2")
+	ui.handleItemDataInput(tea.KeyMsg{Type: tea.KeyEnter})
+
+	ui.handleItemDataInput(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}})
+	assert.Equal(t, "true", ui.input)
+	typeRunes(ui.handleItemDataInput, "x")
+	assert.Equal(t, "true", ui.input)
+	_, cmd = ui.handleItemDataInput(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.NotNil(t, cmd)
+
+	data := ui.newItem.Data.(*models.Custom)
+	assert.Equal(t, "Server", data.Template)
+	assert.Equal(t, []string{"https://db.example.com", "s3cret", "line 1\nline 2", "true"},
+		[]string{data.Fields[0].Value, data.Fields[1].Value, data.Fields[2].Value, data.Fields[3].Value})
+
+	// Esc walks back through the fields.
+	ui.handleItemDataInput(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.Equal(t, 2, ui.customField)
+	assert.Equal(t, "line 1\nline 2", ui.input)
+}
+
+func TestUIController_editCustomItem(t *testing.T) {
+	ui := &UIController{}
+	ui.messages.init()
+	ui.decryptedItem = &models.Item{Name: "wifi", Type: models.ItemTypeCUSTOM, Data: &models.Custom{
+		Template: "Wi-Fi",
+		Fields:   []models.CustomField{{Name: "SSID", Type: models.FieldText, Value: "home"}, {Name: "Port", Type: models.FieldNumber, Value: "80"}},
+	}}
+	ui.startEditItem()
+	ui.state = stateEditItemExpiry
+	ui.input = ""
+	ui.handleEditItemExpiryInput(tea.KeyMsg{Type: tea.KeyEnter})
+	require.Equal(t, stateEditCustomField, ui.state)
+	assert.Equal(t, "home", ui.input)
+	assert.Contains(t, ui.editCustomFieldView(), "Port")
+
+	ui.input = "office"
+	ui.handleEditCustomFieldInput(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Equal(t, "80", ui.input)
+	ui.input = "eighty"
+	ui.handleEditCustomFieldInput(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Contains(t, ui.messages.Get("error"), "must be a number")
+	ui.input = "8080"
+	_, cmd := ui.handleEditCustomFieldInput(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.NotNil(t, cmd)
+
+	assert.Equal(t, "office", ui.editingItem.Data.(*models.Custom).Fields[0].Value)
+	// The opened item is untouched until the edit is saved.
+	assert.Equal(t, "home", ui.decryptedItem.Data.(*models.Custom).Fields[0].Value)
+}
+
+func TestCustomDataView(t *testing.T) {
+	view := itemDataView(&models.Item{Data: &models.Custom{
+		Template: "Server",
+		Fields:   []models.CustomField{{Name: "Notes", Type: models.FieldMultiline, Value: "a\nb"}},
+	}})
+	assert.Contains(t, view, "Template: Server")
+	assert.Contains(t, view, "Notes: a\n    b")
+}
@@ -1,6 +1,7 @@
 package ui
 
 import (
+	"context"
 	"fmt"
 	"gophkeeper/models"
 	"strings"
@@ -19,7 +20,7 @@ type decryptError struct {
 
 func (ui *UIController) decryptItemCmd(item *models.EncryptedItem) tea.Cmd {
 	return func() tea.Msg {
-		decryptedItem, err := ui.Item.DecryptItem(item)
+		decryptedItem, err := ui.Item.DecryptItem(context.Background(), item)
 		if err != nil {
 			errStr := err.Error()
 			if strings.Contains(errStr, "failed to decrypt") ||
@@ -37,6 +38,11 @@ func (ui *UIController) decryptItemCmd(item *models.EncryptedItem) tea.Cmd {
 			}
 		}
 
+		// Usage only orders the item list, a failed update must not keep
+		// the item from opening.
+		touched := *item
+		_ = ui.Item.TouchItem(context.Background(), &touched)
+
 		return itemDecrypted{
 			item: decryptedItem,
 		}
@@ -0,0 +1,203 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"gophkeeper/models"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type devicesLoaded struct {
+	devices          []models.Device
+	approvalRequired bool
+}
+
+func deviceTitle(d models.Device) string {
+	name := d.Name
+	if name == "" {
+		name = "unnamed device"
+	}
+	text := fmt.Sprintf("%s (%s) %s", name, d.OS, d.Fingerprint())
+	if !d.LastSeenAt.IsZero() {
+		text += fmt.Sprintf(" - last seen %s", d.LastSeenAt.Local().Format("2006-01-02 15:04"))
+	}
+	if d.LastSeenIP != "" {
+		text += fmt.Sprintf(" from %s", d.LastSeenIP)
+	}
+	if d.Current {
+		text += " - this device"
+	}
+	if !d.Approved {
+		text += " - PENDING"
+	}
+	return text
+}
+
+func (ui *UIController) handleDevices() (tea.Model, tea.Cmd) {
+	ui.state = stateProcessing
+	ui.currentDevice = 0
+	return ui, ui.deviceActionCmd(nil, "")
+}
+
+// deviceActionCmd runs an optional action and reloads the device list.
+func (ui *UIController) deviceActionCmd(action func(ctx context.Context) error, errContext string) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		if action != nil {
+			if err := action(ctx); err != nil {
+				return errorMsg{
+					err:     err,
+					context: errContext,
+				}
+			}
+		}
+
+		devices, required, err := ui.User.ListDevices(ctx)
+		if err != nil {
+			return errorMsg{
+				err:     err,
+				context: "load_devices",
+			}
+		}
+		return devicesLoaded{
+			devices:          devices,
+			approvalRequired: required,
+		}
+	}
+}
+
+func (ui *UIController) selectedDevice() *models.Device {
+	if ui.currentDevice < len(ui.devices) {
+		return &ui.devices[ui.currentDevice]
+	}
+	return nil
+}
+
+func (ui *UIController) handleDeviceListInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	device := ui.selectedDevice()
+
+	switch msg.String() {
+	case "ctrl+c", "q":
+		return ui, tea.Quit
+	case "esc", "b":
+		ui.state = stateMenuLoggedIn
+		return ui, nil
+	case "up", "k":
+		if ui.currentDevice > 0 {
+			ui.currentDevice--
+		}
+	case "down", "j":
+		if ui.currentDevice < len(ui.devices)-1 {
+			ui.currentDevice++
+		}
+	case "r", "delete":
+		if device != nil && !device.Current {
+			ui.state = stateConfirmRemoveDevice
+			ui.confirmChoice = 0
+		}
+	case "a":
+		if device != nil && !device.Approved {
+			id := device.ID
+			ui.state = stateProcessing
+			return ui, ui.deviceActionCmd(func(ctx context.Context) error {
+				return ui.User.ApproveDevice(ctx, id)
+			}, "approve_device")
+		}
+	case "f":
+		if ui.remembersDevice() {
+			ui.state = stateProcessing
+			return ui, ui.deviceActionCmd(func(context.Context) error {
+				return ui.User.ForgetDevice()
+			}, "forget_device")
+		}
+	case "t":
+		required := !ui.approvalRequired
+		ui.state = stateProcessing
+		return ui, ui.deviceActionCmd(func(ctx context.Context) error {
+			return ui.User.SetDeviceApproval(ctx, required)
+		}, "set_device_approval")
+	}
+	return ui, nil
+}
+
+func (ui *UIController) deviceListView() string {
+	title := titleStyle.Render("Devices")
+
+	approval := "New devices sign in without approval"
+	if ui.approvalRequired {
+		approval = "New devices need approval from a trusted device"
+	}
+	forget := ""
+	if ui.remembersDevice() {
+		approval += "\nThis device remembers your sign in"
+		forget = ", f to forget this device"
+	}
+
+	if len(ui.devices) == 0 {
+		return fmt.Sprintf("%s\n\n%s\n\nNo devices yet.\n\nControls: t to toggle approval%s, Esc to go back", title, approval, forget)
+	}
+
+	list := ""
+	for i, d := range ui.devices {
+		if i == ui.currentDevice {
+			list += selectedStyle.Render("→ "+deviceTitle(d)) + "\n"
+		} else {
+			list += menuStyle.Render("  "+deviceTitle(d)) + "\n"
+		}
+	}
+
+	controls := "\nControls: ↑/↓ to navigate, r to sign out a device, a to approve, t to toggle approval" + forget + ", b/Esc to go back"
+	return fmt.Sprintf("%s\n\n%s\n\n%s%s", title, approval, list, controls)
+}
+
+func (ui *UIController) handleConfirmRemoveDeviceInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c", "q":
+		return ui, tea.Quit
+	case "esc", "n":
+		ui.state = stateDeviceList
+		return ui, nil
+	case "left", "h":
+		ui.confirmChoice = 0
+	case "right", "l":
+		ui.confirmChoice = 1
+	case "y":
+		ui.confirmChoice = 1
+		return ui.executeRemoveDevice()
+	case "enter":
+		return ui.executeRemoveDevice()
+	}
+	return ui, nil
+}
+
+func (ui *UIController) executeRemoveDevice() (*UIController, tea.Cmd) {
+	device := ui.selectedDevice()
+	if ui.confirmChoice == 0 || device == nil {
+		ui.state = stateDeviceList
+		return ui, nil
+	}
+
+	id := device.ID
+	ui.state = stateProcessing
+	return ui, ui.deviceActionCmd(func(ctx context.Context) error {
+		return ui.User.RemoveDevice(ctx, id)
+	}, "remove_device")
+}
+
+func (ui *UIController) confirmRemoveDeviceView() string {
+	title := titleStyle.Render("Confirm Sign Out")
+	warning := fmt.Sprintf("Sign out %s? It will have to sign in again.", deviceTitle(ui.devices[ui.currentDevice]))
+
+	options := ""
+	if ui.confirmChoice == 0 {
+		options += selectedStyle.Render("[ No ]") + "  "
+		options += menuStyle.Render("[ Yes ]")
+	} else {
+		options += menuStyle.Render("[ No ]") + "  "
+		options += selectedStyle.Render("[ Yes ]")
+	}
+
+	controls := "\nControls: ←/→ to select, Enter to confirm, y/n for quick choice, Esc to cancel"
+	return fmt.Sprintf("%s\n\n%s\n\n%s%s", title, warning, options, controls)
+}
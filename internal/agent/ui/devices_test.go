@@ -0,0 +1,79 @@
+package ui
+
+import (
+	"gophkeeper/models"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+)
+
+func newDeviceTestUI() *UIController {
+	seen := time.Date(2025, 3, 1, 10, 0, 0, 0, time.Local)
+	return &UIController{
+		state: stateDeviceList,
+		deviceCtrl: deviceCtrl{
+			devices: []models.Device{
+				{ID: [16]byte{1}, Name: "laptop", OS: "linux", Approved: true, Current: true, LastSeenAt: seen, LastSeenIP: "10.0.0.1"},
+				{ID: [16]byte{2}, Name: "phone", OS: "android", LastSeenAt: seen},
+			},
+		},
+	}
+}
+
+func TestUIController_deviceListView(t *testing.T) {
+	ui := newDeviceTestUI()
+
+	view := ui.deviceListView()
+	assert.Contains(t, view, "laptop (linux)")
+	assert.Contains(t, view, "last seen 2025-03-01 10:00 from 10.0.0.1 - this device")
+	assert.Contains(t, view, "phone (android)")
+	assert.Contains(t, view, "PENDING")
+	assert.Contains(t, view, "without approval")
+}
+
+func TestUIController_handleDeviceListInput(t *testing.T) {
+	ui := newDeviceTestUI()
+
+	// The current device is signed out with logout, not from here.
+	_, cmd := ui.handleDeviceListInput(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r'}})
+	assert.Nil(t, cmd)
+	assert.Equal(t, stateDeviceList, ui.state)
+
+	// Approved devices have nothing to approve.
+	_, cmd = ui.handleDeviceListInput(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}})
+	assert.Nil(t, cmd)
+
+	ui.handleDeviceListInput(tea.KeyMsg{Type: tea.KeyDown})
+	assert.Equal(t, 1, ui.currentDevice)
+
+	_, cmd = ui.handleDeviceListInput(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}})
+	assert.NotNil(t, cmd)
+	assert.Equal(t, stateProcessing, ui.state)
+
+	ui.state = stateDeviceList
+	_, cmd = ui.handleDeviceListInput(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r'}})
+	assert.Nil(t, cmd)
+	assert.Equal(t, stateConfirmRemoveDevice, ui.state)
+	assert.Contains(t, ui.confirmRemoveDeviceView(), "Sign out phone")
+
+	_, cmd = ui.handleConfirmRemoveDeviceInput(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Nil(t, cmd)
+	assert.Equal(t, stateDeviceList, ui.state)
+
+	ui.state = stateConfirmRemoveDevice
+	_, cmd = ui.handleConfirmRemoveDeviceInput(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	assert.NotNil(t, cmd)
+	assert.Equal(t, stateProcessing, ui.state)
+}
+
+func TestUIController_Update_DevicesLoaded(t *testing.T) {
+	ui := &UIController{deviceCtrl: deviceCtrl{currentDevice: 5}}
+
+	ui.Update(devicesLoaded{devices: []models.Device{{Name: "laptop"}}, approvalRequired: true})
+	assert.Equal(t, stateDeviceList, ui.state)
+	assert.Equal(t, 0, ui.currentDevice)
+	assert.True(t, ui.approvalRequired)
+	assert.Contains(t, ui.deviceListView(), "need approval")
+}
@@ -175,8 +175,8 @@ func TestUIController_handleEditItemNameInput_Enter_ValidName(t *testing.T) {
 	assert.Equal(t, ui, model)
 	assert.Nil(t, cmd)
 	assert.Equal(t, "new-name", ui.itemCtrl.editingItem.Name)
-	assert.Equal(t, "test-login", ui.input) // Input should be set to login value
-	assert.Equal(t, stateEditCredentialLogin, ui.state)
+	assert.Empty(t, ui.input) // No expiry set yet
+	assert.Equal(t, stateEditItemExpiry, ui.state)
 }
 
 func TestUIController_handleEditItemNameInput_Enter_EmptyName(t *testing.T) {
@@ -196,9 +196,9 @@ func TestUIController_handleEditItemNameInput_Enter_EmptyName(t *testing.T) {
 	assert.Equal(t, stateEditItemName, ui.state)              // Should remain unchanged
 }
 
-func TestUIController_handleEditItemNameInput_Enter_TextType(t *testing.T) {
+func TestUIController_handleEditItemExpiryInput_Enter_TextType(t *testing.T) {
 	ui := &UIController{
-		input: "new-text-name",
+		input: "",
 		itemCtrl: itemCtrl{
 			editingItem: &models.Item{
 				Type: models.ItemTypeTEXT,
@@ -207,18 +207,18 @@ func TestUIController_handleEditItemNameInput_Enter_TextType(t *testing.T) {
 		},
 	}
 
-	model, cmd := ui.handleEditItemNameInput(tea.KeyMsg{Type: tea.KeyEnter})
+	model, cmd := ui.handleEditItemExpiryInput(tea.KeyMsg{Type: tea.KeyEnter})
 
 	assert.Equal(t, ui, model)
 	assert.Nil(t, cmd)
-	assert.Equal(t, "new-text-name", ui.itemCtrl.editingItem.Name)
-	assert.Equal(t, stateEditTextContent, ui.state)
+	assert.True(t, ui.itemCtrl.editingItem.ExpiresAt.IsZero())
+	assert.Equal(t, stateEditDataForm, ui.state)
 	assert.Equal(t, "test content", ui.input)
 }
 
-func TestUIController_handleEditItemNameInput_Enter_CardType(t *testing.T) {
+func TestUIController_handleEditItemExpiryInput_Enter_CardType(t *testing.T) {
 	ui := &UIController{
-		input: "new-card-name",
+		input: "",
 		itemCtrl: itemCtrl{
 			editingItem: &models.Item{
 				Type: models.ItemTypeCARD,
@@ -227,18 +227,18 @@ func TestUIController_handleEditItemNameInput_Enter_CardType(t *testing.T) {
 		},
 	}
 
-	model, cmd := ui.handleEditItemNameInput(tea.KeyMsg{Type: tea.KeyEnter})
+	model, cmd := ui.handleEditItemExpiryInput(tea.KeyMsg{Type: tea.KeyEnter})
 
 	assert.Equal(t, ui, model)
 	assert.Nil(t, cmd)
-	assert.Equal(t, "new-card-name", ui.itemCtrl.editingItem.Name)
-	assert.Equal(t, stateEditCardNumber, ui.state)
+	assert.True(t, ui.itemCtrl.editingItem.ExpiresAt.IsZero())
+	assert.Equal(t, stateEditDataForm, ui.state)
 	assert.Equal(t, "1234567890123456", ui.input)
 }
 
-func TestUIController_handleEditItemNameInput_Enter_BinaryType(t *testing.T) {
+func TestUIController_handleEditItemExpiryInput_Enter_BinaryType(t *testing.T) {
 	ui := &UIController{
-		input: "new-binary-name",
+		input: "",
 		itemCtrl: itemCtrl{
 			editingItem: &models.Item{
 				Type: models.ItemTypeBINARY,
@@ -247,12 +247,12 @@ func TestUIController_handleEditItemNameInput_Enter_BinaryType(t *testing.T) {
 		},
 	}
 
-	model, cmd := ui.handleEditItemNameInput(tea.KeyMsg{Type: tea.KeyEnter})
+	model, cmd := ui.handleEditItemExpiryInput(tea.KeyMsg{Type: tea.KeyEnter})
 
 	assert.Equal(t, ui, model)
 	assert.Nil(t, cmd)
-	assert.Equal(t, "new-binary-name", ui.itemCtrl.editingItem.Name)
-	assert.Equal(t, stateEditBinaryData, ui.state)
+	assert.True(t, ui.itemCtrl.editingItem.ExpiresAt.IsZero())
+	assert.Equal(t, stateEditDataForm, ui.state)
 	assert.Equal(t, "binary data", ui.input)
 }
 
@@ -276,41 +276,32 @@ func TestUIController_handleEditItemNameInput_CharacterInput(t *testing.T) {
 	assert.Equal(t, "testa", ui.input)
 }
 
-func TestUIController_handleEditCredentialLoginInput_Enter(t *testing.T) {
+func TestUIController_handleEditDataFormInput_Credentials(t *testing.T) {
 	ui := &UIController{
-		input: "  new-login  ",
+		state: stateEditDataForm,
 		itemCtrl: itemCtrl{
 			editingItem: &models.Item{
-				Data: &models.Credentials{Password: "test-password"},
+				Type: models.ItemTypeCREDENTIALS,
+				Data: &models.Credentials{Login: "old-login", Password: "test-password"},
 			},
 		},
 	}
+	ui.messages.init()
+	ui.startDataForm(ui.editingItem.Data)
+	ui.input = "  new-login  "
 
-	model, cmd := ui.handleEditCredentialLoginInput(tea.KeyMsg{Type: tea.KeyEnter})
+	model, cmd := ui.handleEditDataFormInput(tea.KeyMsg{Type: tea.KeyEnter})
 
 	assert.Equal(t, ui, model)
 	assert.Nil(t, cmd)
-	assert.Equal(t, "new-login", ui.itemCtrl.editingItem.Data.(*models.Credentials).Login)
-	assert.Equal(t, stateEditCredentialPassword, ui.state)
 	assert.Equal(t, "test-password", ui.input)
-}
-
-func TestUIController_handleEditCredentialPasswordInput_Enter(t *testing.T) {
-	ui := &UIController{
-		input: "  new-password  ",
-		itemCtrl: itemCtrl{
-			editingItem: &models.Item{
-				Data: &models.Credentials{},
-			},
-		},
-	}
 
-	model, cmd := ui.handleEditCredentialPasswordInput(tea.KeyMsg{Type: tea.KeyEnter})
+	ui.input = "new-password"
+	_, cmd = ui.handleEditDataFormInput(tea.KeyMsg{Type: tea.KeyEnter})
 
-	assert.Equal(t, ui, model)
 	assert.NotNil(t, cmd) // Should return saveEditedItemCmd
-	assert.Equal(t, "new-password", ui.itemCtrl.editingItem.Data.(*models.Credentials).Password)
-	assert.Empty(t, ui.input)
+	assert.Equal(t, &models.Credentials{Login: "new-login", Password: "new-password"}, ui.itemCtrl.editingItem.Data)
+	assert.Equal(t, stateProcessing, ui.state)
 }
 
 func TestUIController_saveEditedItemCmd(t *testing.T) {
@@ -370,56 +361,45 @@ func TestUIController_handleEditErrorInput_Escape(t *testing.T) {
 	assert.Nil(t, ui.itemCtrl.editingItem)
 }
 
-func TestUIController_handleEditTextContentInput_Enter_ValidContent(t *testing.T) {
-	ui := &UIController{
-		input: "  new content  ",
-		itemCtrl: itemCtrl{
-			editingItem: &models.Item{
-				Data: &models.Text{},
-			},
-		},
-	}
-
-	model, cmd := ui.handleEditTextContentInput(tea.KeyMsg{Type: tea.KeyEnter})
-
-	assert.Equal(t, ui, model)
-	assert.NotNil(t, cmd) // Should return saveEditedItemCmd
-	assert.Equal(t, "new content", ui.itemCtrl.editingItem.Data.(*models.Text).Content)
-	assert.Empty(t, ui.input)
-}
-
-func TestUIController_handleEditTextContentInput_Enter_EmptyContent(t *testing.T) {
+func TestUIController_handleEditDataFormInput_EmptyContent(t *testing.T) {
 	ui := &UIController{
-		input: "   ",
+		state: stateEditDataForm,
 		itemCtrl: itemCtrl{
 			editingItem: &models.Item{
+				Type: models.ItemTypeTEXT,
 				Data: &models.Text{Content: "original"},
 			},
 		},
-		state: stateEditTextContent,
 	}
+	ui.messages.init()
+	ui.startDataForm(ui.editingItem.Data)
+	ui.input = ""
 
-	model, cmd := ui.handleEditTextContentInput(tea.KeyMsg{Type: tea.KeyEnter})
+	model, cmd := ui.handleEditDataFormInput(tea.KeyMsg{Type: tea.KeyEnter})
 
 	assert.Equal(t, ui, model)
 	assert.Nil(t, cmd)
+	assert.Equal(t, "Content is required", ui.messages.Get("error"))
 	assert.Equal(t, "original", ui.itemCtrl.editingItem.Data.(*models.Text).Content) // Should remain unchanged
-	assert.Equal(t, stateEditTextContent, ui.state)                                  // Should remain unchanged
+	assert.Equal(t, stateEditDataForm, ui.state)                                     // Should remain unchanged
 }
 
-func TestUIController_handleEditBinaryDataInput_Enter_ValidData(t *testing.T) {
+func TestUIController_handleEditDataFormInput_Binary(t *testing.T) {
 	ui := &UIController{
-		input: "  new binary data  ",
+		state: stateEditDataForm,
 		itemCtrl: itemCtrl{
 			editingItem: &models.Item{
-				Data: &models.Binary{},
+				Type: models.ItemTypeBINARY,
+				Data: &models.Binary{Content: []byte("old")},
 			},
 		},
 	}
+	ui.messages.init()
+	ui.startDataForm(ui.editingItem.Data)
+	ui.input = "new binary data"
 
-	model, cmd := ui.handleEditBinaryDataInput(tea.KeyMsg{Type: tea.KeyEnter})
+	_, cmd := ui.handleEditDataFormInput(tea.KeyMsg{Type: tea.KeyEnter})
 
-	assert.Equal(t, ui, model)
 	assert.NotNil(t, cmd) // Should return saveEditedItemCmd
 	assert.Equal(t, []byte("new binary data"), ui.itemCtrl.editingItem.Data.(*models.Binary).Content)
 	assert.Empty(t, ui.input)
@@ -439,38 +419,24 @@ func TestUIController_editItemNameView(t *testing.T) {
 	assert.Contains(t, view, "Esc to cancel")
 }
 
-func TestUIController_editCredentialLoginView(t *testing.T) {
+func TestUIController_editDataFormView(t *testing.T) {
 	ui := &UIController{
-		input: "test-login",
-		itemCtrl: itemCtrl{
-			editingItem: &models.Item{
-				Name: "test-item",
-			},
-		},
-	}
-
-	view := ui.editCredentialLoginView()
-
-	assert.Contains(t, view, "Edit Credentials - Login")
-	assert.Contains(t, view, "test-item")
-	assert.Contains(t, view, "test-login")
-	assert.Contains(t, view, "█")
-}
-
-func TestUIController_editCredentialPasswordView(t *testing.T) {
-	ui := &UIController{
-		input: "password123",
 		itemCtrl: itemCtrl{
 			editingItem: &models.Item{
 				Name: "test-item",
+				Type: models.ItemTypeCREDENTIALS,
 				Data: &models.Credentials{Login: "test-login"},
 			},
 		},
 	}
+	ui.messages.init()
+	ui.startDataForm(ui.editingItem.Data)
+	ui.customField = 1
+	ui.input = "password123"
 
-	view := ui.editCredentialPasswordView()
+	view := ui.editDataFormView()
 
-	assert.Contains(t, view, "Edit Credentials - Password")
+	assert.Contains(t, view, "Edit Item - Credentials")
 	assert.Contains(t, view, "test-item")
 	assert.Contains(t, view, "test-login")
 	assert.Contains(t, view, "***********")    // Hidden password
@@ -505,39 +471,3 @@ func TestUIController_editErrorView(t *testing.T) {
 	assert.Contains(t, view, "Failed to update item")
 	assert.Contains(t, view, "Enter to return to item details")
 }
-
-func TestUIController_editTextContentView(t *testing.T) {
-	ui := &UIController{
-		input: "test content",
-		itemCtrl: itemCtrl{
-			editingItem: &models.Item{
-				Name: "test-text",
-			},
-		},
-	}
-
-	view := ui.editTextContentView()
-
-	assert.Contains(t, view, "Edit Text - Content")
-	assert.Contains(t, view, "test-text")
-	assert.Contains(t, view, "test content")
-	assert.Contains(t, view, "█")
-}
-
-func TestUIController_editBinaryDataView(t *testing.T) {
-	ui := &UIController{
-		input: "binary data",
-		itemCtrl: itemCtrl{
-			editingItem: &models.Item{
-				Name: "test-binary",
-			},
-		},
-	}
-
-	view := ui.editBinaryDataView()
-
-	assert.Contains(t, view, "Edit Binary - Data")
-	assert.Contains(t, view, "test-binary")
-	assert.Contains(t, view, "binary data")
-	assert.Contains(t, view, "█")
-}
@@ -0,0 +1,447 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"gophkeeper/models"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// emergencyEntry is a row of the emergency access list: a contact trusted by
+// the user (mine) or a user who trusts them.
+type emergencyEntry struct {
+	access models.EmergencyAccess
+	mine   bool
+}
+
+type emergencyLoaded struct {
+	entries []emergencyEntry
+}
+
+type emergencyVaultOpened struct {
+	grantor string
+	items   []models.Item
+}
+
+const (
+	takeoverStepPassword = iota
+	takeoverStepMasterPassword
+)
+
+func (e emergencyEntry) title(now time.Time) string {
+	a := e.access
+	if e.mine {
+		text := fmt.Sprintf("%s can %s after %s", a.Grantee, strings.ToLower(string(a.Type)), formatWait(a.WaitPeriod))
+		if a.Status == models.EmergencyStatusREQUESTED {
+			text += fmt.Sprintf(" - REQUESTED, opens %s", a.AccessibleAt().Format("2006-01-02 15:04"))
+		} else if a.Status == models.EmergencyStatusAPPROVED {
+			text += " - APPROVED"
+		}
+		return text
+	}
+
+	text := fmt.Sprintf("%s trusts you (%s)", a.Grantor, a.Type)
+	switch {
+	case a.IsAccessible(now):
+		text += " - available"
+	case a.Status == models.EmergencyStatusREQUESTED:
+		text += fmt.Sprintf(" - requested, opens %s", a.AccessibleAt().Format("2006-01-02 15:04"))
+	}
+	return text
+}
+
+func formatWait(wait time.Duration) string {
+	hours := int(wait / time.Hour)
+	if hours%24 == 0 {
+		return fmt.Sprintf("%dd", hours/24)
+	}
+	return fmt.Sprintf("%dh", hours)
+}
+
+func (ui *UIController) handleEmergencyAccess() (tea.Model, tea.Cmd) {
+	ui.state = stateProcessing
+	ui.currentEmergency = 0
+	return ui, ui.emergencyActionCmd(nil, "")
+}
+
+// emergencyActionCmd runs an optional action and reloads the emergency access list.
+func (ui *UIController) emergencyActionCmd(action func(ctx context.Context) error, errContext string) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		if action != nil {
+			if err := action(ctx); err != nil {
+				return errorMsg{
+					err:     err,
+					context: errContext,
+				}
+			}
+		}
+
+		granted, trustedBy, err := ui.Emergency.List(ctx)
+		if err != nil {
+			return errorMsg{
+				err:     err,
+				context: "load_emergency_access",
+			}
+		}
+		entries := make([]emergencyEntry, 0, len(granted)+len(trustedBy))
+		for _, a := range granted {
+			entries = append(entries, emergencyEntry{access: a, mine: true})
+		}
+		for _, a := range trustedBy {
+			entries = append(entries, emergencyEntry{access: a})
+		}
+		return emergencyLoaded{
+			entries: entries,
+		}
+	}
+}
+
+func (ui *UIController) selectedEmergency() *emergencyEntry {
+	if ui.currentEmergency < len(ui.emergency) {
+		return &ui.emergency[ui.currentEmergency]
+	}
+	return nil
+}
+
+func (ui *UIController) handleEmergencyListInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	entry := ui.selectedEmergency()
+
+	switch msg.String() {
+	case "ctrl+c", "q":
+		return ui, tea.Quit
+	case "esc", "b":
+		ui.state = stateMenuLoggedIn
+		return ui, nil
+	case "up", "k":
+		if ui.currentEmergency > 0 {
+			ui.currentEmergency--
+		}
+	case "down", "j":
+		if ui.currentEmergency < len(ui.emergency)-1 {
+			ui.currentEmergency++
+		}
+	case "g":
+		ui.state = stateEmergencyGrant
+		ui.input = ""
+		ui.messages.Clear("emergency_error")
+	case "r":
+		if entry != nil && entry.mine {
+			grantee := entry.access.Grantee
+			ui.state = stateProcessing
+			return ui, ui.emergencyActionCmd(func(ctx context.Context) error {
+				return ui.Emergency.Revoke(ctx, grantee)
+			}, "revoke_emergency_access")
+		}
+	case "a":
+		if entry != nil && entry.mine && entry.access.Status == models.EmergencyStatusREQUESTED {
+			grantee := entry.access.Grantee
+			ui.state = stateProcessing
+			return ui, ui.emergencyActionCmd(func(ctx context.Context) error {
+				return ui.Emergency.Approve(ctx, grantee)
+			}, "approve_emergency_access")
+		}
+	case "d":
+		if entry != nil && entry.mine && entry.access.Status == models.EmergencyStatusREQUESTED {
+			grantee := entry.access.Grantee
+			ui.state = stateProcessing
+			return ui, ui.emergencyActionCmd(func(ctx context.Context) error {
+				return ui.Emergency.Deny(ctx, grantee)
+			}, "deny_emergency_access")
+		}
+	case "enter":
+		if entry == nil || entry.mine {
+			return ui, nil
+		}
+		grantor := entry.access.Grantor
+		ui.state = stateProcessing
+		if entry.access.Status == models.EmergencyStatusIDLE {
+			return ui, ui.emergencyActionCmd(func(ctx context.Context) error {
+				return ui.Emergency.Request(ctx, grantor)
+			}, "request_emergency_access")
+		}
+		return ui, ui.openEmergencyVaultCmd(grantor)
+	case "t":
+		if entry != nil && !entry.mine && entry.access.Type == models.EmergencyAccessTAKEOVER &&
+			entry.access.IsAccessible(time.Now()) {
+			ui.emergencyGrantor = entry.access.Grantor
+			ui.takeoverStep = takeoverStepPassword
+			ui.takeoverPassword = ""
+			ui.input = ""
+			ui.state = stateEmergencyTakeover
+		}
+	}
+	return ui, nil
+}
+
+func (ui *UIController) openEmergencyVaultCmd(grantor string) tea.Cmd {
+	return func() tea.Msg {
+		items, err := ui.Emergency.OpenVault(context.Background(), grantor)
+		if err != nil {
+			return errorMsg{
+				err:     err,
+				context: "open_emergency_vault",
+			}
+		}
+		return emergencyVaultOpened{
+			grantor: grantor,
+			items:   items,
+		}
+	}
+}
+
+func (ui *UIController) emergencyListView() string {
+	title := titleStyle.Render("Emergency Access")
+
+	if len(ui.emergency) == 0 {
+		return fmt.Sprintf("%s\n\nNo trusted contacts yet.\n\nControls: g to add a trusted contact, Esc to go back", title)
+	}
+
+	now := time.Now()
+	list := ""
+	for i, e := range ui.emergency {
+		if i == ui.currentEmergency {
+			list += selectedStyle.Render("→ "+e.title(now)) + "\n"
+		} else {
+			list += menuStyle.Render("  "+e.title(now)) + "\n"
+		}
+	}
+
+	controls := "\nControls: ↑/↓ to navigate, g to add a trusted contact, r to revoke, a/d to approve/deny a request,\n" +
+		"Enter to request or open a vault, t to take over an account, b/Esc to go back"
+	return fmt.Sprintf("%s\n\n%s%s", title, list, controls)
+}
+
+func (ui *UIController) handleEmergencyGrantInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return ui, tea.Quit
+	case "esc":
+		ui.state = stateEmergencyList
+		ui.input = ""
+		return ui, nil
+	case "enter":
+		grantee, typ, wait, err := parseEmergencyGrant(ui.input)
+		if err != nil {
+			ui.messages.Set("emergency_error", err.Error())
+			return ui, nil
+		}
+		ui.input = ""
+		ui.messages.Clear("emergency_error")
+		ui.state = stateProcessing
+		return ui, ui.recipientKeyCmd(grantee, ui.grantKeyUse(typ, wait))
+	case "backspace":
+		if len(ui.input) > 0 {
+			ui.input = ui.input[:len(ui.input)-1]
+		}
+	default:
+		if len(msg.String()) == 1 {
+			ui.input += msg.String()
+		}
+	}
+	return ui, nil
+}
+
+// grantKeyUse grants emergency access once the key of the grantee is
+// trusted, the master key is wrapped for it.
+func (ui *UIController) grantKeyUse(typ models.EmergencyAccessType, wait time.Duration) keyUse {
+	return keyUse{
+		verb: "grant access",
+		back: stateEmergencyList,
+		fail: func(err error) tea.Msg {
+			return errorMsg{
+				err:     err,
+				context: "grant_emergency_access",
+			}
+		},
+		run: func(grantee, fingerprint string) tea.Cmd {
+			return ui.emergencyActionCmd(func(ctx context.Context) error {
+				if err := ui.trustRecipientKey(ctx, grantee, fingerprint); err != nil {
+					return err
+				}
+				return ui.Emergency.Grant(ctx, grantee, typ, wait)
+			}, "grant_emergency_access")
+		},
+	}
+}
+
+// parseEmergencyGrant parses "login [view|takeover] [hours]". Access defaults
+// to view and the wait period to 48 hours.
+func parseEmergencyGrant(input string) (string, models.EmergencyAccessType, time.Duration, error) {
+	fields := strings.Fields(input)
+	if len(fields) == 0 || len(fields) > 3 {
+		return "", "", 0, fmt.Errorf("enter login, access and wait hours")
+	}
+
+	typ := models.EmergencyAccessVIEW
+	if len(fields) > 1 {
+		typ = models.EmergencyAccessType(strings.ToUpper(fields[1]))
+		if !typ.IsValid() {
+			return "", "", 0, fmt.Errorf("unknown access %q, use view or takeover", fields[1])
+		}
+	}
+
+	hours := 48
+	if len(fields) > 2 {
+		var err error
+		hours, err = strconv.Atoi(fields[2])
+		if err != nil || hours < 1 {
+			return "", "", 0, fmt.Errorf("wait period must be a positive number of hours")
+		}
+	}
+	return fields[0], typ, time.Duration(hours) * time.Hour, nil
+}
+
+func (ui *UIController) emergencyGrantView() string {
+	title := titleStyle.Render("Add Trusted Contact")
+	input := inputStyle.Render(ui.input + "█")
+
+	errText := ""
+	if e := ui.messages.Get("emergency_error"); e != "" {
+		errText = "\n" + errorStyle.Render(e) + "\n"
+	}
+
+	help := "The contact can request access to your vault. You have the wait period to deny it."
+	controls := "\nControls: Enter to grant, Esc to cancel"
+	return fmt.Sprintf("%s\n\n%s\n\nLogin, access (view, takeover) and wait hours: %s\n%s%s", title, help, input, errText, controls)
+}
+
+func (ui *UIController) handleEmergencyVaultInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c", "q":
+		return ui, tea.Quit
+	case "esc", "b":
+		ui.emergencyItems = nil
+		ui.state = stateEmergencyList
+		return ui, nil
+	case "up", "k":
+		if ui.currentEmergencyItem > 0 {
+			ui.currentEmergencyItem--
+		}
+	case "down", "j":
+		if ui.currentEmergencyItem < len(ui.emergencyItems)-1 {
+			ui.currentEmergencyItem++
+		}
+	case "enter":
+		if ui.currentEmergencyItem < len(ui.emergencyItems) {
+			ui.state = stateEmergencyItemDetails
+		}
+	}
+	return ui, nil
+}
+
+func (ui *UIController) emergencyVaultView() string {
+	title := titleStyle.Render(fmt.Sprintf("Vault of %s (read-only)", ui.emergencyGrantor))
+
+	if len(ui.emergencyItems) == 0 {
+		return fmt.Sprintf("%s\n\nThe vault is empty.\n\nControls: b/Esc to go back", title)
+	}
+
+	list := ""
+	for i, item := range ui.emergencyItems {
+		text := fmt.Sprintf("%s (%s)", item.Name, item.Type)
+		if i == ui.currentEmergencyItem {
+			list += selectedStyle.Render("→ "+text) + "\n"
+		} else {
+			list += menuStyle.Render("  "+text) + "\n"
+		}
+	}
+
+	controls := "\nControls: ↑/↓ to navigate, Enter to view details, b/Esc to go back"
+	return fmt.Sprintf("%s\n\n%s%s", title, list, controls)
+}
+
+func (ui *UIController) handleEmergencyItemDetailsInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c", "q":
+		return ui, tea.Quit
+	case "esc", "b":
+		ui.state = stateEmergencyVault
+		return ui, nil
+	}
+	return ui, nil
+}
+
+func (ui *UIController) emergencyItemDetailsView() string {
+	if ui.currentEmergencyItem >= len(ui.emergencyItems) {
+		return "No item selected"
+	}
+
+	item := &ui.emergencyItems[ui.currentEmergencyItem]
+	title := titleStyle.Render(fmt.Sprintf("Item: %s", item.Name))
+
+	details := fmt.Sprintf("Owner: %s\n", ui.emergencyGrantor)
+	details += fmt.Sprintf("Type: %s\n", item.Type)
+	details += fmt.Sprintf("Updated: %s\n\n", item.UpdatedAt.Format("2006-01-02 15:04:05"))
+	details += itemDataView(item)
+
+	controls := "\nControls: b/Esc to go back"
+	return fmt.Sprintf("%s\n\n%s%s", title, details, controls)
+}
+
+func (ui *UIController) handleEmergencyTakeoverInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return ui, tea.Quit
+	case "esc":
+		ui.input = ""
+		ui.takeoverPassword = ""
+		ui.state = stateEmergencyList
+		return ui, nil
+	case "enter":
+		if ui.input == "" {
+			return ui, nil
+		}
+		if ui.takeoverStep == takeoverStepPassword {
+			ui.takeoverPassword = ui.input
+			ui.takeoverStep = takeoverStepMasterPassword
+			ui.input = ""
+			return ui, nil
+		}
+
+		grantor, password, masterPassword := ui.emergencyGrantor, ui.takeoverPassword, ui.input
+		ui.input = ""
+		ui.takeoverPassword = ""
+		ui.state = stateProcessing
+		return ui, func() tea.Msg {
+			if err := ui.Emergency.Takeover(context.Background(), grantor, password, masterPassword); err != nil {
+				return errorMsg{
+					err:     err,
+					context: "takeover_account",
+				}
+			}
+			return processComplete{
+				success: true,
+				message: fmt.Sprintf("Account %s now uses the new passwords", grantor),
+				context: "takeover_account",
+			}
+		}
+	case "backspace":
+		if len(ui.input) > 0 {
+			ui.input = ui.input[:len(ui.input)-1]
+		}
+	default:
+		if len(msg.String()) == 1 {
+			ui.input += msg.String()
+		}
+	}
+	return ui, nil
+}
+
+func (ui *UIController) emergencyTakeoverView() string {
+	title := titleStyle.Render(fmt.Sprintf("Take Over %s", ui.emergencyGrantor))
+	input := inputStyle.Render(strings.Repeat("*", len(ui.input)) + "█")
+
+	prompt := "New login password"
+	if ui.takeoverStep == takeoverStepMasterPassword {
+		prompt = "New master password"
+	}
+
+	warning := "The account's passwords are replaced and its sessions are signed out."
+	controls := "\nControls: Enter to continue, Esc to cancel"
+	return fmt.Sprintf("%s\n\n%s\n\n%s: %s\n%s", title, warning, prompt, input, controls)
+}
@@ -0,0 +1,128 @@
+package ui
+
+import (
+	"gophkeeper/models"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newEmergencyTestUI() *UIController {
+	return &UIController{
+		state: stateEmergencyList,
+		emergencyCtrl: emergencyCtrl{
+			emergency: []emergencyEntry{
+				{mine: true, access: models.EmergencyAccess{
+					Grantor: "alice", Grantee: "bob", Type: models.EmergencyAccessVIEW,
+					Status: models.EmergencyStatusREQUESTED, WaitPeriod: 48 * time.Hour, RequestedAt: time.Now(),
+				}},
+				{access: models.EmergencyAccess{
+					Grantor: "carol", Grantee: "alice", Type: models.EmergencyAccessTAKEOVER,
+					Status: models.EmergencyStatusAPPROVED, WaitPeriod: 24 * time.Hour,
+				}},
+				{access: models.EmergencyAccess{
+					Grantor: "dave", Grantee: "alice", Type: models.EmergencyAccessVIEW,
+					Status: models.EmergencyStatusIDLE, WaitPeriod: 30 * time.Hour,
+				}},
+			},
+		},
+	}
+}
+
+func TestUIController_emergencyListView(t *testing.T) {
+	ui := newEmergencyTestUI()
+
+	view := ui.emergencyListView()
+	assert.Contains(t, view, "bob can view after 2d - REQUESTED")
+	assert.Contains(t, view, "carol trusts you (TAKEOVER) - available")
+	assert.Contains(t, view, "dave trusts you (VIEW)")
+}
+
+func TestUIController_handleEmergencyListInput(t *testing.T) {
+	ui := newEmergencyTestUI()
+
+	// Requests on own grants are approved or denied, not opened.
+	_, cmd := ui.handleEmergencyListInput(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Nil(t, cmd)
+	_, cmd = ui.handleEmergencyListInput(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+	assert.NotNil(t, cmd)
+	assert.Equal(t, stateProcessing, ui.state)
+
+	// Takeover needs takeover access.
+	ui.state = stateEmergencyList
+	ui.currentEmergency = 2
+	_, cmd = ui.handleEmergencyListInput(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'t'}})
+	assert.Nil(t, cmd)
+	assert.Equal(t, stateEmergencyList, ui.state)
+
+	_, cmd = ui.handleEmergencyListInput(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.NotNil(t, cmd)
+
+	ui.state = stateEmergencyList
+	ui.currentEmergency = 1
+	_, cmd = ui.handleEmergencyListInput(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'t'}})
+	assert.Nil(t, cmd)
+	assert.Equal(t, stateEmergencyTakeover, ui.state)
+	assert.Equal(t, "carol", ui.emergencyGrantor)
+}
+
+func TestUIController_handleEmergencyTakeoverInput(t *testing.T) {
+	ui := newEmergencyTestUI()
+	ui.state = stateEmergencyTakeover
+	ui.emergencyGrantor = "carol"
+
+	for _, r := range "pass" {
+		ui.handleEmergencyTakeoverInput(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	assert.Contains(t, ui.emergencyTakeoverView(), "****")
+	_, cmd := ui.handleEmergencyTakeoverInput(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Nil(t, cmd)
+	assert.Equal(t, takeoverStepMasterPassword, ui.takeoverStep)
+	assert.Contains(t, ui.emergencyTakeoverView(), "New master password")
+
+	ui.handleEmergencyTakeoverInput(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'m'}})
+	_, cmd = ui.handleEmergencyTakeoverInput(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.NotNil(t, cmd)
+	assert.Equal(t, stateProcessing, ui.state)
+	assert.Empty(t, ui.takeoverPassword)
+}
+
+func TestUIController_grantVerifyKey(t *testing.T) {
+	ui := newEmergencyTestUI()
+	ui.state = stateProcessing
+
+	// An unverified grantee key is confirmed first, declining goes back
+	// to the list.
+	ui.Update(recipientKeyLoaded{recipient: "erin", fingerprint: "ab12 cd34", use: ui.grantKeyUse(models.EmergencyAccessVIEW, time.Hour)})
+	assert.Equal(t, stateShareVerifyKey, ui.state)
+	view := ui.shareVerifyKeyView()
+	assert.Contains(t, view, "key of erin is not verified")
+	assert.Contains(t, view, "Trust and grant access")
+
+	_, cmd := ui.handleShareVerifyKeyInput(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.Nil(t, cmd)
+	assert.Equal(t, stateEmergencyList, ui.state)
+}
+
+func TestParseEmergencyGrant(t *testing.T) {
+	login, typ, wait, err := parseEmergencyGrant("bob takeover 72")
+	require.NoError(t, err)
+	assert.Equal(t, "bob", login)
+	assert.Equal(t, models.EmergencyAccessTAKEOVER, typ)
+	assert.Equal(t, 72*time.Hour, wait)
+
+	_, typ, wait, err = parseEmergencyGrant("carol")
+	require.NoError(t, err)
+	assert.Equal(t, models.EmergencyAccessVIEW, typ)
+	assert.Equal(t, 48*time.Hour, wait)
+
+	_, _, _, err = parseEmergencyGrant("bob admin")
+	assert.Error(t, err)
+	_, _, _, err = parseEmergencyGrant("bob view 0")
+	assert.Error(t, err)
+	_, _, _, err = parseEmergencyGrant("")
+	assert.Error(t, err)
+}
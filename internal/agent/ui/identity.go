@@ -0,0 +1,130 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"gophkeeper/models"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type identityImagesSet struct {
+	item *models.Item
+}
+
+// identityDataView shows an identity document and marks it when it has
+// expired by now.
+func identityDataView(data *models.Identity, now time.Time) string {
+	details := fmt.Sprintf("  Document: %s\n", data.DocumentType)
+	details += fmt.Sprintf("  Number: %s\n", data.Number)
+	if data.FullName != "" {
+		details += fmt.Sprintf("  Full name: %s\n", data.FullName)
+	}
+	if data.Country != "" {
+		details += fmt.Sprintf("  Country: %s\n", data.Country)
+	}
+	if data.IssueDate != "" {
+		details += fmt.Sprintf("  Issued: %s\n", data.IssueDate)
+	}
+	if data.ExpiryDate != "" {
+		expired := ""
+		if data.Expired(now) {
+			expired = " " + errorStyle.Render("(expired)")
+		}
+		details += fmt.Sprintf("  Expires: %s%s\n", data.ExpiryDate, expired)
+	}
+	details += fmt.Sprintf("  Front image: %s\n", imageStatus(data.FrontImage))
+	details += fmt.Sprintf("  Back image: %s\n", imageStatus(data.BackImage))
+	return details
+}
+
+func imageStatus(id [16]byte) string {
+	if id == ([16]byte{}) {
+		return "none"
+	}
+	return "attached"
+}
+
+func (ui *UIController) startIdentityImages() (tea.Model, tea.Cmd) {
+	if _, ok := ui.decryptedItem.Data.(*models.Identity); !ok {
+		return ui, nil
+	}
+	ui.identityFrontImage = ""
+	ui.state = stateIdentityFrontImage
+	ui.input = ""
+	return ui, nil
+}
+
+// handleIdentityImageInput asks for the front and then the back scan, an
+// empty path keeps the current image.
+func (ui *UIController) handleIdentityImageInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return ui, tea.Quit
+	case "esc":
+		if ui.state == stateIdentityBackImage {
+			ui.state = stateIdentityFrontImage
+			ui.input = ui.identityFrontImage
+			return ui, nil
+		}
+		ui.state = stateItemDetails
+		ui.input = ""
+		return ui, nil
+	case "enter":
+		path := strings.TrimSpace(ui.input)
+		ui.input = ""
+		if ui.state == stateIdentityFrontImage {
+			ui.identityFrontImage = path
+			ui.state = stateIdentityBackImage
+			return ui, nil
+		}
+		if ui.identityFrontImage == "" && path == "" {
+			ui.state = stateItemDetails
+			return ui, nil
+		}
+		ui.state = stateProcessing
+		return ui, ui.setIdentityImagesCmd(ui.identityFrontImage, path)
+	case "backspace":
+		if len(ui.input) > 0 {
+			ui.input = ui.input[:len(ui.input)-1]
+		}
+	default:
+		if len(msg.String()) == 1 {
+			ui.input += msg.String()
+		}
+	}
+	return ui, nil
+}
+
+func (ui *UIController) setIdentityImagesCmd(front, back string) tea.Cmd {
+	item := *ui.decryptedItem
+	data, ok := item.Data.(*models.Identity)
+	if !ok {
+		return nil
+	}
+	images := *data
+	item.Data = &images
+	return func() tea.Msg {
+		if err := ui.Item.SetIdentityImages(context.Background(), &item, front, back); err != nil {
+			return errorMsg{
+				err:     err,
+				context: "set_identity_images",
+			}
+		}
+		return identityImagesSet{item: &item}
+	}
+}
+
+func (ui *UIController) identityImageView() string {
+	side := "Front"
+	if ui.state == stateIdentityBackImage {
+		side = "Back"
+	}
+	title := titleStyle.Render(fmt.Sprintf("Document Images: %s", ui.decryptedItem.Name))
+	input := inputStyle.Render(ui.input + "█")
+
+	controls := "\nControls: Enter to continue, empty path to keep the current image, Esc to go back"
+	return fmt.Sprintf("%s\n\n%s image file: %s%s", title, side, input, controls)
+}
@@ -0,0 +1,98 @@
+package ui
+
+import (
+	"gophkeeper/models"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUIController_identityForm(t *testing.T) {
+	ui := &UIController{}
+	ui.messages.init()
+	ui.handleItemTypeSelection(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'8'}})
+	require.Equal(t, stateAddItemName, ui.state)
+	assert.Equal(t, models.ItemTypeIDENTITY, ui.newItem.Type)
+
+	ui.newItem.Name = "passport"
+	ui.state = stateAddItemExpiry
+	ui.handleAddItemExpiryInput(tea.KeyMsg{Type: tea.KeyEnter})
+	require.Equal(t, stateAddItemData, ui.state)
+
+	// The document type is required.
+	ui.handleItemDataInput(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Contains(t, ui.messages.Get("error"), "Document type is required")
+
+	for _, value := range []string{"passport", "X1234567", "Jane Doe", "NL", "2030-01-01", "2020-01-01"} {
+		typeRunes(ui.handleItemDataInput, value)
+		ui.handleItemDataInput(tea.KeyMsg{Type: tea.KeyEnter})
+	}
+	assert.Contains(t, ui.addItemDataView(), "********")
+	assert.NotContains(t, ui.addItemDataView(), "X1234567")
+
+	// Dates in the wrong order send the user back to the expiry date.
+	assert.Contains(t, ui.messages.Get("error"), "after the issue date")
+	assert.Equal(t, len(ui.dataForm.Fields)-1, ui.customField)
+	ui.input = "2035-01-01"
+	_, cmd := ui.handleItemDataInput(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.NotNil(t, cmd)
+
+	assert.Equal(t, &models.Identity{
+		DocumentType: "passport",
+		Number:       "X1234567",
+		FullName:     "Jane Doe",
+		Country:      "NL",
+		IssueDate:    "2030-01-01",
+		ExpiryDate:   "2035-01-01",
+	}, ui.newItem.Data)
+}
+
+func TestUIController_identityDetails(t *testing.T) {
+	ui := &UIController{
+		state: stateItemDetails,
+		itemCtrl: itemCtrl{
+			items: []models.EncryptedItem{{Name: "passport", Type: models.ItemTypeIDENTITY}},
+			decryptedItem: &models.Item{Name: "passport", Type: models.ItemTypeIDENTITY, Data: &models.Identity{
+				DocumentType: "passport",
+				Number:       "X1234567",
+				ExpiryDate:   "2001-01-01",
+				FrontImage:   [16]byte{1},
+			}},
+		},
+	}
+	ui.messages.init()
+
+	view := ui.itemDetailsView()
+	assert.Contains(t, view, "Number: ********")
+	assert.Contains(t, view, "(expired)")
+	assert.Contains(t, view, "Front image: attached")
+	assert.Contains(t, view, "Back image: none")
+
+	ui.handleItemDetailsInput(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r'}})
+	assert.Contains(t, ui.itemDetailsView(), "Number: X1234567")
+
+	// Sent and shared items show the number.
+	assert.Contains(t, itemDataView(ui.decryptedItem), "X1234567")
+
+	ui.handleItemDetailsInput(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'i'}})
+	require.Equal(t, stateIdentityFrontImage, ui.state)
+	typeRunes(ui.handleIdentityImageInput, "front.png")
+	ui.handleIdentityImageInput(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Equal(t, stateIdentityBackImage, ui.state)
+	assert.Contains(t, ui.identityImageView(), "Back image file")
+	_, cmd := ui.handleIdentityImageInput(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.NotNil(t, cmd)
+	assert.Equal(t, stateProcessing, ui.state)
+
+	ui.Update(identityImagesSet{item: ui.decryptedItem})
+	assert.Equal(t, stateItemDetails, ui.state)
+}
+
+func TestIdentityDataView_NotExpired(t *testing.T) {
+	view := identityDataView(&models.Identity{DocumentType: "ID card", ExpiryDate: "2030-06-01"}, time.Date(2030, 6, 1, 12, 0, 0, 0, time.UTC))
+	assert.NotContains(t, view, "expired")
+	assert.Contains(t, view, "Expires: 2030-06-01")
+}
@@ -2,8 +2,11 @@ package ui
 
 import (
 	"fmt"
+	"gophkeeper/internal/agent/offline"
 	"gophkeeper/internal/agent/services"
+	"gophkeeper/internal/agent/sshagent"
 	"gophkeeper/models"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 )
@@ -13,8 +16,12 @@ type UserInterface interface {
 }
 
 type UIController struct {
-	User *services.UserService
-	Item *services.ItemService
+	User      *services.UserService
+	Item      *services.ItemService
+	Org       *services.OrgService
+	Emergency *services.EmergencyService
+	SSHAgent  *sshagent.Agent
+	Offline   *offline.Client
 
 	state           state
 	input           string
@@ -28,6 +35,18 @@ type UIController struct {
 	userCtrl
 	itemCtrl
 	logoutCtrl
+	shareCtrl
+	orgCtrl
+	emergencyCtrl
+	deviceCtrl
+	breachCtrl
+	attachmentCtrl
+	sshCtrl
+	customCtrl
+	identityCtrl
+	connectionCtrl
+	syncCtrl
+	lockCtrl
 }
 
 type menuCtrl struct {
@@ -44,12 +63,23 @@ type userCtrl struct {
 type itemCtrl struct {
 	items    []models.EncryptedItem
 	maxItems int
+	// failedItems are the listed items that could not be decrypted.
+	failedItems services.ItemErrors
 
 	currentItem     int
 	selectedItem    *models.EncryptedItem
 	decryptedItem   *models.Item
 	decryptErrorMsg string
 
+	// revealed shows the secrets the item details mask.
+	revealed bool
+
+	searchQuery string
+	sortMode    services.SortMode
+
+	// totpTicks tells the running countdown apart from older ones.
+	totpTicks int
+
 	itemTypeMenu int
 	selectedType string
 	maxItemTypes int
@@ -78,24 +108,148 @@ type itemMetaCtrl struct {
 	metadataErrorMsg   string
 }
 
+type shareCtrl struct {
+	shares          []models.ItemShare
+	currentShare    int
+	shareSuccessMsg string
+	shareErrorMsg   string
+
+	// shareRecipient waits for its key fingerprint to be confirmed before
+	// pendingKeyUse runs, shareKeyChanged tells the key differs from the
+	// verified one.
+	shareRecipient   string
+	shareFingerprint string
+	shareKeyChanged  bool
+	pendingKeyUse    keyUse
+	ownFingerprint   string
+
+	sharedItems   []models.SharedItem
+	currentShared int
+	sharedItem    *models.Item
+}
+
+type orgCtrl struct {
+	vaults       []vaultEntry
+	currentVault int
+
+	selectedOrg   *models.Organization
+	members       []models.Membership
+	currentMember int
+}
+
+type emergencyCtrl struct {
+	emergency        []emergencyEntry
+	currentEmergency int
+
+	emergencyGrantor     string
+	emergencyItems       []models.Item
+	currentEmergencyItem int
+
+	takeoverStep     int
+	takeoverPassword string
+}
+
+type deviceCtrl struct {
+	devices          []models.Device
+	currentDevice    int
+	approvalRequired bool
+}
+
+type breachCtrl struct {
+	breaches        []services.BreachReport
+	breachesChecked int
+	currentBreach   int
+}
+
+type attachmentCtrl struct {
+	attachments       []services.AttachmentFile
+	currentAttachment int
+	attachmentMsg     string
+}
+
+type sshCtrl struct {
+	sshKeyPath     string
+	sshRequests    []sshSignRequest
+	sshReturnState state
+}
+
+type customCtrl struct {
+	templates       []models.ItemTemplate
+	currentTemplate int
+	templateMsg     string
+
+	newTemplate   models.ItemTemplate
+	newField      models.TemplateField
+	fieldTypeMenu int
+
+	// customTemplate is the template of the CUSTOM item being entered, it
+	// tells which fields are required.
+	customTemplate *models.ItemTemplate
+	customField    int
+
+	// dataForm holds the fields of a built-in item type entered with the
+	// CUSTOM item form, dataFields their descriptions.
+	dataForm   *models.Custom
+	dataFields []models.FieldInfo
+}
+
+type identityCtrl struct {
+	identityFrontImage string
+}
+
+type connectionCtrl struct {
+	connectionKindMenu int
+	connectionCopyMenu int
+	connectionMsg      string
+}
+
+type lockCtrl struct {
+	idleTimeout  time.Duration
+	lastActivity time.Time
+	idleTicks    int
+
+	// unlocked is set while the master key is in memory, locked while the
+	// vault waits for it after a lock.
+	unlocked    bool
+	locked      bool
+	lockedState state
+}
+
+type syncCtrl struct {
+	conflicts       []conflictEntry
+	currentConflict int
+	syncMsg         string
+}
+
 type logoutCtrl struct {
 	logoutSuccessMsg string
 	logoutErrorMsg   string
 }
 
-func NewUIController(us *services.UserService, is *services.ItemService) (UserInterface, error) {
+// NewUIController creates the UI. sa is the built-in ssh-agent and oc the
+// offline cache, each nil when it is turned off. The vault locks after
+// idleTimeout without a key press, zero never locks it.
+func NewUIController(us *services.UserService, is *services.ItemService, ors *services.OrgService, es *services.EmergencyService, sa *sshagent.Agent, oc *offline.Client, idleTimeout time.Duration) (UserInterface, error) {
 	ui := &UIController{
 		User:            us,
 		Item:            is,
+		Org:             ors,
+		Emergency:       es,
+		SSHAgent:        sa,
+		Offline:         oc,
 		state:           stateMenuLoggedOut,
-		maxLoggedInMenu: 4,
+		maxLoggedInMenu: 8,
 	}
+	ui.idleTimeout = idleTimeout
 	ui.messages.init()
 	return ui, nil
 }
 
 func (ui *UIController) Run() error {
 	p := tea.NewProgram(ui, tea.WithAltScreen())
+	if ui.SSHAgent != nil {
+		ui.SSHAgent.SetConfirm(confirmSSHSign(p))
+	}
 	finalModel, err := p.Run()
 	if err != nil {
 		return err
@@ -110,5 +264,5 @@ func (ui *UIController) Run() error {
 }
 
 func (ui *UIController) Init() tea.Cmd {
-	return nil
+	return ui.resumeSession()
 }
@@ -1,8 +1,12 @@
 package ui
 
 import (
+	"context"
 	"fmt"
+	"gophkeeper/internal/agent/services"
 	"gophkeeper/models"
+	"strings"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 )
@@ -13,6 +17,7 @@ func (ui *UIController) handleViewItemDetails() (*UIController, tea.Cmd) {
 		ui.state = stateItemDetails
 		ui.input = ""
 		ui.decryptedItem = nil
+		ui.revealed = false
 
 		return ui, ui.decryptItemCmd(ui.selectedItem)
 	}
@@ -40,6 +45,77 @@ func (ui *UIController) handleItemDetailsInput(msg tea.KeyMsg) (tea.Model, tea.C
 			return ui.startManageMetadata()
 		}
 		return ui, nil
+	case "s":
+		if ui.decryptedItem != nil {
+			return ui.startShareItem()
+		}
+		return ui, nil
+	case "v":
+		if ui.decryptedItem != nil {
+			return ui.handleViewItemShares()
+		}
+		return ui, nil
+	case "o":
+		if ui.decryptedItem != nil {
+			return ui.startSendItem()
+		}
+		return ui, nil
+	case "a":
+		if ui.decryptedItem != nil {
+			return ui.handleViewAttachments()
+		}
+		return ui, nil
+	case "f":
+		if ui.selectedItem != nil {
+			return ui, ui.setItemFavoriteCmd(*ui.selectedItem, !ui.selectedItem.Favorite)
+		}
+		return ui, nil
+	case "r":
+		ui.revealed = !ui.revealed
+		return ui, nil
+	case "i":
+		if ui.decryptedItem != nil {
+			return ui.startIdentityImages()
+		}
+		return ui, nil
+	case "c":
+		if ui.decryptedItem != nil {
+			return ui.startConnectionCopy()
+		}
+		return ui, nil
+	}
+	return ui, nil
+}
+
+type itemFavoriteSet struct {
+	item models.EncryptedItem
+}
+
+func (ui *UIController) setItemFavoriteCmd(item models.EncryptedItem, favorite bool) tea.Cmd {
+	return func() tea.Msg {
+		if err := ui.Item.SetItemFavorite(context.Background(), &item, favorite); err != nil {
+			return errorMsg{
+				err:     err,
+				context: "set_item_favorite",
+			}
+		}
+		return itemFavoriteSet{item: item}
+	}
+}
+
+// handleItemFavoriteSet re-sorts the list and keeps the item selected.
+func (ui *UIController) handleItemFavoriteSet(msg itemFavoriteSet) (tea.Model, tea.Cmd) {
+	for i := range ui.items {
+		if ui.items[i].ID == msg.item.ID {
+			ui.items[i].Favorite = msg.item.Favorite
+		}
+	}
+	services.SortItems(ui.items, ui.sortMode)
+	for i := range ui.items {
+		if ui.items[i].ID == msg.item.ID {
+			ui.currentItem = i
+			ui.selectedItem = &ui.items[i]
+		}
 	}
 	return ui, nil
 }
@@ -53,40 +129,123 @@ func (ui *UIController) itemDetailsView() string {
 	title := titleStyle.Render(fmt.Sprintf("Item Details: %s", selectedItem.Name))
 
 	details := fmt.Sprintf("Type: %s\n", selectedItem.Type)
+	if selectedItem.Favorite {
+		details += "Favorite: yes\n"
+	}
 	details += fmt.Sprintf("Created: %s\n", selectedItem.CreatedAt.Format("2006-01-02 15:04:05"))
-	details += fmt.Sprintf("Updated: %s\n\n", selectedItem.UpdatedAt.Format("2006-01-02 15:04:05"))
+	details += fmt.Sprintf("Updated: %s\n", selectedItem.UpdatedAt.Format("2006-01-02 15:04:05"))
+	if !selectedItem.ExpiresAt.IsZero() {
+		details += fmt.Sprintf("Expires: %s\n", formatExpiry(selectedItem.ExpiresAt))
+	}
+	if !selectedItem.LastUsedAt.IsZero() {
+		details += fmt.Sprintf("Last used: %s (%d times)\n", selectedItem.LastUsedAt.Format("2006-01-02 15:04:05"), selectedItem.UseCount)
+	}
+	details += "\n"
 
 	if ui.decryptedItem != nil {
-		details += "Data:\n"
-		switch data := ui.decryptedItem.Data.(type) {
-		case *models.Credentials:
-			details += fmt.Sprintf("  Login: %s\n", data.Login)
-			details += fmt.Sprintf("  Password: %s\n", data.Password)
-		case *models.Text:
-			details += fmt.Sprintf("  Content: %s\n", data.Content)
-		case *models.Card:
-			details += fmt.Sprintf("  Number: %s\n", data.Number)
-			details += fmt.Sprintf("  Expiry: %s\n", data.ExpiryDate)
-			details += fmt.Sprintf("  CVV: %s\n", data.SecurityCode)
-			details += fmt.Sprintf("  Cardholder: %s\n", data.CardholderName)
-		case *models.Binary:
-			details += fmt.Sprintf("  Content: %s\n", string(data.Content))
-		default:
-			details += "  Unknown data type\n"
-		}
-
-		if len(ui.decryptedItem.Meta.Map) > 0 {
-			details += "\nMetadata:\n"
-			for key, value := range ui.decryptedItem.Meta.Map {
-				details += fmt.Sprintf("  %s: %s\n", key, value)
-			}
-		} else {
-			details += "\nNo metadata\n"
+		item := ui.decryptedItem
+		if !ui.revealed {
+			item = maskSecrets(item)
 		}
+		details += itemDataView(item)
 	} else {
 		details += "Loading data...\n"
 	}
 
-	controls := "\nControls: e to edit, m to manage metadata, d to delete, b/Esc to go back"
+	controls := "\nControls: e to edit, f to toggle favorite, a for attachments, m to manage metadata, s to share, v to view shares, o for one-time link, d to delete, b/Esc to go back"
+	if ui.decryptedItem != nil {
+		if hasSecrets(ui.decryptedItem) {
+			controls += "\nr to reveal secrets"
+		}
+		switch ui.decryptedItem.Data.(type) {
+		case *models.Identity:
+			controls += "\ni to attach document images"
+		case *models.Connection:
+			controls += "\nc to copy fields or connection strings"
+		}
+	}
 	return fmt.Sprintf("%s\n\n%s%s", title, details, controls)
 }
+
+// hasSecrets reports whether the details of item hide secrets until they
+// are revealed. TOTP details show the code and never the secret.
+func hasSecrets(item *models.Item) bool {
+	if item.Data == nil || item.Data.GetType() == models.ItemTypeTOTP {
+		return false
+	}
+	info, _ := models.LookupType(item.Data.GetType())
+	for _, f := range info.FieldsOf(item.Data) {
+		if f.Secret() {
+			return true
+		}
+	}
+	return false
+}
+
+// maskSecrets returns a copy of item with the secrets the details hide
+// until they are revealed replaced by asterisks.
+func maskSecrets(item *models.Item) *models.Item {
+	if !hasSecrets(item) {
+		return item
+	}
+	info, _ := models.LookupType(item.Data.GetType())
+	data := models.CloneData(item.Data)
+	for _, f := range info.FieldsOf(data) {
+		if f.Secret() {
+			f.Set(data, strings.Repeat("*", len(f.Get(data))))
+		}
+	}
+	masked := *item
+	masked.Data = data
+	return &masked
+}
+
+// fieldsDataView shows the set fields of data as they are registered.
+func fieldsDataView(info models.TypeInfo, data models.Data) string {
+	details := ""
+	for _, f := range info.FieldsOf(data) {
+		if value := f.Get(data); value != "" {
+			details += fmt.Sprintf("  %s: %s\n", f.Label, strings.ReplaceAll(value, "\n", "\n    "))
+		}
+	}
+	return details
+}
+
+func itemDataView(item *models.Item) string {
+	details := "Data:\n"
+	switch data := item.Data.(type) {
+	case *models.TOTP:
+		details += totpDataView(data, time.Now())
+	case *models.Custom:
+		details += customDataView(data)
+	case *models.Identity:
+		details += identityDataView(data, time.Now())
+	case *models.Connection:
+		details += connectionDataView(data)
+	case *models.SSHKey:
+		details += fmt.Sprintf("  Key type: %s\n", data.KeyType)
+		details += fmt.Sprintf("  Fingerprint: %s\n", data.Fingerprint)
+		details += fmt.Sprintf("  Public key: %s\n", data.PublicKey)
+		if data.Comment != "" {
+			details += fmt.Sprintf("  Comment: %s\n", data.Comment)
+		}
+	case nil:
+		details += "  No data\n"
+	default:
+		if info, ok := models.LookupType(data.GetType()); ok {
+			details += fieldsDataView(info, data)
+		} else {
+			details += "  Unknown data type\n"
+		}
+	}
+
+	if len(item.Meta.Map) > 0 {
+		details += "\nMetadata:\n"
+		for key, value := range item.Meta.Map {
+			details += fmt.Sprintf("  %s: %s\n", key, value)
+		}
+	} else {
+		details += "\nNo metadata\n"
+	}
+	return details
+}
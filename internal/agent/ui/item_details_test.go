@@ -1,6 +1,7 @@
 package ui
 
 import (
+	"fmt"
 	"gophkeeper/models"
 	"testing"
 	"time"
@@ -281,7 +282,8 @@ func TestUIController_itemDetailsView_WithCredentials(t *testing.T) {
 	assert.Contains(t, view, "Type: CREDENTIALS")
 	assert.Contains(t, view, "Data:")
 	assert.Contains(t, view, "Login: test-login")
-	assert.Contains(t, view, "Password: test-password")
+	assert.Contains(t, view, "Password: *************")
+	assert.Contains(t, view, "r to reveal secrets")
 	assert.Contains(t, view, "Metadata:")
 	assert.Contains(t, view, "note: test note")
 }
@@ -345,7 +347,10 @@ func TestUIController_itemDetailsView_WithCard(t *testing.T) {
 	assert.Contains(t, view, "Type: CARD")
 	assert.Contains(t, view, "Number: 1234567890123456")
 	assert.Contains(t, view, "Expiry: 12/25")
-	assert.Contains(t, view, "CVV: 123")
+	assert.Contains(t, view, "CVV: ***")
+
+	ui.revealed = true
+	assert.Contains(t, ui.itemDetailsView(), "CVV: 123")
 	assert.Contains(t, view, "Cardholder: John Doe")
 }
 
@@ -409,3 +414,55 @@ func TestUIController_itemDetailsView_UnknownDataType(t *testing.T) {
 	assert.Contains(t, view, "Type: UNKNOWN")
 	assert.Contains(t, view, "Unknown data type")
 }
+
+func TestUIController_handleItemFavoriteSet(t *testing.T) {
+	ui := &UIController{
+		state: stateItemDetails,
+		itemCtrl: itemCtrl{
+			items: []models.EncryptedItem{
+				{ID: [16]byte{1}, Name: "bank"},
+				{ID: [16]byte{2}, Name: "wifi"},
+			},
+			currentItem: 1,
+		},
+	}
+	ui.selectedItem = &ui.items[1]
+
+	ui.Update(itemFavoriteSet{item: models.EncryptedItem{ID: [16]byte{2}, Favorite: true}})
+	assert.Equal(t, 0, ui.currentItem)
+	assert.Equal(t, "wifi", ui.selectedItem.Name)
+	assert.True(t, ui.selectedItem.Favorite)
+	assert.Contains(t, ui.itemDetailsView(), "Favorite: yes")
+}
+
+func TestMaskSecrets_RegisteredFields(t *testing.T) {
+	item := &models.Item{Type: models.ItemTypeIDENTITY, Data: &models.Identity{DocumentType: "ID card", Number: "AB12", BackImage: [16]byte{2}}}
+
+	masked := maskSecrets(item)
+	assert.Equal(t, &models.Identity{DocumentType: "ID card", Number: "****", BackImage: [16]byte{2}}, masked.Data)
+	assert.Equal(t, "AB12", item.Data.(*models.Identity).Number)
+
+	text := &models.Item{Type: models.ItemTypeTEXT, Data: &models.Text{Content: "note"}}
+	assert.Equal(t, text, maskSecrets(text))
+
+	conn := &models.Item{Type: models.ItemTypeCONNECTION, Data: &models.Connection{Kind: models.ConnectionHTTP, BaseURL: "https://api.example.com", Token: "tok"}}
+	assert.Equal(t, "***", maskSecrets(conn).Data.(*models.Connection).Token)
+
+	otp := &models.Item{Type: models.ItemTypeTOTP, Data: &models.TOTP{Secret: "JBSWY3DPEHPK3PXP"}}
+	assert.Equal(t, otp, maskSecrets(otp))
+}
+
+func TestFieldsDataView(t *testing.T) {
+	info, _ := models.LookupType(models.ItemTypeIDENTITY)
+	view := fieldsDataView(info, &models.Identity{DocumentType: "ID card", Country: "NL"})
+	assert.Equal(t, "  Document type: ID card\n  Country: NL\n", view)
+}
+
+func TestUIController_addItemTypeView_Registry(t *testing.T) {
+	ui := &UIController{}
+	view := ui.addItemTypeView()
+	for i, typ := range models.ItemTypes {
+		info, _ := models.LookupType(typ)
+		assert.Contains(t, view, fmt.Sprintf("%d. %s", i+1, info.Name))
+	}
+}
@@ -0,0 +1,148 @@
+package ui
+
+import (
+	"fmt"
+	"gophkeeper/models"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const expiryLayout = "2006-01-02 15:04"
+
+// parseExpiry accepts a duration like 12h or 7d, a local date in
+// expiryLayout, or an empty string for items that never expire.
+func parseExpiry(input string, now time.Time) (time.Time, error) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return time.Time{}, nil
+	}
+
+	var expiresAt time.Time
+	if days, ok := strings.CutSuffix(input, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid number of days: %s", days)
+		}
+		expiresAt = now.AddDate(0, 0, n)
+	} else if d, err := time.ParseDuration(input); err == nil {
+		expiresAt = now.Add(d)
+	} else if t, err := time.ParseInLocation(expiryLayout, input, time.Local); err == nil {
+		expiresAt = t
+	} else {
+		return time.Time{}, fmt.Errorf("expiry must be a duration like 12h or 7d, or a date in %s format", expiryLayout)
+	}
+
+	if !expiresAt.After(now) {
+		return time.Time{}, fmt.Errorf("expiry must be in the future")
+	}
+	return expiresAt, nil
+}
+
+func formatExpiry(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Local().Format(expiryLayout)
+}
+
+func (ui *UIController) handleAddItemExpiryInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return ui, tea.Quit
+	case "esc":
+		ui.state = stateAddItemName
+		ui.input = ui.newItem.Name
+		ui.messages.Clear("error")
+		return ui, nil
+	case "enter":
+		expiresAt, err := parseExpiry(ui.input, time.Now())
+		if err != nil {
+			ui.messages.Set("error", err.Error())
+			return ui, nil
+		}
+		ui.newItem.ExpiresAt = expiresAt
+		ui.input = ""
+		ui.state = stateAddItemData
+		if data, ok := ui.newItem.Data.(*models.Custom); ok {
+			ui.startCustomForm(data)
+		} else if _, ok := dataImports[ui.newItem.Type]; !ok {
+			ui.startDataForm(ui.newItem.Data)
+		}
+		ui.messages.Clear("error")
+		return ui, nil
+	case "backspace":
+		if len(ui.input) > 0 {
+			ui.input = ui.input[:len(ui.input)-1]
+		}
+	default:
+		if len(msg.String()) == 1 {
+			ui.input += msg.String()
+		}
+	}
+	return ui, nil
+}
+
+func (ui *UIController) handleEditItemExpiryInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return ui, tea.Quit
+	case "esc":
+		ui.state = stateEditItemName
+		ui.input = ui.editingItem.Name
+		ui.messages.Clear("error")
+		return ui, nil
+	case "enter":
+		expiresAt, err := parseExpiry(ui.input, time.Now())
+		if err != nil {
+			ui.messages.Set("error", err.Error())
+			return ui, nil
+		}
+		ui.editingItem.ExpiresAt = expiresAt
+		ui.messages.Clear("error")
+
+		if data, ok := ui.editingItem.Data.(*models.Custom); ok {
+			ui.customTemplate = ui.templateOf(data)
+			ui.state = stateEditCustomField
+			ui.startCustomForm(data)
+		} else {
+			ui.state = stateEditDataForm
+			ui.startDataForm(ui.editingItem.Data)
+		}
+		return ui, nil
+	case "backspace":
+		if len(ui.input) > 0 {
+			ui.input = ui.input[:len(ui.input)-1]
+		}
+	default:
+		if len(msg.String()) == 1 {
+			ui.input += msg.String()
+		}
+	}
+	return ui, nil
+}
+
+func (ui *UIController) addItemExpiryView() string {
+	title := titleStyle.Render(fmt.Sprintf("Add %s - Self-destruct", ui.newItem.Type))
+	return title + "\n\n" + ui.expiryInputView()
+}
+
+func (ui *UIController) editItemExpiryView() string {
+	title := titleStyle.Render("Edit Item - Self-destruct")
+	return title + "\n\n" + ui.expiryInputView()
+}
+
+func (ui *UIController) expiryInputView() string {
+	input := inputStyle.Render(ui.input + "█")
+
+	errorMsg := ""
+	if err := ui.messages.Get("error"); err != "" {
+		errorMsg = "\n" + errorStyle.Render(err)
+	}
+
+	hint := fmt.Sprintf("\nDuration (12h, 7d), date (%s) or empty to keep forever", expiryLayout)
+	controls := "\nControls: Esc to go back, Enter to continue"
+	return fmt.Sprintf("Expires: %s%s%s%s", input, hint, errorMsg, controls)
+}
@@ -0,0 +1,83 @@
+package ui
+
+import (
+	"testing"
+	"time"
+
+	"gophkeeper/models"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseExpiry(t *testing.T) {
+	now := time.Date(2025, 3, 1, 10, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		name    string
+		input   string
+		want    time.Time
+		wantErr bool
+	}{
+		{name: "empty never expires", input: "  ", want: time.Time{}},
+		{name: "duration", input: "12h", want: now.Add(12 * time.Hour)},
+		{name: "days", input: "7d", want: now.AddDate(0, 0, 7)},
+		{name: "date", input: "2025-03-02 08:30", want: time.Date(2025, 3, 2, 8, 30, 0, 0, time.Local)},
+		{name: "past date", input: "2025-02-01 08:30", wantErr: true},
+		{name: "negative duration", input: "-1h", wantErr: true},
+		{name: "bad days", input: "xd", wantErr: true},
+		{name: "garbage", input: "tomorrow", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseExpiry(tt.input, now)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, tt.want.Equal(got), "want %v, got %v", tt.want, got)
+		})
+	}
+}
+
+func TestUIController_handleAddItemExpiryInput(t *testing.T) {
+	ui := &UIController{state: stateAddItemExpiry, input: "soon"}
+	ui.newItem = models.Item{Type: models.ItemTypeTEXT, Data: &models.Text{}}
+	ui.messages.init()
+
+	ui.handleAddItemExpiryInput(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Equal(t, stateAddItemExpiry, ui.state)
+	assert.NotEmpty(t, ui.messages.Get("error"))
+
+	ui.input = "2h"
+	ui.handleAddItemExpiryInput(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Equal(t, stateAddItemData, ui.state)
+	assert.Empty(t, ui.messages.Get("error"))
+	assert.WithinDuration(t, time.Now().Add(2*time.Hour), ui.newItem.ExpiresAt, time.Minute)
+}
+
+func TestUIController_handleEditItemExpiryInput_Esc(t *testing.T) {
+	ui := &UIController{
+		state: stateEditItemExpiry,
+		itemCtrl: itemCtrl{
+			editingItem: &models.Item{Name: "bank"},
+		},
+	}
+	ui.messages.init()
+
+	ui.handleEditItemExpiryInput(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.Equal(t, stateEditItemName, ui.state)
+	assert.Equal(t, "bank", ui.input)
+}
+
+func TestUIController_itemDetailsView_ShowsExpiry(t *testing.T) {
+	expiresAt := time.Date(2030, 1, 2, 3, 4, 0, 0, time.Local)
+	ui := &UIController{itemCtrl: itemCtrl{
+		items: []models.EncryptedItem{{Name: "temp", ExpiresAt: expiresAt}},
+	}}
+
+	assert.Contains(t, ui.itemDetailsView(), "Expires: 2030-01-02 03:04")
+}
@@ -3,6 +3,7 @@ package ui
 import (
 	"context"
 	"fmt"
+	"gophkeeper/internal/agent/services"
 	"gophkeeper/models"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -16,12 +17,15 @@ func (ui *UIController) handleViewAllItems() (tea.Model, tea.Cmd) {
 }
 
 type itemsLoaded struct {
-	items []models.EncryptedItem
+	items  []models.EncryptedItem
+	failed services.ItemErrors
+	query  string
 }
 
 func (ui *UIController) loadItemsCmd() tea.Cmd {
 	return func() tea.Msg {
 		items, err := ui.Item.GetItems(context.Background(), ui.login, models.ItemTypeUNSPECIFIED)
+		failed, err := services.SplitItemErrors(err)
 		if err != nil {
 			return errorMsg{
 				err:     err,
@@ -30,7 +34,8 @@ func (ui *UIController) loadItemsCmd() tea.Cmd {
 		}
 
 		return itemsLoaded{
-			items: items,
+			items:  items,
+			failed: failed,
 		}
 	}
 }
@@ -58,21 +63,40 @@ func (ui *UIController) handleItemsListInput(msg tea.KeyMsg) (tea.Model, tea.Cmd
 	case "r":
 		ui.state = stateProcessing
 		return ui, ui.loadItemsCmd()
+	case "/":
+		return ui.handleSearchItems()
+	case "s":
+		ui.sortMode = ui.sortMode.Next()
+		services.SortItems(ui.items, ui.sortMode)
+		ui.currentItem = 0
 	}
 	return ui, nil
 }
 
 func (ui *UIController) itemsListView() string {
 	title := titleStyle.Render("Your Items")
+	if ui.searchQuery != "" {
+		title = titleStyle.Render(fmt.Sprintf("Search: %s", ui.searchQuery))
+	}
 
 	if len(ui.items) == 0 {
-		controls := "\nControls: r to refresh, Esc to go back, q to quit"
+		controls := "\nControls: / to search, r to refresh, Esc to go back, q to quit"
 		return fmt.Sprintf("%s\n\nNo items found.%s", title, controls)
 	}
 
+	// Favorites are sorted first and get a section of their own.
 	itemsList := ""
+	hasFavorites := ui.items[0].Favorite
 	for i, item := range ui.items {
+		if hasFavorites && i == 0 {
+			itemsList += "★ Favorites\n"
+		} else if hasFavorites && !item.Favorite && ui.items[i-1].Favorite {
+			itemsList += "\nAll items\n"
+		}
 		itemText := fmt.Sprintf("%s (%s)", item.Name, item.Type)
+		if _, ok := ui.failedItems[item.ID]; ok {
+			itemText += " ⚠ cannot decrypt"
+		}
 		if i == ui.currentItem {
 			itemsList += selectedStyle.Render("→ "+itemText) + "\n"
 		} else {
@@ -80,8 +104,9 @@ func (ui *UIController) itemsListView() string {
 		}
 	}
 
-	controls := "\nControls: ↑/↓ to navigate, Enter to view details, r to refresh, Esc to go back"
-	return fmt.Sprintf("%s\n\n%s%s", title, itemsList, controls)
+	sorted := fmt.Sprintf("Sorted by %s", ui.sortMode)
+	controls := "\nControls: ↑/↓ to navigate, Enter to view details, / to search, s to change sorting, r to refresh, Esc to go back"
+	return fmt.Sprintf("%s\n%s\n\n%s%s", title, sorted, itemsList, controls)
 }
 
 func (ui *UIController) handleViewItemsWithType() (tea.Model, tea.Cmd) {
@@ -122,6 +147,7 @@ func (ui *UIController) loadItemTypesCmd() tea.Cmd {
 func (ui *UIController) loadItemsByTypeCmd(itemType string) tea.Cmd {
 	return func() tea.Msg {
 		items, err := ui.Item.GetItems(context.Background(), ui.login, models.ItemType(itemType))
+		failed, err := services.SplitItemErrors(err)
 		if err != nil {
 			return errorMsg{
 				err:     err,
@@ -131,6 +157,7 @@ func (ui *UIController) loadItemsByTypeCmd(itemType string) tea.Cmd {
 
 		return itemsByTypeLoaded{
 			items:    items,
+			failed:   failed,
 			itemType: itemType,
 		}
 	}
@@ -146,6 +173,7 @@ type itemTypeLoaded struct {
 
 type itemsByTypeLoaded struct {
 	items    []models.EncryptedItem
+	failed   services.ItemErrors
 	itemType string
 }
 
@@ -250,6 +278,9 @@ func (ui *UIController) viewItemsByTypeView() string {
 		if itemName == "" {
 			itemName = "Unnamed Item"
 		}
+		if _, ok := ui.failedItems[item.ID]; ok {
+			itemName += " ⚠ cannot decrypt"
+		}
 
 		if i == ui.currentItem {
 			menu += selectedStyle.Render(prefix+itemName) + "\n"
@@ -362,3 +362,21 @@ func TestUIController_itemsListView_DifferentItemTypes(t *testing.T) {
 	assert.Contains(t, view, "Payment (CARD)")
 	assert.Contains(t, view, "File (BINARY)")
 }
+
+func TestUIController_itemsListView_SortAndFavorites(t *testing.T) {
+	ui := &UIController{state: stateItemsList}
+	ui.Update(itemsLoaded{items: []models.EncryptedItem{
+		{Name: "wifi", Type: models.ItemTypeTEXT},
+		{Name: "bank", Type: models.ItemTypeCREDENTIALS, Favorite: true},
+	}})
+	assert.Equal(t, "bank", ui.items[0].Name)
+
+	view := ui.itemsListView()
+	assert.Contains(t, view, "Sorted by recent")
+	assert.Contains(t, view, "★ Favorites")
+	assert.Contains(t, view, "All items")
+
+	ui.handleItemsListInput(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}})
+	assert.Contains(t, ui.itemsListView(), "Sorted by name")
+	assert.Equal(t, "bank", ui.items[0].Name)
+}
@@ -0,0 +1,143 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"gophkeeper/internal/agent/services"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// idleTick checks the time since the last key press, id tells the running
+// timer apart from older ones.
+type idleTick struct {
+	id int
+}
+
+type vaultUnlocked struct {
+	vault *services.Vault
+}
+
+func idleTickCmd(id int, after time.Duration) tea.Cmd {
+	return tea.Tick(after, func(time.Time) tea.Msg {
+		return idleTick{id: id}
+	})
+}
+
+// startIdleTimer starts counting idle time once the vault is unlocked.
+func (ui *UIController) startIdleTimer() tea.Cmd {
+	ui.unlocked = true
+	ui.lastActivity = time.Now()
+	ui.idleTicks++
+	if ui.idleTimeout <= 0 {
+		return nil
+	}
+	return idleTickCmd(ui.idleTicks, ui.idleTimeout)
+}
+
+func (ui *UIController) handleIdleTick(msg idleTick) (tea.Model, tea.Cmd) {
+	if msg.id != ui.idleTicks || !ui.unlocked {
+		return ui, nil
+	}
+	idle := time.Since(ui.lastActivity)
+	// A running request would land on the lock screen, the next check
+	// locks after it.
+	if idle < ui.idleTimeout || ui.state == stateProcessing {
+		return ui, idleTickCmd(msg.id, max(ui.idleTimeout-idle, time.Second))
+	}
+	return ui.lock()
+}
+
+// lock wipes the keys and the decrypted items and asks for the master
+// password. The screen and the edits in progress are kept for the unlock.
+func (ui *UIController) lock() (tea.Model, tea.Cmd) {
+	if !ui.unlocked || ui.state == stateProcessing {
+		return ui, nil
+	}
+	if err := ui.User.Lock(); err != nil {
+		return ui, func() tea.Msg {
+			return errorMsg{
+				err:     err,
+				context: "lock",
+			}
+		}
+	}
+	if vault := ui.Item.Vault(); vault != nil {
+		vault.Wipe()
+	}
+	if ui.SSHAgent != nil {
+		ui.SSHAgent.SetSource(nil)
+	}
+
+	ui.unlocked = false
+	ui.locked = true
+	ui.idleTicks++
+	ui.totpTicks++
+	ui.lockedState = ui.state
+	ui.decryptedItem = nil
+	ui.sharedItem = nil
+	ui.emergencyItems = nil
+	// A takeover asks for both passwords again after unlock.
+	ui.takeoverPassword = ""
+	ui.takeoverStep = takeoverStepPassword
+	ui.revealed = false
+	ui.input = ""
+	ui.messages.Clear("master_password_error")
+	ui.state = stateMasterPassword
+	return ui, nil
+}
+
+// unlockCmd opens the locked vault again, with the collection vault that
+// was open.
+func (ui *UIController) unlockCmd(masterPassword string) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		err := ui.User.SetMasterKey(ctx, masterPassword)
+		if err == nil {
+			err = ui.User.EnsureUserKeys(ctx)
+		}
+		var vault *services.Vault
+		if open := ui.Item.Vault(); err == nil && open != nil {
+			vault, err = ui.Org.OpenVault(ctx, &open.Org, &open.Collection)
+		}
+		if err != nil {
+			// A wrong master password does not stay in memory either.
+			return processComplete{
+				success: false,
+				message: fmt.Sprintf("Unlock vault: %v", errors.Join(err, ui.User.Lock())),
+				context: "master_password",
+			}
+		}
+		return vaultUnlocked{vault: vault}
+	}
+}
+
+// handleVaultUnlocked returns to the screen the vault was locked on. The
+// items it showed are decrypted again.
+func (ui *UIController) handleVaultUnlocked(msg vaultUnlocked) (tea.Model, tea.Cmd) {
+	if msg.vault != nil {
+		ui.Item.SetVault(msg.vault)
+	}
+	ui.locked = false
+	ui.input = ""
+	ui.messages.Clear("master_password_error")
+	ui.unlockSSHAgent()
+	cmds := []tea.Cmd{ui.startIdleTimer()}
+
+	ui.state = ui.lockedState
+	switch ui.lockedState {
+	case stateItemDetails:
+		if ui.selectedItem != nil {
+			cmds = append(cmds, ui.decryptItemCmd(ui.selectedItem))
+		}
+	case stateSharedItemDetails:
+		if ui.currentShared < len(ui.sharedItems) {
+			cmds = append(cmds, ui.decryptSharedItemCmd(&ui.sharedItems[ui.currentShared]))
+		}
+	case stateEmergencyVault, stateEmergencyItemDetails:
+		ui.state = stateEmergencyList
+	}
+	return ui, tea.Batch(cmds...)
+}
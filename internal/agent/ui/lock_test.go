@@ -0,0 +1,103 @@
+package ui
+
+import (
+	"gophkeeper/config"
+	"gophkeeper/internal/agent/services"
+	"gophkeeper/models"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newLockTestUI(t *testing.T) (*UIController, *config.Config) {
+	cnfg := &config.Config{}
+	us, err := services.NewUserService(cnfg, nil, nil)
+	require.NoError(t, err)
+	is, err := services.NewItemService(nil, nil)
+	require.NoError(t, err)
+
+	ui := &UIController{User: us, Item: is, state: stateItemsList}
+	ui.idleTimeout = time.Minute
+	require.NoError(t, cnfg.SetMasterPassword([]byte("master")))
+	require.NoError(t, cnfg.SetMasterKey([]byte("0123456789abcdef")))
+	ui.startIdleTimer()
+	return ui, cnfg
+}
+
+func TestUIController_Lock(t *testing.T) {
+	ui, cnfg := newLockTestUI(t)
+	key, err := cnfg.GetMasterKey()
+	require.NoError(t, err)
+	ui.decryptedItem = &models.Item{Name: "mail"}
+	ui.currentItem = 3
+	ui.takeoverPassword = "new-account-password"
+	ui.takeoverStep = takeoverStepMasterPassword
+
+	ui.Update(tea.KeyMsg{Type: tea.KeyCtrlL})
+	assert.Equal(t, stateMasterPassword, ui.state)
+	assert.Equal(t, make([]byte, 16), key)
+	mk, err := cnfg.GetMasterKey()
+	require.NoError(t, err)
+	assert.Nil(t, mk)
+	_, err = cnfg.GetMasterPassword()
+	assert.Error(t, err)
+	assert.Nil(t, ui.decryptedItem)
+	assert.Empty(t, ui.takeoverPassword)
+	assert.Equal(t, takeoverStepPassword, ui.takeoverStep)
+	assert.Contains(t, ui.masterPasswordInputView(), "Vault Locked")
+
+	// Only the master password leaves the lock screen.
+	ui.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.Equal(t, stateMasterPassword, ui.state)
+	_, cmd := ui.Update(tea.KeyMsg{Type: tea.KeyCtrlL})
+	assert.Nil(t, cmd)
+
+	_, cmd = ui.Update(vaultUnlocked{})
+	assert.NotNil(t, cmd)
+	assert.Equal(t, stateItemsList, ui.state)
+	assert.Equal(t, 3, ui.currentItem)
+	assert.True(t, ui.unlocked)
+	assert.False(t, ui.locked)
+}
+
+func TestUIController_Lock_NotUnlocked(t *testing.T) {
+	ui := &UIController{state: stateMenuLoggedOut}
+
+	_, cmd := ui.Update(tea.KeyMsg{Type: tea.KeyCtrlL})
+	assert.Nil(t, cmd)
+	assert.Equal(t, stateMenuLoggedOut, ui.state)
+}
+
+func TestUIController_handleIdleTick(t *testing.T) {
+	ui, _ := newLockTestUI(t)
+
+	// Older timers are ignored.
+	_, cmd := ui.handleIdleTick(idleTick{id: ui.idleTicks - 1})
+	assert.Nil(t, cmd)
+
+	_, cmd = ui.handleIdleTick(idleTick{id: ui.idleTicks})
+	assert.NotNil(t, cmd)
+	assert.Equal(t, stateItemsList, ui.state)
+
+	ui.lastActivity = time.Now().Add(-2 * time.Minute)
+	ui.state = stateProcessing
+	_, cmd = ui.handleIdleTick(idleTick{id: ui.idleTicks})
+	assert.NotNil(t, cmd)
+	assert.Equal(t, stateProcessing, ui.state)
+
+	ui.state = stateItemDetails
+	ui.handleIdleTick(idleTick{id: ui.idleTicks})
+	assert.Equal(t, stateMasterPassword, ui.state)
+	assert.Equal(t, stateItemDetails, ui.lockedState)
+	assert.False(t, ui.unlocked)
+}
+
+func TestUIController_startIdleTimer_Off(t *testing.T) {
+	ui := &UIController{}
+
+	assert.Nil(t, ui.startIdleTimer())
+	assert.True(t, ui.unlocked)
+}
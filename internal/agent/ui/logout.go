@@ -136,13 +136,30 @@ func (ui *UIController) logoutErrorView() string {
 
 func (ui *UIController) clearUserSession() {
 	ui.isAuthenticated = false
+	ui.unlocked = false
+	ui.locked = false
+	ui.idleTicks++
 	ui.login = ""
 	ui.items = nil
+	ui.searchQuery = ""
 	ui.currentItem = 0
 	ui.selectedItem = nil
 	ui.decryptedItem = nil
 	ui.editingItem = nil
 	ui.newItem = models.Item{}
+	ui.vaults = nil
+	ui.selectedOrg = nil
+	ui.members = nil
+	ui.emergency = nil
+	ui.emergencyItems = nil
+	ui.emergencyGrantor = ""
+	ui.takeoverPassword = ""
+	if ui.Item != nil {
+		ui.Item.SetVault(nil)
+	}
+	if ui.SSHAgent != nil {
+		ui.SSHAgent.SetSource(nil)
+	}
 	ui.metadataKeys = nil
 	ui.currentMetaKey = ""
 	ui.currentMetaValue = ""
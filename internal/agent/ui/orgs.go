@@ -0,0 +1,518 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"gophkeeper/internal/agent/services"
+	"gophkeeper/models"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// vaultEntry is a row of the vault list: the personal vault (nil org),
+// an organization collection or a pending invitation (nil collection).
+type vaultEntry struct {
+	org  *models.Organization
+	coll *models.Collection
+}
+
+type vaultsLoaded struct {
+	vaults []vaultEntry
+}
+
+type vaultOpened struct {
+	vault *services.Vault
+}
+
+type membersLoaded struct {
+	members []models.Membership
+}
+
+func (v vaultEntry) title() string {
+	switch {
+	case v.org == nil:
+		return "Personal"
+	case !v.org.Accepted:
+		return fmt.Sprintf("%s (invited as %s)", v.org.Name, v.org.Role)
+	default:
+		return fmt.Sprintf("%s / %s (%s)", v.org.Name, v.coll.Name, v.org.Role)
+	}
+}
+
+func (ui *UIController) currentVaultName() string {
+	if ui.Item == nil || ui.Item.Vault() == nil {
+		return "Personal"
+	}
+	v := ui.Item.Vault()
+	return fmt.Sprintf("%s / %s", v.Org.Name, v.Collection.Name)
+}
+
+func (ui *UIController) handleSwitchVault() (tea.Model, tea.Cmd) {
+	ui.state = stateProcessing
+	ui.currentVault = 0
+	return ui, ui.loadVaultsCmd()
+}
+
+func (ui *UIController) loadVaultsCmd() tea.Cmd {
+	return func() tea.Msg {
+		vaults, err := ui.loadVaults(context.Background())
+		if err != nil {
+			return errorMsg{
+				err:     err,
+				context: "load_vaults",
+			}
+		}
+		return vaultsLoaded{
+			vaults: vaults,
+		}
+	}
+}
+
+func (ui *UIController) loadVaults(ctx context.Context) ([]vaultEntry, error) {
+	orgs, err := ui.Org.ListOrgs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	vaults := []vaultEntry{{}}
+	for i := range orgs {
+		org := &orgs[i]
+		if !org.Accepted {
+			vaults = append(vaults, vaultEntry{org: org})
+			continue
+		}
+		colls, err := ui.Org.ListCollections(ctx, org.ID)
+		if err != nil {
+			return nil, err
+		}
+		for j := range colls {
+			vaults = append(vaults, vaultEntry{org: org, coll: &colls[j]})
+		}
+	}
+	return vaults, nil
+}
+
+func (ui *UIController) handleVaultListInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c", "q":
+		return ui, tea.Quit
+	case "esc":
+		ui.state = stateMenuLoggedIn
+		return ui, nil
+	case "up", "k":
+		if ui.currentVault > 0 {
+			ui.currentVault--
+		}
+	case "down", "j":
+		if ui.currentVault < len(ui.vaults)-1 {
+			ui.currentVault++
+		}
+	case "enter":
+		if ui.currentVault < len(ui.vaults) {
+			entry := ui.vaults[ui.currentVault]
+			if entry.org != nil && !entry.org.Accepted {
+				return ui, nil
+			}
+			ui.state = stateProcessing
+			return ui, ui.openVaultCmd(entry)
+		}
+	case "a":
+		if ui.currentVault < len(ui.vaults) {
+			entry := ui.vaults[ui.currentVault]
+			if entry.org != nil && !entry.org.Accepted {
+				ui.state = stateProcessing
+				return ui, ui.acceptInviteCmd(entry.org.ID)
+			}
+		}
+	case "n":
+		ui.state = stateNewOrgName
+		ui.input = ""
+	case "m":
+		if ui.currentVault < len(ui.vaults) {
+			entry := ui.vaults[ui.currentVault]
+			if entry.org != nil && entry.org.Accepted {
+				ui.selectedOrg = entry.org
+				ui.currentMember = 0
+				ui.state = stateProcessing
+				return ui, ui.loadMembersCmd()
+			}
+		}
+	}
+	return ui, nil
+}
+
+func (ui *UIController) openVaultCmd(entry vaultEntry) tea.Cmd {
+	return func() tea.Msg {
+		if entry.org == nil {
+			return vaultOpened{}
+		}
+		vault, err := ui.Org.OpenVault(context.Background(), entry.org, entry.coll)
+		if err != nil {
+			return errorMsg{
+				err:     err,
+				context: "open_vault",
+			}
+		}
+		return vaultOpened{
+			vault: vault,
+		}
+	}
+}
+
+func (ui *UIController) acceptInviteCmd(orgID [16]byte) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		if err := ui.Org.AcceptInvite(ctx, orgID); err != nil {
+			return errorMsg{
+				err:     err,
+				context: "accept_invite",
+			}
+		}
+		vaults, err := ui.loadVaults(ctx)
+		if err != nil {
+			return errorMsg{
+				err:     err,
+				context: "load_vaults",
+			}
+		}
+		return vaultsLoaded{
+			vaults: vaults,
+		}
+	}
+}
+
+func (ui *UIController) vaultListView() string {
+	title := titleStyle.Render("Switch Vault")
+	current := fmt.Sprintf("Current vault: %s\n\n", ui.currentVaultName())
+
+	list := ""
+	for i, v := range ui.vaults {
+		if i == ui.currentVault {
+			list += selectedStyle.Render("→ "+v.title()) + "\n"
+		} else {
+			list += menuStyle.Render("  "+v.title()) + "\n"
+		}
+	}
+
+	controls := "\nControls: ↑/↓ to navigate, Enter to switch, a to accept invite, n for new organization, m for members, Esc to go back"
+	return fmt.Sprintf("%s\n\n%s%s%s", title, current, list, controls)
+}
+
+func (ui *UIController) handleNewOrgNameInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return ui, tea.Quit
+	case "esc":
+		ui.state = stateVaultList
+		ui.input = ""
+		return ui, nil
+	case "enter":
+		if ui.input == "" {
+			return ui, nil
+		}
+		name := ui.input
+		ui.input = ""
+		ui.state = stateProcessing
+		return ui, ui.createOrgCmd(name)
+	case "backspace":
+		if len(ui.input) > 0 {
+			ui.input = ui.input[:len(ui.input)-1]
+		}
+	default:
+		if len(msg.String()) == 1 {
+			ui.input += msg.String()
+		}
+	}
+	return ui, nil
+}
+
+func (ui *UIController) createOrgCmd(name string) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		if _, err := ui.Org.CreateOrg(ctx, name); err != nil {
+			return errorMsg{
+				err:     err,
+				context: "create_org",
+			}
+		}
+		vaults, err := ui.loadVaults(ctx)
+		if err != nil {
+			return errorMsg{
+				err:     err,
+				context: "load_vaults",
+			}
+		}
+		return vaultsLoaded{
+			vaults: vaults,
+		}
+	}
+}
+
+func (ui *UIController) newOrgNameView() string {
+	title := titleStyle.Render("New Organization")
+	input := inputStyle.Render(ui.input + "█")
+	controls := "\nControls: Enter to create, Esc to cancel"
+	return fmt.Sprintf("%s\n\nName: %s\n%s", title, input, controls)
+}
+
+func (ui *UIController) loadMembersCmd() tea.Cmd {
+	orgID := ui.selectedOrg.ID
+	return func() tea.Msg {
+		members, err := ui.Org.ListMembers(context.Background(), orgID)
+		if err != nil {
+			return errorMsg{
+				err:     err,
+				context: "load_members",
+			}
+		}
+		return membersLoaded{
+			members: members,
+		}
+	}
+}
+
+func (ui *UIController) handleOrgMembersInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c", "q":
+		return ui, tea.Quit
+	case "esc", "b":
+		ui.state = stateVaultList
+		return ui, nil
+	case "up", "k":
+		if ui.currentMember > 0 {
+			ui.currentMember--
+		}
+	case "down", "j":
+		if ui.currentMember < len(ui.members)-1 {
+			ui.currentMember++
+		}
+	case "i":
+		ui.state = stateInviteMember
+		ui.input = ""
+		ui.messages.Clear("invite_error")
+	case "r":
+		if ui.currentMember < len(ui.members) {
+			ui.state = stateConfirmRemoveMember
+			ui.confirmChoice = 0
+		}
+	case "+", "-":
+		if ui.currentMember < len(ui.members) {
+			member := ui.members[ui.currentMember]
+			role, ok := shiftRole(member.Role, msg.String() == "+")
+			if !ok {
+				return ui, nil
+			}
+			ui.state = stateProcessing
+			return ui, ui.orgActionCmd(func(ctx context.Context) error {
+				return ui.Org.ChangeRole(ctx, member.OrgID, member.Login, role)
+			}, "change_role")
+		}
+	}
+	return ui, nil
+}
+
+// shiftRole returns the next more (up) or less privileged role. Nobody is
+// promoted to owner and the owner is never demoted.
+func shiftRole(role models.OrgRole, up bool) (models.OrgRole, bool) {
+	for i, r := range models.OrgRoles {
+		if r != role {
+			continue
+		}
+		if up {
+			i--
+		} else {
+			i++
+		}
+		if i < 1 || i >= len(models.OrgRoles) || role == models.OrgRoleOWNER {
+			return role, false
+		}
+		return models.OrgRoles[i], true
+	}
+	return role, false
+}
+
+// orgActionCmd runs a membership change and reloads the member list.
+func (ui *UIController) orgActionCmd(action func(ctx context.Context) error, errContext string) tea.Cmd {
+	orgID := ui.selectedOrg.ID
+	return func() tea.Msg {
+		ctx := context.Background()
+		if err := action(ctx); err != nil {
+			return errorMsg{
+				err:     err,
+				context: errContext,
+			}
+		}
+		members, err := ui.Org.ListMembers(ctx, orgID)
+		if err != nil {
+			return errorMsg{
+				err:     err,
+				context: "load_members",
+			}
+		}
+		return membersLoaded{
+			members: members,
+		}
+	}
+}
+
+func (ui *UIController) orgMembersView() string {
+	title := titleStyle.Render(fmt.Sprintf("Members: %s", ui.selectedOrg.Name))
+
+	list := ""
+	for i, m := range ui.members {
+		text := fmt.Sprintf("%s - %s", m.Login, m.Role)
+		if !m.Accepted {
+			text += " (invited)"
+		}
+		if i == ui.currentMember {
+			list += selectedStyle.Render("→ "+text) + "\n"
+		} else {
+			list += menuStyle.Render("  "+text) + "\n"
+		}
+	}
+
+	controls := "\nControls: ↑/↓ to navigate, i to invite, r to remove, +/- to change role, b/Esc to go back"
+	return fmt.Sprintf("%s\n\n%s%s", title, list, controls)
+}
+
+func (ui *UIController) handleInviteMemberInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return ui, tea.Quit
+	case "esc":
+		ui.state = stateOrgMembers
+		ui.input = ""
+		return ui, nil
+	case "enter":
+		login, role, err := parseInvite(ui.input)
+		if err != nil {
+			ui.messages.Set("invite_error", err.Error())
+			return ui, nil
+		}
+		ui.input = ""
+		ui.messages.Clear("invite_error")
+		ui.state = stateProcessing
+		return ui, ui.recipientKeyCmd(login, ui.inviteKeyUse(role))
+	case "backspace":
+		if len(ui.input) > 0 {
+			ui.input = ui.input[:len(ui.input)-1]
+		}
+	default:
+		if len(msg.String()) == 1 {
+			ui.input += msg.String()
+		}
+	}
+	return ui, nil
+}
+
+// inviteKeyUse invites a member once their key is trusted, the org key is
+// wrapped for it.
+func (ui *UIController) inviteKeyUse(role models.OrgRole) keyUse {
+	org := ui.selectedOrg
+	return keyUse{
+		verb: "invite",
+		back: stateOrgMembers,
+		fail: func(err error) tea.Msg {
+			return errorMsg{
+				err:     err,
+				context: "invite_member",
+			}
+		},
+		run: func(login, fingerprint string) tea.Cmd {
+			return ui.orgActionCmd(func(ctx context.Context) error {
+				if err := ui.trustRecipientKey(ctx, login, fingerprint); err != nil {
+					return err
+				}
+				return ui.Org.InviteMember(ctx, org, login, role)
+			}, "invite_member")
+		},
+	}
+}
+
+// parseInvite parses "login role", the role defaults to viewer.
+func parseInvite(input string) (string, models.OrgRole, error) {
+	fields := strings.Fields(input)
+	switch len(fields) {
+	case 1:
+		return fields[0], models.OrgRoleVIEWER, nil
+	case 2:
+		role := models.OrgRole(strings.ToUpper(fields[1]))
+		if !role.IsValid() || role == models.OrgRoleOWNER {
+			return "", "", fmt.Errorf("unknown role %q, use admin, editor or viewer", fields[1])
+		}
+		return fields[0], role, nil
+	}
+	return "", "", fmt.Errorf("enter login and role")
+}
+
+func (ui *UIController) inviteMemberView() string {
+	title := titleStyle.Render(fmt.Sprintf("Invite to %s", ui.selectedOrg.Name))
+	input := inputStyle.Render(ui.input + "█")
+
+	errText := ""
+	if e := ui.messages.Get("invite_error"); e != "" {
+		errText = "\n" + errorStyle.Render(e) + "\n"
+	}
+
+	controls := "\nControls: Enter to invite, Esc to cancel"
+	return fmt.Sprintf("%s\n\nLogin and role (admin, editor, viewer): %s\n%s%s", title, input, errText, controls)
+}
+
+func (ui *UIController) handleConfirmRemoveMemberInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c", "q":
+		return ui, tea.Quit
+	case "esc", "n":
+		ui.state = stateOrgMembers
+		return ui, nil
+	case "left", "h":
+		ui.confirmChoice = 0
+	case "right", "l":
+		ui.confirmChoice = 1
+	case "y":
+		ui.confirmChoice = 1
+		return ui.executeRemoveMember()
+	case "enter":
+		return ui.executeRemoveMember()
+	}
+	return ui, nil
+}
+
+func (ui *UIController) executeRemoveMember() (*UIController, tea.Cmd) {
+	if ui.confirmChoice == 0 || ui.currentMember >= len(ui.members) {
+		ui.state = stateOrgMembers
+		return ui, nil
+	}
+
+	login := ui.members[ui.currentMember].Login
+	org := ui.selectedOrg
+	ui.state = stateProcessing
+	return ui, ui.orgActionCmd(func(ctx context.Context) error {
+		if login == ui.login {
+			return ui.Org.LeaveOrg(ctx, org, login)
+		}
+		return ui.Org.RemoveMember(ctx, org, login)
+	}, "remove_member")
+}
+
+func (ui *UIController) confirmRemoveMemberView() string {
+	title := titleStyle.Render("Confirm Remove")
+	warning := fmt.Sprintf("Remove %s from %s? The organization key will be rotated.",
+		ui.members[ui.currentMember].Login, ui.selectedOrg.Name)
+	if ui.members[ui.currentMember].Login == ui.login {
+		warning = fmt.Sprintf("Leave %s? The owner or an admin will rotate the organization key.", ui.selectedOrg.Name)
+	}
+
+	options := ""
+	if ui.confirmChoice == 0 {
+		options += selectedStyle.Render("[ No ]") + "  "
+		options += menuStyle.Render("[ Yes ]")
+	} else {
+		options += menuStyle.Render("[ No ]") + "  "
+		options += selectedStyle.Render("[ Yes ]")
+	}
+
+	controls := "\nControls: ←/→ to select, Enter to confirm, y/n for quick choice, Esc to cancel"
+	return fmt.Sprintf("%s\n\n%s\n\n%s%s", title, warning, options, controls)
+}
@@ -0,0 +1,135 @@
+package ui
+
+import (
+	"gophkeeper/models"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newOrgTestUI() *UIController {
+	team := &models.Organization{ID: [16]byte{1}, Name: "team", Role: models.OrgRoleADMIN, Accepted: true}
+	invite := &models.Organization{ID: [16]byte{2}, Name: "ops", Role: models.OrgRoleVIEWER}
+	return &UIController{
+		state: stateVaultList,
+		orgCtrl: orgCtrl{
+			vaults: []vaultEntry{
+				{},
+				{org: team, coll: &models.Collection{ID: [16]byte{3}, Name: "Shared"}},
+				{org: invite},
+			},
+		},
+	}
+}
+
+func TestUIController_vaultListView(t *testing.T) {
+	ui := newOrgTestUI()
+
+	view := ui.vaultListView()
+	assert.Contains(t, view, "Current vault: Personal")
+	assert.Contains(t, view, "team / Shared (ADMIN)")
+	assert.Contains(t, view, "ops (invited as VIEWER)")
+}
+
+func TestUIController_handleVaultListInput(t *testing.T) {
+	ui := newOrgTestUI()
+
+	// Invitations must be accepted before the vault opens.
+	ui.currentVault = 2
+	_, cmd := ui.handleVaultListInput(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Nil(t, cmd)
+	assert.Equal(t, stateVaultList, ui.state)
+
+	_, cmd = ui.handleVaultListInput(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}})
+	assert.NotNil(t, cmd)
+	assert.Equal(t, stateProcessing, ui.state)
+
+	ui.state = stateVaultList
+	ui.currentVault = 1
+	_, cmd = ui.handleVaultListInput(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'m'}})
+	assert.NotNil(t, cmd)
+	assert.Equal(t, "team", ui.selectedOrg.Name)
+
+	ui.state = stateVaultList
+	_, cmd = ui.handleVaultListInput(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+	assert.Nil(t, cmd)
+	assert.Equal(t, stateNewOrgName, ui.state)
+}
+
+func TestUIController_handleOrgMembersInput_ChangeRole(t *testing.T) {
+	ui := newOrgTestUI()
+	ui.state = stateOrgMembers
+	ui.selectedOrg = ui.vaults[1].org
+	ui.members = []models.Membership{
+		{Login: "alice", Role: models.OrgRoleOWNER, Accepted: true},
+		{Login: "bob", Role: models.OrgRoleVIEWER},
+	}
+
+	// Owner role never changes.
+	_, cmd := ui.handleOrgMembersInput(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'-'}})
+	assert.Nil(t, cmd)
+	assert.Equal(t, stateOrgMembers, ui.state)
+
+	ui.currentMember = 1
+	_, cmd = ui.handleOrgMembersInput(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'+'}})
+	assert.NotNil(t, cmd)
+	assert.Equal(t, stateProcessing, ui.state)
+}
+
+func TestShiftRole(t *testing.T) {
+	tests := []struct {
+		role models.OrgRole
+		up   bool
+		want models.OrgRole
+		ok   bool
+	}{
+		{models.OrgRoleVIEWER, true, models.OrgRoleEDITOR, true},
+		{models.OrgRoleEDITOR, true, models.OrgRoleADMIN, true},
+		{models.OrgRoleADMIN, true, models.OrgRoleADMIN, false},
+		{models.OrgRoleADMIN, false, models.OrgRoleEDITOR, true},
+		{models.OrgRoleVIEWER, false, models.OrgRoleVIEWER, false},
+		{models.OrgRoleOWNER, false, models.OrgRoleOWNER, false},
+	}
+
+	for _, tt := range tests {
+		got, ok := shiftRole(tt.role, tt.up)
+		assert.Equal(t, tt.want, got)
+		assert.Equal(t, tt.ok, ok)
+	}
+}
+
+func TestUIController_inviteVerifyKey(t *testing.T) {
+	ui := newOrgTestUI()
+	ui.selectedOrg = ui.vaults[1].org
+	ui.state = stateProcessing
+
+	// An unverified invitee key is confirmed first, declining goes back to
+	// the members.
+	ui.Update(recipientKeyLoaded{recipient: "bob", fingerprint: "ef56", changed: true, use: ui.inviteKeyUse(models.OrgRoleEDITOR)})
+	assert.Equal(t, stateShareVerifyKey, ui.state)
+	view := ui.shareVerifyKeyView()
+	assert.Contains(t, view, "key of bob changed")
+	assert.Contains(t, view, "Trust and invite")
+
+	_, cmd := ui.handleShareVerifyKeyInput(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+	assert.Nil(t, cmd)
+	assert.Equal(t, stateOrgMembers, ui.state)
+}
+
+func TestParseInvite(t *testing.T) {
+	login, role, err := parseInvite("bob editor")
+	require.NoError(t, err)
+	assert.Equal(t, "bob", login)
+	assert.Equal(t, models.OrgRoleEDITOR, role)
+
+	_, role, err = parseInvite("carol")
+	require.NoError(t, err)
+	assert.Equal(t, models.OrgRoleVIEWER, role)
+
+	_, _, err = parseInvite("bob owner")
+	assert.Error(t, err)
+	_, _, err = parseInvite("")
+	assert.Error(t, err)
+}
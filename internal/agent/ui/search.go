@@ -0,0 +1,79 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"gophkeeper/internal/agent/services"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func (ui *UIController) handleSearchItems() (tea.Model, tea.Cmd) {
+	ui.state = stateSearchItems
+	ui.input = ui.searchQuery
+	ui.messages.Clear("search_error")
+	return ui, nil
+}
+
+func (ui *UIController) searchItemsCmd(query string) tea.Cmd {
+	return func() tea.Msg {
+		items, err := ui.Item.SearchItems(context.Background(), ui.login, query)
+		failed, err := services.SplitItemErrors(err)
+		if err != nil {
+			return errorMsg{
+				err:     err,
+				context: "search_items",
+			}
+		}
+
+		return itemsLoaded{
+			items:  items,
+			failed: failed,
+			query:  query,
+		}
+	}
+}
+
+func (ui *UIController) handleSearchInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return ui, tea.Quit
+	case "esc":
+		ui.input = ""
+		ui.state = stateItemsList
+		return ui, nil
+	case "enter":
+		query := strings.TrimSpace(ui.input)
+		if query == "" {
+			ui.messages.Set("search_error", "Enter at least one word")
+			return ui, nil
+		}
+		ui.input = ""
+		ui.state = stateProcessing
+		return ui, ui.searchItemsCmd(query)
+	case "backspace":
+		if len(ui.input) > 0 {
+			ui.input = ui.input[:len(ui.input)-1]
+		}
+	default:
+		if len(msg.String()) == 1 {
+			ui.input += msg.String()
+		}
+	}
+	return ui, nil
+}
+
+func (ui *UIController) searchItemsView() string {
+	title := titleStyle.Render("Search Items")
+	input := inputStyle.Render(ui.input + "█")
+
+	errText := ""
+	if e := ui.messages.Get("search_error"); e != "" {
+		errText = "\n" + errorStyle.Render(e) + "\n"
+	}
+
+	help := "Items match when every word is in the name, tags or URL host."
+	controls := "\nControls: Enter to search, Esc to cancel"
+	return fmt.Sprintf("%s\n\n%s\n\nSearch: %s\n%s%s", title, help, input, errText, controls)
+}
@@ -0,0 +1,49 @@
+package ui
+
+import (
+	"gophkeeper/models"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUIController_handleSearchInput(t *testing.T) {
+	ui := &UIController{state: stateItemsList}
+	ui.messages.init()
+
+	ui.handleItemsListInput(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'/'}})
+	assert.Equal(t, stateSearchItems, ui.state)
+
+	_, cmd := ui.handleSearchInput(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Nil(t, cmd)
+	assert.Contains(t, ui.searchItemsView(), "Enter at least one word")
+
+	for _, r := range "bank" {
+		ui.handleSearchInput(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	_, cmd = ui.handleSearchInput(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.NotNil(t, cmd)
+	assert.Equal(t, stateProcessing, ui.state)
+	assert.Empty(t, ui.input)
+
+	ui.state = stateSearchItems
+	ui.handleSearchInput(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.Equal(t, stateItemsList, ui.state)
+}
+
+func TestUIController_itemsListView_SearchResults(t *testing.T) {
+	ui := &UIController{state: stateProcessing}
+	ui.messages.init()
+
+	ui.Update(itemsLoaded{
+		items: []models.EncryptedItem{{Name: "Bank", Type: models.ItemTypeTEXT}},
+		query: "bank",
+	})
+	assert.Equal(t, stateItemsList, ui.state)
+	assert.Contains(t, ui.itemsListView(), "Search: bank")
+	assert.Contains(t, ui.itemsListView(), "Bank (TEXT)")
+
+	ui.Update(itemsLoaded{})
+	assert.Contains(t, ui.itemsListView(), "Your Items")
+}
@@ -0,0 +1,109 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"gophkeeper/internal/agent/services"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const defaultSendOptions = "1 24h"
+
+// parseSendOptions reads "<views> <ttl>", the ttl in any form parseExpiry
+// accepts as a duration.
+func parseSendOptions(input string, now time.Time) (int32, time.Duration, error) {
+	fields := strings.Fields(input)
+	if len(fields) != 2 {
+		return 0, 0, fmt.Errorf("enter max views and lifetime, e.g. %s", defaultSendOptions)
+	}
+
+	views, err := strconv.ParseInt(fields[0], 10, 32)
+	if err != nil || views < 1 {
+		return 0, 0, fmt.Errorf("max views must be a positive number")
+	}
+
+	expiresAt, err := parseExpiry(fields[1], now)
+	if err != nil {
+		return 0, 0, err
+	}
+	return int32(views), expiresAt.Sub(now), nil
+}
+
+func (ui *UIController) startSendItem() (*UIController, tea.Cmd) {
+	ui.state = stateSendOptions
+	ui.input = defaultSendOptions
+	ui.messages.Clear("error")
+	return ui, nil
+}
+
+func (ui *UIController) handleSendOptionsInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return ui, tea.Quit
+	case "esc":
+		ui.state = stateItemDetails
+		ui.input = ""
+		ui.messages.Clear("error")
+		return ui, nil
+	case "enter":
+		views, ttl, err := parseSendOptions(ui.input, time.Now())
+		if err != nil {
+			ui.messages.Set("error", err.Error())
+			return ui, nil
+		}
+		ui.messages.Clear("error")
+		ui.input = ""
+		ui.state = stateProcessing
+		return ui, ui.createSendCmd(views, ttl)
+	case "backspace":
+		if len(ui.input) > 0 {
+			ui.input = ui.input[:len(ui.input)-1]
+		}
+	default:
+		if len(msg.String()) == 1 {
+			ui.input += msg.String()
+		}
+	}
+	return ui, nil
+}
+
+func (ui *UIController) createSendCmd(views int32, ttl time.Duration) tea.Cmd {
+	item := ui.decryptedItem
+	payload := services.SendPayload{
+		Name:    item.Name,
+		Content: itemDataView(item),
+	}
+	return func() tea.Msg {
+		link, err := ui.Item.CreateSend(context.Background(), payload, views, ttl)
+		if err != nil {
+			return processComplete{
+				success: false,
+				message: fmt.Sprintf("Create one-time link error: %v", err),
+				context: "create_send",
+			}
+		}
+		return processComplete{
+			success: true,
+			message: fmt.Sprintf("One-time link (shown once, the key is in the part after #):\n\n%s\n\nOpen with: gophkeeper receive '<link>'", link),
+			context: "create_send",
+		}
+	}
+}
+
+func (ui *UIController) sendOptionsView() string {
+	title := titleStyle.Render(fmt.Sprintf("One-time Link: %s", ui.decryptedItem.Name))
+	input := inputStyle.Render(ui.input + "█")
+	help := "Max views and lifetime, e.g. 1 24h or 3 7d"
+
+	errMsg := ""
+	if msg := ui.messages.Get("error"); msg != "" {
+		errMsg = "\n" + errorStyle.Render(msg) + "\n"
+	}
+
+	controls := "\nControls: Enter to create, Esc to cancel"
+	return fmt.Sprintf("%s\n\n%s\n%s\n%s%s", title, help, input, errMsg, controls)
+}
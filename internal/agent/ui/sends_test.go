@@ -0,0 +1,75 @@
+package ui
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSendOptions(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.Local)
+
+	views, ttl, err := parseSendOptions("1 24h", now)
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), views)
+	assert.Equal(t, 24*time.Hour, ttl)
+
+	views, ttl, err = parseSendOptions(" 3  7d ", now)
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), views)
+	assert.Equal(t, 7*24*time.Hour, ttl)
+
+	for _, input := range []string{"", "1", "0 1h", "x 1h", "1 -1h", "1 soon"} {
+		_, _, err := parseSendOptions(input, now)
+		assert.Error(t, err, input)
+	}
+}
+
+func TestUIController_handleItemDetailsInput_Send(t *testing.T) {
+	ui := newShareTestUI()
+	ui.messages.init()
+
+	_, cmd := ui.handleItemDetailsInput(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'o'}})
+	assert.Nil(t, cmd)
+	assert.Equal(t, stateSendOptions, ui.state)
+	assert.Equal(t, defaultSendOptions, ui.input)
+	assert.Contains(t, ui.sendOptionsView(), "One-time Link: note")
+}
+
+func TestUIController_handleSendOptionsInput(t *testing.T) {
+	ui := newShareTestUI()
+	ui.messages.init()
+	ui.state = stateSendOptions
+	ui.input = "0 1h"
+
+	_, cmd := ui.handleSendOptionsInput(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Nil(t, cmd)
+	assert.Equal(t, stateSendOptions, ui.state)
+	assert.Contains(t, ui.sendOptionsView(), "max views must be a positive number")
+
+	ui.input = "2 1h"
+	_, cmd = ui.handleSendOptionsInput(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.NotNil(t, cmd)
+	assert.Equal(t, stateProcessing, ui.state)
+	assert.Empty(t, ui.messages.Get("error"))
+
+	ui.state = stateSendOptions
+	_, cmd = ui.handleSendOptionsInput(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.Nil(t, cmd)
+	assert.Equal(t, stateItemDetails, ui.state)
+}
+
+func TestUIController_handleProcessComplete_CreateSend(t *testing.T) {
+	ui := newShareTestUI()
+	ui.messages.init()
+
+	ui.handleProcessComplete(processComplete{success: true, message: "link", context: "create_send"})
+	assert.Equal(t, stateShareSuccess, ui.state)
+	assert.Contains(t, ui.shareSuccessView(), "link")
+
+	ui.handleProcessComplete(processComplete{success: false, message: "boom", context: "create_send"})
+	assert.Equal(t, stateShareError, ui.state)
+}
@@ -0,0 +1,131 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type sessionResumed struct {
+	login string
+}
+
+// canRememberDevice reports whether the sign in can be saved for the next
+// start.
+func (ui *UIController) canRememberDevice() bool {
+	return ui.User != nil && ui.User.CanRememberSession()
+}
+
+// remembersDevice reports whether a session is saved on this device.
+func (ui *UIController) remembersDevice() bool {
+	return ui.User != nil && ui.User.HasSavedSession()
+}
+
+// resumeSession starts from the saved session, a keyfile opens it right
+// away.
+func (ui *UIController) resumeSession() tea.Cmd {
+	if !ui.remembersDevice() {
+		return nil
+	}
+	if ui.User.SessionNeedsPIN() {
+		ui.state = stateUnlockSession
+		return nil
+	}
+	ui.state = stateProcessing
+	return ui.resumeSessionCmd("")
+}
+
+func (ui *UIController) resumeSessionCmd(pin string) tea.Cmd {
+	return func() tea.Msg {
+		login, err := ui.User.ResumeSession(context.Background(), pin)
+		if err != nil {
+			return processComplete{
+				success: false,
+				message: fmt.Sprintf("Saved session: %v", err),
+				context: "session",
+			}
+		}
+		if ui.Offline != nil {
+			ui.Offline.Resume(login)
+		}
+		return sessionResumed{login: login}
+	}
+}
+
+func (ui *UIController) rememberDeviceCmd(pin string) tea.Cmd {
+	return func() tea.Msg {
+		if err := ui.User.RememberSession(pin); err != nil {
+			return processComplete{
+				success: false,
+				message: fmt.Sprintf("Remember device: %v", err),
+				context: "remember_device",
+			}
+		}
+		return processComplete{
+			success: true,
+			message: "Device remembered",
+			context: "remember_device",
+		}
+	}
+}
+
+// handleSessionPINInput takes the PIN of the saved session, to open it or
+// to save a new one.
+func (ui *UIController) handleSessionPINInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return ui, tea.Quit
+	case "esc":
+		ui.input = ""
+		if ui.state == stateRememberDevice {
+			ui.state = stateMasterPassword
+		} else {
+			ui.state = stateMenuLoggedOut
+			ui.messages.Clear("session_error")
+		}
+		return ui, nil
+	case "enter":
+		pin := strings.TrimSpace(ui.input)
+		ui.input = ""
+		if ui.state == stateRememberDevice {
+			if pin == "" {
+				ui.state = stateMasterPassword
+				return ui, nil
+			}
+			ui.state = stateProcessing
+			return ui, ui.rememberDeviceCmd(pin)
+		}
+		ui.state = stateProcessing
+		return ui, ui.resumeSessionCmd(pin)
+	case "backspace":
+		if len(ui.input) > 0 {
+			ui.input = ui.input[:len(ui.input)-1]
+		}
+	default:
+		if len(msg.String()) == 1 {
+			ui.input += msg.String()
+		}
+	}
+	return ui, nil
+}
+
+func (ui *UIController) sessionPINView() string {
+	title := titleStyle.Render("Saved Session")
+	info := "\nEnter your PIN to continue without signing in:"
+	controls := "\nControls: Esc to sign in instead, Enter to continue"
+	if ui.state == stateRememberDevice {
+		title = titleStyle.Render("Remember This Device")
+		info = "\nChoose a PIN to skip sign in on the next start, or leave it empty to skip." +
+			"\nThe PIN only keeps out casual access to this device:"
+		controls = "\nControls: Esc to skip, Enter to continue"
+	}
+	if errMsg := ui.messages.Get("session_error"); errMsg != "" {
+		info = "\n" + errorStyle.Render(errMsg) + info
+	}
+
+	hiddenPIN := strings.Repeat("*", len(ui.input))
+	input := inputStyle.Render(hiddenPIN + "█")
+	return fmt.Sprintf("%s%s\n\nPIN: %s%s", title, info, input, controls)
+}
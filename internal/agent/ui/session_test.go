@@ -0,0 +1,73 @@
+package ui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUIController_handleSessionPINInput_Remember(t *testing.T) {
+	ui := &UIController{state: stateRememberDevice}
+
+	assert.Contains(t, ui.sessionPINView(), "Remember This Device")
+
+	// An empty PIN skips remembering.
+	_, cmd := ui.handleSessionPINInput(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Nil(t, cmd)
+	assert.Equal(t, stateMasterPassword, ui.state)
+
+	ui.state = stateRememberDevice
+	for _, r := range "1234" {
+		ui.handleSessionPINInput(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	assert.Contains(t, ui.sessionPINView(), "****")
+	_, cmd = ui.handleSessionPINInput(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.NotNil(t, cmd)
+	assert.Equal(t, stateProcessing, ui.state)
+	assert.Empty(t, ui.input)
+
+	ui.state = stateRememberDevice
+	ui.handleSessionPINInput(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.Equal(t, stateMasterPassword, ui.state)
+}
+
+func TestUIController_handleSessionPINInput_Unlock(t *testing.T) {
+	ui := &UIController{state: stateUnlockSession}
+
+	assert.Contains(t, ui.sessionPINView(), "without signing in")
+
+	ui.handleSessionPINInput(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'7'}})
+	_, cmd := ui.handleSessionPINInput(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.NotNil(t, cmd)
+	assert.Equal(t, stateProcessing, ui.state)
+
+	ui.state = stateUnlockSession
+	ui.handleSessionPINInput(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.Equal(t, stateMenuLoggedOut, ui.state)
+}
+
+func TestUIController_Update_SessionResumed(t *testing.T) {
+	ui := &UIController{state: stateProcessing}
+
+	ui.Update(sessionResumed{login: "alice"})
+	assert.Equal(t, stateMasterPassword, ui.state)
+	assert.Equal(t, "alice", ui.login)
+	assert.True(t, ui.isAuthenticated)
+}
+
+func TestUIController_Update_SessionFailed(t *testing.T) {
+	ui := &UIController{state: stateProcessing}
+
+	ui.Update(processComplete{success: false, message: "Saved session: saved session has expired", context: "session"})
+	assert.Equal(t, stateMenuLoggedOut, ui.state)
+	assert.Contains(t, ui.menuLoggedOutView(), "saved session has expired")
+}
+
+func TestUIController_Init_NoSession(t *testing.T) {
+	ui := &UIController{state: stateMenuLoggedOut}
+
+	assert.Nil(t, ui.Init())
+	assert.Equal(t, stateMenuLoggedOut, ui.state)
+	assert.NotContains(t, ui.deviceListView(), "forget this device")
+}
@@ -0,0 +1,518 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"gophkeeper/internal/agent/services"
+	"gophkeeper/internal/errs"
+	"gophkeeper/internal/logger"
+	"gophkeeper/models"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"go.uber.org/zap"
+)
+
+type sharesLoaded struct {
+	shares []models.ItemShare
+}
+
+type sharedItemsLoaded struct {
+	items       []models.SharedItem
+	failed      services.ItemErrors
+	fingerprint string
+}
+
+type recipientKeyLoaded struct {
+	recipient   string
+	fingerprint string
+	verified    bool
+	changed     bool
+	use         keyUse
+}
+
+// keyUse is what waits for the key of a recipient to be trusted: sharing an
+// item, granting emergency access or inviting an org member. run gets the
+// fingerprint the user confirmed, empty for a key that is already pinned.
+type keyUse struct {
+	verb string
+	back state
+	fail func(err error) tea.Msg
+	run  func(recipient, fingerprint string) tea.Cmd
+}
+
+type sharedItemDecrypted struct {
+	item *models.Item
+}
+
+func (ui *UIController) startShareItem() (*UIController, tea.Cmd) {
+	ui.state = stateShareRecipient
+	ui.input = ""
+	ui.shareSuccessMsg = ""
+	ui.shareErrorMsg = ""
+	return ui, nil
+}
+
+func (ui *UIController) handleShareRecipientInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return ui, tea.Quit
+	case "esc":
+		ui.state = stateItemDetails
+		ui.input = ""
+		return ui, nil
+	case "enter":
+		if ui.input == "" {
+			return ui, nil
+		}
+		recipient := ui.input
+		ui.input = ""
+		ui.state = stateProcessing
+		return ui, ui.recipientKeyCmd(recipient, ui.shareKeyUse())
+	case "backspace":
+		if len(ui.input) > 0 {
+			ui.input = ui.input[:len(ui.input)-1]
+		}
+	default:
+		if len(msg.String()) == 1 {
+			ui.input += msg.String()
+		}
+	}
+	return ui, nil
+}
+
+// shareKeyUse shares the decrypted item once the key of the recipient is
+// trusted.
+func (ui *UIController) shareKeyUse() keyUse {
+	return keyUse{
+		verb: "share",
+		back: stateItemDetails,
+		fail: func(err error) tea.Msg {
+			return processComplete{
+				success: false,
+				message: fmt.Sprintf("Share item error: %v", err),
+				context: "share_item",
+			}
+		},
+		run: ui.shareItemCmd,
+	}
+}
+
+// recipientKeyCmd looks up the key of recipient, a key that is not the
+// verified one is shown for confirmation before use runs.
+func (ui *UIController) recipientKeyCmd(recipient string, use keyUse) tea.Cmd {
+	return func() tea.Msg {
+		fingerprint, err := ui.Item.RecipientKey(context.Background(), recipient)
+		changed := errors.Is(err, errs.ErrKeyChanged)
+		if err != nil && !changed && !errors.Is(err, errs.ErrKeyNotVerified) {
+			return use.fail(err)
+		}
+		return recipientKeyLoaded{
+			recipient:   recipient,
+			fingerprint: fingerprint,
+			verified:    err == nil,
+			changed:     changed,
+			use:         use,
+		}
+	}
+}
+
+func (ui *UIController) handleRecipientKeyLoaded(msg recipientKeyLoaded) (tea.Model, tea.Cmd) {
+	if msg.verified {
+		return ui, msg.use.run(msg.recipient, "")
+	}
+	ui.shareRecipient = msg.recipient
+	ui.shareFingerprint = msg.fingerprint
+	ui.shareKeyChanged = msg.changed
+	ui.pendingKeyUse = msg.use
+	ui.confirmChoice = 0
+	ui.state = stateShareVerifyKey
+	return ui, nil
+}
+
+func (ui *UIController) handleShareVerifyKeyInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c", "q":
+		return ui, tea.Quit
+	case "esc", "n":
+		ui.shareRecipient = ""
+		ui.state = ui.pendingKeyUse.back
+		return ui, nil
+	case "left", "h":
+		ui.confirmChoice = 0
+	case "right", "l":
+		ui.confirmChoice = 1
+	case "y":
+		ui.confirmChoice = 1
+		return ui.executeShareVerifyKey()
+	case "enter":
+		return ui.executeShareVerifyKey()
+	}
+	return ui, nil
+}
+
+func (ui *UIController) executeShareVerifyKey() (tea.Model, tea.Cmd) {
+	recipient := ui.shareRecipient
+	ui.shareRecipient = ""
+	if ui.confirmChoice == 0 {
+		ui.state = ui.pendingKeyUse.back
+		return ui, nil
+	}
+	ui.state = stateProcessing
+	return ui, ui.pendingKeyUse.run(recipient, ui.shareFingerprint)
+}
+
+// trustRecipientKey pins the key of recipient when the user confirmed
+// fingerprint, an empty one means the key is pinned already.
+func (ui *UIController) trustRecipientKey(ctx context.Context, recipient, fingerprint string) error {
+	if fingerprint == "" {
+		return nil
+	}
+	return ui.Item.VerifyRecipientKey(ctx, recipient, fingerprint)
+}
+
+// shareItemCmd shares the item with recipient, pinning its key first when
+// the user confirmed fingerprint.
+func (ui *UIController) shareItemCmd(recipient, fingerprint string) tea.Cmd {
+	item := ui.decryptedItem
+	return func() tea.Msg {
+		ctx := context.Background()
+		if err := ui.trustRecipientKey(ctx, recipient, fingerprint); err != nil {
+			return processComplete{
+				success: false,
+				message: fmt.Sprintf("Share item error: %v", err),
+				context: "share_item",
+			}
+		}
+		if err := ui.Item.ShareItem(ctx, item, recipient); err != nil {
+			return processComplete{
+				success: false,
+				message: fmt.Sprintf("Share item error: %v", err),
+				context: "share_item",
+			}
+		}
+		return processComplete{
+			success: true,
+			message: fmt.Sprintf("Item shared with %s", recipient),
+			context: "share_item",
+		}
+	}
+}
+
+func (ui *UIController) shareRecipientView() string {
+	title := titleStyle.Render(fmt.Sprintf("Share Item: %s", ui.decryptedItem.Name))
+	input := inputStyle.Render(ui.input + "█")
+	controls := "\nControls: Enter to share, Esc to cancel"
+	return fmt.Sprintf("%s\n\nRecipient login: %s\n%s", title, input, controls)
+}
+
+func (ui *UIController) shareVerifyKeyView() string {
+	title := titleStyle.Render("Verify Recipient Key")
+	verb := ui.pendingKeyUse.verb
+	info := fmt.Sprintf("The key of %s is not verified yet. Ask them for the key fingerprint shown under\n"+
+		"Shared With Me and %s only if it matches:", ui.shareRecipient, verb)
+	if ui.shareKeyChanged {
+		info = errorStyle.Render(fmt.Sprintf("The key of %s changed since you verified it!", ui.shareRecipient)) +
+			fmt.Sprintf("\nSomeone may be trying to read your secrets. Go on to %s only if they replaced their keys\n"+
+				"and their fingerprint matches:", verb)
+	}
+
+	trust := fmt.Sprintf("[ Trust and %s ]", verb)
+	options := ""
+	if ui.confirmChoice == 0 {
+		options += selectedStyle.Render("[ No ]") + "  "
+		options += menuStyle.Render(trust)
+	} else {
+		options += menuStyle.Render("[ No ]") + "  "
+		options += selectedStyle.Render(trust)
+	}
+
+	controls := "\nControls: ←/→ to select, Enter to confirm, y/n for quick choice, Esc to cancel"
+	return fmt.Sprintf("%s\n\n%s\n\n  %s\n\n%s%s", title, info, ui.shareFingerprint, options, controls)
+}
+
+func (ui *UIController) handleViewItemShares() (*UIController, tea.Cmd) {
+	ui.state = stateProcessing
+	ui.currentShare = 0
+	return ui, ui.loadItemSharesCmd()
+}
+
+func (ui *UIController) loadItemSharesCmd() tea.Cmd {
+	itemID := ui.decryptedItem.ID
+	return func() tea.Msg {
+		shares, err := ui.Item.ListItemShares(context.Background(), itemID)
+		if err != nil {
+			return errorMsg{
+				err:     err,
+				context: "load_item_shares",
+			}
+		}
+		return sharesLoaded{
+			shares: shares,
+		}
+	}
+}
+
+func (ui *UIController) handleItemSharesInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c", "q":
+		return ui, tea.Quit
+	case "esc", "b":
+		ui.state = stateItemDetails
+		return ui, nil
+	case "up", "k":
+		if ui.currentShare > 0 {
+			ui.currentShare--
+		}
+	case "down", "j":
+		if ui.currentShare < len(ui.shares)-1 {
+			ui.currentShare++
+		}
+	case "r":
+		if len(ui.shares) > 0 {
+			ui.state = stateConfirmRevokeShare
+			ui.confirmChoice = 0
+		}
+	}
+	return ui, nil
+}
+
+func (ui *UIController) itemSharesView() string {
+	title := titleStyle.Render(fmt.Sprintf("Shares: %s", ui.decryptedItem.Name))
+
+	if len(ui.shares) == 0 {
+		return fmt.Sprintf("%s\n\nItem is not shared.\n\nControls: b/Esc to go back", title)
+	}
+
+	list := ""
+	for i, share := range ui.shares {
+		text := fmt.Sprintf("%s (since %s)", share.RecipientLogin, share.CreatedAt.Format("2006-01-02"))
+		if i == ui.currentShare {
+			list += selectedStyle.Render("→ "+text) + "\n"
+		} else {
+			list += menuStyle.Render("  "+text) + "\n"
+		}
+	}
+
+	controls := "\nControls: ↑/↓ to navigate, r to revoke, b/Esc to go back"
+	return fmt.Sprintf("%s\n\n%s%s", title, list, controls)
+}
+
+func (ui *UIController) handleConfirmRevokeShareInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c", "q":
+		return ui, tea.Quit
+	case "esc", "n":
+		ui.state = stateItemShares
+		return ui, nil
+	case "left", "h":
+		ui.confirmChoice = 0
+	case "right", "l":
+		ui.confirmChoice = 1
+	case "y":
+		ui.confirmChoice = 1
+		return ui.executeRevokeShare()
+	case "enter":
+		return ui.executeRevokeShare()
+	}
+	return ui, nil
+}
+
+func (ui *UIController) executeRevokeShare() (*UIController, tea.Cmd) {
+	if ui.confirmChoice == 0 || ui.currentShare >= len(ui.shares) {
+		ui.state = stateItemShares
+		return ui, nil
+	}
+
+	recipient := ui.shares[ui.currentShare].RecipientLogin
+	item := ui.decryptedItem
+	ui.state = stateProcessing
+	return ui, func() tea.Msg {
+		if err := ui.Item.RevokeShare(context.Background(), item, recipient); err != nil {
+			return processComplete{
+				success: false,
+				message: fmt.Sprintf("Revoke share error: %v", err),
+				context: "revoke_share",
+			}
+		}
+		return processComplete{
+			success: true,
+			message: fmt.Sprintf("Access revoked for %s", recipient),
+			context: "revoke_share",
+		}
+	}
+}
+
+func (ui *UIController) confirmRevokeShareView() string {
+	title := titleStyle.Render("Confirm Revoke")
+	warning := fmt.Sprintf("Revoke access for %s? The item will be re-encrypted with a new key.",
+		ui.shares[ui.currentShare].RecipientLogin)
+
+	options := ""
+	if ui.confirmChoice == 0 {
+		options += selectedStyle.Render("[ No ]") + "  "
+		options += menuStyle.Render("[ Yes ]")
+	} else {
+		options += menuStyle.Render("[ No ]") + "  "
+		options += selectedStyle.Render("[ Yes ]")
+	}
+
+	controls := "\nControls: ←/→ to select, Enter to confirm, y/n for quick choice, Esc to cancel"
+	return fmt.Sprintf("%s\n\n%s\n\n%s%s", title, warning, options, controls)
+}
+
+func (ui *UIController) handleShareResultInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c", "q":
+		return ui, tea.Quit
+	case "enter", "esc":
+		ui.shareSuccessMsg = ""
+		ui.shareErrorMsg = ""
+		ui.state = stateItemDetails
+		return ui, nil
+	}
+	return ui, nil
+}
+
+func (ui *UIController) shareSuccessView() string {
+	title := successStyle.Render("Success!")
+	return fmt.Sprintf("%s\n\n%s\n\nPress Enter to continue", title, ui.shareSuccessMsg)
+}
+
+func (ui *UIController) shareErrorView() string {
+	title := errorStyle.Render("Error!")
+	return fmt.Sprintf("%s\n\n%s\n\nPress Enter to continue", title, ui.shareErrorMsg)
+}
+
+func (ui *UIController) handleViewSharedWithMe() (tea.Model, tea.Cmd) {
+	ui.state = stateProcessing
+	ui.currentShared = 0
+	return ui, ui.loadSharedWithMeCmd()
+}
+
+func (ui *UIController) loadSharedWithMeCmd() tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		items, err := ui.Item.ListSharedWithMe(ctx)
+		failed, err := services.SplitItemErrors(err)
+		if err != nil {
+			return errorMsg{
+				err:     err,
+				context: "load_shared_items",
+			}
+		}
+		fingerprint, err := ui.Item.OwnKeyFingerprint(ctx)
+		if err != nil {
+			logger.Log.Warn("get own key fingerprint error", zap.Error(err))
+		}
+		return sharedItemsLoaded{
+			items:       items,
+			failed:      failed,
+			fingerprint: fingerprint,
+		}
+	}
+}
+
+func (ui *UIController) handleSharedWithMeInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c", "q":
+		return ui, tea.Quit
+	case "esc":
+		ui.state = stateMenuLoggedIn
+		return ui, nil
+	case "up", "k":
+		if ui.currentShared > 0 {
+			ui.currentShared--
+		}
+	case "down", "j":
+		if ui.currentShared < len(ui.sharedItems)-1 {
+			ui.currentShared++
+		}
+	case "enter":
+		if ui.currentShared < len(ui.sharedItems) {
+			ui.sharedItem = nil
+			ui.state = stateSharedItemDetails
+			return ui, ui.decryptSharedItemCmd(&ui.sharedItems[ui.currentShared])
+		}
+	case "r":
+		return ui.handleViewSharedWithMe()
+	}
+	return ui, nil
+}
+
+func (ui *UIController) decryptSharedItemCmd(shared *models.SharedItem) tea.Cmd {
+	return func() tea.Msg {
+		item, err := ui.Item.DecryptSharedItem(context.Background(), shared)
+		if err != nil {
+			return errorMsg{
+				err:     err,
+				context: "decrypt_shared_item",
+			}
+		}
+		return sharedItemDecrypted{
+			item: item,
+		}
+	}
+}
+
+func (ui *UIController) sharedWithMeView() string {
+	title := titleStyle.Render("Shared With Me")
+
+	if ui.ownFingerprint != "" {
+		title += fmt.Sprintf("\n\nYour key fingerprint: %s", ui.ownFingerprint)
+	}
+
+	if len(ui.sharedItems) == 0 {
+		return fmt.Sprintf("%s\n\nNothing is shared with you.\n\nControls: r to refresh, Esc to go back", title)
+	}
+
+	list := ""
+	for i, shared := range ui.sharedItems {
+		text := fmt.Sprintf("%s (%s) from %s", shared.Item.Name, shared.Item.Type, shared.Item.UserLogin)
+		if _, ok := ui.failedItems[shared.Item.ID]; ok {
+			text += " ⚠ cannot decrypt"
+		}
+		if i == ui.currentShared {
+			list += selectedStyle.Render("→ "+text) + "\n"
+		} else {
+			list += menuStyle.Render("  "+text) + "\n"
+		}
+	}
+
+	controls := "\nControls: ↑/↓ to navigate, Enter to view details, r to refresh, Esc to go back"
+	return fmt.Sprintf("%s\n\n%s%s", title, list, controls)
+}
+
+func (ui *UIController) handleSharedItemDetailsInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c", "q":
+		return ui, tea.Quit
+	case "esc", "b":
+		ui.sharedItem = nil
+		ui.state = stateSharedWithMe
+		return ui, nil
+	}
+	return ui, nil
+}
+
+func (ui *UIController) sharedItemDetailsView() string {
+	if ui.currentShared >= len(ui.sharedItems) {
+		return "No item selected"
+	}
+
+	shared := &ui.sharedItems[ui.currentShared]
+	title := titleStyle.Render(fmt.Sprintf("Shared Item: %s", shared.Item.Name))
+
+	details := fmt.Sprintf("Owner: %s\n", shared.Item.UserLogin)
+	details += fmt.Sprintf("Type: %s\n", shared.Item.Type)
+	details += fmt.Sprintf("Updated: %s\n\n", shared.Item.UpdatedAt.Format("2006-01-02 15:04:05"))
+
+	if ui.sharedItem != nil {
+		details += itemDataView(ui.sharedItem)
+	} else {
+		details += "Loading data...\n"
+	}
+
+	controls := "\nControls: b/Esc to go back"
+	return fmt.Sprintf("%s\n\n%s%s", title, details, controls)
+}
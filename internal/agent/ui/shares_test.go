@@ -0,0 +1,159 @@
+package ui
+
+import (
+	"gophkeeper/models"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+)
+
+func newShareTestUI() *UIController {
+	return &UIController{
+		state: stateItemDetails,
+		itemCtrl: itemCtrl{
+			decryptedItem: &models.Item{
+				ID:   [16]byte{1},
+				Name: "note",
+				Type: models.ItemTypeTEXT,
+				Data: &models.Text{Content: "secret"},
+			},
+		},
+	}
+}
+
+func TestUIController_handleItemDetailsInput_Share(t *testing.T) {
+	ui := newShareTestUI()
+
+	model, cmd := ui.handleItemDetailsInput(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}})
+
+	assert.Equal(t, ui, model)
+	assert.Nil(t, cmd)
+	assert.Equal(t, stateShareRecipient, ui.state)
+}
+
+func TestUIController_handleShareRecipientInput(t *testing.T) {
+	ui := newShareTestUI()
+	ui.state = stateShareRecipient
+
+	for _, r := range "bob" {
+		ui.handleShareRecipientInput(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	assert.Equal(t, "bob", ui.input)
+	assert.Contains(t, ui.shareRecipientView(), "bob")
+
+	_, cmd := ui.handleShareRecipientInput(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.NotNil(t, cmd)
+	assert.Equal(t, stateProcessing, ui.state)
+	assert.Empty(t, ui.input)
+}
+
+func TestUIController_handleShareRecipientInput_Esc(t *testing.T) {
+	ui := newShareTestUI()
+	ui.state = stateShareRecipient
+	ui.input = "bo"
+
+	_, cmd := ui.handleShareRecipientInput(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.Nil(t, cmd)
+	assert.Equal(t, stateItemDetails, ui.state)
+	assert.Empty(t, ui.input)
+}
+
+func TestUIController_Update_RecipientKeyLoaded(t *testing.T) {
+	ui := newShareTestUI()
+	ui.state = stateProcessing
+
+	// A verified key shares right away.
+	use := ui.shareKeyUse()
+	_, cmd := ui.Update(recipientKeyLoaded{recipient: "bob", fingerprint: "ab12", verified: true, use: use})
+	assert.NotNil(t, cmd)
+	assert.Equal(t, stateProcessing, ui.state)
+
+	ui.Update(recipientKeyLoaded{recipient: "bob", fingerprint: "ab12 cd34", use: use})
+	assert.Equal(t, stateShareVerifyKey, ui.state)
+	view := ui.shareVerifyKeyView()
+	assert.Contains(t, view, "key of bob is not verified")
+	assert.Contains(t, view, "Trust and share")
+	assert.Contains(t, view, "ab12 cd34")
+
+	_, cmd = ui.handleShareVerifyKeyInput(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Nil(t, cmd)
+	assert.Equal(t, stateItemDetails, ui.state)
+
+	ui.Update(recipientKeyLoaded{recipient: "bob", fingerprint: "ef56", changed: true, use: use})
+	assert.Contains(t, ui.shareVerifyKeyView(), "key of bob changed")
+	_, cmd = ui.handleShareVerifyKeyInput(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	assert.NotNil(t, cmd)
+	assert.Equal(t, stateProcessing, ui.state)
+	assert.Empty(t, ui.shareRecipient)
+}
+
+func TestUIController_itemSharesView(t *testing.T) {
+	ui := newShareTestUI()
+	assert.Contains(t, ui.itemSharesView(), "Item is not shared")
+
+	ui.shares = []models.ItemShare{
+		{RecipientLogin: "bob", CreatedAt: time.Now()},
+		{RecipientLogin: "carol", CreatedAt: time.Now()},
+	}
+	view := ui.itemSharesView()
+	assert.Contains(t, view, "bob")
+	assert.Contains(t, view, "carol")
+}
+
+func TestUIController_handleItemSharesInput_Revoke(t *testing.T) {
+	ui := newShareTestUI()
+	ui.state = stateItemShares
+	ui.shares = []models.ItemShare{{RecipientLogin: "bob"}, {RecipientLogin: "carol"}}
+
+	ui.handleItemSharesInput(tea.KeyMsg{Type: tea.KeyDown})
+	assert.Equal(t, 1, ui.currentShare)
+
+	ui.handleItemSharesInput(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r'}})
+	assert.Equal(t, stateConfirmRevokeShare, ui.state)
+	assert.Contains(t, ui.confirmRevokeShareView(), "carol")
+
+	_, cmd := ui.handleConfirmRevokeShareInput(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+	assert.Nil(t, cmd)
+	assert.Equal(t, stateItemShares, ui.state)
+
+	ui.state = stateConfirmRevokeShare
+	_, cmd = ui.handleConfirmRevokeShareInput(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	assert.NotNil(t, cmd)
+	assert.Equal(t, stateProcessing, ui.state)
+}
+
+func TestUIController_handleProcessComplete_Share(t *testing.T) {
+	ui := newShareTestUI()
+
+	ui.handleProcessComplete(processComplete{success: true, message: "shared", context: "share_item"})
+	assert.Equal(t, stateShareSuccess, ui.state)
+	assert.Equal(t, "shared", ui.shareSuccessMsg)
+
+	ui.handleShareResultInput(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Equal(t, stateItemDetails, ui.state)
+
+	ui.handleProcessComplete(processComplete{success: false, message: "failed", context: "revoke_share"})
+	assert.Equal(t, stateShareError, ui.state)
+	assert.Equal(t, "failed", ui.shareErrorMsg)
+}
+
+func TestUIController_Update_SharedItemsLoaded(t *testing.T) {
+	ui := &UIController{state: stateProcessing}
+	items := []models.SharedItem{{Item: models.EncryptedItem{Name: "note", UserLogin: "alice"}}}
+
+	ui.Update(sharedItemsLoaded{items: items, fingerprint: "ab12 cd34"})
+	assert.Equal(t, stateSharedWithMe, ui.state)
+	assert.Contains(t, ui.sharedWithMeView(), "Your key fingerprint: ab12 cd34")
+	assert.Contains(t, ui.sharedWithMeView(), "note")
+	assert.Contains(t, ui.sharedWithMeView(), "alice")
+
+	_, cmd := ui.handleSharedWithMeInput(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.NotNil(t, cmd)
+	assert.Equal(t, stateSharedItemDetails, ui.state)
+	assert.Contains(t, ui.sharedItemDetailsView(), "Loading data")
+
+	ui.Update(sharedItemDecrypted{item: &models.Item{Data: &models.Text{Content: "secret"}}})
+	assert.Contains(t, ui.sharedItemDetailsView(), "secret")
+}
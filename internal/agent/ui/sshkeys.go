@@ -0,0 +1,245 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"gophkeeper/internal/agent/sshagent"
+	"gophkeeper/internal/sshkeys"
+	"gophkeeper/models"
+	"os"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type (
+	sshKeyLoaded struct {
+		key *models.SSHKey
+		err error
+	}
+	// sshSignRequest is sent by the ssh-agent and waits for the user.
+	sshSignRequest struct {
+		req   sshagent.Request
+		reply chan bool
+	}
+	// sshSignExpired drops a request nobody answered in time.
+	sshSignExpired struct {
+		reply chan bool
+	}
+)
+
+// loadSSHKey generates a new key for ed25519/rsa or imports the key file
+// at the given path.
+func (ui *UIController) loadSSHKey(source string) (tea.Model, tea.Cmd) {
+	comment := ui.newItem.Name
+	switch keyType := strings.ToLower(source); keyType {
+	case sshkeys.TypeEd25519, sshkeys.TypeRSA:
+		ui.state = stateProcessing
+		ui.input = ""
+		return ui, func() tea.Msg {
+			key, err := sshkeys.Generate(keyType, 0, comment)
+			return sshKeyLoaded{key: key, err: err}
+		}
+	}
+
+	ui.sshKeyPath = expandHome(source)
+	ui.state = stateProcessing
+	ui.input = ""
+	return ui, importSSHKeyCmd(ui.sshKeyPath, nil, comment)
+}
+
+func importSSHKeyCmd(path string, passphrase []byte, comment string) tea.Cmd {
+	return func() tea.Msg {
+		pemBytes, err := os.ReadFile(path)
+		if err != nil {
+			return sshKeyLoaded{err: err}
+		}
+		key, err := sshkeys.Import(pemBytes, passphrase, comment)
+		return sshKeyLoaded{key: key, err: err}
+	}
+}
+
+func (ui *UIController) handleSSHKeyLoaded(msg sshKeyLoaded) (tea.Model, tea.Cmd) {
+	switch {
+	case errors.Is(msg.err, sshkeys.ErrPassphraseRequired):
+		ui.state = stateAddSSHKeyPassphrase
+		ui.input = ""
+		ui.messages.Clear("error")
+		return ui, nil
+	case errors.Is(msg.err, sshkeys.ErrWrongPassphrase):
+		ui.state = stateAddSSHKeyPassphrase
+		ui.input = ""
+		ui.messages.Set("error", msg.err.Error())
+		return ui, nil
+	case msg.err != nil:
+		ui.state = stateAddItemData
+		ui.input = ui.sshKeyPath
+		ui.messages.Set("error", msg.err.Error())
+		return ui, nil
+	}
+
+	ui.newItem.Data = msg.key
+	ui.sshKeyPath = ""
+	ui.messages.Clear("error")
+	return ui, ui.addItemCmd()
+}
+
+func (ui *UIController) handleSSHKeyPassphraseInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return ui, tea.Quit
+	case "esc":
+		ui.state = stateAddItemData
+		ui.input = ui.sshKeyPath
+		ui.messages.Clear("error")
+		return ui, nil
+	case "enter":
+		if ui.input == "" {
+			return ui, nil
+		}
+		passphrase := []byte(ui.input)
+		ui.input = ""
+		ui.state = stateProcessing
+		return ui, importSSHKeyCmd(ui.sshKeyPath, passphrase, ui.newItem.Name)
+	case "backspace":
+		if len(ui.input) > 0 {
+			ui.input = ui.input[:len(ui.input)-1]
+		}
+	default:
+		if len(msg.String()) == 1 {
+			ui.input += msg.String()
+		}
+	}
+	return ui, nil
+}
+
+func (ui *UIController) sshKeyPassphraseView() string {
+	title := titleStyle.Render("Add SSH Key - Enter Passphrase")
+	input := inputStyle.Render(strings.Repeat("*", len(ui.input)) + "█")
+
+	errorMsg := ""
+	if err := ui.messages.Get("error"); err != "" {
+		errorMsg = "\n" + errorStyle.Render(err)
+	}
+
+	hint := "\nThe key is stored without the passphrase, the vault encrypts it."
+	controls := "\nControls: Esc to go back, Enter to import"
+	return fmt.Sprintf("%s\n\nKey file: %s\nPassphrase: %s%s%s%s", title, ui.sshKeyPath, input, hint, errorMsg, controls)
+}
+
+// unlockSSHAgent serves the SSH keys of the signed in user once the vault
+// is unlocked.
+func (ui *UIController) unlockSSHAgent() {
+	if ui.SSHAgent == nil {
+		return
+	}
+	login := ui.login
+	ui.SSHAgent.SetSource(func(ctx context.Context) ([]sshagent.Key, error) {
+		items, err := ui.Item.SSHKeys(ctx, login)
+		if err != nil {
+			return nil, err
+		}
+		keys := make([]sshagent.Key, len(items))
+		for i, item := range items {
+			keys[i] = sshagent.Key{Name: item.Name, SSHKey: item.Data.(*models.SSHKey)}
+		}
+		return keys, nil
+	})
+}
+
+// confirmSSHSign asks the user through the program whether the ssh-agent
+// may sign. An unanswered request is denied when the context ends.
+func confirmSSHSign(p *tea.Program) sshagent.Confirm {
+	return func(ctx context.Context, req sshagent.Request) bool {
+		reply := make(chan bool, 1)
+		p.Send(sshSignRequest{req: req, reply: reply})
+		select {
+		case allowed := <-reply:
+			return allowed
+		case <-ctx.Done():
+			p.Send(sshSignExpired{reply: reply})
+			return false
+		}
+	}
+}
+
+func (ui *UIController) handleSSHSignRequest(msg sshSignRequest) (tea.Model, tea.Cmd) {
+	if len(ui.sshRequests) == 0 {
+		ui.sshReturnState = ui.state
+		ui.confirmChoice = 0
+	}
+	ui.sshRequests = append(ui.sshRequests, msg)
+	ui.state = stateSSHSignConfirm
+	return ui, nil
+}
+
+func (ui *UIController) handleSSHSignExpired(msg sshSignExpired) (tea.Model, tea.Cmd) {
+	for i, r := range ui.sshRequests {
+		if r.reply == msg.reply {
+			ui.sshRequests = append(ui.sshRequests[:i], ui.sshRequests[i+1:]...)
+			break
+		}
+	}
+	if len(ui.sshRequests) == 0 && ui.state == stateSSHSignConfirm {
+		ui.state = ui.sshReturnState
+	}
+	return ui, nil
+}
+
+func (ui *UIController) handleSSHSignConfirmInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		ui.answerSSHSign(false)
+		return ui, tea.Quit
+	case "esc", "n":
+		ui.answerSSHSign(false)
+	case "left", "h":
+		ui.confirmChoice = 0
+	case "right", "l":
+		ui.confirmChoice = 1
+	case "y":
+		ui.answerSSHSign(true)
+	case "enter":
+		ui.answerSSHSign(ui.confirmChoice == 1)
+	}
+	return ui, nil
+}
+
+func (ui *UIController) answerSSHSign(allow bool) {
+	if len(ui.sshRequests) == 0 {
+		ui.state = ui.sshReturnState
+		return
+	}
+	ui.sshRequests[0].reply <- allow
+	ui.sshRequests = ui.sshRequests[1:]
+	ui.confirmChoice = 0
+	if len(ui.sshRequests) == 0 {
+		ui.state = ui.sshReturnState
+	}
+}
+
+func (ui *UIController) sshSignConfirmView() string {
+	title := titleStyle.Render("SSH Signature Request")
+	if len(ui.sshRequests) == 0 {
+		return title
+	}
+
+	req := ui.sshRequests[0].req
+	details := fmt.Sprintf("A program asks to sign with the key %q.\nFingerprint: %s\n", req.KeyName, req.Fingerprint)
+	if pending := len(ui.sshRequests) - 1; pending > 0 {
+		details += fmt.Sprintf("%d more request(s) waiting\n", pending)
+	}
+
+	options := ""
+	if ui.confirmChoice == 0 {
+		options += selectedStyle.Render("[ Deny ]") + "  "
+		options += menuStyle.Render("[ Allow ]")
+	} else {
+		options += menuStyle.Render("[ Deny ]") + "  "
+		options += selectedStyle.Render("[ Allow ]")
+	}
+
+	controls := "\nControls: ←/→ to select, Enter to confirm, y/n for quick choice, Esc to deny"
+	return fmt.Sprintf("%s\n\n%s\n%s%s", title, details, options, controls)
+}
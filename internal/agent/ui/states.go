@@ -12,8 +12,6 @@ const (
 	stateAddItem
 	stateAddItemName
 	stateAddItemData
-	//stateAddCredentialLogin
-	stateAddCredentialPassword
 	stateAddItemError
 	stateAddItemSuccess
 	stateEditItem
@@ -32,15 +30,6 @@ const (
 	stateDeleteSuccess
 	stateDeleteError
 	stateEditItemName
-	stateEditItemData
-	stateEditCredentialLogin
-	stateEditCredentialPassword
-	stateEditTextContent
-	stateEditBinaryData
-	stateEditCardNumber
-	stateEditCardExpiry
-	stateEditCardCVV
-	stateEditCardHolder
 	stateEditSuccess
 	stateEditError
 	stateMetadataList
@@ -50,12 +39,53 @@ const (
 	stateConfirmDeleteMetadata
 	stateMetadataSuccess
 	stateMetadataError
-	stateAddCardExpiry
-	stateAddCardCVV
-	stateAddCardHolder
 	stateConfirmLogout
 	stateLogoutSuccess
 	stateLogoutError
+	stateShareRecipient
+	stateItemShares
+	stateConfirmRevokeShare
+	stateShareSuccess
+	stateShareError
+	stateSharedWithMe
+	stateSharedItemDetails
+	stateVaultList
+	stateNewOrgName
+	stateOrgMembers
+	stateInviteMember
+	stateConfirmRemoveMember
+	stateEmergencyList
+	stateEmergencyGrant
+	stateEmergencyVault
+	stateEmergencyItemDetails
+	stateEmergencyTakeover
+	stateSearchItems
+	stateAddItemExpiry
+	stateEditItemExpiry
+	stateSendOptions
+	stateDeviceList
+	stateConfirmRemoveDevice
+	stateBreachReport
+	stateAttachmentList
+	stateAttachFile
+	stateSaveAttachment
+	stateConfirmDeleteAttachment
+	stateAddSSHKeyPassphrase
+	stateSSHSignConfirm
+	stateTemplateList
+	stateTemplateName
+	stateTemplateFieldName
+	stateTemplateFieldType
+	stateEditCustomField
+	stateEditDataForm
+	stateIdentityFrontImage
+	stateIdentityBackImage
+	stateConnectionKind
+	stateConnectionCopy
+	stateSyncConflicts
+	stateUnlockSession
+	stateRememberDevice
+	stateShareVerifyKey
 )
 
 func (s state) IsAuth() bool {
@@ -83,5 +113,5 @@ func (s state) IsLoggedIn() bool {
 }
 
 func (s state) IsAddItemInput() bool {
-	return s >= stateAddItem && s <= stateAddCredentialPassword
+	return s >= stateAddItem && s <= stateAddItemData
 }
@@ -0,0 +1,161 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"gophkeeper/internal/agent/offline"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type synced struct {
+	message string
+}
+
+type conflictEntry struct {
+	conflict offline.Conflict
+	name     string
+}
+
+type conflictsLoaded struct {
+	conflicts []conflictEntry
+}
+
+// syncStatus tells whether the agent works from the offline cache and what
+// waits for the server. It is empty without a cache.
+func (ui *UIController) syncStatus() string {
+	if ui.Offline == nil {
+		return ""
+	}
+
+	status := "Online"
+	if ui.Offline.Offline() {
+		status = "Offline - changes are saved locally"
+	}
+	if pending, err := ui.Offline.Pending(); err == nil && pending > 0 {
+		status += fmt.Sprintf(", %d pending", pending)
+	}
+	if conflicts, err := ui.Offline.Conflicts(); err == nil && len(conflicts) > 0 {
+		status += fmt.Sprintf(", %d conflicts (press c)", len(conflicts))
+	}
+	if ui.syncMsg != "" {
+		status += "\n" + ui.syncMsg
+	}
+	return status
+}
+
+func (ui *UIController) handleSync() (tea.Model, tea.Cmd) {
+	if ui.Offline == nil {
+		return ui, nil
+	}
+	ui.state = stateProcessing
+	return ui, func() tea.Msg {
+		sent, err := ui.Offline.Sync(context.Background())
+		if err != nil {
+			return synced{message: fmt.Sprintf("Sync failed: %v", err)}
+		}
+		return synced{message: fmt.Sprintf("Synced %d changes", sent)}
+	}
+}
+
+func (ui *UIController) handleConflicts() (tea.Model, tea.Cmd) {
+	if ui.Offline == nil {
+		return ui, nil
+	}
+	ui.state = stateProcessing
+	ui.currentConflict = 0
+	return ui, ui.conflictActionCmd(nil, "")
+}
+
+// conflictActionCmd runs an optional action and reloads the conflicts.
+func (ui *UIController) conflictActionCmd(action func(ctx context.Context) error, errContext string) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		if action != nil {
+			if err := action(ctx); err != nil {
+				return errorMsg{
+					err:     err,
+					context: errContext,
+				}
+			}
+		}
+
+		conflicts, err := ui.Offline.Conflicts()
+		if err != nil {
+			return errorMsg{
+				err:     err,
+				context: "load_conflicts",
+			}
+		}
+		entries := make([]conflictEntry, 0, len(conflicts))
+		for _, conflict := range conflicts {
+			entry := conflictEntry{conflict: conflict, name: "unreadable item"}
+			if item, err := ui.Item.DecryptItem(ctx, &conflict.Op.Item); err == nil {
+				entry.name = item.Name
+			}
+			entries = append(entries, entry)
+		}
+		return conflictsLoaded{conflicts: entries}
+	}
+}
+
+func (ui *UIController) selectedConflict() *conflictEntry {
+	if ui.currentConflict < len(ui.conflicts) {
+		return &ui.conflicts[ui.currentConflict]
+	}
+	return nil
+}
+
+func (ui *UIController) handleSyncConflictsInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	entry := ui.selectedConflict()
+
+	switch msg.String() {
+	case "ctrl+c", "q":
+		return ui, tea.Quit
+	case "esc", "b":
+		ui.state = stateMenuLoggedIn
+		return ui, nil
+	case "up", "k":
+		if ui.currentConflict > 0 {
+			ui.currentConflict--
+		}
+	case "down", "j":
+		if ui.currentConflict < len(ui.conflicts)-1 {
+			ui.currentConflict++
+		}
+	case "l", "s":
+		if entry != nil {
+			id, keepLocal := entry.conflict.ID, msg.String() == "l"
+			ui.state = stateProcessing
+			return ui, ui.conflictActionCmd(func(ctx context.Context) error {
+				return ui.Offline.ResolveConflict(ctx, id, keepLocal)
+			}, "resolve_conflict")
+		}
+	}
+	return ui, nil
+}
+
+func conflictTitle(entry conflictEntry) string {
+	return fmt.Sprintf("%s %s - %s", entry.conflict.Op.Kind, entry.name, entry.conflict.Reason)
+}
+
+func (ui *UIController) syncConflictsView() string {
+	title := titleStyle.Render("Sync Conflicts")
+
+	if len(ui.conflicts) == 0 {
+		return fmt.Sprintf("%s\n\nNo conflicts.\n\nControls: Esc to go back", title)
+	}
+
+	list := ""
+	for i, entry := range ui.conflicts {
+		if i == ui.currentConflict {
+			list += selectedStyle.Render("→ "+conflictTitle(entry)) + "\n"
+		} else {
+			list += menuStyle.Render("  "+conflictTitle(entry)) + "\n"
+		}
+	}
+
+	explain := "These changes were made offline to items that changed on the server since."
+	controls := "\nControls: ↑/↓ to navigate, l to keep the local version, s to keep the server version, b/Esc to go back"
+	return fmt.Sprintf("%s\n\n%s\n\n%s%s", title, explain, list, controls)
+}
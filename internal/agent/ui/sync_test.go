@@ -0,0 +1,76 @@
+package ui
+
+import (
+	"gophkeeper/internal/agent/offline"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+)
+
+func newSyncTestUI() *UIController {
+	return &UIController{
+		state: stateSyncConflicts,
+		syncCtrl: syncCtrl{
+			conflicts: []conflictEntry{
+				{conflict: offline.Conflict{ID: 1, Op: offline.Op{Kind: offline.OpEdit}, Reason: offline.ReasonChanged}, name: "mail"},
+				{conflict: offline.Conflict{ID: 2, Op: offline.Op{Kind: offline.OpDelete}, Reason: offline.ReasonChanged}, name: "bank"},
+			},
+		},
+	}
+}
+
+func TestUIController_syncConflictsView(t *testing.T) {
+	ui := newSyncTestUI()
+
+	view := ui.syncConflictsView()
+	assert.Contains(t, view, "edit mail - changed on the server")
+	assert.Contains(t, view, "delete bank - changed on the server")
+	assert.Contains(t, view, "l to keep the local version")
+
+	ui.conflicts = nil
+	assert.Contains(t, ui.syncConflictsView(), "No conflicts.")
+}
+
+func TestUIController_handleSyncConflictsInput(t *testing.T) {
+	ui := newSyncTestUI()
+
+	ui.handleSyncConflictsInput(tea.KeyMsg{Type: tea.KeyDown})
+	assert.Equal(t, 1, ui.currentConflict)
+	ui.handleSyncConflictsInput(tea.KeyMsg{Type: tea.KeyDown})
+	assert.Equal(t, 1, ui.currentConflict)
+
+	_, cmd := ui.handleSyncConflictsInput(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'l'}})
+	assert.NotNil(t, cmd)
+	assert.Equal(t, stateProcessing, ui.state)
+
+	ui.state = stateSyncConflicts
+	ui.handleSyncConflictsInput(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.Equal(t, stateMenuLoggedIn, ui.state)
+}
+
+func TestUIController_Update_ConflictsLoaded(t *testing.T) {
+	ui := newSyncTestUI()
+	ui.currentConflict = 1
+	ui.state = stateProcessing
+
+	ui.Update(conflictsLoaded{conflicts: ui.conflicts[:1]})
+	assert.Equal(t, stateSyncConflicts, ui.state)
+	assert.Equal(t, 0, ui.currentConflict)
+
+	ui.Update(synced{message: "Synced 2 changes"})
+	assert.Equal(t, stateMenuLoggedIn, ui.state)
+	assert.Equal(t, "Synced 2 changes", ui.syncMsg)
+}
+
+func TestUIController_syncStatus_NoCache(t *testing.T) {
+	ui := &UIController{state: stateMenuLoggedIn}
+
+	assert.Empty(t, ui.syncStatus())
+	_, cmd := ui.handleMenuLoggedInInput(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}})
+	assert.Nil(t, cmd)
+	_, cmd = ui.handleMenuLoggedInInput(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'c'}})
+	assert.Nil(t, cmd)
+	assert.Equal(t, stateMenuLoggedIn, ui.state)
+	assert.NotContains(t, ui.menuLoggedInView(), "s to sync")
+}
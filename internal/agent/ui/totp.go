@@ -0,0 +1,117 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"gophkeeper/internal/totp"
+	"gophkeeper/models"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// totpTick refreshes the code shown in the item details.
+type totpTick struct {
+	id int
+}
+
+func totpTickCmd(id int) tea.Cmd {
+	return tea.Tick(time.Second, func(time.Time) tea.Msg {
+		return totpTick{id: id}
+	})
+}
+
+// startTOTPTicks starts the countdown when the opened item is a TOTP item.
+// A countdown started for an earlier item stops on its next tick.
+func (ui *UIController) startTOTPTicks() tea.Cmd {
+	ui.totpTicks++
+	if ui.decryptedItem == nil {
+		return nil
+	}
+	if _, ok := ui.decryptedItem.Data.(*models.TOTP); !ok {
+		return nil
+	}
+	return totpTickCmd(ui.totpTicks)
+}
+
+func (ui *UIController) handleTOTPTick(msg totpTick) (tea.Model, tea.Cmd) {
+	if msg.id != ui.totpTicks || ui.decryptedItem == nil {
+		return ui, nil
+	}
+	if _, ok := ui.decryptedItem.Data.(*models.TOTP); !ok {
+		return ui, nil
+	}
+	// Keep ticking while the details are open or covered by a prompt.
+	if ui.state != stateItemDetails && ui.state != stateSSHSignConfirm {
+		return ui, nil
+	}
+	return ui, totpTickCmd(msg.id)
+}
+
+func totpDataView(data *models.TOTP, now time.Time) string {
+	details := ""
+	code, err := totp.Code(data, now)
+	if err != nil {
+		details += fmt.Sprintf("  Code: %s\n", errorStyle.Render(err.Error()))
+	} else {
+		left := totp.Remaining(data, now)
+		details += fmt.Sprintf("  Code: %s (%ds left)\n", successStyle.Render(groupCode(code)), int(left.Seconds()))
+	}
+	if data.Issuer != "" {
+		details += fmt.Sprintf("  Issuer: %s\n", data.Issuer)
+	}
+	if data.Account != "" {
+		details += fmt.Sprintf("  Account: %s\n", data.Account)
+	}
+	details += fmt.Sprintf("  Parameters: %s, %d digits, %ds period\n", data.Algorithm, data.Digits, data.Period)
+	return details
+}
+
+// groupCode splits a code in two halves the way authenticator apps show it.
+func groupCode(code string) string {
+	half := len(code) / 2
+	return code[:half] + " " + code[half:]
+}
+
+// importTOTP reads a secret or URI. A Google Authenticator export adds one
+// item per entry, named after the issuer and account.
+func (ui *UIController) importTOTP(source string) (tea.Model, tea.Cmd) {
+	entries, err := totp.Parse(source)
+	if err != nil {
+		ui.messages.Set("error", err.Error())
+		return ui, nil
+	}
+	ui.messages.Clear("error")
+	ui.input = ""
+
+	if len(entries) == 1 {
+		ui.newItem.Data = &entries[0]
+		return ui, ui.addItemCmd()
+	}
+
+	template := ui.newItem
+	ui.state = stateProcessing
+	return ui, func() tea.Msg {
+		ctx := context.Background()
+		added := make([]string, 0, len(entries))
+		for i := range entries {
+			item := template
+			item.Name = totp.Label(&entries[i])
+			item.Data = &entries[i]
+			if err := ui.Item.AddItem(ctx, &item); err != nil {
+				return processComplete{
+					success: false,
+					message: fmt.Sprintf("Save error after %d of %d entries: %v", len(added), len(entries), err),
+					context: "save_item",
+				}
+			}
+			added = append(added, item.Name)
+		}
+		return processComplete{
+			success: true,
+			message: fmt.Sprintf("Imported %d authenticator entries: %s", len(added), strings.Join(added, ", ")),
+			context: "save_item",
+		}
+	}
+}
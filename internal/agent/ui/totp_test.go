@@ -0,0 +1,67 @@
+package ui
+
+import (
+	"gophkeeper/models"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTOTPDataView(t *testing.T) {
+	data := &models.TOTP{Secret: "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ", Issuer: "GitHub", Account: "octocat", Algorithm: "SHA1", Digits: 8, Period: 30}
+	view := totpDataView(data, time.Unix(59, 0))
+	assert.Contains(t, view, "9428 7082")
+	assert.Contains(t, view, "(1s left)")
+	assert.Contains(t, view, "Issuer: GitHub")
+	assert.Contains(t, view, "Account: octocat")
+	assert.NotContains(t, view, data.Secret)
+
+	assert.Contains(t, totpDataView(&models.TOTP{Secret: "!"}, time.Now()), "not valid base32")
+}
+
+func TestUIController_totpTicks(t *testing.T) {
+	ui := &UIController{state: stateItemDetails}
+
+	_, cmd := ui.Update(itemDecrypted{item: &models.Item{Data: &models.Text{Content: "note"}}})
+	assert.Nil(t, cmd)
+
+	_, cmd = ui.Update(itemDecrypted{item: &models.Item{Data: &models.TOTP{Secret: "JBSWY3DPEHPK3PXP"}}})
+	require.NotNil(t, cmd)
+	id := ui.totpTicks
+
+	_, cmd = ui.Update(totpTick{id: id})
+	assert.NotNil(t, cmd)
+
+	// A tick of an earlier countdown stops it.
+	_, cmd = ui.Update(totpTick{id: id - 1})
+	assert.Nil(t, cmd)
+
+	ui.state = stateItemsList
+	_, cmd = ui.Update(totpTick{id: id})
+	assert.Nil(t, cmd)
+}
+
+func TestUIController_importTOTP(t *testing.T) {
+	ui := &UIController{}
+	ui.messages.init()
+	ui.selectItemType(models.ItemTypeTOTP)
+	ui.newItem.Name = "GitHub"
+	ui.state = stateAddItemData
+	assert.Contains(t, ui.addItemDataView(), "otpauth://")
+
+	ui.input = "not a secret!"
+	_, cmd := ui.handleItemDataInput(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Nil(t, cmd)
+	assert.NotEmpty(t, ui.messages.Get("error"))
+
+	ui.input = "otpauth://totp/GitHub:octocat?secret=JBSWY3DPEHPK3PXP&issuer=GitHub"
+	_, cmd = ui.handleItemDataInput(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.NotNil(t, cmd)
+	data := ui.newItem.Data.(*models.TOTP)
+	assert.Equal(t, "octocat", data.Account)
+	assert.Equal(t, 6, data.Digits)
+	assert.Equal(t, "GitHub", ui.newItem.Name)
+}
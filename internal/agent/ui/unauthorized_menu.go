@@ -6,13 +6,8 @@ func (ui *UIController) menuLoggedOutView() string {
 	title := titleStyle.Render("Welcome to GophKeeper - best sensitive info manager :)")
 	subtitle := "Choose an option - enter number or use arrow keys:"
 
-	options := []string{
-		"Sign up",
-		"Sign in",
-	}
-
 	menu := ""
-	for i, option := range options {
+	for i, option := range ui.loggedOutOptions() {
 		prefix := fmt.Sprintf("%d. ", i+1)
 		if i == ui.currentMenu {
 			menu += selectedStyle.Render(prefix+option) + "\n"
@@ -22,6 +17,11 @@ func (ui *UIController) menuLoggedOutView() string {
 	}
 
 	controls := "\nControls: ↑/↓ to navigate, Enter to select, q to quit"
+	if errMsg := ui.messages.Get("session_error"); errMsg != "" {
+		subtitle = errorStyle.Render(errMsg) + "\n" + subtitle
+	} else if info := ui.messages.Get("session_info"); info != "" {
+		subtitle = successStyle.Render(info) + "\n" + subtitle
+	}
 
 	return fmt.Sprintf("%s\n\n%s\n\n%s%s", title, subtitle, menu, controls)
 }
@@ -7,15 +7,72 @@ var (
 	ErrRequiredArgumentIsMissing = errors.New("one of required arguments is missing")
 
 	//User errors
-	ErrUserNotFound          = errors.New("user not found")
-	ErrUserAlreadyRegistered = errors.New("user is already registered")
-	ErrIncorrectCredentials  = errors.New("incorrect login or password")
+	ErrUserNotFound            = errors.New("user not found")
+	ErrUserAlreadyRegistered   = errors.New("user is already registered")
+	ErrIncorrectCredentials    = errors.New("incorrect login or password")
+	ErrUserLocked              = errors.New("user account is locked")
+	ErrSessionRevoked          = errors.New("session has been revoked")
+	ErrIncorrectMasterPassword = errors.New("incorrect master password")
+	ErrIncorrectPIN            = errors.New("incorrect PIN or keyfile")
+	ErrSessionExpired          = errors.New("saved session has expired")
+	ErrInvalidPasswordEncoding = errors.New("password must be RSA-OAEP encrypted with the server public key and base64 encoded")
 
 	//Item errors
 	//ErrIncorrectItemType = errors.New("incorrect item type")
 	ErrItemAlreadyExists = errors.New("item already exists")
 	ErrItemNotFound      = errors.New("item not found")
 
+	//Attachment errors
+	ErrAttachmentNotFound = errors.New("attachment not found")
+	ErrAttachmentTooLarge = errors.New("attachment is too large")
+	ErrAttachmentsChanged = errors.New("re-sealed attachments do not match the item attachments")
+
+	//Template errors
+	ErrTemplateNotFound = errors.New("template not found")
+
+	//Sharing errors
+	ErrKeysNotFound      = errors.New("sharing keys not found")
+	ErrKeysAlreadyExist  = errors.New("sharing keys already exist")
+	ErrNotItemOwner      = errors.New("only the item owner can manage its shares")
+	ErrShareNotFound     = errors.New("share not found")
+	ErrShareWithSelf     = errors.New("cannot share an item with yourself")
+	ErrShareKeysMismatch = errors.New("re-wrapped keys do not match remaining recipients")
+	ErrKeyNotVerified    = errors.New("recipient key is not verified")
+	ErrKeyChanged        = errors.New("recipient key changed since it was verified")
+
+	//Organization errors
+	ErrNotOrgMember        = errors.New("user is not a member of the organization")
+	ErrOrgPermissionDenied = errors.New("organization role does not allow this action")
+	ErrMemberAlreadyExists = errors.New("user is already a member of the organization")
+	ErrInviteNotFound      = errors.New("invite not found")
+	ErrInvalidOrgRole      = errors.New("invalid organization role")
+	ErrOwnerImmutable      = errors.New("organization owner cannot be removed or demoted")
+	ErrOrgKeysMismatch     = errors.New("re-wrapped keys do not match remaining members")
+
+	//Emergency access errors
+	ErrEmergencyAccessNotFound     = errors.New("emergency access not found")
+	ErrEmergencyWithSelf           = errors.New("cannot grant emergency access to yourself")
+	ErrInvalidWaitPeriod           = errors.New("wait period must be positive")
+	ErrInvalidEmergencyAccessType  = errors.New("invalid emergency access type")
+	ErrEmergencyAlreadyRequested   = errors.New("emergency access is already requested")
+	ErrEmergencyNotRequested       = errors.New("emergency access is not requested")
+	ErrEmergencyAccessPending      = errors.New("emergency access wait period has not passed yet")
+	ErrEmergencyTakeoverNotAllowed = errors.New("emergency access does not allow account takeover")
+	ErrEmergencyItemsMismatch      = errors.New("re-encrypted items do not match the vault")
+	ErrEmergencyTemplatesMismatch  = errors.New("re-encrypted templates do not match the vault")
+
+	//Send errors
+	ErrSendNotFound      = errors.New("send not found or already burned")
+	ErrInvalidSendViews  = errors.New("max views must be between 1 and 100")
+	ErrInvalidSendExpiry = errors.New("send expiry must be within 30 days")
+
+	//Device errors
+	ErrDeviceNotFound         = errors.New("device not found")
+	ErrDeviceNotApproved      = errors.New("device is waiting for approval from a trusted device")
+	ErrDeviceNotTrusted       = errors.New("only an approved device can do this")
+	ErrInvalidDeviceSignature = errors.New("device signature is invalid")
+	ErrDeviceSignInReplayed   = errors.New("device sign in is stale or was already used")
+
 	//Other errors
 	ErrInternalServerError = errors.New("internal server error")
 )
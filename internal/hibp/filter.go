@@ -0,0 +1,195 @@
+package hibp
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+)
+
+// A filter file holds one binary fuse filter per first byte of the hash, so
+// building needs memory for 1/256 of the dataset only and a lookup reads
+// three fingerprints from disk.
+//
+// Layout, little endian: the magic, 256 shard headers and the fingerprints
+// of all shards in order.
+var filterMagic = [8]byte{'G', 'K', 'H', 'I', 'B', 'P', '1', '6'}
+
+const filterShards = 256
+
+type shardHeader struct {
+	Seed          uint64
+	SegmentLength uint32
+	SegmentCount  uint32
+	Length        uint32
+	_             uint32
+}
+
+var filterHeaderSize = int64(len(filterMagic)) + filterShards*int64(binary.Size(shardHeader{}))
+
+// Filter is a filter file built with BuildFilter. False positives happen
+// once in 65536 lookups; there are no false negatives.
+type Filter struct {
+	r       io.ReaderAt
+	closer  io.Closer
+	shards  [filterShards]*binaryFuse
+	offsets [filterShards]int64
+}
+
+var _ Index = (*Filter)(nil)
+
+func OpenFilter(path string) (*Filter, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open filter error: %w", err)
+	}
+	filter, err := NewFilter(f)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	filter.closer = f
+	return filter, nil
+}
+
+// NewFilter reads the filter headers from r. Fingerprints are read on
+// lookup.
+func NewFilter(r io.ReaderAt) (*Filter, error) {
+	header := io.NewSectionReader(r, 0, filterHeaderSize)
+	var magic [8]byte
+	if err := binary.Read(header, binary.LittleEndian, &magic); err != nil || magic != filterMagic {
+		return nil, fmt.Errorf("%w: not a filter file", ErrInvalidDataset)
+	}
+
+	filter := &Filter{r: r}
+	offset := filterHeaderSize
+	for i := range filter.shards {
+		var h shardHeader
+		if err := binary.Read(header, binary.LittleEndian, &h); err != nil {
+			return nil, fmt.Errorf("%w: read shard header: %v", ErrInvalidDataset, err)
+		}
+		if h.Length == 0 {
+			continue
+		}
+		if h.SegmentLength == 0 || h.SegmentLength&(h.SegmentLength-1) != 0 ||
+			uint64(h.SegmentCount+2)*uint64(h.SegmentLength) != uint64(h.Length) {
+			return nil, fmt.Errorf("%w: bad shard header %d", ErrInvalidDataset, i)
+		}
+		filter.shards[i] = newBinaryFuse(h.Seed, h.SegmentLength, h.SegmentCount)
+		filter.offsets[i] = offset
+		offset += 2 * int64(h.Length)
+	}
+	return filter, nil
+}
+
+func (f *Filter) Lookup(hash [sha1.Size]byte) (bool, int, error) {
+	shard := f.shards[hash[0]]
+	if shard == nil {
+		return false, 0, nil
+	}
+
+	h := mixsplit(filterKey(hash), shard.seed)
+	fp := fingerprint(h)
+	h0, h1, h2 := shard.positions(h)
+	var buf [2]byte
+	for _, pos := range []uint32{h0, h1, h2} {
+		if _, err := f.r.ReadAt(buf[:], f.offsets[hash[0]]+2*int64(pos)); err != nil {
+			return false, 0, fmt.Errorf("read filter error: %w", err)
+		}
+		fp ^= binary.LittleEndian.Uint16(buf[:])
+	}
+	return fp == 0, 0, nil
+}
+
+func (f *Filter) Close() error {
+	if f.closer == nil {
+		return nil
+	}
+	return f.closer.Close()
+}
+
+// filterKey picks the filter key from the hash bytes after the shard byte.
+// SHA-1 output is uniform, so no further mixing is needed before the filter
+// hash.
+func filterKey(hash [sha1.Size]byte) uint64 {
+	return binary.BigEndian.Uint64(hash[1:9])
+}
+
+// BuildFilter writes a filter file of all hashes in the range directory to
+// w and returns the number of hashes.
+func BuildFilter(ranges *Ranges, w io.WriteSeeker) (int, error) {
+	files, err := ranges.files()
+	if err != nil {
+		return 0, err
+	}
+	if _, err := w.Seek(filterHeaderSize, io.SeekStart); err != nil {
+		return 0, err
+	}
+
+	var headers [filterShards]shardHeader
+	total := 0
+	for shard := range filterShards {
+		keys, err := shardKeys(ranges, files[shard])
+		if err != nil {
+			return total, err
+		}
+		total += len(keys)
+		if len(keys) == 0 {
+			continue
+		}
+
+		fuse, err := populateBinaryFuse(keys)
+		if err != nil {
+			return total, fmt.Errorf("build shard %02X error: %w", shard, err)
+		}
+		headers[shard] = shardHeader{
+			Seed:          fuse.seed,
+			SegmentLength: fuse.segmentLength,
+			SegmentCount:  fuse.segmentCount,
+			Length:        uint32(len(fuse.fingerprints)),
+		}
+		if err := binary.Write(w, binary.LittleEndian, fuse.fingerprints); err != nil {
+			return total, fmt.Errorf("write filter error: %w", err)
+		}
+	}
+
+	var header bytes.Buffer
+	header.Write(filterMagic[:])
+	if err := binary.Write(&header, binary.LittleEndian, headers); err != nil {
+		return total, err
+	}
+	if _, err := w.Seek(0, io.SeekStart); err != nil {
+		return total, err
+	}
+	if _, err := w.Write(header.Bytes()); err != nil {
+		return total, fmt.Errorf("write filter error: %w", err)
+	}
+	return total, nil
+}
+
+// shardKeys collects the keys of the range files of one shard.
+func shardKeys(ranges *Ranges, prefixes []string) ([]uint64, error) {
+	var keys []uint64
+	for _, prefix := range prefixes {
+		var parseErr error
+		err := ranges.scan(prefix, func(suffix string, _ int) bool {
+			var hash [sha1.Size]byte
+			if _, err := hex.Decode(hash[:], []byte(prefix+suffix)); err != nil {
+				parseErr = fmt.Errorf("%w: bad hash %s%s", ErrInvalidDataset, prefix, suffix)
+				return false
+			}
+			keys = append(keys, filterKey(hash))
+			return true
+		})
+		if err == nil {
+			err = parseErr
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return keys, nil
+}
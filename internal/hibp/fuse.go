@@ -0,0 +1,260 @@
+package hibp
+
+import (
+	"errors"
+	"math"
+	"math/bits"
+)
+
+// binaryFuse is a binary fuse filter with 16 bit fingerprints (Graf and
+// Lemire, "Binary Fuse Filters: Fast and Smaller Than Xor Filters"). It
+// takes about 2.25 bytes per key and has a false positive rate of 1/65536.
+type binaryFuse struct {
+	seed               uint64
+	segmentLength      uint32
+	segmentLengthMask  uint32
+	segmentCount       uint32
+	segmentCountLength uint32
+	fingerprints       []uint16
+}
+
+const maxFuseIterations = 100
+
+var errFuseConstruction = errors.New("binary fuse filter construction failed")
+
+func newBinaryFuse(seed uint64, segmentLength, segmentCount uint32) *binaryFuse {
+	return &binaryFuse{
+		seed:               seed,
+		segmentLength:      segmentLength,
+		segmentLengthMask:  segmentLength - 1,
+		segmentCount:       segmentCount,
+		segmentCountLength: segmentCount * segmentLength,
+	}
+}
+
+// fuseParams sizes a filter for size keys.
+func fuseParams(size uint32) (segmentLength, segmentCount, arrayLength uint32) {
+	const arity = 3
+	segmentLength = 4
+	if size > 0 {
+		segmentLength = uint32(1) << int(math.Floor(math.Log(float64(size))/math.Log(3.33)+2.25))
+	}
+	if segmentLength > 262144 {
+		segmentLength = 262144
+	}
+
+	var capacity uint32
+	if size > 1 {
+		sizeFactor := math.Max(1.125, 0.875+0.25*math.Log(1000000)/math.Log(float64(size)))
+		capacity = uint32(math.Round(float64(size) * sizeFactor))
+	}
+	initSegmentCount := (capacity+segmentLength-1)/segmentLength - (arity - 1)
+	arrayLength = (initSegmentCount + arity - 1) * segmentLength
+	segmentCount = (arrayLength + segmentLength - 1) / segmentLength
+	if segmentCount <= arity-1 {
+		segmentCount = 1
+	} else {
+		segmentCount -= arity - 1
+	}
+	arrayLength = (segmentCount + arity - 1) * segmentLength
+	return segmentLength, segmentCount, arrayLength
+}
+
+func (f *binaryFuse) positions(hash uint64) (uint32, uint32, uint32) {
+	hi, _ := bits.Mul64(hash, uint64(f.segmentCountLength))
+	h0 := uint32(hi)
+	h1 := h0 + f.segmentLength
+	h2 := h1 + f.segmentLength
+	h1 ^= uint32(hash>>18) & f.segmentLengthMask
+	h2 ^= uint32(hash) & f.segmentLengthMask
+	return h0, h1, h2
+}
+
+// populateBinaryFuse builds a filter for keys. Duplicate keys are allowed.
+func populateBinaryFuse(keys []uint64) (*binaryFuse, error) {
+	size := uint32(len(keys))
+	segmentLength, segmentCount, arrayLength := fuseParams(size)
+	rng := uint64(1)
+	f := newBinaryFuse(splitmix64(&rng), segmentLength, segmentCount)
+	f.fingerprints = make([]uint16, arrayLength)
+	capacity := arrayLength
+
+	alone := make([]uint32, capacity)
+	t2count := make([]uint8, capacity)
+	t2hash := make([]uint64, capacity)
+	reverseH := make([]uint8, size)
+	reverseOrder := make([]uint64, size+1)
+	reverseOrder[size] = 1
+
+	blockBits := 1
+	for (uint32(1) << blockBits) < segmentCount {
+		blockBits++
+	}
+	block := uint32(1) << blockBits
+	startPos := make([]uint32, block)
+	var h012 [5]uint32
+
+	for iteration := 0; ; iteration++ {
+		if iteration > maxFuseIterations {
+			return nil, errFuseConstruction
+		}
+
+		// Sort the hashes roughly by segment, which keeps the memory
+		// accesses below local.
+		for i := uint32(0); i < block; i++ {
+			startPos[i] = uint32((uint64(i) * uint64(size)) >> blockBits)
+		}
+		maskBlock := uint64(block - 1)
+		for _, key := range keys {
+			hash := mixsplit(key, f.seed)
+			segment := hash >> (64 - blockBits)
+			for reverseOrder[startPos[segment]] != 0 {
+				segment = (segment + 1) & maskBlock
+			}
+			reverseOrder[startPos[segment]] = hash
+			startPos[segment]++
+		}
+
+		failed := false
+		duplicates := uint32(0)
+		for i := uint32(0); i < size; i++ {
+			hash := reverseOrder[i]
+			i1, i2, i3 := f.positions(hash)
+			t2count[i1] += 4
+			t2hash[i1] ^= hash
+			t2count[i2] += 4
+			t2count[i2] ^= 1
+			t2hash[i2] ^= hash
+			t2count[i3] += 4
+			t2count[i3] ^= 2
+			t2hash[i3] ^= hash
+			// A duplicate key cancels its own hash out.
+			if t2hash[i1]&t2hash[i2]&t2hash[i3] == 0 {
+				if (t2hash[i1] == 0 && t2count[i1] == 8) ||
+					(t2hash[i2] == 0 && t2count[i2] == 8) ||
+					(t2hash[i3] == 0 && t2count[i3] == 8) {
+					duplicates++
+					t2count[i1] -= 4
+					t2hash[i1] ^= hash
+					t2count[i2] -= 4
+					t2count[i2] ^= 1
+					t2hash[i2] ^= hash
+					t2count[i3] -= 4
+					t2count[i3] ^= 2
+					t2hash[i3] ^= hash
+				}
+			}
+			if t2count[i1] < 4 || t2count[i2] < 4 || t2count[i3] < 4 {
+				failed = true
+			}
+		}
+
+		stackSize := uint32(0)
+		if !failed {
+			queueSize := 0
+			for i := uint32(0); i < capacity; i++ {
+				alone[queueSize] = i
+				if t2count[i]>>2 == 1 {
+					queueSize++
+				}
+			}
+			for queueSize > 0 {
+				queueSize--
+				index := alone[queueSize]
+				if t2count[index]>>2 != 1 {
+					continue
+				}
+				hash := t2hash[index]
+				found := t2count[index] & 3
+				reverseH[stackSize] = found
+				reverseOrder[stackSize] = hash
+				stackSize++
+
+				i1, i2, i3 := f.positions(hash)
+				h012[1] = i2
+				h012[2] = i3
+				h012[3] = i1
+				h012[4] = h012[1]
+
+				other := h012[found+1]
+				alone[queueSize] = other
+				if t2count[other]>>2 == 2 {
+					queueSize++
+				}
+				t2count[other] -= 4
+				t2count[other] ^= mod3(found + 1)
+				t2hash[other] ^= hash
+
+				other = h012[found+2]
+				alone[queueSize] = other
+				if t2count[other]>>2 == 2 {
+					queueSize++
+				}
+				t2count[other] -= 4
+				t2count[other] ^= mod3(found + 2)
+				t2hash[other] ^= hash
+			}
+		}
+
+		if !failed && stackSize+duplicates == size {
+			size = stackSize
+			break
+		}
+
+		clear(reverseOrder[:size])
+		clear(t2count)
+		clear(t2hash)
+		f.seed = splitmix64(&rng)
+	}
+
+	for i := int(size) - 1; i >= 0; i-- {
+		hash := reverseOrder[i]
+		i1, i2, i3 := f.positions(hash)
+		found := reverseH[i]
+		h012[0] = i1
+		h012[1] = i2
+		h012[2] = i3
+		h012[3] = h012[0]
+		h012[4] = h012[1]
+		f.fingerprints[h012[found]] = fingerprint(hash) ^ f.fingerprints[h012[found+1]] ^ f.fingerprints[h012[found+2]]
+	}
+	return f, nil
+}
+
+func (f *binaryFuse) contains(key uint64) bool {
+	hash := mixsplit(key, f.seed)
+	h0, h1, h2 := f.positions(hash)
+	return fingerprint(hash)^f.fingerprints[h0]^f.fingerprints[h1]^f.fingerprints[h2] == 0
+}
+
+func mod3(x uint8) uint8 {
+	if x > 2 {
+		x -= 3
+	}
+	return x
+}
+
+func fingerprint(hash uint64) uint16 {
+	return uint16(hash ^ (hash >> 32))
+}
+
+func mixsplit(key, seed uint64) uint64 {
+	return murmur64(key + seed)
+}
+
+func murmur64(h uint64) uint64 {
+	h ^= h >> 33
+	h *= 0xff51afd7ed558ccd
+	h ^= h >> 33
+	h *= 0xc4ceb9fe1a85ec53
+	h ^= h >> 33
+	return h
+}
+
+func splitmix64(seed *uint64) uint64 {
+	*seed += 0x9E3779B97F4A7C15
+	z := *seed
+	z = (z ^ (z >> 30)) * 0xBF58476D1CE4E5B9
+	z = (z ^ (z >> 27)) * 0x94D049BB133111EB
+	return z ^ (z >> 31)
+}
@@ -0,0 +1,34 @@
+package hibp
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBinaryFuse(t *testing.T) {
+	for _, n := range []int{1, 2, 10, 1000, 100000} {
+		keys := make([]uint64, n)
+		for i := range keys {
+			keys[i] = rand.Uint64()
+		}
+		// Duplicates must not break construction.
+		keys = append(keys, keys[0])
+
+		f, err := populateBinaryFuse(keys)
+		require.NoError(t, err)
+		for _, k := range keys {
+			require.True(t, f.contains(k), "key %d of %d", k, n)
+		}
+
+		falsePositives := 0
+		for range 100000 {
+			if f.contains(rand.Uint64()) {
+				falsePositives++
+			}
+		}
+		assert.Less(t, falsePositives, 20, "n = %d", n)
+	}
+}
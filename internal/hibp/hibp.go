@@ -0,0 +1,40 @@
+// Package hibp checks passwords against a local copy of the Have I Been
+// Pwned Pwned Passwords dataset. Nothing leaves the machine: the dataset is
+// either the directory of SHA-1 range files fetched by the official
+// downloader or a compact filter built from it with BuildFilter.
+package hibp
+
+import (
+	"crypto/sha1"
+	"errors"
+	"fmt"
+	"os"
+)
+
+var ErrInvalidDataset = errors.New("invalid breach dataset")
+
+// Index is a local breach dataset.
+type Index interface {
+	// Lookup reports whether the SHA-1 hash of a password is in the
+	// dataset and how often it was seen in breaches. Filters keep no
+	// counts and return 0 for found hashes.
+	Lookup(hash [sha1.Size]byte) (found bool, count int, err error)
+	Close() error
+}
+
+// Hash returns the SHA-1 hash of password, the key of the dataset.
+func Hash(password string) [sha1.Size]byte {
+	return sha1.Sum([]byte(password))
+}
+
+// Open opens the dataset at path, a range directory or a filter file.
+func Open(path string) (Index, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("open breach dataset error: %w", err)
+	}
+	if info.IsDir() {
+		return OpenRanges(path)
+	}
+	return OpenFilter(path)
+}
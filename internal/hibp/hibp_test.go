@@ -0,0 +1,120 @@
+package hibp
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeRanges writes range files of passwords with their breach counts and
+// some random filler hashes.
+func writeRanges(t *testing.T, passwords map[string]int) string {
+	t.Helper()
+	dir := t.TempDir()
+	files := make(map[string][]string)
+	add := func(hash [sha1.Size]byte, count int) {
+		full := strings.ToUpper(hex.EncodeToString(hash[:]))
+		files[full[:prefixLen]] = append(files[full[:prefixLen]], fmt.Sprintf("%s:%d", full[prefixLen:], count))
+	}
+	for password, count := range passwords {
+		add(Hash(password), count)
+	}
+	for i := range 500 {
+		add(Hash(fmt.Sprintf("filler-%d", i)), i+1)
+	}
+
+	i := 0
+	for prefix, lines := range files {
+		// The downloader names files with .txt, older copies without.
+		name := prefix
+		if i%2 == 0 {
+			name += ".txt"
+		}
+		i++
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(strings.Join(lines, "\r\n")+"\r\n"), 0o600))
+	}
+	return dir
+}
+
+func TestRanges_Lookup(t *testing.T) {
+	dir := writeRanges(t, map[string]int{"password": 9659365, "hunter2": 42})
+	idx, err := Open(dir)
+	require.NoError(t, err)
+	defer idx.Close()
+
+	found, count, err := idx.Lookup(Hash("password"))
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, 9659365, count)
+
+	found, count, err = idx.Lookup(Hash("hunter2"))
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, 42, count)
+
+	found, _, err = idx.Lookup(Hash("correct horse battery staple 42"))
+	require.NoError(t, err)
+	assert.False(t, found)
+}
+
+func TestRanges_Lookup_BadLine(t *testing.T) {
+	dir := t.TempDir()
+	full := strings.ToUpper(hex.EncodeToString(func() []byte { h := Hash("x"); return h[:] }()))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, full[:prefixLen]), []byte("garbage\n"), 0o600))
+
+	idx, err := OpenRanges(dir)
+	require.NoError(t, err)
+	_, _, err = idx.Lookup(Hash("x"))
+	assert.ErrorIs(t, err, ErrInvalidDataset)
+}
+
+func TestBuildFilter(t *testing.T) {
+	dir := writeRanges(t, map[string]int{"password": 9659365, "hunter2": 42})
+	ranges, err := OpenRanges(dir)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "hibp.filter")
+	out, err := os.Create(path)
+	require.NoError(t, err)
+	n, err := BuildFilter(ranges, out)
+	require.NoError(t, err)
+	require.NoError(t, out.Close())
+	assert.Equal(t, 502, n)
+
+	idx, err := Open(path)
+	require.NoError(t, err)
+	defer idx.Close()
+
+	for _, password := range []string{"password", "hunter2", "filler-0", "filler-499"} {
+		found, count, err := idx.Lookup(Hash(password))
+		require.NoError(t, err)
+		assert.True(t, found, password)
+		assert.Zero(t, count)
+	}
+
+	misses := 0
+	for i := range 1000 {
+		found, _, err := idx.Lookup(Hash(fmt.Sprintf("not breached %d", i)))
+		require.NoError(t, err)
+		if !found {
+			misses++
+		}
+	}
+	assert.GreaterOrEqual(t, misses, 998)
+}
+
+func TestNewFilter_Invalid(t *testing.T) {
+	_, err := NewFilter(bytes.NewReader([]byte("not a filter")))
+	assert.ErrorIs(t, err, ErrInvalidDataset)
+
+	_, err = Open(filepath.Join(t.TempDir(), "missing"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,126 @@
+package hibp
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// prefixLen is the number of hex characters of the hash that name a range
+// file. The rest of the hash is listed inside the file.
+const prefixLen = 5
+
+// Ranges is a directory of range files named by the first five hex
+// characters of the hash, with or without a .txt extension. Every line of a
+// file is the remaining hash suffix and a breach count, "SUFFIX:COUNT".
+type Ranges struct {
+	dir string
+}
+
+var _ Index = (*Ranges)(nil)
+
+func OpenRanges(dir string) (*Ranges, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, fmt.Errorf("open range directory error: %w", err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("%w: %s is not a directory", ErrInvalidDataset, dir)
+	}
+	return &Ranges{dir: dir}, nil
+}
+
+func (r *Ranges) Lookup(hash [sha1.Size]byte) (bool, int, error) {
+	full := strings.ToUpper(hex.EncodeToString(hash[:]))
+	prefix, suffix := full[:prefixLen], full[prefixLen:]
+
+	var found bool
+	var count int
+	err := r.scan(prefix, func(s string, c int) bool {
+		if s == suffix {
+			found, count = true, c
+			return false
+		}
+		return true
+	})
+	return found, count, err
+}
+
+func (r *Ranges) Close() error {
+	return nil
+}
+
+// scan calls fn for every entry of the range file of prefix until fn
+// returns false. A missing range file has no entries, which lets a partial
+// download be used.
+func (r *Ranges) scan(prefix string, fn func(suffix string, count int) bool) error {
+	f, err := r.open(prefix)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("open range file error: %w", err)
+	}
+	defer f.Close()
+	return scanRange(f, fn)
+}
+
+func (r *Ranges) open(prefix string) (*os.File, error) {
+	f, err := os.Open(filepath.Join(r.dir, prefix+".txt"))
+	if errors.Is(err, fs.ErrNotExist) {
+		return os.Open(filepath.Join(r.dir, prefix))
+	}
+	return f, err
+}
+
+// files lists the range prefixes in the directory grouped by their first
+// byte. Other files are ignored.
+func (r *Ranges) files() ([256][]string, error) {
+	var files [256][]string
+	entries, err := os.ReadDir(r.dir)
+	if err != nil {
+		return files, fmt.Errorf("read range directory error: %w", err)
+	}
+	for _, e := range entries {
+		prefix := strings.ToUpper(strings.TrimSuffix(e.Name(), ".txt"))
+		if e.IsDir() || len(prefix) != prefixLen {
+			continue
+		}
+		n, err := strconv.ParseUint(prefix, 16, 32)
+		if err != nil {
+			continue
+		}
+		files[n>>12] = append(files[n>>12], prefix)
+	}
+	return files, nil
+}
+
+func scanRange(rd io.Reader, fn func(suffix string, count int) bool) error {
+	sc := bufio.NewScanner(rd)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" {
+			continue
+		}
+		suffix, rawCount, ok := strings.Cut(line, ":")
+		if !ok || len(suffix) != 2*sha1.Size-prefixLen {
+			return fmt.Errorf("%w: bad range line %q", ErrInvalidDataset, line)
+		}
+		count, err := strconv.Atoi(rawCount)
+		if err != nil {
+			return fmt.Errorf("%w: bad range line %q", ErrInvalidDataset, line)
+		}
+		if !fn(strings.ToUpper(suffix), count) {
+			return nil
+		}
+	}
+	return sc.Err()
+}
@@ -0,0 +1,123 @@
+// Package lifecycle starts the server parts in order and stops them in
+// reverse order when the process is asked to quit
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"gophkeeper/internal/logger"
+	"os"
+	"os/signal"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Component is a long lived part of the server, such as storage, a
+// background worker or a listener. Start must not block, Stop must return
+// once ctx is done even if the component did not stop cleanly.
+type Component interface {
+	Start(ctx context.Context) error
+	Stop(ctx context.Context) error
+}
+
+// Failer is implemented by components that can fail after Start, e.g. a
+// server whose listener broke. The manager shuts everything down then.
+type Failer interface {
+	Failed() <-chan error
+}
+
+type entry struct {
+	name string
+	c    Component
+}
+
+// Manager runs components until a signal arrives or one of them fails.
+type Manager struct {
+	timeout time.Duration
+	signals []os.Signal
+
+	components []entry
+}
+
+// NewManager returns a manager that waits for signals and gives the
+// components timeout to stop.
+func NewManager(timeout time.Duration, signals ...os.Signal) *Manager {
+	return &Manager{
+		timeout: timeout,
+		signals: signals,
+	}
+}
+
+// Add registers c. Components are started in the order they were added, so
+// dependencies go first.
+func (m *Manager) Add(name string, c Component) {
+	m.components = append(m.components, entry{name: name, c: c})
+}
+
+// Run starts every component, waits for a signal, ctx to be done or a
+// component to fail, and then stops them all within the timeout.
+func (m *Manager) Run(ctx context.Context) error {
+	if err := m.start(ctx); err != nil {
+		return err
+	}
+
+	sigCtx, stop := signal.NotifyContext(ctx, m.signals...)
+	defer stop()
+
+	var runErr error
+	select {
+	case <-sigCtx.Done():
+		logger.Log.Info("Shutdown requested")
+	case runErr = <-m.failed():
+		logger.Log.Error("Component failed, shutting down", zap.Error(runErr))
+	}
+
+	stopCtx, cancel := context.WithTimeout(context.Background(), m.timeout)
+	defer cancel()
+	return errors.Join(runErr, m.stop(stopCtx, len(m.components)))
+}
+
+func (m *Manager) start(ctx context.Context) error {
+	for i, e := range m.components {
+		logger.Log.Info("Start component", zap.String("component", e.name))
+		if err := e.c.Start(ctx); err != nil {
+			stopCtx, cancel := context.WithTimeout(context.Background(), m.timeout)
+			defer cancel()
+			return errors.Join(fmt.Errorf("start %s error: %w", e.name, err), m.stop(stopCtx, i))
+		}
+	}
+	return nil
+}
+
+// stop stops the first n components in reverse order. A component that
+// fails to stop does not keep the rest from stopping.
+func (m *Manager) stop(ctx context.Context, n int) error {
+	var errs []error
+	for i := n - 1; i >= 0; i-- {
+		e := m.components[i]
+		logger.Log.Info("Stop component", zap.String("component", e.name))
+		if err := e.c.Stop(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("stop %s error: %w", e.name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// failed merges the failure channels of the components into one.
+func (m *Manager) failed() <-chan error {
+	out := make(chan error, len(m.components))
+	for _, e := range m.components {
+		f, ok := e.c.(Failer)
+		if !ok {
+			continue
+		}
+		go func(name string, ch <-chan error) {
+			if err, ok := <-ch; ok && err != nil {
+				out <- fmt.Errorf("%s error: %w", name, err)
+			}
+		}(e.name, f.Failed())
+	}
+	return out
+}
@@ -0,0 +1,140 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// journal records start and stop calls of every component in order.
+type journal struct {
+	mu    sync.Mutex
+	calls []string
+}
+
+func (j *journal) add(call string) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.calls = append(j.calls, call)
+}
+
+func (j *journal) get() []string {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return append([]string(nil), j.calls...)
+}
+
+type fakeComponent struct {
+	name     string
+	j        *journal
+	startErr error
+	// block makes Stop wait for the deadline.
+	block  bool
+	failed chan error
+}
+
+func (f *fakeComponent) Start(ctx context.Context) error {
+	f.j.add("start " + f.name)
+	return f.startErr
+}
+
+func (f *fakeComponent) Stop(ctx context.Context) error {
+	f.j.add("stop " + f.name)
+	if f.block {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	return nil
+}
+
+type failingComponent struct {
+	fakeComponent
+}
+
+func (f *failingComponent) Failed() <-chan error { return f.failed }
+
+func TestManager_StopsOnSignal(t *testing.T) {
+	j := &journal{}
+	m := NewManager(time.Second, syscall.SIGUSR1)
+	m.Add("storage", &fakeComponent{name: "storage", j: j})
+	m.Add("reaper", &fakeComponent{name: "reaper", j: j})
+	m.Add("server", &fakeComponent{name: "server", j: j})
+
+	done := make(chan error, 1)
+	go func() { done <- m.Run(context.Background()) }()
+
+	require.Eventually(t, func() bool { return len(j.get()) == 3 }, time.Second, time.Millisecond)
+	// Give Run time to subscribe to the signal before it is sent.
+	time.Sleep(20 * time.Millisecond)
+	require.NoError(t, syscall.Kill(syscall.Getpid(), syscall.SIGUSR1))
+
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("manager did not stop on signal")
+	}
+	assert.Equal(t, []string{
+		"start storage", "start reaper", "start server",
+		"stop server", "stop reaper", "stop storage",
+	}, j.get())
+}
+
+func TestManager_StopsOnContext(t *testing.T) {
+	j := &journal{}
+	m := NewManager(time.Second)
+	m.Add("storage", &fakeComponent{name: "storage", j: j})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.NoError(t, m.Run(ctx))
+	assert.Equal(t, []string{"start storage", "stop storage"}, j.get())
+}
+
+func TestManager_StartError(t *testing.T) {
+	j := &journal{}
+	m := NewManager(time.Second)
+	m.Add("storage", &fakeComponent{name: "storage", j: j})
+	m.Add("reaper", &fakeComponent{name: "reaper", j: j, startErr: errors.New("boom")})
+	m.Add("server", &fakeComponent{name: "server", j: j})
+
+	err := m.Run(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "start reaper error: boom")
+	assert.Equal(t, []string{"start storage", "start reaper", "stop storage"}, j.get())
+}
+
+func TestManager_StopsOnComponentFailure(t *testing.T) {
+	j := &journal{}
+	server := &failingComponent{fakeComponent{name: "server", j: j, failed: make(chan error, 1)}}
+	m := NewManager(time.Second)
+	m.Add("storage", &fakeComponent{name: "storage", j: j})
+	m.Add("server", server)
+
+	server.failed <- errors.New("listener closed")
+	err := m.Run(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "server error: listener closed")
+	assert.Equal(t, []string{"start storage", "start server", "stop server", "stop storage"}, j.get())
+}
+
+func TestManager_StopDeadline(t *testing.T) {
+	j := &journal{}
+	m := NewManager(20 * time.Millisecond)
+	m.Add("storage", &fakeComponent{name: "storage", j: j})
+	m.Add("server", &fakeComponent{name: "server", j: j, block: true})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	start := time.Now()
+	err := m.Run(ctx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), time.Second)
+	assert.Equal(t, []string{"start storage", "start server", "stop server", "stop storage"}, j.get())
+}
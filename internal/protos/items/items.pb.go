@@ -31,17 +31,27 @@ const (
 	ItemType_ITEM_TYPE_TEXT        ItemType = 3
 	ItemType_ITEM_TYPE_BINARY      ItemType = 4
 	ItemType_ITEM_TYPE_CARD        ItemType = 5
+	ItemType_ITEM_TYPE_SSH_KEY     ItemType = 6
+	ItemType_ITEM_TYPE_TOTP        ItemType = 7
+	ItemType_ITEM_TYPE_CUSTOM      ItemType = 8
+	ItemType_ITEM_TYPE_IDENTITY    ItemType = 9
+	ItemType_ITEM_TYPE_CONNECTION  ItemType = 10
 )
 
 // Enum value maps for ItemType.
 var (
 	ItemType_name = map[int32]string{
-		0: "ITEM_TYPE_EMPTY",
-		1: "ITEM_TYPE_UNSPECIFIED",
-		2: "ITEM_TYPE_CREDENTIALS",
-		3: "ITEM_TYPE_TEXT",
-		4: "ITEM_TYPE_BINARY",
-		5: "ITEM_TYPE_CARD",
+		0:  "ITEM_TYPE_EMPTY",
+		1:  "ITEM_TYPE_UNSPECIFIED",
+		2:  "ITEM_TYPE_CREDENTIALS",
+		3:  "ITEM_TYPE_TEXT",
+		4:  "ITEM_TYPE_BINARY",
+		5:  "ITEM_TYPE_CARD",
+		6:  "ITEM_TYPE_SSH_KEY",
+		7:  "ITEM_TYPE_TOTP",
+		8:  "ITEM_TYPE_CUSTOM",
+		9:  "ITEM_TYPE_IDENTITY",
+		10: "ITEM_TYPE_CONNECTION",
 	}
 	ItemType_value = map[string]int32{
 		"ITEM_TYPE_EMPTY":       0,
@@ -50,6 +60,11 @@ var (
 		"ITEM_TYPE_TEXT":        3,
 		"ITEM_TYPE_BINARY":      4,
 		"ITEM_TYPE_CARD":        5,
+		"ITEM_TYPE_SSH_KEY":     6,
+		"ITEM_TYPE_TOTP":        7,
+		"ITEM_TYPE_CUSTOM":      8,
+		"ITEM_TYPE_IDENTITY":    9,
+		"ITEM_TYPE_CONNECTION":  10,
 	}
 )
 
@@ -80,18 +95,128 @@ func (ItemType) EnumDescriptor() ([]byte, []int) {
 	return file_internal_protos_items_items_proto_rawDescGZIP(), []int{0}
 }
 
+type EmergencyAccessType int32
+
+const (
+	EmergencyAccessType_EMERGENCY_ACCESS_TYPE_UNSPECIFIED EmergencyAccessType = 0
+	EmergencyAccessType_EMERGENCY_ACCESS_TYPE_VIEW        EmergencyAccessType = 1
+	EmergencyAccessType_EMERGENCY_ACCESS_TYPE_TAKEOVER    EmergencyAccessType = 2
+)
+
+// Enum value maps for EmergencyAccessType.
+var (
+	EmergencyAccessType_name = map[int32]string{
+		0: "EMERGENCY_ACCESS_TYPE_UNSPECIFIED",
+		1: "EMERGENCY_ACCESS_TYPE_VIEW",
+		2: "EMERGENCY_ACCESS_TYPE_TAKEOVER",
+	}
+	EmergencyAccessType_value = map[string]int32{
+		"EMERGENCY_ACCESS_TYPE_UNSPECIFIED": 0,
+		"EMERGENCY_ACCESS_TYPE_VIEW":        1,
+		"EMERGENCY_ACCESS_TYPE_TAKEOVER":    2,
+	}
+)
+
+func (x EmergencyAccessType) Enum() *EmergencyAccessType {
+	p := new(EmergencyAccessType)
+	*p = x
+	return p
+}
+
+func (x EmergencyAccessType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (EmergencyAccessType) Descriptor() protoreflect.EnumDescriptor {
+	return file_internal_protos_items_items_proto_enumTypes[1].Descriptor()
+}
+
+func (EmergencyAccessType) Type() protoreflect.EnumType {
+	return &file_internal_protos_items_items_proto_enumTypes[1]
+}
+
+func (x EmergencyAccessType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use EmergencyAccessType.Descriptor instead.
+func (EmergencyAccessType) EnumDescriptor() ([]byte, []int) {
+	return file_internal_protos_items_items_proto_rawDescGZIP(), []int{1}
+}
+
+type EmergencyStatus int32
+
+const (
+	EmergencyStatus_EMERGENCY_STATUS_UNSPECIFIED EmergencyStatus = 0
+	EmergencyStatus_EMERGENCY_STATUS_IDLE        EmergencyStatus = 1
+	EmergencyStatus_EMERGENCY_STATUS_REQUESTED   EmergencyStatus = 2
+	EmergencyStatus_EMERGENCY_STATUS_APPROVED    EmergencyStatus = 3
+)
+
+// Enum value maps for EmergencyStatus.
+var (
+	EmergencyStatus_name = map[int32]string{
+		0: "EMERGENCY_STATUS_UNSPECIFIED",
+		1: "EMERGENCY_STATUS_IDLE",
+		2: "EMERGENCY_STATUS_REQUESTED",
+		3: "EMERGENCY_STATUS_APPROVED",
+	}
+	EmergencyStatus_value = map[string]int32{
+		"EMERGENCY_STATUS_UNSPECIFIED": 0,
+		"EMERGENCY_STATUS_IDLE":        1,
+		"EMERGENCY_STATUS_REQUESTED":   2,
+		"EMERGENCY_STATUS_APPROVED":    3,
+	}
+)
+
+func (x EmergencyStatus) Enum() *EmergencyStatus {
+	p := new(EmergencyStatus)
+	*p = x
+	return p
+}
+
+func (x EmergencyStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (EmergencyStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_internal_protos_items_items_proto_enumTypes[2].Descriptor()
+}
+
+func (EmergencyStatus) Type() protoreflect.EnumType {
+	return &file_internal_protos_items_items_proto_enumTypes[2]
+}
+
+func (x EmergencyStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use EmergencyStatus.Descriptor instead.
+func (EmergencyStatus) EnumDescriptor() ([]byte, []int) {
+	return file_internal_protos_items_items_proto_rawDescGZIP(), []int{2}
+}
+
 type EncryptedItem struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            []byte                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	UserLogin     string                 `protobuf:"bytes,2,opt,name=user_login,json=userLogin,proto3" json:"user_login,omitempty"`
-	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
-	Type          ItemType               `protobuf:"varint,4,opt,name=type,proto3,enum=items.ItemType" json:"type,omitempty"`
-	EncryptedData *EncryptedData         `protobuf:"bytes,5,opt,name=encrypted_data,json=encryptedData,proto3" json:"encrypted_data,omitempty"`
-	Meta          map[string]string      `protobuf:"bytes,6,rep,name=meta,proto3" json:"meta,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              []byte                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserLogin       string                 `protobuf:"bytes,2,opt,name=user_login,json=userLogin,proto3" json:"user_login,omitempty"`
+	Name            string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Type            ItemType               `protobuf:"varint,4,opt,name=type,proto3,enum=items.ItemType" json:"type,omitempty"`
+	EncryptedData   *EncryptedData         `protobuf:"bytes,5,opt,name=encrypted_data,json=encryptedData,proto3" json:"encrypted_data,omitempty"`
+	Meta            map[string]string      `protobuf:"bytes,6,rep,name=meta,proto3" json:"meta,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	CreatedAt       *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt       *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	EncryptedKey    string                 `protobuf:"bytes,9,opt,name=encrypted_key,json=encryptedKey,proto3" json:"encrypted_key,omitempty"`
+	CollectionId    []byte                 `protobuf:"bytes,10,opt,name=collection_id,json=collectionId,proto3" json:"collection_id,omitempty"`
+	Format          uint32                 `protobuf:"varint,11,opt,name=format,proto3" json:"format,omitempty"`
+	SearchTokens    []string               `protobuf:"bytes,12,rep,name=search_tokens,json=searchTokens,proto3" json:"search_tokens,omitempty"`
+	ExpiresAt       *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	Favorite        bool                   `protobuf:"varint,14,opt,name=favorite,proto3" json:"favorite,omitempty"`
+	LastUsedAt      *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=last_used_at,json=lastUsedAt,proto3" json:"last_used_at,omitempty"`
+	UseCount        int32                  `protobuf:"varint,16,opt,name=use_count,json=useCount,proto3" json:"use_count,omitempty"`
+	EncryptedHeader *EncryptedData         `protobuf:"bytes,17,opt,name=encrypted_header,json=encryptedHeader,proto3" json:"encrypted_header,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *EncryptedItem) Reset() {
@@ -180,6 +305,69 @@ func (x *EncryptedItem) GetUpdatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *EncryptedItem) GetEncryptedKey() string {
+	if x != nil {
+		return x.EncryptedKey
+	}
+	return ""
+}
+
+func (x *EncryptedItem) GetCollectionId() []byte {
+	if x != nil {
+		return x.CollectionId
+	}
+	return nil
+}
+
+func (x *EncryptedItem) GetFormat() uint32 {
+	if x != nil {
+		return x.Format
+	}
+	return 0
+}
+
+func (x *EncryptedItem) GetSearchTokens() []string {
+	if x != nil {
+		return x.SearchTokens
+	}
+	return nil
+}
+
+func (x *EncryptedItem) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *EncryptedItem) GetFavorite() bool {
+	if x != nil {
+		return x.Favorite
+	}
+	return false
+}
+
+func (x *EncryptedItem) GetLastUsedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastUsedAt
+	}
+	return nil
+}
+
+func (x *EncryptedItem) GetUseCount() int32 {
+	if x != nil {
+		return x.UseCount
+	}
+	return 0
+}
+
+func (x *EncryptedItem) GetEncryptedHeader() *EncryptedData {
+	if x != nil {
+		return x.EncryptedHeader
+	}
+	return nil
+}
+
 type EncryptedData struct {
 	state            protoimpl.MessageState `protogen:"open.v1"`
 	EncryptedContent string                 `protobuf:"bytes,1,opt,name=encrypted_content,json=encryptedContent,proto3" json:"encrypted_content,omitempty"`
@@ -324,6 +512,7 @@ type GetUserItemsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	UserLogin     string                 `protobuf:"bytes,1,opt,name=user_login,json=userLogin,proto3" json:"user_login,omitempty"`
 	Type          ItemType               `protobuf:"varint,2,opt,name=type,proto3,enum=items.ItemType" json:"type,omitempty"`
+	CollectionId  []byte                 `protobuf:"bytes,3,opt,name=collection_id,json=collectionId,proto3" json:"collection_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -372,6 +561,13 @@ func (x *GetUserItemsRequest) GetType() ItemType {
 	return ItemType_ITEM_TYPE_EMPTY
 }
 
+func (x *GetUserItemsRequest) GetCollectionId() []byte {
+	if x != nil {
+		return x.CollectionId
+	}
+	return nil
+}
+
 type GetUserItemsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Items         []*EncryptedItem       `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
@@ -603,6 +799,7 @@ func (x *DeleteItemResponse) GetSuccess() bool {
 type TypesCountsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	UserLogin     string                 `protobuf:"bytes,1,opt,name=user_login,json=userLogin,proto3" json:"user_login,omitempty"`
+	CollectionId  []byte                 `protobuf:"bytes,2,opt,name=collection_id,json=collectionId,proto3" json:"collection_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -644,6 +841,13 @@ func (x *TypesCountsRequest) GetUserLogin() string {
 	return ""
 }
 
+func (x *TypesCountsRequest) GetCollectionId() []byte {
+	if x != nil {
+		return x.CollectionId
+	}
+	return nil
+}
+
 type TypesCountsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Types         map[string]int32       `protobuf:"bytes,1,rep,name=types,proto3" json:"types,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
@@ -688,72 +892,3160 @@ func (x *TypesCountsResponse) GetTypes() map[string]int32 {
 	return nil
 }
 
-var File_internal_protos_items_items_proto protoreflect.FileDescriptor
+type SearchItemsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tokens        []string               `protobuf:"bytes,1,rep,name=tokens,proto3" json:"tokens,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_internal_protos_items_items_proto_rawDesc = "" +
-	"\n" +
-	"!internal/protos/items/items.proto\x12\x05items\x1a\x1fgoogle/protobuf/timestamp.proto\"\x97\x03\n" +
-	"\rEncryptedItem\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\fR\x02id\x12\x1d\n" +
-	"\n" +
-	"user_login\x18\x02 \x01(\tR\tuserLogin\x12\x12\n" +
-	"\x04name\x18\x03 \x01(\tR\x04name\x12#\n" +
-	"\x04type\x18\x04 \x01(\x0e2\x0f.items.ItemTypeR\x04type\x12;\n" +
-	"\x0eencrypted_data\x18\x05 \x01(\v2\x14.items.EncryptedDataR\rencryptedData\x122\n" +
-	"\x04meta\x18\x06 \x03(\v2\x1e.items.EncryptedItem.MetaEntryR\x04meta\x129\n" +
-	"\n" +
-	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
-	"\n" +
-	"updated_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x1a7\n" +
-	"\tMetaEntry\x12\x10\n" +
-	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"R\n" +
-	"\rEncryptedData\x12+\n" +
-	"\x11encrypted_content\x18\x01 \x01(\tR\x10encryptedContent\x12\x14\n" +
-	"\x05nonce\x18\x02 \x01(\tR\x05nonce\":\n" +
-	"\x0eAddItemRequest\x12(\n" +
-	"\x04item\x18\x01 \x01(\v2\x14.items.EncryptedItemR\x04item\"+\n" +
-	"\x0fAddItemResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\"Y\n" +
-	"\x13GetUserItemsRequest\x12\x1d\n" +
-	"\n" +
-	"user_login\x18\x01 \x01(\tR\tuserLogin\x12#\n" +
-	"\x04type\x18\x02 \x01(\x0e2\x0f.items.ItemTypeR\x04type\"B\n" +
-	"\x14GetUserItemsResponse\x12*\n" +
-	"\x05items\x18\x01 \x03(\v2\x14.items.EncryptedItemR\x05items\";\n" +
-	"\x0fEditItemRequest\x12(\n" +
-	"\x04item\x18\x01 \x01(\v2\x14.items.EncryptedItemR\x04item\",\n" +
-	"\x10EditItemResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\"K\n" +
-	"\x11DeleteItemRequest\x12\x1d\n" +
-	"\n" +
-	"user_login\x18\x01 \x01(\tR\tuserLogin\x12\x17\n" +
-	"\aitem_id\x18\x02 \x01(\fR\x06itemId\".\n" +
-	"\x12DeleteItemResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\"3\n" +
-	"\x12TypesCountsRequest\x12\x1d\n" +
-	"\n" +
-	"user_login\x18\x01 \x01(\tR\tuserLogin\"\x8c\x01\n" +
-	"\x13TypesCountsResponse\x12;\n" +
-	"\x05types\x18\x01 \x03(\v2%.items.TypesCountsResponse.TypesEntryR\x05types\x1a8\n" +
-	"\n" +
-	"TypesEntry\x12\x10\n" +
-	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01*\x93\x01\n" +
-	"\bItemType\x12\x13\n" +
-	"\x0fITEM_TYPE_EMPTY\x10\x00\x12\x19\n" +
-	"\x15ITEM_TYPE_UNSPECIFIED\x10\x01\x12\x19\n" +
-	"\x15ITEM_TYPE_CREDENTIALS\x10\x02\x12\x12\n" +
-	"\x0eITEM_TYPE_TEXT\x10\x03\x12\x14\n" +
-	"\x10ITEM_TYPE_BINARY\x10\x04\x12\x12\n" +
-	"\x0eITEM_TYPE_CARD\x10\x052\xda\x02\n" +
-	"\x0fItemsController\x128\n" +
-	"\aAddItem\x12\x15.items.AddItemRequest\x1a\x16.items.AddItemResponse\x12;\n" +
-	"\bEditItem\x12\x16.items.EditItemRequest\x1a\x17.items.EditItemResponse\x12A\n" +
-	"\n" +
-	"DeleteItem\x12\x18.items.DeleteItemRequest\x1a\x19.items.DeleteItemResponse\x12G\n" +
-	"\fGetUserItems\x12\x1a.items.GetUserItemsRequest\x1a\x1b.items.GetUserItemsResponse\x12D\n" +
-	"\vTypesCounts\x12\x19.items.TypesCountsRequest\x1a\x1a.items.TypesCountsResponseB\fZ\n" +
+func (x *SearchItemsRequest) Reset() {
+	*x = SearchItemsRequest{}
+	mi := &file_internal_protos_items_items_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchItemsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchItemsRequest) ProtoMessage() {}
+
+func (x *SearchItemsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protos_items_items_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchItemsRequest.ProtoReflect.Descriptor instead.
+func (*SearchItemsRequest) Descriptor() ([]byte, []int) {
+	return file_internal_protos_items_items_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *SearchItemsRequest) GetTokens() []string {
+	if x != nil {
+		return x.Tokens
+	}
+	return nil
+}
+
+type SearchItemsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*EncryptedItem       `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchItemsResponse) Reset() {
+	*x = SearchItemsResponse{}
+	mi := &file_internal_protos_items_items_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchItemsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchItemsResponse) ProtoMessage() {}
+
+func (x *SearchItemsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protos_items_items_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchItemsResponse.ProtoReflect.Descriptor instead.
+func (*SearchItemsResponse) Descriptor() ([]byte, []int) {
+	return file_internal_protos_items_items_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *SearchItemsResponse) GetItems() []*EncryptedItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type TouchItemRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ItemId        []byte                 `protobuf:"bytes,1,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TouchItemRequest) Reset() {
+	*x = TouchItemRequest{}
+	mi := &file_internal_protos_items_items_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TouchItemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TouchItemRequest) ProtoMessage() {}
+
+func (x *TouchItemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protos_items_items_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TouchItemRequest.ProtoReflect.Descriptor instead.
+func (*TouchItemRequest) Descriptor() ([]byte, []int) {
+	return file_internal_protos_items_items_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *TouchItemRequest) GetItemId() []byte {
+	if x != nil {
+		return x.ItemId
+	}
+	return nil
+}
+
+type TouchItemResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TouchItemResponse) Reset() {
+	*x = TouchItemResponse{}
+	mi := &file_internal_protos_items_items_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TouchItemResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TouchItemResponse) ProtoMessage() {}
+
+func (x *TouchItemResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protos_items_items_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TouchItemResponse.ProtoReflect.Descriptor instead.
+func (*TouchItemResponse) Descriptor() ([]byte, []int) {
+	return file_internal_protos_items_items_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *TouchItemResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type SetItemFavoriteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ItemId        []byte                 `protobuf:"bytes,1,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	Favorite      bool                   `protobuf:"varint,2,opt,name=favorite,proto3" json:"favorite,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetItemFavoriteRequest) Reset() {
+	*x = SetItemFavoriteRequest{}
+	mi := &file_internal_protos_items_items_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetItemFavoriteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetItemFavoriteRequest) ProtoMessage() {}
+
+func (x *SetItemFavoriteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protos_items_items_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetItemFavoriteRequest.ProtoReflect.Descriptor instead.
+func (*SetItemFavoriteRequest) Descriptor() ([]byte, []int) {
+	return file_internal_protos_items_items_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *SetItemFavoriteRequest) GetItemId() []byte {
+	if x != nil {
+		return x.ItemId
+	}
+	return nil
+}
+
+func (x *SetItemFavoriteRequest) GetFavorite() bool {
+	if x != nil {
+		return x.Favorite
+	}
+	return false
+}
+
+type SetItemFavoriteResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetItemFavoriteResponse) Reset() {
+	*x = SetItemFavoriteResponse{}
+	mi := &file_internal_protos_items_items_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetItemFavoriteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetItemFavoriteResponse) ProtoMessage() {}
+
+func (x *SetItemFavoriteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protos_items_items_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetItemFavoriteResponse.ProtoReflect.Descriptor instead.
+func (*SetItemFavoriteResponse) Descriptor() ([]byte, []int) {
+	return file_internal_protos_items_items_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *SetItemFavoriteResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type Attachment struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            []byte                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ItemId        []byte                 `protobuf:"bytes,2,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	EncryptedInfo *EncryptedData         `protobuf:"bytes,3,opt,name=encrypted_info,json=encryptedInfo,proto3" json:"encrypted_info,omitempty"`
+	Size          int64                  `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Attachment) Reset() {
+	*x = Attachment{}
+	mi := &file_internal_protos_items_items_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Attachment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Attachment) ProtoMessage() {}
+
+func (x *Attachment) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protos_items_items_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Attachment.ProtoReflect.Descriptor instead.
+func (*Attachment) Descriptor() ([]byte, []int) {
+	return file_internal_protos_items_items_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *Attachment) GetId() []byte {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *Attachment) GetItemId() []byte {
+	if x != nil {
+		return x.ItemId
+	}
+	return nil
+}
+
+func (x *Attachment) GetEncryptedInfo() *EncryptedData {
+	if x != nil {
+		return x.EncryptedInfo
+	}
+	return nil
+}
+
+func (x *Attachment) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *Attachment) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type UploadAttachmentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Attachment    *Attachment            `protobuf:"bytes,1,opt,name=attachment,proto3" json:"attachment,omitempty"`
+	Content       []byte                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	Nonce         []byte                 `protobuf:"bytes,3,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadAttachmentRequest) Reset() {
+	*x = UploadAttachmentRequest{}
+	mi := &file_internal_protos_items_items_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadAttachmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadAttachmentRequest) ProtoMessage() {}
+
+func (x *UploadAttachmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protos_items_items_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadAttachmentRequest.ProtoReflect.Descriptor instead.
+func (*UploadAttachmentRequest) Descriptor() ([]byte, []int) {
+	return file_internal_protos_items_items_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *UploadAttachmentRequest) GetAttachment() *Attachment {
+	if x != nil {
+		return x.Attachment
+	}
+	return nil
+}
+
+func (x *UploadAttachmentRequest) GetContent() []byte {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+func (x *UploadAttachmentRequest) GetNonce() []byte {
+	if x != nil {
+		return x.Nonce
+	}
+	return nil
+}
+
+type UploadAttachmentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            []byte                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadAttachmentResponse) Reset() {
+	*x = UploadAttachmentResponse{}
+	mi := &file_internal_protos_items_items_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadAttachmentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadAttachmentResponse) ProtoMessage() {}
+
+func (x *UploadAttachmentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protos_items_items_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadAttachmentResponse.ProtoReflect.Descriptor instead.
+func (*UploadAttachmentResponse) Descriptor() ([]byte, []int) {
+	return file_internal_protos_items_items_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *UploadAttachmentResponse) GetId() []byte {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+type ListAttachmentsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ItemId        []byte                 `protobuf:"bytes,1,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAttachmentsRequest) Reset() {
+	*x = ListAttachmentsRequest{}
+	mi := &file_internal_protos_items_items_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAttachmentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAttachmentsRequest) ProtoMessage() {}
+
+func (x *ListAttachmentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protos_items_items_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAttachmentsRequest.ProtoReflect.Descriptor instead.
+func (*ListAttachmentsRequest) Descriptor() ([]byte, []int) {
+	return file_internal_protos_items_items_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ListAttachmentsRequest) GetItemId() []byte {
+	if x != nil {
+		return x.ItemId
+	}
+	return nil
+}
+
+type ListAttachmentsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Attachments   []*Attachment          `protobuf:"bytes,1,rep,name=attachments,proto3" json:"attachments,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAttachmentsResponse) Reset() {
+	*x = ListAttachmentsResponse{}
+	mi := &file_internal_protos_items_items_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAttachmentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAttachmentsResponse) ProtoMessage() {}
+
+func (x *ListAttachmentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protos_items_items_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAttachmentsResponse.ProtoReflect.Descriptor instead.
+func (*ListAttachmentsResponse) Descriptor() ([]byte, []int) {
+	return file_internal_protos_items_items_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ListAttachmentsResponse) GetAttachments() []*Attachment {
+	if x != nil {
+		return x.Attachments
+	}
+	return nil
+}
+
+type DownloadAttachmentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ItemId        []byte                 `protobuf:"bytes,1,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	AttachmentId  []byte                 `protobuf:"bytes,2,opt,name=attachment_id,json=attachmentId,proto3" json:"attachment_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DownloadAttachmentRequest) Reset() {
+	*x = DownloadAttachmentRequest{}
+	mi := &file_internal_protos_items_items_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadAttachmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadAttachmentRequest) ProtoMessage() {}
+
+func (x *DownloadAttachmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protos_items_items_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadAttachmentRequest.ProtoReflect.Descriptor instead.
+func (*DownloadAttachmentRequest) Descriptor() ([]byte, []int) {
+	return file_internal_protos_items_items_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *DownloadAttachmentRequest) GetItemId() []byte {
+	if x != nil {
+		return x.ItemId
+	}
+	return nil
+}
+
+func (x *DownloadAttachmentRequest) GetAttachmentId() []byte {
+	if x != nil {
+		return x.AttachmentId
+	}
+	return nil
+}
+
+type DownloadAttachmentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Attachment    *Attachment            `protobuf:"bytes,1,opt,name=attachment,proto3" json:"attachment,omitempty"`
+	Content       []byte                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	Nonce         []byte                 `protobuf:"bytes,3,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DownloadAttachmentResponse) Reset() {
+	*x = DownloadAttachmentResponse{}
+	mi := &file_internal_protos_items_items_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadAttachmentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadAttachmentResponse) ProtoMessage() {}
+
+func (x *DownloadAttachmentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protos_items_items_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadAttachmentResponse.ProtoReflect.Descriptor instead.
+func (*DownloadAttachmentResponse) Descriptor() ([]byte, []int) {
+	return file_internal_protos_items_items_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *DownloadAttachmentResponse) GetAttachment() *Attachment {
+	if x != nil {
+		return x.Attachment
+	}
+	return nil
+}
+
+func (x *DownloadAttachmentResponse) GetContent() []byte {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+func (x *DownloadAttachmentResponse) GetNonce() []byte {
+	if x != nil {
+		return x.Nonce
+	}
+	return nil
+}
+
+type DeleteAttachmentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ItemId        []byte                 `protobuf:"bytes,1,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	AttachmentId  []byte                 `protobuf:"bytes,2,opt,name=attachment_id,json=attachmentId,proto3" json:"attachment_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteAttachmentRequest) Reset() {
+	*x = DeleteAttachmentRequest{}
+	mi := &file_internal_protos_items_items_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAttachmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAttachmentRequest) ProtoMessage() {}
+
+func (x *DeleteAttachmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protos_items_items_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAttachmentRequest.ProtoReflect.Descriptor instead.
+func (*DeleteAttachmentRequest) Descriptor() ([]byte, []int) {
+	return file_internal_protos_items_items_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *DeleteAttachmentRequest) GetItemId() []byte {
+	if x != nil {
+		return x.ItemId
+	}
+	return nil
+}
+
+func (x *DeleteAttachmentRequest) GetAttachmentId() []byte {
+	if x != nil {
+		return x.AttachmentId
+	}
+	return nil
+}
+
+type DeleteAttachmentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteAttachmentResponse) Reset() {
+	*x = DeleteAttachmentResponse{}
+	mi := &file_internal_protos_items_items_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAttachmentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAttachmentResponse) ProtoMessage() {}
+
+func (x *DeleteAttachmentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protos_items_items_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAttachmentResponse.ProtoReflect.Descriptor instead.
+func (*DeleteAttachmentResponse) Descriptor() ([]byte, []int) {
+	return file_internal_protos_items_items_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *DeleteAttachmentResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type ItemTemplate struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            []byte                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	EncryptedData *EncryptedData         `protobuf:"bytes,2,opt,name=encrypted_data,json=encryptedData,proto3" json:"encrypted_data,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ItemTemplate) Reset() {
+	*x = ItemTemplate{}
+	mi := &file_internal_protos_items_items_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ItemTemplate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ItemTemplate) ProtoMessage() {}
+
+func (x *ItemTemplate) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protos_items_items_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ItemTemplate.ProtoReflect.Descriptor instead.
+func (*ItemTemplate) Descriptor() ([]byte, []int) {
+	return file_internal_protos_items_items_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ItemTemplate) GetId() []byte {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *ItemTemplate) GetEncryptedData() *EncryptedData {
+	if x != nil {
+		return x.EncryptedData
+	}
+	return nil
+}
+
+func (x *ItemTemplate) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *ItemTemplate) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type SaveTemplateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Template      *ItemTemplate          `protobuf:"bytes,1,opt,name=template,proto3" json:"template,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SaveTemplateRequest) Reset() {
+	*x = SaveTemplateRequest{}
+	mi := &file_internal_protos_items_items_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SaveTemplateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SaveTemplateRequest) ProtoMessage() {}
+
+func (x *SaveTemplateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protos_items_items_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SaveTemplateRequest.ProtoReflect.Descriptor instead.
+func (*SaveTemplateRequest) Descriptor() ([]byte, []int) {
+	return file_internal_protos_items_items_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *SaveTemplateRequest) GetTemplate() *ItemTemplate {
+	if x != nil {
+		return x.Template
+	}
+	return nil
+}
+
+type SaveTemplateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SaveTemplateResponse) Reset() {
+	*x = SaveTemplateResponse{}
+	mi := &file_internal_protos_items_items_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SaveTemplateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SaveTemplateResponse) ProtoMessage() {}
+
+func (x *SaveTemplateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protos_items_items_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SaveTemplateResponse.ProtoReflect.Descriptor instead.
+func (*SaveTemplateResponse) Descriptor() ([]byte, []int) {
+	return file_internal_protos_items_items_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *SaveTemplateResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type ListTemplatesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTemplatesRequest) Reset() {
+	*x = ListTemplatesRequest{}
+	mi := &file_internal_protos_items_items_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTemplatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTemplatesRequest) ProtoMessage() {}
+
+func (x *ListTemplatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protos_items_items_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTemplatesRequest.ProtoReflect.Descriptor instead.
+func (*ListTemplatesRequest) Descriptor() ([]byte, []int) {
+	return file_internal_protos_items_items_proto_rawDescGZIP(), []int{30}
+}
+
+type ListTemplatesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Templates     []*ItemTemplate        `protobuf:"bytes,1,rep,name=templates,proto3" json:"templates,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTemplatesResponse) Reset() {
+	*x = ListTemplatesResponse{}
+	mi := &file_internal_protos_items_items_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTemplatesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTemplatesResponse) ProtoMessage() {}
+
+func (x *ListTemplatesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protos_items_items_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTemplatesResponse.ProtoReflect.Descriptor instead.
+func (*ListTemplatesResponse) Descriptor() ([]byte, []int) {
+	return file_internal_protos_items_items_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ListTemplatesResponse) GetTemplates() []*ItemTemplate {
+	if x != nil {
+		return x.Templates
+	}
+	return nil
+}
+
+type DeleteTemplateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            []byte                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTemplateRequest) Reset() {
+	*x = DeleteTemplateRequest{}
+	mi := &file_internal_protos_items_items_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTemplateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTemplateRequest) ProtoMessage() {}
+
+func (x *DeleteTemplateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protos_items_items_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTemplateRequest.ProtoReflect.Descriptor instead.
+func (*DeleteTemplateRequest) Descriptor() ([]byte, []int) {
+	return file_internal_protos_items_items_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *DeleteTemplateRequest) GetId() []byte {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+type DeleteTemplateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTemplateResponse) Reset() {
+	*x = DeleteTemplateResponse{}
+	mi := &file_internal_protos_items_items_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTemplateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTemplateResponse) ProtoMessage() {}
+
+func (x *DeleteTemplateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protos_items_items_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTemplateResponse.ProtoReflect.Descriptor instead.
+func (*DeleteTemplateResponse) Descriptor() ([]byte, []int) {
+	return file_internal_protos_items_items_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *DeleteTemplateResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type UserKeys struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	PublicKey           []byte                 `protobuf:"bytes,1,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	EncryptedPrivateKey string                 `protobuf:"bytes,2,opt,name=encrypted_private_key,json=encryptedPrivateKey,proto3" json:"encrypted_private_key,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *UserKeys) Reset() {
+	*x = UserKeys{}
+	mi := &file_internal_protos_items_items_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserKeys) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserKeys) ProtoMessage() {}
+
+func (x *UserKeys) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protos_items_items_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserKeys.ProtoReflect.Descriptor instead.
+func (*UserKeys) Descriptor() ([]byte, []int) {
+	return file_internal_protos_items_items_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *UserKeys) GetPublicKey() []byte {
+	if x != nil {
+		return x.PublicKey
+	}
+	return nil
+}
+
+func (x *UserKeys) GetEncryptedPrivateKey() string {
+	if x != nil {
+		return x.EncryptedPrivateKey
+	}
+	return ""
+}
+
+type ItemShare struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ItemId         []byte                 `protobuf:"bytes,1,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	RecipientLogin string                 `protobuf:"bytes,2,opt,name=recipient_login,json=recipientLogin,proto3" json:"recipient_login,omitempty"`
+	WrappedKey     string                 `protobuf:"bytes,3,opt,name=wrapped_key,json=wrappedKey,proto3" json:"wrapped_key,omitempty"`
+	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ItemShare) Reset() {
+	*x = ItemShare{}
+	mi := &file_internal_protos_items_items_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ItemShare) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ItemShare) ProtoMessage() {}
+
+func (x *ItemShare) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protos_items_items_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ItemShare.ProtoReflect.Descriptor instead.
+func (*ItemShare) Descriptor() ([]byte, []int) {
+	return file_internal_protos_items_items_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *ItemShare) GetItemId() []byte {
+	if x != nil {
+		return x.ItemId
+	}
+	return nil
+}
+
+func (x *ItemShare) GetRecipientLogin() string {
+	if x != nil {
+		return x.RecipientLogin
+	}
+	return ""
+}
+
+func (x *ItemShare) GetWrappedKey() string {
+	if x != nil {
+		return x.WrappedKey
+	}
+	return ""
+}
+
+func (x *ItemShare) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type SharedItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Item          *EncryptedItem         `protobuf:"bytes,1,opt,name=item,proto3" json:"item,omitempty"`
+	WrappedKey    string                 `protobuf:"bytes,2,opt,name=wrapped_key,json=wrappedKey,proto3" json:"wrapped_key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SharedItem) Reset() {
+	*x = SharedItem{}
+	mi := &file_internal_protos_items_items_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SharedItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SharedItem) ProtoMessage() {}
+
+func (x *SharedItem) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protos_items_items_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SharedItem.ProtoReflect.Descriptor instead.
+func (*SharedItem) Descriptor() ([]byte, []int) {
+	return file_internal_protos_items_items_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *SharedItem) GetItem() *EncryptedItem {
+	if x != nil {
+		return x.Item
+	}
+	return nil
+}
+
+func (x *SharedItem) GetWrappedKey() string {
+	if x != nil {
+		return x.WrappedKey
+	}
+	return ""
+}
+
+type SetUserKeysRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Keys          *UserKeys              `protobuf:"bytes,1,opt,name=keys,proto3" json:"keys,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetUserKeysRequest) Reset() {
+	*x = SetUserKeysRequest{}
+	mi := &file_internal_protos_items_items_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetUserKeysRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetUserKeysRequest) ProtoMessage() {}
+
+func (x *SetUserKeysRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protos_items_items_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetUserKeysRequest.ProtoReflect.Descriptor instead.
+func (*SetUserKeysRequest) Descriptor() ([]byte, []int) {
+	return file_internal_protos_items_items_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *SetUserKeysRequest) GetKeys() *UserKeys {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+type SetUserKeysResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetUserKeysResponse) Reset() {
+	*x = SetUserKeysResponse{}
+	mi := &file_internal_protos_items_items_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetUserKeysResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetUserKeysResponse) ProtoMessage() {}
+
+func (x *SetUserKeysResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protos_items_items_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetUserKeysResponse.ProtoReflect.Descriptor instead.
+func (*SetUserKeysResponse) Descriptor() ([]byte, []int) {
+	return file_internal_protos_items_items_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *SetUserKeysResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type GetUserKeysRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserKeysRequest) Reset() {
+	*x = GetUserKeysRequest{}
+	mi := &file_internal_protos_items_items_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserKeysRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserKeysRequest) ProtoMessage() {}
+
+func (x *GetUserKeysRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protos_items_items_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserKeysRequest.ProtoReflect.Descriptor instead.
+func (*GetUserKeysRequest) Descriptor() ([]byte, []int) {
+	return file_internal_protos_items_items_proto_rawDescGZIP(), []int{39}
+}
+
+type GetUserKeysResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Keys          *UserKeys              `protobuf:"bytes,1,opt,name=keys,proto3" json:"keys,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserKeysResponse) Reset() {
+	*x = GetUserKeysResponse{}
+	mi := &file_internal_protos_items_items_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserKeysResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserKeysResponse) ProtoMessage() {}
+
+func (x *GetUserKeysResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protos_items_items_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserKeysResponse.ProtoReflect.Descriptor instead.
+func (*GetUserKeysResponse) Descriptor() ([]byte, []int) {
+	return file_internal_protos_items_items_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *GetUserKeysResponse) GetKeys() *UserKeys {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+type GetPublicKeyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Login         string                 `protobuf:"bytes,1,opt,name=login,proto3" json:"login,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPublicKeyRequest) Reset() {
+	*x = GetPublicKeyRequest{}
+	mi := &file_internal_protos_items_items_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPublicKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPublicKeyRequest) ProtoMessage() {}
+
+func (x *GetPublicKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protos_items_items_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPublicKeyRequest.ProtoReflect.Descriptor instead.
+func (*GetPublicKeyRequest) Descriptor() ([]byte, []int) {
+	return file_internal_protos_items_items_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *GetPublicKeyRequest) GetLogin() string {
+	if x != nil {
+		return x.Login
+	}
+	return ""
+}
+
+type GetPublicKeyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PublicKey     []byte                 `protobuf:"bytes,1,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPublicKeyResponse) Reset() {
+	*x = GetPublicKeyResponse{}
+	mi := &file_internal_protos_items_items_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPublicKeyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPublicKeyResponse) ProtoMessage() {}
+
+func (x *GetPublicKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protos_items_items_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPublicKeyResponse.ProtoReflect.Descriptor instead.
+func (*GetPublicKeyResponse) Descriptor() ([]byte, []int) {
+	return file_internal_protos_items_items_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *GetPublicKeyResponse) GetPublicKey() []byte {
+	if x != nil {
+		return x.PublicKey
+	}
+	return nil
+}
+
+type ShareItemRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Share         *ItemShare             `protobuf:"bytes,1,opt,name=share,proto3" json:"share,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShareItemRequest) Reset() {
+	*x = ShareItemRequest{}
+	mi := &file_internal_protos_items_items_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShareItemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShareItemRequest) ProtoMessage() {}
+
+func (x *ShareItemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protos_items_items_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShareItemRequest.ProtoReflect.Descriptor instead.
+func (*ShareItemRequest) Descriptor() ([]byte, []int) {
+	return file_internal_protos_items_items_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *ShareItemRequest) GetShare() *ItemShare {
+	if x != nil {
+		return x.Share
+	}
+	return nil
+}
+
+type ShareItemResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShareItemResponse) Reset() {
+	*x = ShareItemResponse{}
+	mi := &file_internal_protos_items_items_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShareItemResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShareItemResponse) ProtoMessage() {}
+
+func (x *ShareItemResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protos_items_items_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShareItemResponse.ProtoReflect.Descriptor instead.
+func (*ShareItemResponse) Descriptor() ([]byte, []int) {
+	return file_internal_protos_items_items_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *ShareItemResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type ListItemSharesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ItemId        []byte                 `protobuf:"bytes,1,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListItemSharesRequest) Reset() {
+	*x = ListItemSharesRequest{}
+	mi := &file_internal_protos_items_items_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListItemSharesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListItemSharesRequest) ProtoMessage() {}
+
+func (x *ListItemSharesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protos_items_items_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListItemSharesRequest.ProtoReflect.Descriptor instead.
+func (*ListItemSharesRequest) Descriptor() ([]byte, []int) {
+	return file_internal_protos_items_items_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *ListItemSharesRequest) GetItemId() []byte {
+	if x != nil {
+		return x.ItemId
+	}
+	return nil
+}
+
+type ListItemSharesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Shares        []*ItemShare           `protobuf:"bytes,1,rep,name=shares,proto3" json:"shares,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListItemSharesResponse) Reset() {
+	*x = ListItemSharesResponse{}
+	mi := &file_internal_protos_items_items_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListItemSharesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListItemSharesResponse) ProtoMessage() {}
+
+func (x *ListItemSharesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protos_items_items_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListItemSharesResponse.ProtoReflect.Descriptor instead.
+func (*ListItemSharesResponse) Descriptor() ([]byte, []int) {
+	return file_internal_protos_items_items_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *ListItemSharesResponse) GetShares() []*ItemShare {
+	if x != nil {
+		return x.Shares
+	}
+	return nil
+}
+
+type ListSharedWithMeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSharedWithMeRequest) Reset() {
+	*x = ListSharedWithMeRequest{}
+	mi := &file_internal_protos_items_items_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSharedWithMeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSharedWithMeRequest) ProtoMessage() {}
+
+func (x *ListSharedWithMeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protos_items_items_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSharedWithMeRequest.ProtoReflect.Descriptor instead.
+func (*ListSharedWithMeRequest) Descriptor() ([]byte, []int) {
+	return file_internal_protos_items_items_proto_rawDescGZIP(), []int{47}
+}
+
+type ListSharedWithMeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*SharedItem          `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSharedWithMeResponse) Reset() {
+	*x = ListSharedWithMeResponse{}
+	mi := &file_internal_protos_items_items_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSharedWithMeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSharedWithMeResponse) ProtoMessage() {}
+
+func (x *ListSharedWithMeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protos_items_items_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSharedWithMeResponse.ProtoReflect.Descriptor instead.
+func (*ListSharedWithMeResponse) Descriptor() ([]byte, []int) {
+	return file_internal_protos_items_items_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *ListSharedWithMeResponse) GetItems() []*SharedItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type RevokeShareRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ItemId         []byte                 `protobuf:"bytes,1,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	RecipientLogin string                 `protobuf:"bytes,2,opt,name=recipient_login,json=recipientLogin,proto3" json:"recipient_login,omitempty"`
+	Item           *EncryptedItem         `protobuf:"bytes,3,opt,name=item,proto3" json:"item,omitempty"`
+	Shares         []*ItemShare           `protobuf:"bytes,4,rep,name=shares,proto3" json:"shares,omitempty"`
+	Attachments    []*Attachment          `protobuf:"bytes,5,rep,name=attachments,proto3" json:"attachments,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *RevokeShareRequest) Reset() {
+	*x = RevokeShareRequest{}
+	mi := &file_internal_protos_items_items_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeShareRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeShareRequest) ProtoMessage() {}
+
+func (x *RevokeShareRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protos_items_items_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeShareRequest.ProtoReflect.Descriptor instead.
+func (*RevokeShareRequest) Descriptor() ([]byte, []int) {
+	return file_internal_protos_items_items_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *RevokeShareRequest) GetItemId() []byte {
+	if x != nil {
+		return x.ItemId
+	}
+	return nil
+}
+
+func (x *RevokeShareRequest) GetRecipientLogin() string {
+	if x != nil {
+		return x.RecipientLogin
+	}
+	return ""
+}
+
+func (x *RevokeShareRequest) GetItem() *EncryptedItem {
+	if x != nil {
+		return x.Item
+	}
+	return nil
+}
+
+func (x *RevokeShareRequest) GetShares() []*ItemShare {
+	if x != nil {
+		return x.Shares
+	}
+	return nil
+}
+
+func (x *RevokeShareRequest) GetAttachments() []*Attachment {
+	if x != nil {
+		return x.Attachments
+	}
+	return nil
+}
+
+type RevokeShareResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeShareResponse) Reset() {
+	*x = RevokeShareResponse{}
+	mi := &file_internal_protos_items_items_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeShareResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeShareResponse) ProtoMessage() {}
+
+func (x *RevokeShareResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protos_items_items_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeShareResponse.ProtoReflect.Descriptor instead.
+func (*RevokeShareResponse) Descriptor() ([]byte, []int) {
+	return file_internal_protos_items_items_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *RevokeShareResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type EmergencyAccess struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Grantor       string                 `protobuf:"bytes,1,opt,name=grantor,proto3" json:"grantor,omitempty"`
+	Grantee       string                 `protobuf:"bytes,2,opt,name=grantee,proto3" json:"grantee,omitempty"`
+	Type          EmergencyAccessType    `protobuf:"varint,3,opt,name=type,proto3,enum=items.EmergencyAccessType" json:"type,omitempty"`
+	Status        EmergencyStatus        `protobuf:"varint,4,opt,name=status,proto3,enum=items.EmergencyStatus" json:"status,omitempty"`
+	WaitHours     uint32                 `protobuf:"varint,5,opt,name=wait_hours,json=waitHours,proto3" json:"wait_hours,omitempty"`
+	WrappedKey    string                 `protobuf:"bytes,6,opt,name=wrapped_key,json=wrappedKey,proto3" json:"wrapped_key,omitempty"`
+	RequestedAt   *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=requested_at,json=requestedAt,proto3" json:"requested_at,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EmergencyAccess) Reset() {
+	*x = EmergencyAccess{}
+	mi := &file_internal_protos_items_items_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EmergencyAccess) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmergencyAccess) ProtoMessage() {}
+
+func (x *EmergencyAccess) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protos_items_items_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmergencyAccess.ProtoReflect.Descriptor instead.
+func (*EmergencyAccess) Descriptor() ([]byte, []int) {
+	return file_internal_protos_items_items_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *EmergencyAccess) GetGrantor() string {
+	if x != nil {
+		return x.Grantor
+	}
+	return ""
+}
+
+func (x *EmergencyAccess) GetGrantee() string {
+	if x != nil {
+		return x.Grantee
+	}
+	return ""
+}
+
+func (x *EmergencyAccess) GetType() EmergencyAccessType {
+	if x != nil {
+		return x.Type
+	}
+	return EmergencyAccessType_EMERGENCY_ACCESS_TYPE_UNSPECIFIED
+}
+
+func (x *EmergencyAccess) GetStatus() EmergencyStatus {
+	if x != nil {
+		return x.Status
+	}
+	return EmergencyStatus_EMERGENCY_STATUS_UNSPECIFIED
+}
+
+func (x *EmergencyAccess) GetWaitHours() uint32 {
+	if x != nil {
+		return x.WaitHours
+	}
+	return 0
+}
+
+func (x *EmergencyAccess) GetWrappedKey() string {
+	if x != nil {
+		return x.WrappedKey
+	}
+	return ""
+}
+
+func (x *EmergencyAccess) GetRequestedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RequestedAt
+	}
+	return nil
+}
+
+func (x *EmergencyAccess) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type GrantEmergencyAccessRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Access        *EmergencyAccess       `protobuf:"bytes,1,opt,name=access,proto3" json:"access,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GrantEmergencyAccessRequest) Reset() {
+	*x = GrantEmergencyAccessRequest{}
+	mi := &file_internal_protos_items_items_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GrantEmergencyAccessRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GrantEmergencyAccessRequest) ProtoMessage() {}
+
+func (x *GrantEmergencyAccessRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protos_items_items_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GrantEmergencyAccessRequest.ProtoReflect.Descriptor instead.
+func (*GrantEmergencyAccessRequest) Descriptor() ([]byte, []int) {
+	return file_internal_protos_items_items_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *GrantEmergencyAccessRequest) GetAccess() *EmergencyAccess {
+	if x != nil {
+		return x.Access
+	}
+	return nil
+}
+
+type GrantEmergencyAccessResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GrantEmergencyAccessResponse) Reset() {
+	*x = GrantEmergencyAccessResponse{}
+	mi := &file_internal_protos_items_items_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GrantEmergencyAccessResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GrantEmergencyAccessResponse) ProtoMessage() {}
+
+func (x *GrantEmergencyAccessResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protos_items_items_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GrantEmergencyAccessResponse.ProtoReflect.Descriptor instead.
+func (*GrantEmergencyAccessResponse) Descriptor() ([]byte, []int) {
+	return file_internal_protos_items_items_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *GrantEmergencyAccessResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type RevokeEmergencyAccessRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Grantee       string                 `protobuf:"bytes,1,opt,name=grantee,proto3" json:"grantee,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeEmergencyAccessRequest) Reset() {
+	*x = RevokeEmergencyAccessRequest{}
+	mi := &file_internal_protos_items_items_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeEmergencyAccessRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeEmergencyAccessRequest) ProtoMessage() {}
+
+func (x *RevokeEmergencyAccessRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protos_items_items_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeEmergencyAccessRequest.ProtoReflect.Descriptor instead.
+func (*RevokeEmergencyAccessRequest) Descriptor() ([]byte, []int) {
+	return file_internal_protos_items_items_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *RevokeEmergencyAccessRequest) GetGrantee() string {
+	if x != nil {
+		return x.Grantee
+	}
+	return ""
+}
+
+type RevokeEmergencyAccessResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeEmergencyAccessResponse) Reset() {
+	*x = RevokeEmergencyAccessResponse{}
+	mi := &file_internal_protos_items_items_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeEmergencyAccessResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeEmergencyAccessResponse) ProtoMessage() {}
+
+func (x *RevokeEmergencyAccessResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protos_items_items_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeEmergencyAccessResponse.ProtoReflect.Descriptor instead.
+func (*RevokeEmergencyAccessResponse) Descriptor() ([]byte, []int) {
+	return file_internal_protos_items_items_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *RevokeEmergencyAccessResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type ListEmergencyAccessRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListEmergencyAccessRequest) Reset() {
+	*x = ListEmergencyAccessRequest{}
+	mi := &file_internal_protos_items_items_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListEmergencyAccessRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListEmergencyAccessRequest) ProtoMessage() {}
+
+func (x *ListEmergencyAccessRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protos_items_items_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListEmergencyAccessRequest.ProtoReflect.Descriptor instead.
+func (*ListEmergencyAccessRequest) Descriptor() ([]byte, []int) {
+	return file_internal_protos_items_items_proto_rawDescGZIP(), []int{56}
+}
+
+type ListEmergencyAccessResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Granted       []*EmergencyAccess     `protobuf:"bytes,1,rep,name=granted,proto3" json:"granted,omitempty"`
+	TrustedBy     []*EmergencyAccess     `protobuf:"bytes,2,rep,name=trusted_by,json=trustedBy,proto3" json:"trusted_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListEmergencyAccessResponse) Reset() {
+	*x = ListEmergencyAccessResponse{}
+	mi := &file_internal_protos_items_items_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListEmergencyAccessResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListEmergencyAccessResponse) ProtoMessage() {}
+
+func (x *ListEmergencyAccessResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protos_items_items_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListEmergencyAccessResponse.ProtoReflect.Descriptor instead.
+func (*ListEmergencyAccessResponse) Descriptor() ([]byte, []int) {
+	return file_internal_protos_items_items_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *ListEmergencyAccessResponse) GetGranted() []*EmergencyAccess {
+	if x != nil {
+		return x.Granted
+	}
+	return nil
+}
+
+func (x *ListEmergencyAccessResponse) GetTrustedBy() []*EmergencyAccess {
+	if x != nil {
+		return x.TrustedBy
+	}
+	return nil
+}
+
+type RequestEmergencyAccessRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Grantor       string                 `protobuf:"bytes,1,opt,name=grantor,proto3" json:"grantor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestEmergencyAccessRequest) Reset() {
+	*x = RequestEmergencyAccessRequest{}
+	mi := &file_internal_protos_items_items_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestEmergencyAccessRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestEmergencyAccessRequest) ProtoMessage() {}
+
+func (x *RequestEmergencyAccessRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protos_items_items_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestEmergencyAccessRequest.ProtoReflect.Descriptor instead.
+func (*RequestEmergencyAccessRequest) Descriptor() ([]byte, []int) {
+	return file_internal_protos_items_items_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *RequestEmergencyAccessRequest) GetGrantor() string {
+	if x != nil {
+		return x.Grantor
+	}
+	return ""
+}
+
+type RequestEmergencyAccessResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestEmergencyAccessResponse) Reset() {
+	*x = RequestEmergencyAccessResponse{}
+	mi := &file_internal_protos_items_items_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestEmergencyAccessResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestEmergencyAccessResponse) ProtoMessage() {}
+
+func (x *RequestEmergencyAccessResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protos_items_items_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestEmergencyAccessResponse.ProtoReflect.Descriptor instead.
+func (*RequestEmergencyAccessResponse) Descriptor() ([]byte, []int) {
+	return file_internal_protos_items_items_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *RequestEmergencyAccessResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type ApproveEmergencyAccessRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Grantee       string                 `protobuf:"bytes,1,opt,name=grantee,proto3" json:"grantee,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApproveEmergencyAccessRequest) Reset() {
+	*x = ApproveEmergencyAccessRequest{}
+	mi := &file_internal_protos_items_items_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApproveEmergencyAccessRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApproveEmergencyAccessRequest) ProtoMessage() {}
+
+func (x *ApproveEmergencyAccessRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protos_items_items_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApproveEmergencyAccessRequest.ProtoReflect.Descriptor instead.
+func (*ApproveEmergencyAccessRequest) Descriptor() ([]byte, []int) {
+	return file_internal_protos_items_items_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *ApproveEmergencyAccessRequest) GetGrantee() string {
+	if x != nil {
+		return x.Grantee
+	}
+	return ""
+}
+
+type ApproveEmergencyAccessResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApproveEmergencyAccessResponse) Reset() {
+	*x = ApproveEmergencyAccessResponse{}
+	mi := &file_internal_protos_items_items_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApproveEmergencyAccessResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApproveEmergencyAccessResponse) ProtoMessage() {}
+
+func (x *ApproveEmergencyAccessResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protos_items_items_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApproveEmergencyAccessResponse.ProtoReflect.Descriptor instead.
+func (*ApproveEmergencyAccessResponse) Descriptor() ([]byte, []int) {
+	return file_internal_protos_items_items_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *ApproveEmergencyAccessResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type DenyEmergencyAccessRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Grantee       string                 `protobuf:"bytes,1,opt,name=grantee,proto3" json:"grantee,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DenyEmergencyAccessRequest) Reset() {
+	*x = DenyEmergencyAccessRequest{}
+	mi := &file_internal_protos_items_items_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DenyEmergencyAccessRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DenyEmergencyAccessRequest) ProtoMessage() {}
+
+func (x *DenyEmergencyAccessRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protos_items_items_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DenyEmergencyAccessRequest.ProtoReflect.Descriptor instead.
+func (*DenyEmergencyAccessRequest) Descriptor() ([]byte, []int) {
+	return file_internal_protos_items_items_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *DenyEmergencyAccessRequest) GetGrantee() string {
+	if x != nil {
+		return x.Grantee
+	}
+	return ""
+}
+
+type DenyEmergencyAccessResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DenyEmergencyAccessResponse) Reset() {
+	*x = DenyEmergencyAccessResponse{}
+	mi := &file_internal_protos_items_items_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DenyEmergencyAccessResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DenyEmergencyAccessResponse) ProtoMessage() {}
+
+func (x *DenyEmergencyAccessResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protos_items_items_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DenyEmergencyAccessResponse.ProtoReflect.Descriptor instead.
+func (*DenyEmergencyAccessResponse) Descriptor() ([]byte, []int) {
+	return file_internal_protos_items_items_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *DenyEmergencyAccessResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type GetEmergencyVaultRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Grantor       string                 `protobuf:"bytes,1,opt,name=grantor,proto3" json:"grantor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetEmergencyVaultRequest) Reset() {
+	*x = GetEmergencyVaultRequest{}
+	mi := &file_internal_protos_items_items_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetEmergencyVaultRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEmergencyVaultRequest) ProtoMessage() {}
+
+func (x *GetEmergencyVaultRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protos_items_items_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEmergencyVaultRequest.ProtoReflect.Descriptor instead.
+func (*GetEmergencyVaultRequest) Descriptor() ([]byte, []int) {
+	return file_internal_protos_items_items_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *GetEmergencyVaultRequest) GetGrantor() string {
+	if x != nil {
+		return x.Grantor
+	}
+	return ""
+}
+
+type GetEmergencyVaultResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Access        *EmergencyAccess       `protobuf:"bytes,1,opt,name=access,proto3" json:"access,omitempty"`
+	Salt          string                 `protobuf:"bytes,2,opt,name=salt,proto3" json:"salt,omitempty"`
+	Keys          *UserKeys              `protobuf:"bytes,3,opt,name=keys,proto3" json:"keys,omitempty"`
+	Items         []*EncryptedItem       `protobuf:"bytes,4,rep,name=items,proto3" json:"items,omitempty"`
+	Templates     []*ItemTemplate        `protobuf:"bytes,5,rep,name=templates,proto3" json:"templates,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetEmergencyVaultResponse) Reset() {
+	*x = GetEmergencyVaultResponse{}
+	mi := &file_internal_protos_items_items_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetEmergencyVaultResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEmergencyVaultResponse) ProtoMessage() {}
+
+func (x *GetEmergencyVaultResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protos_items_items_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEmergencyVaultResponse.ProtoReflect.Descriptor instead.
+func (*GetEmergencyVaultResponse) Descriptor() ([]byte, []int) {
+	return file_internal_protos_items_items_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *GetEmergencyVaultResponse) GetAccess() *EmergencyAccess {
+	if x != nil {
+		return x.Access
+	}
+	return nil
+}
+
+func (x *GetEmergencyVaultResponse) GetSalt() string {
+	if x != nil {
+		return x.Salt
+	}
+	return ""
+}
+
+func (x *GetEmergencyVaultResponse) GetKeys() *UserKeys {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+func (x *GetEmergencyVaultResponse) GetItems() []*EncryptedItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *GetEmergencyVaultResponse) GetTemplates() []*ItemTemplate {
+	if x != nil {
+		return x.Templates
+	}
+	return nil
+}
+
+type TakeoverAccountRequest struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Grantor             string                 `protobuf:"bytes,1,opt,name=grantor,proto3" json:"grantor,omitempty"`
+	Password            string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	EncryptedPrivateKey string                 `protobuf:"bytes,3,opt,name=encrypted_private_key,json=encryptedPrivateKey,proto3" json:"encrypted_private_key,omitempty"`
+	Items               []*EncryptedItem       `protobuf:"bytes,4,rep,name=items,proto3" json:"items,omitempty"`
+	Templates           []*ItemTemplate        `protobuf:"bytes,5,rep,name=templates,proto3" json:"templates,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *TakeoverAccountRequest) Reset() {
+	*x = TakeoverAccountRequest{}
+	mi := &file_internal_protos_items_items_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TakeoverAccountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TakeoverAccountRequest) ProtoMessage() {}
+
+func (x *TakeoverAccountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protos_items_items_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TakeoverAccountRequest.ProtoReflect.Descriptor instead.
+func (*TakeoverAccountRequest) Descriptor() ([]byte, []int) {
+	return file_internal_protos_items_items_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *TakeoverAccountRequest) GetGrantor() string {
+	if x != nil {
+		return x.Grantor
+	}
+	return ""
+}
+
+func (x *TakeoverAccountRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *TakeoverAccountRequest) GetEncryptedPrivateKey() string {
+	if x != nil {
+		return x.EncryptedPrivateKey
+	}
+	return ""
+}
+
+func (x *TakeoverAccountRequest) GetItems() []*EncryptedItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *TakeoverAccountRequest) GetTemplates() []*ItemTemplate {
+	if x != nil {
+		return x.Templates
+	}
+	return nil
+}
+
+type TakeoverAccountResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TakeoverAccountResponse) Reset() {
+	*x = TakeoverAccountResponse{}
+	mi := &file_internal_protos_items_items_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TakeoverAccountResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TakeoverAccountResponse) ProtoMessage() {}
+
+func (x *TakeoverAccountResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protos_items_items_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TakeoverAccountResponse.ProtoReflect.Descriptor instead.
+func (*TakeoverAccountResponse) Descriptor() ([]byte, []int) {
+	return file_internal_protos_items_items_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *TakeoverAccountResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type CreateSendRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EncryptedData *EncryptedData         `protobuf:"bytes,1,opt,name=encrypted_data,json=encryptedData,proto3" json:"encrypted_data,omitempty"`
+	MaxViews      uint32                 `protobuf:"varint,2,opt,name=max_views,json=maxViews,proto3" json:"max_views,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateSendRequest) Reset() {
+	*x = CreateSendRequest{}
+	mi := &file_internal_protos_items_items_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateSendRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSendRequest) ProtoMessage() {}
+
+func (x *CreateSendRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protos_items_items_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSendRequest.ProtoReflect.Descriptor instead.
+func (*CreateSendRequest) Descriptor() ([]byte, []int) {
+	return file_internal_protos_items_items_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *CreateSendRequest) GetEncryptedData() *EncryptedData {
+	if x != nil {
+		return x.EncryptedData
+	}
+	return nil
+}
+
+func (x *CreateSendRequest) GetMaxViews() uint32 {
+	if x != nil {
+		return x.MaxViews
+	}
+	return 0
+}
+
+func (x *CreateSendRequest) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+type CreateSendResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            []byte                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Url           string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateSendResponse) Reset() {
+	*x = CreateSendResponse{}
+	mi := &file_internal_protos_items_items_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateSendResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSendResponse) ProtoMessage() {}
+
+func (x *CreateSendResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protos_items_items_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSendResponse.ProtoReflect.Descriptor instead.
+func (*CreateSendResponse) Descriptor() ([]byte, []int) {
+	return file_internal_protos_items_items_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *CreateSendResponse) GetId() []byte {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *CreateSendResponse) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+var File_internal_protos_items_items_proto protoreflect.FileDescriptor
+
+const file_internal_protos_items_items_proto_rawDesc = "" +
+	"\n" +
+	"!internal/protos/items/items.proto\x12\x05items\x1a\x1fgoogle/protobuf/timestamp.proto\"\x91\x06\n" +
+	"\rEncryptedItem\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\fR\x02id\x12\x1d\n" +
+	"\n" +
+	"user_login\x18\x02 \x01(\tR\tuserLogin\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12#\n" +
+	"\x04type\x18\x04 \x01(\x0e2\x0f.items.ItemTypeR\x04type\x12;\n" +
+	"\x0eencrypted_data\x18\x05 \x01(\v2\x14.items.EncryptedDataR\rencryptedData\x122\n" +
+	"\x04meta\x18\x06 \x03(\v2\x1e.items.EncryptedItem.MetaEntryR\x04meta\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12#\n" +
+	"\rencrypted_key\x18\t \x01(\tR\fencryptedKey\x12#\n" +
+	"\rcollection_id\x18\n" +
+	" \x01(\fR\fcollectionId\x12\x16\n" +
+	"\x06format\x18\v \x01(\rR\x06format\x12#\n" +
+	"\rsearch_tokens\x18\f \x03(\tR\fsearchTokens\x129\n" +
+	"\n" +
+	"expires_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12\x1a\n" +
+	"\bfavorite\x18\x0e \x01(\bR\bfavorite\x12<\n" +
+	"\flast_used_at\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"lastUsedAt\x12\x1b\n" +
+	"\tuse_count\x18\x10 \x01(\x05R\buseCount\x12?\n" +
+	"\x10encrypted_header\x18\x11 \x01(\v2\x14.items.EncryptedDataR\x0fencryptedHeader\x1a7\n" +
+	"\tMetaEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"R\n" +
+	"\rEncryptedData\x12+\n" +
+	"\x11encrypted_content\x18\x01 \x01(\tR\x10encryptedContent\x12\x14\n" +
+	"\x05nonce\x18\x02 \x01(\tR\x05nonce\":\n" +
+	"\x0eAddItemRequest\x12(\n" +
+	"\x04item\x18\x01 \x01(\v2\x14.items.EncryptedItemR\x04item\"+\n" +
+	"\x0fAddItemResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"~\n" +
+	"\x13GetUserItemsRequest\x12\x1d\n" +
+	"\n" +
+	"user_login\x18\x01 \x01(\tR\tuserLogin\x12#\n" +
+	"\x04type\x18\x02 \x01(\x0e2\x0f.items.ItemTypeR\x04type\x12#\n" +
+	"\rcollection_id\x18\x03 \x01(\fR\fcollectionId\"B\n" +
+	"\x14GetUserItemsResponse\x12*\n" +
+	"\x05items\x18\x01 \x03(\v2\x14.items.EncryptedItemR\x05items\";\n" +
+	"\x0fEditItemRequest\x12(\n" +
+	"\x04item\x18\x01 \x01(\v2\x14.items.EncryptedItemR\x04item\",\n" +
+	"\x10EditItemResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"K\n" +
+	"\x11DeleteItemRequest\x12\x1d\n" +
+	"\n" +
+	"user_login\x18\x01 \x01(\tR\tuserLogin\x12\x17\n" +
+	"\aitem_id\x18\x02 \x01(\fR\x06itemId\".\n" +
+	"\x12DeleteItemResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"X\n" +
+	"\x12TypesCountsRequest\x12\x1d\n" +
+	"\n" +
+	"user_login\x18\x01 \x01(\tR\tuserLogin\x12#\n" +
+	"\rcollection_id\x18\x02 \x01(\fR\fcollectionId\"\x8c\x01\n" +
+	"\x13TypesCountsResponse\x12;\n" +
+	"\x05types\x18\x01 \x03(\v2%.items.TypesCountsResponse.TypesEntryR\x05types\x1a8\n" +
+	"\n" +
+	"TypesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\",\n" +
+	"\x12SearchItemsRequest\x12\x16\n" +
+	"\x06tokens\x18\x01 \x03(\tR\x06tokens\"A\n" +
+	"\x13SearchItemsResponse\x12*\n" +
+	"\x05items\x18\x01 \x03(\v2\x14.items.EncryptedItemR\x05items\"+\n" +
+	"\x10TouchItemRequest\x12\x17\n" +
+	"\aitem_id\x18\x01 \x01(\fR\x06itemId\"-\n" +
+	"\x11TouchItemResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"M\n" +
+	"\x16SetItemFavoriteRequest\x12\x17\n" +
+	"\aitem_id\x18\x01 \x01(\fR\x06itemId\x12\x1a\n" +
+	"\bfavorite\x18\x02 \x01(\bR\bfavorite\"3\n" +
+	"\x17SetItemFavoriteResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\xc1\x01\n" +
+	"\n" +
+	"Attachment\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\fR\x02id\x12\x17\n" +
+	"\aitem_id\x18\x02 \x01(\fR\x06itemId\x12;\n" +
+	"\x0eencrypted_info\x18\x03 \x01(\v2\x14.items.EncryptedDataR\rencryptedInfo\x12\x12\n" +
+	"\x04size\x18\x04 \x01(\x03R\x04size\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"|\n" +
+	"\x17UploadAttachmentRequest\x121\n" +
+	"\n" +
+	"attachment\x18\x01 \x01(\v2\x11.items.AttachmentR\n" +
+	"attachment\x12\x18\n" +
+	"\acontent\x18\x02 \x01(\fR\acontent\x12\x14\n" +
+	"\x05nonce\x18\x03 \x01(\fR\x05nonce\"*\n" +
+	"\x18UploadAttachmentResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\fR\x02id\"1\n" +
+	"\x16ListAttachmentsRequest\x12\x17\n" +
+	"\aitem_id\x18\x01 \x01(\fR\x06itemId\"N\n" +
+	"\x17ListAttachmentsResponse\x123\n" +
+	"\vattachments\x18\x01 \x03(\v2\x11.items.AttachmentR\vattachments\"Y\n" +
+	"\x19DownloadAttachmentRequest\x12\x17\n" +
+	"\aitem_id\x18\x01 \x01(\fR\x06itemId\x12#\n" +
+	"\rattachment_id\x18\x02 \x01(\fR\fattachmentId\"\x7f\n" +
+	"\x1aDownloadAttachmentResponse\x121\n" +
+	"\n" +
+	"attachment\x18\x01 \x01(\v2\x11.items.AttachmentR\n" +
+	"attachment\x12\x18\n" +
+	"\acontent\x18\x02 \x01(\fR\acontent\x12\x14\n" +
+	"\x05nonce\x18\x03 \x01(\fR\x05nonce\"W\n" +
+	"\x17DeleteAttachmentRequest\x12\x17\n" +
+	"\aitem_id\x18\x01 \x01(\fR\x06itemId\x12#\n" +
+	"\rattachment_id\x18\x02 \x01(\fR\fattachmentId\"4\n" +
+	"\x18DeleteAttachmentResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\xd1\x01\n" +
+	"\fItemTemplate\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\fR\x02id\x12;\n" +
+	"\x0eencrypted_data\x18\x02 \x01(\v2\x14.items.EncryptedDataR\rencryptedData\x129\n" +
+	"\n" +
+	"created_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"F\n" +
+	"\x13SaveTemplateRequest\x12/\n" +
+	"\btemplate\x18\x01 \x01(\v2\x13.items.ItemTemplateR\btemplate\"0\n" +
+	"\x14SaveTemplateResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\x16\n" +
+	"\x14ListTemplatesRequest\"J\n" +
+	"\x15ListTemplatesResponse\x121\n" +
+	"\ttemplates\x18\x01 \x03(\v2\x13.items.ItemTemplateR\ttemplates\"'\n" +
+	"\x15DeleteTemplateRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\fR\x02id\"2\n" +
+	"\x16DeleteTemplateResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"]\n" +
+	"\bUserKeys\x12\x1d\n" +
+	"\n" +
+	"public_key\x18\x01 \x01(\fR\tpublicKey\x122\n" +
+	"\x15encrypted_private_key\x18\x02 \x01(\tR\x13encryptedPrivateKey\"\xa9\x01\n" +
+	"\tItemShare\x12\x17\n" +
+	"\aitem_id\x18\x01 \x01(\fR\x06itemId\x12'\n" +
+	"\x0frecipient_login\x18\x02 \x01(\tR\x0erecipientLogin\x12\x1f\n" +
+	"\vwrapped_key\x18\x03 \x01(\tR\n" +
+	"wrappedKey\x129\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"W\n" +
+	"\n" +
+	"SharedItem\x12(\n" +
+	"\x04item\x18\x01 \x01(\v2\x14.items.EncryptedItemR\x04item\x12\x1f\n" +
+	"\vwrapped_key\x18\x02 \x01(\tR\n" +
+	"wrappedKey\"9\n" +
+	"\x12SetUserKeysRequest\x12#\n" +
+	"\x04keys\x18\x01 \x01(\v2\x0f.items.UserKeysR\x04keys\"/\n" +
+	"\x13SetUserKeysResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\x14\n" +
+	"\x12GetUserKeysRequest\":\n" +
+	"\x13GetUserKeysResponse\x12#\n" +
+	"\x04keys\x18\x01 \x01(\v2\x0f.items.UserKeysR\x04keys\"+\n" +
+	"\x13GetPublicKeyRequest\x12\x14\n" +
+	"\x05login\x18\x01 \x01(\tR\x05login\"5\n" +
+	"\x14GetPublicKeyResponse\x12\x1d\n" +
+	"\n" +
+	"public_key\x18\x01 \x01(\fR\tpublicKey\":\n" +
+	"\x10ShareItemRequest\x12&\n" +
+	"\x05share\x18\x01 \x01(\v2\x10.items.ItemShareR\x05share\"-\n" +
+	"\x11ShareItemResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"0\n" +
+	"\x15ListItemSharesRequest\x12\x17\n" +
+	"\aitem_id\x18\x01 \x01(\fR\x06itemId\"B\n" +
+	"\x16ListItemSharesResponse\x12(\n" +
+	"\x06shares\x18\x01 \x03(\v2\x10.items.ItemShareR\x06shares\"\x19\n" +
+	"\x17ListSharedWithMeRequest\"C\n" +
+	"\x18ListSharedWithMeResponse\x12'\n" +
+	"\x05items\x18\x01 \x03(\v2\x11.items.SharedItemR\x05items\"\xdf\x01\n" +
+	"\x12RevokeShareRequest\x12\x17\n" +
+	"\aitem_id\x18\x01 \x01(\fR\x06itemId\x12'\n" +
+	"\x0frecipient_login\x18\x02 \x01(\tR\x0erecipientLogin\x12(\n" +
+	"\x04item\x18\x03 \x01(\v2\x14.items.EncryptedItemR\x04item\x12(\n" +
+	"\x06shares\x18\x04 \x03(\v2\x10.items.ItemShareR\x06shares\x123\n" +
+	"\vattachments\x18\x05 \x03(\v2\x11.items.AttachmentR\vattachments\"/\n" +
+	"\x13RevokeShareResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\xdf\x02\n" +
+	"\x0fEmergencyAccess\x12\x18\n" +
+	"\agrantor\x18\x01 \x01(\tR\agrantor\x12\x18\n" +
+	"\agrantee\x18\x02 \x01(\tR\agrantee\x12.\n" +
+	"\x04type\x18\x03 \x01(\x0e2\x1a.items.EmergencyAccessTypeR\x04type\x12.\n" +
+	"\x06status\x18\x04 \x01(\x0e2\x16.items.EmergencyStatusR\x06status\x12\x1d\n" +
+	"\n" +
+	"wait_hours\x18\x05 \x01(\rR\twaitHours\x12\x1f\n" +
+	"\vwrapped_key\x18\x06 \x01(\tR\n" +
+	"wrappedKey\x12=\n" +
+	"\frequested_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\vrequestedAt\x129\n" +
+	"\n" +
+	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"M\n" +
+	"\x1bGrantEmergencyAccessRequest\x12.\n" +
+	"\x06access\x18\x01 \x01(\v2\x16.items.EmergencyAccessR\x06access\"8\n" +
+	"\x1cGrantEmergencyAccessResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"8\n" +
+	"\x1cRevokeEmergencyAccessRequest\x12\x18\n" +
+	"\agrantee\x18\x01 \x01(\tR\agrantee\"9\n" +
+	"\x1dRevokeEmergencyAccessResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\x1c\n" +
+	"\x1aListEmergencyAccessRequest\"\x86\x01\n" +
+	"\x1bListEmergencyAccessResponse\x120\n" +
+	"\agranted\x18\x01 \x03(\v2\x16.items.EmergencyAccessR\agranted\x125\n" +
+	"\n" +
+	"trusted_by\x18\x02 \x03(\v2\x16.items.EmergencyAccessR\ttrustedBy\"9\n" +
+	"\x1dRequestEmergencyAccessRequest\x12\x18\n" +
+	"\agrantor\x18\x01 \x01(\tR\agrantor\":\n" +
+	"\x1eRequestEmergencyAccessResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"9\n" +
+	"\x1dApproveEmergencyAccessRequest\x12\x18\n" +
+	"\agrantee\x18\x01 \x01(\tR\agrantee\":\n" +
+	"\x1eApproveEmergencyAccessResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"6\n" +
+	"\x1aDenyEmergencyAccessRequest\x12\x18\n" +
+	"\agrantee\x18\x01 \x01(\tR\agrantee\"7\n" +
+	"\x1bDenyEmergencyAccessResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"4\n" +
+	"\x18GetEmergencyVaultRequest\x12\x18\n" +
+	"\agrantor\x18\x01 \x01(\tR\agrantor\"\xe3\x01\n" +
+	"\x19GetEmergencyVaultResponse\x12.\n" +
+	"\x06access\x18\x01 \x01(\v2\x16.items.EmergencyAccessR\x06access\x12\x12\n" +
+	"\x04salt\x18\x02 \x01(\tR\x04salt\x12#\n" +
+	"\x04keys\x18\x03 \x01(\v2\x0f.items.UserKeysR\x04keys\x12*\n" +
+	"\x05items\x18\x04 \x03(\v2\x14.items.EncryptedItemR\x05items\x121\n" +
+	"\ttemplates\x18\x05 \x03(\v2\x13.items.ItemTemplateR\ttemplates\"\xe1\x01\n" +
+	"\x16TakeoverAccountRequest\x12\x18\n" +
+	"\agrantor\x18\x01 \x01(\tR\agrantor\x12\x1a\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\x122\n" +
+	"\x15encrypted_private_key\x18\x03 \x01(\tR\x13encryptedPrivateKey\x12*\n" +
+	"\x05items\x18\x04 \x03(\v2\x14.items.EncryptedItemR\x05items\x121\n" +
+	"\ttemplates\x18\x05 \x03(\v2\x13.items.ItemTemplateR\ttemplates\"3\n" +
+	"\x17TakeoverAccountResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\xa8\x01\n" +
+	"\x11CreateSendRequest\x12;\n" +
+	"\x0eencrypted_data\x18\x01 \x01(\v2\x14.items.EncryptedDataR\rencryptedData\x12\x1b\n" +
+	"\tmax_views\x18\x02 \x01(\rR\bmaxViews\x129\n" +
+	"\n" +
+	"expires_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"6\n" +
+	"\x12CreateSendResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\fR\x02id\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url*\x86\x02\n" +
+	"\bItemType\x12\x13\n" +
+	"\x0fITEM_TYPE_EMPTY\x10\x00\x12\x19\n" +
+	"\x15ITEM_TYPE_UNSPECIFIED\x10\x01\x12\x19\n" +
+	"\x15ITEM_TYPE_CREDENTIALS\x10\x02\x12\x12\n" +
+	"\x0eITEM_TYPE_TEXT\x10\x03\x12\x14\n" +
+	"\x10ITEM_TYPE_BINARY\x10\x04\x12\x12\n" +
+	"\x0eITEM_TYPE_CARD\x10\x05\x12\x15\n" +
+	"\x11ITEM_TYPE_SSH_KEY\x10\x06\x12\x12\n" +
+	"\x0eITEM_TYPE_TOTP\x10\a\x12\x14\n" +
+	"\x10ITEM_TYPE_CUSTOM\x10\b\x12\x16\n" +
+	"\x12ITEM_TYPE_IDENTITY\x10\t\x12\x18\n" +
+	"\x14ITEM_TYPE_CONNECTION\x10\n" +
+	"*\x80\x01\n" +
+	"\x13EmergencyAccessType\x12%\n" +
+	"!EMERGENCY_ACCESS_TYPE_UNSPECIFIED\x10\x00\x12\x1e\n" +
+	"\x1aEMERGENCY_ACCESS_TYPE_VIEW\x10\x01\x12\"\n" +
+	"\x1eEMERGENCY_ACCESS_TYPE_TAKEOVER\x10\x02*\x8d\x01\n" +
+	"\x0fEmergencyStatus\x12 \n" +
+	"\x1cEMERGENCY_STATUS_UNSPECIFIED\x10\x00\x12\x19\n" +
+	"\x15EMERGENCY_STATUS_IDLE\x10\x01\x12\x1e\n" +
+	"\x1aEMERGENCY_STATUS_REQUESTED\x10\x02\x12\x1d\n" +
+	"\x19EMERGENCY_STATUS_APPROVED\x10\x032\xed\b\n" +
+	"\x0fItemsController\x128\n" +
+	"\aAddItem\x12\x15.items.AddItemRequest\x1a\x16.items.AddItemResponse\x12;\n" +
+	"\bEditItem\x12\x16.items.EditItemRequest\x1a\x17.items.EditItemResponse\x12A\n" +
+	"\n" +
+	"DeleteItem\x12\x18.items.DeleteItemRequest\x1a\x19.items.DeleteItemResponse\x12G\n" +
+	"\fGetUserItems\x12\x1a.items.GetUserItemsRequest\x1a\x1b.items.GetUserItemsResponse\x12D\n" +
+	"\vTypesCounts\x12\x19.items.TypesCountsRequest\x1a\x1a.items.TypesCountsResponse\x12D\n" +
+	"\vSearchItems\x12\x19.items.SearchItemsRequest\x1a\x1a.items.SearchItemsResponse\x12>\n" +
+	"\tTouchItem\x12\x17.items.TouchItemRequest\x1a\x18.items.TouchItemResponse\x12P\n" +
+	"\x0fSetItemFavorite\x12\x1d.items.SetItemFavoriteRequest\x1a\x1e.items.SetItemFavoriteResponse\x12S\n" +
+	"\x10UploadAttachment\x12\x1e.items.UploadAttachmentRequest\x1a\x1f.items.UploadAttachmentResponse\x12P\n" +
+	"\x0fListAttachments\x12\x1d.items.ListAttachmentsRequest\x1a\x1e.items.ListAttachmentsResponse\x12Y\n" +
+	"\x12DownloadAttachment\x12 .items.DownloadAttachmentRequest\x1a!.items.DownloadAttachmentResponse\x12S\n" +
+	"\x10DeleteAttachment\x12\x1e.items.DeleteAttachmentRequest\x1a\x1f.items.DeleteAttachmentResponse\x12G\n" +
+	"\fSaveTemplate\x12\x1a.items.SaveTemplateRequest\x1a\x1b.items.SaveTemplateResponse\x12J\n" +
+	"\rListTemplates\x12\x1b.items.ListTemplatesRequest\x1a\x1c.items.ListTemplatesResponse\x12M\n" +
+	"\x0eDeleteTemplate\x12\x1c.items.DeleteTemplateRequest\x1a\x1d.items.DeleteTemplateResponse2\x91\x04\n" +
+	"\x10SharesController\x12D\n" +
+	"\vSetUserKeys\x12\x19.items.SetUserKeysRequest\x1a\x1a.items.SetUserKeysResponse\x12D\n" +
+	"\vGetUserKeys\x12\x19.items.GetUserKeysRequest\x1a\x1a.items.GetUserKeysResponse\x12G\n" +
+	"\fGetPublicKey\x12\x1a.items.GetPublicKeyRequest\x1a\x1b.items.GetPublicKeyResponse\x12>\n" +
+	"\tShareItem\x12\x17.items.ShareItemRequest\x1a\x18.items.ShareItemResponse\x12M\n" +
+	"\x0eListItemShares\x12\x1c.items.ListItemSharesRequest\x1a\x1d.items.ListItemSharesResponse\x12S\n" +
+	"\x10ListSharedWithMe\x12\x1e.items.ListSharedWithMeRequest\x1a\x1f.items.ListSharedWithMeResponse\x12D\n" +
+	"\vRevokeShare\x12\x19.items.RevokeShareRequest\x1a\x1a.items.RevokeShareResponse2\x8e\x06\n" +
+	"\x13EmergencyController\x12_\n" +
+	"\x14GrantEmergencyAccess\x12\".items.GrantEmergencyAccessRequest\x1a#.items.GrantEmergencyAccessResponse\x12b\n" +
+	"\x15RevokeEmergencyAccess\x12#.items.RevokeEmergencyAccessRequest\x1a$.items.RevokeEmergencyAccessResponse\x12\\\n" +
+	"\x13ListEmergencyAccess\x12!.items.ListEmergencyAccessRequest\x1a\".items.ListEmergencyAccessResponse\x12e\n" +
+	"\x16RequestEmergencyAccess\x12$.items.RequestEmergencyAccessRequest\x1a%.items.RequestEmergencyAccessResponse\x12e\n" +
+	"\x16ApproveEmergencyAccess\x12$.items.ApproveEmergencyAccessRequest\x1a%.items.ApproveEmergencyAccessResponse\x12\\\n" +
+	"\x13DenyEmergencyAccess\x12!.items.DenyEmergencyAccessRequest\x1a\".items.DenyEmergencyAccessResponse\x12V\n" +
+	"\x11GetEmergencyVault\x12\x1f.items.GetEmergencyVaultRequest\x1a .items.GetEmergencyVaultResponse\x12P\n" +
+	"\x0fTakeoverAccount\x12\x1d.items.TakeoverAccountRequest\x1a\x1e.items.TakeoverAccountResponse2T\n" +
+	"\x0fSendsController\x12A\n" +
+	"\n" +
+	"CreateSend\x12\x18.items.CreateSendRequest\x1a\x19.items.CreateSendResponseB\fZ\n" +
 	"grpc/protob\x06proto3"
 
 var (
@@ -768,52 +4060,203 @@ func file_internal_protos_items_items_proto_rawDescGZIP() []byte {
 	return file_internal_protos_items_items_proto_rawDescData
 }
 
-var file_internal_protos_items_items_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_internal_protos_items_items_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
+var file_internal_protos_items_items_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_internal_protos_items_items_proto_msgTypes = make([]protoimpl.MessageInfo, 72)
 var file_internal_protos_items_items_proto_goTypes = []any{
-	(ItemType)(0),                 // 0: items.ItemType
-	(*EncryptedItem)(nil),         // 1: items.EncryptedItem
-	(*EncryptedData)(nil),         // 2: items.EncryptedData
-	(*AddItemRequest)(nil),        // 3: items.AddItemRequest
-	(*AddItemResponse)(nil),       // 4: items.AddItemResponse
-	(*GetUserItemsRequest)(nil),   // 5: items.GetUserItemsRequest
-	(*GetUserItemsResponse)(nil),  // 6: items.GetUserItemsResponse
-	(*EditItemRequest)(nil),       // 7: items.EditItemRequest
-	(*EditItemResponse)(nil),      // 8: items.EditItemResponse
-	(*DeleteItemRequest)(nil),     // 9: items.DeleteItemRequest
-	(*DeleteItemResponse)(nil),    // 10: items.DeleteItemResponse
-	(*TypesCountsRequest)(nil),    // 11: items.TypesCountsRequest
-	(*TypesCountsResponse)(nil),   // 12: items.TypesCountsResponse
-	nil,                           // 13: items.EncryptedItem.MetaEntry
-	nil,                           // 14: items.TypesCountsResponse.TypesEntry
-	(*timestamppb.Timestamp)(nil), // 15: google.protobuf.Timestamp
+	(ItemType)(0),                          // 0: items.ItemType
+	(EmergencyAccessType)(0),               // 1: items.EmergencyAccessType
+	(EmergencyStatus)(0),                   // 2: items.EmergencyStatus
+	(*EncryptedItem)(nil),                  // 3: items.EncryptedItem
+	(*EncryptedData)(nil),                  // 4: items.EncryptedData
+	(*AddItemRequest)(nil),                 // 5: items.AddItemRequest
+	(*AddItemResponse)(nil),                // 6: items.AddItemResponse
+	(*GetUserItemsRequest)(nil),            // 7: items.GetUserItemsRequest
+	(*GetUserItemsResponse)(nil),           // 8: items.GetUserItemsResponse
+	(*EditItemRequest)(nil),                // 9: items.EditItemRequest
+	(*EditItemResponse)(nil),               // 10: items.EditItemResponse
+	(*DeleteItemRequest)(nil),              // 11: items.DeleteItemRequest
+	(*DeleteItemResponse)(nil),             // 12: items.DeleteItemResponse
+	(*TypesCountsRequest)(nil),             // 13: items.TypesCountsRequest
+	(*TypesCountsResponse)(nil),            // 14: items.TypesCountsResponse
+	(*SearchItemsRequest)(nil),             // 15: items.SearchItemsRequest
+	(*SearchItemsResponse)(nil),            // 16: items.SearchItemsResponse
+	(*TouchItemRequest)(nil),               // 17: items.TouchItemRequest
+	(*TouchItemResponse)(nil),              // 18: items.TouchItemResponse
+	(*SetItemFavoriteRequest)(nil),         // 19: items.SetItemFavoriteRequest
+	(*SetItemFavoriteResponse)(nil),        // 20: items.SetItemFavoriteResponse
+	(*Attachment)(nil),                     // 21: items.Attachment
+	(*UploadAttachmentRequest)(nil),        // 22: items.UploadAttachmentRequest
+	(*UploadAttachmentResponse)(nil),       // 23: items.UploadAttachmentResponse
+	(*ListAttachmentsRequest)(nil),         // 24: items.ListAttachmentsRequest
+	(*ListAttachmentsResponse)(nil),        // 25: items.ListAttachmentsResponse
+	(*DownloadAttachmentRequest)(nil),      // 26: items.DownloadAttachmentRequest
+	(*DownloadAttachmentResponse)(nil),     // 27: items.DownloadAttachmentResponse
+	(*DeleteAttachmentRequest)(nil),        // 28: items.DeleteAttachmentRequest
+	(*DeleteAttachmentResponse)(nil),       // 29: items.DeleteAttachmentResponse
+	(*ItemTemplate)(nil),                   // 30: items.ItemTemplate
+	(*SaveTemplateRequest)(nil),            // 31: items.SaveTemplateRequest
+	(*SaveTemplateResponse)(nil),           // 32: items.SaveTemplateResponse
+	(*ListTemplatesRequest)(nil),           // 33: items.ListTemplatesRequest
+	(*ListTemplatesResponse)(nil),          // 34: items.ListTemplatesResponse
+	(*DeleteTemplateRequest)(nil),          // 35: items.DeleteTemplateRequest
+	(*DeleteTemplateResponse)(nil),         // 36: items.DeleteTemplateResponse
+	(*UserKeys)(nil),                       // 37: items.UserKeys
+	(*ItemShare)(nil),                      // 38: items.ItemShare
+	(*SharedItem)(nil),                     // 39: items.SharedItem
+	(*SetUserKeysRequest)(nil),             // 40: items.SetUserKeysRequest
+	(*SetUserKeysResponse)(nil),            // 41: items.SetUserKeysResponse
+	(*GetUserKeysRequest)(nil),             // 42: items.GetUserKeysRequest
+	(*GetUserKeysResponse)(nil),            // 43: items.GetUserKeysResponse
+	(*GetPublicKeyRequest)(nil),            // 44: items.GetPublicKeyRequest
+	(*GetPublicKeyResponse)(nil),           // 45: items.GetPublicKeyResponse
+	(*ShareItemRequest)(nil),               // 46: items.ShareItemRequest
+	(*ShareItemResponse)(nil),              // 47: items.ShareItemResponse
+	(*ListItemSharesRequest)(nil),          // 48: items.ListItemSharesRequest
+	(*ListItemSharesResponse)(nil),         // 49: items.ListItemSharesResponse
+	(*ListSharedWithMeRequest)(nil),        // 50: items.ListSharedWithMeRequest
+	(*ListSharedWithMeResponse)(nil),       // 51: items.ListSharedWithMeResponse
+	(*RevokeShareRequest)(nil),             // 52: items.RevokeShareRequest
+	(*RevokeShareResponse)(nil),            // 53: items.RevokeShareResponse
+	(*EmergencyAccess)(nil),                // 54: items.EmergencyAccess
+	(*GrantEmergencyAccessRequest)(nil),    // 55: items.GrantEmergencyAccessRequest
+	(*GrantEmergencyAccessResponse)(nil),   // 56: items.GrantEmergencyAccessResponse
+	(*RevokeEmergencyAccessRequest)(nil),   // 57: items.RevokeEmergencyAccessRequest
+	(*RevokeEmergencyAccessResponse)(nil),  // 58: items.RevokeEmergencyAccessResponse
+	(*ListEmergencyAccessRequest)(nil),     // 59: items.ListEmergencyAccessRequest
+	(*ListEmergencyAccessResponse)(nil),    // 60: items.ListEmergencyAccessResponse
+	(*RequestEmergencyAccessRequest)(nil),  // 61: items.RequestEmergencyAccessRequest
+	(*RequestEmergencyAccessResponse)(nil), // 62: items.RequestEmergencyAccessResponse
+	(*ApproveEmergencyAccessRequest)(nil),  // 63: items.ApproveEmergencyAccessRequest
+	(*ApproveEmergencyAccessResponse)(nil), // 64: items.ApproveEmergencyAccessResponse
+	(*DenyEmergencyAccessRequest)(nil),     // 65: items.DenyEmergencyAccessRequest
+	(*DenyEmergencyAccessResponse)(nil),    // 66: items.DenyEmergencyAccessResponse
+	(*GetEmergencyVaultRequest)(nil),       // 67: items.GetEmergencyVaultRequest
+	(*GetEmergencyVaultResponse)(nil),      // 68: items.GetEmergencyVaultResponse
+	(*TakeoverAccountRequest)(nil),         // 69: items.TakeoverAccountRequest
+	(*TakeoverAccountResponse)(nil),        // 70: items.TakeoverAccountResponse
+	(*CreateSendRequest)(nil),              // 71: items.CreateSendRequest
+	(*CreateSendResponse)(nil),             // 72: items.CreateSendResponse
+	nil,                                    // 73: items.EncryptedItem.MetaEntry
+	nil,                                    // 74: items.TypesCountsResponse.TypesEntry
+	(*timestamppb.Timestamp)(nil),          // 75: google.protobuf.Timestamp
 }
 var file_internal_protos_items_items_proto_depIdxs = []int32{
 	0,  // 0: items.EncryptedItem.type:type_name -> items.ItemType
-	2,  // 1: items.EncryptedItem.encrypted_data:type_name -> items.EncryptedData
-	13, // 2: items.EncryptedItem.meta:type_name -> items.EncryptedItem.MetaEntry
-	15, // 3: items.EncryptedItem.created_at:type_name -> google.protobuf.Timestamp
-	15, // 4: items.EncryptedItem.updated_at:type_name -> google.protobuf.Timestamp
-	1,  // 5: items.AddItemRequest.item:type_name -> items.EncryptedItem
-	0,  // 6: items.GetUserItemsRequest.type:type_name -> items.ItemType
-	1,  // 7: items.GetUserItemsResponse.items:type_name -> items.EncryptedItem
-	1,  // 8: items.EditItemRequest.item:type_name -> items.EncryptedItem
-	14, // 9: items.TypesCountsResponse.types:type_name -> items.TypesCountsResponse.TypesEntry
-	3,  // 10: items.ItemsController.AddItem:input_type -> items.AddItemRequest
-	7,  // 11: items.ItemsController.EditItem:input_type -> items.EditItemRequest
-	9,  // 12: items.ItemsController.DeleteItem:input_type -> items.DeleteItemRequest
-	5,  // 13: items.ItemsController.GetUserItems:input_type -> items.GetUserItemsRequest
-	11, // 14: items.ItemsController.TypesCounts:input_type -> items.TypesCountsRequest
-	4,  // 15: items.ItemsController.AddItem:output_type -> items.AddItemResponse
-	8,  // 16: items.ItemsController.EditItem:output_type -> items.EditItemResponse
-	10, // 17: items.ItemsController.DeleteItem:output_type -> items.DeleteItemResponse
-	6,  // 18: items.ItemsController.GetUserItems:output_type -> items.GetUserItemsResponse
-	12, // 19: items.ItemsController.TypesCounts:output_type -> items.TypesCountsResponse
-	15, // [15:20] is the sub-list for method output_type
-	10, // [10:15] is the sub-list for method input_type
-	10, // [10:10] is the sub-list for extension type_name
-	10, // [10:10] is the sub-list for extension extendee
-	0,  // [0:10] is the sub-list for field type_name
+	4,  // 1: items.EncryptedItem.encrypted_data:type_name -> items.EncryptedData
+	73, // 2: items.EncryptedItem.meta:type_name -> items.EncryptedItem.MetaEntry
+	75, // 3: items.EncryptedItem.created_at:type_name -> google.protobuf.Timestamp
+	75, // 4: items.EncryptedItem.updated_at:type_name -> google.protobuf.Timestamp
+	75, // 5: items.EncryptedItem.expires_at:type_name -> google.protobuf.Timestamp
+	75, // 6: items.EncryptedItem.last_used_at:type_name -> google.protobuf.Timestamp
+	4,  // 7: items.EncryptedItem.encrypted_header:type_name -> items.EncryptedData
+	3,  // 8: items.AddItemRequest.item:type_name -> items.EncryptedItem
+	0,  // 9: items.GetUserItemsRequest.type:type_name -> items.ItemType
+	3,  // 10: items.GetUserItemsResponse.items:type_name -> items.EncryptedItem
+	3,  // 11: items.EditItemRequest.item:type_name -> items.EncryptedItem
+	74, // 12: items.TypesCountsResponse.types:type_name -> items.TypesCountsResponse.TypesEntry
+	3,  // 13: items.SearchItemsResponse.items:type_name -> items.EncryptedItem
+	4,  // 14: items.Attachment.encrypted_info:type_name -> items.EncryptedData
+	75, // 15: items.Attachment.created_at:type_name -> google.protobuf.Timestamp
+	21, // 16: items.UploadAttachmentRequest.attachment:type_name -> items.Attachment
+	21, // 17: items.ListAttachmentsResponse.attachments:type_name -> items.Attachment
+	21, // 18: items.DownloadAttachmentResponse.attachment:type_name -> items.Attachment
+	4,  // 19: items.ItemTemplate.encrypted_data:type_name -> items.EncryptedData
+	75, // 20: items.ItemTemplate.created_at:type_name -> google.protobuf.Timestamp
+	75, // 21: items.ItemTemplate.updated_at:type_name -> google.protobuf.Timestamp
+	30, // 22: items.SaveTemplateRequest.template:type_name -> items.ItemTemplate
+	30, // 23: items.ListTemplatesResponse.templates:type_name -> items.ItemTemplate
+	75, // 24: items.ItemShare.created_at:type_name -> google.protobuf.Timestamp
+	3,  // 25: items.SharedItem.item:type_name -> items.EncryptedItem
+	37, // 26: items.SetUserKeysRequest.keys:type_name -> items.UserKeys
+	37, // 27: items.GetUserKeysResponse.keys:type_name -> items.UserKeys
+	38, // 28: items.ShareItemRequest.share:type_name -> items.ItemShare
+	38, // 29: items.ListItemSharesResponse.shares:type_name -> items.ItemShare
+	39, // 30: items.ListSharedWithMeResponse.items:type_name -> items.SharedItem
+	3,  // 31: items.RevokeShareRequest.item:type_name -> items.EncryptedItem
+	38, // 32: items.RevokeShareRequest.shares:type_name -> items.ItemShare
+	21, // 33: items.RevokeShareRequest.attachments:type_name -> items.Attachment
+	1,  // 34: items.EmergencyAccess.type:type_name -> items.EmergencyAccessType
+	2,  // 35: items.EmergencyAccess.status:type_name -> items.EmergencyStatus
+	75, // 36: items.EmergencyAccess.requested_at:type_name -> google.protobuf.Timestamp
+	75, // 37: items.EmergencyAccess.created_at:type_name -> google.protobuf.Timestamp
+	54, // 38: items.GrantEmergencyAccessRequest.access:type_name -> items.EmergencyAccess
+	54, // 39: items.ListEmergencyAccessResponse.granted:type_name -> items.EmergencyAccess
+	54, // 40: items.ListEmergencyAccessResponse.trusted_by:type_name -> items.EmergencyAccess
+	54, // 41: items.GetEmergencyVaultResponse.access:type_name -> items.EmergencyAccess
+	37, // 42: items.GetEmergencyVaultResponse.keys:type_name -> items.UserKeys
+	3,  // 43: items.GetEmergencyVaultResponse.items:type_name -> items.EncryptedItem
+	30, // 44: items.GetEmergencyVaultResponse.templates:type_name -> items.ItemTemplate
+	3,  // 45: items.TakeoverAccountRequest.items:type_name -> items.EncryptedItem
+	30, // 46: items.TakeoverAccountRequest.templates:type_name -> items.ItemTemplate
+	4,  // 47: items.CreateSendRequest.encrypted_data:type_name -> items.EncryptedData
+	75, // 48: items.CreateSendRequest.expires_at:type_name -> google.protobuf.Timestamp
+	5,  // 49: items.ItemsController.AddItem:input_type -> items.AddItemRequest
+	9,  // 50: items.ItemsController.EditItem:input_type -> items.EditItemRequest
+	11, // 51: items.ItemsController.DeleteItem:input_type -> items.DeleteItemRequest
+	7,  // 52: items.ItemsController.GetUserItems:input_type -> items.GetUserItemsRequest
+	13, // 53: items.ItemsController.TypesCounts:input_type -> items.TypesCountsRequest
+	15, // 54: items.ItemsController.SearchItems:input_type -> items.SearchItemsRequest
+	17, // 55: items.ItemsController.TouchItem:input_type -> items.TouchItemRequest
+	19, // 56: items.ItemsController.SetItemFavorite:input_type -> items.SetItemFavoriteRequest
+	22, // 57: items.ItemsController.UploadAttachment:input_type -> items.UploadAttachmentRequest
+	24, // 58: items.ItemsController.ListAttachments:input_type -> items.ListAttachmentsRequest
+	26, // 59: items.ItemsController.DownloadAttachment:input_type -> items.DownloadAttachmentRequest
+	28, // 60: items.ItemsController.DeleteAttachment:input_type -> items.DeleteAttachmentRequest
+	31, // 61: items.ItemsController.SaveTemplate:input_type -> items.SaveTemplateRequest
+	33, // 62: items.ItemsController.ListTemplates:input_type -> items.ListTemplatesRequest
+	35, // 63: items.ItemsController.DeleteTemplate:input_type -> items.DeleteTemplateRequest
+	40, // 64: items.SharesController.SetUserKeys:input_type -> items.SetUserKeysRequest
+	42, // 65: items.SharesController.GetUserKeys:input_type -> items.GetUserKeysRequest
+	44, // 66: items.SharesController.GetPublicKey:input_type -> items.GetPublicKeyRequest
+	46, // 67: items.SharesController.ShareItem:input_type -> items.ShareItemRequest
+	48, // 68: items.SharesController.ListItemShares:input_type -> items.ListItemSharesRequest
+	50, // 69: items.SharesController.ListSharedWithMe:input_type -> items.ListSharedWithMeRequest
+	52, // 70: items.SharesController.RevokeShare:input_type -> items.RevokeShareRequest
+	55, // 71: items.EmergencyController.GrantEmergencyAccess:input_type -> items.GrantEmergencyAccessRequest
+	57, // 72: items.EmergencyController.RevokeEmergencyAccess:input_type -> items.RevokeEmergencyAccessRequest
+	59, // 73: items.EmergencyController.ListEmergencyAccess:input_type -> items.ListEmergencyAccessRequest
+	61, // 74: items.EmergencyController.RequestEmergencyAccess:input_type -> items.RequestEmergencyAccessRequest
+	63, // 75: items.EmergencyController.ApproveEmergencyAccess:input_type -> items.ApproveEmergencyAccessRequest
+	65, // 76: items.EmergencyController.DenyEmergencyAccess:input_type -> items.DenyEmergencyAccessRequest
+	67, // 77: items.EmergencyController.GetEmergencyVault:input_type -> items.GetEmergencyVaultRequest
+	69, // 78: items.EmergencyController.TakeoverAccount:input_type -> items.TakeoverAccountRequest
+	71, // 79: items.SendsController.CreateSend:input_type -> items.CreateSendRequest
+	6,  // 80: items.ItemsController.AddItem:output_type -> items.AddItemResponse
+	10, // 81: items.ItemsController.EditItem:output_type -> items.EditItemResponse
+	12, // 82: items.ItemsController.DeleteItem:output_type -> items.DeleteItemResponse
+	8,  // 83: items.ItemsController.GetUserItems:output_type -> items.GetUserItemsResponse
+	14, // 84: items.ItemsController.TypesCounts:output_type -> items.TypesCountsResponse
+	16, // 85: items.ItemsController.SearchItems:output_type -> items.SearchItemsResponse
+	18, // 86: items.ItemsController.TouchItem:output_type -> items.TouchItemResponse
+	20, // 87: items.ItemsController.SetItemFavorite:output_type -> items.SetItemFavoriteResponse
+	23, // 88: items.ItemsController.UploadAttachment:output_type -> items.UploadAttachmentResponse
+	25, // 89: items.ItemsController.ListAttachments:output_type -> items.ListAttachmentsResponse
+	27, // 90: items.ItemsController.DownloadAttachment:output_type -> items.DownloadAttachmentResponse
+	29, // 91: items.ItemsController.DeleteAttachment:output_type -> items.DeleteAttachmentResponse
+	32, // 92: items.ItemsController.SaveTemplate:output_type -> items.SaveTemplateResponse
+	34, // 93: items.ItemsController.ListTemplates:output_type -> items.ListTemplatesResponse
+	36, // 94: items.ItemsController.DeleteTemplate:output_type -> items.DeleteTemplateResponse
+	41, // 95: items.SharesController.SetUserKeys:output_type -> items.SetUserKeysResponse
+	43, // 96: items.SharesController.GetUserKeys:output_type -> items.GetUserKeysResponse
+	45, // 97: items.SharesController.GetPublicKey:output_type -> items.GetPublicKeyResponse
+	47, // 98: items.SharesController.ShareItem:output_type -> items.ShareItemResponse
+	49, // 99: items.SharesController.ListItemShares:output_type -> items.ListItemSharesResponse
+	51, // 100: items.SharesController.ListSharedWithMe:output_type -> items.ListSharedWithMeResponse
+	53, // 101: items.SharesController.RevokeShare:output_type -> items.RevokeShareResponse
+	56, // 102: items.EmergencyController.GrantEmergencyAccess:output_type -> items.GrantEmergencyAccessResponse
+	58, // 103: items.EmergencyController.RevokeEmergencyAccess:output_type -> items.RevokeEmergencyAccessResponse
+	60, // 104: items.EmergencyController.ListEmergencyAccess:output_type -> items.ListEmergencyAccessResponse
+	62, // 105: items.EmergencyController.RequestEmergencyAccess:output_type -> items.RequestEmergencyAccessResponse
+	64, // 106: items.EmergencyController.ApproveEmergencyAccess:output_type -> items.ApproveEmergencyAccessResponse
+	66, // 107: items.EmergencyController.DenyEmergencyAccess:output_type -> items.DenyEmergencyAccessResponse
+	68, // 108: items.EmergencyController.GetEmergencyVault:output_type -> items.GetEmergencyVaultResponse
+	70, // 109: items.EmergencyController.TakeoverAccount:output_type -> items.TakeoverAccountResponse
+	72, // 110: items.SendsController.CreateSend:output_type -> items.CreateSendResponse
+	80, // [80:111] is the sub-list for method output_type
+	49, // [49:80] is the sub-list for method input_type
+	49, // [49:49] is the sub-list for extension type_name
+	49, // [49:49] is the sub-list for extension extendee
+	0,  // [0:49] is the sub-list for field type_name
 }
 
 func init() { file_internal_protos_items_items_proto_init() }
@@ -826,10 +4269,10 @@ func file_internal_protos_items_items_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_internal_protos_items_items_proto_rawDesc), len(file_internal_protos_items_items_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   14,
+			NumEnums:      3,
+			NumMessages:   72,
 			NumExtensions: 0,
-			NumServices:   1,
+			NumServices:   4,
 		},
 		GoTypes:           file_internal_protos_items_items_proto_goTypes,
 		DependencyIndexes: file_internal_protos_items_items_proto_depIdxs,
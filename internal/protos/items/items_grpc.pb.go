@@ -19,11 +19,21 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	ItemsController_AddItem_FullMethodName      = "/items.ItemsController/AddItem"
-	ItemsController_EditItem_FullMethodName     = "/items.ItemsController/EditItem"
-	ItemsController_DeleteItem_FullMethodName   = "/items.ItemsController/DeleteItem"
-	ItemsController_GetUserItems_FullMethodName = "/items.ItemsController/GetUserItems"
-	ItemsController_TypesCounts_FullMethodName  = "/items.ItemsController/TypesCounts"
+	ItemsController_AddItem_FullMethodName            = "/items.ItemsController/AddItem"
+	ItemsController_EditItem_FullMethodName           = "/items.ItemsController/EditItem"
+	ItemsController_DeleteItem_FullMethodName         = "/items.ItemsController/DeleteItem"
+	ItemsController_GetUserItems_FullMethodName       = "/items.ItemsController/GetUserItems"
+	ItemsController_TypesCounts_FullMethodName        = "/items.ItemsController/TypesCounts"
+	ItemsController_SearchItems_FullMethodName        = "/items.ItemsController/SearchItems"
+	ItemsController_TouchItem_FullMethodName          = "/items.ItemsController/TouchItem"
+	ItemsController_SetItemFavorite_FullMethodName    = "/items.ItemsController/SetItemFavorite"
+	ItemsController_UploadAttachment_FullMethodName   = "/items.ItemsController/UploadAttachment"
+	ItemsController_ListAttachments_FullMethodName    = "/items.ItemsController/ListAttachments"
+	ItemsController_DownloadAttachment_FullMethodName = "/items.ItemsController/DownloadAttachment"
+	ItemsController_DeleteAttachment_FullMethodName   = "/items.ItemsController/DeleteAttachment"
+	ItemsController_SaveTemplate_FullMethodName       = "/items.ItemsController/SaveTemplate"
+	ItemsController_ListTemplates_FullMethodName      = "/items.ItemsController/ListTemplates"
+	ItemsController_DeleteTemplate_FullMethodName     = "/items.ItemsController/DeleteTemplate"
 )
 
 // ItemsControllerClient is the client API for ItemsController service.
@@ -35,6 +45,16 @@ type ItemsControllerClient interface {
 	DeleteItem(ctx context.Context, in *DeleteItemRequest, opts ...grpc.CallOption) (*DeleteItemResponse, error)
 	GetUserItems(ctx context.Context, in *GetUserItemsRequest, opts ...grpc.CallOption) (*GetUserItemsResponse, error)
 	TypesCounts(ctx context.Context, in *TypesCountsRequest, opts ...grpc.CallOption) (*TypesCountsResponse, error)
+	SearchItems(ctx context.Context, in *SearchItemsRequest, opts ...grpc.CallOption) (*SearchItemsResponse, error)
+	TouchItem(ctx context.Context, in *TouchItemRequest, opts ...grpc.CallOption) (*TouchItemResponse, error)
+	SetItemFavorite(ctx context.Context, in *SetItemFavoriteRequest, opts ...grpc.CallOption) (*SetItemFavoriteResponse, error)
+	UploadAttachment(ctx context.Context, in *UploadAttachmentRequest, opts ...grpc.CallOption) (*UploadAttachmentResponse, error)
+	ListAttachments(ctx context.Context, in *ListAttachmentsRequest, opts ...grpc.CallOption) (*ListAttachmentsResponse, error)
+	DownloadAttachment(ctx context.Context, in *DownloadAttachmentRequest, opts ...grpc.CallOption) (*DownloadAttachmentResponse, error)
+	DeleteAttachment(ctx context.Context, in *DeleteAttachmentRequest, opts ...grpc.CallOption) (*DeleteAttachmentResponse, error)
+	SaveTemplate(ctx context.Context, in *SaveTemplateRequest, opts ...grpc.CallOption) (*SaveTemplateResponse, error)
+	ListTemplates(ctx context.Context, in *ListTemplatesRequest, opts ...grpc.CallOption) (*ListTemplatesResponse, error)
+	DeleteTemplate(ctx context.Context, in *DeleteTemplateRequest, opts ...grpc.CallOption) (*DeleteTemplateResponse, error)
 }
 
 type itemsControllerClient struct {
@@ -95,6 +115,106 @@ func (c *itemsControllerClient) TypesCounts(ctx context.Context, in *TypesCounts
 	return out, nil
 }
 
+func (c *itemsControllerClient) SearchItems(ctx context.Context, in *SearchItemsRequest, opts ...grpc.CallOption) (*SearchItemsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchItemsResponse)
+	err := c.cc.Invoke(ctx, ItemsController_SearchItems_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *itemsControllerClient) TouchItem(ctx context.Context, in *TouchItemRequest, opts ...grpc.CallOption) (*TouchItemResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TouchItemResponse)
+	err := c.cc.Invoke(ctx, ItemsController_TouchItem_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *itemsControllerClient) SetItemFavorite(ctx context.Context, in *SetItemFavoriteRequest, opts ...grpc.CallOption) (*SetItemFavoriteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetItemFavoriteResponse)
+	err := c.cc.Invoke(ctx, ItemsController_SetItemFavorite_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *itemsControllerClient) UploadAttachment(ctx context.Context, in *UploadAttachmentRequest, opts ...grpc.CallOption) (*UploadAttachmentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UploadAttachmentResponse)
+	err := c.cc.Invoke(ctx, ItemsController_UploadAttachment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *itemsControllerClient) ListAttachments(ctx context.Context, in *ListAttachmentsRequest, opts ...grpc.CallOption) (*ListAttachmentsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAttachmentsResponse)
+	err := c.cc.Invoke(ctx, ItemsController_ListAttachments_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *itemsControllerClient) DownloadAttachment(ctx context.Context, in *DownloadAttachmentRequest, opts ...grpc.CallOption) (*DownloadAttachmentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DownloadAttachmentResponse)
+	err := c.cc.Invoke(ctx, ItemsController_DownloadAttachment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *itemsControllerClient) DeleteAttachment(ctx context.Context, in *DeleteAttachmentRequest, opts ...grpc.CallOption) (*DeleteAttachmentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteAttachmentResponse)
+	err := c.cc.Invoke(ctx, ItemsController_DeleteAttachment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *itemsControllerClient) SaveTemplate(ctx context.Context, in *SaveTemplateRequest, opts ...grpc.CallOption) (*SaveTemplateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SaveTemplateResponse)
+	err := c.cc.Invoke(ctx, ItemsController_SaveTemplate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *itemsControllerClient) ListTemplates(ctx context.Context, in *ListTemplatesRequest, opts ...grpc.CallOption) (*ListTemplatesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTemplatesResponse)
+	err := c.cc.Invoke(ctx, ItemsController_ListTemplates_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *itemsControllerClient) DeleteTemplate(ctx context.Context, in *DeleteTemplateRequest, opts ...grpc.CallOption) (*DeleteTemplateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteTemplateResponse)
+	err := c.cc.Invoke(ctx, ItemsController_DeleteTemplate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ItemsControllerServer is the server API for ItemsController service.
 // All implementations must embed UnimplementedItemsControllerServer
 // for forward compatibility.
@@ -104,6 +224,16 @@ type ItemsControllerServer interface {
 	DeleteItem(context.Context, *DeleteItemRequest) (*DeleteItemResponse, error)
 	GetUserItems(context.Context, *GetUserItemsRequest) (*GetUserItemsResponse, error)
 	TypesCounts(context.Context, *TypesCountsRequest) (*TypesCountsResponse, error)
+	SearchItems(context.Context, *SearchItemsRequest) (*SearchItemsResponse, error)
+	TouchItem(context.Context, *TouchItemRequest) (*TouchItemResponse, error)
+	SetItemFavorite(context.Context, *SetItemFavoriteRequest) (*SetItemFavoriteResponse, error)
+	UploadAttachment(context.Context, *UploadAttachmentRequest) (*UploadAttachmentResponse, error)
+	ListAttachments(context.Context, *ListAttachmentsRequest) (*ListAttachmentsResponse, error)
+	DownloadAttachment(context.Context, *DownloadAttachmentRequest) (*DownloadAttachmentResponse, error)
+	DeleteAttachment(context.Context, *DeleteAttachmentRequest) (*DeleteAttachmentResponse, error)
+	SaveTemplate(context.Context, *SaveTemplateRequest) (*SaveTemplateResponse, error)
+	ListTemplates(context.Context, *ListTemplatesRequest) (*ListTemplatesResponse, error)
+	DeleteTemplate(context.Context, *DeleteTemplateRequest) (*DeleteTemplateResponse, error)
 	mustEmbedUnimplementedItemsControllerServer()
 }
 
@@ -129,6 +259,36 @@ func (UnimplementedItemsControllerServer) GetUserItems(context.Context, *GetUser
 func (UnimplementedItemsControllerServer) TypesCounts(context.Context, *TypesCountsRequest) (*TypesCountsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method TypesCounts not implemented")
 }
+func (UnimplementedItemsControllerServer) SearchItems(context.Context, *SearchItemsRequest) (*SearchItemsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchItems not implemented")
+}
+func (UnimplementedItemsControllerServer) TouchItem(context.Context, *TouchItemRequest) (*TouchItemResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TouchItem not implemented")
+}
+func (UnimplementedItemsControllerServer) SetItemFavorite(context.Context, *SetItemFavoriteRequest) (*SetItemFavoriteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetItemFavorite not implemented")
+}
+func (UnimplementedItemsControllerServer) UploadAttachment(context.Context, *UploadAttachmentRequest) (*UploadAttachmentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UploadAttachment not implemented")
+}
+func (UnimplementedItemsControllerServer) ListAttachments(context.Context, *ListAttachmentsRequest) (*ListAttachmentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAttachments not implemented")
+}
+func (UnimplementedItemsControllerServer) DownloadAttachment(context.Context, *DownloadAttachmentRequest) (*DownloadAttachmentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DownloadAttachment not implemented")
+}
+func (UnimplementedItemsControllerServer) DeleteAttachment(context.Context, *DeleteAttachmentRequest) (*DeleteAttachmentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteAttachment not implemented")
+}
+func (UnimplementedItemsControllerServer) SaveTemplate(context.Context, *SaveTemplateRequest) (*SaveTemplateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SaveTemplate not implemented")
+}
+func (UnimplementedItemsControllerServer) ListTemplates(context.Context, *ListTemplatesRequest) (*ListTemplatesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTemplates not implemented")
+}
+func (UnimplementedItemsControllerServer) DeleteTemplate(context.Context, *DeleteTemplateRequest) (*DeleteTemplateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteTemplate not implemented")
+}
 func (UnimplementedItemsControllerServer) mustEmbedUnimplementedItemsControllerServer() {}
 func (UnimplementedItemsControllerServer) testEmbeddedByValue()                         {}
 
@@ -240,6 +400,186 @@ func _ItemsController_TypesCounts_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ItemsController_SearchItems_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchItemsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ItemsControllerServer).SearchItems(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ItemsController_SearchItems_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ItemsControllerServer).SearchItems(ctx, req.(*SearchItemsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ItemsController_TouchItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TouchItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ItemsControllerServer).TouchItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ItemsController_TouchItem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ItemsControllerServer).TouchItem(ctx, req.(*TouchItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ItemsController_SetItemFavorite_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetItemFavoriteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ItemsControllerServer).SetItemFavorite(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ItemsController_SetItemFavorite_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ItemsControllerServer).SetItemFavorite(ctx, req.(*SetItemFavoriteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ItemsController_UploadAttachment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UploadAttachmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ItemsControllerServer).UploadAttachment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ItemsController_UploadAttachment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ItemsControllerServer).UploadAttachment(ctx, req.(*UploadAttachmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ItemsController_ListAttachments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAttachmentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ItemsControllerServer).ListAttachments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ItemsController_ListAttachments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ItemsControllerServer).ListAttachments(ctx, req.(*ListAttachmentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ItemsController_DownloadAttachment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DownloadAttachmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ItemsControllerServer).DownloadAttachment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ItemsController_DownloadAttachment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ItemsControllerServer).DownloadAttachment(ctx, req.(*DownloadAttachmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ItemsController_DeleteAttachment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteAttachmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ItemsControllerServer).DeleteAttachment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ItemsController_DeleteAttachment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ItemsControllerServer).DeleteAttachment(ctx, req.(*DeleteAttachmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ItemsController_SaveTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SaveTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ItemsControllerServer).SaveTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ItemsController_SaveTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ItemsControllerServer).SaveTemplate(ctx, req.(*SaveTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ItemsController_ListTemplates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTemplatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ItemsControllerServer).ListTemplates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ItemsController_ListTemplates_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ItemsControllerServer).ListTemplates(ctx, req.(*ListTemplatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ItemsController_DeleteTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ItemsControllerServer).DeleteTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ItemsController_DeleteTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ItemsControllerServer).DeleteTemplate(ctx, req.(*DeleteTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ItemsController_ServiceDesc is the grpc.ServiceDesc for ItemsController service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -267,6 +607,846 @@ var ItemsController_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "TypesCounts",
 			Handler:    _ItemsController_TypesCounts_Handler,
 		},
+		{
+			MethodName: "SearchItems",
+			Handler:    _ItemsController_SearchItems_Handler,
+		},
+		{
+			MethodName: "TouchItem",
+			Handler:    _ItemsController_TouchItem_Handler,
+		},
+		{
+			MethodName: "SetItemFavorite",
+			Handler:    _ItemsController_SetItemFavorite_Handler,
+		},
+		{
+			MethodName: "UploadAttachment",
+			Handler:    _ItemsController_UploadAttachment_Handler,
+		},
+		{
+			MethodName: "ListAttachments",
+			Handler:    _ItemsController_ListAttachments_Handler,
+		},
+		{
+			MethodName: "DownloadAttachment",
+			Handler:    _ItemsController_DownloadAttachment_Handler,
+		},
+		{
+			MethodName: "DeleteAttachment",
+			Handler:    _ItemsController_DeleteAttachment_Handler,
+		},
+		{
+			MethodName: "SaveTemplate",
+			Handler:    _ItemsController_SaveTemplate_Handler,
+		},
+		{
+			MethodName: "ListTemplates",
+			Handler:    _ItemsController_ListTemplates_Handler,
+		},
+		{
+			MethodName: "DeleteTemplate",
+			Handler:    _ItemsController_DeleteTemplate_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "internal/protos/items/items.proto",
+}
+
+const (
+	SharesController_SetUserKeys_FullMethodName      = "/items.SharesController/SetUserKeys"
+	SharesController_GetUserKeys_FullMethodName      = "/items.SharesController/GetUserKeys"
+	SharesController_GetPublicKey_FullMethodName     = "/items.SharesController/GetPublicKey"
+	SharesController_ShareItem_FullMethodName        = "/items.SharesController/ShareItem"
+	SharesController_ListItemShares_FullMethodName   = "/items.SharesController/ListItemShares"
+	SharesController_ListSharedWithMe_FullMethodName = "/items.SharesController/ListSharedWithMe"
+	SharesController_RevokeShare_FullMethodName      = "/items.SharesController/RevokeShare"
+)
+
+// SharesControllerClient is the client API for SharesController service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type SharesControllerClient interface {
+	SetUserKeys(ctx context.Context, in *SetUserKeysRequest, opts ...grpc.CallOption) (*SetUserKeysResponse, error)
+	GetUserKeys(ctx context.Context, in *GetUserKeysRequest, opts ...grpc.CallOption) (*GetUserKeysResponse, error)
+	GetPublicKey(ctx context.Context, in *GetPublicKeyRequest, opts ...grpc.CallOption) (*GetPublicKeyResponse, error)
+	ShareItem(ctx context.Context, in *ShareItemRequest, opts ...grpc.CallOption) (*ShareItemResponse, error)
+	ListItemShares(ctx context.Context, in *ListItemSharesRequest, opts ...grpc.CallOption) (*ListItemSharesResponse, error)
+	ListSharedWithMe(ctx context.Context, in *ListSharedWithMeRequest, opts ...grpc.CallOption) (*ListSharedWithMeResponse, error)
+	RevokeShare(ctx context.Context, in *RevokeShareRequest, opts ...grpc.CallOption) (*RevokeShareResponse, error)
+}
+
+type sharesControllerClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSharesControllerClient(cc grpc.ClientConnInterface) (SharesControllerClient, error) {
+	return &sharesControllerClient{cc}, nil
+}
+
+func (c *sharesControllerClient) SetUserKeys(ctx context.Context, in *SetUserKeysRequest, opts ...grpc.CallOption) (*SetUserKeysResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetUserKeysResponse)
+	err := c.cc.Invoke(ctx, SharesController_SetUserKeys_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sharesControllerClient) GetUserKeys(ctx context.Context, in *GetUserKeysRequest, opts ...grpc.CallOption) (*GetUserKeysResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetUserKeysResponse)
+	err := c.cc.Invoke(ctx, SharesController_GetUserKeys_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sharesControllerClient) GetPublicKey(ctx context.Context, in *GetPublicKeyRequest, opts ...grpc.CallOption) (*GetPublicKeyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetPublicKeyResponse)
+	err := c.cc.Invoke(ctx, SharesController_GetPublicKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sharesControllerClient) ShareItem(ctx context.Context, in *ShareItemRequest, opts ...grpc.CallOption) (*ShareItemResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ShareItemResponse)
+	err := c.cc.Invoke(ctx, SharesController_ShareItem_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sharesControllerClient) ListItemShares(ctx context.Context, in *ListItemSharesRequest, opts ...grpc.CallOption) (*ListItemSharesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListItemSharesResponse)
+	err := c.cc.Invoke(ctx, SharesController_ListItemShares_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sharesControllerClient) ListSharedWithMe(ctx context.Context, in *ListSharedWithMeRequest, opts ...grpc.CallOption) (*ListSharedWithMeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSharedWithMeResponse)
+	err := c.cc.Invoke(ctx, SharesController_ListSharedWithMe_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sharesControllerClient) RevokeShare(ctx context.Context, in *RevokeShareRequest, opts ...grpc.CallOption) (*RevokeShareResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RevokeShareResponse)
+	err := c.cc.Invoke(ctx, SharesController_RevokeShare_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SharesControllerServer is the server API for SharesController service.
+// All implementations must embed UnimplementedSharesControllerServer
+// for forward compatibility.
+type SharesControllerServer interface {
+	SetUserKeys(context.Context, *SetUserKeysRequest) (*SetUserKeysResponse, error)
+	GetUserKeys(context.Context, *GetUserKeysRequest) (*GetUserKeysResponse, error)
+	GetPublicKey(context.Context, *GetPublicKeyRequest) (*GetPublicKeyResponse, error)
+	ShareItem(context.Context, *ShareItemRequest) (*ShareItemResponse, error)
+	ListItemShares(context.Context, *ListItemSharesRequest) (*ListItemSharesResponse, error)
+	ListSharedWithMe(context.Context, *ListSharedWithMeRequest) (*ListSharedWithMeResponse, error)
+	RevokeShare(context.Context, *RevokeShareRequest) (*RevokeShareResponse, error)
+	mustEmbedUnimplementedSharesControllerServer()
+}
+
+// UnimplementedSharesControllerServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedSharesControllerServer struct{}
+
+func (UnimplementedSharesControllerServer) SetUserKeys(context.Context, *SetUserKeysRequest) (*SetUserKeysResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetUserKeys not implemented")
+}
+func (UnimplementedSharesControllerServer) GetUserKeys(context.Context, *GetUserKeysRequest) (*GetUserKeysResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUserKeys not implemented")
+}
+func (UnimplementedSharesControllerServer) GetPublicKey(context.Context, *GetPublicKeyRequest) (*GetPublicKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPublicKey not implemented")
+}
+func (UnimplementedSharesControllerServer) ShareItem(context.Context, *ShareItemRequest) (*ShareItemResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ShareItem not implemented")
+}
+func (UnimplementedSharesControllerServer) ListItemShares(context.Context, *ListItemSharesRequest) (*ListItemSharesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListItemShares not implemented")
+}
+func (UnimplementedSharesControllerServer) ListSharedWithMe(context.Context, *ListSharedWithMeRequest) (*ListSharedWithMeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSharedWithMe not implemented")
+}
+func (UnimplementedSharesControllerServer) RevokeShare(context.Context, *RevokeShareRequest) (*RevokeShareResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeShare not implemented")
+}
+func (UnimplementedSharesControllerServer) mustEmbedUnimplementedSharesControllerServer() {}
+func (UnimplementedSharesControllerServer) testEmbeddedByValue()                          {}
+
+// UnsafeSharesControllerServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SharesControllerServer will
+// result in compilation errors.
+type UnsafeSharesControllerServer interface {
+	mustEmbedUnimplementedSharesControllerServer()
+}
+
+func RegisterSharesControllerServer(s grpc.ServiceRegistrar, srv SharesControllerServer) {
+	// If the following call pancis, it indicates UnimplementedSharesControllerServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&SharesController_ServiceDesc, srv)
+}
+
+func _SharesController_SetUserKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetUserKeysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SharesControllerServer).SetUserKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SharesController_SetUserKeys_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SharesControllerServer).SetUserKeys(ctx, req.(*SetUserKeysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SharesController_GetUserKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserKeysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SharesControllerServer).GetUserKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SharesController_GetUserKeys_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SharesControllerServer).GetUserKeys(ctx, req.(*GetUserKeysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SharesController_GetPublicKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPublicKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SharesControllerServer).GetPublicKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SharesController_GetPublicKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SharesControllerServer).GetPublicKey(ctx, req.(*GetPublicKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SharesController_ShareItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShareItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SharesControllerServer).ShareItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SharesController_ShareItem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SharesControllerServer).ShareItem(ctx, req.(*ShareItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SharesController_ListItemShares_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListItemSharesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SharesControllerServer).ListItemShares(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SharesController_ListItemShares_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SharesControllerServer).ListItemShares(ctx, req.(*ListItemSharesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SharesController_ListSharedWithMe_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSharedWithMeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SharesControllerServer).ListSharedWithMe(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SharesController_ListSharedWithMe_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SharesControllerServer).ListSharedWithMe(ctx, req.(*ListSharedWithMeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SharesController_RevokeShare_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeShareRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SharesControllerServer).RevokeShare(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SharesController_RevokeShare_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SharesControllerServer).RevokeShare(ctx, req.(*RevokeShareRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// SharesController_ServiceDesc is the grpc.ServiceDesc for SharesController service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SharesController_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "items.SharesController",
+	HandlerType: (*SharesControllerServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SetUserKeys",
+			Handler:    _SharesController_SetUserKeys_Handler,
+		},
+		{
+			MethodName: "GetUserKeys",
+			Handler:    _SharesController_GetUserKeys_Handler,
+		},
+		{
+			MethodName: "GetPublicKey",
+			Handler:    _SharesController_GetPublicKey_Handler,
+		},
+		{
+			MethodName: "ShareItem",
+			Handler:    _SharesController_ShareItem_Handler,
+		},
+		{
+			MethodName: "ListItemShares",
+			Handler:    _SharesController_ListItemShares_Handler,
+		},
+		{
+			MethodName: "ListSharedWithMe",
+			Handler:    _SharesController_ListSharedWithMe_Handler,
+		},
+		{
+			MethodName: "RevokeShare",
+			Handler:    _SharesController_RevokeShare_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "internal/protos/items/items.proto",
+}
+
+const (
+	EmergencyController_GrantEmergencyAccess_FullMethodName   = "/items.EmergencyController/GrantEmergencyAccess"
+	EmergencyController_RevokeEmergencyAccess_FullMethodName  = "/items.EmergencyController/RevokeEmergencyAccess"
+	EmergencyController_ListEmergencyAccess_FullMethodName    = "/items.EmergencyController/ListEmergencyAccess"
+	EmergencyController_RequestEmergencyAccess_FullMethodName = "/items.EmergencyController/RequestEmergencyAccess"
+	EmergencyController_ApproveEmergencyAccess_FullMethodName = "/items.EmergencyController/ApproveEmergencyAccess"
+	EmergencyController_DenyEmergencyAccess_FullMethodName    = "/items.EmergencyController/DenyEmergencyAccess"
+	EmergencyController_GetEmergencyVault_FullMethodName      = "/items.EmergencyController/GetEmergencyVault"
+	EmergencyController_TakeoverAccount_FullMethodName        = "/items.EmergencyController/TakeoverAccount"
+)
+
+// EmergencyControllerClient is the client API for EmergencyController service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type EmergencyControllerClient interface {
+	GrantEmergencyAccess(ctx context.Context, in *GrantEmergencyAccessRequest, opts ...grpc.CallOption) (*GrantEmergencyAccessResponse, error)
+	RevokeEmergencyAccess(ctx context.Context, in *RevokeEmergencyAccessRequest, opts ...grpc.CallOption) (*RevokeEmergencyAccessResponse, error)
+	ListEmergencyAccess(ctx context.Context, in *ListEmergencyAccessRequest, opts ...grpc.CallOption) (*ListEmergencyAccessResponse, error)
+	RequestEmergencyAccess(ctx context.Context, in *RequestEmergencyAccessRequest, opts ...grpc.CallOption) (*RequestEmergencyAccessResponse, error)
+	ApproveEmergencyAccess(ctx context.Context, in *ApproveEmergencyAccessRequest, opts ...grpc.CallOption) (*ApproveEmergencyAccessResponse, error)
+	DenyEmergencyAccess(ctx context.Context, in *DenyEmergencyAccessRequest, opts ...grpc.CallOption) (*DenyEmergencyAccessResponse, error)
+	GetEmergencyVault(ctx context.Context, in *GetEmergencyVaultRequest, opts ...grpc.CallOption) (*GetEmergencyVaultResponse, error)
+	TakeoverAccount(ctx context.Context, in *TakeoverAccountRequest, opts ...grpc.CallOption) (*TakeoverAccountResponse, error)
+}
+
+type emergencyControllerClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewEmergencyControllerClient(cc grpc.ClientConnInterface) (EmergencyControllerClient, error) {
+	return &emergencyControllerClient{cc}, nil
+}
+
+func (c *emergencyControllerClient) GrantEmergencyAccess(ctx context.Context, in *GrantEmergencyAccessRequest, opts ...grpc.CallOption) (*GrantEmergencyAccessResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GrantEmergencyAccessResponse)
+	err := c.cc.Invoke(ctx, EmergencyController_GrantEmergencyAccess_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *emergencyControllerClient) RevokeEmergencyAccess(ctx context.Context, in *RevokeEmergencyAccessRequest, opts ...grpc.CallOption) (*RevokeEmergencyAccessResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RevokeEmergencyAccessResponse)
+	err := c.cc.Invoke(ctx, EmergencyController_RevokeEmergencyAccess_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *emergencyControllerClient) ListEmergencyAccess(ctx context.Context, in *ListEmergencyAccessRequest, opts ...grpc.CallOption) (*ListEmergencyAccessResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListEmergencyAccessResponse)
+	err := c.cc.Invoke(ctx, EmergencyController_ListEmergencyAccess_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *emergencyControllerClient) RequestEmergencyAccess(ctx context.Context, in *RequestEmergencyAccessRequest, opts ...grpc.CallOption) (*RequestEmergencyAccessResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RequestEmergencyAccessResponse)
+	err := c.cc.Invoke(ctx, EmergencyController_RequestEmergencyAccess_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *emergencyControllerClient) ApproveEmergencyAccess(ctx context.Context, in *ApproveEmergencyAccessRequest, opts ...grpc.CallOption) (*ApproveEmergencyAccessResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ApproveEmergencyAccessResponse)
+	err := c.cc.Invoke(ctx, EmergencyController_ApproveEmergencyAccess_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *emergencyControllerClient) DenyEmergencyAccess(ctx context.Context, in *DenyEmergencyAccessRequest, opts ...grpc.CallOption) (*DenyEmergencyAccessResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DenyEmergencyAccessResponse)
+	err := c.cc.Invoke(ctx, EmergencyController_DenyEmergencyAccess_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *emergencyControllerClient) GetEmergencyVault(ctx context.Context, in *GetEmergencyVaultRequest, opts ...grpc.CallOption) (*GetEmergencyVaultResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetEmergencyVaultResponse)
+	err := c.cc.Invoke(ctx, EmergencyController_GetEmergencyVault_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *emergencyControllerClient) TakeoverAccount(ctx context.Context, in *TakeoverAccountRequest, opts ...grpc.CallOption) (*TakeoverAccountResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TakeoverAccountResponse)
+	err := c.cc.Invoke(ctx, EmergencyController_TakeoverAccount_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// EmergencyControllerServer is the server API for EmergencyController service.
+// All implementations must embed UnimplementedEmergencyControllerServer
+// for forward compatibility.
+type EmergencyControllerServer interface {
+	GrantEmergencyAccess(context.Context, *GrantEmergencyAccessRequest) (*GrantEmergencyAccessResponse, error)
+	RevokeEmergencyAccess(context.Context, *RevokeEmergencyAccessRequest) (*RevokeEmergencyAccessResponse, error)
+	ListEmergencyAccess(context.Context, *ListEmergencyAccessRequest) (*ListEmergencyAccessResponse, error)
+	RequestEmergencyAccess(context.Context, *RequestEmergencyAccessRequest) (*RequestEmergencyAccessResponse, error)
+	ApproveEmergencyAccess(context.Context, *ApproveEmergencyAccessRequest) (*ApproveEmergencyAccessResponse, error)
+	DenyEmergencyAccess(context.Context, *DenyEmergencyAccessRequest) (*DenyEmergencyAccessResponse, error)
+	GetEmergencyVault(context.Context, *GetEmergencyVaultRequest) (*GetEmergencyVaultResponse, error)
+	TakeoverAccount(context.Context, *TakeoverAccountRequest) (*TakeoverAccountResponse, error)
+	mustEmbedUnimplementedEmergencyControllerServer()
+}
+
+// UnimplementedEmergencyControllerServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedEmergencyControllerServer struct{}
+
+func (UnimplementedEmergencyControllerServer) GrantEmergencyAccess(context.Context, *GrantEmergencyAccessRequest) (*GrantEmergencyAccessResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GrantEmergencyAccess not implemented")
+}
+func (UnimplementedEmergencyControllerServer) RevokeEmergencyAccess(context.Context, *RevokeEmergencyAccessRequest) (*RevokeEmergencyAccessResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeEmergencyAccess not implemented")
+}
+func (UnimplementedEmergencyControllerServer) ListEmergencyAccess(context.Context, *ListEmergencyAccessRequest) (*ListEmergencyAccessResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListEmergencyAccess not implemented")
+}
+func (UnimplementedEmergencyControllerServer) RequestEmergencyAccess(context.Context, *RequestEmergencyAccessRequest) (*RequestEmergencyAccessResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequestEmergencyAccess not implemented")
+}
+func (UnimplementedEmergencyControllerServer) ApproveEmergencyAccess(context.Context, *ApproveEmergencyAccessRequest) (*ApproveEmergencyAccessResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApproveEmergencyAccess not implemented")
+}
+func (UnimplementedEmergencyControllerServer) DenyEmergencyAccess(context.Context, *DenyEmergencyAccessRequest) (*DenyEmergencyAccessResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DenyEmergencyAccess not implemented")
+}
+func (UnimplementedEmergencyControllerServer) GetEmergencyVault(context.Context, *GetEmergencyVaultRequest) (*GetEmergencyVaultResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEmergencyVault not implemented")
+}
+func (UnimplementedEmergencyControllerServer) TakeoverAccount(context.Context, *TakeoverAccountRequest) (*TakeoverAccountResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TakeoverAccount not implemented")
+}
+func (UnimplementedEmergencyControllerServer) mustEmbedUnimplementedEmergencyControllerServer() {}
+func (UnimplementedEmergencyControllerServer) testEmbeddedByValue()                             {}
+
+// UnsafeEmergencyControllerServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to EmergencyControllerServer will
+// result in compilation errors.
+type UnsafeEmergencyControllerServer interface {
+	mustEmbedUnimplementedEmergencyControllerServer()
+}
+
+func RegisterEmergencyControllerServer(s grpc.ServiceRegistrar, srv EmergencyControllerServer) {
+	// If the following call pancis, it indicates UnimplementedEmergencyControllerServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&EmergencyController_ServiceDesc, srv)
+}
+
+func _EmergencyController_GrantEmergencyAccess_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GrantEmergencyAccessRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmergencyControllerServer).GrantEmergencyAccess(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EmergencyController_GrantEmergencyAccess_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmergencyControllerServer).GrantEmergencyAccess(ctx, req.(*GrantEmergencyAccessRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EmergencyController_RevokeEmergencyAccess_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeEmergencyAccessRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmergencyControllerServer).RevokeEmergencyAccess(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EmergencyController_RevokeEmergencyAccess_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmergencyControllerServer).RevokeEmergencyAccess(ctx, req.(*RevokeEmergencyAccessRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EmergencyController_ListEmergencyAccess_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListEmergencyAccessRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmergencyControllerServer).ListEmergencyAccess(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EmergencyController_ListEmergencyAccess_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmergencyControllerServer).ListEmergencyAccess(ctx, req.(*ListEmergencyAccessRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EmergencyController_RequestEmergencyAccess_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestEmergencyAccessRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmergencyControllerServer).RequestEmergencyAccess(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EmergencyController_RequestEmergencyAccess_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmergencyControllerServer).RequestEmergencyAccess(ctx, req.(*RequestEmergencyAccessRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EmergencyController_ApproveEmergencyAccess_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApproveEmergencyAccessRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmergencyControllerServer).ApproveEmergencyAccess(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EmergencyController_ApproveEmergencyAccess_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmergencyControllerServer).ApproveEmergencyAccess(ctx, req.(*ApproveEmergencyAccessRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EmergencyController_DenyEmergencyAccess_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DenyEmergencyAccessRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmergencyControllerServer).DenyEmergencyAccess(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EmergencyController_DenyEmergencyAccess_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmergencyControllerServer).DenyEmergencyAccess(ctx, req.(*DenyEmergencyAccessRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EmergencyController_GetEmergencyVault_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEmergencyVaultRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmergencyControllerServer).GetEmergencyVault(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EmergencyController_GetEmergencyVault_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmergencyControllerServer).GetEmergencyVault(ctx, req.(*GetEmergencyVaultRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EmergencyController_TakeoverAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TakeoverAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmergencyControllerServer).TakeoverAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EmergencyController_TakeoverAccount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmergencyControllerServer).TakeoverAccount(ctx, req.(*TakeoverAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// EmergencyController_ServiceDesc is the grpc.ServiceDesc for EmergencyController service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var EmergencyController_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "items.EmergencyController",
+	HandlerType: (*EmergencyControllerServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GrantEmergencyAccess",
+			Handler:    _EmergencyController_GrantEmergencyAccess_Handler,
+		},
+		{
+			MethodName: "RevokeEmergencyAccess",
+			Handler:    _EmergencyController_RevokeEmergencyAccess_Handler,
+		},
+		{
+			MethodName: "ListEmergencyAccess",
+			Handler:    _EmergencyController_ListEmergencyAccess_Handler,
+		},
+		{
+			MethodName: "RequestEmergencyAccess",
+			Handler:    _EmergencyController_RequestEmergencyAccess_Handler,
+		},
+		{
+			MethodName: "ApproveEmergencyAccess",
+			Handler:    _EmergencyController_ApproveEmergencyAccess_Handler,
+		},
+		{
+			MethodName: "DenyEmergencyAccess",
+			Handler:    _EmergencyController_DenyEmergencyAccess_Handler,
+		},
+		{
+			MethodName: "GetEmergencyVault",
+			Handler:    _EmergencyController_GetEmergencyVault_Handler,
+		},
+		{
+			MethodName: "TakeoverAccount",
+			Handler:    _EmergencyController_TakeoverAccount_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "internal/protos/items/items.proto",
+}
+
+const (
+	SendsController_CreateSend_FullMethodName = "/items.SendsController/CreateSend"
+)
+
+// SendsControllerClient is the client API for SendsController service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type SendsControllerClient interface {
+	CreateSend(ctx context.Context, in *CreateSendRequest, opts ...grpc.CallOption) (*CreateSendResponse, error)
+}
+
+type sendsControllerClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSendsControllerClient(cc grpc.ClientConnInterface) (SendsControllerClient, error) {
+	return &sendsControllerClient{cc}, nil
+}
+
+func (c *sendsControllerClient) CreateSend(ctx context.Context, in *CreateSendRequest, opts ...grpc.CallOption) (*CreateSendResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateSendResponse)
+	err := c.cc.Invoke(ctx, SendsController_CreateSend_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SendsControllerServer is the server API for SendsController service.
+// All implementations must embed UnimplementedSendsControllerServer
+// for forward compatibility.
+type SendsControllerServer interface {
+	CreateSend(context.Context, *CreateSendRequest) (*CreateSendResponse, error)
+	mustEmbedUnimplementedSendsControllerServer()
+}
+
+// UnimplementedSendsControllerServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedSendsControllerServer struct{}
+
+func (UnimplementedSendsControllerServer) CreateSend(context.Context, *CreateSendRequest) (*CreateSendResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSend not implemented")
+}
+func (UnimplementedSendsControllerServer) mustEmbedUnimplementedSendsControllerServer() {}
+func (UnimplementedSendsControllerServer) testEmbeddedByValue()                         {}
+
+// UnsafeSendsControllerServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SendsControllerServer will
+// result in compilation errors.
+type UnsafeSendsControllerServer interface {
+	mustEmbedUnimplementedSendsControllerServer()
+}
+
+func RegisterSendsControllerServer(s grpc.ServiceRegistrar, srv SendsControllerServer) {
+	// If the following call pancis, it indicates UnimplementedSendsControllerServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&SendsController_ServiceDesc, srv)
+}
+
+func _SendsController_CreateSend_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSendRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SendsControllerServer).CreateSend(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SendsController_CreateSend_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SendsControllerServer).CreateSend(ctx, req.(*CreateSendRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// SendsController_ServiceDesc is the grpc.ServiceDesc for SendsController service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SendsController_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "items.SendsController",
+	HandlerType: (*SendsControllerServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateSend",
+			Handler:    _SendsController_CreateSend_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "internal/protos/items/items.proto",
@@ -0,0 +1,1732 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.3
+// source: internal/protos/orgs/orgs.proto
+
+package proto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type OrgRole int32
+
+const (
+	OrgRole_ORG_ROLE_UNSPECIFIED OrgRole = 0
+	OrgRole_ORG_ROLE_OWNER       OrgRole = 1
+	OrgRole_ORG_ROLE_ADMIN       OrgRole = 2
+	OrgRole_ORG_ROLE_EDITOR      OrgRole = 3
+	OrgRole_ORG_ROLE_VIEWER      OrgRole = 4
+)
+
+// Enum value maps for OrgRole.
+var (
+	OrgRole_name = map[int32]string{
+		0: "ORG_ROLE_UNSPECIFIED",
+		1: "ORG_ROLE_OWNER",
+		2: "ORG_ROLE_ADMIN",
+		3: "ORG_ROLE_EDITOR",
+		4: "ORG_ROLE_VIEWER",
+	}
+	OrgRole_value = map[string]int32{
+		"ORG_ROLE_UNSPECIFIED": 0,
+		"ORG_ROLE_OWNER":       1,
+		"ORG_ROLE_ADMIN":       2,
+		"ORG_ROLE_EDITOR":      3,
+		"ORG_ROLE_VIEWER":      4,
+	}
+)
+
+func (x OrgRole) Enum() *OrgRole {
+	p := new(OrgRole)
+	*p = x
+	return p
+}
+
+func (x OrgRole) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OrgRole) Descriptor() protoreflect.EnumDescriptor {
+	return file_internal_protos_orgs_orgs_proto_enumTypes[0].Descriptor()
+}
+
+func (OrgRole) Type() protoreflect.EnumType {
+	return &file_internal_protos_orgs_orgs_proto_enumTypes[0]
+}
+
+func (x OrgRole) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use OrgRole.Descriptor instead.
+func (OrgRole) EnumDescriptor() ([]byte, []int) {
+	return file_internal_protos_orgs_orgs_proto_rawDescGZIP(), []int{0}
+}
+
+type Organization struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             []byte                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name           string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	CreatedBy      string                 `protobuf:"bytes,3,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	Role           OrgRole                `protobuf:"varint,4,opt,name=role,proto3,enum=orgs.OrgRole" json:"role,omitempty"`
+	Accepted       bool                   `protobuf:"varint,5,opt,name=accepted,proto3" json:"accepted,omitempty"`
+	WrappedKey     string                 `protobuf:"bytes,6,opt,name=wrapped_key,json=wrappedKey,proto3" json:"wrapped_key,omitempty"`
+	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	KeyRotationDue bool                   `protobuf:"varint,8,opt,name=key_rotation_due,json=keyRotationDue,proto3" json:"key_rotation_due,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Organization) Reset() {
+	*x = Organization{}
+	mi := &file_internal_protos_orgs_orgs_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Organization) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Organization) ProtoMessage() {}
+
+func (x *Organization) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protos_orgs_orgs_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Organization.ProtoReflect.Descriptor instead.
+func (*Organization) Descriptor() ([]byte, []int) {
+	return file_internal_protos_orgs_orgs_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Organization) GetId() []byte {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *Organization) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Organization) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *Organization) GetRole() OrgRole {
+	if x != nil {
+		return x.Role
+	}
+	return OrgRole_ORG_ROLE_UNSPECIFIED
+}
+
+func (x *Organization) GetAccepted() bool {
+	if x != nil {
+		return x.Accepted
+	}
+	return false
+}
+
+func (x *Organization) GetWrappedKey() string {
+	if x != nil {
+		return x.WrappedKey
+	}
+	return ""
+}
+
+func (x *Organization) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Organization) GetKeyRotationDue() bool {
+	if x != nil {
+		return x.KeyRotationDue
+	}
+	return false
+}
+
+type Membership struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrgId         []byte                 `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	Login         string                 `protobuf:"bytes,2,opt,name=login,proto3" json:"login,omitempty"`
+	Role          OrgRole                `protobuf:"varint,3,opt,name=role,proto3,enum=orgs.OrgRole" json:"role,omitempty"`
+	Accepted      bool                   `protobuf:"varint,4,opt,name=accepted,proto3" json:"accepted,omitempty"`
+	WrappedKey    string                 `protobuf:"bytes,5,opt,name=wrapped_key,json=wrappedKey,proto3" json:"wrapped_key,omitempty"`
+	InvitedBy     string                 `protobuf:"bytes,6,opt,name=invited_by,json=invitedBy,proto3" json:"invited_by,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Membership) Reset() {
+	*x = Membership{}
+	mi := &file_internal_protos_orgs_orgs_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Membership) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Membership) ProtoMessage() {}
+
+func (x *Membership) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protos_orgs_orgs_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Membership.ProtoReflect.Descriptor instead.
+func (*Membership) Descriptor() ([]byte, []int) {
+	return file_internal_protos_orgs_orgs_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Membership) GetOrgId() []byte {
+	if x != nil {
+		return x.OrgId
+	}
+	return nil
+}
+
+func (x *Membership) GetLogin() string {
+	if x != nil {
+		return x.Login
+	}
+	return ""
+}
+
+func (x *Membership) GetRole() OrgRole {
+	if x != nil {
+		return x.Role
+	}
+	return OrgRole_ORG_ROLE_UNSPECIFIED
+}
+
+func (x *Membership) GetAccepted() bool {
+	if x != nil {
+		return x.Accepted
+	}
+	return false
+}
+
+func (x *Membership) GetWrappedKey() string {
+	if x != nil {
+		return x.WrappedKey
+	}
+	return ""
+}
+
+func (x *Membership) GetInvitedBy() string {
+	if x != nil {
+		return x.InvitedBy
+	}
+	return ""
+}
+
+func (x *Membership) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type Collection struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            []byte                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	OrgId         []byte                 `protobuf:"bytes,2,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	EncryptedKey  string                 `protobuf:"bytes,4,opt,name=encrypted_key,json=encryptedKey,proto3" json:"encrypted_key,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Collection) Reset() {
+	*x = Collection{}
+	mi := &file_internal_protos_orgs_orgs_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Collection) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Collection) ProtoMessage() {}
+
+func (x *Collection) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protos_orgs_orgs_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Collection.ProtoReflect.Descriptor instead.
+func (*Collection) Descriptor() ([]byte, []int) {
+	return file_internal_protos_orgs_orgs_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Collection) GetId() []byte {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *Collection) GetOrgId() []byte {
+	if x != nil {
+		return x.OrgId
+	}
+	return nil
+}
+
+func (x *Collection) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Collection) GetEncryptedKey() string {
+	if x != nil {
+		return x.EncryptedKey
+	}
+	return ""
+}
+
+func (x *Collection) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+// RekeyedItem is a collection item re-encrypted in the V2 format with a new
+// data key sealed with the new collection key.
+type RekeyedItem struct {
+	state                  protoimpl.MessageState `protogen:"open.v1"`
+	Id                     []byte                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	CollectionId           []byte                 `protobuf:"bytes,2,opt,name=collection_id,json=collectionId,proto3" json:"collection_id,omitempty"`
+	EncryptedKey           string                 `protobuf:"bytes,3,opt,name=encrypted_key,json=encryptedKey,proto3" json:"encrypted_key,omitempty"`
+	EncryptedDataContent   string                 `protobuf:"bytes,4,opt,name=encrypted_data_content,json=encryptedDataContent,proto3" json:"encrypted_data_content,omitempty"`
+	EncryptedDataNonce     string                 `protobuf:"bytes,5,opt,name=encrypted_data_nonce,json=encryptedDataNonce,proto3" json:"encrypted_data_nonce,omitempty"`
+	EncryptedHeaderContent string                 `protobuf:"bytes,6,opt,name=encrypted_header_content,json=encryptedHeaderContent,proto3" json:"encrypted_header_content,omitempty"`
+	EncryptedHeaderNonce   string                 `protobuf:"bytes,7,opt,name=encrypted_header_nonce,json=encryptedHeaderNonce,proto3" json:"encrypted_header_nonce,omitempty"`
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *RekeyedItem) Reset() {
+	*x = RekeyedItem{}
+	mi := &file_internal_protos_orgs_orgs_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RekeyedItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RekeyedItem) ProtoMessage() {}
+
+func (x *RekeyedItem) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protos_orgs_orgs_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RekeyedItem.ProtoReflect.Descriptor instead.
+func (*RekeyedItem) Descriptor() ([]byte, []int) {
+	return file_internal_protos_orgs_orgs_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *RekeyedItem) GetId() []byte {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *RekeyedItem) GetCollectionId() []byte {
+	if x != nil {
+		return x.CollectionId
+	}
+	return nil
+}
+
+func (x *RekeyedItem) GetEncryptedKey() string {
+	if x != nil {
+		return x.EncryptedKey
+	}
+	return ""
+}
+
+func (x *RekeyedItem) GetEncryptedDataContent() string {
+	if x != nil {
+		return x.EncryptedDataContent
+	}
+	return ""
+}
+
+func (x *RekeyedItem) GetEncryptedDataNonce() string {
+	if x != nil {
+		return x.EncryptedDataNonce
+	}
+	return ""
+}
+
+func (x *RekeyedItem) GetEncryptedHeaderContent() string {
+	if x != nil {
+		return x.EncryptedHeaderContent
+	}
+	return ""
+}
+
+func (x *RekeyedItem) GetEncryptedHeaderNonce() string {
+	if x != nil {
+		return x.EncryptedHeaderNonce
+	}
+	return ""
+}
+
+// RekeyedAttachment is the attachment info re-sealed with the new item data key.
+type RekeyedAttachment struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	Id                   []byte                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ItemId               []byte                 `protobuf:"bytes,2,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	EncryptedInfoContent string                 `protobuf:"bytes,3,opt,name=encrypted_info_content,json=encryptedInfoContent,proto3" json:"encrypted_info_content,omitempty"`
+	EncryptedInfoNonce   string                 `protobuf:"bytes,4,opt,name=encrypted_info_nonce,json=encryptedInfoNonce,proto3" json:"encrypted_info_nonce,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *RekeyedAttachment) Reset() {
+	*x = RekeyedAttachment{}
+	mi := &file_internal_protos_orgs_orgs_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RekeyedAttachment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RekeyedAttachment) ProtoMessage() {}
+
+func (x *RekeyedAttachment) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protos_orgs_orgs_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RekeyedAttachment.ProtoReflect.Descriptor instead.
+func (*RekeyedAttachment) Descriptor() ([]byte, []int) {
+	return file_internal_protos_orgs_orgs_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *RekeyedAttachment) GetId() []byte {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *RekeyedAttachment) GetItemId() []byte {
+	if x != nil {
+		return x.ItemId
+	}
+	return nil
+}
+
+func (x *RekeyedAttachment) GetEncryptedInfoContent() string {
+	if x != nil {
+		return x.EncryptedInfoContent
+	}
+	return ""
+}
+
+func (x *RekeyedAttachment) GetEncryptedInfoNonce() string {
+	if x != nil {
+		return x.EncryptedInfoNonce
+	}
+	return ""
+}
+
+type CreateOrgRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	WrappedKey    string                 `protobuf:"bytes,2,opt,name=wrapped_key,json=wrappedKey,proto3" json:"wrapped_key,omitempty"`
+	Collection    *Collection            `protobuf:"bytes,3,opt,name=collection,proto3" json:"collection,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateOrgRequest) Reset() {
+	*x = CreateOrgRequest{}
+	mi := &file_internal_protos_orgs_orgs_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateOrgRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateOrgRequest) ProtoMessage() {}
+
+func (x *CreateOrgRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protos_orgs_orgs_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateOrgRequest.ProtoReflect.Descriptor instead.
+func (*CreateOrgRequest) Descriptor() ([]byte, []int) {
+	return file_internal_protos_orgs_orgs_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CreateOrgRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateOrgRequest) GetWrappedKey() string {
+	if x != nil {
+		return x.WrappedKey
+	}
+	return ""
+}
+
+func (x *CreateOrgRequest) GetCollection() *Collection {
+	if x != nil {
+		return x.Collection
+	}
+	return nil
+}
+
+type CreateOrgResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Org           *Organization          `protobuf:"bytes,1,opt,name=org,proto3" json:"org,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateOrgResponse) Reset() {
+	*x = CreateOrgResponse{}
+	mi := &file_internal_protos_orgs_orgs_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateOrgResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateOrgResponse) ProtoMessage() {}
+
+func (x *CreateOrgResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protos_orgs_orgs_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateOrgResponse.ProtoReflect.Descriptor instead.
+func (*CreateOrgResponse) Descriptor() ([]byte, []int) {
+	return file_internal_protos_orgs_orgs_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CreateOrgResponse) GetOrg() *Organization {
+	if x != nil {
+		return x.Org
+	}
+	return nil
+}
+
+type ListOrgsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOrgsRequest) Reset() {
+	*x = ListOrgsRequest{}
+	mi := &file_internal_protos_orgs_orgs_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOrgsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOrgsRequest) ProtoMessage() {}
+
+func (x *ListOrgsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protos_orgs_orgs_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOrgsRequest.ProtoReflect.Descriptor instead.
+func (*ListOrgsRequest) Descriptor() ([]byte, []int) {
+	return file_internal_protos_orgs_orgs_proto_rawDescGZIP(), []int{7}
+}
+
+type ListOrgsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Orgs          []*Organization        `protobuf:"bytes,1,rep,name=orgs,proto3" json:"orgs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOrgsResponse) Reset() {
+	*x = ListOrgsResponse{}
+	mi := &file_internal_protos_orgs_orgs_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOrgsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOrgsResponse) ProtoMessage() {}
+
+func (x *ListOrgsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protos_orgs_orgs_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOrgsResponse.ProtoReflect.Descriptor instead.
+func (*ListOrgsResponse) Descriptor() ([]byte, []int) {
+	return file_internal_protos_orgs_orgs_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListOrgsResponse) GetOrgs() []*Organization {
+	if x != nil {
+		return x.Orgs
+	}
+	return nil
+}
+
+type InviteMemberRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Member        *Membership            `protobuf:"bytes,1,opt,name=member,proto3" json:"member,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InviteMemberRequest) Reset() {
+	*x = InviteMemberRequest{}
+	mi := &file_internal_protos_orgs_orgs_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InviteMemberRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InviteMemberRequest) ProtoMessage() {}
+
+func (x *InviteMemberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protos_orgs_orgs_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InviteMemberRequest.ProtoReflect.Descriptor instead.
+func (*InviteMemberRequest) Descriptor() ([]byte, []int) {
+	return file_internal_protos_orgs_orgs_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *InviteMemberRequest) GetMember() *Membership {
+	if x != nil {
+		return x.Member
+	}
+	return nil
+}
+
+type InviteMemberResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InviteMemberResponse) Reset() {
+	*x = InviteMemberResponse{}
+	mi := &file_internal_protos_orgs_orgs_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InviteMemberResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InviteMemberResponse) ProtoMessage() {}
+
+func (x *InviteMemberResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protos_orgs_orgs_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InviteMemberResponse.ProtoReflect.Descriptor instead.
+func (*InviteMemberResponse) Descriptor() ([]byte, []int) {
+	return file_internal_protos_orgs_orgs_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *InviteMemberResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type AcceptInviteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrgId         []byte                 `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AcceptInviteRequest) Reset() {
+	*x = AcceptInviteRequest{}
+	mi := &file_internal_protos_orgs_orgs_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AcceptInviteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AcceptInviteRequest) ProtoMessage() {}
+
+func (x *AcceptInviteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protos_orgs_orgs_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AcceptInviteRequest.ProtoReflect.Descriptor instead.
+func (*AcceptInviteRequest) Descriptor() ([]byte, []int) {
+	return file_internal_protos_orgs_orgs_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *AcceptInviteRequest) GetOrgId() []byte {
+	if x != nil {
+		return x.OrgId
+	}
+	return nil
+}
+
+type AcceptInviteResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AcceptInviteResponse) Reset() {
+	*x = AcceptInviteResponse{}
+	mi := &file_internal_protos_orgs_orgs_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AcceptInviteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AcceptInviteResponse) ProtoMessage() {}
+
+func (x *AcceptInviteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protos_orgs_orgs_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AcceptInviteResponse.ProtoReflect.Descriptor instead.
+func (*AcceptInviteResponse) Descriptor() ([]byte, []int) {
+	return file_internal_protos_orgs_orgs_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *AcceptInviteResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type RemoveMemberRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrgId         []byte                 `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	Login         string                 `protobuf:"bytes,2,opt,name=login,proto3" json:"login,omitempty"`
+	Members       []*Membership          `protobuf:"bytes,3,rep,name=members,proto3" json:"members,omitempty"`
+	Collections   []*Collection          `protobuf:"bytes,4,rep,name=collections,proto3" json:"collections,omitempty"`
+	Items         []*RekeyedItem         `protobuf:"bytes,5,rep,name=items,proto3" json:"items,omitempty"`
+	Attachments   []*RekeyedAttachment   `protobuf:"bytes,6,rep,name=attachments,proto3" json:"attachments,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveMemberRequest) Reset() {
+	*x = RemoveMemberRequest{}
+	mi := &file_internal_protos_orgs_orgs_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveMemberRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveMemberRequest) ProtoMessage() {}
+
+func (x *RemoveMemberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protos_orgs_orgs_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveMemberRequest.ProtoReflect.Descriptor instead.
+func (*RemoveMemberRequest) Descriptor() ([]byte, []int) {
+	return file_internal_protos_orgs_orgs_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *RemoveMemberRequest) GetOrgId() []byte {
+	if x != nil {
+		return x.OrgId
+	}
+	return nil
+}
+
+func (x *RemoveMemberRequest) GetLogin() string {
+	if x != nil {
+		return x.Login
+	}
+	return ""
+}
+
+func (x *RemoveMemberRequest) GetMembers() []*Membership {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+func (x *RemoveMemberRequest) GetCollections() []*Collection {
+	if x != nil {
+		return x.Collections
+	}
+	return nil
+}
+
+func (x *RemoveMemberRequest) GetItems() []*RekeyedItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *RemoveMemberRequest) GetAttachments() []*RekeyedAttachment {
+	if x != nil {
+		return x.Attachments
+	}
+	return nil
+}
+
+type RemoveMemberResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveMemberResponse) Reset() {
+	*x = RemoveMemberResponse{}
+	mi := &file_internal_protos_orgs_orgs_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveMemberResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveMemberResponse) ProtoMessage() {}
+
+func (x *RemoveMemberResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protos_orgs_orgs_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveMemberResponse.ProtoReflect.Descriptor instead.
+func (*RemoveMemberResponse) Descriptor() ([]byte, []int) {
+	return file_internal_protos_orgs_orgs_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *RemoveMemberResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type RotateOrgKeyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrgId         []byte                 `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	Members       []*Membership          `protobuf:"bytes,2,rep,name=members,proto3" json:"members,omitempty"`
+	Collections   []*Collection          `protobuf:"bytes,3,rep,name=collections,proto3" json:"collections,omitempty"`
+	Items         []*RekeyedItem         `protobuf:"bytes,4,rep,name=items,proto3" json:"items,omitempty"`
+	Attachments   []*RekeyedAttachment   `protobuf:"bytes,5,rep,name=attachments,proto3" json:"attachments,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RotateOrgKeyRequest) Reset() {
+	*x = RotateOrgKeyRequest{}
+	mi := &file_internal_protos_orgs_orgs_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RotateOrgKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RotateOrgKeyRequest) ProtoMessage() {}
+
+func (x *RotateOrgKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protos_orgs_orgs_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RotateOrgKeyRequest.ProtoReflect.Descriptor instead.
+func (*RotateOrgKeyRequest) Descriptor() ([]byte, []int) {
+	return file_internal_protos_orgs_orgs_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *RotateOrgKeyRequest) GetOrgId() []byte {
+	if x != nil {
+		return x.OrgId
+	}
+	return nil
+}
+
+func (x *RotateOrgKeyRequest) GetMembers() []*Membership {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+func (x *RotateOrgKeyRequest) GetCollections() []*Collection {
+	if x != nil {
+		return x.Collections
+	}
+	return nil
+}
+
+func (x *RotateOrgKeyRequest) GetItems() []*RekeyedItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *RotateOrgKeyRequest) GetAttachments() []*RekeyedAttachment {
+	if x != nil {
+		return x.Attachments
+	}
+	return nil
+}
+
+type RotateOrgKeyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RotateOrgKeyResponse) Reset() {
+	*x = RotateOrgKeyResponse{}
+	mi := &file_internal_protos_orgs_orgs_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RotateOrgKeyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RotateOrgKeyResponse) ProtoMessage() {}
+
+func (x *RotateOrgKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protos_orgs_orgs_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RotateOrgKeyResponse.ProtoReflect.Descriptor instead.
+func (*RotateOrgKeyResponse) Descriptor() ([]byte, []int) {
+	return file_internal_protos_orgs_orgs_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *RotateOrgKeyResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type ChangeRoleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrgId         []byte                 `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	Login         string                 `protobuf:"bytes,2,opt,name=login,proto3" json:"login,omitempty"`
+	Role          OrgRole                `protobuf:"varint,3,opt,name=role,proto3,enum=orgs.OrgRole" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChangeRoleRequest) Reset() {
+	*x = ChangeRoleRequest{}
+	mi := &file_internal_protos_orgs_orgs_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangeRoleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangeRoleRequest) ProtoMessage() {}
+
+func (x *ChangeRoleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protos_orgs_orgs_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangeRoleRequest.ProtoReflect.Descriptor instead.
+func (*ChangeRoleRequest) Descriptor() ([]byte, []int) {
+	return file_internal_protos_orgs_orgs_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ChangeRoleRequest) GetOrgId() []byte {
+	if x != nil {
+		return x.OrgId
+	}
+	return nil
+}
+
+func (x *ChangeRoleRequest) GetLogin() string {
+	if x != nil {
+		return x.Login
+	}
+	return ""
+}
+
+func (x *ChangeRoleRequest) GetRole() OrgRole {
+	if x != nil {
+		return x.Role
+	}
+	return OrgRole_ORG_ROLE_UNSPECIFIED
+}
+
+type ChangeRoleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChangeRoleResponse) Reset() {
+	*x = ChangeRoleResponse{}
+	mi := &file_internal_protos_orgs_orgs_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangeRoleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangeRoleResponse) ProtoMessage() {}
+
+func (x *ChangeRoleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protos_orgs_orgs_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangeRoleResponse.ProtoReflect.Descriptor instead.
+func (*ChangeRoleResponse) Descriptor() ([]byte, []int) {
+	return file_internal_protos_orgs_orgs_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ChangeRoleResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type ListMembersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrgId         []byte                 `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMembersRequest) Reset() {
+	*x = ListMembersRequest{}
+	mi := &file_internal_protos_orgs_orgs_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMembersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMembersRequest) ProtoMessage() {}
+
+func (x *ListMembersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protos_orgs_orgs_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMembersRequest.ProtoReflect.Descriptor instead.
+func (*ListMembersRequest) Descriptor() ([]byte, []int) {
+	return file_internal_protos_orgs_orgs_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ListMembersRequest) GetOrgId() []byte {
+	if x != nil {
+		return x.OrgId
+	}
+	return nil
+}
+
+type ListMembersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Members       []*Membership          `protobuf:"bytes,1,rep,name=members,proto3" json:"members,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMembersResponse) Reset() {
+	*x = ListMembersResponse{}
+	mi := &file_internal_protos_orgs_orgs_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMembersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMembersResponse) ProtoMessage() {}
+
+func (x *ListMembersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protos_orgs_orgs_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMembersResponse.ProtoReflect.Descriptor instead.
+func (*ListMembersResponse) Descriptor() ([]byte, []int) {
+	return file_internal_protos_orgs_orgs_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ListMembersResponse) GetMembers() []*Membership {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+type CreateCollectionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Collection    *Collection            `protobuf:"bytes,1,opt,name=collection,proto3" json:"collection,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateCollectionRequest) Reset() {
+	*x = CreateCollectionRequest{}
+	mi := &file_internal_protos_orgs_orgs_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateCollectionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCollectionRequest) ProtoMessage() {}
+
+func (x *CreateCollectionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protos_orgs_orgs_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCollectionRequest.ProtoReflect.Descriptor instead.
+func (*CreateCollectionRequest) Descriptor() ([]byte, []int) {
+	return file_internal_protos_orgs_orgs_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *CreateCollectionRequest) GetCollection() *Collection {
+	if x != nil {
+		return x.Collection
+	}
+	return nil
+}
+
+type CreateCollectionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Collection    *Collection            `protobuf:"bytes,1,opt,name=collection,proto3" json:"collection,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateCollectionResponse) Reset() {
+	*x = CreateCollectionResponse{}
+	mi := &file_internal_protos_orgs_orgs_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateCollectionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCollectionResponse) ProtoMessage() {}
+
+func (x *CreateCollectionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protos_orgs_orgs_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCollectionResponse.ProtoReflect.Descriptor instead.
+func (*CreateCollectionResponse) Descriptor() ([]byte, []int) {
+	return file_internal_protos_orgs_orgs_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *CreateCollectionResponse) GetCollection() *Collection {
+	if x != nil {
+		return x.Collection
+	}
+	return nil
+}
+
+type ListCollectionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrgId         []byte                 `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCollectionsRequest) Reset() {
+	*x = ListCollectionsRequest{}
+	mi := &file_internal_protos_orgs_orgs_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCollectionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCollectionsRequest) ProtoMessage() {}
+
+func (x *ListCollectionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protos_orgs_orgs_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCollectionsRequest.ProtoReflect.Descriptor instead.
+func (*ListCollectionsRequest) Descriptor() ([]byte, []int) {
+	return file_internal_protos_orgs_orgs_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ListCollectionsRequest) GetOrgId() []byte {
+	if x != nil {
+		return x.OrgId
+	}
+	return nil
+}
+
+type ListCollectionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Collections   []*Collection          `protobuf:"bytes,1,rep,name=collections,proto3" json:"collections,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCollectionsResponse) Reset() {
+	*x = ListCollectionsResponse{}
+	mi := &file_internal_protos_orgs_orgs_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCollectionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCollectionsResponse) ProtoMessage() {}
+
+func (x *ListCollectionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protos_orgs_orgs_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCollectionsResponse.ProtoReflect.Descriptor instead.
+func (*ListCollectionsResponse) Descriptor() ([]byte, []int) {
+	return file_internal_protos_orgs_orgs_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ListCollectionsResponse) GetCollections() []*Collection {
+	if x != nil {
+		return x.Collections
+	}
+	return nil
+}
+
+var File_internal_protos_orgs_orgs_proto protoreflect.FileDescriptor
+
+const file_internal_protos_orgs_orgs_proto_rawDesc = "" +
+	"\n" +
+	"\x1finternal/protos/orgs/orgs.proto\x12\x04orgs\x1a\x1fgoogle/protobuf/timestamp.proto\"\x96\x02\n" +
+	"\fOrganization\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\fR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\x03 \x01(\tR\tcreatedBy\x12!\n" +
+	"\x04role\x18\x04 \x01(\x0e2\r.orgs.OrgRoleR\x04role\x12\x1a\n" +
+	"\baccepted\x18\x05 \x01(\bR\baccepted\x12\x1f\n" +
+	"\vwrapped_key\x18\x06 \x01(\tR\n" +
+	"wrappedKey\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12(\n" +
+	"\x10key_rotation_due\x18\b \x01(\bR\x0ekeyRotationDue\"\xf3\x01\n" +
+	"\n" +
+	"Membership\x12\x15\n" +
+	"\x06org_id\x18\x01 \x01(\fR\x05orgId\x12\x14\n" +
+	"\x05login\x18\x02 \x01(\tR\x05login\x12!\n" +
+	"\x04role\x18\x03 \x01(\x0e2\r.orgs.OrgRoleR\x04role\x12\x1a\n" +
+	"\baccepted\x18\x04 \x01(\bR\baccepted\x12\x1f\n" +
+	"\vwrapped_key\x18\x05 \x01(\tR\n" +
+	"wrappedKey\x12\x1d\n" +
+	"\n" +
+	"invited_by\x18\x06 \x01(\tR\tinvitedBy\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xa7\x01\n" +
+	"\n" +
+	"Collection\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\fR\x02id\x12\x15\n" +
+	"\x06org_id\x18\x02 \x01(\fR\x05orgId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12#\n" +
+	"\rencrypted_key\x18\x04 \x01(\tR\fencryptedKey\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xbf\x02\n" +
+	"\vRekeyedItem\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\fR\x02id\x12#\n" +
+	"\rcollection_id\x18\x02 \x01(\fR\fcollectionId\x12#\n" +
+	"\rencrypted_key\x18\x03 \x01(\tR\fencryptedKey\x124\n" +
+	"\x16encrypted_data_content\x18\x04 \x01(\tR\x14encryptedDataContent\x120\n" +
+	"\x14encrypted_data_nonce\x18\x05 \x01(\tR\x12encryptedDataNonce\x128\n" +
+	"\x18encrypted_header_content\x18\x06 \x01(\tR\x16encryptedHeaderContent\x124\n" +
+	"\x16encrypted_header_nonce\x18\a \x01(\tR\x14encryptedHeaderNonce\"\xa4\x01\n" +
+	"\x11RekeyedAttachment\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\fR\x02id\x12\x17\n" +
+	"\aitem_id\x18\x02 \x01(\fR\x06itemId\x124\n" +
+	"\x16encrypted_info_content\x18\x03 \x01(\tR\x14encryptedInfoContent\x120\n" +
+	"\x14encrypted_info_nonce\x18\x04 \x01(\tR\x12encryptedInfoNonce\"y\n" +
+	"\x10CreateOrgRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1f\n" +
+	"\vwrapped_key\x18\x02 \x01(\tR\n" +
+	"wrappedKey\x120\n" +
+	"\n" +
+	"collection\x18\x03 \x01(\v2\x10.orgs.CollectionR\n" +
+	"collection\"9\n" +
+	"\x11CreateOrgResponse\x12$\n" +
+	"\x03org\x18\x01 \x01(\v2\x12.orgs.OrganizationR\x03org\"\x11\n" +
+	"\x0fListOrgsRequest\":\n" +
+	"\x10ListOrgsResponse\x12&\n" +
+	"\x04orgs\x18\x01 \x03(\v2\x12.orgs.OrganizationR\x04orgs\"?\n" +
+	"\x13InviteMemberRequest\x12(\n" +
+	"\x06member\x18\x01 \x01(\v2\x10.orgs.MembershipR\x06member\"0\n" +
+	"\x14InviteMemberResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\",\n" +
+	"\x13AcceptInviteRequest\x12\x15\n" +
+	"\x06org_id\x18\x01 \x01(\fR\x05orgId\"0\n" +
+	"\x14AcceptInviteResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\x86\x02\n" +
+	"\x13RemoveMemberRequest\x12\x15\n" +
+	"\x06org_id\x18\x01 \x01(\fR\x05orgId\x12\x14\n" +
+	"\x05login\x18\x02 \x01(\tR\x05login\x12*\n" +
+	"\amembers\x18\x03 \x03(\v2\x10.orgs.MembershipR\amembers\x122\n" +
+	"\vcollections\x18\x04 \x03(\v2\x10.orgs.CollectionR\vcollections\x12'\n" +
+	"\x05items\x18\x05 \x03(\v2\x11.orgs.RekeyedItemR\x05items\x129\n" +
+	"\vattachments\x18\x06 \x03(\v2\x17.orgs.RekeyedAttachmentR\vattachments\"0\n" +
+	"\x14RemoveMemberResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\xf0\x01\n" +
+	"\x13RotateOrgKeyRequest\x12\x15\n" +
+	"\x06org_id\x18\x01 \x01(\fR\x05orgId\x12*\n" +
+	"\amembers\x18\x02 \x03(\v2\x10.orgs.MembershipR\amembers\x122\n" +
+	"\vcollections\x18\x03 \x03(\v2\x10.orgs.CollectionR\vcollections\x12'\n" +
+	"\x05items\x18\x04 \x03(\v2\x11.orgs.RekeyedItemR\x05items\x129\n" +
+	"\vattachments\x18\x05 \x03(\v2\x17.orgs.RekeyedAttachmentR\vattachments\"0\n" +
+	"\x14RotateOrgKeyResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"c\n" +
+	"\x11ChangeRoleRequest\x12\x15\n" +
+	"\x06org_id\x18\x01 \x01(\fR\x05orgId\x12\x14\n" +
+	"\x05login\x18\x02 \x01(\tR\x05login\x12!\n" +
+	"\x04role\x18\x03 \x01(\x0e2\r.orgs.OrgRoleR\x04role\".\n" +
+	"\x12ChangeRoleResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"+\n" +
+	"\x12ListMembersRequest\x12\x15\n" +
+	"\x06org_id\x18\x01 \x01(\fR\x05orgId\"A\n" +
+	"\x13ListMembersResponse\x12*\n" +
+	"\amembers\x18\x01 \x03(\v2\x10.orgs.MembershipR\amembers\"K\n" +
+	"\x17CreateCollectionRequest\x120\n" +
+	"\n" +
+	"collection\x18\x01 \x01(\v2\x10.orgs.CollectionR\n" +
+	"collection\"L\n" +
+	"\x18CreateCollectionResponse\x120\n" +
+	"\n" +
+	"collection\x18\x01 \x01(\v2\x10.orgs.CollectionR\n" +
+	"collection\"/\n" +
+	"\x16ListCollectionsRequest\x12\x15\n" +
+	"\x06org_id\x18\x01 \x01(\fR\x05orgId\"M\n" +
+	"\x17ListCollectionsResponse\x122\n" +
+	"\vcollections\x18\x01 \x03(\v2\x10.orgs.CollectionR\vcollections*u\n" +
+	"\aOrgRole\x12\x18\n" +
+	"\x14ORG_ROLE_UNSPECIFIED\x10\x00\x12\x12\n" +
+	"\x0eORG_ROLE_OWNER\x10\x01\x12\x12\n" +
+	"\x0eORG_ROLE_ADMIN\x10\x02\x12\x13\n" +
+	"\x0fORG_ROLE_EDITOR\x10\x03\x12\x13\n" +
+	"\x0fORG_ROLE_VIEWER\x10\x042\xcc\x05\n" +
+	"\rOrgController\x12<\n" +
+	"\tCreateOrg\x12\x16.orgs.CreateOrgRequest\x1a\x17.orgs.CreateOrgResponse\x129\n" +
+	"\bListOrgs\x12\x15.orgs.ListOrgsRequest\x1a\x16.orgs.ListOrgsResponse\x12E\n" +
+	"\fInviteMember\x12\x19.orgs.InviteMemberRequest\x1a\x1a.orgs.InviteMemberResponse\x12E\n" +
+	"\fAcceptInvite\x12\x19.orgs.AcceptInviteRequest\x1a\x1a.orgs.AcceptInviteResponse\x12E\n" +
+	"\fRemoveMember\x12\x19.orgs.RemoveMemberRequest\x1a\x1a.orgs.RemoveMemberResponse\x12E\n" +
+	"\fRotateOrgKey\x12\x19.orgs.RotateOrgKeyRequest\x1a\x1a.orgs.RotateOrgKeyResponse\x12?\n" +
+	"\n" +
+	"ChangeRole\x12\x17.orgs.ChangeRoleRequest\x1a\x18.orgs.ChangeRoleResponse\x12B\n" +
+	"\vListMembers\x12\x18.orgs.ListMembersRequest\x1a\x19.orgs.ListMembersResponse\x12Q\n" +
+	"\x10CreateCollection\x12\x1d.orgs.CreateCollectionRequest\x1a\x1e.orgs.CreateCollectionResponse\x12N\n" +
+	"\x0fListCollections\x12\x1c.orgs.ListCollectionsRequest\x1a\x1d.orgs.ListCollectionsResponseB\fZ\n" +
+	"grpc/protob\x06proto3"
+
+var (
+	file_internal_protos_orgs_orgs_proto_rawDescOnce sync.Once
+	file_internal_protos_orgs_orgs_proto_rawDescData []byte
+)
+
+func file_internal_protos_orgs_orgs_proto_rawDescGZIP() []byte {
+	file_internal_protos_orgs_orgs_proto_rawDescOnce.Do(func() {
+		file_internal_protos_orgs_orgs_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_internal_protos_orgs_orgs_proto_rawDesc), len(file_internal_protos_orgs_orgs_proto_rawDesc)))
+	})
+	return file_internal_protos_orgs_orgs_proto_rawDescData
+}
+
+var file_internal_protos_orgs_orgs_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_internal_protos_orgs_orgs_proto_msgTypes = make([]protoimpl.MessageInfo, 25)
+var file_internal_protos_orgs_orgs_proto_goTypes = []any{
+	(OrgRole)(0),                     // 0: orgs.OrgRole
+	(*Organization)(nil),             // 1: orgs.Organization
+	(*Membership)(nil),               // 2: orgs.Membership
+	(*Collection)(nil),               // 3: orgs.Collection
+	(*RekeyedItem)(nil),              // 4: orgs.RekeyedItem
+	(*RekeyedAttachment)(nil),        // 5: orgs.RekeyedAttachment
+	(*CreateOrgRequest)(nil),         // 6: orgs.CreateOrgRequest
+	(*CreateOrgResponse)(nil),        // 7: orgs.CreateOrgResponse
+	(*ListOrgsRequest)(nil),          // 8: orgs.ListOrgsRequest
+	(*ListOrgsResponse)(nil),         // 9: orgs.ListOrgsResponse
+	(*InviteMemberRequest)(nil),      // 10: orgs.InviteMemberRequest
+	(*InviteMemberResponse)(nil),     // 11: orgs.InviteMemberResponse
+	(*AcceptInviteRequest)(nil),      // 12: orgs.AcceptInviteRequest
+	(*AcceptInviteResponse)(nil),     // 13: orgs.AcceptInviteResponse
+	(*RemoveMemberRequest)(nil),      // 14: orgs.RemoveMemberRequest
+	(*RemoveMemberResponse)(nil),     // 15: orgs.RemoveMemberResponse
+	(*RotateOrgKeyRequest)(nil),      // 16: orgs.RotateOrgKeyRequest
+	(*RotateOrgKeyResponse)(nil),     // 17: orgs.RotateOrgKeyResponse
+	(*ChangeRoleRequest)(nil),        // 18: orgs.ChangeRoleRequest
+	(*ChangeRoleResponse)(nil),       // 19: orgs.ChangeRoleResponse
+	(*ListMembersRequest)(nil),       // 20: orgs.ListMembersRequest
+	(*ListMembersResponse)(nil),      // 21: orgs.ListMembersResponse
+	(*CreateCollectionRequest)(nil),  // 22: orgs.CreateCollectionRequest
+	(*CreateCollectionResponse)(nil), // 23: orgs.CreateCollectionResponse
+	(*ListCollectionsRequest)(nil),   // 24: orgs.ListCollectionsRequest
+	(*ListCollectionsResponse)(nil),  // 25: orgs.ListCollectionsResponse
+	(*timestamppb.Timestamp)(nil),    // 26: google.protobuf.Timestamp
+}
+var file_internal_protos_orgs_orgs_proto_depIdxs = []int32{
+	0,  // 0: orgs.Organization.role:type_name -> orgs.OrgRole
+	26, // 1: orgs.Organization.created_at:type_name -> google.protobuf.Timestamp
+	0,  // 2: orgs.Membership.role:type_name -> orgs.OrgRole
+	26, // 3: orgs.Membership.created_at:type_name -> google.protobuf.Timestamp
+	26, // 4: orgs.Collection.created_at:type_name -> google.protobuf.Timestamp
+	3,  // 5: orgs.CreateOrgRequest.collection:type_name -> orgs.Collection
+	1,  // 6: orgs.CreateOrgResponse.org:type_name -> orgs.Organization
+	1,  // 7: orgs.ListOrgsResponse.orgs:type_name -> orgs.Organization
+	2,  // 8: orgs.InviteMemberRequest.member:type_name -> orgs.Membership
+	2,  // 9: orgs.RemoveMemberRequest.members:type_name -> orgs.Membership
+	3,  // 10: orgs.RemoveMemberRequest.collections:type_name -> orgs.Collection
+	4,  // 11: orgs.RemoveMemberRequest.items:type_name -> orgs.RekeyedItem
+	5,  // 12: orgs.RemoveMemberRequest.attachments:type_name -> orgs.RekeyedAttachment
+	2,  // 13: orgs.RotateOrgKeyRequest.members:type_name -> orgs.Membership
+	3,  // 14: orgs.RotateOrgKeyRequest.collections:type_name -> orgs.Collection
+	4,  // 15: orgs.RotateOrgKeyRequest.items:type_name -> orgs.RekeyedItem
+	5,  // 16: orgs.RotateOrgKeyRequest.attachments:type_name -> orgs.RekeyedAttachment
+	0,  // 17: orgs.ChangeRoleRequest.role:type_name -> orgs.OrgRole
+	2,  // 18: orgs.ListMembersResponse.members:type_name -> orgs.Membership
+	3,  // 19: orgs.CreateCollectionRequest.collection:type_name -> orgs.Collection
+	3,  // 20: orgs.CreateCollectionResponse.collection:type_name -> orgs.Collection
+	3,  // 21: orgs.ListCollectionsResponse.collections:type_name -> orgs.Collection
+	6,  // 22: orgs.OrgController.CreateOrg:input_type -> orgs.CreateOrgRequest
+	8,  // 23: orgs.OrgController.ListOrgs:input_type -> orgs.ListOrgsRequest
+	10, // 24: orgs.OrgController.InviteMember:input_type -> orgs.InviteMemberRequest
+	12, // 25: orgs.OrgController.AcceptInvite:input_type -> orgs.AcceptInviteRequest
+	14, // 26: orgs.OrgController.RemoveMember:input_type -> orgs.RemoveMemberRequest
+	16, // 27: orgs.OrgController.RotateOrgKey:input_type -> orgs.RotateOrgKeyRequest
+	18, // 28: orgs.OrgController.ChangeRole:input_type -> orgs.ChangeRoleRequest
+	20, // 29: orgs.OrgController.ListMembers:input_type -> orgs.ListMembersRequest
+	22, // 30: orgs.OrgController.CreateCollection:input_type -> orgs.CreateCollectionRequest
+	24, // 31: orgs.OrgController.ListCollections:input_type -> orgs.ListCollectionsRequest
+	7,  // 32: orgs.OrgController.CreateOrg:output_type -> orgs.CreateOrgResponse
+	9,  // 33: orgs.OrgController.ListOrgs:output_type -> orgs.ListOrgsResponse
+	11, // 34: orgs.OrgController.InviteMember:output_type -> orgs.InviteMemberResponse
+	13, // 35: orgs.OrgController.AcceptInvite:output_type -> orgs.AcceptInviteResponse
+	15, // 36: orgs.OrgController.RemoveMember:output_type -> orgs.RemoveMemberResponse
+	17, // 37: orgs.OrgController.RotateOrgKey:output_type -> orgs.RotateOrgKeyResponse
+	19, // 38: orgs.OrgController.ChangeRole:output_type -> orgs.ChangeRoleResponse
+	21, // 39: orgs.OrgController.ListMembers:output_type -> orgs.ListMembersResponse
+	23, // 40: orgs.OrgController.CreateCollection:output_type -> orgs.CreateCollectionResponse
+	25, // 41: orgs.OrgController.ListCollections:output_type -> orgs.ListCollectionsResponse
+	32, // [32:42] is the sub-list for method output_type
+	22, // [22:32] is the sub-list for method input_type
+	22, // [22:22] is the sub-list for extension type_name
+	22, // [22:22] is the sub-list for extension extendee
+	0,  // [0:22] is the sub-list for field type_name
+}
+
+func init() { file_internal_protos_orgs_orgs_proto_init() }
+func file_internal_protos_orgs_orgs_proto_init() {
+	if File_internal_protos_orgs_orgs_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_internal_protos_orgs_orgs_proto_rawDesc), len(file_internal_protos_orgs_orgs_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   25,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_internal_protos_orgs_orgs_proto_goTypes,
+		DependencyIndexes: file_internal_protos_orgs_orgs_proto_depIdxs,
+		EnumInfos:         file_internal_protos_orgs_orgs_proto_enumTypes,
+		MessageInfos:      file_internal_protos_orgs_orgs_proto_msgTypes,
+	}.Build()
+	File_internal_protos_orgs_orgs_proto = out.File
+	file_internal_protos_orgs_orgs_proto_goTypes = nil
+	file_internal_protos_orgs_orgs_proto_depIdxs = nil
+}
@@ -9,6 +9,7 @@ package proto
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -76,6 +77,7 @@ func (x *User) GetPassword() string {
 type SignUpUserRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	User          *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	Device        *DeviceSignIn          `protobuf:"bytes,2,opt,name=device,proto3" json:"device,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -117,6 +119,13 @@ func (x *SignUpUserRequest) GetUser() *User {
 	return nil
 }
 
+func (x *SignUpUserRequest) GetDevice() *DeviceSignIn {
+	if x != nil {
+		return x.Device
+	}
+	return nil
+}
+
 type SignUpUserResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
@@ -180,6 +189,7 @@ func (x *SignUpUserResponse) GetError() string {
 type SignInUserRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	User          *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	Device        *DeviceSignIn          `protobuf:"bytes,2,opt,name=device,proto3" json:"device,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -221,6 +231,13 @@ func (x *SignInUserRequest) GetUser() *User {
 	return nil
 }
 
+func (x *SignInUserRequest) GetDevice() *DeviceSignIn {
+	if x != nil {
+		return x.Device
+	}
+	return nil
+}
+
 type SignInUserResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
@@ -281,31 +298,592 @@ func (x *SignInUserResponse) GetError() string {
 	return ""
 }
 
+type Device struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            []byte                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Os            string                 `protobuf:"bytes,3,opt,name=os,proto3" json:"os,omitempty"`
+	PublicKey     []byte                 `protobuf:"bytes,4,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	Approved      bool                   `protobuf:"varint,5,opt,name=approved,proto3" json:"approved,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	LastSeenAt    *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=last_seen_at,json=lastSeenAt,proto3" json:"last_seen_at,omitempty"`
+	LastSeenIp    string                 `protobuf:"bytes,8,opt,name=last_seen_ip,json=lastSeenIp,proto3" json:"last_seen_ip,omitempty"`
+	Current       bool                   `protobuf:"varint,9,opt,name=current,proto3" json:"current,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Device) Reset() {
+	*x = Device{}
+	mi := &file_internal_protos_users_users_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Device) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Device) ProtoMessage() {}
+
+func (x *Device) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protos_users_users_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Device.ProtoReflect.Descriptor instead.
+func (*Device) Descriptor() ([]byte, []int) {
+	return file_internal_protos_users_users_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Device) GetId() []byte {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *Device) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Device) GetOs() string {
+	if x != nil {
+		return x.Os
+	}
+	return ""
+}
+
+func (x *Device) GetPublicKey() []byte {
+	if x != nil {
+		return x.PublicKey
+	}
+	return nil
+}
+
+func (x *Device) GetApproved() bool {
+	if x != nil {
+		return x.Approved
+	}
+	return false
+}
+
+func (x *Device) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Device) GetLastSeenAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastSeenAt
+	}
+	return nil
+}
+
+func (x *Device) GetLastSeenIp() string {
+	if x != nil {
+		return x.LastSeenIp
+	}
+	return ""
+}
+
+func (x *Device) GetCurrent() bool {
+	if x != nil {
+		return x.Current
+	}
+	return false
+}
+
+type DeviceSignIn struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Device        *Device                `protobuf:"bytes,1,opt,name=device,proto3" json:"device,omitempty"`
+	Signature     []byte                 `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	SignedAt      *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=signed_at,json=signedAt,proto3" json:"signed_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeviceSignIn) Reset() {
+	*x = DeviceSignIn{}
+	mi := &file_internal_protos_users_users_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeviceSignIn) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeviceSignIn) ProtoMessage() {}
+
+func (x *DeviceSignIn) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protos_users_users_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeviceSignIn.ProtoReflect.Descriptor instead.
+func (*DeviceSignIn) Descriptor() ([]byte, []int) {
+	return file_internal_protos_users_users_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *DeviceSignIn) GetDevice() *Device {
+	if x != nil {
+		return x.Device
+	}
+	return nil
+}
+
+func (x *DeviceSignIn) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+func (x *DeviceSignIn) GetSignedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SignedAt
+	}
+	return nil
+}
+
+type ListDevicesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDevicesRequest) Reset() {
+	*x = ListDevicesRequest{}
+	mi := &file_internal_protos_users_users_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDevicesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDevicesRequest) ProtoMessage() {}
+
+func (x *ListDevicesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protos_users_users_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDevicesRequest.ProtoReflect.Descriptor instead.
+func (*ListDevicesRequest) Descriptor() ([]byte, []int) {
+	return file_internal_protos_users_users_proto_rawDescGZIP(), []int{7}
+}
+
+type ListDevicesResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Devices          []*Device              `protobuf:"bytes,1,rep,name=devices,proto3" json:"devices,omitempty"`
+	ApprovalRequired bool                   `protobuf:"varint,2,opt,name=approval_required,json=approvalRequired,proto3" json:"approval_required,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ListDevicesResponse) Reset() {
+	*x = ListDevicesResponse{}
+	mi := &file_internal_protos_users_users_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDevicesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDevicesResponse) ProtoMessage() {}
+
+func (x *ListDevicesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protos_users_users_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDevicesResponse.ProtoReflect.Descriptor instead.
+func (*ListDevicesResponse) Descriptor() ([]byte, []int) {
+	return file_internal_protos_users_users_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListDevicesResponse) GetDevices() []*Device {
+	if x != nil {
+		return x.Devices
+	}
+	return nil
+}
+
+func (x *ListDevicesResponse) GetApprovalRequired() bool {
+	if x != nil {
+		return x.ApprovalRequired
+	}
+	return false
+}
+
+type RemoveDeviceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeviceId      []byte                 `protobuf:"bytes,1,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveDeviceRequest) Reset() {
+	*x = RemoveDeviceRequest{}
+	mi := &file_internal_protos_users_users_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveDeviceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveDeviceRequest) ProtoMessage() {}
+
+func (x *RemoveDeviceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protos_users_users_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveDeviceRequest.ProtoReflect.Descriptor instead.
+func (*RemoveDeviceRequest) Descriptor() ([]byte, []int) {
+	return file_internal_protos_users_users_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *RemoveDeviceRequest) GetDeviceId() []byte {
+	if x != nil {
+		return x.DeviceId
+	}
+	return nil
+}
+
+type RemoveDeviceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveDeviceResponse) Reset() {
+	*x = RemoveDeviceResponse{}
+	mi := &file_internal_protos_users_users_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveDeviceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveDeviceResponse) ProtoMessage() {}
+
+func (x *RemoveDeviceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protos_users_users_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveDeviceResponse.ProtoReflect.Descriptor instead.
+func (*RemoveDeviceResponse) Descriptor() ([]byte, []int) {
+	return file_internal_protos_users_users_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *RemoveDeviceResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type ApproveDeviceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeviceId      []byte                 `protobuf:"bytes,1,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApproveDeviceRequest) Reset() {
+	*x = ApproveDeviceRequest{}
+	mi := &file_internal_protos_users_users_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApproveDeviceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApproveDeviceRequest) ProtoMessage() {}
+
+func (x *ApproveDeviceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protos_users_users_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApproveDeviceRequest.ProtoReflect.Descriptor instead.
+func (*ApproveDeviceRequest) Descriptor() ([]byte, []int) {
+	return file_internal_protos_users_users_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ApproveDeviceRequest) GetDeviceId() []byte {
+	if x != nil {
+		return x.DeviceId
+	}
+	return nil
+}
+
+type ApproveDeviceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApproveDeviceResponse) Reset() {
+	*x = ApproveDeviceResponse{}
+	mi := &file_internal_protos_users_users_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApproveDeviceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApproveDeviceResponse) ProtoMessage() {}
+
+func (x *ApproveDeviceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protos_users_users_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApproveDeviceResponse.ProtoReflect.Descriptor instead.
+func (*ApproveDeviceResponse) Descriptor() ([]byte, []int) {
+	return file_internal_protos_users_users_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ApproveDeviceResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type SetDeviceApprovalRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Required      bool                   `protobuf:"varint,1,opt,name=required,proto3" json:"required,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetDeviceApprovalRequest) Reset() {
+	*x = SetDeviceApprovalRequest{}
+	mi := &file_internal_protos_users_users_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetDeviceApprovalRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetDeviceApprovalRequest) ProtoMessage() {}
+
+func (x *SetDeviceApprovalRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protos_users_users_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetDeviceApprovalRequest.ProtoReflect.Descriptor instead.
+func (*SetDeviceApprovalRequest) Descriptor() ([]byte, []int) {
+	return file_internal_protos_users_users_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *SetDeviceApprovalRequest) GetRequired() bool {
+	if x != nil {
+		return x.Required
+	}
+	return false
+}
+
+type SetDeviceApprovalResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetDeviceApprovalResponse) Reset() {
+	*x = SetDeviceApprovalResponse{}
+	mi := &file_internal_protos_users_users_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetDeviceApprovalResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetDeviceApprovalResponse) ProtoMessage() {}
+
+func (x *SetDeviceApprovalResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_protos_users_users_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetDeviceApprovalResponse.ProtoReflect.Descriptor instead.
+func (*SetDeviceApprovalResponse) Descriptor() ([]byte, []int) {
+	return file_internal_protos_users_users_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *SetDeviceApprovalResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
 var File_internal_protos_users_users_proto protoreflect.FileDescriptor
 
 const file_internal_protos_users_users_proto_rawDesc = "" +
 	"\n" +
-	"!internal/protos/users/users.proto\x12\x05users\"8\n" +
+	"!internal/protos/users/users.proto\x12\x05users\x1a\x1fgoogle/protobuf/timestamp.proto\"8\n" +
 	"\x04User\x12\x14\n" +
 	"\x05login\x18\x01 \x01(\tR\x05login\x12\x1a\n" +
-	"\bpassword\x18\x02 \x01(\tR\bpassword\"4\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\"a\n" +
 	"\x11SignUpUserRequest\x12\x1f\n" +
-	"\x04user\x18\x01 \x01(\v2\v.users.UserR\x04user\"T\n" +
+	"\x04user\x18\x01 \x01(\v2\v.users.UserR\x04user\x12+\n" +
+	"\x06device\x18\x02 \x01(\v2\x13.users.DeviceSignInR\x06device\"T\n" +
 	"\x12SignUpUserResponse\x12\x14\n" +
 	"\x05token\x18\x01 \x01(\tR\x05token\x12\x12\n" +
 	"\x04salt\x18\x02 \x01(\tR\x04salt\x12\x14\n" +
-	"\x05error\x18\x03 \x01(\tR\x05error\"4\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\"a\n" +
 	"\x11SignInUserRequest\x12\x1f\n" +
-	"\x04user\x18\x01 \x01(\v2\v.users.UserR\x04user\"T\n" +
+	"\x04user\x18\x01 \x01(\v2\v.users.UserR\x04user\x12+\n" +
+	"\x06device\x18\x02 \x01(\v2\x13.users.DeviceSignInR\x06device\"T\n" +
 	"\x12SignInUserResponse\x12\x14\n" +
 	"\x05token\x18\x01 \x01(\tR\x05token\x12\x12\n" +
 	"\x04salt\x18\x02 \x01(\tR\x04salt\x12\x14\n" +
-	"\x05error\x18\x03 \x01(\tR\x05error2\x96\x01\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\"\xac\x02\n" +
+	"\x06Device\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\fR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x0e\n" +
+	"\x02os\x18\x03 \x01(\tR\x02os\x12\x1d\n" +
+	"\n" +
+	"public_key\x18\x04 \x01(\fR\tpublicKey\x12\x1a\n" +
+	"\bapproved\x18\x05 \x01(\bR\bapproved\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12<\n" +
+	"\flast_seen_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"lastSeenAt\x12 \n" +
+	"\flast_seen_ip\x18\b \x01(\tR\n" +
+	"lastSeenIp\x12\x18\n" +
+	"\acurrent\x18\t \x01(\bR\acurrent\"\x8c\x01\n" +
+	"\fDeviceSignIn\x12%\n" +
+	"\x06device\x18\x01 \x01(\v2\r.users.DeviceR\x06device\x12\x1c\n" +
+	"\tsignature\x18\x02 \x01(\fR\tsignature\x127\n" +
+	"\tsigned_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\bsignedAt\"\x14\n" +
+	"\x12ListDevicesRequest\"k\n" +
+	"\x13ListDevicesResponse\x12'\n" +
+	"\adevices\x18\x01 \x03(\v2\r.users.DeviceR\adevices\x12+\n" +
+	"\x11approval_required\x18\x02 \x01(\bR\x10approvalRequired\"2\n" +
+	"\x13RemoveDeviceRequest\x12\x1b\n" +
+	"\tdevice_id\x18\x01 \x01(\fR\bdeviceId\"0\n" +
+	"\x14RemoveDeviceResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"3\n" +
+	"\x14ApproveDeviceRequest\x12\x1b\n" +
+	"\tdevice_id\x18\x01 \x01(\fR\bdeviceId\"1\n" +
+	"\x15ApproveDeviceResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"6\n" +
+	"\x18SetDeviceApprovalRequest\x12\x1a\n" +
+	"\brequired\x18\x01 \x01(\bR\brequired\"5\n" +
+	"\x19SetDeviceApprovalResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess2\x96\x01\n" +
 	"\x0eUserController\x12A\n" +
 	"\n" +
 	"SignUpUser\x12\x18.users.SignUpUserRequest\x1a\x19.users.SignUpUserResponse\x12A\n" +
 	"\n" +
-	"SignInUser\x12\x18.users.SignInUserRequest\x1a\x19.users.SignInUserResponseB\fZ\n" +
+	"SignInUser\x12\x18.users.SignInUserRequest\x1a\x19.users.SignInUserResponse2\xc5\x02\n" +
+	"\x10DeviceController\x12D\n" +
+	"\vListDevices\x12\x19.users.ListDevicesRequest\x1a\x1a.users.ListDevicesResponse\x12G\n" +
+	"\fRemoveDevice\x12\x1a.users.RemoveDeviceRequest\x1a\x1b.users.RemoveDeviceResponse\x12J\n" +
+	"\rApproveDevice\x12\x1b.users.ApproveDeviceRequest\x1a\x1c.users.ApproveDeviceResponse\x12V\n" +
+	"\x11SetDeviceApproval\x12\x1f.users.SetDeviceApprovalRequest\x1a .users.SetDeviceApprovalResponseB\fZ\n" +
 	"grpc/protob\x06proto3"
 
 var (
@@ -320,26 +898,52 @@ func file_internal_protos_users_users_proto_rawDescGZIP() []byte {
 	return file_internal_protos_users_users_proto_rawDescData
 }
 
-var file_internal_protos_users_users_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_internal_protos_users_users_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
 var file_internal_protos_users_users_proto_goTypes = []any{
-	(*User)(nil),               // 0: users.User
-	(*SignUpUserRequest)(nil),  // 1: users.SignUpUserRequest
-	(*SignUpUserResponse)(nil), // 2: users.SignUpUserResponse
-	(*SignInUserRequest)(nil),  // 3: users.SignInUserRequest
-	(*SignInUserResponse)(nil), // 4: users.SignInUserResponse
+	(*User)(nil),                      // 0: users.User
+	(*SignUpUserRequest)(nil),         // 1: users.SignUpUserRequest
+	(*SignUpUserResponse)(nil),        // 2: users.SignUpUserResponse
+	(*SignInUserRequest)(nil),         // 3: users.SignInUserRequest
+	(*SignInUserResponse)(nil),        // 4: users.SignInUserResponse
+	(*Device)(nil),                    // 5: users.Device
+	(*DeviceSignIn)(nil),              // 6: users.DeviceSignIn
+	(*ListDevicesRequest)(nil),        // 7: users.ListDevicesRequest
+	(*ListDevicesResponse)(nil),       // 8: users.ListDevicesResponse
+	(*RemoveDeviceRequest)(nil),       // 9: users.RemoveDeviceRequest
+	(*RemoveDeviceResponse)(nil),      // 10: users.RemoveDeviceResponse
+	(*ApproveDeviceRequest)(nil),      // 11: users.ApproveDeviceRequest
+	(*ApproveDeviceResponse)(nil),     // 12: users.ApproveDeviceResponse
+	(*SetDeviceApprovalRequest)(nil),  // 13: users.SetDeviceApprovalRequest
+	(*SetDeviceApprovalResponse)(nil), // 14: users.SetDeviceApprovalResponse
+	(*timestamppb.Timestamp)(nil),     // 15: google.protobuf.Timestamp
 }
 var file_internal_protos_users_users_proto_depIdxs = []int32{
-	0, // 0: users.SignUpUserRequest.user:type_name -> users.User
-	0, // 1: users.SignInUserRequest.user:type_name -> users.User
-	1, // 2: users.UserController.SignUpUser:input_type -> users.SignUpUserRequest
-	3, // 3: users.UserController.SignInUser:input_type -> users.SignInUserRequest
-	2, // 4: users.UserController.SignUpUser:output_type -> users.SignUpUserResponse
-	4, // 5: users.UserController.SignInUser:output_type -> users.SignInUserResponse
-	4, // [4:6] is the sub-list for method output_type
-	2, // [2:4] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	0,  // 0: users.SignUpUserRequest.user:type_name -> users.User
+	6,  // 1: users.SignUpUserRequest.device:type_name -> users.DeviceSignIn
+	0,  // 2: users.SignInUserRequest.user:type_name -> users.User
+	6,  // 3: users.SignInUserRequest.device:type_name -> users.DeviceSignIn
+	15, // 4: users.Device.created_at:type_name -> google.protobuf.Timestamp
+	15, // 5: users.Device.last_seen_at:type_name -> google.protobuf.Timestamp
+	5,  // 6: users.DeviceSignIn.device:type_name -> users.Device
+	15, // 7: users.DeviceSignIn.signed_at:type_name -> google.protobuf.Timestamp
+	5,  // 8: users.ListDevicesResponse.devices:type_name -> users.Device
+	1,  // 9: users.UserController.SignUpUser:input_type -> users.SignUpUserRequest
+	3,  // 10: users.UserController.SignInUser:input_type -> users.SignInUserRequest
+	7,  // 11: users.DeviceController.ListDevices:input_type -> users.ListDevicesRequest
+	9,  // 12: users.DeviceController.RemoveDevice:input_type -> users.RemoveDeviceRequest
+	11, // 13: users.DeviceController.ApproveDevice:input_type -> users.ApproveDeviceRequest
+	13, // 14: users.DeviceController.SetDeviceApproval:input_type -> users.SetDeviceApprovalRequest
+	2,  // 15: users.UserController.SignUpUser:output_type -> users.SignUpUserResponse
+	4,  // 16: users.UserController.SignInUser:output_type -> users.SignInUserResponse
+	8,  // 17: users.DeviceController.ListDevices:output_type -> users.ListDevicesResponse
+	10, // 18: users.DeviceController.RemoveDevice:output_type -> users.RemoveDeviceResponse
+	12, // 19: users.DeviceController.ApproveDevice:output_type -> users.ApproveDeviceResponse
+	14, // 20: users.DeviceController.SetDeviceApproval:output_type -> users.SetDeviceApprovalResponse
+	15, // [15:21] is the sub-list for method output_type
+	9,  // [9:15] is the sub-list for method input_type
+	9,  // [9:9] is the sub-list for extension type_name
+	9,  // [9:9] is the sub-list for extension extendee
+	0,  // [0:9] is the sub-list for field type_name
 }
 
 func init() { file_internal_protos_users_users_proto_init() }
@@ -353,9 +957,9 @@ func file_internal_protos_users_users_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_internal_protos_users_users_proto_rawDesc), len(file_internal_protos_users_users_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   5,
+			NumMessages:   15,
 			NumExtensions: 0,
-			NumServices:   1,
+			NumServices:   2,
 		},
 		GoTypes:           file_internal_protos_users_users_proto_goTypes,
 		DependencyIndexes: file_internal_protos_users_users_proto_depIdxs,
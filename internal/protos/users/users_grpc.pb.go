@@ -157,3 +157,219 @@ var UserController_ServiceDesc = grpc.ServiceDesc{
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "internal/protos/users/users.proto",
 }
+
+const (
+	DeviceController_ListDevices_FullMethodName       = "/users.DeviceController/ListDevices"
+	DeviceController_RemoveDevice_FullMethodName      = "/users.DeviceController/RemoveDevice"
+	DeviceController_ApproveDevice_FullMethodName     = "/users.DeviceController/ApproveDevice"
+	DeviceController_SetDeviceApproval_FullMethodName = "/users.DeviceController/SetDeviceApproval"
+)
+
+// DeviceControllerClient is the client API for DeviceController service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type DeviceControllerClient interface {
+	ListDevices(ctx context.Context, in *ListDevicesRequest, opts ...grpc.CallOption) (*ListDevicesResponse, error)
+	RemoveDevice(ctx context.Context, in *RemoveDeviceRequest, opts ...grpc.CallOption) (*RemoveDeviceResponse, error)
+	ApproveDevice(ctx context.Context, in *ApproveDeviceRequest, opts ...grpc.CallOption) (*ApproveDeviceResponse, error)
+	SetDeviceApproval(ctx context.Context, in *SetDeviceApprovalRequest, opts ...grpc.CallOption) (*SetDeviceApprovalResponse, error)
+}
+
+type deviceControllerClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewDeviceControllerClient(cc grpc.ClientConnInterface) (DeviceControllerClient, error) {
+	return &deviceControllerClient{cc}, nil
+}
+
+func (c *deviceControllerClient) ListDevices(ctx context.Context, in *ListDevicesRequest, opts ...grpc.CallOption) (*ListDevicesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDevicesResponse)
+	err := c.cc.Invoke(ctx, DeviceController_ListDevices_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deviceControllerClient) RemoveDevice(ctx context.Context, in *RemoveDeviceRequest, opts ...grpc.CallOption) (*RemoveDeviceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RemoveDeviceResponse)
+	err := c.cc.Invoke(ctx, DeviceController_RemoveDevice_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deviceControllerClient) ApproveDevice(ctx context.Context, in *ApproveDeviceRequest, opts ...grpc.CallOption) (*ApproveDeviceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ApproveDeviceResponse)
+	err := c.cc.Invoke(ctx, DeviceController_ApproveDevice_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deviceControllerClient) SetDeviceApproval(ctx context.Context, in *SetDeviceApprovalRequest, opts ...grpc.CallOption) (*SetDeviceApprovalResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetDeviceApprovalResponse)
+	err := c.cc.Invoke(ctx, DeviceController_SetDeviceApproval_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DeviceControllerServer is the server API for DeviceController service.
+// All implementations must embed UnimplementedDeviceControllerServer
+// for forward compatibility.
+type DeviceControllerServer interface {
+	ListDevices(context.Context, *ListDevicesRequest) (*ListDevicesResponse, error)
+	RemoveDevice(context.Context, *RemoveDeviceRequest) (*RemoveDeviceResponse, error)
+	ApproveDevice(context.Context, *ApproveDeviceRequest) (*ApproveDeviceResponse, error)
+	SetDeviceApproval(context.Context, *SetDeviceApprovalRequest) (*SetDeviceApprovalResponse, error)
+	mustEmbedUnimplementedDeviceControllerServer()
+}
+
+// UnimplementedDeviceControllerServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedDeviceControllerServer struct{}
+
+func (UnimplementedDeviceControllerServer) ListDevices(context.Context, *ListDevicesRequest) (*ListDevicesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDevices not implemented")
+}
+func (UnimplementedDeviceControllerServer) RemoveDevice(context.Context, *RemoveDeviceRequest) (*RemoveDeviceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveDevice not implemented")
+}
+func (UnimplementedDeviceControllerServer) ApproveDevice(context.Context, *ApproveDeviceRequest) (*ApproveDeviceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApproveDevice not implemented")
+}
+func (UnimplementedDeviceControllerServer) SetDeviceApproval(context.Context, *SetDeviceApprovalRequest) (*SetDeviceApprovalResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetDeviceApproval not implemented")
+}
+func (UnimplementedDeviceControllerServer) mustEmbedUnimplementedDeviceControllerServer() {}
+func (UnimplementedDeviceControllerServer) testEmbeddedByValue()                          {}
+
+// UnsafeDeviceControllerServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to DeviceControllerServer will
+// result in compilation errors.
+type UnsafeDeviceControllerServer interface {
+	mustEmbedUnimplementedDeviceControllerServer()
+}
+
+func RegisterDeviceControllerServer(s grpc.ServiceRegistrar, srv DeviceControllerServer) {
+	// If the following call pancis, it indicates UnimplementedDeviceControllerServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&DeviceController_ServiceDesc, srv)
+}
+
+func _DeviceController_ListDevices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDevicesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceControllerServer).ListDevices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeviceController_ListDevices_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceControllerServer).ListDevices(ctx, req.(*ListDevicesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeviceController_RemoveDevice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveDeviceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceControllerServer).RemoveDevice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeviceController_RemoveDevice_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceControllerServer).RemoveDevice(ctx, req.(*RemoveDeviceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeviceController_ApproveDevice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApproveDeviceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceControllerServer).ApproveDevice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeviceController_ApproveDevice_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceControllerServer).ApproveDevice(ctx, req.(*ApproveDeviceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeviceController_SetDeviceApproval_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetDeviceApprovalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceControllerServer).SetDeviceApproval(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeviceController_SetDeviceApproval_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceControllerServer).SetDeviceApproval(ctx, req.(*SetDeviceApprovalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// DeviceController_ServiceDesc is the grpc.ServiceDesc for DeviceController service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var DeviceController_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "users.DeviceController",
+	HandlerType: (*DeviceControllerServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListDevices",
+			Handler:    _DeviceController_ListDevices_Handler,
+		},
+		{
+			MethodName: "RemoveDevice",
+			Handler:    _DeviceController_RemoveDevice_Handler,
+		},
+		{
+			MethodName: "ApproveDevice",
+			Handler:    _DeviceController_ApproveDevice_Handler,
+		},
+		{
+			MethodName: "SetDeviceApproval",
+			Handler:    _DeviceController_SetDeviceApproval_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "internal/protos/users/users.proto",
+}
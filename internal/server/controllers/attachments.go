@@ -0,0 +1,104 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"gophkeeper/internal/errs"
+	pb "gophkeeper/internal/protos/items"
+	"gophkeeper/models"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (ic *ItemController) UploadAttachment(ctx context.Context, in *pb.UploadAttachmentRequest) (*pb.UploadAttachmentResponse, error) {
+	login, err := loginFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if in.Attachment == nil || len(in.Attachment.ItemId) != 16 {
+		return nil, status.Error(codes.InvalidArgument, errs.ErrRequiredArgumentIsMissing.Error())
+	}
+
+	a := models.AttachmentPbToModels(in.Attachment)
+	a.Content, a.Nonce = in.Content, in.Nonce
+	id, err := ic.service.AddAttachment(ctx, login, a)
+	if err != nil {
+		return nil, attachmentErrorToStatus(err)
+	}
+	return &pb.UploadAttachmentResponse{
+		Id: id[:],
+	}, nil
+}
+
+func (ic *ItemController) ListAttachments(ctx context.Context, in *pb.ListAttachmentsRequest) (*pb.ListAttachmentsResponse, error) {
+	login, err := loginFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(in.ItemId) != 16 {
+		return nil, status.Error(codes.InvalidArgument, errs.ErrRequiredArgumentIsMissing.Error())
+	}
+
+	attachments, err := ic.service.ListAttachments(ctx, login, models.ItemIdPbToModels(in.ItemId))
+	if err != nil {
+		return nil, attachmentErrorToStatus(err)
+	}
+	pbAttachments := make([]*pb.Attachment, len(attachments))
+	for i := range attachments {
+		pbAttachments[i] = attachments[i].ToPb()
+	}
+	return &pb.ListAttachmentsResponse{
+		Attachments: pbAttachments,
+	}, nil
+}
+
+func (ic *ItemController) DownloadAttachment(ctx context.Context, in *pb.DownloadAttachmentRequest) (*pb.DownloadAttachmentResponse, error) {
+	login, err := loginFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(in.ItemId) != 16 || len(in.AttachmentId) != 16 {
+		return nil, status.Error(codes.InvalidArgument, errs.ErrRequiredArgumentIsMissing.Error())
+	}
+
+	a, err := ic.service.GetAttachment(ctx, login, models.ItemIdPbToModels(in.ItemId), models.ItemIdPbToModels(in.AttachmentId))
+	if err != nil {
+		return nil, attachmentErrorToStatus(err)
+	}
+	return &pb.DownloadAttachmentResponse{
+		Attachment: a.ToPb(),
+		Content:    a.Content,
+		Nonce:      a.Nonce,
+	}, nil
+}
+
+func (ic *ItemController) DeleteAttachment(ctx context.Context, in *pb.DeleteAttachmentRequest) (*pb.DeleteAttachmentResponse, error) {
+	login, err := loginFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(in.ItemId) != 16 || len(in.AttachmentId) != 16 {
+		return nil, status.Error(codes.InvalidArgument, errs.ErrRequiredArgumentIsMissing.Error())
+	}
+
+	if err := ic.service.DeleteAttachment(ctx, login, models.ItemIdPbToModels(in.ItemId), models.ItemIdPbToModels(in.AttachmentId)); err != nil {
+		return nil, attachmentErrorToStatus(err)
+	}
+	return &pb.DeleteAttachmentResponse{
+		Success: true,
+	}, nil
+}
+
+func attachmentErrorToStatus(err error) error {
+	if st, ok := collectionAccessError(err); ok {
+		return st
+	}
+	switch {
+	case errors.Is(err, errs.ErrItemNotFound), errors.Is(err, errs.ErrAttachmentNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, errs.ErrRequiredArgumentIsMissing), errors.Is(err, errs.ErrAttachmentTooLarge):
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return status.Error(codes.Internal, errs.ErrInternalServerError.Error())
+}
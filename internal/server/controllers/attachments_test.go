@@ -0,0 +1,41 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"gophkeeper/internal/errs"
+	pb "gophkeeper/internal/protos/items"
+	iserv "gophkeeper/internal/server/services/item_service"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestItemController_Attachments_Validation(t *testing.T) {
+	controller := NewItemController(&iserv.ItemService{})
+	id := make([]byte, 16)
+
+	_, err := controller.ListAttachments(context.Background(), &pb.ListAttachmentsRequest{ItemId: id})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	ctx := context.WithValue(context.Background(), "login", "alice")
+	_, err = controller.UploadAttachment(ctx, &pb.UploadAttachmentRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = controller.ListAttachments(ctx, &pb.ListAttachmentsRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = controller.DownloadAttachment(ctx, &pb.DownloadAttachmentRequest{ItemId: id})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = controller.DeleteAttachment(ctx, &pb.DeleteAttachmentRequest{AttachmentId: id})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestAttachmentErrorToStatus(t *testing.T) {
+	assert.Equal(t, codes.NotFound, status.Code(attachmentErrorToStatus(errs.ErrAttachmentNotFound)))
+	assert.Equal(t, codes.NotFound, status.Code(attachmentErrorToStatus(errs.ErrItemNotFound)))
+	assert.Equal(t, codes.InvalidArgument, status.Code(attachmentErrorToStatus(errs.ErrAttachmentTooLarge)))
+	assert.Equal(t, codes.PermissionDenied, status.Code(attachmentErrorToStatus(errs.ErrOrgPermissionDenied)))
+	assert.Equal(t, codes.Internal, status.Code(attachmentErrorToStatus(errors.New("db down"))))
+}
@@ -0,0 +1,105 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"gophkeeper/internal/errs"
+	pb "gophkeeper/internal/protos/users"
+	userv "gophkeeper/internal/server/services/user_service"
+	"gophkeeper/models"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type DeviceController struct {
+	pb.UnimplementedDeviceControllerServer
+	service *userv.UserService
+}
+
+func NewDeviceController(service *userv.UserService) *DeviceController {
+	return &DeviceController{
+		service: service,
+	}
+}
+
+func (dc *DeviceController) ListDevices(ctx context.Context, in *pb.ListDevicesRequest) (*pb.ListDevicesResponse, error) {
+	login, err := loginFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	devices, required, err := dc.service.ListDevices(ctx, login, deviceFromContext(ctx))
+	if err != nil {
+		return nil, deviceErrorToStatus(err)
+	}
+
+	pbDevices := make([]*pb.Device, len(devices))
+	for i := range devices {
+		pbDevices[i] = devices[i].ToPb()
+	}
+	return &pb.ListDevicesResponse{
+		Devices:          pbDevices,
+		ApprovalRequired: required,
+	}, nil
+}
+
+func (dc *DeviceController) RemoveDevice(ctx context.Context, in *pb.RemoveDeviceRequest) (*pb.RemoveDeviceResponse, error) {
+	login, err := loginFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(in.DeviceId) != 16 {
+		return nil, status.Error(codes.InvalidArgument, errs.ErrRequiredArgumentIsMissing.Error())
+	}
+
+	if err := dc.service.RemoveDevice(ctx, login, models.ItemIdPbToModels(in.DeviceId)); err != nil {
+		return nil, deviceErrorToStatus(err)
+	}
+	return &pb.RemoveDeviceResponse{
+		Success: true,
+	}, nil
+}
+
+func (dc *DeviceController) ApproveDevice(ctx context.Context, in *pb.ApproveDeviceRequest) (*pb.ApproveDeviceResponse, error) {
+	login, err := loginFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(in.DeviceId) != 16 {
+		return nil, status.Error(codes.InvalidArgument, errs.ErrRequiredArgumentIsMissing.Error())
+	}
+
+	err = dc.service.ApproveDevice(ctx, login, deviceFromContext(ctx), models.ItemIdPbToModels(in.DeviceId))
+	if err != nil {
+		return nil, deviceErrorToStatus(err)
+	}
+	return &pb.ApproveDeviceResponse{
+		Success: true,
+	}, nil
+}
+
+func (dc *DeviceController) SetDeviceApproval(ctx context.Context, in *pb.SetDeviceApprovalRequest) (*pb.SetDeviceApprovalResponse, error) {
+	login, err := loginFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := dc.service.SetDeviceApproval(ctx, login, deviceFromContext(ctx), in.Required); err != nil {
+		return nil, deviceErrorToStatus(err)
+	}
+	return &pb.SetDeviceApprovalResponse{
+		Success: true,
+	}, nil
+}
+
+func deviceErrorToStatus(err error) error {
+	switch {
+	case errors.Is(err, errs.ErrDeviceNotFound), errors.Is(err, errs.ErrUserNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, errs.ErrDeviceNotTrusted):
+		return status.Error(codes.PermissionDenied, err.Error())
+	default:
+		return status.Error(codes.Internal, errs.ErrInternalServerError.Error())
+	}
+}
@@ -0,0 +1,48 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"gophkeeper/internal/errs"
+	pb "gophkeeper/internal/protos/users"
+	userv "gophkeeper/internal/server/services/user_service"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestDeviceController_RequiresLogin(t *testing.T) {
+	controller := NewDeviceController(&userv.UserService{})
+
+	_, err := controller.ListDevices(context.Background(), &pb.ListDevicesRequest{})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestDeviceController_Validation(t *testing.T) {
+	controller := NewDeviceController(&userv.UserService{})
+	ctx := context.WithValue(context.Background(), "login", "alice")
+
+	_, err := controller.RemoveDevice(ctx, &pb.RemoveDeviceRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = controller.ApproveDevice(ctx, &pb.ApproveDeviceRequest{DeviceId: []byte{1, 2}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestDeviceErrorToStatus(t *testing.T) {
+	tests := []struct {
+		err  error
+		want codes.Code
+	}{
+		{errs.ErrDeviceNotFound, codes.NotFound},
+		{fmt.Errorf("wrapped: %w", errs.ErrDeviceNotTrusted), codes.PermissionDenied},
+		{fmt.Errorf("db down"), codes.Internal},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, status.Code(deviceErrorToStatus(tt.err)), tt.err.Error())
+	}
+}
@@ -0,0 +1,224 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"gophkeeper/internal/errs"
+	pb "gophkeeper/internal/protos/items"
+	eserv "gophkeeper/internal/server/services/emergency_service"
+	"gophkeeper/models"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type EmergencyController struct {
+	pb.UnimplementedEmergencyControllerServer
+	service *eserv.EmergencyService
+}
+
+func NewEmergencyController(service *eserv.EmergencyService) *EmergencyController {
+	return &EmergencyController{
+		service: service,
+	}
+}
+
+func (ec *EmergencyController) GrantEmergencyAccess(ctx context.Context, in *pb.GrantEmergencyAccessRequest) (*pb.GrantEmergencyAccessResponse, error) {
+	login, err := loginFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if in.Access == nil || in.Access.Grantee == "" || in.Access.WrappedKey == "" {
+		return nil, status.Error(codes.InvalidArgument, errs.ErrRequiredArgumentIsMissing.Error())
+	}
+
+	if err := ec.service.GrantAccess(ctx, login, models.EmergencyAccessPbToModels(in.Access)); err != nil {
+		return nil, emergencyErrorToStatus(err)
+	}
+	return &pb.GrantEmergencyAccessResponse{
+		Success: true,
+	}, nil
+}
+
+func (ec *EmergencyController) RevokeEmergencyAccess(ctx context.Context, in *pb.RevokeEmergencyAccessRequest) (*pb.RevokeEmergencyAccessResponse, error) {
+	login, err := loginFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if in.Grantee == "" {
+		return nil, status.Error(codes.InvalidArgument, errs.ErrRequiredArgumentIsMissing.Error())
+	}
+
+	if err := ec.service.RevokeAccess(ctx, login, in.Grantee); err != nil {
+		return nil, emergencyErrorToStatus(err)
+	}
+	return &pb.RevokeEmergencyAccessResponse{
+		Success: true,
+	}, nil
+}
+
+func (ec *EmergencyController) ListEmergencyAccess(ctx context.Context, in *pb.ListEmergencyAccessRequest) (*pb.ListEmergencyAccessResponse, error) {
+	login, err := loginFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	granted, trustedBy, err := ec.service.ListAccess(ctx, login)
+	if err != nil {
+		return nil, emergencyErrorToStatus(err)
+	}
+
+	pbGranted := make([]*pb.EmergencyAccess, len(granted))
+	for i := range granted {
+		pbGranted[i] = granted[i].ToPb()
+	}
+	pbTrustedBy := make([]*pb.EmergencyAccess, len(trustedBy))
+	for i := range trustedBy {
+		pbTrustedBy[i] = trustedBy[i].ToPb()
+	}
+	return &pb.ListEmergencyAccessResponse{
+		Granted:   pbGranted,
+		TrustedBy: pbTrustedBy,
+	}, nil
+}
+
+func (ec *EmergencyController) RequestEmergencyAccess(ctx context.Context, in *pb.RequestEmergencyAccessRequest) (*pb.RequestEmergencyAccessResponse, error) {
+	login, err := loginFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if in.Grantor == "" {
+		return nil, status.Error(codes.InvalidArgument, errs.ErrRequiredArgumentIsMissing.Error())
+	}
+
+	if err := ec.service.RequestAccess(ctx, login, in.Grantor); err != nil {
+		return nil, emergencyErrorToStatus(err)
+	}
+	return &pb.RequestEmergencyAccessResponse{
+		Success: true,
+	}, nil
+}
+
+func (ec *EmergencyController) ApproveEmergencyAccess(ctx context.Context, in *pb.ApproveEmergencyAccessRequest) (*pb.ApproveEmergencyAccessResponse, error) {
+	login, err := loginFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if in.Grantee == "" {
+		return nil, status.Error(codes.InvalidArgument, errs.ErrRequiredArgumentIsMissing.Error())
+	}
+
+	if err := ec.service.ApproveAccess(ctx, login, in.Grantee); err != nil {
+		return nil, emergencyErrorToStatus(err)
+	}
+	return &pb.ApproveEmergencyAccessResponse{
+		Success: true,
+	}, nil
+}
+
+func (ec *EmergencyController) DenyEmergencyAccess(ctx context.Context, in *pb.DenyEmergencyAccessRequest) (*pb.DenyEmergencyAccessResponse, error) {
+	login, err := loginFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if in.Grantee == "" {
+		return nil, status.Error(codes.InvalidArgument, errs.ErrRequiredArgumentIsMissing.Error())
+	}
+
+	if err := ec.service.DenyAccess(ctx, login, in.Grantee); err != nil {
+		return nil, emergencyErrorToStatus(err)
+	}
+	return &pb.DenyEmergencyAccessResponse{
+		Success: true,
+	}, nil
+}
+
+func (ec *EmergencyController) GetEmergencyVault(ctx context.Context, in *pb.GetEmergencyVaultRequest) (*pb.GetEmergencyVaultResponse, error) {
+	login, err := loginFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if in.Grantor == "" {
+		return nil, status.Error(codes.InvalidArgument, errs.ErrRequiredArgumentIsMissing.Error())
+	}
+
+	vault, err := ec.service.GetVault(ctx, login, in.Grantor)
+	if err != nil {
+		return nil, emergencyErrorToStatus(err)
+	}
+
+	pbItems := make([]*pb.EncryptedItem, len(vault.Items))
+	for i := range vault.Items {
+		pbItem, err := vault.Items[i].ToPb()
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		pbItems[i] = pbItem
+	}
+	pbTemplates := make([]*pb.ItemTemplate, len(vault.Templates))
+	for i := range vault.Templates {
+		pbTemplates[i] = vault.Templates[i].ToPb()
+	}
+	return &pb.GetEmergencyVaultResponse{
+		Access:    vault.Access.ToPb(),
+		Salt:      vault.Salt,
+		Keys:      vault.Keys.ToPb(),
+		Items:     pbItems,
+		Templates: pbTemplates,
+	}, nil
+}
+
+func (ec *EmergencyController) TakeoverAccount(ctx context.Context, in *pb.TakeoverAccountRequest) (*pb.TakeoverAccountResponse, error) {
+	login, err := loginFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if in.Grantor == "" || in.Password == "" {
+		return nil, status.Error(codes.InvalidArgument, errs.ErrRequiredArgumentIsMissing.Error())
+	}
+
+	takeover := &models.EmergencyTakeover{
+		Grantor:             in.Grantor,
+		Password:            in.Password,
+		EncryptedPrivateKey: in.EncryptedPrivateKey,
+		Items:               make([]models.EncryptedItem, len(in.Items)),
+		Templates:           make([]models.Template, len(in.Templates)),
+	}
+	for i, item := range in.Items {
+		if item.Id == nil || item.EncryptedData == nil || item.EncryptedKey == "" {
+			return nil, status.Error(codes.InvalidArgument, errs.ErrRequiredArgumentIsMissing.Error())
+		}
+		takeover.Items[i] = *models.EncryptedItemPbToModels(item)
+	}
+	for i, template := range in.Templates {
+		if template.Id == nil || template.EncryptedData == nil {
+			return nil, status.Error(codes.InvalidArgument, errs.ErrRequiredArgumentIsMissing.Error())
+		}
+		takeover.Templates[i] = *models.TemplatePbToModels(template)
+	}
+
+	if err := ec.service.Takeover(ctx, login, takeover); err != nil {
+		return nil, emergencyErrorToStatus(err)
+	}
+	return &pb.TakeoverAccountResponse{
+		Success: true,
+	}, nil
+}
+
+func emergencyErrorToStatus(err error) error {
+	switch {
+	case errors.Is(err, errs.ErrEmergencyAccessNotFound), errors.Is(err, errs.ErrKeysNotFound), errors.Is(err, errs.ErrUserNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, errs.ErrEmergencyWithSelf), errors.Is(err, errs.ErrInvalidWaitPeriod),
+		errors.Is(err, errs.ErrInvalidEmergencyAccessType), errors.Is(err, errs.ErrRequiredArgumentIsMissing):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, errs.ErrEmergencyAlreadyRequested), errors.Is(err, errs.ErrEmergencyNotRequested),
+		errors.Is(err, errs.ErrEmergencyAccessPending), errors.Is(err, errs.ErrEmergencyItemsMismatch),
+		errors.Is(err, errs.ErrEmergencyTemplatesMismatch):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, errs.ErrEmergencyTakeoverNotAllowed):
+		return status.Error(codes.PermissionDenied, err.Error())
+	default:
+		return status.Error(codes.Internal, errs.ErrInternalServerError.Error())
+	}
+}
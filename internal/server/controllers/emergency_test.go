@@ -0,0 +1,65 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"gophkeeper/internal/errs"
+	pb "gophkeeper/internal/protos/items"
+	eserv "gophkeeper/internal/server/services/emergency_service"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestEmergencyController_RequiresLogin(t *testing.T) {
+	controller := NewEmergencyController(&eserv.EmergencyService{})
+
+	_, err := controller.ListEmergencyAccess(context.Background(), &pb.ListEmergencyAccessRequest{})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestEmergencyController_Validation(t *testing.T) {
+	controller := NewEmergencyController(&eserv.EmergencyService{})
+	ctx := context.WithValue(context.Background(), "login", "alice")
+
+	_, err := controller.GrantEmergencyAccess(ctx, &pb.GrantEmergencyAccessRequest{Access: &pb.EmergencyAccess{Grantee: "bob"}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = controller.RequestEmergencyAccess(ctx, &pb.RequestEmergencyAccessRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = controller.DenyEmergencyAccess(ctx, &pb.DenyEmergencyAccessRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = controller.TakeoverAccount(ctx, &pb.TakeoverAccountRequest{
+		Grantor:  "carol",
+		Password: "secret",
+		Items:    []*pb.EncryptedItem{{Id: []byte{1}}},
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestEmergencyErrorToStatus(t *testing.T) {
+	tests := []struct {
+		err  error
+		want codes.Code
+	}{
+		{errs.ErrEmergencyAccessNotFound, codes.NotFound},
+		{errs.ErrEmergencyWithSelf, codes.InvalidArgument},
+		{errs.ErrInvalidWaitPeriod, codes.InvalidArgument},
+		{fmt.Errorf("wrapped: %w", errs.ErrEmergencyAccessPending), codes.FailedPrecondition},
+		{errs.ErrEmergencyNotRequested, codes.FailedPrecondition},
+		{errs.ErrEmergencyItemsMismatch, codes.FailedPrecondition},
+		{errs.ErrEmergencyTakeoverNotAllowed, codes.PermissionDenied},
+		{fmt.Errorf("db down"), codes.Internal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.err.Error(), func(t *testing.T) {
+			assert.Equal(t, tt.want, status.Code(emergencyErrorToStatus(tt.err)))
+		})
+	}
+}
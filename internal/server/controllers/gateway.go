@@ -0,0 +1,276 @@
+package controllers
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"gophkeeper/config"
+	"gophkeeper/internal/errs"
+	pbcr "gophkeeper/internal/protos/crypto"
+	pbit "gophkeeper/internal/protos/items"
+	pbus "gophkeeper/internal/protos/users"
+	"gophkeeper/internal/telemetry"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// maxGatewayBody matches the default gRPC message size limit.
+const maxGatewayBody = 4 << 20
+
+var (
+	gatewayMarshal   = protojson.MarshalOptions{UseProtoNames: true}
+	gatewayUnmarshal = protojson.UnmarshalOptions{DiscardUnknown: true}
+)
+
+// GatewayError is the JSON body of every failed gateway request.
+type GatewayError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// NewGatewayHandler exposes the users and items APIs as HTTP/JSON. Requests
+// go through AuthInterceptor and the gRPC controllers, so the gateway shares
+// their auth, validation and error codes. The user login is always taken
+// from the token, never from the request. Passwords are encrypted with the
+// server public key, which clients get from the gateway too.
+func NewGatewayHandler(cnfg config.ServerInterceptorsConfig, sessions SessionValidator, cc *CryptoController, uc *UserController, ic *ItemController) http.Handler {
+	gw := &gateway{
+		cnfg:     cnfg,
+		sessions: sessions,
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("GET /api/v1/crypto/public-key", handleGateway(gw, pbcr.CryptoController_GetPublicKeyPEM_FullMethodName,
+		func(r *http.Request, in *pbcr.GetPublicKeyPEMRequest) error { return nil },
+		func(ctx context.Context, in *pbcr.GetPublicKeyPEMRequest) (proto.Message, error) {
+			return cc.GetPublicKeyPEM(ctx, in)
+		}))
+	mux.Handle("POST /api/v1/users/signup", handleGateway(gw, pbus.UserController_SignUpUser_FullMethodName,
+		bindBody[*pbus.SignUpUserRequest],
+		func(ctx context.Context, in *pbus.SignUpUserRequest) (proto.Message, error) {
+			resp, err := uc.SignUpUser(ctx, in)
+			if err == nil && resp.Error != "" {
+				return nil, status.Error(codes.AlreadyExists, resp.Error)
+			}
+			return resp, err
+		}))
+	mux.Handle("POST /api/v1/users/signin", handleGateway(gw, pbus.UserController_SignInUser_FullMethodName,
+		bindBody[*pbus.SignInUserRequest],
+		func(ctx context.Context, in *pbus.SignInUserRequest) (proto.Message, error) {
+			resp, err := uc.SignInUser(ctx, in)
+			if err == nil && resp.Error != "" {
+				return nil, status.Error(codes.Unauthenticated, resp.Error)
+			}
+			return resp, err
+		}))
+
+	mux.Handle("GET /api/v1/items", handleGateway(gw, pbit.ItemsController_GetUserItems_FullMethodName,
+		func(r *http.Request, in *pbit.GetUserItemsRequest) error {
+			typ, err := parseGatewayItemType(r.URL.Query().Get("type"))
+			if err != nil {
+				return err
+			}
+			in.Type = typ
+			if coll := r.URL.Query().Get("collection_id"); coll != "" {
+				in.CollectionId, err = parseGatewayID(coll)
+			}
+			return err
+		},
+		func(ctx context.Context, in *pbit.GetUserItemsRequest) (proto.Message, error) {
+			in.UserLogin, _ = loginFromContext(ctx)
+			return ic.GetUserItems(ctx, in)
+		}))
+	mux.Handle("POST /api/v1/items", handleGateway(gw, pbit.ItemsController_AddItem_FullMethodName,
+		func(r *http.Request, in *pbit.AddItemRequest) error {
+			in.Item = &pbit.EncryptedItem{}
+			return bindBody(r, in.Item)
+		},
+		func(ctx context.Context, in *pbit.AddItemRequest) (proto.Message, error) {
+			in.Item.UserLogin, _ = loginFromContext(ctx)
+			return ic.AddItem(ctx, in)
+		}))
+	mux.Handle("PUT /api/v1/items/{id}", handleGateway(gw, pbit.ItemsController_EditItem_FullMethodName,
+		func(r *http.Request, in *pbit.EditItemRequest) error {
+			in.Item = &pbit.EncryptedItem{}
+			if err := bindBody(r, in.Item); err != nil {
+				return err
+			}
+			id, err := parseGatewayID(r.PathValue("id"))
+			in.Item.Id = id
+			return err
+		},
+		func(ctx context.Context, in *pbit.EditItemRequest) (proto.Message, error) {
+			in.Item.UserLogin, _ = loginFromContext(ctx)
+			return ic.EditItem(ctx, in)
+		}))
+	mux.Handle("DELETE /api/v1/items/{id}", handleGateway(gw, pbit.ItemsController_DeleteItem_FullMethodName,
+		func(r *http.Request, in *pbit.DeleteItemRequest) error {
+			id, err := parseGatewayID(r.PathValue("id"))
+			in.ItemId = id
+			return err
+		},
+		func(ctx context.Context, in *pbit.DeleteItemRequest) (proto.Message, error) {
+			in.UserLogin, _ = loginFromContext(ctx)
+			return ic.DeleteItem(ctx, in)
+		}))
+
+	return mux
+}
+
+type gateway struct {
+	cnfg     config.ServerInterceptorsConfig
+	sessions SessionValidator
+}
+
+// handleGateway binds the HTTP request into a new T and runs call behind
+// AuthInterceptor as if it were the gRPC method.
+func handleGateway[T proto.Message](gw *gateway, method string, bind func(*http.Request, T) error, call func(context.Context, T) (proto.Message, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+		if addr, err := netip.ParseAddrPort(r.RemoteAddr); err == nil {
+			ctx = peer.NewContext(ctx, &peer.Peer{Addr: net.TCPAddrFromAddrPort(addr)})
+		}
+		ctx, span := telemetry.Tracer("gophkeeper/internal/server/controllers").Start(ctx, method,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(attribute.String("http.route", r.Pattern)),
+		)
+		defer span.End()
+
+		var zero T
+		in := zero.ProtoReflect().New().Interface().(T)
+		if err := bind(r, in); err != nil {
+			writeGatewayError(w, span, status.Error(codes.InvalidArgument, err.Error()))
+			return
+		}
+
+		md := metadata.MD{}
+		if auth := r.Header.Get("Authorization"); auth != "" {
+			md.Set("authorization", auth)
+		}
+		ctx = metadata.NewIncomingContext(ctx, md)
+
+		resp, err := AuthInterceptor(ctx, gw.cnfg, gw.sessions, in, &grpc.UnaryServerInfo{FullMethod: method},
+			func(ctx context.Context, req interface{}) (interface{}, error) {
+				return call(ctx, req.(T))
+			})
+		if err != nil {
+			writeGatewayError(w, span, err)
+			return
+		}
+
+		body, err := gatewayMarshal.Marshal(resp.(proto.Message))
+		if err != nil {
+			writeGatewayError(w, span, status.Error(codes.Internal, errs.ErrInternalServerError.Error()))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(body)
+	}
+}
+
+func bindBody[T proto.Message](r *http.Request, in T) error {
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxGatewayBody+1))
+	if err != nil {
+		return fmt.Errorf("read body error: %w", err)
+	}
+	if len(body) > maxGatewayBody {
+		return errors.New("request body is too large")
+	}
+	if err := gatewayUnmarshal.Unmarshal(body, in); err != nil {
+		return fmt.Errorf("invalid JSON body: %w", err)
+	}
+	return nil
+}
+
+// parseGatewayID accepts an id in base64 with either alphabet, with or
+// without padding, the form the JSON responses carry bytes in.
+func parseGatewayID(s string) ([]byte, error) {
+	s = strings.TrimRight(s, "=")
+	s = strings.NewReplacer("-", "+", "_", "/").Replace(s)
+	id, err := base64.RawStdEncoding.DecodeString(s)
+	if err != nil || len(id) != 16 {
+		return nil, errors.New("invalid id")
+	}
+	return id, nil
+}
+
+// parseGatewayItemType accepts both CREDENTIALS and ITEM_TYPE_CREDENTIALS,
+// an empty type lists items of every type.
+func parseGatewayItemType(s string) (pbit.ItemType, error) {
+	if s == "" {
+		return pbit.ItemType_ITEM_TYPE_UNSPECIFIED, nil
+	}
+	name := strings.ToUpper(s)
+	if !strings.HasPrefix(name, "ITEM_TYPE_") {
+		name = "ITEM_TYPE_" + name
+	}
+	typ, ok := pbit.ItemType_value[name]
+	if !ok {
+		return 0, fmt.Errorf("unknown item type %q", s)
+	}
+	return pbit.ItemType(typ), nil
+}
+
+func writeGatewayError(w http.ResponseWriter, span trace.Span, err error) {
+	st := status.Convert(err)
+	span.SetAttributes(attribute.String("rpc.grpc.status_code", st.Code().String()))
+	if st.Code() == codes.Internal || st.Code() == codes.Unknown {
+		span.SetStatus(otelcodes.Error, st.Message())
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(httpStatusFromCode(st.Code()))
+	json.NewEncoder(w).Encode(GatewayError{
+		Code:    st.Code().String(),
+		Message: st.Message(),
+	})
+}
+
+// httpStatusFromCode follows the mapping grpc-gateway uses.
+func httpStatusFromCode(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.Canceled:
+		return 499
+	case codes.InvalidArgument, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.FailedPrecondition:
+		return http.StatusPreconditionFailed
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
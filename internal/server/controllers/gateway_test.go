@@ -0,0 +1,276 @@
+package controllers
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"gophkeeper/internal/errs"
+	"gophkeeper/internal/server/repositories"
+	iserv "gophkeeper/internal/server/services/item_service"
+	userv "gophkeeper/internal/server/services/user_service"
+	"gophkeeper/models"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+// gatewayStorage records what the item service asked for.
+type gatewayStorage struct {
+	repositories.Storage
+	login string
+	added *models.EncryptedItem
+	user  *models.User
+}
+
+// gatewayConfig signs tokens with the secret AuthInterceptor checks.
+type gatewayConfig struct {
+	testSecretConfig
+	key *rsa.PrivateKey
+}
+
+func (c gatewayConfig) GetPrivateKey() *rsa.PrivateKey { return c.key }
+
+func (c gatewayConfig) GetPublicKeyPEM() []byte {
+	der, _ := x509.MarshalPKIXPublicKey(&c.key.PublicKey)
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+}
+
+func (s *gatewayStorage) GetAllUserItems(ctx context.Context, login string) ([]models.EncryptedItem, error) {
+	s.login = login
+	return []models.EncryptedItem{{
+		ID:            [16]byte{1},
+		UserLogin:     login,
+		Name:          "mail",
+		Type:          models.ItemTypeCREDENTIALS,
+		EncryptedData: models.EncryptedData{EncryptedContent: "content", Nonce: "nonce"},
+	}}, nil
+}
+
+func (s *gatewayStorage) AddItem(ctx context.Context, item *models.EncryptedItem) error {
+	s.added = item
+	return nil
+}
+
+func (s *gatewayStorage) SignUpUser(ctx context.Context, user *models.User) error {
+	s.user = user
+	return nil
+}
+
+func (s *gatewayStorage) GetUser(ctx context.Context, login string) (*models.User, error) {
+	if s.user == nil || s.user.Login != login {
+		return nil, pgx.ErrNoRows
+	}
+	return s.user, nil
+}
+
+func (s *gatewayStorage) GetDeviceApprovalRequired(ctx context.Context, login string) (bool, error) {
+	return false, nil
+}
+
+func (s *gatewayStorage) GetItemCollection(ctx context.Context, itemID [16]byte) ([16]byte, error) {
+	return [16]byte{}, nil
+}
+
+func (s *gatewayStorage) DeleteItem(ctx context.Context, login string, itemID [16]byte) error {
+	return errs.ErrItemNotFound
+}
+
+func newTestGateway(t *testing.T) (http.Handler, *gatewayStorage) {
+	repo := &gatewayStorage{}
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	cnfg := gatewayConfig{key: key}
+	is, err := iserv.NewItemService(repo)
+	require.NoError(t, err)
+	us, err := userv.NewUserService(cnfg, repo)
+	require.NoError(t, err)
+	return NewGatewayHandler(cnfg, nil, NewCryptoController(cnfg), NewUserController(us), NewItemController(is)), repo
+}
+
+func doGateway(t *testing.T, h http.Handler, method, path, body, token string) (*httptest.ResponseRecorder, GatewayError) {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	var gerr GatewayError
+	if rec.Code != http.StatusOK {
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&gerr))
+	}
+	return rec, gerr
+}
+
+func TestGateway_Auth(t *testing.T) {
+	h, _ := newTestGateway(t)
+
+	rec, gerr := doGateway(t, h, http.MethodGet, "/api/v1/items", "", "")
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Equal(t, codes.Unauthenticated.String(), gerr.Code)
+
+	rec, _ = doGateway(t, h, http.MethodGet, "/api/v1/items", "", "garbage")
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestGateway_SignInValidation(t *testing.T) {
+	h, _ := newTestGateway(t)
+
+	rec, gerr := doGateway(t, h, http.MethodPost, "/api/v1/users/signin", `{}`, "")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, errs.ErrRequiredArgumentIsMissing.Error(), gerr.Message)
+
+	rec, _ = doGateway(t, h, http.MethodPost, "/api/v1/users/signin", `{not json`, "")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestGateway_SignIn(t *testing.T) {
+	h, _ := newTestGateway(t)
+
+	rec, _ := doGateway(t, h, http.MethodGet, "/api/v1/crypto/public-key", "", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	var keyResp struct {
+		PublicKeyPEM string `json:"public_key_pem"`
+	}
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&keyResp))
+	block, _ := pem.Decode([]byte(keyResp.PublicKeyPEM))
+	require.NotNil(t, block)
+	pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+	require.NoError(t, err)
+
+	// What a client in any language does with the key.
+	encrypt := func(password string) string {
+		ciphertext, err := rsa.EncryptOAEP(sha256.New(), rand.Reader, pub.(*rsa.PublicKey), []byte(password), nil)
+		require.NoError(t, err)
+		return base64.StdEncoding.EncodeToString(ciphertext)
+	}
+	userBody := func(password string) string {
+		return `{"user":{"login":"alice","password":"` + password + `"}}`
+	}
+
+	rec, gerr := doGateway(t, h, http.MethodPost, "/api/v1/users/signup", userBody("secret"), "")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, errs.ErrInvalidPasswordEncoding.Error(), gerr.Message)
+
+	rec, _ = doGateway(t, h, http.MethodPost, "/api/v1/users/signup", userBody(encrypt("secret")), "")
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	rec, gerr = doGateway(t, h, http.MethodPost, "/api/v1/users/signin", userBody("secret"), "")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, errs.ErrInvalidPasswordEncoding.Error(), gerr.Message)
+
+	rec, _ = doGateway(t, h, http.MethodPost, "/api/v1/users/signin", userBody(encrypt("wrong")), "")
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	rec, _ = doGateway(t, h, http.MethodPost, "/api/v1/users/signin", userBody(encrypt("secret")), "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	var authResp struct {
+		Token string `json:"token"`
+		Salt  string `json:"salt"`
+	}
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&authResp))
+	assert.NotEmpty(t, authResp.Salt)
+
+	rec, _ = doGateway(t, h, http.MethodGet, "/api/v1/items", "", authResp.Token)
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestGateway_ListItems(t *testing.T) {
+	h, repo := newTestGateway(t)
+	token := signTestToken(t, jwt.MapClaims{"login": "alice"})
+
+	rec, _ := doGateway(t, h, http.MethodGet, "/api/v1/items?user_login=bob", "", token)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "alice", repo.login)
+
+	var resp struct {
+		Items []struct {
+			ID   string `json:"id"`
+			Name string `json:"name"`
+			Type string `json:"type"`
+		} `json:"items"`
+	}
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	require.Len(t, resp.Items, 1)
+	assert.Equal(t, "mail", resp.Items[0].Name)
+	assert.Equal(t, "ITEM_TYPE_CREDENTIALS", resp.Items[0].Type)
+
+	rec, _ = doGateway(t, h, http.MethodGet, "/api/v1/items?type=nope", "", token)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestGateway_AddItem(t *testing.T) {
+	h, repo := newTestGateway(t)
+	token := signTestToken(t, jwt.MapClaims{"login": "alice"})
+
+	rec, _ := doGateway(t, h, http.MethodPost, "/api/v1/items", `{"name":"mail","type":"ITEM_TYPE_TEXT"}`, token)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Nil(t, repo.added)
+
+	body := `{"name":"mail","type":"ITEM_TYPE_TEXT","user_login":"bob","encrypted_data":{"encrypted_content":"c","nonce":"n"}}`
+	rec, _ = doGateway(t, h, http.MethodPost, "/api/v1/items", body, token)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.NotNil(t, repo.added)
+	assert.Equal(t, "alice", repo.added.UserLogin)
+	assert.JSONEq(t, `{"success":true}`, rec.Body.String())
+}
+
+func TestGateway_DeleteItem(t *testing.T) {
+	h, _ := newTestGateway(t)
+	token := signTestToken(t, jwt.MapClaims{"login": "alice"})
+
+	rec, _ := doGateway(t, h, http.MethodDelete, "/api/v1/items/short", "", token)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	id := base64.RawURLEncoding.EncodeToString([]byte{0xfb, 0xff, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+	rec, gerr := doGateway(t, h, http.MethodDelete, "/api/v1/items/"+id, "", token)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Equal(t, errs.ErrItemNotFound.Error(), gerr.Message)
+}
+
+func TestParseGatewayID(t *testing.T) {
+	raw := []byte{0xfb, 0xff, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}
+
+	for _, s := range []string{
+		base64.StdEncoding.EncodeToString(raw),
+		base64.RawStdEncoding.EncodeToString(raw),
+		base64.URLEncoding.EncodeToString(raw),
+		base64.RawURLEncoding.EncodeToString(raw),
+	} {
+		id, err := parseGatewayID(s)
+		require.NoError(t, err, s)
+		assert.Equal(t, raw, id)
+	}
+
+	_, err := parseGatewayID(base64.StdEncoding.EncodeToString(raw[:8]))
+	assert.Error(t, err)
+}
+
+func TestHttpStatusFromCode(t *testing.T) {
+	tests := map[codes.Code]int{
+		codes.OK:                 http.StatusOK,
+		codes.InvalidArgument:    http.StatusBadRequest,
+		codes.NotFound:           http.StatusNotFound,
+		codes.AlreadyExists:      http.StatusConflict,
+		codes.PermissionDenied:   http.StatusForbidden,
+		codes.Unauthenticated:    http.StatusUnauthorized,
+		codes.FailedPrecondition: http.StatusPreconditionFailed,
+		codes.Internal:           http.StatusInternalServerError,
+	}
+	for code, want := range tests {
+		assert.Equal(t, want, httpStatusFromCode(code), code.String())
+	}
+}
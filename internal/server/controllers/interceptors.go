@@ -2,24 +2,35 @@ package controllers
 
 import (
 	"context"
+	"encoding/hex"
 	"fmt"
 	"gophkeeper/config"
+	"gophkeeper/models"
+	"net"
 	"strings"
 
 	"github.com/golang-jwt/jwt/v5"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
 )
 
-func NewAuthInterceptor(cnfg config.ServerInterceptorsConfig) grpc.UnaryServerInterceptor {
+// SessionValidator rejects tokens of locked or deleted accounts, tokens
+// issued before the account sessions were revoked and tokens of removed
+// devices.
+type SessionValidator interface {
+	ValidateSession(ctx context.Context, session models.Session) error
+}
+
+func NewAuthInterceptor(cnfg config.ServerInterceptorsConfig, sessions SessionValidator) grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
-		return AuthInterceptor(ctx, cnfg, req, info, handler)
+		return AuthInterceptor(ctx, cnfg, sessions, req, info, handler)
 	}
 }
 
-func AuthInterceptor(ctx context.Context, cnfg config.ServerInterceptorsConfig, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+func AuthInterceptor(ctx context.Context, cnfg config.ServerInterceptorsConfig, sessions SessionValidator, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
 	if isPublicMethod(info.FullMethod) {
 		return handler(ctx, req)
 	}
@@ -46,12 +57,79 @@ func AuthInterceptor(ctx context.Context, cnfg config.ServerInterceptorsConfig,
 		return nil, status.Errorf(codes.Unauthenticated, "invalid token: missing login")
 	}
 
+	deviceID, err := deviceFromClaims(claims)
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "invalid token: %v", err)
+	}
+
+	if sessions != nil {
+		session := models.Session{
+			Login:    login,
+			DeviceID: deviceID,
+			IP:       clientIP(ctx),
+		}
+		if iat, err := claims.GetIssuedAt(); err == nil && iat != nil {
+			session.IssuedAt = iat.Time
+		}
+		if err := sessions.ValidateSession(ctx, session); err != nil {
+			return nil, status.Errorf(codes.Unauthenticated, "invalid session: %v", err)
+		}
+	}
+
 	ctx = context.WithValue(ctx, "user_claims", claims)
 	ctx = context.WithValue(ctx, "login", login)
+	ctx = context.WithValue(ctx, "device_id", deviceID)
 
 	return handler(ctx, req)
 }
 
+// loginFromContext returns the login that AuthInterceptor took from the token.
+func loginFromContext(ctx context.Context) (string, error) {
+	login, ok := ctx.Value("login").(string)
+	if !ok || login == "" {
+		return "", status.Error(codes.Unauthenticated, "missing login in context")
+	}
+	return login, nil
+}
+
+// deviceFromContext returns the device the token was issued to, zero for
+// tokens without one.
+func deviceFromContext(ctx context.Context) [16]byte {
+	id, _ := ctx.Value("device_id").([16]byte)
+	return id
+}
+
+func deviceFromClaims(claims jwt.MapClaims) ([16]byte, error) {
+	var id [16]byte
+	raw, ok := claims["device_id"]
+	if !ok {
+		return id, nil
+	}
+	s, ok := raw.(string)
+	if !ok {
+		return id, fmt.Errorf("malformed device id")
+	}
+	b, err := hex.DecodeString(s)
+	if err != nil || len(b) != len(id) {
+		return id, fmt.Errorf("malformed device id")
+	}
+	copy(id[:], b)
+	return id, nil
+}
+
+// clientIP returns the address of the caller without the port.
+func clientIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
+
 func isPublicMethod(method string) bool {
 	publicMethods := []string{
 		"/users.UserController/SignUpUser",
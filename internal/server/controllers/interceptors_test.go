@@ -0,0 +1,139 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"gophkeeper/internal/errs"
+	"gophkeeper/models"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type testSecretConfig struct{}
+
+func (testSecretConfig) GetSecretKey() string { return "test-secret" }
+
+type mockSessions struct {
+	err      error
+	login    string
+	deviceID [16]byte
+	issuedAt time.Time
+}
+
+func (m *mockSessions) ValidateSession(ctx context.Context, session models.Session) error {
+	m.login = session.Login
+	m.deviceID = session.DeviceID
+	m.issuedAt = session.IssuedAt
+	return m.err
+}
+
+func signTestToken(t *testing.T, claims jwt.MapClaims) string {
+	t.Helper()
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte("test-secret"))
+	require.NoError(t, err)
+	return token
+}
+
+func TestAuthInterceptor(t *testing.T) {
+	iat := time.Now().Add(-time.Minute).Truncate(time.Second)
+	validToken := signTestToken(t, jwt.MapClaims{
+		"login": "testuser",
+		"iat":   iat.Unix(),
+		"exp":   time.Now().Add(time.Hour).Unix(),
+	})
+
+	tests := []struct {
+		name     string
+		method   string
+		token    string
+		sessions *mockSessions
+		wantCode codes.Code
+	}{
+		{name: "public method", method: "/users.UserController/SignInUser", wantCode: codes.OK},
+		{name: "missing token", method: "/items.ItemController/AddItem", wantCode: codes.Unauthenticated},
+		{name: "invalid token", method: "/items.ItemController/AddItem", token: "garbage", wantCode: codes.Unauthenticated},
+		{name: "valid token", method: "/items.ItemController/AddItem", token: validToken, wantCode: codes.OK},
+		{
+			name:     "valid session",
+			method:   "/items.ItemController/AddItem",
+			token:    validToken,
+			sessions: &mockSessions{},
+			wantCode: codes.OK,
+		},
+		{
+			name:     "revoked session",
+			method:   "/items.ItemController/AddItem",
+			token:    validToken,
+			sessions: &mockSessions{err: errs.ErrSessionRevoked},
+			wantCode: codes.Unauthenticated,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.token != "" {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", "Bearer "+tt.token))
+			} else {
+				ctx = metadata.NewIncomingContext(ctx, metadata.MD{})
+			}
+
+			var sessions SessionValidator
+			if tt.sessions != nil {
+				sessions = tt.sessions
+			}
+
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				return "ok", nil
+			}
+			_, err := AuthInterceptor(ctx, testSecretConfig{}, sessions, nil,
+				&grpc.UnaryServerInfo{FullMethod: tt.method}, handler)
+
+			assert.Equal(t, tt.wantCode, status.Code(err))
+			if tt.sessions != nil {
+				assert.Equal(t, "testuser", tt.sessions.login)
+				assert.True(t, iat.Equal(tt.sessions.issuedAt))
+			}
+		})
+	}
+}
+
+func TestAuthInterceptor_Device(t *testing.T) {
+	deviceID := [16]byte{0xde, 1}
+	call := func(t *testing.T, claims jwt.MapClaims, sessions *mockSessions) ([16]byte, error) {
+		ctx := metadata.NewIncomingContext(context.Background(),
+			metadata.Pairs("authorization", "Bearer "+signTestToken(t, claims)))
+		var got [16]byte
+		_, err := AuthInterceptor(ctx, testSecretConfig{}, sessions, nil,
+			&grpc.UnaryServerInfo{FullMethod: "/users.DeviceController/ListDevices"},
+			func(ctx context.Context, req interface{}) (interface{}, error) {
+				got = deviceFromContext(ctx)
+				return "ok", nil
+			})
+		return got, err
+	}
+
+	sessions := &mockSessions{}
+	got, err := call(t, jwt.MapClaims{"login": "alice", "device_id": "de010000000000000000000000000000"}, sessions)
+	require.NoError(t, err)
+	assert.Equal(t, deviceID, got)
+	assert.Equal(t, deviceID, sessions.deviceID)
+
+	got, err = call(t, jwt.MapClaims{"login": "alice"}, &mockSessions{})
+	require.NoError(t, err)
+	assert.Equal(t, [16]byte{}, got)
+
+	_, err = call(t, jwt.MapClaims{"login": "alice", "device_id": "xyz"}, &mockSessions{})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	_, err = call(t, jwt.MapClaims{"login": "alice", "device_id": "de01"}, &mockSessions{err: errs.ErrSessionRevoked})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
@@ -2,10 +2,12 @@ package controllers
 
 import (
 	"context"
+	"errors"
 	"gophkeeper/internal/errs"
 	pb "gophkeeper/internal/protos/items"
 	iserv "gophkeeper/internal/server/services/item_service"
 	"gophkeeper/models"
+	"time"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -28,8 +30,14 @@ func (ic *ItemController) AddItem(ctx context.Context, in *pb.AddItemRequest) (*
 	}
 
 	item := models.EncryptedItemPbToModels(in.Item)
+	if login, err := loginFromContext(ctx); err == nil {
+		item.UserLogin = login
+	}
 
 	if err := ic.service.AddItem(ctx, item); err != nil {
+		if st, ok := collectionAccessError(err); ok {
+			return nil, st
+		}
 		switch err {
 		case errs.ErrItemAlreadyExists:
 			return nil, status.Error(codes.AlreadyExists, err.Error())
@@ -48,8 +56,14 @@ func (ic *ItemController) EditItem(ctx context.Context, in *pb.EditItemRequest)
 	}
 
 	item := models.EncryptedItemPbToModels(in.Item)
+	if login, err := loginFromContext(ctx); err == nil {
+		item.UserLogin = login
+	}
 
 	if err := ic.service.EditItem(ctx, item); err != nil {
+		if st, ok := collectionAccessError(err); ok {
+			return nil, st
+		}
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 	return &pb.EditItemResponse{
@@ -58,8 +72,29 @@ func (ic *ItemController) EditItem(ctx context.Context, in *pb.EditItemRequest)
 
 }
 
+// isPbItemValid requires a plaintext name only for V1 items, V2 items keep it
+// encrypted with the data and V3 items in a header.
 func isPbItemValid(i *pb.EncryptedItem) bool {
-	return i.Name != "" && i.Type.String() != "" && i.UserLogin != "" && i.EncryptedData.EncryptedContent != "" && i.EncryptedData.Nonce != ""
+	if i == nil || i.EncryptedData == nil {
+		return false
+	}
+	switch models.FormatPbToModels(i.Format) {
+	case models.ItemFormatV1:
+		if i.Name == "" {
+			return false
+		}
+	case models.ItemFormatV2:
+	case models.ItemFormatV3:
+		if i.EncryptedHeader == nil || i.EncryptedHeader.EncryptedContent == "" || i.EncryptedHeader.Nonce == "" {
+			return false
+		}
+	default:
+		return false
+	}
+	if i.ExpiresAt != nil && !i.ExpiresAt.AsTime().After(time.Now()) {
+		return false
+	}
+	return i.Type.String() != "" && i.UserLogin != "" && i.EncryptedData.EncryptedContent != "" && i.EncryptedData.Nonce != ""
 }
 
 func (ic *ItemController) DeleteItem(ctx context.Context, in *pb.DeleteItemRequest) (*pb.DeleteItemResponse, error) {
@@ -67,8 +102,16 @@ func (ic *ItemController) DeleteItem(ctx context.Context, in *pb.DeleteItemReque
 		return nil, status.Error(codes.InvalidArgument, errs.ErrRequiredArgumentIsMissing.Error())
 	}
 
-	err := ic.service.DeleteItem(ctx, in.UserLogin, models.ItemIdPbToModels(in.ItemId))
+	login := in.UserLogin
+	if ctxLogin, err := loginFromContext(ctx); err == nil {
+		login = ctxLogin
+	}
+
+	err := ic.service.DeleteItem(ctx, login, models.ItemIdPbToModels(in.ItemId))
 	if err != nil {
+		if st, ok := collectionAccessError(err); ok {
+			return nil, st
+		}
 		switch err {
 		case errs.ErrUserNotFound:
 			return nil, status.Error(codes.NotFound, err.Error())
@@ -85,8 +128,21 @@ func (ic *ItemController) DeleteItem(ctx context.Context, in *pb.DeleteItemReque
 }
 
 func (ic *ItemController) GetUserItems(ctx context.Context, in *pb.GetUserItemsRequest) (*pb.GetUserItemsResponse, error) {
-	items, err := ic.service.GetUserItems(ctx, models.ItemTypePbToModel(in.Type), in.UserLogin)
+	var items []models.EncryptedItem
+	var err error
+	if len(in.CollectionId) > 0 {
+		login, lerr := loginFromContext(ctx)
+		if lerr != nil {
+			return nil, lerr
+		}
+		items, err = ic.service.GetCollectionItems(ctx, models.ItemTypePbToModel(in.Type), login, models.ItemIdPbToModels(in.CollectionId))
+	} else {
+		items, err = ic.service.GetUserItems(ctx, models.ItemTypePbToModel(in.Type), in.UserLogin)
+	}
 	if err != nil {
+		if st, ok := collectionAccessError(err); ok {
+			return nil, st
+		}
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
@@ -104,9 +160,91 @@ func (ic *ItemController) GetUserItems(ctx context.Context, in *pb.GetUserItemsR
 	}, nil
 }
 
+func (ic *ItemController) SearchItems(ctx context.Context, in *pb.SearchItemsRequest) (*pb.SearchItemsResponse, error) {
+	login, err := loginFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(in.Tokens) == 0 {
+		return nil, status.Error(codes.InvalidArgument, errs.ErrRequiredArgumentIsMissing.Error())
+	}
+
+	items, err := ic.service.SearchItems(ctx, login, in.Tokens)
+	if err != nil {
+		return nil, status.Error(codes.Internal, errs.ErrInternalServerError.Error())
+	}
+
+	pbItems := make([]*pb.EncryptedItem, len(items))
+	for i, item := range items {
+		pbItem, err := item.ToPb()
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		pbItems[i] = pbItem
+	}
+
+	return &pb.SearchItemsResponse{
+		Items: pbItems,
+	}, nil
+}
+
+func (ic *ItemController) TouchItem(ctx context.Context, in *pb.TouchItemRequest) (*pb.TouchItemResponse, error) {
+	login, err := loginFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(in.ItemId) != 16 {
+		return nil, status.Error(codes.InvalidArgument, errs.ErrRequiredArgumentIsMissing.Error())
+	}
+
+	if err := ic.service.TouchItem(ctx, login, models.ItemIdPbToModels(in.ItemId)); err != nil {
+		return nil, itemUsageErrorToStatus(err)
+	}
+	return &pb.TouchItemResponse{
+		Success: true,
+	}, nil
+}
+
+func (ic *ItemController) SetItemFavorite(ctx context.Context, in *pb.SetItemFavoriteRequest) (*pb.SetItemFavoriteResponse, error) {
+	login, err := loginFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(in.ItemId) != 16 {
+		return nil, status.Error(codes.InvalidArgument, errs.ErrRequiredArgumentIsMissing.Error())
+	}
+
+	if err := ic.service.SetItemFavorite(ctx, login, models.ItemIdPbToModels(in.ItemId), in.Favorite); err != nil {
+		return nil, itemUsageErrorToStatus(err)
+	}
+	return &pb.SetItemFavoriteResponse{
+		Success: true,
+	}, nil
+}
+
+func itemUsageErrorToStatus(err error) error {
+	if errors.Is(err, errs.ErrItemNotFound) {
+		return status.Error(codes.NotFound, err.Error())
+	}
+	return status.Error(codes.Internal, errs.ErrInternalServerError.Error())
+}
+
 func (ic *ItemController) TypesCounts(ctx context.Context, in *pb.TypesCountsRequest) (*pb.TypesCountsResponse, error) {
-	counters, err := ic.service.GetTypesCounts(ctx, in.UserLogin)
+	var counters map[models.ItemType]int32
+	var err error
+	if len(in.CollectionId) > 0 {
+		login, lerr := loginFromContext(ctx)
+		if lerr != nil {
+			return nil, lerr
+		}
+		counters, err = ic.service.GetCollectionTypesCounts(ctx, login, models.ItemIdPbToModels(in.CollectionId))
+	} else {
+		counters, err = ic.service.GetTypesCounts(ctx, in.UserLogin)
+	}
 	if err != nil {
+		if st, ok := collectionAccessError(err); ok {
+			return nil, st
+		}
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
@@ -120,3 +258,11 @@ func (ic *ItemController) TypesCounts(ctx context.Context, in *pb.TypesCountsReq
 		Types: pbCounters,
 	}, nil
 }
+
+// collectionAccessError maps organization role errors of collection items.
+func collectionAccessError(err error) (error, bool) {
+	if errors.Is(err, errs.ErrNotOrgMember) || errors.Is(err, errs.ErrOrgPermissionDenied) {
+		return status.Error(codes.PermissionDenied, err.Error()), true
+	}
+	return nil, false
+}
@@ -3,11 +3,16 @@ package controllers
 import (
 	"context"
 	"testing"
+	"time"
 
 	pb "gophkeeper/internal/protos/items"
 	iserv "gophkeeper/internal/server/services/item_service"
+	"gophkeeper/models"
 
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func TestNewItemController(t *testing.T) {
@@ -195,6 +200,91 @@ func TestIsPbItemValid(t *testing.T) {
 			},
 			expected: false,
 		},
+		{
+			name: "valid - encrypted name",
+			item: &pb.EncryptedItem{
+				Type:      pb.ItemType_ITEM_TYPE_CREDENTIALS,
+				UserLogin: "user",
+				EncryptedData: &pb.EncryptedData{
+					EncryptedContent: "content",
+					Nonce:            "nonce",
+				},
+				Format: uint32(models.ItemFormatV2),
+			},
+			expected: true,
+		},
+		{
+			name: "valid - sealed header",
+			item: &pb.EncryptedItem{
+				Type:      pb.ItemType_ITEM_TYPE_CREDENTIALS,
+				UserLogin: "user",
+				EncryptedData: &pb.EncryptedData{
+					EncryptedContent: "content",
+					Nonce:            "nonce",
+				},
+				EncryptedHeader: &pb.EncryptedData{
+					EncryptedContent: "header",
+					Nonce:            "header_nonce",
+				},
+				Format: uint32(models.ItemFormatV3),
+			},
+			expected: true,
+		},
+		{
+			name: "invalid - header format without header",
+			item: &pb.EncryptedItem{
+				Type:      pb.ItemType_ITEM_TYPE_CREDENTIALS,
+				UserLogin: "user",
+				EncryptedData: &pb.EncryptedData{
+					EncryptedContent: "content",
+					Nonce:            "nonce",
+				},
+				Format: uint32(models.ItemFormatV3),
+			},
+			expected: false,
+		},
+		{
+			name: "invalid - unknown format",
+			item: &pb.EncryptedItem{
+				Name:      "test",
+				Type:      pb.ItemType_ITEM_TYPE_CREDENTIALS,
+				UserLogin: "user",
+				EncryptedData: &pb.EncryptedData{
+					EncryptedContent: "content",
+					Nonce:            "nonce",
+				},
+				Format: 7,
+			},
+			expected: false,
+		},
+		{
+			name: "valid - expires in the future",
+			item: &pb.EncryptedItem{
+				Name:      "test",
+				Type:      pb.ItemType_ITEM_TYPE_CREDENTIALS,
+				UserLogin: "user",
+				EncryptedData: &pb.EncryptedData{
+					EncryptedContent: "content",
+					Nonce:            "nonce",
+				},
+				ExpiresAt: timestamppb.New(time.Now().Add(time.Hour)),
+			},
+			expected: true,
+		},
+		{
+			name: "invalid - already expired",
+			item: &pb.EncryptedItem{
+				Name:      "test",
+				Type:      pb.ItemType_ITEM_TYPE_CREDENTIALS,
+				UserLogin: "user",
+				EncryptedData: &pb.EncryptedData{
+					EncryptedContent: "content",
+					Nonce:            "nonce",
+				},
+				ExpiresAt: timestamppb.New(time.Now().Add(-time.Minute)),
+			},
+			expected: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -204,3 +294,29 @@ func TestIsPbItemValid(t *testing.T) {
 		})
 	}
 }
+
+func TestItemController_SearchItems_Validation(t *testing.T) {
+	controller := NewItemController(&iserv.ItemService{})
+
+	_, err := controller.SearchItems(context.Background(), &pb.SearchItemsRequest{Tokens: []string{"t"}})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	ctx := context.WithValue(context.Background(), "login", "alice")
+	_, err = controller.SearchItems(ctx, &pb.SearchItemsRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestItemController_ItemUsage_Validation(t *testing.T) {
+	controller := NewItemController(&iserv.ItemService{})
+
+	_, err := controller.TouchItem(context.Background(), &pb.TouchItemRequest{ItemId: make([]byte, 16)})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	_, err = controller.SetItemFavorite(context.Background(), &pb.SetItemFavoriteRequest{ItemId: make([]byte, 16)})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	ctx := context.WithValue(context.Background(), "login", "alice")
+	_, err = controller.TouchItem(ctx, &pb.TouchItemRequest{ItemId: []byte{1}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = controller.SetItemFavorite(ctx, &pb.SetItemFavoriteRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
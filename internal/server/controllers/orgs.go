@@ -0,0 +1,265 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"gophkeeper/internal/errs"
+	pb "gophkeeper/internal/protos/orgs"
+	oserv "gophkeeper/internal/server/services/org_service"
+	"gophkeeper/models"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type OrgController struct {
+	pb.UnimplementedOrgControllerServer
+	service *oserv.OrgService
+}
+
+func NewOrgController(service *oserv.OrgService) *OrgController {
+	return &OrgController{
+		service: service,
+	}
+}
+
+func (oc *OrgController) CreateOrg(ctx context.Context, in *pb.CreateOrgRequest) (*pb.CreateOrgResponse, error) {
+	login, err := loginFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if in.Name == "" || in.WrappedKey == "" {
+		return nil, status.Error(codes.InvalidArgument, errs.ErrRequiredArgumentIsMissing.Error())
+	}
+
+	org := &models.Organization{
+		Name:       in.Name,
+		WrappedKey: in.WrappedKey,
+	}
+	var coll *models.Collection
+	if in.Collection != nil {
+		coll = models.CollectionPbToModels(in.Collection)
+	}
+
+	if err := oc.service.CreateOrg(ctx, login, org, coll); err != nil {
+		return nil, orgErrorToStatus(err)
+	}
+	return &pb.CreateOrgResponse{
+		Org: org.ToPb(),
+	}, nil
+}
+
+func (oc *OrgController) ListOrgs(ctx context.Context, in *pb.ListOrgsRequest) (*pb.ListOrgsResponse, error) {
+	login, err := loginFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	orgs, err := oc.service.ListOrgs(ctx, login)
+	if err != nil {
+		return nil, orgErrorToStatus(err)
+	}
+
+	pbOrgs := make([]*pb.Organization, len(orgs))
+	for i := range orgs {
+		pbOrgs[i] = orgs[i].ToPb()
+	}
+	return &pb.ListOrgsResponse{
+		Orgs: pbOrgs,
+	}, nil
+}
+
+func (oc *OrgController) InviteMember(ctx context.Context, in *pb.InviteMemberRequest) (*pb.InviteMemberResponse, error) {
+	login, err := loginFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if in.Member == nil || in.Member.OrgId == nil || in.Member.Login == "" || in.Member.WrappedKey == "" {
+		return nil, status.Error(codes.InvalidArgument, errs.ErrRequiredArgumentIsMissing.Error())
+	}
+
+	if err := oc.service.InviteMember(ctx, login, models.MembershipPbToModels(in.Member)); err != nil {
+		return nil, orgErrorToStatus(err)
+	}
+	return &pb.InviteMemberResponse{
+		Success: true,
+	}, nil
+}
+
+func (oc *OrgController) AcceptInvite(ctx context.Context, in *pb.AcceptInviteRequest) (*pb.AcceptInviteResponse, error) {
+	login, err := loginFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if in.OrgId == nil {
+		return nil, status.Error(codes.InvalidArgument, errs.ErrRequiredArgumentIsMissing.Error())
+	}
+
+	if err := oc.service.AcceptInvite(ctx, login, models.ItemIdPbToModels(in.OrgId)); err != nil {
+		return nil, orgErrorToStatus(err)
+	}
+	return &pb.AcceptInviteResponse{
+		Success: true,
+	}, nil
+}
+
+func (oc *OrgController) RemoveMember(ctx context.Context, in *pb.RemoveMemberRequest) (*pb.RemoveMemberResponse, error) {
+	login, err := loginFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if in.OrgId == nil || in.Login == "" {
+		return nil, status.Error(codes.InvalidArgument, errs.ErrRequiredArgumentIsMissing.Error())
+	}
+
+	rot := orgKeyRotationPbToModels(in.OrgId, in.Members, in.Collections, in.Items, in.Attachments)
+	rot.Login = in.Login
+
+	if err := oc.service.RemoveMember(ctx, login, rot); err != nil {
+		return nil, orgErrorToStatus(err)
+	}
+	return &pb.RemoveMemberResponse{
+		Success: true,
+	}, nil
+}
+
+func (oc *OrgController) RotateOrgKey(ctx context.Context, in *pb.RotateOrgKeyRequest) (*pb.RotateOrgKeyResponse, error) {
+	login, err := loginFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if in.OrgId == nil {
+		return nil, status.Error(codes.InvalidArgument, errs.ErrRequiredArgumentIsMissing.Error())
+	}
+
+	rot := orgKeyRotationPbToModels(in.OrgId, in.Members, in.Collections, in.Items, in.Attachments)
+	if err := oc.service.RotateOrgKey(ctx, login, rot); err != nil {
+		return nil, orgErrorToStatus(err)
+	}
+	return &pb.RotateOrgKeyResponse{
+		Success: true,
+	}, nil
+}
+
+func orgKeyRotationPbToModels(orgID []byte, members []*pb.Membership, collections []*pb.Collection,
+	items []*pb.RekeyedItem, attachments []*pb.RekeyedAttachment) *models.OrgKeyRotation {
+	rot := &models.OrgKeyRotation{
+		OrgID:       models.ItemIdPbToModels(orgID),
+		Members:     make([]models.Membership, len(members)),
+		Collections: make([]models.Collection, len(collections)),
+		Items:       make([]models.EncryptedItem, len(items)),
+		Attachments: make([]models.Attachment, len(attachments)),
+	}
+	for i, m := range members {
+		rot.Members[i] = *models.MembershipPbToModels(m)
+	}
+	for i, c := range collections {
+		rot.Collections[i] = *models.CollectionPbToModels(c)
+	}
+	for i, item := range items {
+		rot.Items[i] = *models.RekeyedItemPbToModels(item)
+	}
+	for i, a := range attachments {
+		rot.Attachments[i] = *models.RekeyedAttachmentPbToModels(a)
+	}
+	return rot
+}
+
+func (oc *OrgController) ChangeRole(ctx context.Context, in *pb.ChangeRoleRequest) (*pb.ChangeRoleResponse, error) {
+	login, err := loginFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if in.OrgId == nil || in.Login == "" {
+		return nil, status.Error(codes.InvalidArgument, errs.ErrRequiredArgumentIsMissing.Error())
+	}
+
+	err = oc.service.ChangeRole(ctx, login, models.ItemIdPbToModels(in.OrgId), in.Login, models.OrgRolePbToModels(in.Role))
+	if err != nil {
+		return nil, orgErrorToStatus(err)
+	}
+	return &pb.ChangeRoleResponse{
+		Success: true,
+	}, nil
+}
+
+func (oc *OrgController) ListMembers(ctx context.Context, in *pb.ListMembersRequest) (*pb.ListMembersResponse, error) {
+	login, err := loginFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if in.OrgId == nil {
+		return nil, status.Error(codes.InvalidArgument, errs.ErrRequiredArgumentIsMissing.Error())
+	}
+
+	members, err := oc.service.ListMembers(ctx, login, models.ItemIdPbToModels(in.OrgId))
+	if err != nil {
+		return nil, orgErrorToStatus(err)
+	}
+
+	pbMembers := make([]*pb.Membership, len(members))
+	for i := range members {
+		pbMembers[i] = members[i].ToPb()
+	}
+	return &pb.ListMembersResponse{
+		Members: pbMembers,
+	}, nil
+}
+
+func (oc *OrgController) CreateCollection(ctx context.Context, in *pb.CreateCollectionRequest) (*pb.CreateCollectionResponse, error) {
+	login, err := loginFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if in.Collection == nil || in.Collection.OrgId == nil {
+		return nil, status.Error(codes.InvalidArgument, errs.ErrRequiredArgumentIsMissing.Error())
+	}
+
+	coll := models.CollectionPbToModels(in.Collection)
+	if err := oc.service.CreateCollection(ctx, login, coll); err != nil {
+		return nil, orgErrorToStatus(err)
+	}
+	return &pb.CreateCollectionResponse{
+		Collection: coll.ToPb(),
+	}, nil
+}
+
+func (oc *OrgController) ListCollections(ctx context.Context, in *pb.ListCollectionsRequest) (*pb.ListCollectionsResponse, error) {
+	login, err := loginFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if in.OrgId == nil {
+		return nil, status.Error(codes.InvalidArgument, errs.ErrRequiredArgumentIsMissing.Error())
+	}
+
+	colls, err := oc.service.ListCollections(ctx, login, models.ItemIdPbToModels(in.OrgId))
+	if err != nil {
+		return nil, orgErrorToStatus(err)
+	}
+
+	pbColls := make([]*pb.Collection, len(colls))
+	for i := range colls {
+		pbColls[i] = colls[i].ToPb()
+	}
+	return &pb.ListCollectionsResponse{
+		Collections: pbColls,
+	}, nil
+}
+
+func orgErrorToStatus(err error) error {
+	switch {
+	case errors.Is(err, errs.ErrInviteNotFound), errors.Is(err, errs.ErrKeysNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, errs.ErrMemberAlreadyExists):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, errs.ErrNotOrgMember), errors.Is(err, errs.ErrOrgPermissionDenied), errors.Is(err, errs.ErrOwnerImmutable):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, errs.ErrInvalidOrgRole), errors.Is(err, errs.ErrRequiredArgumentIsMissing):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, errs.ErrOrgKeysMismatch):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		return status.Error(codes.Internal, errs.ErrInternalServerError.Error())
+	}
+}
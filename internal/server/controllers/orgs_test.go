@@ -0,0 +1,64 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"gophkeeper/internal/errs"
+	pb "gophkeeper/internal/protos/orgs"
+	oserv "gophkeeper/internal/server/services/org_service"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestOrgController_RequiresLogin(t *testing.T) {
+	controller := NewOrgController(&oserv.OrgService{})
+
+	_, err := controller.ListOrgs(context.Background(), &pb.ListOrgsRequest{})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestOrgController_Validation(t *testing.T) {
+	controller := NewOrgController(&oserv.OrgService{})
+	ctx := context.WithValue(context.Background(), "login", "alice")
+
+	_, err := controller.CreateOrg(ctx, &pb.CreateOrgRequest{Name: "acme"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = controller.InviteMember(ctx, &pb.InviteMemberRequest{Member: &pb.Membership{OrgId: []byte{1}, Login: "bob"}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = controller.RemoveMember(ctx, &pb.RemoveMemberRequest{OrgId: []byte{1}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = controller.RotateOrgKey(ctx, &pb.RotateOrgKeyRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = controller.ListCollections(ctx, &pb.ListCollectionsRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestOrgErrorToStatus(t *testing.T) {
+	tests := []struct {
+		err  error
+		want codes.Code
+	}{
+		{errs.ErrInviteNotFound, codes.NotFound},
+		{errs.ErrMemberAlreadyExists, codes.AlreadyExists},
+		{fmt.Errorf("wrapped: %w", errs.ErrOrgPermissionDenied), codes.PermissionDenied},
+		{errs.ErrNotOrgMember, codes.PermissionDenied},
+		{errs.ErrOwnerImmutable, codes.PermissionDenied},
+		{errs.ErrInvalidOrgRole, codes.InvalidArgument},
+		{errs.ErrOrgKeysMismatch, codes.FailedPrecondition},
+		{fmt.Errorf("db down"), codes.Internal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.err.Error(), func(t *testing.T) {
+			assert.Equal(t, tt.want, status.Code(orgErrorToStatus(tt.err)))
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package controllers
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"gophkeeper/internal/errs"
+	pb "gophkeeper/internal/protos/items"
+	sdserv "gophkeeper/internal/server/services/send_service"
+	"gophkeeper/models"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type SendController struct {
+	pb.UnimplementedSendsControllerServer
+	service *sdserv.SendService
+	baseURL string
+}
+
+func NewSendController(service *sdserv.SendService, baseURL string) *SendController {
+	return &SendController{
+		service: service,
+		baseURL: baseURL,
+	}
+}
+
+func (sc *SendController) CreateSend(ctx context.Context, in *pb.CreateSendRequest) (*pb.CreateSendResponse, error) {
+	login, err := loginFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if in.EncryptedData == nil || in.ExpiresAt == nil {
+		return nil, status.Error(codes.InvalidArgument, errs.ErrRequiredArgumentIsMissing.Error())
+	}
+
+	id, err := sc.service.CreateSend(ctx, login, models.SendPbToModels(in))
+	if err != nil {
+		return nil, sendErrorToStatus(err)
+	}
+	return &pb.CreateSendResponse{
+		Id:  id[:],
+		Url: sc.baseURL + sendPathPrefix + hex.EncodeToString(id[:]),
+	}, nil
+}
+
+func sendErrorToStatus(err error) error {
+	switch {
+	case errors.Is(err, errs.ErrSendNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, errs.ErrRequiredArgumentIsMissing), errors.Is(err, errs.ErrInvalidSendViews), errors.Is(err, errs.ErrInvalidSendExpiry):
+		return status.Error(codes.InvalidArgument, err.Error())
+	default:
+		return status.Error(codes.Internal, errs.ErrInternalServerError.Error())
+	}
+}
@@ -0,0 +1,58 @@
+package controllers
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"gophkeeper/internal/errs"
+	"gophkeeper/internal/logger"
+	sdserv "gophkeeper/internal/server/services/send_service"
+	"net/http"
+
+	"go.uber.org/zap"
+)
+
+const sendPathPrefix = "/send/"
+
+// SendView is what the recipient of a link downloads. It holds only
+// ciphertext, the key never leaves the URL fragment.
+type SendView struct {
+	EncryptedContent string `json:"encrypted_content"`
+	Nonce            string `json:"nonce"`
+	ViewsLeft        int32  `json:"views_left"`
+}
+
+// NewSendHandler serves one-time secret links to people without an account.
+// Every successful GET spends a view.
+func NewSendHandler(service *sdserv.SendService) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET "+sendPathPrefix+"{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cache-Control", "no-store")
+		w.Header().Set("Referrer-Policy", "no-referrer")
+
+		raw, err := hex.DecodeString(r.PathValue("id"))
+		if err != nil || len(raw) != 16 {
+			http.Error(w, errs.ErrSendNotFound.Error(), http.StatusNotFound)
+			return
+		}
+
+		send, err := service.OpenSend(r.Context(), [16]byte(raw))
+		if errors.Is(err, errs.ErrSendNotFound) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		if err != nil {
+			logger.Log.Error("Open send error", zap.Error(err))
+			http.Error(w, errs.ErrInternalServerError.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(SendView{
+			EncryptedContent: send.EncryptedData.EncryptedContent,
+			Nonce:            send.EncryptedData.Nonce,
+			ViewsLeft:        send.ViewsLeft(),
+		})
+	})
+	return mux
+}
@@ -0,0 +1,112 @@
+package controllers
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"gophkeeper/internal/errs"
+	pb "gophkeeper/internal/protos/items"
+	"gophkeeper/internal/server/repositories"
+	sdserv "gophkeeper/internal/server/services/send_service"
+	"gophkeeper/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type sendStorage struct {
+	repositories.Storage
+	sends map[[16]byte]*models.Send
+}
+
+func (s *sendStorage) CreateSend(ctx context.Context, send *models.Send) ([16]byte, error) {
+	id := [16]byte{0xab, byte(len(s.sends) + 1)}
+	stored := *send
+	s.sends[id] = &stored
+	return id, nil
+}
+
+func (s *sendStorage) OpenSend(ctx context.Context, id [16]byte) (*models.Send, error) {
+	send, ok := s.sends[id]
+	if !ok {
+		return nil, errs.ErrSendNotFound
+	}
+	send.Views++
+	if send.ViewsLeft() == 0 {
+		delete(s.sends, id)
+	}
+	opened := *send
+	return &opened, nil
+}
+
+func newTestSendService(t *testing.T) *sdserv.SendService {
+	ss, err := sdserv.NewSendService(&sendStorage{sends: make(map[[16]byte]*models.Send)})
+	require.NoError(t, err)
+	return ss
+}
+
+func TestSendController_CreateSend(t *testing.T) {
+	controller := NewSendController(newTestSendService(t), "https://keeper.example.com")
+
+	_, err := controller.CreateSend(context.Background(), &pb.CreateSendRequest{})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	ctx := context.WithValue(context.Background(), "login", "alice")
+	_, err = controller.CreateSend(ctx, &pb.CreateSendRequest{MaxViews: 1})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	req := &pb.CreateSendRequest{
+		EncryptedData: &pb.EncryptedData{EncryptedContent: "content", Nonce: "nonce"},
+		MaxViews:      0,
+		ExpiresAt:     timestamppb.New(time.Now().Add(time.Hour)),
+	}
+	_, err = controller.CreateSend(ctx, req)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	req.MaxViews = 1
+	resp, err := controller.CreateSend(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, "https://keeper.example.com/send/"+hex.EncodeToString(resp.Id), resp.Url)
+}
+
+func TestSendHandler_BurnsAfterLastView(t *testing.T) {
+	ss := newTestSendService(t)
+	id, err := ss.CreateSend(context.Background(), "alice", &models.Send{
+		EncryptedData: models.EncryptedData{EncryptedContent: "content", Nonce: "nonce"},
+		MaxViews:      1,
+		ExpiresAt:     time.Now().Add(time.Hour),
+	})
+	require.NoError(t, err)
+
+	handler := NewSendHandler(ss)
+	path := "/send/" + hex.EncodeToString(id[:])
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "no-store", rec.Header().Get("Cache-Control"))
+
+	var view SendView
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&view))
+	assert.Equal(t, SendView{EncryptedContent: "content", Nonce: "nonce", ViewsLeft: 0}, view)
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/send/not-hex", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, path, nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
@@ -0,0 +1,187 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"gophkeeper/internal/errs"
+	pb "gophkeeper/internal/protos/items"
+	sserv "gophkeeper/internal/server/services/share_service"
+	"gophkeeper/models"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type ShareController struct {
+	pb.UnimplementedSharesControllerServer
+	service *sserv.ShareService
+}
+
+func NewShareController(service *sserv.ShareService) *ShareController {
+	return &ShareController{
+		service: service,
+	}
+}
+
+func (sc *ShareController) SetUserKeys(ctx context.Context, in *pb.SetUserKeysRequest) (*pb.SetUserKeysResponse, error) {
+	login, err := loginFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if in.Keys == nil {
+		return nil, status.Error(codes.InvalidArgument, errs.ErrRequiredArgumentIsMissing.Error())
+	}
+
+	keys := models.UserKeysPbToModels(in.Keys)
+	keys.Login = login
+	if err := sc.service.SetUserKeys(ctx, keys); err != nil {
+		return nil, shareErrorToStatus(err)
+	}
+	return &pb.SetUserKeysResponse{
+		Success: true,
+	}, nil
+}
+
+func (sc *ShareController) GetUserKeys(ctx context.Context, in *pb.GetUserKeysRequest) (*pb.GetUserKeysResponse, error) {
+	login, err := loginFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	keys, err := sc.service.GetUserKeys(ctx, login)
+	if err != nil {
+		return nil, shareErrorToStatus(err)
+	}
+	return &pb.GetUserKeysResponse{
+		Keys: keys.ToPb(),
+	}, nil
+}
+
+func (sc *ShareController) GetPublicKey(ctx context.Context, in *pb.GetPublicKeyRequest) (*pb.GetPublicKeyResponse, error) {
+	if in.Login == "" {
+		return nil, status.Error(codes.InvalidArgument, errs.ErrRequiredArgumentIsMissing.Error())
+	}
+
+	pub, err := sc.service.GetPublicKey(ctx, in.Login)
+	if err != nil {
+		return nil, shareErrorToStatus(err)
+	}
+	return &pb.GetPublicKeyResponse{
+		PublicKey: pub,
+	}, nil
+}
+
+func (sc *ShareController) ShareItem(ctx context.Context, in *pb.ShareItemRequest) (*pb.ShareItemResponse, error) {
+	login, err := loginFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if in.Share == nil || in.Share.ItemId == nil || in.Share.RecipientLogin == "" || in.Share.WrappedKey == "" {
+		return nil, status.Error(codes.InvalidArgument, errs.ErrRequiredArgumentIsMissing.Error())
+	}
+
+	if err := sc.service.ShareItem(ctx, login, models.ItemSharePbToModels(in.Share)); err != nil {
+		return nil, shareErrorToStatus(err)
+	}
+	return &pb.ShareItemResponse{
+		Success: true,
+	}, nil
+}
+
+func (sc *ShareController) ListItemShares(ctx context.Context, in *pb.ListItemSharesRequest) (*pb.ListItemSharesResponse, error) {
+	login, err := loginFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if in.ItemId == nil {
+		return nil, status.Error(codes.InvalidArgument, errs.ErrRequiredArgumentIsMissing.Error())
+	}
+
+	shares, err := sc.service.ListItemShares(ctx, login, models.ItemIdPbToModels(in.ItemId))
+	if err != nil {
+		return nil, shareErrorToStatus(err)
+	}
+
+	pbShares := make([]*pb.ItemShare, len(shares))
+	for i := range shares {
+		pbShares[i] = shares[i].ToPb()
+	}
+	return &pb.ListItemSharesResponse{
+		Shares: pbShares,
+	}, nil
+}
+
+func (sc *ShareController) ListSharedWithMe(ctx context.Context, in *pb.ListSharedWithMeRequest) (*pb.ListSharedWithMeResponse, error) {
+	login, err := loginFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := sc.service.ListSharedWithMe(ctx, login)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	pbItems := make([]*pb.SharedItem, len(items))
+	for i := range items {
+		pbItem, err := items[i].ToPb()
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		pbItems[i] = pbItem
+	}
+	return &pb.ListSharedWithMeResponse{
+		Items: pbItems,
+	}, nil
+}
+
+func (sc *ShareController) RevokeShare(ctx context.Context, in *pb.RevokeShareRequest) (*pb.RevokeShareResponse, error) {
+	login, err := loginFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if in.ItemId == nil || in.RecipientLogin == "" || in.Item == nil || in.Item.EncryptedData == nil || in.Item.EncryptedKey == "" {
+		return nil, status.Error(codes.InvalidArgument, errs.ErrRequiredArgumentIsMissing.Error())
+	}
+
+	rev := &models.ShareRevocation{
+		ItemID:         models.ItemIdPbToModels(in.ItemId),
+		RecipientLogin: in.RecipientLogin,
+		Item:           *models.EncryptedItemPbToModels(in.Item),
+		Shares:         make([]models.ItemShare, len(in.Shares)),
+		Attachments:    make([]models.Attachment, len(in.Attachments)),
+	}
+	for i, s := range in.Shares {
+		rev.Shares[i] = *models.ItemSharePbToModels(s)
+	}
+	for i, a := range in.Attachments {
+		if a.EncryptedInfo == nil {
+			return nil, status.Error(codes.InvalidArgument, errs.ErrRequiredArgumentIsMissing.Error())
+		}
+		rev.Attachments[i] = *models.AttachmentPbToModels(a)
+	}
+
+	if err := sc.service.RevokeShare(ctx, login, rev); err != nil {
+		return nil, shareErrorToStatus(err)
+	}
+	return &pb.RevokeShareResponse{
+		Success: true,
+	}, nil
+}
+
+func shareErrorToStatus(err error) error {
+	switch {
+	case errors.Is(err, errs.ErrKeysNotFound), errors.Is(err, errs.ErrItemNotFound), errors.Is(err, errs.ErrShareNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, errs.ErrKeysAlreadyExist):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, errs.ErrNotItemOwner):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, errs.ErrShareWithSelf), errors.Is(err, errs.ErrRequiredArgumentIsMissing):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, errs.ErrShareKeysMismatch), errors.Is(err, errs.ErrAttachmentsChanged):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		return status.Error(codes.Internal, errs.ErrInternalServerError.Error())
+	}
+}
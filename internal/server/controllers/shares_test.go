@@ -0,0 +1,57 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"gophkeeper/internal/errs"
+	pb "gophkeeper/internal/protos/items"
+	sserv "gophkeeper/internal/server/services/share_service"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestShareController_RequiresLogin(t *testing.T) {
+	controller := NewShareController(&sserv.ShareService{})
+
+	_, err := controller.ListSharedWithMe(context.Background(), &pb.ListSharedWithMeRequest{})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestShareController_Validation(t *testing.T) {
+	controller := NewShareController(&sserv.ShareService{})
+	ctx := context.WithValue(context.Background(), "login", "alice")
+
+	_, err := controller.ShareItem(ctx, &pb.ShareItemRequest{Share: &pb.ItemShare{ItemId: []byte{1}}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = controller.RevokeShare(ctx, &pb.RevokeShareRequest{ItemId: []byte{1}, RecipientLogin: "bob"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = controller.GetPublicKey(ctx, &pb.GetPublicKeyRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestShareErrorToStatus(t *testing.T) {
+	tests := []struct {
+		err  error
+		want codes.Code
+	}{
+		{errs.ErrKeysNotFound, codes.NotFound},
+		{fmt.Errorf("wrapped: %w", errs.ErrShareNotFound), codes.NotFound},
+		{errs.ErrKeysAlreadyExist, codes.AlreadyExists},
+		{errs.ErrNotItemOwner, codes.PermissionDenied},
+		{errs.ErrShareWithSelf, codes.InvalidArgument},
+		{errs.ErrShareKeysMismatch, codes.FailedPrecondition},
+		{fmt.Errorf("db down"), codes.Internal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.err.Error(), func(t *testing.T) {
+			assert.Equal(t, tt.want, status.Code(shareErrorToStatus(tt.err)))
+		})
+	}
+}
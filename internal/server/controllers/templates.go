@@ -0,0 +1,75 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"gophkeeper/internal/errs"
+	pb "gophkeeper/internal/protos/items"
+	"gophkeeper/models"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (ic *ItemController) SaveTemplate(ctx context.Context, in *pb.SaveTemplateRequest) (*pb.SaveTemplateResponse, error) {
+	login, err := loginFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if in.Template == nil || len(in.Template.Id) != 16 || in.Template.EncryptedData == nil {
+		return nil, status.Error(codes.InvalidArgument, errs.ErrRequiredArgumentIsMissing.Error())
+	}
+
+	if err := ic.service.SaveTemplate(ctx, login, models.TemplatePbToModels(in.Template)); err != nil {
+		return nil, templateErrorToStatus(err)
+	}
+	return &pb.SaveTemplateResponse{
+		Success: true,
+	}, nil
+}
+
+func (ic *ItemController) ListTemplates(ctx context.Context, in *pb.ListTemplatesRequest) (*pb.ListTemplatesResponse, error) {
+	login, err := loginFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	templates, err := ic.service.ListTemplates(ctx, login)
+	if err != nil {
+		return nil, templateErrorToStatus(err)
+	}
+	pbTemplates := make([]*pb.ItemTemplate, len(templates))
+	for i := range templates {
+		pbTemplates[i] = templates[i].ToPb()
+	}
+	return &pb.ListTemplatesResponse{
+		Templates: pbTemplates,
+	}, nil
+}
+
+func (ic *ItemController) DeleteTemplate(ctx context.Context, in *pb.DeleteTemplateRequest) (*pb.DeleteTemplateResponse, error) {
+	login, err := loginFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(in.Id) != 16 {
+		return nil, status.Error(codes.InvalidArgument, errs.ErrRequiredArgumentIsMissing.Error())
+	}
+
+	if err := ic.service.DeleteTemplate(ctx, login, models.ItemIdPbToModels(in.Id)); err != nil {
+		return nil, templateErrorToStatus(err)
+	}
+	return &pb.DeleteTemplateResponse{
+		Success: true,
+	}, nil
+}
+
+func templateErrorToStatus(err error) error {
+	switch {
+	case errors.Is(err, errs.ErrTemplateNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, errs.ErrRequiredArgumentIsMissing):
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return status.Error(codes.Internal, errs.ErrInternalServerError.Error())
+}
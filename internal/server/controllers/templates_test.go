@@ -0,0 +1,36 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"gophkeeper/internal/errs"
+	pb "gophkeeper/internal/protos/items"
+	iserv "gophkeeper/internal/server/services/item_service"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestItemController_Templates_Validation(t *testing.T) {
+	controller := NewItemController(&iserv.ItemService{})
+
+	_, err := controller.ListTemplates(context.Background(), &pb.ListTemplatesRequest{})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	ctx := context.WithValue(context.Background(), "login", "alice")
+	_, err = controller.SaveTemplate(ctx, &pb.SaveTemplateRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = controller.SaveTemplate(ctx, &pb.SaveTemplateRequest{Template: &pb.ItemTemplate{Id: make([]byte, 16)}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = controller.DeleteTemplate(ctx, &pb.DeleteTemplateRequest{Id: []byte{1}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestTemplateErrorToStatus(t *testing.T) {
+	assert.Equal(t, codes.NotFound, status.Code(templateErrorToStatus(errs.ErrTemplateNotFound)))
+	assert.Equal(t, codes.InvalidArgument, status.Code(templateErrorToStatus(errs.ErrRequiredArgumentIsMissing)))
+	assert.Equal(t, codes.Internal, status.Code(templateErrorToStatus(errors.New("db down"))))
+}
@@ -8,6 +8,7 @@ import (
 	"gophkeeper/internal/logger"
 	pb "gophkeeper/internal/protos/users"
 	userv "gophkeeper/internal/server/services/user_service"
+	"gophkeeper/models"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -25,17 +26,19 @@ func NewUserController(service *userv.UserService) *UserController {
 }
 
 func (us *UserController) SignUpUser(ctx context.Context, in *pb.SignUpUserRequest) (*pb.SignUpUserResponse, error) {
-	if in.User.Login == "" || in.User.Password == "" {
+	if in.GetUser().GetLogin() == "" || in.GetUser().GetPassword() == "" {
 		return nil, status.Error(codes.InvalidArgument, errs.ErrRequiredArgumentIsMissing.Error())
 	}
 	logger.Log.Info("Try to sign up user", zap.String("user", in.User.Login))
 
-	token, salt, err := us.service.SignUpUser(ctx, in.User.Login, in.User.Password)
+	token, salt, err := us.service.SignUpUser(ctx, in.User.Login, in.User.Password, signInDevice(ctx, in.Device))
 	switch {
 	case errors.Is(err, errs.ErrUserAlreadyRegistered):
 		return &pb.SignUpUserResponse{
 			Error: errs.ErrUserAlreadyRegistered.Error(),
 		}, nil
+	case errors.Is(err, errs.ErrInvalidPasswordEncoding):
+		return nil, status.Error(codes.InvalidArgument, errs.ErrInvalidPasswordEncoding.Error())
 	case err != nil && !errors.Is(err, errs.ErrUserAlreadyRegistered):
 		logger.Log.Info("Sign up user error", zap.Error(err))
 		return nil, status.Error(codes.Internal, errs.ErrInternalServerError.Error())
@@ -48,12 +51,12 @@ func (us *UserController) SignUpUser(ctx context.Context, in *pb.SignUpUserReque
 }
 
 func (us *UserController) SignInUser(ctx context.Context, in *pb.SignInUserRequest) (*pb.SignInUserResponse, error) {
-	if in.User.Login == "" || in.User.Password == "" {
+	if in.GetUser().GetLogin() == "" || in.GetUser().GetPassword() == "" {
 		return nil, status.Error(codes.InvalidArgument, errs.ErrRequiredArgumentIsMissing.Error())
 	}
 	logger.Log.Info("Try to sign in", zap.String("user", in.User.Login))
 
-	token, salt, err := us.service.SignInUser(ctx, in.User.Login, in.User.Password)
+	token, salt, err := us.service.SignInUser(ctx, in.User.Login, in.User.Password, signInDevice(ctx, in.Device))
 	switch {
 	case errors.Is(err, errs.ErrUserNotFound):
 		return &pb.SignInUserResponse{
@@ -63,6 +66,26 @@ func (us *UserController) SignInUser(ctx context.Context, in *pb.SignInUserReque
 		return &pb.SignInUserResponse{
 			Error: errs.ErrIncorrectCredentials.Error(),
 		}, nil
+	case errors.Is(err, errs.ErrUserLocked):
+		return &pb.SignInUserResponse{
+			Error: errs.ErrUserLocked.Error(),
+		}, nil
+	case errors.Is(err, errs.ErrDeviceNotApproved):
+		return &pb.SignInUserResponse{
+			Error: errs.ErrDeviceNotApproved.Error(),
+		}, nil
+	case errors.Is(err, errs.ErrInvalidDeviceSignature):
+		return &pb.SignInUserResponse{
+			Error: errs.ErrInvalidDeviceSignature.Error(),
+		}, nil
+	case errors.Is(err, errs.ErrDeviceSignInReplayed):
+		return &pb.SignInUserResponse{
+			Error: errs.ErrDeviceSignInReplayed.Error(),
+		}, nil
+	case errors.Is(err, errs.ErrRequiredArgumentIsMissing):
+		return nil, status.Error(codes.InvalidArgument, errs.ErrRequiredArgumentIsMissing.Error())
+	case errors.Is(err, errs.ErrInvalidPasswordEncoding):
+		return nil, status.Error(codes.InvalidArgument, errs.ErrInvalidPasswordEncoding.Error())
 	case err != nil:
 		return nil, status.Error(codes.Internal, errs.ErrInternalServerError.Error())
 	}
@@ -72,3 +95,13 @@ func (us *UserController) SignInUser(ctx context.Context, in *pb.SignInUserReque
 		Salt:  salt,
 	}, nil
 }
+
+// signInDevice takes the device of a sign in request and the address it
+// came from.
+func signInDevice(ctx context.Context, in *pb.DeviceSignIn) *models.DeviceSignIn {
+	device := models.DeviceSignInPbToModels(in)
+	if device != nil {
+		device.Device.LastSeenIP = clientIP(ctx)
+	}
+	return device
+}
@@ -2,42 +2,54 @@ package server
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"gophkeeper/config"
+	"gophkeeper/internal/lifecycle"
 	"gophkeeper/internal/logger"
 	pbcs "gophkeeper/internal/protos/crypto"
 	pbit "gophkeeper/internal/protos/items"
+	pbor "gophkeeper/internal/protos/orgs"
 	pbus "gophkeeper/internal/protos/users"
 	"gophkeeper/internal/server/controllers"
 	cserv "gophkeeper/internal/server/services/crypto_service"
+	eserv "gophkeeper/internal/server/services/emergency_service"
 	iserv "gophkeeper/internal/server/services/item_service"
+	oserv "gophkeeper/internal/server/services/org_service"
+	sdserv "gophkeeper/internal/server/services/send_service"
+	sserv "gophkeeper/internal/server/services/share_service"
 	userv "gophkeeper/internal/server/services/user_service"
 	"net"
-	"os"
-	"os/signal"
-	"syscall"
+	"net/http"
 	"time"
 
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.uber.org/zap"
 	"google.golang.org/grpc"
 )
 
 var _ Server = (*GRPCServer)(nil)
 
 type Server interface {
-	Run() error
-	Shutdown(ctx context.Context, idleConnsClosed chan struct{})
+	lifecycle.Component
+	lifecycle.Failer
 }
 
-func CreateAndRun(cnfg config.ServerConfig, us *userv.UserService, cs *cserv.CryptoService, is *iserv.ItemService) error {
-	g, err := createGRPCServer(cnfg, us, cs, is)
+// CreateAndRun adds the server after the components already in m, so it
+// stops first and in-flight RPCs drain before workers and storage go away,
+// and runs m until the process is asked to quit.
+func CreateAndRun(cnfg config.ServerConfig, m *lifecycle.Manager, us *userv.UserService, cs *cserv.CryptoService, is *iserv.ItemService, ss *sserv.ShareService, ors *oserv.OrgService, es *eserv.EmergencyService, sds *sdserv.SendService) error {
+	g, err := createGRPCServer(cnfg, us, cs, is, ss, ors, es, sds)
 	if err != nil {
 		return fmt.Errorf("create grpc server error: %w\n", err)
 	}
+	m.Add("grpc server", g)
 
-	if err := g.Run(); err != nil {
+	if err := m.Run(context.Background()); err != nil {
 		return fmt.Errorf("grpc server error: %w\n", err)
 	}
 
+	logger.Log.Info("Server shutted down gracefully")
 	return nil
 }
 
@@ -45,72 +57,128 @@ type GRPCServer struct {
 	Server *grpc.Server
 	Listen net.Listener
 
+	// SendServer serves one-time secret links to people without an account.
+	SendServer *http.Server
+	// GatewayServer serves the users and items APIs as HTTP/JSON.
+	GatewayServer *http.Server
+
+	failed chan error
+
 	US *userv.UserService
 	CS *cserv.CryptoService
 	IS *iserv.ItemService
+	SS *sserv.ShareService
+	OS *oserv.OrgService
+	ES *eserv.EmergencyService
+	SD *sdserv.SendService
 }
 
-func createGRPCServer(cnfg config.ServerConfig, us *userv.UserService, cs *cserv.CryptoService, is *iserv.ItemService) (*GRPCServer, error) {
+func createGRPCServer(cnfg config.ServerConfig, us *userv.UserService, cs *cserv.CryptoService, is *iserv.ItemService, ss *sserv.ShareService, ors *oserv.OrgService, es *eserv.EmergencyService, sds *sdserv.SendService) (*GRPCServer, error) {
 	uc := controllers.NewUserController(us)
 	cc := controllers.NewCryptoController(cnfg)
 	ic := controllers.NewItemController(is)
+	sc := controllers.NewShareController(ss)
+	oc := controllers.NewOrgController(ors)
+	ec := controllers.NewEmergencyController(es)
+	sdc := controllers.NewSendController(sds, cnfg.GetSendPublicURL())
 	listen, err := net.Listen("tcp", cnfg.GetAddress())
 	if err != nil {
 		return nil, fmt.Errorf("create listener error: %w", err)
 	}
 
-	authInterceptor := controllers.NewAuthInterceptor(cnfg)
+	authInterceptor := controllers.NewAuthInterceptor(cnfg, us)
 	s := grpc.NewServer(
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
 		grpc.UnaryInterceptor(authInterceptor),
 	)
 	pbus.RegisterUserControllerServer(s, uc)
+	pbus.RegisterDeviceControllerServer(s, controllers.NewDeviceController(us))
 	pbcs.RegisterCryptoControllerServer(s, cc)
 	pbit.RegisterItemsControllerServer(s, ic)
+	pbit.RegisterSharesControllerServer(s, sc)
+	pbit.RegisterEmergencyControllerServer(s, ec)
+	pbit.RegisterSendsControllerServer(s, sdc)
+	pbor.RegisterOrgControllerServer(s, oc)
 
 	return &GRPCServer{
 		Server: s,
 		Listen: listen,
 
+		SendServer: &http.Server{
+			Addr:              cnfg.GetSendAddress(),
+			Handler:           controllers.NewSendHandler(sds),
+			ReadHeaderTimeout: 5 * time.Second,
+		},
+		GatewayServer: &http.Server{
+			Addr:              cnfg.GetGatewayAddress(),
+			Handler:           controllers.NewGatewayHandler(cnfg, us, cc, uc, ic),
+			ReadHeaderTimeout: 5 * time.Second,
+		},
+
+		failed: make(chan error, 3),
+
 		US: us,
 		CS: cs,
 		IS: is,
+		SS: ss,
+		OS: ors,
+		ES: es,
+		SD: sds,
 	}, nil
 }
 
-func (s *GRPCServer) Run() error {
-	logger.Log.Info("Run grpc server")
-
-	idleConnsClosed := make(chan struct{})
-
-	quit := make(chan os.Signal, 1)
-	signal.Notify(quit, syscall.SIGTERM, syscall.SIGINT, syscall.SIGQUIT)
+// Start serves gRPC, secret links and the gateway in the background.
+func (s *GRPCServer) Start(ctx context.Context) error {
+	logger.Log.Info("Run grpc server", zap.String("address", s.Listen.Addr().String()))
 
 	go func() {
-		<-quit
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-		defer cancel()
-		s.Shutdown(ctx, idleConnsClosed)
+		if err := s.Server.Serve(s.Listen); err != nil {
+			s.failed <- fmt.Errorf("failed to run grpc server: %w", err)
+		}
 	}()
+	go s.serveHTTP("send", s.SendServer)
+	go s.serveHTTP("gateway", s.GatewayServer)
 
-	if err := s.Server.Serve(s.Listen); err != nil {
-
-		return fmt.Errorf("failed to run grpc server: %w", err)
-	}
-
-	<-idleConnsClosed
-
-	logger.Log.Info("Server shutted down gracefully")
 	return nil
 }
 
-func (s *GRPCServer) Shutdown(ctx context.Context, idleConnsClosed chan struct{}) {
+// Failed reports listeners that stopped serving on their own.
+func (s *GRPCServer) Failed() <-chan error {
+	return s.failed
+}
+
+// Stop stops accepting connections and waits for in-flight requests. RPCs
+// still running when ctx is done are cancelled.
+func (s *GRPCServer) Stop(ctx context.Context) error {
 	logger.Log.Info("Shutdown server")
-	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
-	defer cancel()
 
-	//(*s.Storage).Close()
+	var errs []error
+	if err := s.SendServer.Shutdown(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("shutdown send server error: %w", err))
+	}
+	if err := s.GatewayServer.Shutdown(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("shutdown gateway server error: %w", err))
+	}
 
-	s.Server.GracefulStop()
+	stopped := make(chan struct{})
+	go func() {
+		s.Server.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		s.Server.Stop()
+		<-stopped
+		errs = append(errs, fmt.Errorf("drain grpc server error: %w", ctx.Err()))
+	}
 
-	close(idleConnsClosed)
+	return errors.Join(errs...)
+}
+
+func (s *GRPCServer) serveHTTP(name string, srv *http.Server) {
+	logger.Log.Info("Run http server", zap.String("server", name), zap.String("address", srv.Addr))
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		s.failed <- fmt.Errorf("%s server error: %w", name, err)
+	}
 }
@@ -2,12 +2,19 @@ package server
 
 import (
 	"gophkeeper/config"
+	"gophkeeper/internal/lifecycle"
 	"gophkeeper/internal/server/repositories"
 	"gophkeeper/internal/server/repositories/database"
 	"gophkeeper/internal/server/services/crypto_service"
+	"gophkeeper/internal/server/services/emergency_service"
 	"gophkeeper/internal/server/services/item_service"
+	"gophkeeper/internal/server/services/org_service"
+	"gophkeeper/internal/server/services/send_service"
+	"gophkeeper/internal/server/services/share_service"
 	"gophkeeper/internal/server/services/user_service"
+	"syscall"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -25,8 +32,62 @@ func TestCreateGRPCServer(t *testing.T) {
 	require.NoError(t, err)
 	is, err := item_service.NewItemService(repo)
 	require.NoError(t, err)
+	ss, err := share_service.NewShareService(repo)
+	require.NoError(t, err)
+
+	ors, err := org_service.NewOrgService(repo)
+	require.NoError(t, err)
+
+	es, err := emergency_service.NewEmergencyService(repo, us)
+	require.NoError(t, err)
+
+	sds, err := send_service.NewSendService(repo)
+	require.NoError(t, err)
 
-	server, err := createGRPCServer(cnfg, us, cs, is)
+	server, err := createGRPCServer(cnfg, us, cs, is, ss, ors, es, sds)
 	require.NoError(t, err)
 	require.NotNil(t, server)
+	require.NotNil(t, server.SendServer)
+	require.NotNil(t, server.GatewayServer)
+}
+
+func TestCreateAndRun_StopsOnSignal(t *testing.T) {
+	t.Setenv("ADDRESS", "127.0.0.1:0")
+	t.Setenv("SEND_ADDRESS", "127.0.0.1:0")
+	t.Setenv("GATEWAY_ADDRESS", "127.0.0.1:0")
+	cnfg, err := config.NewServerConfig()
+	require.NoError(t, err)
+
+	repo := &database.PGDB{}
+	us, err := user_service.NewUserService(cnfg, repo)
+	require.NoError(t, err)
+	cs, err := crypto_service.NewCryptoService(cnfg)
+	require.NoError(t, err)
+	is, err := item_service.NewItemService(repo)
+	require.NoError(t, err)
+	ss, err := share_service.NewShareService(repo)
+	require.NoError(t, err)
+	ors, err := org_service.NewOrgService(repo)
+	require.NoError(t, err)
+	es, err := emergency_service.NewEmergencyService(repo, us)
+	require.NoError(t, err)
+	sds, err := send_service.NewSendService(repo)
+	require.NoError(t, err)
+
+	m := lifecycle.NewManager(time.Second, syscall.SIGUSR2)
+	m.Add("storage", repo)
+	m.Add("item reaper", is.NewReaper(time.Hour))
+
+	done := make(chan error, 1)
+	go func() { done <- CreateAndRun(cnfg, m, us, cs, is, ss, ors, es, sds) }()
+
+	time.Sleep(100 * time.Millisecond)
+	require.NoError(t, syscall.Kill(syscall.Getpid(), syscall.SIGUSR2))
+
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(2 * time.Second):
+		t.Fatal("server did not shut down on signal")
+	}
 }
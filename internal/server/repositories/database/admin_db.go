@@ -0,0 +1,149 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"gophkeeper/internal/errs"
+	"gophkeeper/models"
+
+	gen "gophkeeper/internal/server/repositories/database/generated"
+)
+
+type AdminDatabase interface {
+	ListUsersStats(ctx context.Context) ([]models.UserStats, error)
+	SetUserLocked(ctx context.Context, login string, locked bool) error
+	MarkUserDeleted(ctx context.Context, login string) error
+	RevokeUserSessions(ctx context.Context, login string) error
+	RevokeAllSessions(ctx context.Context) (int64, error)
+	PurgeDeletedUsers(ctx context.Context) (int64, error)
+	GetServerStats(ctx context.Context) (*models.ServerStats, error)
+	ListMigrations(ctx context.Context) ([]models.Migration, error)
+	Migrate(ctx context.Context) ([]string, error)
+}
+
+type AdminDB struct {
+	q    *gen.Queries
+	pool PoolInterface
+}
+
+var _ AdminDatabase = (*AdminDB)(nil)
+
+func NewAdminDB(q *gen.Queries, pool PoolInterface) (AdminDatabase, error) {
+	if pool == nil || q == nil {
+		return nil, errors.New("create admin database error: pool or quaries is nil")
+	}
+	return &AdminDB{
+		q:    q,
+		pool: pool,
+	}, nil
+}
+
+func (db *AdminDB) ListUsersStats(ctx context.Context) ([]models.UserStats, error) {
+	rows, err := db.q.ListUsersStats(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list users stats error: %w", err)
+	}
+
+	stats := make([]models.UserStats, len(rows))
+	for i, r := range rows {
+		stats[i] = models.UserStats{
+			Login:        r.Login,
+			Locked:       r.Locked,
+			Deleted:      r.DeletedAt.Valid,
+			CreatedAt:    r.CreatedAt.Time,
+			ItemCount:    r.ItemCount,
+			StorageBytes: r.StorageBytes,
+		}
+	}
+	return stats, nil
+}
+
+func (db *AdminDB) SetUserLocked(ctx context.Context, login string, locked bool) error {
+	n, err := db.q.SetUserLocked(ctx, gen.SetUserLockedParams{
+		Login:  login,
+		Locked: locked,
+	})
+	if err != nil {
+		return fmt.Errorf("set user locked error: %w", err)
+	}
+	if n == 0 {
+		return errs.ErrUserNotFound
+	}
+	return nil
+}
+
+func (db *AdminDB) MarkUserDeleted(ctx context.Context, login string) error {
+	n, err := db.q.MarkUserDeleted(ctx, login)
+	if err != nil {
+		return fmt.Errorf("mark user deleted error: %w", err)
+	}
+	if n == 0 {
+		return errs.ErrUserNotFound
+	}
+	return nil
+}
+
+func (db *AdminDB) RevokeUserSessions(ctx context.Context, login string) error {
+	n, err := db.q.RevokeUserSessions(ctx, login)
+	if err != nil {
+		return fmt.Errorf("revoke user sessions error: %w", err)
+	}
+	if n == 0 {
+		return errs.ErrUserNotFound
+	}
+	return nil
+}
+
+func (db *AdminDB) RevokeAllSessions(ctx context.Context) (int64, error) {
+	n, err := db.q.RevokeAllSessions(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("revoke all sessions error: %w", err)
+	}
+	return n, nil
+}
+
+// PurgeDeletedUsers removes accounts marked as deleted together with their items.
+func (db *AdminDB) PurgeDeletedUsers(ctx context.Context) (int64, error) {
+	tx, err := db.pool.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("begin tx error: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	q := db.q.WithTx(tx)
+	if _, err := q.DeleteItemsOfDeletedUsers(ctx); err != nil {
+		return 0, fmt.Errorf("delete items of deleted users error: %w", err)
+	}
+	n, err := q.DeleteDeletedUsers(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("delete deleted users error: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("commit tx error: %w", err)
+	}
+	return n, nil
+}
+
+func (db *AdminDB) GetServerStats(ctx context.Context) (*models.ServerStats, error) {
+	row, err := db.q.GetServerStats(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get server stats error: %w", err)
+	}
+	return &models.ServerStats{
+		UsersCount:        row.UsersCount,
+		LockedUsersCount:  row.LockedUsersCount,
+		DeletedUsersCount: row.DeletedUsersCount,
+		ItemsCount:        row.ItemsCount,
+		StorageBytes:      row.StorageBytes,
+	}, nil
+}
+
+func (db *AdminDB) ListMigrations(ctx context.Context) ([]models.Migration, error) {
+	return listMigrations(ctx, db.pool)
+}
+
+func (db *AdminDB) Migrate(ctx context.Context) ([]string, error) {
+	return migrate(ctx, db.pool)
+}
@@ -0,0 +1,173 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"gophkeeper/internal/errs"
+	gen "gophkeeper/internal/server/repositories/database/generated"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pashagolub/pgxmock/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestAdminDB(t *testing.T) (AdminDatabase, pgxmock.PgxPoolIface) {
+	t.Helper()
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	t.Cleanup(mock.Close)
+
+	adminDB, err := NewAdminDB(gen.New(mock), mock)
+	require.NoError(t, err)
+	return adminDB, mock
+}
+
+func TestNewAdminDB(t *testing.T) {
+	_, err := NewAdminDB(nil, nil)
+	assert.Error(t, err)
+}
+
+func TestAdminDB_ListUsersStats(t *testing.T) {
+	adminDB, mock := newTestAdminDB(t)
+	created := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	mock.ExpectQuery("SELECT(.+)FROM users u").
+		WillReturnRows(pgxmock.NewRows([]string{"login", "locked", "deleted_at", "created_at", "item_count", "storage_bytes"}).
+			AddRow("alice", false, pgtype.Timestamp{}, pgtype.Timestamp{Time: created, Valid: true}, int64(2), int64(128)).
+			AddRow("bob", true, pgtype.Timestamp{Time: created, Valid: true}, pgtype.Timestamp{}, int64(0), int64(0)))
+
+	users, err := adminDB.ListUsersStats(context.Background())
+	require.NoError(t, err)
+	require.Len(t, users, 2)
+
+	assert.Equal(t, "alice", users[0].Login)
+	assert.Equal(t, created, users[0].CreatedAt)
+	assert.Equal(t, int64(2), users[0].ItemCount)
+	assert.Equal(t, int64(128), users[0].StorageBytes)
+	assert.False(t, users[0].Deleted)
+
+	assert.True(t, users[1].Locked)
+	assert.True(t, users[1].Deleted)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAdminDB_UserUpdates(t *testing.T) {
+	tests := []struct {
+		name    string
+		mockFn  func(mock pgxmock.PgxPoolIface)
+		call    func(db AdminDatabase) error
+		wantErr error
+	}{
+		{
+			name: "lock user",
+			mockFn: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectExec("UPDATE users").WithArgs("alice", true).
+					WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+			},
+			call: func(db AdminDatabase) error { return db.SetUserLocked(context.Background(), "alice", true) },
+		},
+		{
+			name: "lock unknown user",
+			mockFn: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectExec("UPDATE users").WithArgs("nobody", true).
+					WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+			},
+			call:    func(db AdminDatabase) error { return db.SetUserLocked(context.Background(), "nobody", true) },
+			wantErr: errs.ErrUserNotFound,
+		},
+		{
+			name: "mark user deleted",
+			mockFn: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectExec("SET deleted_at = NOW()").WithArgs("alice").
+					WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+			},
+			call: func(db AdminDatabase) error { return db.MarkUserDeleted(context.Background(), "alice") },
+		},
+		{
+			name: "mark already deleted user",
+			mockFn: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectExec("SET deleted_at = NOW()").WithArgs("alice").
+					WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+			},
+			call:    func(db AdminDatabase) error { return db.MarkUserDeleted(context.Background(), "alice") },
+			wantErr: errs.ErrUserNotFound,
+		},
+		{
+			name: "revoke user sessions",
+			mockFn: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectExec("SET sessions_revoked_at = NOW()").WithArgs("alice").
+					WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+			},
+			call: func(db AdminDatabase) error { return db.RevokeUserSessions(context.Background(), "alice") },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			adminDB, mock := newTestAdminDB(t)
+			tt.mockFn(mock)
+
+			err := tt.call(adminDB)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestAdminDB_RevokeAllSessions(t *testing.T) {
+	adminDB, mock := newTestAdminDB(t)
+	mock.ExpectExec("SET sessions_revoked_at = NOW()").
+		WillReturnResult(pgxmock.NewResult("UPDATE", 5))
+
+	n, err := adminDB.RevokeAllSessions(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), n)
+}
+
+func TestAdminDB_PurgeDeletedUsers(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		adminDB, mock := newTestAdminDB(t)
+		mock.ExpectBegin()
+		mock.ExpectExec("DELETE FROM items").WillReturnResult(pgxmock.NewResult("DELETE", 4))
+		mock.ExpectExec("DELETE FROM users").WillReturnResult(pgxmock.NewResult("DELETE", 2))
+		mock.ExpectCommit()
+
+		n, err := adminDB.PurgeDeletedUsers(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), n)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("rollback on error", func(t *testing.T) {
+		adminDB, mock := newTestAdminDB(t)
+		mock.ExpectBegin()
+		mock.ExpectExec("DELETE FROM items").WillReturnError(fmt.Errorf("db error"))
+		mock.ExpectRollback()
+
+		_, err := adminDB.PurgeDeletedUsers(context.Background())
+		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestAdminDB_GetServerStats(t *testing.T) {
+	adminDB, mock := newTestAdminDB(t)
+	mock.ExpectQuery("SELECT").
+		WillReturnRows(pgxmock.NewRows([]string{"users_count", "locked_users_count", "deleted_users_count", "items_count", "storage_bytes"}).
+			AddRow(int64(3), int64(1), int64(1), int64(10), int64(4096)))
+
+	stats, err := adminDB.GetServerStats(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), stats.UsersCount)
+	assert.Equal(t, int64(1), stats.LockedUsersCount)
+	assert.Equal(t, int64(1), stats.DeletedUsersCount)
+	assert.Equal(t, int64(10), stats.ItemsCount)
+	assert.Equal(t, int64(4096), stats.StorageBytes)
+}
@@ -0,0 +1,115 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"gophkeeper/internal/errs"
+	"gophkeeper/models"
+
+	gen "gophkeeper/internal/server/repositories/database/generated"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type AttachmentDatabase interface {
+	AddAttachment(ctx context.Context, a *models.Attachment) ([16]byte, error)
+	ListAttachments(ctx context.Context, itemID [16]byte) ([]models.Attachment, error)
+	GetAttachment(ctx context.Context, itemID, attachmentID [16]byte) (*models.Attachment, error)
+	DeleteAttachment(ctx context.Context, itemID, attachmentID [16]byte) error
+}
+
+type AttachmentDB struct {
+	q    *gen.Queries
+	pool PoolInterface
+}
+
+var _ AttachmentDatabase = (*AttachmentDB)(nil)
+
+func NewAttachmentDB(q *gen.Queries, pool PoolInterface) (AttachmentDatabase, error) {
+	if pool == nil || q == nil {
+		return nil, errors.New("create attachment database error: pool or quaries is nil")
+	}
+	return &AttachmentDB{
+		q:    q,
+		pool: pool,
+	}, nil
+}
+
+func (db *AttachmentDB) AddAttachment(ctx context.Context, a *models.Attachment) ([16]byte, error) {
+	id, err := db.q.AddAttachment(ctx, gen.AddAttachmentParams{
+		ItemID:               pgtype.UUID{Bytes: a.ItemID, Valid: true},
+		EncryptedInfoContent: a.EncryptedInfo.EncryptedContent,
+		EncryptedInfoNonce:   a.EncryptedInfo.Nonce,
+		Size:                 a.Size,
+		Content:              a.Content,
+		Nonce:                a.Nonce,
+	})
+	if err != nil {
+		return [16]byte{}, fmt.Errorf("add attachment error: %w", err)
+	}
+	return id.Bytes, nil
+}
+
+// ListAttachments returns the attachments of the item without their content.
+func (db *AttachmentDB) ListAttachments(ctx context.Context, itemID [16]byte) ([]models.Attachment, error) {
+	rows, err := db.q.ListAttachments(ctx, pgtype.UUID{Bytes: itemID, Valid: true})
+	if err != nil {
+		return nil, fmt.Errorf("list attachments error: %w", err)
+	}
+
+	attachments := make([]models.Attachment, len(rows))
+	for i, r := range rows {
+		attachments[i] = models.Attachment{
+			ID:     r.ID.Bytes,
+			ItemID: r.ItemID.Bytes,
+			EncryptedInfo: models.EncryptedData{
+				EncryptedContent: r.EncryptedInfoContent,
+				Nonce:            r.EncryptedInfoNonce,
+			},
+			Size:      r.Size,
+			CreatedAt: r.CreatedAt.Time,
+		}
+	}
+	return attachments, nil
+}
+
+func (db *AttachmentDB) GetAttachment(ctx context.Context, itemID, attachmentID [16]byte) (*models.Attachment, error) {
+	a, err := db.q.GetAttachment(ctx, gen.GetAttachmentParams{
+		ID:     pgtype.UUID{Bytes: attachmentID, Valid: true},
+		ItemID: pgtype.UUID{Bytes: itemID, Valid: true},
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, errs.ErrAttachmentNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get attachment error: %w", err)
+	}
+	return &models.Attachment{
+		ID:     a.ID.Bytes,
+		ItemID: a.ItemID.Bytes,
+		EncryptedInfo: models.EncryptedData{
+			EncryptedContent: a.EncryptedInfoContent,
+			Nonce:            a.EncryptedInfoNonce,
+		},
+		Size:      a.Size,
+		Content:   a.Content,
+		Nonce:     a.Nonce,
+		CreatedAt: a.CreatedAt.Time,
+	}, nil
+}
+
+func (db *AttachmentDB) DeleteAttachment(ctx context.Context, itemID, attachmentID [16]byte) error {
+	n, err := db.q.DeleteAttachment(ctx, gen.DeleteAttachmentParams{
+		ID:     pgtype.UUID{Bytes: attachmentID, Valid: true},
+		ItemID: pgtype.UUID{Bytes: itemID, Valid: true},
+	})
+	if err != nil {
+		return fmt.Errorf("delete attachment error: %w", err)
+	}
+	if n == 0 {
+		return errs.ErrAttachmentNotFound
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package database
+
+import (
+	"context"
+	"gophkeeper/internal/errs"
+	gen "gophkeeper/internal/server/repositories/database/generated"
+	"gophkeeper/models"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pashagolub/pgxmock/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	attachmentTestItemID = [16]byte{0xa7, 0x01}
+	attachmentTestID     = [16]byte{0xa7, 0x02}
+)
+
+func newTestAttachmentDB(t *testing.T) (AttachmentDatabase, pgxmock.PgxPoolIface) {
+	t.Helper()
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	t.Cleanup(mock.Close)
+
+	attachmentDB, err := NewAttachmentDB(gen.New(mock), mock)
+	require.NoError(t, err)
+	return attachmentDB, mock
+}
+
+func TestNewAttachmentDB(t *testing.T) {
+	_, err := NewAttachmentDB(nil, nil)
+	assert.Error(t, err)
+}
+
+func TestAttachmentDB_AddAttachment(t *testing.T) {
+	attachmentDB, mock := newTestAttachmentDB(t)
+	itemID := pgtype.UUID{Bytes: attachmentTestItemID, Valid: true}
+	mock.ExpectQuery("INSERT INTO item_attachments").
+		WithArgs(itemID, "info", "info-nonce", int64(3), []byte("enc"), []byte("nonce")).
+		WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(pgtype.UUID{Bytes: attachmentTestID, Valid: true}))
+
+	id, err := attachmentDB.AddAttachment(context.Background(), &models.Attachment{
+		ItemID:        attachmentTestItemID,
+		EncryptedInfo: models.EncryptedData{EncryptedContent: "info", Nonce: "info-nonce"},
+		Size:          3,
+		Content:       []byte("enc"),
+		Nonce:         []byte("nonce"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, attachmentTestID, id)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAttachmentDB_ListAttachments(t *testing.T) {
+	attachmentDB, mock := newTestAttachmentDB(t)
+	now := time.Now()
+	mock.ExpectQuery("SELECT .* FROM item_attachments").
+		WithArgs(pgtype.UUID{Bytes: attachmentTestItemID, Valid: true}).
+		WillReturnRows(pgxmock.NewRows([]string{
+			"id", "item_id", "encrypted_info_content", "encrypted_info_nonce", "size", "created_at",
+		}).AddRow(
+			pgtype.UUID{Bytes: attachmentTestID, Valid: true}, pgtype.UUID{Bytes: attachmentTestItemID, Valid: true},
+			"info", "info-nonce", int64(42), pgtype.Timestamp{Time: now, Valid: true},
+		))
+
+	attachments, err := attachmentDB.ListAttachments(context.Background(), attachmentTestItemID)
+	require.NoError(t, err)
+	require.Len(t, attachments, 1)
+	assert.Equal(t, attachmentTestID, attachments[0].ID)
+	assert.Equal(t, int64(42), attachments[0].Size)
+	assert.Equal(t, "info", attachments[0].EncryptedInfo.EncryptedContent)
+	assert.Nil(t, attachments[0].Content)
+	assert.Equal(t, now, attachments[0].CreatedAt)
+}
+
+func TestAttachmentDB_GetAttachment(t *testing.T) {
+	attachmentDB, mock := newTestAttachmentDB(t)
+	args := []any{pgtype.UUID{Bytes: attachmentTestID, Valid: true}, pgtype.UUID{Bytes: attachmentTestItemID, Valid: true}}
+	mock.ExpectQuery("SELECT .* FROM item_attachments").WithArgs(args...).
+		WillReturnRows(pgxmock.NewRows([]string{
+			"id", "item_id", "encrypted_info_content", "encrypted_info_nonce", "size", "content", "nonce", "created_at",
+		}).AddRow(
+			args[0], args[1], "info", "info-nonce", int64(3), []byte("enc"), []byte("nonce"),
+			pgtype.Timestamp{Time: time.Now(), Valid: true},
+		))
+	mock.ExpectQuery("SELECT .* FROM item_attachments").WithArgs(args...).WillReturnError(pgx.ErrNoRows)
+
+	a, err := attachmentDB.GetAttachment(context.Background(), attachmentTestItemID, attachmentTestID)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("enc"), a.Content)
+	assert.Equal(t, []byte("nonce"), a.Nonce)
+
+	_, err = attachmentDB.GetAttachment(context.Background(), attachmentTestItemID, attachmentTestID)
+	assert.ErrorIs(t, err, errs.ErrAttachmentNotFound)
+}
+
+func TestAttachmentDB_DeleteAttachment(t *testing.T) {
+	attachmentDB, mock := newTestAttachmentDB(t)
+	args := []any{pgtype.UUID{Bytes: attachmentTestID, Valid: true}, pgtype.UUID{Bytes: attachmentTestItemID, Valid: true}}
+	mock.ExpectExec("DELETE FROM item_attachments").WithArgs(args...).
+		WillReturnResult(pgxmock.NewResult("DELETE", 1))
+	mock.ExpectExec("DELETE FROM item_attachments").WithArgs(args...).
+		WillReturnResult(pgxmock.NewResult("DELETE", 0))
+
+	assert.NoError(t, attachmentDB.DeleteAttachment(context.Background(), attachmentTestItemID, attachmentTestID))
+	assert.ErrorIs(t, attachmentDB.DeleteAttachment(context.Background(), attachmentTestItemID, attachmentTestID), errs.ErrAttachmentNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -5,8 +5,7 @@ import (
 	"fmt"
 	"gophkeeper/config"
 	"gophkeeper/models"
-	"os"
-	"path/filepath"
+	"time"
 
 	gen "gophkeeper/internal/server/repositories/database/generated"
 
@@ -16,23 +15,59 @@ import (
 type Database interface {
 	UserDatabase
 	ItemDatabase
+	AdminDatabase
+	ShareDatabase
+	OrgDatabase
+	EmergencyDatabase
+	SendDatabase
+	DeviceDatabase
+	AttachmentDatabase
+	TemplateDatabase
 }
 
 type PGDB struct {
-	users UserDatabase
-	items ItemDatabase
+	users       UserDatabase
+	items       ItemDatabase
+	admin       AdminDatabase
+	shares      ShareDatabase
+	orgs        OrgDatabase
+	emergency   EmergencyDatabase
+	sends       SendDatabase
+	devices     DeviceDatabase
+	attachments AttachmentDatabase
+	templates   TemplateDatabase
+
+	pool *pgxpool.Pool
 }
 
 var _ Database = (*PGDB)(nil)
 
-func NewPGDB(cfg config.DatabaseConfig) (Database, error) {
-	pool, err := pgxpool.New(context.Background(), cfg.GetConnectionString())
+// NewPGDB connects to the database and applies pending migrations.
+func NewPGDB(cfg config.DatabaseConfig) (*PGDB, error) {
+	return newPGDB(cfg, true)
+}
+
+// OpenPGDB connects to the database without touching the schema.
+func OpenPGDB(cfg config.DatabaseConfig) (*PGDB, error) {
+	return newPGDB(cfg, false)
+}
+
+func newPGDB(cfg config.DatabaseConfig, autoMigrate bool) (*PGDB, error) {
+	poolCfg, err := pgxpool.ParseConfig(cfg.GetConnectionString())
+	if err != nil {
+		return nil, fmt.Errorf("parse db config error: %v", err)
+	}
+	poolCfg.ConnConfig.Tracer = queryTracer{}
+
+	pool, err := pgxpool.NewWithConfig(context.Background(), poolCfg)
 	if err != nil {
 		return nil, fmt.Errorf("create new db error: %v", err)
 	}
 
-	if err := createTables(pool); err != nil {
-		return nil, fmt.Errorf("create db tables error: %v", err)
+	if autoMigrate {
+		if _, err := migrate(context.Background(), pool); err != nil {
+			return nil, fmt.Errorf("migrate db error: %v", err)
+		}
 	}
 
 	q := gen.New(pool)
@@ -45,66 +80,88 @@ func NewPGDB(cfg config.DatabaseConfig) (Database, error) {
 	if err != nil {
 		return nil, fmt.Errorf("create item db error: %v", err)
 	}
-	return &PGDB{
-		users: userDB,
-		items: itemDB,
-	}, nil
-}
-
-func createTables(db *pgxpool.Pool) error {
-	// If we're in a container (no project root), skip table creation
-	// as it should be handled by init scripts
-	rootDir, err := config.GetProjectRoot()
+	adminDB, err := NewAdminDB(q, pool)
 	if err != nil {
-		// Likely in container environment, check if tables already exist
-		var tablesExist bool
-		err = db.QueryRow(context.Background(),
-			"SELECT EXISTS (SELECT 1 FROM information_schema.tables WHERE table_name = 'users')").Scan(&tablesExist)
-		if err != nil {
-			return fmt.Errorf("failed to check if tables exist: %w", err)
-		}
-		if tablesExist {
-			return nil // Tables already exist, skip creation
-		}
-		return fmt.Errorf("tables don't exist and can't create them in container environment: %w", err)
+		return nil, fmt.Errorf("create admin db error: %v", err)
 	}
-	dirpath := filepath.Join(rootDir, "internal", "server", "repositories", "database", "schema")
-
-	var typeExists bool
-	err = db.QueryRow(context.Background(),
-		"SELECT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'item_type')").Scan(&typeExists)
+	shareDB, err := NewShareDB(q, pool)
 	if err != nil {
-		return fmt.Errorf("failed to check if type exists: %w", err)
+		return nil, fmt.Errorf("create share db error: %v", err)
 	}
-	if !typeExists {
-		filepathTypes := filepath.Join(dirpath, "001_types.sql")
-		err = createTable(db, filepathTypes)
-		if err != nil {
-			return err
-		}
+	orgDB, err := NewOrgDB(q, pool)
+	if err != nil {
+		return nil, fmt.Errorf("create org db error: %v", err)
 	}
-
-	filepathTables := filepath.Join(dirpath, "002_tables.sql")
-	err = createTable(db, filepathTables)
+	emergencyDB, err := NewEmergencyDB(q, pool)
+	if err != nil {
+		return nil, fmt.Errorf("create emergency db error: %v", err)
+	}
+	sendDB, err := NewSendDB(q, pool)
 	if err != nil {
-		return err
+		return nil, fmt.Errorf("create send db error: %v", err)
 	}
+	deviceDB, err := NewDeviceDB(q, pool)
+	if err != nil {
+		return nil, fmt.Errorf("create device db error: %v", err)
+	}
+	attachmentDB, err := NewAttachmentDB(q, pool)
+	if err != nil {
+		return nil, fmt.Errorf("create attachment db error: %v", err)
+	}
+	templateDB, err := NewTemplateDB(q, pool)
+	if err != nil {
+		return nil, fmt.Errorf("create template db error: %v", err)
+	}
+	return &PGDB{
+		users:       userDB,
+		items:       itemDB,
+		admin:       adminDB,
+		shares:      shareDB,
+		orgs:        orgDB,
+		emergency:   emergencyDB,
+		sends:       sendDB,
+		devices:     deviceDB,
+		attachments: attachmentDB,
+		templates:   templateDB,
 
-	return nil
+		pool: pool,
+	}, nil
 }
 
-func createTable(db *pgxpool.Pool, filepath string) error {
-	script, err := os.ReadFile(filepath)
-	if err != nil {
-		return err
+// Start checks that the database is reachable.
+func (pg *PGDB) Start(ctx context.Context) error {
+	if pg.pool == nil {
+		return nil
 	}
-
-	if _, err := db.Exec(context.Background(), string(script)); err != nil {
-		return err
+	if err := pg.pool.Ping(ctx); err != nil {
+		return fmt.Errorf("ping db error: %w", err)
 	}
 	return nil
 }
 
+// Stop closes the pool. Closing waits for acquired connections to be
+// released, so Stop gives up when ctx is done and leaves the rest to exit.
+func (pg *PGDB) Stop(ctx context.Context) error {
+	if pg.pool == nil {
+		return nil
+	}
+	closed := make(chan struct{})
+	go func() {
+		pg.pool.Close()
+		close(closed)
+	}()
+	select {
+	case <-closed:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("close db error: %w", ctx.Err())
+	}
+}
+
+func (pg *PGDB) Close() error {
+	return pg.Stop(context.Background())
+}
+
 func (pg *PGDB) SignUpUser(ctx context.Context, user *models.User) error {
 	return pg.users.SignUpUser(ctx, user)
 }
@@ -121,6 +178,10 @@ func (pg *PGDB) GetUserItemsWithType(ctx context.Context, typ models.ItemType, l
 	return pg.items.GetUserItemsWithType(ctx, typ, login)
 }
 
+func (pg *PGDB) SearchUserItems(ctx context.Context, login string, tokens []string) ([]models.EncryptedItem, error) {
+	return pg.items.SearchUserItems(ctx, login, tokens)
+}
+
 func (pg *PGDB) AddItem(ctx context.Context, item *models.EncryptedItem) error {
 	return pg.items.AddItem(ctx, item)
 }
@@ -133,6 +194,258 @@ func (pg *PGDB) DeleteItem(ctx context.Context, login string, itemID [16]byte) e
 	return pg.items.DeleteItem(ctx, login, itemID)
 }
 
+func (pg *PGDB) DeleteExpiredItems(ctx context.Context) (int64, error) {
+	return pg.items.DeleteExpiredItems(ctx)
+}
+
 func (pg *PGDB) GetTypesCounts(ctx context.Context, login string) (map[models.ItemType]int32, error) {
 	return pg.items.GetTypesCounts(ctx, login)
 }
+
+func (pg *PGDB) GetCollectionItems(ctx context.Context, typ models.ItemType, collectionID [16]byte) ([]models.EncryptedItem, error) {
+	return pg.items.GetCollectionItems(ctx, typ, collectionID)
+}
+
+func (pg *PGDB) GetCollectionTypesCounts(ctx context.Context, collectionID [16]byte) (map[models.ItemType]int32, error) {
+	return pg.items.GetCollectionTypesCounts(ctx, collectionID)
+}
+
+func (pg *PGDB) GetItemCollection(ctx context.Context, itemID [16]byte) ([16]byte, error) {
+	return pg.items.GetItemCollection(ctx, itemID)
+}
+
+func (pg *PGDB) DeleteCollectionItem(ctx context.Context, collectionID, itemID [16]byte) error {
+	return pg.items.DeleteCollectionItem(ctx, collectionID, itemID)
+}
+
+func (pg *PGDB) TouchItem(ctx context.Context, login string, itemID [16]byte) error {
+	return pg.items.TouchItem(ctx, login, itemID)
+}
+
+func (pg *PGDB) SetItemFavorite(ctx context.Context, login string, itemID [16]byte, favorite bool) error {
+	return pg.items.SetItemFavorite(ctx, login, itemID, favorite)
+}
+
+func (pg *PGDB) ListUsersStats(ctx context.Context) ([]models.UserStats, error) {
+	return pg.admin.ListUsersStats(ctx)
+}
+
+func (pg *PGDB) SetUserLocked(ctx context.Context, login string, locked bool) error {
+	return pg.admin.SetUserLocked(ctx, login, locked)
+}
+
+func (pg *PGDB) MarkUserDeleted(ctx context.Context, login string) error {
+	return pg.admin.MarkUserDeleted(ctx, login)
+}
+
+func (pg *PGDB) RevokeUserSessions(ctx context.Context, login string) error {
+	return pg.admin.RevokeUserSessions(ctx, login)
+}
+
+func (pg *PGDB) RevokeAllSessions(ctx context.Context) (int64, error) {
+	return pg.admin.RevokeAllSessions(ctx)
+}
+
+func (pg *PGDB) PurgeDeletedUsers(ctx context.Context) (int64, error) {
+	return pg.admin.PurgeDeletedUsers(ctx)
+}
+
+func (pg *PGDB) GetServerStats(ctx context.Context) (*models.ServerStats, error) {
+	return pg.admin.GetServerStats(ctx)
+}
+
+func (pg *PGDB) ListMigrations(ctx context.Context) ([]models.Migration, error) {
+	return pg.admin.ListMigrations(ctx)
+}
+
+func (pg *PGDB) Migrate(ctx context.Context) ([]string, error) {
+	return pg.admin.Migrate(ctx)
+}
+
+func (pg *PGDB) AddUserKeys(ctx context.Context, keys *models.UserKeys) error {
+	return pg.shares.AddUserKeys(ctx, keys)
+}
+
+func (pg *PGDB) GetUserKeys(ctx context.Context, login string) (*models.UserKeys, error) {
+	return pg.shares.GetUserKeys(ctx, login)
+}
+
+func (pg *PGDB) GetItemOwner(ctx context.Context, itemID [16]byte) (string, error) {
+	return pg.shares.GetItemOwner(ctx, itemID)
+}
+
+func (pg *PGDB) ShareItem(ctx context.Context, share *models.ItemShare) error {
+	return pg.shares.ShareItem(ctx, share)
+}
+
+func (pg *PGDB) ListItemShares(ctx context.Context, itemID [16]byte) ([]models.ItemShare, error) {
+	return pg.shares.ListItemShares(ctx, itemID)
+}
+
+func (pg *PGDB) ListSharedWithUser(ctx context.Context, login string) ([]models.SharedItem, error) {
+	return pg.shares.ListSharedWithUser(ctx, login)
+}
+
+func (pg *PGDB) RevokeShare(ctx context.Context, owner string, rev *models.ShareRevocation) error {
+	return pg.shares.RevokeShare(ctx, owner, rev)
+}
+
+func (pg *PGDB) CreateOrganization(ctx context.Context, org *models.Organization, coll *models.Collection) error {
+	return pg.orgs.CreateOrganization(ctx, org, coll)
+}
+
+func (pg *PGDB) ListUserOrganizations(ctx context.Context, login string) ([]models.Organization, error) {
+	return pg.orgs.ListUserOrganizations(ctx, login)
+}
+
+func (pg *PGDB) GetMembership(ctx context.Context, orgID [16]byte, login string) (*models.Membership, error) {
+	return pg.orgs.GetMembership(ctx, orgID, login)
+}
+
+func (pg *PGDB) AddMembership(ctx context.Context, m *models.Membership) error {
+	return pg.orgs.AddMembership(ctx, m)
+}
+
+func (pg *PGDB) AcceptMembership(ctx context.Context, orgID [16]byte, login string) error {
+	return pg.orgs.AcceptMembership(ctx, orgID, login)
+}
+
+func (pg *PGDB) UpdateMembershipRole(ctx context.Context, orgID [16]byte, login string, role models.OrgRole) error {
+	return pg.orgs.UpdateMembershipRole(ctx, orgID, login, role)
+}
+
+func (pg *PGDB) ListMemberships(ctx context.Context, orgID [16]byte) ([]models.Membership, error) {
+	return pg.orgs.ListMemberships(ctx, orgID)
+}
+
+func (pg *PGDB) LeaveOrganization(ctx context.Context, orgID [16]byte, login string) error {
+	return pg.orgs.LeaveOrganization(ctx, orgID, login)
+}
+
+func (pg *PGDB) RotateOrgKey(ctx context.Context, rot *models.OrgKeyRotation) error {
+	return pg.orgs.RotateOrgKey(ctx, rot)
+}
+
+func (pg *PGDB) CreateCollection(ctx context.Context, coll *models.Collection) error {
+	return pg.orgs.CreateCollection(ctx, coll)
+}
+
+func (pg *PGDB) ListCollections(ctx context.Context, orgID [16]byte) ([]models.Collection, error) {
+	return pg.orgs.ListCollections(ctx, orgID)
+}
+
+func (pg *PGDB) GetCollectionRole(ctx context.Context, collectionID [16]byte, login string) (models.OrgRole, error) {
+	return pg.orgs.GetCollectionRole(ctx, collectionID, login)
+}
+
+func (pg *PGDB) UpsertEmergencyAccess(ctx context.Context, access *models.EmergencyAccess) error {
+	return pg.emergency.UpsertEmergencyAccess(ctx, access)
+}
+
+func (pg *PGDB) GetEmergencyAccess(ctx context.Context, grantor, grantee string) (*models.EmergencyAccess, error) {
+	return pg.emergency.GetEmergencyAccess(ctx, grantor, grantee)
+}
+
+func (pg *PGDB) ListEmergencyAccessByGrantor(ctx context.Context, grantor string) ([]models.EmergencyAccess, error) {
+	return pg.emergency.ListEmergencyAccessByGrantor(ctx, grantor)
+}
+
+func (pg *PGDB) ListEmergencyAccessByGrantee(ctx context.Context, grantee string) ([]models.EmergencyAccess, error) {
+	return pg.emergency.ListEmergencyAccessByGrantee(ctx, grantee)
+}
+
+func (pg *PGDB) RequestEmergencyAccess(ctx context.Context, grantor, grantee string) error {
+	return pg.emergency.RequestEmergencyAccess(ctx, grantor, grantee)
+}
+
+func (pg *PGDB) ApproveEmergencyAccess(ctx context.Context, grantor, grantee string) error {
+	return pg.emergency.ApproveEmergencyAccess(ctx, grantor, grantee)
+}
+
+func (pg *PGDB) DenyEmergencyAccess(ctx context.Context, grantor, grantee string) error {
+	return pg.emergency.DenyEmergencyAccess(ctx, grantor, grantee)
+}
+
+func (pg *PGDB) DeleteEmergencyAccess(ctx context.Context, grantor, grantee string) error {
+	return pg.emergency.DeleteEmergencyAccess(ctx, grantor, grantee)
+}
+
+func (pg *PGDB) TakeoverAccount(ctx context.Context, t *models.EmergencyTakeover, passwordHash []byte) error {
+	return pg.emergency.TakeoverAccount(ctx, t, passwordHash)
+}
+
+func (pg *PGDB) CreateSend(ctx context.Context, send *models.Send) ([16]byte, error) {
+	return pg.sends.CreateSend(ctx, send)
+}
+
+func (pg *PGDB) OpenSend(ctx context.Context, id [16]byte) (*models.Send, error) {
+	return pg.sends.OpenSend(ctx, id)
+}
+
+func (pg *PGDB) DeleteExpiredSends(ctx context.Context) (int64, error) {
+	return pg.sends.DeleteExpiredSends(ctx)
+}
+
+func (pg *PGDB) RegisterDevice(ctx context.Context, device *models.Device) (*models.Device, error) {
+	return pg.devices.RegisterDevice(ctx, device)
+}
+
+func (pg *PGDB) GetDevice(ctx context.Context, login string, id [16]byte) (*models.Device, error) {
+	return pg.devices.GetDevice(ctx, login, id)
+}
+
+func (pg *PGDB) ListDevices(ctx context.Context, login string) ([]models.Device, error) {
+	return pg.devices.ListDevices(ctx, login)
+}
+
+func (pg *PGDB) RemoveDevice(ctx context.Context, login string, id [16]byte) error {
+	return pg.devices.RemoveDevice(ctx, login, id)
+}
+
+func (pg *PGDB) ApproveDevice(ctx context.Context, login string, id [16]byte) error {
+	return pg.devices.ApproveDevice(ctx, login, id)
+}
+
+func (pg *PGDB) TouchDevice(ctx context.Context, login string, id [16]byte, ip string) error {
+	return pg.devices.TouchDevice(ctx, login, id, ip)
+}
+
+func (pg *PGDB) UseDeviceSignIn(ctx context.Context, login string, id [16]byte, signedAt time.Time) (bool, error) {
+	return pg.devices.UseDeviceSignIn(ctx, login, id, signedAt)
+}
+
+func (pg *PGDB) GetDeviceApprovalRequired(ctx context.Context, login string) (bool, error) {
+	return pg.devices.GetDeviceApprovalRequired(ctx, login)
+}
+
+func (pg *PGDB) SetDeviceApprovalRequired(ctx context.Context, login string, required bool) error {
+	return pg.devices.SetDeviceApprovalRequired(ctx, login, required)
+}
+
+func (pg *PGDB) AddAttachment(ctx context.Context, a *models.Attachment) ([16]byte, error) {
+	return pg.attachments.AddAttachment(ctx, a)
+}
+
+func (pg *PGDB) ListAttachments(ctx context.Context, itemID [16]byte) ([]models.Attachment, error) {
+	return pg.attachments.ListAttachments(ctx, itemID)
+}
+
+func (pg *PGDB) GetAttachment(ctx context.Context, itemID, attachmentID [16]byte) (*models.Attachment, error) {
+	return pg.attachments.GetAttachment(ctx, itemID, attachmentID)
+}
+
+func (pg *PGDB) DeleteAttachment(ctx context.Context, itemID, attachmentID [16]byte) error {
+	return pg.attachments.DeleteAttachment(ctx, itemID, attachmentID)
+}
+
+func (pg *PGDB) SaveTemplate(ctx context.Context, t *models.Template) error {
+	return pg.templates.SaveTemplate(ctx, t)
+}
+
+func (pg *PGDB) ListTemplates(ctx context.Context, login string) ([]models.Template, error) {
+	return pg.templates.ListTemplates(ctx, login)
+}
+
+func (pg *PGDB) DeleteTemplate(ctx context.Context, login string, id [16]byte) error {
+	return pg.templates.DeleteTemplate(ctx, login, id)
+}
@@ -113,9 +113,9 @@ func TestPGDB_GetUser(t *testing.T) {
 			name:  "successful get user",
 			login: "testuser",
 			mockFn: func() {
-				rows := pgxmock.NewRows([]string{"login", "password", "salt"}).
-					AddRow("testuser", []byte("hashedpassword"), "testsalt")
-				mock.ExpectQuery("SELECT login, password, salt FROM users").
+				rows := pgxmock.NewRows([]string{"login", "password", "salt", "locked", "sessions_revoked_at", "deleted_at", "created_at"}).
+					AddRow("testuser", []byte("hashedpassword"), "testsalt", false, nil, nil, nil)
+				mock.ExpectQuery("SELECT login, password, salt, locked, sessions_revoked_at, deleted_at, created_at FROM users").
 					WithArgs("testuser").
 					WillReturnRows(rows)
 			},
@@ -130,7 +130,7 @@ func TestPGDB_GetUser(t *testing.T) {
 			name:  "user not found",
 			login: "nonexistent",
 			mockFn: func() {
-				mock.ExpectQuery("SELECT login, password, salt FROM users").
+				mock.ExpectQuery("SELECT login, password, salt, locked, sessions_revoked_at, deleted_at, created_at FROM users").
 					WithArgs("nonexistent").
 					WillReturnError(pgx.ErrNoRows)
 			},
@@ -180,6 +180,31 @@ func TestItemTypeModelsToPg(t *testing.T) {
 			itemType: models.ItemType("CARD"),
 			expected: "CARD",
 		},
+		{
+			name:     "ssh key type",
+			itemType: models.ItemType("SSH_KEY"),
+			expected: "SSH_KEY",
+		},
+		{
+			name:     "totp type",
+			itemType: models.ItemType("TOTP"),
+			expected: "TOTP",
+		},
+		{
+			name:     "custom type",
+			itemType: models.ItemType("CUSTOM"),
+			expected: "CUSTOM",
+		},
+		{
+			name:     "identity type",
+			itemType: models.ItemType("IDENTITY"),
+			expected: "IDENTITY",
+		},
+		{
+			name:     "connection type",
+			itemType: models.ItemType("CONNECTION"),
+			expected: "CONNECTION",
+		},
 		{
 			name:     "unknown type",
 			itemType: models.ItemType("UNKNOWN"),
@@ -0,0 +1,186 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"gophkeeper/internal/errs"
+	"gophkeeper/models"
+	"time"
+
+	gen "gophkeeper/internal/server/repositories/database/generated"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type DeviceDatabase interface {
+	RegisterDevice(ctx context.Context, device *models.Device) (*models.Device, error)
+	GetDevice(ctx context.Context, login string, id [16]byte) (*models.Device, error)
+	ListDevices(ctx context.Context, login string) ([]models.Device, error)
+	RemoveDevice(ctx context.Context, login string, id [16]byte) error
+	ApproveDevice(ctx context.Context, login string, id [16]byte) error
+	TouchDevice(ctx context.Context, login string, id [16]byte, ip string) error
+	UseDeviceSignIn(ctx context.Context, login string, id [16]byte, signedAt time.Time) (bool, error)
+	GetDeviceApprovalRequired(ctx context.Context, login string) (bool, error)
+	SetDeviceApprovalRequired(ctx context.Context, login string, required bool) error
+}
+
+type DeviceDB struct {
+	q    *gen.Queries
+	pool PoolInterface
+}
+
+var _ DeviceDatabase = (*DeviceDB)(nil)
+
+func NewDeviceDB(q *gen.Queries, pool PoolInterface) (DeviceDatabase, error) {
+	if pool == nil || q == nil {
+		return nil, errors.New("create device database error: pool or quaries is nil")
+	}
+	return &DeviceDB{
+		q:    q,
+		pool: pool,
+	}, nil
+}
+
+func deviceFromDB(d gen.Device) models.Device {
+	return models.Device{
+		ID:         d.ID.Bytes,
+		Login:      d.UserLogin,
+		Name:       d.Name,
+		OS:         d.Os,
+		PublicKey:  d.PublicKey,
+		Approved:   d.Approved,
+		CreatedAt:  d.CreatedAt.Time,
+		LastSeenAt: d.LastSeenAt.Time,
+		LastSeenIP: d.LastSeenIp,
+	}
+}
+
+// RegisterDevice adds the device or refreshes its name, OS and last seen
+// address. An already stored public key is kept as it is. Approval is kept
+// too, unless the device gets its first key: then it takes the approval of
+// the request, like a new device.
+func (db *DeviceDB) RegisterDevice(ctx context.Context, device *models.Device) (*models.Device, error) {
+	d, err := db.q.UpsertDevice(ctx, gen.UpsertDeviceParams{
+		ID:         pgtype.UUID{Bytes: device.ID, Valid: true},
+		UserLogin:  device.Login,
+		Name:       device.Name,
+		Os:         device.OS,
+		PublicKey:  device.PublicKey,
+		Approved:   device.Approved,
+		LastSeenIp: device.LastSeenIP,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("register device error: %w", err)
+	}
+	stored := deviceFromDB(d)
+	return &stored, nil
+}
+
+func (db *DeviceDB) GetDevice(ctx context.Context, login string, id [16]byte) (*models.Device, error) {
+	d, err := db.q.GetDevice(ctx, gen.GetDeviceParams{
+		UserLogin: login,
+		ID:        pgtype.UUID{Bytes: id, Valid: true},
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, errs.ErrDeviceNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get device error: %w", err)
+	}
+	device := deviceFromDB(d)
+	return &device, nil
+}
+
+// ListDevices returns the devices of the user, most recently seen first.
+func (db *DeviceDB) ListDevices(ctx context.Context, login string) ([]models.Device, error) {
+	rows, err := db.q.ListDevices(ctx, login)
+	if err != nil {
+		return nil, fmt.Errorf("list devices error: %w", err)
+	}
+	devices := make([]models.Device, 0, len(rows))
+	for _, d := range rows {
+		devices = append(devices, deviceFromDB(d))
+	}
+	return devices, nil
+}
+
+func (db *DeviceDB) RemoveDevice(ctx context.Context, login string, id [16]byte) error {
+	n, err := db.q.DeleteDevice(ctx, gen.DeleteDeviceParams{
+		UserLogin: login,
+		ID:        pgtype.UUID{Bytes: id, Valid: true},
+	})
+	if err != nil {
+		return fmt.Errorf("remove device error: %w", err)
+	}
+	if n == 0 {
+		return errs.ErrDeviceNotFound
+	}
+	return nil
+}
+
+func (db *DeviceDB) ApproveDevice(ctx context.Context, login string, id [16]byte) error {
+	n, err := db.q.ApproveDevice(ctx, gen.ApproveDeviceParams{
+		UserLogin: login,
+		ID:        pgtype.UUID{Bytes: id, Valid: true},
+	})
+	if err != nil {
+		return fmt.Errorf("approve device error: %w", err)
+	}
+	if n == 0 {
+		return errs.ErrDeviceNotFound
+	}
+	return nil
+}
+
+func (db *DeviceDB) TouchDevice(ctx context.Context, login string, id [16]byte, ip string) error {
+	err := db.q.TouchDevice(ctx, gen.TouchDeviceParams{
+		UserLogin:  login,
+		ID:         pgtype.UUID{Bytes: id, Valid: true},
+		LastSeenIp: ip,
+	})
+	if err != nil {
+		return fmt.Errorf("touch device error: %w", err)
+	}
+	return nil
+}
+
+// UseDeviceSignIn records signedAt as the last sign in of the device. It
+// reports false when the device already signed in at that time or later.
+func (db *DeviceDB) UseDeviceSignIn(ctx context.Context, login string, id [16]byte, signedAt time.Time) (bool, error) {
+	n, err := db.q.UseDeviceSignIn(ctx, gen.UseDeviceSignInParams{
+		UserLogin: login,
+		DeviceID:  pgtype.UUID{Bytes: id, Valid: true},
+		SignedAt:  pgtype.Timestamptz{Time: signedAt, Valid: true},
+	})
+	if err != nil {
+		return false, fmt.Errorf("use device sign in error: %w", err)
+	}
+	return n > 0, nil
+}
+
+func (db *DeviceDB) GetDeviceApprovalRequired(ctx context.Context, login string) (bool, error) {
+	required, err := db.q.GetDeviceApprovalRequired(ctx, login)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, errs.ErrUserNotFound
+	}
+	if err != nil {
+		return false, fmt.Errorf("get device approval error: %w", err)
+	}
+	return required, nil
+}
+
+func (db *DeviceDB) SetDeviceApprovalRequired(ctx context.Context, login string, required bool) error {
+	n, err := db.q.SetDeviceApprovalRequired(ctx, gen.SetDeviceApprovalRequiredParams{
+		Login:                 login,
+		RequireDeviceApproval: required,
+	})
+	if err != nil {
+		return fmt.Errorf("set device approval error: %w", err)
+	}
+	if n == 0 {
+		return errs.ErrUserNotFound
+	}
+	return nil
+}
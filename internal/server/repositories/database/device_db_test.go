@@ -0,0 +1,133 @@
+package database
+
+import (
+	"context"
+	"gophkeeper/internal/errs"
+	gen "gophkeeper/internal/server/repositories/database/generated"
+	"gophkeeper/models"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pashagolub/pgxmock/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var deviceTestID = [16]byte{0xde, 0x01}
+
+var deviceColumns = []string{
+	"id", "user_login", "name", "os", "public_key", "approved", "created_at", "last_seen_at", "last_seen_ip",
+}
+
+func newTestDeviceDB(t *testing.T) (DeviceDatabase, pgxmock.PgxPoolIface) {
+	t.Helper()
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	t.Cleanup(mock.Close)
+
+	deviceDB, err := NewDeviceDB(gen.New(mock), mock)
+	require.NoError(t, err)
+	return deviceDB, mock
+}
+
+func TestNewDeviceDB(t *testing.T) {
+	_, err := NewDeviceDB(nil, nil)
+	assert.Error(t, err)
+}
+
+func TestDeviceDB_RegisterDevice(t *testing.T) {
+	deviceDB, mock := newTestDeviceDB(t)
+	seen := time.Now()
+	id := pgtype.UUID{Bytes: deviceTestID, Valid: true}
+	mock.ExpectQuery("INSERT INTO devices").
+		WithArgs(id, "alice", "laptop", "linux", []byte("key"), false, "10.0.0.1").
+		WillReturnRows(pgxmock.NewRows(deviceColumns).AddRow(
+			id, "alice", "laptop", "linux", []byte("old key"), true,
+			pgtype.Timestamp{Time: seen, Valid: true}, pgtype.Timestamptz{Time: seen, Valid: true}, "10.0.0.1",
+		))
+
+	device, err := deviceDB.RegisterDevice(context.Background(), &models.Device{
+		ID:         deviceTestID,
+		Login:      "alice",
+		Name:       "laptop",
+		OS:         "linux",
+		PublicKey:  []byte("key"),
+		LastSeenIP: "10.0.0.1",
+	})
+	require.NoError(t, err)
+	assert.True(t, device.Approved)
+	assert.Equal(t, []byte("old key"), device.PublicKey)
+	assert.Equal(t, seen, device.LastSeenAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeviceDB_GetDevice_NotFound(t *testing.T) {
+	deviceDB, mock := newTestDeviceDB(t)
+	mock.ExpectQuery("SELECT .* FROM devices").
+		WithArgs("alice", pgtype.UUID{Bytes: deviceTestID, Valid: true}).
+		WillReturnError(pgx.ErrNoRows)
+
+	_, err := deviceDB.GetDevice(context.Background(), "alice", deviceTestID)
+	assert.ErrorIs(t, err, errs.ErrDeviceNotFound)
+}
+
+func TestDeviceDB_ListDevices(t *testing.T) {
+	deviceDB, mock := newTestDeviceDB(t)
+	now := time.Now()
+	created := pgtype.Timestamp{Time: now, Valid: true}
+	seen := pgtype.Timestamptz{Time: now, Valid: true}
+	mock.ExpectQuery("SELECT .* FROM devices").WithArgs("alice").
+		WillReturnRows(pgxmock.NewRows(deviceColumns).
+			AddRow(pgtype.UUID{Bytes: deviceTestID, Valid: true}, "alice", "laptop", "linux", nil, true, created, seen, "10.0.0.1").
+			AddRow(pgtype.UUID{Bytes: [16]byte{2}, Valid: true}, "alice", "ci", "linux", nil, false, created, seen, ""))
+
+	devices, err := deviceDB.ListDevices(context.Background(), "alice")
+	require.NoError(t, err)
+	require.Len(t, devices, 2)
+	assert.Equal(t, deviceTestID, devices[0].ID)
+	assert.False(t, devices[1].Approved)
+}
+
+func TestDeviceDB_RemoveDevice_NotFound(t *testing.T) {
+	deviceDB, mock := newTestDeviceDB(t)
+	mock.ExpectExec("DELETE FROM devices").
+		WithArgs("alice", pgtype.UUID{Bytes: deviceTestID, Valid: true}).
+		WillReturnResult(pgxmock.NewResult("DELETE", 0))
+
+	err := deviceDB.RemoveDevice(context.Background(), "alice", deviceTestID)
+	assert.ErrorIs(t, err, errs.ErrDeviceNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeviceDB_UseDeviceSignIn(t *testing.T) {
+	deviceDB, mock := newTestDeviceDB(t)
+	signedAt := time.Now()
+	args := []any{"alice", pgtype.UUID{Bytes: deviceTestID, Valid: true}, pgtype.Timestamptz{Time: signedAt, Valid: true}}
+	mock.ExpectExec("INSERT INTO device_sign_ins").WithArgs(args...).WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	mock.ExpectExec("INSERT INTO device_sign_ins").WithArgs(args...).WillReturnResult(pgxmock.NewResult("INSERT", 0))
+
+	used, err := deviceDB.UseDeviceSignIn(context.Background(), "alice", deviceTestID, signedAt)
+	require.NoError(t, err)
+	assert.True(t, used)
+
+	used, err = deviceDB.UseDeviceSignIn(context.Background(), "alice", deviceTestID, signedAt)
+	require.NoError(t, err)
+	assert.False(t, used)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeviceDB_DeviceApprovalRequired(t *testing.T) {
+	deviceDB, mock := newTestDeviceDB(t)
+	mock.ExpectExec("UPDATE users").WithArgs("alice", true).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	mock.ExpectQuery("SELECT require_device_approval").WithArgs("alice").
+		WillReturnRows(pgxmock.NewRows([]string{"require_device_approval"}).AddRow(true))
+
+	require.NoError(t, deviceDB.SetDeviceApprovalRequired(context.Background(), "alice", true))
+	required, err := deviceDB.GetDeviceApprovalRequired(context.Background(), "alice")
+	require.NoError(t, err)
+	assert.True(t, required)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,278 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"gophkeeper/internal/errs"
+	"gophkeeper/models"
+	"time"
+
+	gen "gophkeeper/internal/server/repositories/database/generated"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type EmergencyDatabase interface {
+	UpsertEmergencyAccess(ctx context.Context, access *models.EmergencyAccess) error
+	GetEmergencyAccess(ctx context.Context, grantor, grantee string) (*models.EmergencyAccess, error)
+	ListEmergencyAccessByGrantor(ctx context.Context, grantor string) ([]models.EmergencyAccess, error)
+	ListEmergencyAccessByGrantee(ctx context.Context, grantee string) ([]models.EmergencyAccess, error)
+	RequestEmergencyAccess(ctx context.Context, grantor, grantee string) error
+	ApproveEmergencyAccess(ctx context.Context, grantor, grantee string) error
+	DenyEmergencyAccess(ctx context.Context, grantor, grantee string) error
+	DeleteEmergencyAccess(ctx context.Context, grantor, grantee string) error
+	TakeoverAccount(ctx context.Context, t *models.EmergencyTakeover, passwordHash []byte) error
+}
+
+type EmergencyDB struct {
+	q    *gen.Queries
+	pool PoolInterface
+}
+
+var _ EmergencyDatabase = (*EmergencyDB)(nil)
+
+func NewEmergencyDB(q *gen.Queries, pool PoolInterface) (EmergencyDatabase, error) {
+	if pool == nil || q == nil {
+		return nil, errors.New("create emergency database error: pool or quaries is nil")
+	}
+	return &EmergencyDB{
+		q:    q,
+		pool: pool,
+	}, nil
+}
+
+func emergencyAccessFromDB(e gen.EmergencyAccess) models.EmergencyAccess {
+	return models.EmergencyAccess{
+		Grantor:     e.Grantor,
+		Grantee:     e.Grantee,
+		Type:        models.EmergencyAccessType(e.AccessType),
+		Status:      models.EmergencyStatus(e.Status),
+		WaitPeriod:  time.Duration(e.WaitHours) * time.Hour,
+		WrappedKey:  e.WrappedKey,
+		RequestedAt: e.RequestedAt.Time,
+		CreatedAt:   e.CreatedAt.Time,
+	}
+}
+
+// UpsertEmergencyAccess creates the grant or replaces its settings and key.
+// Any pending request is dropped.
+func (db *EmergencyDB) UpsertEmergencyAccess(ctx context.Context, access *models.EmergencyAccess) error {
+	err := db.q.UpsertEmergencyAccess(ctx, gen.UpsertEmergencyAccessParams{
+		Grantor:    access.Grantor,
+		Grantee:    access.Grantee,
+		AccessType: gen.EmergencyAccessType(access.Type),
+		WaitHours:  int32(access.WaitPeriod / time.Hour),
+		WrappedKey: access.WrappedKey,
+	})
+	if err != nil {
+		return fmt.Errorf("upsert emergency access error: %w", err)
+	}
+	return nil
+}
+
+func (db *EmergencyDB) GetEmergencyAccess(ctx context.Context, grantor, grantee string) (*models.EmergencyAccess, error) {
+	e, err := db.q.GetEmergencyAccess(ctx, gen.GetEmergencyAccessParams{
+		Grantor: grantor,
+		Grantee: grantee,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, errs.ErrEmergencyAccessNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get emergency access error: %w", err)
+	}
+	access := emergencyAccessFromDB(e)
+	return &access, nil
+}
+
+func (db *EmergencyDB) ListEmergencyAccessByGrantor(ctx context.Context, grantor string) ([]models.EmergencyAccess, error) {
+	rows, err := db.q.ListEmergencyAccessByGrantor(ctx, grantor)
+	if err != nil {
+		return nil, fmt.Errorf("list emergency access by grantor error: %w", err)
+	}
+	access := make([]models.EmergencyAccess, len(rows))
+	for i, r := range rows {
+		access[i] = emergencyAccessFromDB(r)
+	}
+	return access, nil
+}
+
+func (db *EmergencyDB) ListEmergencyAccessByGrantee(ctx context.Context, grantee string) ([]models.EmergencyAccess, error) {
+	rows, err := db.q.ListEmergencyAccessByGrantee(ctx, grantee)
+	if err != nil {
+		return nil, fmt.Errorf("list emergency access by grantee error: %w", err)
+	}
+	access := make([]models.EmergencyAccess, len(rows))
+	for i, r := range rows {
+		access[i] = emergencyAccessFromDB(r)
+	}
+	return access, nil
+}
+
+func (db *EmergencyDB) RequestEmergencyAccess(ctx context.Context, grantor, grantee string) error {
+	n, err := db.q.RequestEmergencyAccess(ctx, gen.RequestEmergencyAccessParams{
+		Grantor: grantor,
+		Grantee: grantee,
+	})
+	if err != nil {
+		return fmt.Errorf("request emergency access error: %w", err)
+	}
+	if n == 0 {
+		return errs.ErrEmergencyAlreadyRequested
+	}
+	return nil
+}
+
+func (db *EmergencyDB) ApproveEmergencyAccess(ctx context.Context, grantor, grantee string) error {
+	n, err := db.q.ApproveEmergencyAccess(ctx, gen.ApproveEmergencyAccessParams{
+		Grantor: grantor,
+		Grantee: grantee,
+	})
+	if err != nil {
+		return fmt.Errorf("approve emergency access error: %w", err)
+	}
+	if n == 0 {
+		return errs.ErrEmergencyNotRequested
+	}
+	return nil
+}
+
+func (db *EmergencyDB) DenyEmergencyAccess(ctx context.Context, grantor, grantee string) error {
+	n, err := db.q.DenyEmergencyAccess(ctx, gen.DenyEmergencyAccessParams{
+		Grantor: grantor,
+		Grantee: grantee,
+	})
+	if err != nil {
+		return fmt.Errorf("deny emergency access error: %w", err)
+	}
+	if n == 0 {
+		return errs.ErrEmergencyNotRequested
+	}
+	return nil
+}
+
+func (db *EmergencyDB) DeleteEmergencyAccess(ctx context.Context, grantor, grantee string) error {
+	n, err := db.q.DeleteEmergencyAccess(ctx, gen.DeleteEmergencyAccessParams{
+		Grantor: grantor,
+		Grantee: grantee,
+	})
+	if err != nil {
+		return fmt.Errorf("delete emergency access error: %w", err)
+	}
+	if n == 0 {
+		return errs.ErrEmergencyAccessNotFound
+	}
+	return nil
+}
+
+// TakeoverAccount replaces the grantor's password, private key seal,
+// personal item keys and templates in one transaction. The items and
+// templates must cover the grantor's exactly. The grantor's sessions are revoked and all grants
+// made by the grantor are dropped since their wrapped keys are stale.
+func (db *EmergencyDB) TakeoverAccount(ctx context.Context, t *models.EmergencyTakeover, passwordHash []byte) error {
+	tx, err := db.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin tx error: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	q := db.q.WithTx(tx)
+	current, err := q.GetAllUserItems(ctx, t.Grantor)
+	if err != nil {
+		return fmt.Errorf("get grantor items error: %w", err)
+	}
+	ids := make(map[[16]byte]bool, len(current))
+	for _, item := range current {
+		ids[item.ID.Bytes] = true
+	}
+	if len(ids) != len(t.Items) {
+		return errs.ErrEmergencyItemsMismatch
+	}
+	for _, item := range t.Items {
+		if !ids[item.ID] {
+			return errs.ErrEmergencyItemsMismatch
+		}
+		delete(ids, item.ID)
+
+		meta, err := json.Marshal(item.Meta)
+		if err != nil {
+			return fmt.Errorf("marshal meta info error: %w", err)
+		}
+		if _, err := q.RekeyItem(ctx, gen.RekeyItemParams{
+			ID:                     pgUUID(item.ID),
+			UserLogin:              t.Grantor,
+			EncryptedDataContent:   item.EncryptedData.EncryptedContent,
+			EncryptedDataNonce:     item.EncryptedData.Nonce,
+			EncryptedHeaderContent: item.EncryptedHeader.EncryptedContent,
+			EncryptedHeaderNonce:   item.EncryptedHeader.Nonce,
+			EncryptedKey:           item.EncryptedKey,
+			Format:                 itemFormatModelsToPg(item.Format),
+			Name:                   item.Name,
+			Meta:                   meta,
+			SearchTokens:           searchTokensModelsToPg(item.SearchTokens),
+		}); err != nil {
+			return fmt.Errorf("rekey item error: %w", err)
+		}
+	}
+
+	templates, err := q.ListTemplates(ctx, t.Grantor)
+	if err != nil {
+		return fmt.Errorf("get grantor templates error: %w", err)
+	}
+	templateIDs := make(map[[16]byte]bool, len(templates))
+	for _, template := range templates {
+		templateIDs[template.ID.Bytes] = true
+	}
+	if len(templateIDs) != len(t.Templates) {
+		return errs.ErrEmergencyTemplatesMismatch
+	}
+	for _, template := range t.Templates {
+		if !templateIDs[template.ID] {
+			return errs.ErrEmergencyTemplatesMismatch
+		}
+		delete(templateIDs, template.ID)
+
+		if err := q.SaveTemplate(ctx, gen.SaveTemplateParams{
+			ID:                   pgUUID(template.ID),
+			UserLogin:            t.Grantor,
+			EncryptedDataContent: template.EncryptedData.EncryptedContent,
+			EncryptedDataNonce:   template.EncryptedData.Nonce,
+		}); err != nil {
+			return fmt.Errorf("reseal template error: %w", err)
+		}
+	}
+
+	n, err := q.UpdateUserPassword(ctx, gen.UpdateUserPasswordParams{
+		Login:    t.Grantor,
+		Password: passwordHash,
+	})
+	if err != nil {
+		return fmt.Errorf("update password error: %w", err)
+	}
+	if n == 0 {
+		return errs.ErrUserNotFound
+	}
+
+	if t.EncryptedPrivateKey != "" {
+		if _, err := q.UpdateUserPrivateKey(ctx, gen.UpdateUserPrivateKeyParams{
+			Login:               t.Grantor,
+			EncryptedPrivateKey: t.EncryptedPrivateKey,
+		}); err != nil {
+			return fmt.Errorf("update private key error: %w", err)
+		}
+	}
+
+	if _, err := q.RevokeUserSessions(ctx, t.Grantor); err != nil {
+		return fmt.Errorf("revoke sessions error: %w", err)
+	}
+	if err := q.DeleteEmergencyAccessOfGrantor(ctx, t.Grantor); err != nil {
+		return fmt.Errorf("delete emergency access error: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit tx error: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,186 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"gophkeeper/internal/errs"
+	gen "gophkeeper/internal/server/repositories/database/generated"
+	"gophkeeper/models"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pashagolub/pgxmock/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	emergencyTestItemID     = [16]byte{0x0e, 0x01}
+	emergencyTestTemplateID = [16]byte{0x0e, 0x02}
+)
+
+func newTestEmergencyDB(t *testing.T) (EmergencyDatabase, pgxmock.PgxPoolIface) {
+	t.Helper()
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	t.Cleanup(mock.Close)
+
+	emergencyDB, err := NewEmergencyDB(gen.New(mock), mock)
+	require.NoError(t, err)
+	return emergencyDB, mock
+}
+
+func TestNewEmergencyDB(t *testing.T) {
+	_, err := NewEmergencyDB(nil, nil)
+	assert.Error(t, err)
+}
+
+func TestEmergencyDB_GetEmergencyAccess(t *testing.T) {
+	requested := time.Now().Add(-time.Hour)
+
+	t.Run("success", func(t *testing.T) {
+		emergencyDB, mock := newTestEmergencyDB(t)
+		mock.ExpectQuery("SELECT .* FROM emergency_access").WithArgs("alice", "bob").
+			WillReturnRows(pgxmock.NewRows([]string{
+				"grantor", "grantee", "access_type", "status", "wait_hours", "wrapped_key", "requested_at", "created_at",
+			}).AddRow(
+				"alice", "bob", gen.EmergencyAccessTypeTAKEOVER, gen.EmergencyStatusREQUESTED, int32(48), "wrapped",
+				pgtype.Timestamptz{Time: requested, Valid: true}, pgtype.Timestamp{Time: requested, Valid: true},
+			))
+
+		access, err := emergencyDB.GetEmergencyAccess(context.Background(), "alice", "bob")
+		require.NoError(t, err)
+		assert.Equal(t, models.EmergencyAccessTAKEOVER, access.Type)
+		assert.Equal(t, models.EmergencyStatusREQUESTED, access.Status)
+		assert.Equal(t, 48*time.Hour, access.WaitPeriod)
+		assert.Equal(t, requested.Add(48*time.Hour), access.AccessibleAt())
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		emergencyDB, mock := newTestEmergencyDB(t)
+		mock.ExpectQuery("SELECT .* FROM emergency_access").WithArgs("alice", "carol").
+			WillReturnError(pgx.ErrNoRows)
+
+		_, err := emergencyDB.GetEmergencyAccess(context.Background(), "alice", "carol")
+		assert.ErrorIs(t, err, errs.ErrEmergencyAccessNotFound)
+	})
+}
+
+func TestEmergencyDB_RequestEmergencyAccess(t *testing.T) {
+	emergencyDB, mock := newTestEmergencyDB(t)
+	mock.ExpectExec("UPDATE emergency_access").WithArgs("alice", "bob").
+		WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+
+	err := emergencyDB.RequestEmergencyAccess(context.Background(), "alice", "bob")
+	assert.ErrorIs(t, err, errs.ErrEmergencyAlreadyRequested)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestEmergencyDB_DenyEmergencyAccess(t *testing.T) {
+	emergencyDB, mock := newTestEmergencyDB(t)
+	mock.ExpectExec("UPDATE emergency_access").WithArgs("alice", "bob").
+		WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+
+	err := emergencyDB.DenyEmergencyAccess(context.Background(), "alice", "bob")
+	assert.ErrorIs(t, err, errs.ErrEmergencyNotRequested)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestEmergencyDB_TakeoverAccount(t *testing.T) {
+	itemID := pgtype.UUID{Bytes: emergencyTestItemID, Valid: true}
+	now := pgtype.Timestamp{Time: time.Now(), Valid: true}
+	itemRows := func() *pgxmock.Rows {
+		return pgxmock.NewRows([]string{
+			"id", "name", "type", "encrypted_data_content",
+			"encrypted_data_nonce", "encrypted_header_content", "encrypted_header_nonce", "encrypted_key", "meta", "format", "search_tokens", "expires_at", "created_at", "updated_at", "favorite", "last_used_at", "use_count",
+		}).AddRow(itemID, "bank", "CREDENTIALS", "old_content", "old_nonce", "", "", "old_key", []byte(`{"Map":null}`), int16(1), []string{}, pgtype.Timestamptz{}, now, now, false, pgtype.Timestamp{}, int32(0))
+	}
+	templateID := pgtype.UUID{Bytes: emergencyTestTemplateID, Valid: true}
+	templateRows := func() *pgxmock.Rows {
+		return pgxmock.NewRows([]string{
+			"id", "user_login", "encrypted_data_content", "encrypted_data_nonce", "created_at", "updated_at",
+		}).AddRow(templateID, "alice", "old_template", "old_template_nonce", now, now)
+	}
+	takeover := &models.EmergencyTakeover{
+		Grantor:             "alice",
+		Password:            "encrypted",
+		EncryptedPrivateKey: "new_private",
+		Items: []models.EncryptedItem{{
+			ID:            emergencyTestItemID,
+			EncryptedData: models.EncryptedData{EncryptedContent: "new_content", Nonce: "new_nonce"},
+			EncryptedKey:  "new_key",
+		}},
+		Templates: []models.Template{{
+			ID:            emergencyTestTemplateID,
+			EncryptedData: models.EncryptedData{EncryptedContent: "new_template", Nonce: "new_template_nonce"},
+		}},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		emergencyDB, mock := newTestEmergencyDB(t)
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT.*FROM items").WithArgs("alice").WillReturnRows(itemRows())
+		mock.ExpectExec("UPDATE items").
+			WithArgs(itemID, "alice", "new_content", "new_nonce", "new_key", int16(1), "", []byte(`{"Map":null}`), []string{}, "", "").
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+		mock.ExpectQuery("SELECT .* FROM item_templates").WithArgs("alice").WillReturnRows(templateRows())
+		mock.ExpectExec("INSERT INTO item_templates").
+			WithArgs(templateID, "alice", "new_template", "new_template_nonce").
+			WillReturnResult(pgxmock.NewResult("INSERT", 1))
+		mock.ExpectExec("UPDATE users").WithArgs("alice", []byte("hash")).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+		mock.ExpectExec("UPDATE user_keys").WithArgs("alice", "new_private").
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+		mock.ExpectExec("UPDATE users").WithArgs("alice").
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+		mock.ExpectExec("DELETE FROM emergency_access").WithArgs("alice").
+			WillReturnResult(pgxmock.NewResult("DELETE", 1))
+		mock.ExpectCommit()
+
+		require.NoError(t, emergencyDB.TakeoverAccount(context.Background(), takeover, []byte("hash")))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("items mismatch", func(t *testing.T) {
+		emergencyDB, mock := newTestEmergencyDB(t)
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT.*FROM items").WithArgs("alice").WillReturnRows(itemRows())
+		mock.ExpectRollback()
+
+		partial := *takeover
+		partial.Items = nil
+		err := emergencyDB.TakeoverAccount(context.Background(), &partial, []byte("hash"))
+		assert.ErrorIs(t, err, errs.ErrEmergencyItemsMismatch)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("templates mismatch", func(t *testing.T) {
+		emergencyDB, mock := newTestEmergencyDB(t)
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT.*FROM items").WithArgs("alice").WillReturnRows(itemRows())
+		mock.ExpectExec("UPDATE items").WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(),
+			pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg()).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+		mock.ExpectQuery("SELECT .* FROM item_templates").WithArgs("alice").WillReturnRows(templateRows())
+		mock.ExpectRollback()
+
+		partial := *takeover
+		partial.Templates = nil
+		err := emergencyDB.TakeoverAccount(context.Background(), &partial, []byte("hash"))
+		assert.ErrorIs(t, err, errs.ErrEmergencyTemplatesMismatch)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("rollback on error", func(t *testing.T) {
+		emergencyDB, mock := newTestEmergencyDB(t)
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT.*FROM items").WithArgs("alice").WillReturnError(fmt.Errorf("db error"))
+		mock.ExpectRollback()
+
+		assert.Error(t, emergencyDB.TakeoverAccount(context.Background(), takeover, []byte("hash")))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
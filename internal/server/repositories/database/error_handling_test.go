@@ -36,16 +36,25 @@ func TestErrorHandling_InvalidJSONMeta(t *testing.T) {
 		WithArgs("testuser").
 		WillReturnRows(pgxmock.NewRows([]string{
 			"id", "name", "type", "encrypted_data_content",
-			"encrypted_data_nonce", "meta", "created_at", "updated_at",
+			"encrypted_data_nonce", "encrypted_header_content", "encrypted_header_nonce", "encrypted_key", "meta", "format", "search_tokens", "expires_at", "created_at", "updated_at", "favorite", "last_used_at", "use_count",
 		}).AddRow(
 			testUUID,
 			"test item",
 			"CREDENTIALS",
 			"encrypted_content",
 			"test_nonce",
+			"",
+			"",
+			"",
 			[]byte("invalid json"),
+			int16(1),
+			[]string{},
+			pgtype.Timestamptz{},
 			pgtype.Timestamp{Time: time.Now(), Valid: true},
 			pgtype.Timestamp{Time: time.Now(), Valid: true},
+			false,
+			pgtype.Timestamp{},
+			int32(0),
 		))
 
 	items, err := itemDB.GetAllUserItems(context.Background(), "testuser")
@@ -95,16 +104,25 @@ func TestErrorHandling_QueryScanFailure(t *testing.T) {
 	// Test scan failure during GetAllUserItems
 	rows := pgxmock.NewRows([]string{
 		"id", "name", "type", "encrypted_data_content",
-		"encrypted_data_nonce", "meta", "created_at", "updated_at",
+		"encrypted_data_nonce", "encrypted_header_content", "encrypted_header_nonce", "encrypted_key", "meta", "format", "search_tokens", "expires_at", "created_at", "updated_at", "favorite", "last_used_at", "use_count",
 	}).AddRow(
 		"invalid_uuid_format", // This will cause scan failure
 		"test item",
 		"CREDENTIALS",
 		"encrypted_content",
 		"test_nonce",
+		"",
+		"",
+		"",
 		[]byte(`{"Map":null}`),
+		int16(1),
+		[]string{},
+		pgtype.Timestamptz{},
 		pgtype.Timestamp{Time: time.Now(), Valid: true},
 		pgtype.Timestamp{Time: time.Now(), Valid: true},
+		false,
+		pgtype.Timestamp{},
+		int32(0),
 	).RowError(0, fmt.Errorf("scan error"))
 
 	mock.ExpectQuery("SELECT.*FROM items").
@@ -11,6 +11,91 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+type EmergencyAccessType string
+
+const (
+	EmergencyAccessTypeVIEW     EmergencyAccessType = "VIEW"
+	EmergencyAccessTypeTAKEOVER EmergencyAccessType = "TAKEOVER"
+)
+
+func (e *EmergencyAccessType) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = EmergencyAccessType(s)
+	case string:
+		*e = EmergencyAccessType(s)
+	default:
+		return fmt.Errorf("unsupported scan type for EmergencyAccessType: %T", src)
+	}
+	return nil
+}
+
+type NullEmergencyAccessType struct {
+	EmergencyAccessType EmergencyAccessType `json:"emergency_access_type"`
+	Valid               bool                `json:"valid"` // Valid is true if EmergencyAccessType is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullEmergencyAccessType) Scan(value interface{}) error {
+	if value == nil {
+		ns.EmergencyAccessType, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.EmergencyAccessType.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullEmergencyAccessType) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.EmergencyAccessType), nil
+}
+
+type EmergencyStatus string
+
+const (
+	EmergencyStatusIDLE      EmergencyStatus = "IDLE"
+	EmergencyStatusREQUESTED EmergencyStatus = "REQUESTED"
+	EmergencyStatusAPPROVED  EmergencyStatus = "APPROVED"
+)
+
+func (e *EmergencyStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = EmergencyStatus(s)
+	case string:
+		*e = EmergencyStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for EmergencyStatus: %T", src)
+	}
+	return nil
+}
+
+type NullEmergencyStatus struct {
+	EmergencyStatus EmergencyStatus `json:"emergency_status"`
+	Valid           bool            `json:"valid"` // Valid is true if EmergencyStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullEmergencyStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.EmergencyStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.EmergencyStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullEmergencyStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.EmergencyStatus), nil
+}
+
 type ItemType string
 
 const (
@@ -18,6 +103,11 @@ const (
 	ItemTypeTEXT        ItemType = "TEXT"
 	ItemTypeBINARY      ItemType = "BINARY"
 	ItemTypeCARD        ItemType = "CARD"
+	ItemTypeSSHKEY      ItemType = "SSH_KEY"
+	ItemTypeTOTP        ItemType = "TOTP"
+	ItemTypeCUSTOM      ItemType = "CUSTOM"
+	ItemTypeIDENTITY    ItemType = "IDENTITY"
+	ItemTypeCONNECTION  ItemType = "CONNECTION"
 )
 
 func (e *ItemType) Scan(src interface{}) error {
@@ -55,20 +145,229 @@ func (ns NullItemType) Value() (driver.Value, error) {
 	return string(ns.ItemType), nil
 }
 
+type MembershipStatus string
+
+const (
+	MembershipStatusINVITED MembershipStatus = "INVITED"
+	MembershipStatusACTIVE  MembershipStatus = "ACTIVE"
+)
+
+func (e *MembershipStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = MembershipStatus(s)
+	case string:
+		*e = MembershipStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for MembershipStatus: %T", src)
+	}
+	return nil
+}
+
+type NullMembershipStatus struct {
+	MembershipStatus MembershipStatus `json:"membership_status"`
+	Valid            bool             `json:"valid"` // Valid is true if MembershipStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullMembershipStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.MembershipStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.MembershipStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullMembershipStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.MembershipStatus), nil
+}
+
+type OrgRole string
+
+const (
+	OrgRoleOWNER  OrgRole = "OWNER"
+	OrgRoleADMIN  OrgRole = "ADMIN"
+	OrgRoleEDITOR OrgRole = "EDITOR"
+	OrgRoleVIEWER OrgRole = "VIEWER"
+)
+
+func (e *OrgRole) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = OrgRole(s)
+	case string:
+		*e = OrgRole(s)
+	default:
+		return fmt.Errorf("unsupported scan type for OrgRole: %T", src)
+	}
+	return nil
+}
+
+type NullOrgRole struct {
+	OrgRole OrgRole `json:"org_role"`
+	Valid   bool    `json:"valid"` // Valid is true if OrgRole is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullOrgRole) Scan(value interface{}) error {
+	if value == nil {
+		ns.OrgRole, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.OrgRole.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullOrgRole) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.OrgRole), nil
+}
+
+type AuditEvent struct {
+	ID        int64            `json:"id"`
+	Login     string           `json:"login"`
+	Action    string           `json:"action"`
+	ItemID    pgtype.UUID      `json:"item_id"`
+	CreatedAt pgtype.Timestamp `json:"created_at"`
+}
+
+type Collection struct {
+	ID           pgtype.UUID      `json:"id"`
+	OrgID        pgtype.UUID      `json:"org_id"`
+	Name         string           `json:"name"`
+	EncryptedKey string           `json:"encrypted_key"`
+	CreatedAt    pgtype.Timestamp `json:"created_at"`
+}
+
+type Device struct {
+	ID         pgtype.UUID        `json:"id"`
+	UserLogin  string             `json:"user_login"`
+	Name       string             `json:"name"`
+	Os         string             `json:"os"`
+	PublicKey  []byte             `json:"public_key"`
+	Approved   bool               `json:"approved"`
+	CreatedAt  pgtype.Timestamp   `json:"created_at"`
+	LastSeenAt pgtype.Timestamptz `json:"last_seen_at"`
+	LastSeenIp string             `json:"last_seen_ip"`
+}
+
+type DeviceSignIn struct {
+	UserLogin string             `json:"user_login"`
+	DeviceID  pgtype.UUID        `json:"device_id"`
+	SignedAt  pgtype.Timestamptz `json:"signed_at"`
+}
+
+type EmergencyAccess struct {
+	Grantor     string              `json:"grantor"`
+	Grantee     string              `json:"grantee"`
+	AccessType  EmergencyAccessType `json:"access_type"`
+	Status      EmergencyStatus     `json:"status"`
+	WaitHours   int32               `json:"wait_hours"`
+	WrappedKey  string              `json:"wrapped_key"`
+	RequestedAt pgtype.Timestamptz  `json:"requested_at"`
+	CreatedAt   pgtype.Timestamp    `json:"created_at"`
+}
+
 type Item struct {
+	ID                     pgtype.UUID        `json:"id"`
+	UserLogin              string             `json:"user_login"`
+	Name                   string             `json:"name"`
+	EncryptedDataContent   string             `json:"encrypted_data_content"`
+	EncryptedDataNonce     string             `json:"encrypted_data_nonce"`
+	Type                   ItemType           `json:"type"`
+	Meta                   []byte             `json:"meta"`
+	CreatedAt              pgtype.Timestamp   `json:"created_at"`
+	UpdatedAt              pgtype.Timestamp   `json:"updated_at"`
+	EncryptedKey           string             `json:"encrypted_key"`
+	CollectionID           pgtype.UUID        `json:"collection_id"`
+	Format                 int16              `json:"format"`
+	SearchTokens           []string           `json:"search_tokens"`
+	ExpiresAt              pgtype.Timestamptz `json:"expires_at"`
+	Favorite               bool               `json:"favorite"`
+	LastUsedAt             pgtype.Timestamp   `json:"last_used_at"`
+	UseCount               int32              `json:"use_count"`
+	EncryptedHeaderContent string             `json:"encrypted_header_content"`
+	EncryptedHeaderNonce   string             `json:"encrypted_header_nonce"`
+}
+
+type ItemAttachment struct {
+	ID                   pgtype.UUID      `json:"id"`
+	ItemID               pgtype.UUID      `json:"item_id"`
+	EncryptedInfoContent string           `json:"encrypted_info_content"`
+	EncryptedInfoNonce   string           `json:"encrypted_info_nonce"`
+	Size                 int64            `json:"size"`
+	Content              []byte           `json:"content"`
+	Nonce                []byte           `json:"nonce"`
+	CreatedAt            pgtype.Timestamp `json:"created_at"`
+}
+
+type ItemTemplate struct {
 	ID                   pgtype.UUID      `json:"id"`
 	UserLogin            string           `json:"user_login"`
-	Name                 string           `json:"name"`
 	EncryptedDataContent string           `json:"encrypted_data_content"`
 	EncryptedDataNonce   string           `json:"encrypted_data_nonce"`
-	Type                 ItemType         `json:"type"`
-	Meta                 []byte           `json:"meta"`
 	CreatedAt            pgtype.Timestamp `json:"created_at"`
 	UpdatedAt            pgtype.Timestamp `json:"updated_at"`
 }
 
+type ItemShare struct {
+	ItemID         pgtype.UUID      `json:"item_id"`
+	RecipientLogin string           `json:"recipient_login"`
+	WrappedKey     string           `json:"wrapped_key"`
+	CreatedAt      pgtype.Timestamp `json:"created_at"`
+}
+
+type Membership struct {
+	OrgID      pgtype.UUID      `json:"org_id"`
+	Login      string           `json:"login"`
+	Role       OrgRole          `json:"role"`
+	Status     MembershipStatus `json:"status"`
+	WrappedKey string           `json:"wrapped_key"`
+	InvitedBy  string           `json:"invited_by"`
+	CreatedAt  pgtype.Timestamp `json:"created_at"`
+}
+
+type Organization struct {
+	ID             pgtype.UUID      `json:"id"`
+	Name           string           `json:"name"`
+	CreatedBy      string           `json:"created_by"`
+	CreatedAt      pgtype.Timestamp `json:"created_at"`
+	KeyRotationDue bool             `json:"key_rotation_due"`
+}
+
+type Send struct {
+	ID                   pgtype.UUID        `json:"id"`
+	OwnerLogin           string             `json:"owner_login"`
+	EncryptedDataContent string             `json:"encrypted_data_content"`
+	EncryptedDataNonce   string             `json:"encrypted_data_nonce"`
+	MaxViews             int32              `json:"max_views"`
+	Views                int32              `json:"views"`
+	ExpiresAt            pgtype.Timestamptz `json:"expires_at"`
+	CreatedAt            pgtype.Timestamp   `json:"created_at"`
+}
+
 type User struct {
-	Login    string `json:"login"`
-	Password []byte `json:"password"`
-	Salt     string `json:"salt"`
+	Login                 string             `json:"login"`
+	Password              []byte             `json:"password"`
+	Salt                  string             `json:"salt"`
+	Locked                bool               `json:"locked"`
+	SessionsRevokedAt     pgtype.Timestamptz `json:"sessions_revoked_at"`
+	DeletedAt             pgtype.Timestamp   `json:"deleted_at"`
+	CreatedAt             pgtype.Timestamp   `json:"created_at"`
+	RequireDeviceApproval bool               `json:"require_device_approval"`
+}
+
+type UserKey struct {
+	Login               string           `json:"login"`
+	PublicKey           []byte           `json:"public_key"`
+	EncryptedPrivateKey string           `json:"encrypted_private_key"`
+	CreatedAt           pgtype.Timestamp `json:"created_at"`
 }
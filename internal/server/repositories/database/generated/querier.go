@@ -11,14 +11,90 @@ import (
 )
 
 type Querier interface {
+	AcceptMembership(ctx context.Context, arg AcceptMembershipParams) (int64, error)
+	AddAttachment(ctx context.Context, arg AddAttachmentParams) (pgtype.UUID, error)
+	AddAuditEvent(ctx context.Context, arg AddAuditEventParams) error
 	AddItem(ctx context.Context, arg AddItemParams) (pgtype.UUID, error)
+	AddMembership(ctx context.Context, arg AddMembershipParams) (int64, error)
+	AddUserKeys(ctx context.Context, arg AddUserKeysParams) (int64, error)
+	ApproveDevice(ctx context.Context, arg ApproveDeviceParams) (int64, error)
+	ApproveEmergencyAccess(ctx context.Context, arg ApproveEmergencyAccessParams) (int64, error)
+	ConsumeSendView(ctx context.Context, id pgtype.UUID) (Send, error)
+	CreateCollection(ctx context.Context, arg CreateCollectionParams) (CreateCollectionRow, error)
+	CreateOrganization(ctx context.Context, arg CreateOrganizationParams) (CreateOrganizationRow, error)
+	CreateSend(ctx context.Context, arg CreateSendParams) (pgtype.UUID, error)
+	DeleteAttachment(ctx context.Context, arg DeleteAttachmentParams) (int64, error)
+	DeleteCollectionItem(ctx context.Context, arg DeleteCollectionItemParams) (int64, error)
+	DeleteDeletedUsers(ctx context.Context) (int64, error)
+	DeleteDevice(ctx context.Context, arg DeleteDeviceParams) (int64, error)
+	DeleteEmergencyAccess(ctx context.Context, arg DeleteEmergencyAccessParams) (int64, error)
+	DeleteEmergencyAccessOfGrantor(ctx context.Context, grantor string) error
+	DeleteExpiredItems(ctx context.Context) ([]DeleteExpiredItemsRow, error)
+	DeleteExpiredSends(ctx context.Context) (int64, error)
 	DeleteItem(ctx context.Context, arg DeleteItemParams) error
+	DeleteItemShare(ctx context.Context, arg DeleteItemShareParams) (int64, error)
+	DeleteItemsOfDeletedUsers(ctx context.Context) (int64, error)
+	DeleteMembership(ctx context.Context, arg DeleteMembershipParams) (int64, error)
+	DeleteSend(ctx context.Context, id pgtype.UUID) error
+	DeleteTemplate(ctx context.Context, arg DeleteTemplateParams) (int64, error)
+	DenyEmergencyAccess(ctx context.Context, arg DenyEmergencyAccessParams) (int64, error)
 	EditItem(ctx context.Context, arg EditItemParams) error
 	GetAllUserItems(ctx context.Context, userLogin string) ([]GetAllUserItemsRow, error)
+	GetAttachment(ctx context.Context, arg GetAttachmentParams) (ItemAttachment, error)
+	GetCollectionItems(ctx context.Context, collectionID pgtype.UUID) ([]GetCollectionItemsRow, error)
+	GetCollectionItemsWithType(ctx context.Context, arg GetCollectionItemsWithTypeParams) ([]GetCollectionItemsWithTypeRow, error)
+	GetCollectionRole(ctx context.Context, arg GetCollectionRoleParams) (GetCollectionRoleRow, error)
+	GetCollectionTypesCounts(ctx context.Context, collectionID pgtype.UUID) ([]GetCollectionTypesCountsRow, error)
+	GetDevice(ctx context.Context, arg GetDeviceParams) (Device, error)
+	GetDeviceApprovalRequired(ctx context.Context, login string) (bool, error)
+	GetEmergencyAccess(ctx context.Context, arg GetEmergencyAccessParams) (EmergencyAccess, error)
+	GetItemCollection(ctx context.Context, id pgtype.UUID) (pgtype.UUID, error)
+	GetItemOwner(ctx context.Context, id pgtype.UUID) (string, error)
+	GetMembership(ctx context.Context, arg GetMembershipParams) (Membership, error)
+	GetServerStats(ctx context.Context) (GetServerStatsRow, error)
 	GetTypesCounts(ctx context.Context, userLogin string) ([]GetTypesCountsRow, error)
-	GetUser(ctx context.Context, login string) (User, error)
+	GetUser(ctx context.Context, login string) (GetUserRow, error)
 	GetUserItemsWithType(ctx context.Context, arg GetUserItemsWithTypeParams) ([]GetUserItemsWithTypeRow, error)
+	GetUserKeys(ctx context.Context, login string) (UserKey, error)
+	ListAttachments(ctx context.Context, itemID pgtype.UUID) ([]ListAttachmentsRow, error)
+	ListCollections(ctx context.Context, orgID pgtype.UUID) ([]Collection, error)
+	ListDevices(ctx context.Context, userLogin string) ([]Device, error)
+	ListEmergencyAccessByGrantee(ctx context.Context, grantee string) ([]EmergencyAccess, error)
+	ListEmergencyAccessByGrantor(ctx context.Context, grantor string) ([]EmergencyAccess, error)
+	ListItemShares(ctx context.Context, itemID pgtype.UUID) ([]ItemShare, error)
+	ListMemberships(ctx context.Context, orgID pgtype.UUID) ([]Membership, error)
+	ListOrgAttachments(ctx context.Context, orgID pgtype.UUID) ([]ListOrgAttachmentsRow, error)
+	ListOrgItems(ctx context.Context, orgID pgtype.UUID) ([]ListOrgItemsRow, error)
+	ListSharedWithUser(ctx context.Context, recipientLogin string) ([]ListSharedWithUserRow, error)
+	ListTemplates(ctx context.Context, userLogin string) ([]ItemTemplate, error)
+	ListUserOrganizations(ctx context.Context, login string) ([]ListUserOrganizationsRow, error)
+	ListUsersStats(ctx context.Context) ([]ListUsersStatsRow, error)
+	MarkUserDeleted(ctx context.Context, login string) (int64, error)
+	RekeyCollectionItem(ctx context.Context, arg RekeyCollectionItemParams) (int64, error)
+	RekeyItem(ctx context.Context, arg RekeyItemParams) (int64, error)
+	RequestEmergencyAccess(ctx context.Context, arg RequestEmergencyAccessParams) (int64, error)
+	RevokeAllSessions(ctx context.Context) (int64, error)
+	RevokeUserSessions(ctx context.Context, login string) (int64, error)
+	SaveTemplate(ctx context.Context, arg SaveTemplateParams) error
+	SearchUserItems(ctx context.Context, arg SearchUserItemsParams) ([]SearchUserItemsRow, error)
+	SetDeviceApprovalRequired(ctx context.Context, arg SetDeviceApprovalRequiredParams) (int64, error)
+	SetItemFavorite(ctx context.Context, arg SetItemFavoriteParams) (int64, error)
+	SetOrgKeyRotationDue(ctx context.Context, arg SetOrgKeyRotationDueParams) error
+	SetUserLocked(ctx context.Context, arg SetUserLockedParams) (int64, error)
 	SignUpUser(ctx context.Context, arg SignUpUserParams) error
+	TouchDevice(ctx context.Context, arg TouchDeviceParams) error
+	TouchItem(ctx context.Context, arg TouchItemParams) (int64, error)
+	UpdateAttachmentInfo(ctx context.Context, arg UpdateAttachmentInfoParams) (int64, error)
+	UpdateCollectionKey(ctx context.Context, arg UpdateCollectionKeyParams) (int64, error)
+	UpdateItemShareKey(ctx context.Context, arg UpdateItemShareKeyParams) (int64, error)
+	UpdateMembershipKey(ctx context.Context, arg UpdateMembershipKeyParams) (int64, error)
+	UpdateMembershipRole(ctx context.Context, arg UpdateMembershipRoleParams) (int64, error)
+	UpdateUserPassword(ctx context.Context, arg UpdateUserPasswordParams) (int64, error)
+	UpdateUserPrivateKey(ctx context.Context, arg UpdateUserPrivateKeyParams) (int64, error)
+	UpsertDevice(ctx context.Context, arg UpsertDeviceParams) (Device, error)
+	UpsertEmergencyAccess(ctx context.Context, arg UpsertEmergencyAccessParams) error
+	UpsertItemShare(ctx context.Context, arg UpsertItemShareParams) error
+	UseDeviceSignIn(ctx context.Context, arg UseDeviceSignInParams) (int64, error)
 }
 
 var _ Querier = (*Queries)(nil)
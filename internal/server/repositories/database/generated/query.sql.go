@@ -11,19 +11,90 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
-const addItem = `-- name: AddItem :one
-INSERT INTO items (user_login, name, type, encrypted_data_content, encrypted_data_nonce, meta)
+const acceptMembership = `-- name: AcceptMembership :execrows
+UPDATE memberships
+SET status = 'ACTIVE'
+WHERE org_id = $1 AND login = $2 AND status = 'INVITED'
+`
+
+type AcceptMembershipParams struct {
+	OrgID pgtype.UUID `json:"org_id"`
+	Login string      `json:"login"`
+}
+
+func (q *Queries) AcceptMembership(ctx context.Context, arg AcceptMembershipParams) (int64, error) {
+	result, err := q.db.Exec(ctx, acceptMembership, arg.OrgID, arg.Login)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const addAttachment = `-- name: AddAttachment :one
+INSERT INTO item_attachments (item_id, encrypted_info_content, encrypted_info_nonce, size, content, nonce)
 VALUES ($1, $2, $3, $4, $5, $6)
 RETURNING id
 `
 
+type AddAttachmentParams struct {
+	ItemID               pgtype.UUID `json:"item_id"`
+	EncryptedInfoContent string      `json:"encrypted_info_content"`
+	EncryptedInfoNonce   string      `json:"encrypted_info_nonce"`
+	Size                 int64       `json:"size"`
+	Content              []byte      `json:"content"`
+	Nonce                []byte      `json:"nonce"`
+}
+
+func (q *Queries) AddAttachment(ctx context.Context, arg AddAttachmentParams) (pgtype.UUID, error) {
+	row := q.db.QueryRow(ctx, addAttachment,
+		arg.ItemID,
+		arg.EncryptedInfoContent,
+		arg.EncryptedInfoNonce,
+		arg.Size,
+		arg.Content,
+		arg.Nonce,
+	)
+	var id pgtype.UUID
+	err := row.Scan(&id)
+	return id, err
+}
+
+const addAuditEvent = `-- name: AddAuditEvent :exec
+INSERT INTO audit_events (login, action, item_id)
+VALUES ($1, $2, $3)
+`
+
+type AddAuditEventParams struct {
+	Login  string      `json:"login"`
+	Action string      `json:"action"`
+	ItemID pgtype.UUID `json:"item_id"`
+}
+
+func (q *Queries) AddAuditEvent(ctx context.Context, arg AddAuditEventParams) error {
+	_, err := q.db.Exec(ctx, addAuditEvent, arg.Login, arg.Action, arg.ItemID)
+	return err
+}
+
+const addItem = `-- name: AddItem :one
+INSERT INTO items (user_login, name, type, encrypted_data_content, encrypted_data_nonce, meta, encrypted_key, collection_id, format, search_tokens, expires_at, encrypted_header_content, encrypted_header_nonce)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+RETURNING id
+`
+
 type AddItemParams struct {
-	UserLogin            string   `json:"user_login"`
-	Name                 string   `json:"name"`
-	Type                 ItemType `json:"type"`
-	EncryptedDataContent string   `json:"encrypted_data_content"`
-	EncryptedDataNonce   string   `json:"encrypted_data_nonce"`
-	Meta                 []byte   `json:"meta"`
+	UserLogin              string             `json:"user_login"`
+	Name                   string             `json:"name"`
+	Type                   ItemType           `json:"type"`
+	EncryptedDataContent   string             `json:"encrypted_data_content"`
+	EncryptedDataNonce     string             `json:"encrypted_data_nonce"`
+	Meta                   []byte             `json:"meta"`
+	EncryptedKey           string             `json:"encrypted_key"`
+	CollectionID           pgtype.UUID        `json:"collection_id"`
+	Format                 int16              `json:"format"`
+	SearchTokens           []string           `json:"search_tokens"`
+	ExpiresAt              pgtype.Timestamptz `json:"expires_at"`
+	EncryptedHeaderContent string             `json:"encrypted_header_content"`
+	EncryptedHeaderNonce   string             `json:"encrypted_header_nonce"`
 }
 
 func (q *Queries) AddItem(ctx context.Context, arg AddItemParams) (pgtype.UUID, error) {
@@ -34,17 +105,348 @@ func (q *Queries) AddItem(ctx context.Context, arg AddItemParams) (pgtype.UUID,
 		arg.EncryptedDataContent,
 		arg.EncryptedDataNonce,
 		arg.Meta,
+		arg.EncryptedKey,
+		arg.CollectionID,
+		arg.Format,
+		arg.SearchTokens,
+		arg.ExpiresAt,
+		arg.EncryptedHeaderContent,
+		arg.EncryptedHeaderNonce,
 	)
 	var id pgtype.UUID
 	err := row.Scan(&id)
 	return id, err
 }
 
-const deleteItem = `-- name: DeleteItem :exec
+const addMembership = `-- name: AddMembership :execrows
+INSERT INTO memberships (org_id, login, role, status, wrapped_key, invited_by)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (org_id, login) DO NOTHING
+`
+
+type AddMembershipParams struct {
+	OrgID      pgtype.UUID      `json:"org_id"`
+	Login      string           `json:"login"`
+	Role       OrgRole          `json:"role"`
+	Status     MembershipStatus `json:"status"`
+	WrappedKey string           `json:"wrapped_key"`
+	InvitedBy  string           `json:"invited_by"`
+}
+
+func (q *Queries) AddMembership(ctx context.Context, arg AddMembershipParams) (int64, error) {
+	result, err := q.db.Exec(ctx, addMembership,
+		arg.OrgID,
+		arg.Login,
+		arg.Role,
+		arg.Status,
+		arg.WrappedKey,
+		arg.InvitedBy,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const addUserKeys = `-- name: AddUserKeys :execrows
+INSERT INTO user_keys (login, public_key, encrypted_private_key)
+VALUES ($1, $2, $3)
+ON CONFLICT (login) DO NOTHING
+`
+
+type AddUserKeysParams struct {
+	Login               string `json:"login"`
+	PublicKey           []byte `json:"public_key"`
+	EncryptedPrivateKey string `json:"encrypted_private_key"`
+}
+
+func (q *Queries) AddUserKeys(ctx context.Context, arg AddUserKeysParams) (int64, error) {
+	result, err := q.db.Exec(ctx, addUserKeys, arg.Login, arg.PublicKey, arg.EncryptedPrivateKey)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const approveDevice = `-- name: ApproveDevice :execrows
+UPDATE devices
+SET approved = TRUE
+WHERE user_login = $1 AND id = $2
+`
+
+type ApproveDeviceParams struct {
+	UserLogin string      `json:"user_login"`
+	ID        pgtype.UUID `json:"id"`
+}
+
+func (q *Queries) ApproveDevice(ctx context.Context, arg ApproveDeviceParams) (int64, error) {
+	result, err := q.db.Exec(ctx, approveDevice, arg.UserLogin, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const approveEmergencyAccess = `-- name: ApproveEmergencyAccess :execrows
+UPDATE emergency_access
+SET status = 'APPROVED'
+WHERE grantor = $1 AND grantee = $2 AND status = 'REQUESTED'
+`
+
+type ApproveEmergencyAccessParams struct {
+	Grantor string `json:"grantor"`
+	Grantee string `json:"grantee"`
+}
+
+func (q *Queries) ApproveEmergencyAccess(ctx context.Context, arg ApproveEmergencyAccessParams) (int64, error) {
+	result, err := q.db.Exec(ctx, approveEmergencyAccess, arg.Grantor, arg.Grantee)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const consumeSendView = `-- name: ConsumeSendView :one
+UPDATE sends
+SET views = views + 1
+WHERE id = $1 AND views < max_views AND expires_at > NOW()
+RETURNING id, owner_login, encrypted_data_content, encrypted_data_nonce, max_views, views, expires_at, created_at
+`
+
+func (q *Queries) ConsumeSendView(ctx context.Context, id pgtype.UUID) (Send, error) {
+	row := q.db.QueryRow(ctx, consumeSendView, id)
+	var i Send
+	err := row.Scan(
+		&i.ID,
+		&i.OwnerLogin,
+		&i.EncryptedDataContent,
+		&i.EncryptedDataNonce,
+		&i.MaxViews,
+		&i.Views,
+		&i.ExpiresAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createCollection = `-- name: CreateCollection :one
+INSERT INTO collections (org_id, name, encrypted_key)
+VALUES ($1, $2, $3)
+RETURNING id, created_at
+`
+
+type CreateCollectionParams struct {
+	OrgID        pgtype.UUID `json:"org_id"`
+	Name         string      `json:"name"`
+	EncryptedKey string      `json:"encrypted_key"`
+}
+
+type CreateCollectionRow struct {
+	ID        pgtype.UUID      `json:"id"`
+	CreatedAt pgtype.Timestamp `json:"created_at"`
+}
+
+func (q *Queries) CreateCollection(ctx context.Context, arg CreateCollectionParams) (CreateCollectionRow, error) {
+	row := q.db.QueryRow(ctx, createCollection, arg.OrgID, arg.Name, arg.EncryptedKey)
+	var i CreateCollectionRow
+	err := row.Scan(&i.ID, &i.CreatedAt)
+	return i, err
+}
+
+const createOrganization = `-- name: CreateOrganization :one
+INSERT INTO organizations (name, created_by)
+VALUES ($1, $2)
+RETURNING id, created_at
+`
+
+type CreateOrganizationParams struct {
+	Name      string `json:"name"`
+	CreatedBy string `json:"created_by"`
+}
+
+type CreateOrganizationRow struct {
+	ID        pgtype.UUID      `json:"id"`
+	CreatedAt pgtype.Timestamp `json:"created_at"`
+}
+
+func (q *Queries) CreateOrganization(ctx context.Context, arg CreateOrganizationParams) (CreateOrganizationRow, error) {
+	row := q.db.QueryRow(ctx, createOrganization, arg.Name, arg.CreatedBy)
+	var i CreateOrganizationRow
+	err := row.Scan(&i.ID, &i.CreatedAt)
+	return i, err
+}
+
+const createSend = `-- name: CreateSend :one
+INSERT INTO sends (owner_login, encrypted_data_content, encrypted_data_nonce, max_views, expires_at)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id
+`
+
+type CreateSendParams struct {
+	OwnerLogin           string             `json:"owner_login"`
+	EncryptedDataContent string             `json:"encrypted_data_content"`
+	EncryptedDataNonce   string             `json:"encrypted_data_nonce"`
+	MaxViews             int32              `json:"max_views"`
+	ExpiresAt            pgtype.Timestamptz `json:"expires_at"`
+}
+
+func (q *Queries) CreateSend(ctx context.Context, arg CreateSendParams) (pgtype.UUID, error) {
+	row := q.db.QueryRow(ctx, createSend,
+		arg.OwnerLogin,
+		arg.EncryptedDataContent,
+		arg.EncryptedDataNonce,
+		arg.MaxViews,
+		arg.ExpiresAt,
+	)
+	var id pgtype.UUID
+	err := row.Scan(&id)
+	return id, err
+}
+
+const deleteAttachment = `-- name: DeleteAttachment :execrows
+DELETE FROM item_attachments
+WHERE id = $1 AND item_id = $2
+`
+
+type DeleteAttachmentParams struct {
+	ID     pgtype.UUID `json:"id"`
+	ItemID pgtype.UUID `json:"item_id"`
+}
+
+func (q *Queries) DeleteAttachment(ctx context.Context, arg DeleteAttachmentParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteAttachment, arg.ID, arg.ItemID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteCollectionItem = `-- name: DeleteCollectionItem :execrows
 DELETE FROM items
+WHERE id = $1 AND collection_id = $2
+`
+
+type DeleteCollectionItemParams struct {
+	ID           pgtype.UUID `json:"id"`
+	CollectionID pgtype.UUID `json:"collection_id"`
+}
+
+func (q *Queries) DeleteCollectionItem(ctx context.Context, arg DeleteCollectionItemParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteCollectionItem, arg.ID, arg.CollectionID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteDeletedUsers = `-- name: DeleteDeletedUsers :execrows
+DELETE FROM users
+WHERE deleted_at IS NOT NULL
+`
+
+func (q *Queries) DeleteDeletedUsers(ctx context.Context) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteDeletedUsers)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteDevice = `-- name: DeleteDevice :execrows
+DELETE FROM devices
 WHERE user_login = $1 AND id = $2
 `
 
+type DeleteDeviceParams struct {
+	UserLogin string      `json:"user_login"`
+	ID        pgtype.UUID `json:"id"`
+}
+
+func (q *Queries) DeleteDevice(ctx context.Context, arg DeleteDeviceParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteDevice, arg.UserLogin, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteEmergencyAccess = `-- name: DeleteEmergencyAccess :execrows
+DELETE FROM emergency_access
+WHERE grantor = $1 AND grantee = $2
+`
+
+type DeleteEmergencyAccessParams struct {
+	Grantor string `json:"grantor"`
+	Grantee string `json:"grantee"`
+}
+
+func (q *Queries) DeleteEmergencyAccess(ctx context.Context, arg DeleteEmergencyAccessParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteEmergencyAccess, arg.Grantor, arg.Grantee)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteEmergencyAccessOfGrantor = `-- name: DeleteEmergencyAccessOfGrantor :exec
+DELETE FROM emergency_access
+WHERE grantor = $1
+`
+
+func (q *Queries) DeleteEmergencyAccessOfGrantor(ctx context.Context, grantor string) error {
+	_, err := q.db.Exec(ctx, deleteEmergencyAccessOfGrantor, grantor)
+	return err
+}
+
+const deleteExpiredItems = `-- name: DeleteExpiredItems :many
+DELETE FROM items
+WHERE expires_at IS NOT NULL AND expires_at <= NOW()
+RETURNING id, user_login
+`
+
+type DeleteExpiredItemsRow struct {
+	ID        pgtype.UUID `json:"id"`
+	UserLogin string      `json:"user_login"`
+}
+
+func (q *Queries) DeleteExpiredItems(ctx context.Context) ([]DeleteExpiredItemsRow, error) {
+	rows, err := q.db.Query(ctx, deleteExpiredItems)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []DeleteExpiredItemsRow
+	for rows.Next() {
+		var i DeleteExpiredItemsRow
+		if err := rows.Scan(&i.ID, &i.UserLogin); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const deleteExpiredSends = `-- name: DeleteExpiredSends :execrows
+DELETE FROM sends
+WHERE expires_at <= NOW() OR views >= max_views
+`
+
+func (q *Queries) DeleteExpiredSends(ctx context.Context) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteExpiredSends)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteItem = `-- name: DeleteItem :exec
+DELETE FROM items
+WHERE user_login = $1 AND id = $2 AND collection_id IS NULL
+`
+
 type DeleteItemParams struct {
 	UserLogin string      `json:"user_login"`
 	ID        pgtype.UUID `json:"id"`
@@ -55,18 +457,120 @@ func (q *Queries) DeleteItem(ctx context.Context, arg DeleteItemParams) error {
 	return err
 }
 
+const deleteItemShare = `-- name: DeleteItemShare :execrows
+DELETE FROM item_shares
+WHERE item_id = $1 AND recipient_login = $2
+`
+
+type DeleteItemShareParams struct {
+	ItemID         pgtype.UUID `json:"item_id"`
+	RecipientLogin string      `json:"recipient_login"`
+}
+
+func (q *Queries) DeleteItemShare(ctx context.Context, arg DeleteItemShareParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteItemShare, arg.ItemID, arg.RecipientLogin)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteItemsOfDeletedUsers = `-- name: DeleteItemsOfDeletedUsers :execrows
+DELETE FROM items
+WHERE user_login IN (SELECT login FROM users WHERE deleted_at IS NOT NULL)
+`
+
+func (q *Queries) DeleteItemsOfDeletedUsers(ctx context.Context) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteItemsOfDeletedUsers)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteMembership = `-- name: DeleteMembership :execrows
+DELETE FROM memberships
+WHERE org_id = $1 AND login = $2
+`
+
+type DeleteMembershipParams struct {
+	OrgID pgtype.UUID `json:"org_id"`
+	Login string      `json:"login"`
+}
+
+func (q *Queries) DeleteMembership(ctx context.Context, arg DeleteMembershipParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteMembership, arg.OrgID, arg.Login)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteSend = `-- name: DeleteSend :exec
+DELETE FROM sends
+WHERE id = $1
+`
+
+func (q *Queries) DeleteSend(ctx context.Context, id pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, deleteSend, id)
+	return err
+}
+
+const deleteTemplate = `-- name: DeleteTemplate :execrows
+DELETE FROM item_templates
+WHERE id = $1 AND user_login = $2
+`
+
+type DeleteTemplateParams struct {
+	ID        pgtype.UUID `json:"id"`
+	UserLogin string      `json:"user_login"`
+}
+
+func (q *Queries) DeleteTemplate(ctx context.Context, arg DeleteTemplateParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteTemplate, arg.ID, arg.UserLogin)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const denyEmergencyAccess = `-- name: DenyEmergencyAccess :execrows
+UPDATE emergency_access
+SET status = 'IDLE', requested_at = NULL
+WHERE grantor = $1 AND grantee = $2 AND status = 'REQUESTED'
+`
+
+type DenyEmergencyAccessParams struct {
+	Grantor string `json:"grantor"`
+	Grantee string `json:"grantee"`
+}
+
+func (q *Queries) DenyEmergencyAccess(ctx context.Context, arg DenyEmergencyAccessParams) (int64, error) {
+	result, err := q.db.Exec(ctx, denyEmergencyAccess, arg.Grantor, arg.Grantee)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const editItem = `-- name: EditItem :exec
 UPDATE items
-SET name = $2, encrypted_data_content = $3, encrypted_data_nonce = $4, meta = $5, updated_at =  NOW()
+SET name = $2, encrypted_data_content = $3, encrypted_data_nonce = $4, meta = $5, encrypted_key = $6, format = $7, search_tokens = $8, expires_at = $9, encrypted_header_content = $10, encrypted_header_nonce = $11, updated_at =  NOW()
 WHERE id = $1
 `
 
 type EditItemParams struct {
-	ID                   pgtype.UUID `json:"id"`
-	Name                 string      `json:"name"`
-	EncryptedDataContent string      `json:"encrypted_data_content"`
-	EncryptedDataNonce   string      `json:"encrypted_data_nonce"`
-	Meta                 []byte      `json:"meta"`
+	ID                     pgtype.UUID        `json:"id"`
+	Name                   string             `json:"name"`
+	EncryptedDataContent   string             `json:"encrypted_data_content"`
+	EncryptedDataNonce     string             `json:"encrypted_data_nonce"`
+	Meta                   []byte             `json:"meta"`
+	EncryptedKey           string             `json:"encrypted_key"`
+	Format                 int16              `json:"format"`
+	SearchTokens           []string           `json:"search_tokens"`
+	ExpiresAt              pgtype.Timestamptz `json:"expires_at"`
+	EncryptedHeaderContent string             `json:"encrypted_header_content"`
+	EncryptedHeaderNonce   string             `json:"encrypted_header_nonce"`
 }
 
 func (q *Queries) EditItem(ctx context.Context, arg EditItemParams) error {
@@ -76,6 +580,12 @@ func (q *Queries) EditItem(ctx context.Context, arg EditItemParams) error {
 		arg.EncryptedDataContent,
 		arg.EncryptedDataNonce,
 		arg.Meta,
+		arg.EncryptedKey,
+		arg.Format,
+		arg.SearchTokens,
+		arg.ExpiresAt,
+		arg.EncryptedHeaderContent,
+		arg.EncryptedHeaderNonce,
 	)
 	return err
 }
@@ -87,23 +597,41 @@ SELECT
     i.type,
     i.encrypted_data_content,
     i.encrypted_data_nonce,
+    i.encrypted_header_content,
+    i.encrypted_header_nonce,
+    i.encrypted_key,
     i.meta,
+    i.format,
+    i.search_tokens,
+    i.expires_at,
     i.created_at,
-    i.updated_at
+    i.updated_at,
+    i.favorite,
+    i.last_used_at,
+    i.use_count
 FROM items i
-WHERE i.user_login = $1
-ORDER BY i.created_at DESC
+WHERE i.user_login = $1 AND i.collection_id IS NULL AND (i.expires_at IS NULL OR i.expires_at > NOW())
+ORDER BY i.favorite DESC, i.last_used_at DESC NULLS LAST, i.created_at DESC
 `
 
 type GetAllUserItemsRow struct {
-	ID                   pgtype.UUID      `json:"id"`
-	Name                 string           `json:"name"`
-	Type                 ItemType         `json:"type"`
-	EncryptedDataContent string           `json:"encrypted_data_content"`
-	EncryptedDataNonce   string           `json:"encrypted_data_nonce"`
-	Meta                 []byte           `json:"meta"`
-	CreatedAt            pgtype.Timestamp `json:"created_at"`
-	UpdatedAt            pgtype.Timestamp `json:"updated_at"`
+	ID                     pgtype.UUID        `json:"id"`
+	Name                   string             `json:"name"`
+	Type                   ItemType           `json:"type"`
+	EncryptedDataContent   string             `json:"encrypted_data_content"`
+	EncryptedDataNonce     string             `json:"encrypted_data_nonce"`
+	EncryptedHeaderContent string             `json:"encrypted_header_content"`
+	EncryptedHeaderNonce   string             `json:"encrypted_header_nonce"`
+	EncryptedKey           string             `json:"encrypted_key"`
+	Meta                   []byte             `json:"meta"`
+	Format                 int16              `json:"format"`
+	SearchTokens           []string           `json:"search_tokens"`
+	ExpiresAt              pgtype.Timestamptz `json:"expires_at"`
+	CreatedAt              pgtype.Timestamp   `json:"created_at"`
+	UpdatedAt              pgtype.Timestamp   `json:"updated_at"`
+	Favorite               bool               `json:"favorite"`
+	LastUsedAt             pgtype.Timestamp   `json:"last_used_at"`
+	UseCount               int32              `json:"use_count"`
 }
 
 func (q *Queries) GetAllUserItems(ctx context.Context, userLogin string) ([]GetAllUserItemsRow, error) {
@@ -121,9 +649,18 @@ func (q *Queries) GetAllUserItems(ctx context.Context, userLogin string) ([]GetA
 			&i.Type,
 			&i.EncryptedDataContent,
 			&i.EncryptedDataNonce,
+			&i.EncryptedHeaderContent,
+			&i.EncryptedHeaderNonce,
+			&i.EncryptedKey,
 			&i.Meta,
+			&i.Format,
+			&i.SearchTokens,
+			&i.ExpiresAt,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Favorite,
+			&i.LastUsedAt,
+			&i.UseCount,
 		); err != nil {
 			return nil, err
 		}
@@ -135,31 +672,411 @@ func (q *Queries) GetAllUserItems(ctx context.Context, userLogin string) ([]GetA
 	return items, nil
 }
 
-const getTypesCounts = `-- name: GetTypesCounts :many
-SELECT 
-    type, 
-    COUNT(*) as count
-FROM items
-WHERE user_login = $1
-GROUP BY type
+const getAttachment = `-- name: GetAttachment :one
+SELECT id, item_id, encrypted_info_content, encrypted_info_nonce, size, content, nonce, created_at
+FROM item_attachments
+WHERE id = $1 AND item_id = $2
 `
 
-type GetTypesCountsRow struct {
-	Type  ItemType `json:"type"`
-	Count int64    `json:"count"`
+type GetAttachmentParams struct {
+	ID     pgtype.UUID `json:"id"`
+	ItemID pgtype.UUID `json:"item_id"`
 }
 
-func (q *Queries) GetTypesCounts(ctx context.Context, userLogin string) ([]GetTypesCountsRow, error) {
-	rows, err := q.db.Query(ctx, getTypesCounts, userLogin)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-	var items []GetTypesCountsRow
-	for rows.Next() {
-		var i GetTypesCountsRow
-		if err := rows.Scan(&i.Type, &i.Count); err != nil {
-			return nil, err
+func (q *Queries) GetAttachment(ctx context.Context, arg GetAttachmentParams) (ItemAttachment, error) {
+	row := q.db.QueryRow(ctx, getAttachment, arg.ID, arg.ItemID)
+	var i ItemAttachment
+	err := row.Scan(
+		&i.ID,
+		&i.ItemID,
+		&i.EncryptedInfoContent,
+		&i.EncryptedInfoNonce,
+		&i.Size,
+		&i.Content,
+		&i.Nonce,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getCollectionItems = `-- name: GetCollectionItems :many
+SELECT
+    i.id,
+    i.user_login,
+    i.name,
+    i.type,
+    i.encrypted_data_content,
+    i.encrypted_data_nonce,
+    i.encrypted_header_content,
+    i.encrypted_header_nonce,
+    i.encrypted_key,
+    i.meta,
+    i.format,
+    i.created_at,
+    i.updated_at
+FROM items i
+WHERE i.collection_id = $1 AND (i.expires_at IS NULL OR i.expires_at > NOW())
+ORDER BY i.created_at DESC
+`
+
+type GetCollectionItemsRow struct {
+	ID                     pgtype.UUID      `json:"id"`
+	UserLogin              string           `json:"user_login"`
+	Name                   string           `json:"name"`
+	Type                   ItemType         `json:"type"`
+	EncryptedDataContent   string           `json:"encrypted_data_content"`
+	EncryptedDataNonce     string           `json:"encrypted_data_nonce"`
+	EncryptedHeaderContent string           `json:"encrypted_header_content"`
+	EncryptedHeaderNonce   string           `json:"encrypted_header_nonce"`
+	EncryptedKey           string           `json:"encrypted_key"`
+	Meta                   []byte           `json:"meta"`
+	Format                 int16            `json:"format"`
+	CreatedAt              pgtype.Timestamp `json:"created_at"`
+	UpdatedAt              pgtype.Timestamp `json:"updated_at"`
+}
+
+func (q *Queries) GetCollectionItems(ctx context.Context, collectionID pgtype.UUID) ([]GetCollectionItemsRow, error) {
+	rows, err := q.db.Query(ctx, getCollectionItems, collectionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetCollectionItemsRow
+	for rows.Next() {
+		var i GetCollectionItemsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserLogin,
+			&i.Name,
+			&i.Type,
+			&i.EncryptedDataContent,
+			&i.EncryptedDataNonce,
+			&i.EncryptedHeaderContent,
+			&i.EncryptedHeaderNonce,
+			&i.EncryptedKey,
+			&i.Meta,
+			&i.Format,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getCollectionItemsWithType = `-- name: GetCollectionItemsWithType :many
+SELECT
+    i.id,
+    i.user_login,
+    i.name,
+    i.type,
+    i.encrypted_data_content,
+    i.encrypted_data_nonce,
+    i.encrypted_header_content,
+    i.encrypted_header_nonce,
+    i.encrypted_key,
+    i.meta,
+    i.format,
+    i.created_at,
+    i.updated_at
+FROM items i
+WHERE i.collection_id = $1 AND i.type = $2 AND (i.expires_at IS NULL OR i.expires_at > NOW())
+ORDER BY i.created_at DESC
+`
+
+type GetCollectionItemsWithTypeParams struct {
+	CollectionID pgtype.UUID `json:"collection_id"`
+	Type         ItemType    `json:"type"`
+}
+
+type GetCollectionItemsWithTypeRow struct {
+	ID                     pgtype.UUID      `json:"id"`
+	UserLogin              string           `json:"user_login"`
+	Name                   string           `json:"name"`
+	Type                   ItemType         `json:"type"`
+	EncryptedDataContent   string           `json:"encrypted_data_content"`
+	EncryptedDataNonce     string           `json:"encrypted_data_nonce"`
+	EncryptedHeaderContent string           `json:"encrypted_header_content"`
+	EncryptedHeaderNonce   string           `json:"encrypted_header_nonce"`
+	EncryptedKey           string           `json:"encrypted_key"`
+	Meta                   []byte           `json:"meta"`
+	Format                 int16            `json:"format"`
+	CreatedAt              pgtype.Timestamp `json:"created_at"`
+	UpdatedAt              pgtype.Timestamp `json:"updated_at"`
+}
+
+func (q *Queries) GetCollectionItemsWithType(ctx context.Context, arg GetCollectionItemsWithTypeParams) ([]GetCollectionItemsWithTypeRow, error) {
+	rows, err := q.db.Query(ctx, getCollectionItemsWithType, arg.CollectionID, arg.Type)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetCollectionItemsWithTypeRow
+	for rows.Next() {
+		var i GetCollectionItemsWithTypeRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserLogin,
+			&i.Name,
+			&i.Type,
+			&i.EncryptedDataContent,
+			&i.EncryptedDataNonce,
+			&i.EncryptedHeaderContent,
+			&i.EncryptedHeaderNonce,
+			&i.EncryptedKey,
+			&i.Meta,
+			&i.Format,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getCollectionRole = `-- name: GetCollectionRole :one
+SELECT m.role, m.status
+FROM collections c
+JOIN memberships m ON m.org_id = c.org_id
+WHERE c.id = $1 AND m.login = $2
+`
+
+type GetCollectionRoleParams struct {
+	ID    pgtype.UUID `json:"id"`
+	Login string      `json:"login"`
+}
+
+type GetCollectionRoleRow struct {
+	Role   OrgRole          `json:"role"`
+	Status MembershipStatus `json:"status"`
+}
+
+func (q *Queries) GetCollectionRole(ctx context.Context, arg GetCollectionRoleParams) (GetCollectionRoleRow, error) {
+	row := q.db.QueryRow(ctx, getCollectionRole, arg.ID, arg.Login)
+	var i GetCollectionRoleRow
+	err := row.Scan(&i.Role, &i.Status)
+	return i, err
+}
+
+const getCollectionTypesCounts = `-- name: GetCollectionTypesCounts :many
+SELECT
+    type,
+    COUNT(*) as count
+FROM items
+WHERE collection_id = $1 AND (expires_at IS NULL OR expires_at > NOW())
+GROUP BY type
+`
+
+type GetCollectionTypesCountsRow struct {
+	Type  ItemType `json:"type"`
+	Count int64    `json:"count"`
+}
+
+func (q *Queries) GetCollectionTypesCounts(ctx context.Context, collectionID pgtype.UUID) ([]GetCollectionTypesCountsRow, error) {
+	rows, err := q.db.Query(ctx, getCollectionTypesCounts, collectionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetCollectionTypesCountsRow
+	for rows.Next() {
+		var i GetCollectionTypesCountsRow
+		if err := rows.Scan(&i.Type, &i.Count); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getDevice = `-- name: GetDevice :one
+SELECT id, user_login, name, os, public_key, approved, created_at, last_seen_at, last_seen_ip
+FROM devices
+WHERE user_login = $1 AND id = $2
+`
+
+type GetDeviceParams struct {
+	UserLogin string      `json:"user_login"`
+	ID        pgtype.UUID `json:"id"`
+}
+
+func (q *Queries) GetDevice(ctx context.Context, arg GetDeviceParams) (Device, error) {
+	row := q.db.QueryRow(ctx, getDevice, arg.UserLogin, arg.ID)
+	var i Device
+	err := row.Scan(
+		&i.ID,
+		&i.UserLogin,
+		&i.Name,
+		&i.Os,
+		&i.PublicKey,
+		&i.Approved,
+		&i.CreatedAt,
+		&i.LastSeenAt,
+		&i.LastSeenIp,
+	)
+	return i, err
+}
+
+const getDeviceApprovalRequired = `-- name: GetDeviceApprovalRequired :one
+SELECT require_device_approval
+FROM users
+WHERE login = $1
+`
+
+func (q *Queries) GetDeviceApprovalRequired(ctx context.Context, login string) (bool, error) {
+	row := q.db.QueryRow(ctx, getDeviceApprovalRequired, login)
+	var require_device_approval bool
+	err := row.Scan(&require_device_approval)
+	return require_device_approval, err
+}
+
+const getEmergencyAccess = `-- name: GetEmergencyAccess :one
+SELECT grantor, grantee, access_type, status, wait_hours, wrapped_key, requested_at, created_at
+FROM emergency_access
+WHERE grantor = $1 AND grantee = $2
+`
+
+type GetEmergencyAccessParams struct {
+	Grantor string `json:"grantor"`
+	Grantee string `json:"grantee"`
+}
+
+func (q *Queries) GetEmergencyAccess(ctx context.Context, arg GetEmergencyAccessParams) (EmergencyAccess, error) {
+	row := q.db.QueryRow(ctx, getEmergencyAccess, arg.Grantor, arg.Grantee)
+	var i EmergencyAccess
+	err := row.Scan(
+		&i.Grantor,
+		&i.Grantee,
+		&i.AccessType,
+		&i.Status,
+		&i.WaitHours,
+		&i.WrappedKey,
+		&i.RequestedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getItemCollection = `-- name: GetItemCollection :one
+SELECT collection_id
+FROM items
+WHERE id = $1
+`
+
+func (q *Queries) GetItemCollection(ctx context.Context, id pgtype.UUID) (pgtype.UUID, error) {
+	row := q.db.QueryRow(ctx, getItemCollection, id)
+	var collection_id pgtype.UUID
+	err := row.Scan(&collection_id)
+	return collection_id, err
+}
+
+const getItemOwner = `-- name: GetItemOwner :one
+SELECT user_login
+FROM items
+WHERE id = $1
+`
+
+func (q *Queries) GetItemOwner(ctx context.Context, id pgtype.UUID) (string, error) {
+	row := q.db.QueryRow(ctx, getItemOwner, id)
+	var user_login string
+	err := row.Scan(&user_login)
+	return user_login, err
+}
+
+const getMembership = `-- name: GetMembership :one
+SELECT org_id, login, role, status, wrapped_key, invited_by, created_at
+FROM memberships
+WHERE org_id = $1 AND login = $2
+`
+
+type GetMembershipParams struct {
+	OrgID pgtype.UUID `json:"org_id"`
+	Login string      `json:"login"`
+}
+
+func (q *Queries) GetMembership(ctx context.Context, arg GetMembershipParams) (Membership, error) {
+	row := q.db.QueryRow(ctx, getMembership, arg.OrgID, arg.Login)
+	var i Membership
+	err := row.Scan(
+		&i.OrgID,
+		&i.Login,
+		&i.Role,
+		&i.Status,
+		&i.WrappedKey,
+		&i.InvitedBy,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getServerStats = `-- name: GetServerStats :one
+SELECT
+    (SELECT COUNT(*) FROM users) as users_count,
+    (SELECT COUNT(*) FROM users WHERE locked) as locked_users_count,
+    (SELECT COUNT(*) FROM users WHERE deleted_at IS NOT NULL) as deleted_users_count,
+    (SELECT COUNT(*) FROM items) as items_count,
+    (SELECT COALESCE(SUM(octet_length(name) + octet_length(encrypted_data_content) + octet_length(encrypted_header_content) + COALESCE(octet_length(meta::text), 0)), 0) FROM items)::BIGINT as storage_bytes
+`
+
+type GetServerStatsRow struct {
+	UsersCount        int64 `json:"users_count"`
+	LockedUsersCount  int64 `json:"locked_users_count"`
+	DeletedUsersCount int64 `json:"deleted_users_count"`
+	ItemsCount        int64 `json:"items_count"`
+	StorageBytes      int64 `json:"storage_bytes"`
+}
+
+func (q *Queries) GetServerStats(ctx context.Context) (GetServerStatsRow, error) {
+	row := q.db.QueryRow(ctx, getServerStats)
+	var i GetServerStatsRow
+	err := row.Scan(
+		&i.UsersCount,
+		&i.LockedUsersCount,
+		&i.DeletedUsersCount,
+		&i.ItemsCount,
+		&i.StorageBytes,
+	)
+	return i, err
+}
+
+const getTypesCounts = `-- name: GetTypesCounts :many
+SELECT 
+    type, 
+    COUNT(*) as count
+FROM items
+WHERE user_login = $1 AND collection_id IS NULL AND (expires_at IS NULL OR expires_at > NOW())
+GROUP BY type
+`
+
+type GetTypesCountsRow struct {
+	Type  ItemType `json:"type"`
+	Count int64    `json:"count"`
+}
+
+func (q *Queries) GetTypesCounts(ctx context.Context, userLogin string) ([]GetTypesCountsRow, error) {
+	rows, err := q.db.Query(ctx, getTypesCounts, userLogin)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetTypesCountsRow
+	for rows.Next() {
+		var i GetTypesCountsRow
+		if err := rows.Scan(&i.Type, &i.Count); err != nil {
+			return nil, err
 		}
 		items = append(items, i)
 	}
@@ -170,15 +1087,33 @@ func (q *Queries) GetTypesCounts(ctx context.Context, userLogin string) ([]GetTy
 }
 
 const getUser = `-- name: GetUser :one
-SELECT login, password, salt
+SELECT login, password, salt, locked, sessions_revoked_at, deleted_at, created_at
 FROM users
 WHERE login = $1
 `
 
-func (q *Queries) GetUser(ctx context.Context, login string) (User, error) {
+type GetUserRow struct {
+	Login             string             `json:"login"`
+	Password          []byte             `json:"password"`
+	Salt              string             `json:"salt"`
+	Locked            bool               `json:"locked"`
+	SessionsRevokedAt pgtype.Timestamptz `json:"sessions_revoked_at"`
+	DeletedAt         pgtype.Timestamp   `json:"deleted_at"`
+	CreatedAt         pgtype.Timestamp   `json:"created_at"`
+}
+
+func (q *Queries) GetUser(ctx context.Context, login string) (GetUserRow, error) {
 	row := q.db.QueryRow(ctx, getUser, login)
-	var i User
-	err := row.Scan(&i.Login, &i.Password, &i.Salt)
+	var i GetUserRow
+	err := row.Scan(
+		&i.Login,
+		&i.Password,
+		&i.Salt,
+		&i.Locked,
+		&i.SessionsRevokedAt,
+		&i.DeletedAt,
+		&i.CreatedAt,
+	)
 	return i, err
 }
 
@@ -189,12 +1124,21 @@ SELECT
     i.type,
     i.encrypted_data_content,
     i.encrypted_data_nonce,
+    i.encrypted_header_content,
+    i.encrypted_header_nonce,
+    i.encrypted_key,
     i.meta,
+    i.format,
+    i.search_tokens,
+    i.expires_at,
     i.created_at,
-    i.updated_at
+    i.updated_at,
+    i.favorite,
+    i.last_used_at,
+    i.use_count
 FROM items i
-WHERE i.user_login = $1 AND i.type = $2
-ORDER BY created_at DESC
+WHERE i.user_login = $1 AND i.type = $2 AND i.collection_id IS NULL AND (i.expires_at IS NULL OR i.expires_at > NOW())
+ORDER BY i.favorite DESC, i.last_used_at DESC NULLS LAST, i.created_at DESC
 `
 
 type GetUserItemsWithTypeParams struct {
@@ -203,14 +1147,23 @@ type GetUserItemsWithTypeParams struct {
 }
 
 type GetUserItemsWithTypeRow struct {
-	ID                   pgtype.UUID      `json:"id"`
-	Name                 string           `json:"name"`
-	Type                 ItemType         `json:"type"`
-	EncryptedDataContent string           `json:"encrypted_data_content"`
-	EncryptedDataNonce   string           `json:"encrypted_data_nonce"`
-	Meta                 []byte           `json:"meta"`
-	CreatedAt            pgtype.Timestamp `json:"created_at"`
-	UpdatedAt            pgtype.Timestamp `json:"updated_at"`
+	ID                     pgtype.UUID        `json:"id"`
+	Name                   string             `json:"name"`
+	Type                   ItemType           `json:"type"`
+	EncryptedDataContent   string             `json:"encrypted_data_content"`
+	EncryptedDataNonce     string             `json:"encrypted_data_nonce"`
+	EncryptedHeaderContent string             `json:"encrypted_header_content"`
+	EncryptedHeaderNonce   string             `json:"encrypted_header_nonce"`
+	EncryptedKey           string             `json:"encrypted_key"`
+	Meta                   []byte             `json:"meta"`
+	Format                 int16              `json:"format"`
+	SearchTokens           []string           `json:"search_tokens"`
+	ExpiresAt              pgtype.Timestamptz `json:"expires_at"`
+	CreatedAt              pgtype.Timestamp   `json:"created_at"`
+	UpdatedAt              pgtype.Timestamp   `json:"updated_at"`
+	Favorite               bool               `json:"favorite"`
+	LastUsedAt             pgtype.Timestamp   `json:"last_used_at"`
+	UseCount               int32              `json:"use_count"`
 }
 
 func (q *Queries) GetUserItemsWithType(ctx context.Context, arg GetUserItemsWithTypeParams) ([]GetUserItemsWithTypeRow, error) {
@@ -228,9 +1181,18 @@ func (q *Queries) GetUserItemsWithType(ctx context.Context, arg GetUserItemsWith
 			&i.Type,
 			&i.EncryptedDataContent,
 			&i.EncryptedDataNonce,
+			&i.EncryptedHeaderContent,
+			&i.EncryptedHeaderNonce,
+			&i.EncryptedKey,
 			&i.Meta,
+			&i.Format,
+			&i.SearchTokens,
+			&i.ExpiresAt,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Favorite,
+			&i.LastUsedAt,
+			&i.UseCount,
 		); err != nil {
 			return nil, err
 		}
@@ -242,18 +1204,1186 @@ func (q *Queries) GetUserItemsWithType(ctx context.Context, arg GetUserItemsWith
 	return items, nil
 }
 
-const signUpUser = `-- name: SignUpUser :exec
-INSERT INTO users (login, password, salt)
-VALUES ($1, $2, $3)
+const getUserKeys = `-- name: GetUserKeys :one
+SELECT login, public_key, encrypted_private_key, created_at
+FROM user_keys
+WHERE login = $1
 `
 
-type SignUpUserParams struct {
-	Login    string `json:"login"`
-	Password []byte `json:"password"`
-	Salt     string `json:"salt"`
+func (q *Queries) GetUserKeys(ctx context.Context, login string) (UserKey, error) {
+	row := q.db.QueryRow(ctx, getUserKeys, login)
+	var i UserKey
+	err := row.Scan(
+		&i.Login,
+		&i.PublicKey,
+		&i.EncryptedPrivateKey,
+		&i.CreatedAt,
+	)
+	return i, err
 }
 
-func (q *Queries) SignUpUser(ctx context.Context, arg SignUpUserParams) error {
-	_, err := q.db.Exec(ctx, signUpUser, arg.Login, arg.Password, arg.Salt)
-	return err
+const listAttachments = `-- name: ListAttachments :many
+SELECT id, item_id, encrypted_info_content, encrypted_info_nonce, size, created_at
+FROM item_attachments
+WHERE item_id = $1
+ORDER BY created_at
+`
+
+type ListAttachmentsRow struct {
+	ID                   pgtype.UUID      `json:"id"`
+	ItemID               pgtype.UUID      `json:"item_id"`
+	EncryptedInfoContent string           `json:"encrypted_info_content"`
+	EncryptedInfoNonce   string           `json:"encrypted_info_nonce"`
+	Size                 int64            `json:"size"`
+	CreatedAt            pgtype.Timestamp `json:"created_at"`
+}
+
+func (q *Queries) ListAttachments(ctx context.Context, itemID pgtype.UUID) ([]ListAttachmentsRow, error) {
+	rows, err := q.db.Query(ctx, listAttachments, itemID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListAttachmentsRow
+	for rows.Next() {
+		var i ListAttachmentsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ItemID,
+			&i.EncryptedInfoContent,
+			&i.EncryptedInfoNonce,
+			&i.Size,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCollections = `-- name: ListCollections :many
+SELECT id, org_id, name, encrypted_key, created_at
+FROM collections
+WHERE org_id = $1
+ORDER BY name
+`
+
+func (q *Queries) ListCollections(ctx context.Context, orgID pgtype.UUID) ([]Collection, error) {
+	rows, err := q.db.Query(ctx, listCollections, orgID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Collection
+	for rows.Next() {
+		var i Collection
+		if err := rows.Scan(
+			&i.ID,
+			&i.OrgID,
+			&i.Name,
+			&i.EncryptedKey,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listDevices = `-- name: ListDevices :many
+SELECT id, user_login, name, os, public_key, approved, created_at, last_seen_at, last_seen_ip
+FROM devices
+WHERE user_login = $1
+ORDER BY last_seen_at DESC
+`
+
+func (q *Queries) ListDevices(ctx context.Context, userLogin string) ([]Device, error) {
+	rows, err := q.db.Query(ctx, listDevices, userLogin)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Device
+	for rows.Next() {
+		var i Device
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserLogin,
+			&i.Name,
+			&i.Os,
+			&i.PublicKey,
+			&i.Approved,
+			&i.CreatedAt,
+			&i.LastSeenAt,
+			&i.LastSeenIp,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listEmergencyAccessByGrantee = `-- name: ListEmergencyAccessByGrantee :many
+SELECT grantor, grantee, access_type, status, wait_hours, wrapped_key, requested_at, created_at
+FROM emergency_access
+WHERE grantee = $1
+ORDER BY grantor
+`
+
+func (q *Queries) ListEmergencyAccessByGrantee(ctx context.Context, grantee string) ([]EmergencyAccess, error) {
+	rows, err := q.db.Query(ctx, listEmergencyAccessByGrantee, grantee)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []EmergencyAccess
+	for rows.Next() {
+		var i EmergencyAccess
+		if err := rows.Scan(
+			&i.Grantor,
+			&i.Grantee,
+			&i.AccessType,
+			&i.Status,
+			&i.WaitHours,
+			&i.WrappedKey,
+			&i.RequestedAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listEmergencyAccessByGrantor = `-- name: ListEmergencyAccessByGrantor :many
+SELECT grantor, grantee, access_type, status, wait_hours, wrapped_key, requested_at, created_at
+FROM emergency_access
+WHERE grantor = $1
+ORDER BY grantee
+`
+
+func (q *Queries) ListEmergencyAccessByGrantor(ctx context.Context, grantor string) ([]EmergencyAccess, error) {
+	rows, err := q.db.Query(ctx, listEmergencyAccessByGrantor, grantor)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []EmergencyAccess
+	for rows.Next() {
+		var i EmergencyAccess
+		if err := rows.Scan(
+			&i.Grantor,
+			&i.Grantee,
+			&i.AccessType,
+			&i.Status,
+			&i.WaitHours,
+			&i.WrappedKey,
+			&i.RequestedAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listItemShares = `-- name: ListItemShares :many
+SELECT item_id, recipient_login, wrapped_key, created_at
+FROM item_shares
+WHERE item_id = $1
+ORDER BY recipient_login
+`
+
+func (q *Queries) ListItemShares(ctx context.Context, itemID pgtype.UUID) ([]ItemShare, error) {
+	rows, err := q.db.Query(ctx, listItemShares, itemID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ItemShare
+	for rows.Next() {
+		var i ItemShare
+		if err := rows.Scan(
+			&i.ItemID,
+			&i.RecipientLogin,
+			&i.WrappedKey,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listMemberships = `-- name: ListMemberships :many
+SELECT org_id, login, role, status, wrapped_key, invited_by, created_at
+FROM memberships
+WHERE org_id = $1
+ORDER BY login
+`
+
+func (q *Queries) ListMemberships(ctx context.Context, orgID pgtype.UUID) ([]Membership, error) {
+	rows, err := q.db.Query(ctx, listMemberships, orgID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Membership
+	for rows.Next() {
+		var i Membership
+		if err := rows.Scan(
+			&i.OrgID,
+			&i.Login,
+			&i.Role,
+			&i.Status,
+			&i.WrappedKey,
+			&i.InvitedBy,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listOrgAttachments = `-- name: ListOrgAttachments :many
+SELECT a.id, a.item_id
+FROM item_attachments a
+JOIN items i ON i.id = a.item_id
+JOIN collections c ON c.id = i.collection_id
+WHERE c.org_id = $1 AND (i.expires_at IS NULL OR i.expires_at > NOW())
+`
+
+type ListOrgAttachmentsRow struct {
+	ID     pgtype.UUID `json:"id"`
+	ItemID pgtype.UUID `json:"item_id"`
+}
+
+func (q *Queries) ListOrgAttachments(ctx context.Context, orgID pgtype.UUID) ([]ListOrgAttachmentsRow, error) {
+	rows, err := q.db.Query(ctx, listOrgAttachments, orgID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListOrgAttachmentsRow
+	for rows.Next() {
+		var i ListOrgAttachmentsRow
+		if err := rows.Scan(&i.ID, &i.ItemID); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listOrgItems = `-- name: ListOrgItems :many
+SELECT i.id, i.collection_id
+FROM items i
+JOIN collections c ON c.id = i.collection_id
+WHERE c.org_id = $1 AND (i.expires_at IS NULL OR i.expires_at > NOW())
+`
+
+type ListOrgItemsRow struct {
+	ID           pgtype.UUID `json:"id"`
+	CollectionID pgtype.UUID `json:"collection_id"`
+}
+
+func (q *Queries) ListOrgItems(ctx context.Context, orgID pgtype.UUID) ([]ListOrgItemsRow, error) {
+	rows, err := q.db.Query(ctx, listOrgItems, orgID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListOrgItemsRow
+	for rows.Next() {
+		var i ListOrgItemsRow
+		if err := rows.Scan(&i.ID, &i.CollectionID); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSharedWithUser = `-- name: ListSharedWithUser :many
+SELECT
+    i.id,
+    i.user_login,
+    i.name,
+    i.type,
+    i.encrypted_data_content,
+    i.encrypted_data_nonce,
+    i.encrypted_header_content,
+    i.encrypted_header_nonce,
+    i.meta,
+    i.format,
+    i.created_at,
+    i.updated_at,
+    s.wrapped_key
+FROM item_shares s
+JOIN items i ON i.id = s.item_id
+WHERE s.recipient_login = $1 AND (i.expires_at IS NULL OR i.expires_at > NOW())
+ORDER BY i.created_at DESC
+`
+
+type ListSharedWithUserRow struct {
+	ID                     pgtype.UUID      `json:"id"`
+	UserLogin              string           `json:"user_login"`
+	Name                   string           `json:"name"`
+	Type                   ItemType         `json:"type"`
+	EncryptedDataContent   string           `json:"encrypted_data_content"`
+	EncryptedDataNonce     string           `json:"encrypted_data_nonce"`
+	EncryptedHeaderContent string           `json:"encrypted_header_content"`
+	EncryptedHeaderNonce   string           `json:"encrypted_header_nonce"`
+	Meta                   []byte           `json:"meta"`
+	Format                 int16            `json:"format"`
+	CreatedAt              pgtype.Timestamp `json:"created_at"`
+	UpdatedAt              pgtype.Timestamp `json:"updated_at"`
+	WrappedKey             string           `json:"wrapped_key"`
+}
+
+func (q *Queries) ListSharedWithUser(ctx context.Context, recipientLogin string) ([]ListSharedWithUserRow, error) {
+	rows, err := q.db.Query(ctx, listSharedWithUser, recipientLogin)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListSharedWithUserRow
+	for rows.Next() {
+		var i ListSharedWithUserRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserLogin,
+			&i.Name,
+			&i.Type,
+			&i.EncryptedDataContent,
+			&i.EncryptedDataNonce,
+			&i.EncryptedHeaderContent,
+			&i.EncryptedHeaderNonce,
+			&i.Meta,
+			&i.Format,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.WrappedKey,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTemplates = `-- name: ListTemplates :many
+SELECT id, user_login, encrypted_data_content, encrypted_data_nonce, created_at, updated_at
+FROM item_templates
+WHERE user_login = $1
+ORDER BY created_at
+`
+
+func (q *Queries) ListTemplates(ctx context.Context, userLogin string) ([]ItemTemplate, error) {
+	rows, err := q.db.Query(ctx, listTemplates, userLogin)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ItemTemplate
+	for rows.Next() {
+		var i ItemTemplate
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserLogin,
+			&i.EncryptedDataContent,
+			&i.EncryptedDataNonce,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUserOrganizations = `-- name: ListUserOrganizations :many
+SELECT
+    o.id,
+    o.name,
+    o.created_by,
+    o.created_at,
+    m.role,
+    m.status,
+    m.wrapped_key,
+    o.key_rotation_due
+FROM memberships m
+JOIN organizations o ON o.id = m.org_id
+WHERE m.login = $1
+ORDER BY o.name
+`
+
+type ListUserOrganizationsRow struct {
+	ID             pgtype.UUID      `json:"id"`
+	Name           string           `json:"name"`
+	CreatedBy      string           `json:"created_by"`
+	CreatedAt      pgtype.Timestamp `json:"created_at"`
+	Role           OrgRole          `json:"role"`
+	Status         MembershipStatus `json:"status"`
+	WrappedKey     string           `json:"wrapped_key"`
+	KeyRotationDue bool             `json:"key_rotation_due"`
+}
+
+func (q *Queries) ListUserOrganizations(ctx context.Context, login string) ([]ListUserOrganizationsRow, error) {
+	rows, err := q.db.Query(ctx, listUserOrganizations, login)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListUserOrganizationsRow
+	for rows.Next() {
+		var i ListUserOrganizationsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.CreatedBy,
+			&i.CreatedAt,
+			&i.Role,
+			&i.Status,
+			&i.WrappedKey,
+			&i.KeyRotationDue,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUsersStats = `-- name: ListUsersStats :many
+SELECT
+    u.login,
+    u.locked,
+    u.deleted_at,
+    u.created_at,
+    COUNT(i.id) as item_count,
+    COALESCE(SUM(octet_length(i.name) + octet_length(i.encrypted_data_content) + octet_length(i.encrypted_header_content) + COALESCE(octet_length(i.meta::text), 0)), 0)::BIGINT as storage_bytes
+FROM users u
+LEFT JOIN items i ON i.user_login = u.login
+GROUP BY u.login
+ORDER BY u.login
+`
+
+type ListUsersStatsRow struct {
+	Login        string           `json:"login"`
+	Locked       bool             `json:"locked"`
+	DeletedAt    pgtype.Timestamp `json:"deleted_at"`
+	CreatedAt    pgtype.Timestamp `json:"created_at"`
+	ItemCount    int64            `json:"item_count"`
+	StorageBytes int64            `json:"storage_bytes"`
+}
+
+func (q *Queries) ListUsersStats(ctx context.Context) ([]ListUsersStatsRow, error) {
+	rows, err := q.db.Query(ctx, listUsersStats)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListUsersStatsRow
+	for rows.Next() {
+		var i ListUsersStatsRow
+		if err := rows.Scan(
+			&i.Login,
+			&i.Locked,
+			&i.DeletedAt,
+			&i.CreatedAt,
+			&i.ItemCount,
+			&i.StorageBytes,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const markUserDeleted = `-- name: MarkUserDeleted :execrows
+UPDATE users
+SET deleted_at = NOW(), locked = TRUE, sessions_revoked_at = NOW()
+WHERE login = $1 AND deleted_at IS NULL
+`
+
+func (q *Queries) MarkUserDeleted(ctx context.Context, login string) (int64, error) {
+	result, err := q.db.Exec(ctx, markUserDeleted, login)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const rekeyCollectionItem = `-- name: RekeyCollectionItem :execrows
+UPDATE items
+SET encrypted_data_content = $3, encrypted_data_nonce = $4, encrypted_key = $5, format = $6, name = $7, meta = $8, encrypted_header_content = $9, encrypted_header_nonce = $10, updated_at = NOW()
+WHERE id = $1 AND collection_id = $2
+`
+
+type RekeyCollectionItemParams struct {
+	ID                     pgtype.UUID `json:"id"`
+	CollectionID           pgtype.UUID `json:"collection_id"`
+	EncryptedDataContent   string      `json:"encrypted_data_content"`
+	EncryptedDataNonce     string      `json:"encrypted_data_nonce"`
+	EncryptedKey           string      `json:"encrypted_key"`
+	Format                 int16       `json:"format"`
+	Name                   string      `json:"name"`
+	Meta                   []byte      `json:"meta"`
+	EncryptedHeaderContent string      `json:"encrypted_header_content"`
+	EncryptedHeaderNonce   string      `json:"encrypted_header_nonce"`
+}
+
+func (q *Queries) RekeyCollectionItem(ctx context.Context, arg RekeyCollectionItemParams) (int64, error) {
+	result, err := q.db.Exec(ctx, rekeyCollectionItem,
+		arg.ID,
+		arg.CollectionID,
+		arg.EncryptedDataContent,
+		arg.EncryptedDataNonce,
+		arg.EncryptedKey,
+		arg.Format,
+		arg.Name,
+		arg.Meta,
+		arg.EncryptedHeaderContent,
+		arg.EncryptedHeaderNonce,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const rekeyItem = `-- name: RekeyItem :execrows
+UPDATE items
+SET encrypted_data_content = $3, encrypted_data_nonce = $4, encrypted_key = $5, format = $6, name = $7, meta = $8, search_tokens = $9, encrypted_header_content = $10, encrypted_header_nonce = $11, updated_at = NOW()
+WHERE id = $1 AND user_login = $2
+`
+
+type RekeyItemParams struct {
+	ID                     pgtype.UUID `json:"id"`
+	UserLogin              string      `json:"user_login"`
+	EncryptedDataContent   string      `json:"encrypted_data_content"`
+	EncryptedDataNonce     string      `json:"encrypted_data_nonce"`
+	EncryptedKey           string      `json:"encrypted_key"`
+	Format                 int16       `json:"format"`
+	Name                   string      `json:"name"`
+	Meta                   []byte      `json:"meta"`
+	SearchTokens           []string    `json:"search_tokens"`
+	EncryptedHeaderContent string      `json:"encrypted_header_content"`
+	EncryptedHeaderNonce   string      `json:"encrypted_header_nonce"`
+}
+
+func (q *Queries) RekeyItem(ctx context.Context, arg RekeyItemParams) (int64, error) {
+	result, err := q.db.Exec(ctx, rekeyItem,
+		arg.ID,
+		arg.UserLogin,
+		arg.EncryptedDataContent,
+		arg.EncryptedDataNonce,
+		arg.EncryptedKey,
+		arg.Format,
+		arg.Name,
+		arg.Meta,
+		arg.SearchTokens,
+		arg.EncryptedHeaderContent,
+		arg.EncryptedHeaderNonce,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const requestEmergencyAccess = `-- name: RequestEmergencyAccess :execrows
+UPDATE emergency_access
+SET status = 'REQUESTED', requested_at = NOW()
+WHERE grantor = $1 AND grantee = $2 AND status = 'IDLE'
+`
+
+type RequestEmergencyAccessParams struct {
+	Grantor string `json:"grantor"`
+	Grantee string `json:"grantee"`
+}
+
+func (q *Queries) RequestEmergencyAccess(ctx context.Context, arg RequestEmergencyAccessParams) (int64, error) {
+	result, err := q.db.Exec(ctx, requestEmergencyAccess, arg.Grantor, arg.Grantee)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const revokeAllSessions = `-- name: RevokeAllSessions :execrows
+UPDATE users
+SET sessions_revoked_at = NOW()
+`
+
+func (q *Queries) RevokeAllSessions(ctx context.Context) (int64, error) {
+	result, err := q.db.Exec(ctx, revokeAllSessions)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const revokeUserSessions = `-- name: RevokeUserSessions :execrows
+UPDATE users
+SET sessions_revoked_at = NOW()
+WHERE login = $1
+`
+
+func (q *Queries) RevokeUserSessions(ctx context.Context, login string) (int64, error) {
+	result, err := q.db.Exec(ctx, revokeUserSessions, login)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const saveTemplate = `-- name: SaveTemplate :exec
+INSERT INTO item_templates (id, user_login, encrypted_data_content, encrypted_data_nonce)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (user_login, id) DO UPDATE
+SET encrypted_data_content = EXCLUDED.encrypted_data_content,
+    encrypted_data_nonce = EXCLUDED.encrypted_data_nonce,
+    updated_at = NOW()
+`
+
+type SaveTemplateParams struct {
+	ID                   pgtype.UUID `json:"id"`
+	UserLogin            string      `json:"user_login"`
+	EncryptedDataContent string      `json:"encrypted_data_content"`
+	EncryptedDataNonce   string      `json:"encrypted_data_nonce"`
+}
+
+func (q *Queries) SaveTemplate(ctx context.Context, arg SaveTemplateParams) error {
+	_, err := q.db.Exec(ctx, saveTemplate,
+		arg.ID,
+		arg.UserLogin,
+		arg.EncryptedDataContent,
+		arg.EncryptedDataNonce,
+	)
+	return err
+}
+
+const searchUserItems = `-- name: SearchUserItems :many
+SELECT 
+    i.id,
+    i.name,
+    i.type,
+    i.encrypted_data_content,
+    i.encrypted_data_nonce,
+    i.encrypted_header_content,
+    i.encrypted_header_nonce,
+    i.encrypted_key,
+    i.meta,
+    i.format,
+    i.search_tokens,
+    i.expires_at,
+    i.created_at,
+    i.updated_at,
+    i.favorite,
+    i.last_used_at,
+    i.use_count
+FROM items i
+WHERE i.user_login = $1 AND i.collection_id IS NULL AND i.search_tokens @> $2::text[] AND (i.expires_at IS NULL OR i.expires_at > NOW())
+ORDER BY i.favorite DESC, i.last_used_at DESC NULLS LAST, i.created_at DESC
+`
+
+type SearchUserItemsParams struct {
+	UserLogin string   `json:"user_login"`
+	Tokens    []string `json:"tokens"`
+}
+
+type SearchUserItemsRow struct {
+	ID                     pgtype.UUID        `json:"id"`
+	Name                   string             `json:"name"`
+	Type                   ItemType           `json:"type"`
+	EncryptedDataContent   string             `json:"encrypted_data_content"`
+	EncryptedDataNonce     string             `json:"encrypted_data_nonce"`
+	EncryptedHeaderContent string             `json:"encrypted_header_content"`
+	EncryptedHeaderNonce   string             `json:"encrypted_header_nonce"`
+	EncryptedKey           string             `json:"encrypted_key"`
+	Meta                   []byte             `json:"meta"`
+	Format                 int16              `json:"format"`
+	SearchTokens           []string           `json:"search_tokens"`
+	ExpiresAt              pgtype.Timestamptz `json:"expires_at"`
+	CreatedAt              pgtype.Timestamp   `json:"created_at"`
+	UpdatedAt              pgtype.Timestamp   `json:"updated_at"`
+	Favorite               bool               `json:"favorite"`
+	LastUsedAt             pgtype.Timestamp   `json:"last_used_at"`
+	UseCount               int32              `json:"use_count"`
+}
+
+func (q *Queries) SearchUserItems(ctx context.Context, arg SearchUserItemsParams) ([]SearchUserItemsRow, error) {
+	rows, err := q.db.Query(ctx, searchUserItems, arg.UserLogin, arg.Tokens)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SearchUserItemsRow
+	for rows.Next() {
+		var i SearchUserItemsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Type,
+			&i.EncryptedDataContent,
+			&i.EncryptedDataNonce,
+			&i.EncryptedHeaderContent,
+			&i.EncryptedHeaderNonce,
+			&i.EncryptedKey,
+			&i.Meta,
+			&i.Format,
+			&i.SearchTokens,
+			&i.ExpiresAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Favorite,
+			&i.LastUsedAt,
+			&i.UseCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const setDeviceApprovalRequired = `-- name: SetDeviceApprovalRequired :execrows
+UPDATE users
+SET require_device_approval = $2
+WHERE login = $1
+`
+
+type SetDeviceApprovalRequiredParams struct {
+	Login                 string `json:"login"`
+	RequireDeviceApproval bool   `json:"require_device_approval"`
+}
+
+func (q *Queries) SetDeviceApprovalRequired(ctx context.Context, arg SetDeviceApprovalRequiredParams) (int64, error) {
+	result, err := q.db.Exec(ctx, setDeviceApprovalRequired, arg.Login, arg.RequireDeviceApproval)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const setItemFavorite = `-- name: SetItemFavorite :execrows
+UPDATE items
+SET favorite = $3
+WHERE id = $1 AND user_login = $2 AND collection_id IS NULL
+`
+
+type SetItemFavoriteParams struct {
+	ID        pgtype.UUID `json:"id"`
+	UserLogin string      `json:"user_login"`
+	Favorite  bool        `json:"favorite"`
+}
+
+func (q *Queries) SetItemFavorite(ctx context.Context, arg SetItemFavoriteParams) (int64, error) {
+	result, err := q.db.Exec(ctx, setItemFavorite, arg.ID, arg.UserLogin, arg.Favorite)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const setOrgKeyRotationDue = `-- name: SetOrgKeyRotationDue :exec
+UPDATE organizations
+SET key_rotation_due = $2
+WHERE id = $1
+`
+
+type SetOrgKeyRotationDueParams struct {
+	ID             pgtype.UUID `json:"id"`
+	KeyRotationDue bool        `json:"key_rotation_due"`
+}
+
+func (q *Queries) SetOrgKeyRotationDue(ctx context.Context, arg SetOrgKeyRotationDueParams) error {
+	_, err := q.db.Exec(ctx, setOrgKeyRotationDue, arg.ID, arg.KeyRotationDue)
+	return err
+}
+
+const setUserLocked = `-- name: SetUserLocked :execrows
+UPDATE users
+SET locked = $2
+WHERE login = $1
+`
+
+type SetUserLockedParams struct {
+	Login  string `json:"login"`
+	Locked bool   `json:"locked"`
+}
+
+func (q *Queries) SetUserLocked(ctx context.Context, arg SetUserLockedParams) (int64, error) {
+	result, err := q.db.Exec(ctx, setUserLocked, arg.Login, arg.Locked)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const signUpUser = `-- name: SignUpUser :exec
+INSERT INTO users (login, password, salt)
+VALUES ($1, $2, $3)
+`
+
+type SignUpUserParams struct {
+	Login    string `json:"login"`
+	Password []byte `json:"password"`
+	Salt     string `json:"salt"`
+}
+
+func (q *Queries) SignUpUser(ctx context.Context, arg SignUpUserParams) error {
+	_, err := q.db.Exec(ctx, signUpUser, arg.Login, arg.Password, arg.Salt)
+	return err
+}
+
+const touchDevice = `-- name: TouchDevice :exec
+UPDATE devices
+SET last_seen_at = NOW(), last_seen_ip = $3
+WHERE user_login = $1 AND id = $2
+`
+
+type TouchDeviceParams struct {
+	UserLogin  string      `json:"user_login"`
+	ID         pgtype.UUID `json:"id"`
+	LastSeenIp string      `json:"last_seen_ip"`
+}
+
+func (q *Queries) TouchDevice(ctx context.Context, arg TouchDeviceParams) error {
+	_, err := q.db.Exec(ctx, touchDevice, arg.UserLogin, arg.ID, arg.LastSeenIp)
+	return err
+}
+
+const touchItem = `-- name: TouchItem :execrows
+UPDATE items
+SET last_used_at = NOW(), use_count = use_count + 1
+WHERE id = $1 AND user_login = $2 AND collection_id IS NULL
+`
+
+type TouchItemParams struct {
+	ID        pgtype.UUID `json:"id"`
+	UserLogin string      `json:"user_login"`
+}
+
+func (q *Queries) TouchItem(ctx context.Context, arg TouchItemParams) (int64, error) {
+	result, err := q.db.Exec(ctx, touchItem, arg.ID, arg.UserLogin)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const updateAttachmentInfo = `-- name: UpdateAttachmentInfo :execrows
+UPDATE item_attachments
+SET encrypted_info_content = $3, encrypted_info_nonce = $4
+WHERE id = $1 AND item_id = $2
+`
+
+type UpdateAttachmentInfoParams struct {
+	ID                   pgtype.UUID `json:"id"`
+	ItemID               pgtype.UUID `json:"item_id"`
+	EncryptedInfoContent string      `json:"encrypted_info_content"`
+	EncryptedInfoNonce   string      `json:"encrypted_info_nonce"`
+}
+
+func (q *Queries) UpdateAttachmentInfo(ctx context.Context, arg UpdateAttachmentInfoParams) (int64, error) {
+	result, err := q.db.Exec(ctx, updateAttachmentInfo,
+		arg.ID,
+		arg.ItemID,
+		arg.EncryptedInfoContent,
+		arg.EncryptedInfoNonce,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const updateCollectionKey = `-- name: UpdateCollectionKey :execrows
+UPDATE collections
+SET encrypted_key = $3
+WHERE id = $1 AND org_id = $2
+`
+
+type UpdateCollectionKeyParams struct {
+	ID           pgtype.UUID `json:"id"`
+	OrgID        pgtype.UUID `json:"org_id"`
+	EncryptedKey string      `json:"encrypted_key"`
+}
+
+func (q *Queries) UpdateCollectionKey(ctx context.Context, arg UpdateCollectionKeyParams) (int64, error) {
+	result, err := q.db.Exec(ctx, updateCollectionKey, arg.ID, arg.OrgID, arg.EncryptedKey)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const updateItemShareKey = `-- name: UpdateItemShareKey :execrows
+UPDATE item_shares
+SET wrapped_key = $3
+WHERE item_id = $1 AND recipient_login = $2
+`
+
+type UpdateItemShareKeyParams struct {
+	ItemID         pgtype.UUID `json:"item_id"`
+	RecipientLogin string      `json:"recipient_login"`
+	WrappedKey     string      `json:"wrapped_key"`
+}
+
+func (q *Queries) UpdateItemShareKey(ctx context.Context, arg UpdateItemShareKeyParams) (int64, error) {
+	result, err := q.db.Exec(ctx, updateItemShareKey, arg.ItemID, arg.RecipientLogin, arg.WrappedKey)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const updateMembershipKey = `-- name: UpdateMembershipKey :execrows
+UPDATE memberships
+SET wrapped_key = $3
+WHERE org_id = $1 AND login = $2
+`
+
+type UpdateMembershipKeyParams struct {
+	OrgID      pgtype.UUID `json:"org_id"`
+	Login      string      `json:"login"`
+	WrappedKey string      `json:"wrapped_key"`
+}
+
+func (q *Queries) UpdateMembershipKey(ctx context.Context, arg UpdateMembershipKeyParams) (int64, error) {
+	result, err := q.db.Exec(ctx, updateMembershipKey, arg.OrgID, arg.Login, arg.WrappedKey)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const updateMembershipRole = `-- name: UpdateMembershipRole :execrows
+UPDATE memberships
+SET role = $3
+WHERE org_id = $1 AND login = $2
+`
+
+type UpdateMembershipRoleParams struct {
+	OrgID pgtype.UUID `json:"org_id"`
+	Login string      `json:"login"`
+	Role  OrgRole     `json:"role"`
+}
+
+func (q *Queries) UpdateMembershipRole(ctx context.Context, arg UpdateMembershipRoleParams) (int64, error) {
+	result, err := q.db.Exec(ctx, updateMembershipRole, arg.OrgID, arg.Login, arg.Role)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const updateUserPassword = `-- name: UpdateUserPassword :execrows
+UPDATE users
+SET password = $2
+WHERE login = $1
+`
+
+type UpdateUserPasswordParams struct {
+	Login    string `json:"login"`
+	Password []byte `json:"password"`
+}
+
+func (q *Queries) UpdateUserPassword(ctx context.Context, arg UpdateUserPasswordParams) (int64, error) {
+	result, err := q.db.Exec(ctx, updateUserPassword, arg.Login, arg.Password)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const updateUserPrivateKey = `-- name: UpdateUserPrivateKey :execrows
+UPDATE user_keys
+SET encrypted_private_key = $2
+WHERE login = $1
+`
+
+type UpdateUserPrivateKeyParams struct {
+	Login               string `json:"login"`
+	EncryptedPrivateKey string `json:"encrypted_private_key"`
+}
+
+func (q *Queries) UpdateUserPrivateKey(ctx context.Context, arg UpdateUserPrivateKeyParams) (int64, error) {
+	result, err := q.db.Exec(ctx, updateUserPrivateKey, arg.Login, arg.EncryptedPrivateKey)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const upsertDevice = `-- name: UpsertDevice :one
+INSERT INTO devices (id, user_login, name, os, public_key, approved, last_seen_ip)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+ON CONFLICT (user_login, id) DO UPDATE
+SET name = EXCLUDED.name,
+    os = EXCLUDED.os,
+    public_key = COALESCE(devices.public_key, EXCLUDED.public_key),
+    approved = CASE
+        WHEN devices.public_key IS NULL AND EXCLUDED.public_key IS NOT NULL THEN EXCLUDED.approved
+        ELSE devices.approved
+    END,
+    last_seen_at = NOW(),
+    last_seen_ip = EXCLUDED.last_seen_ip
+RETURNING id, user_login, name, os, public_key, approved, created_at, last_seen_at, last_seen_ip
+`
+
+type UpsertDeviceParams struct {
+	ID         pgtype.UUID `json:"id"`
+	UserLogin  string      `json:"user_login"`
+	Name       string      `json:"name"`
+	Os         string      `json:"os"`
+	PublicKey  []byte      `json:"public_key"`
+	Approved   bool        `json:"approved"`
+	LastSeenIp string      `json:"last_seen_ip"`
+}
+
+func (q *Queries) UpsertDevice(ctx context.Context, arg UpsertDeviceParams) (Device, error) {
+	row := q.db.QueryRow(ctx, upsertDevice,
+		arg.ID,
+		arg.UserLogin,
+		arg.Name,
+		arg.Os,
+		arg.PublicKey,
+		arg.Approved,
+		arg.LastSeenIp,
+	)
+	var i Device
+	err := row.Scan(
+		&i.ID,
+		&i.UserLogin,
+		&i.Name,
+		&i.Os,
+		&i.PublicKey,
+		&i.Approved,
+		&i.CreatedAt,
+		&i.LastSeenAt,
+		&i.LastSeenIp,
+	)
+	return i, err
+}
+
+const upsertEmergencyAccess = `-- name: UpsertEmergencyAccess :exec
+INSERT INTO emergency_access (grantor, grantee, access_type, wait_hours, wrapped_key)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (grantor, grantee) DO UPDATE
+SET access_type = EXCLUDED.access_type, wait_hours = EXCLUDED.wait_hours, wrapped_key = EXCLUDED.wrapped_key,
+    status = 'IDLE', requested_at = NULL
+`
+
+type UpsertEmergencyAccessParams struct {
+	Grantor    string              `json:"grantor"`
+	Grantee    string              `json:"grantee"`
+	AccessType EmergencyAccessType `json:"access_type"`
+	WaitHours  int32               `json:"wait_hours"`
+	WrappedKey string              `json:"wrapped_key"`
+}
+
+func (q *Queries) UpsertEmergencyAccess(ctx context.Context, arg UpsertEmergencyAccessParams) error {
+	_, err := q.db.Exec(ctx, upsertEmergencyAccess,
+		arg.Grantor,
+		arg.Grantee,
+		arg.AccessType,
+		arg.WaitHours,
+		arg.WrappedKey,
+	)
+	return err
+}
+
+const upsertItemShare = `-- name: UpsertItemShare :exec
+INSERT INTO item_shares (item_id, recipient_login, wrapped_key)
+VALUES ($1, $2, $3)
+ON CONFLICT (item_id, recipient_login) DO UPDATE SET wrapped_key = EXCLUDED.wrapped_key
+`
+
+type UpsertItemShareParams struct {
+	ItemID         pgtype.UUID `json:"item_id"`
+	RecipientLogin string      `json:"recipient_login"`
+	WrappedKey     string      `json:"wrapped_key"`
+}
+
+func (q *Queries) UpsertItemShare(ctx context.Context, arg UpsertItemShareParams) error {
+	_, err := q.db.Exec(ctx, upsertItemShare, arg.ItemID, arg.RecipientLogin, arg.WrappedKey)
+	return err
+}
+
+const useDeviceSignIn = `-- name: UseDeviceSignIn :execrows
+INSERT INTO device_sign_ins (user_login, device_id, signed_at)
+VALUES ($1, $2, $3)
+ON CONFLICT (user_login, device_id) DO UPDATE
+SET signed_at = EXCLUDED.signed_at
+WHERE device_sign_ins.signed_at < EXCLUDED.signed_at
+`
+
+type UseDeviceSignInParams struct {
+	UserLogin string             `json:"user_login"`
+	DeviceID  pgtype.UUID        `json:"device_id"`
+	SignedAt  pgtype.Timestamptz `json:"signed_at"`
+}
+
+func (q *Queries) UseDeviceSignIn(ctx context.Context, arg UseDeviceSignInParams) (int64, error) {
+	result, err := q.db.Exec(ctx, useDeviceSignIn, arg.UserLogin, arg.DeviceID, arg.SignedAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
 }
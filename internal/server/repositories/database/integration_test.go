@@ -50,10 +50,10 @@ func TestIntegrationUserItemOperations(t *testing.T) {
 	assert.NoError(t, err)
 
 	// Test get user
-	mock.ExpectQuery("SELECT login, password, salt FROM users").
+	mock.ExpectQuery("SELECT login, password, salt, locked, sessions_revoked_at, deleted_at, created_at FROM users").
 		WithArgs("integrationuser").
-		WillReturnRows(pgxmock.NewRows([]string{"login", "password", "salt"}).
-			AddRow("integrationuser", []byte("hashedpassword"), "testsalt"))
+		WillReturnRows(pgxmock.NewRows([]string{"login", "password", "salt", "locked", "sessions_revoked_at", "deleted_at", "created_at"}).
+			AddRow("integrationuser", []byte("hashedpassword"), "testsalt", false, nil, nil, nil))
 
 	retrievedUser, err := pgdb.GetUser(ctx, "integrationuser")
 	assert.NoError(t, err)
@@ -75,7 +75,7 @@ func TestIntegrationUserItemOperations(t *testing.T) {
 
 	// Use correct JSON for Meta
 	mock.ExpectQuery("INSERT INTO items").
-		WithArgs("integrationuser", "test credential", itemTypeModelsToPg(models.ItemTypeCREDENTIALS), "encrypted_login_password", "random_nonce", []byte(`{"Map":null}`)).
+		WithArgs("integrationuser", "test credential", itemTypeModelsToPg(models.ItemTypeCREDENTIALS), "encrypted_login_password", "random_nonce", []byte(`{"Map":null}`), "", pgtype.UUID{}, int16(1), []string{}, pgtype.Timestamptz{}, "", "").
 		WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow("550e8400-e29b-41d4-a716-446655440000"))
 
 	err = pgdb.AddItem(ctx, item)
@@ -91,16 +91,25 @@ func TestIntegrationUserItemOperations(t *testing.T) {
 		WithArgs("integrationuser").
 		WillReturnRows(pgxmock.NewRows([]string{
 			"id", "name", "type", "encrypted_data_content",
-			"encrypted_data_nonce", "meta", "created_at", "updated_at",
+			"encrypted_data_nonce", "encrypted_header_content", "encrypted_header_nonce", "encrypted_key", "meta", "format", "search_tokens", "expires_at", "created_at", "updated_at", "favorite", "last_used_at", "use_count",
 		}).AddRow(
 			testUUID,
 			"test credential",
 			itemTypeModelsToPg(models.ItemTypeCREDENTIALS),
 			"encrypted_login_password",
 			"random_nonce",
+			"",
+			"",
+			"",
 			[]byte(`{"Map":null}`),
+			int16(1),
+			[]string{},
+			pgtype.Timestamptz{},
 			pgtype.Timestamp{Time: time.Now(), Valid: true},
 			pgtype.Timestamp{Time: time.Now(), Valid: true},
+			false,
+			pgtype.Timestamp{},
+			int32(0),
 		))
 
 	items, err := pgdb.GetAllUserItems(ctx, "integrationuser")
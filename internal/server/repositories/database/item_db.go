@@ -6,9 +6,11 @@ import (
 	"errors"
 	"fmt"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"gophkeeper/internal/errs"
 	gen "gophkeeper/internal/server/repositories/database/generated"
 	"gophkeeper/models"
 	"strings"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
@@ -18,16 +20,25 @@ import (
 type ItemDatabase interface {
 	GetAllUserItems(ctx context.Context, login string) ([]models.EncryptedItem, error)
 	GetUserItemsWithType(ctx context.Context, typ models.ItemType, login string) ([]models.EncryptedItem, error)
+	SearchUserItems(ctx context.Context, login string, tokens []string) ([]models.EncryptedItem, error)
 	GetTypesCounts(ctx context.Context, login string) (map[models.ItemType]int32, error)
 	AddItem(ctx context.Context, item *models.EncryptedItem) error
 	EditItem(ctx context.Context, item *models.EncryptedItem) error
 	DeleteItem(ctx context.Context, login string, itemID [16]byte) error
+	DeleteExpiredItems(ctx context.Context) (int64, error)
+	GetCollectionItems(ctx context.Context, typ models.ItemType, collectionID [16]byte) ([]models.EncryptedItem, error)
+	GetCollectionTypesCounts(ctx context.Context, collectionID [16]byte) (map[models.ItemType]int32, error)
+	GetItemCollection(ctx context.Context, itemID [16]byte) ([16]byte, error)
+	DeleteCollectionItem(ctx context.Context, collectionID, itemID [16]byte) error
+	TouchItem(ctx context.Context, login string, itemID [16]byte) error
+	SetItemFavorite(ctx context.Context, login string, itemID [16]byte, favorite bool) error
 }
 
 type PoolInterface interface {
 	Exec(ctx context.Context, sql string, arguments ...interface{}) (pgconn.CommandTag, error)
 	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
 	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+	Begin(ctx context.Context) (pgx.Tx, error)
 }
 
 var _ PoolInterface = (*pgxpool.Pool)(nil)
@@ -73,9 +84,20 @@ func (db *ItemDB) GetAllUserItems(ctx context.Context, login string) ([]models.E
 			Name:          d.Name,
 			Type:          models.ItemType(d.Type),
 			EncryptedData: encData,
-			Meta:          meta,
-			CreatedAt:     d.CreatedAt.Time,
-			UpdatedAt:     d.UpdatedAt.Time,
+			EncryptedHeader: models.EncryptedData{
+				EncryptedContent: d.EncryptedHeaderContent,
+				Nonce:            d.EncryptedHeaderNonce,
+			},
+			EncryptedKey: d.EncryptedKey,
+			Meta:         meta,
+			CreatedAt:    d.CreatedAt.Time,
+			UpdatedAt:    d.UpdatedAt.Time,
+			Format:       models.ItemFormat(d.Format),
+			SearchTokens: d.SearchTokens,
+			ExpiresAt:    d.ExpiresAt.Time,
+			Favorite:     d.Favorite,
+			LastUsedAt:   d.LastUsedAt.Time,
+			UseCount:     d.UseCount,
 		}
 	}
 	return items, nil
@@ -109,27 +131,99 @@ func (db *ItemDB) GetUserItemsWithType(ctx context.Context, typ models.ItemType,
 			Name:          d.Name,
 			Type:          models.ItemType(d.Type),
 			EncryptedData: encData,
-			Meta:          meta,
-			CreatedAt:     d.CreatedAt.Time,
-			UpdatedAt:     d.UpdatedAt.Time,
+			EncryptedHeader: models.EncryptedData{
+				EncryptedContent: d.EncryptedHeaderContent,
+				Nonce:            d.EncryptedHeaderNonce,
+			},
+			EncryptedKey: d.EncryptedKey,
+			Meta:         meta,
+			CreatedAt:    d.CreatedAt.Time,
+			UpdatedAt:    d.UpdatedAt.Time,
+			Format:       models.ItemFormat(d.Format),
+			SearchTokens: d.SearchTokens,
+			ExpiresAt:    d.ExpiresAt.Time,
+			Favorite:     d.Favorite,
+			LastUsedAt:   d.LastUsedAt.Time,
+			UseCount:     d.UseCount,
 		}
 	}
 	return items, nil
 }
 
+// SearchUserItems returns personal items that have all the search tokens.
+func (db *ItemDB) SearchUserItems(ctx context.Context, login string, tokens []string) ([]models.EncryptedItem, error) {
+	dbItems, err := db.q.SearchUserItems(ctx, gen.SearchUserItemsParams{
+		UserLogin: login,
+		Tokens:    tokens,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("search user items error: %w", err)
+	}
+
+	items := make([]models.EncryptedItem, len(dbItems))
+	for i, d := range dbItems {
+		var meta models.Meta
+		if err := json.Unmarshal(d.Meta, &meta); err != nil {
+			return nil, fmt.Errorf("unmarshal meta info error: %w", err)
+		}
+
+		items[i] = models.EncryptedItem{
+			ID:        d.ID.Bytes,
+			UserLogin: login,
+			Name:      d.Name,
+			Type:      models.ItemType(d.Type),
+			EncryptedData: models.EncryptedData{
+				EncryptedContent: d.EncryptedDataContent,
+				Nonce:            d.EncryptedDataNonce,
+			},
+			EncryptedHeader: models.EncryptedData{
+				EncryptedContent: d.EncryptedHeaderContent,
+				Nonce:            d.EncryptedHeaderNonce,
+			},
+			EncryptedKey: d.EncryptedKey,
+			Meta:         meta,
+			CreatedAt:    d.CreatedAt.Time,
+			UpdatedAt:    d.UpdatedAt.Time,
+			Format:       models.ItemFormat(d.Format),
+			SearchTokens: d.SearchTokens,
+			ExpiresAt:    d.ExpiresAt.Time,
+			Favorite:     d.Favorite,
+			LastUsedAt:   d.LastUsedAt.Time,
+			UseCount:     d.UseCount,
+		}
+	}
+	return items, nil
+}
+
+// itemTypeModelsToPg maps registered types to the SQL enum, whose values
+// are the type names.
 func itemTypeModelsToPg(typ models.ItemType) gen.ItemType {
-	switch strings.ToUpper(typ.String()) {
-	case string(gen.ItemTypeCREDENTIALS):
-		return gen.ItemTypeCREDENTIALS
-	case string(gen.ItemTypeTEXT):
-		return gen.ItemTypeTEXT
-	case string(gen.ItemTypeBINARY):
-		return gen.ItemTypeBINARY
-	case string(gen.ItemTypeCARD):
-		return gen.ItemTypeCARD
-	default:
+	t := models.ItemType(strings.ToUpper(typ.String()))
+	if _, ok := models.LookupType(t); !ok {
 		return "unknown"
 	}
+	return gen.ItemType(t)
+}
+
+// itemFormatModelsToPg stores items of clients that do not send a format as V1.
+func itemFormatModelsToPg(f models.ItemFormat) int16 {
+	if f == 0 {
+		return int16(models.ItemFormatV1)
+	}
+	return int16(f)
+}
+
+// searchTokensModelsToPg keeps the column non-null for items without tokens.
+func searchTokensModelsToPg(tokens []string) []string {
+	if tokens == nil {
+		return []string{}
+	}
+	return tokens
+}
+
+// expiresAtModelsToPg stores the zero time as NULL, items without expiry.
+func expiresAtModelsToPg(t time.Time) pgtype.Timestamptz {
+	return pgtype.Timestamptz{Time: t, Valid: !t.IsZero()}
 }
 
 func (db *ItemDB) GetTypesCounts(ctx context.Context, login string) (map[models.ItemType]int32, error) {
@@ -151,12 +245,19 @@ func (db *ItemDB) AddItem(ctx context.Context, item *models.EncryptedItem) error
 		return fmt.Errorf("marshal meta info error: %w", err)
 	}
 	if _, err = db.q.AddItem(ctx, gen.AddItemParams{
-		UserLogin:            item.UserLogin,
-		Name:                 item.Name,
-		Type:                 gen.ItemType(item.Type),
-		EncryptedDataContent: item.EncryptedData.EncryptedContent,
-		EncryptedDataNonce:   item.EncryptedData.Nonce,
-		Meta:                 meta,
+		UserLogin:              item.UserLogin,
+		Name:                   item.Name,
+		Type:                   gen.ItemType(item.Type),
+		EncryptedDataContent:   item.EncryptedData.EncryptedContent,
+		EncryptedDataNonce:     item.EncryptedData.Nonce,
+		EncryptedHeaderContent: item.EncryptedHeader.EncryptedContent,
+		EncryptedHeaderNonce:   item.EncryptedHeader.Nonce,
+		Meta:                   meta,
+		EncryptedKey:           item.EncryptedKey,
+		CollectionID:           pgtype.UUID{Bytes: item.CollectionID, Valid: item.CollectionID != [16]byte{}},
+		Format:                 itemFormatModelsToPg(item.Format),
+		SearchTokens:           searchTokensModelsToPg(item.SearchTokens),
+		ExpiresAt:              expiresAtModelsToPg(item.ExpiresAt),
 	}); err != nil {
 		return fmt.Errorf("add item error: %w", err)
 	}
@@ -169,11 +270,17 @@ func (db *ItemDB) EditItem(ctx context.Context, item *models.EncryptedItem) erro
 		return fmt.Errorf("marshal meta info error: %w", err)
 	}
 	if err := db.q.EditItem(ctx, gen.EditItemParams{
-		ID:                   pgtype.UUID{Bytes: item.ID, Valid: true},
-		Name:                 item.Name,
-		EncryptedDataContent: item.EncryptedData.EncryptedContent,
-		EncryptedDataNonce:   item.EncryptedData.Nonce,
-		Meta:                 meta,
+		ID:                     pgtype.UUID{Bytes: item.ID, Valid: true},
+		Name:                   item.Name,
+		EncryptedDataContent:   item.EncryptedData.EncryptedContent,
+		EncryptedDataNonce:     item.EncryptedData.Nonce,
+		EncryptedHeaderContent: item.EncryptedHeader.EncryptedContent,
+		EncryptedHeaderNonce:   item.EncryptedHeader.Nonce,
+		Meta:                   meta,
+		EncryptedKey:           item.EncryptedKey,
+		Format:                 itemFormatModelsToPg(item.Format),
+		SearchTokens:           searchTokensModelsToPg(item.SearchTokens),
+		ExpiresAt:              expiresAtModelsToPg(item.ExpiresAt),
 	}); err != nil {
 		return fmt.Errorf("edit item error: %w", err)
 	}
@@ -187,3 +294,158 @@ func (db *ItemDB) DeleteItem(ctx context.Context, login string, itemID [16]byte)
 		ID:        pgtype.UUID{Bytes: itemID, Valid: true},
 	})
 }
+
+// auditItemExpired is the audit action of items removed by the reaper.
+const auditItemExpired = "item_expired"
+
+// DeleteExpiredItems removes items past their expiry and records an audit
+// event for each of them.
+func (db *ItemDB) DeleteExpiredItems(ctx context.Context) (int64, error) {
+	tx, err := db.pool.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("begin tx error: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	q := db.q.WithTx(tx)
+	deleted, err := q.DeleteExpiredItems(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("delete expired items error: %w", err)
+	}
+	for _, d := range deleted {
+		if err := q.AddAuditEvent(ctx, gen.AddAuditEventParams{
+			Login:  d.UserLogin,
+			Action: auditItemExpired,
+			ItemID: d.ID,
+		}); err != nil {
+			return 0, fmt.Errorf("add audit event error: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("commit tx error: %w", err)
+	}
+	return int64(len(deleted)), nil
+}
+
+// GetCollectionItems returns organization items of the collection, all
+// types when typ is UNSPECIFIED.
+func (db *ItemDB) GetCollectionItems(ctx context.Context, typ models.ItemType, collectionID [16]byte) ([]models.EncryptedItem, error) {
+	var dbItems []gen.GetCollectionItemsRow
+	var err error
+	if typ != models.ItemTypeUNSPECIFIED {
+		var typed []gen.GetCollectionItemsWithTypeRow
+		typed, err = db.q.GetCollectionItemsWithType(ctx, gen.GetCollectionItemsWithTypeParams{
+			CollectionID: pgtype.UUID{Bytes: collectionID, Valid: true},
+			Type:         itemTypeModelsToPg(typ),
+		})
+		for _, t := range typed {
+			dbItems = append(dbItems, gen.GetCollectionItemsRow(t))
+		}
+	} else {
+		dbItems, err = db.q.GetCollectionItems(ctx, pgtype.UUID{Bytes: collectionID, Valid: true})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get collection items error: %w", err)
+	}
+
+	items := make([]models.EncryptedItem, len(dbItems))
+	for i, d := range dbItems {
+		var meta models.Meta
+		if err := json.Unmarshal(d.Meta, &meta); err != nil {
+			return nil, fmt.Errorf("unmarshal meta info error: %w", err)
+		}
+
+		items[i] = models.EncryptedItem{
+			ID:        d.ID.Bytes,
+			UserLogin: d.UserLogin,
+			Name:      d.Name,
+			Type:      models.ItemType(d.Type),
+			EncryptedData: models.EncryptedData{
+				EncryptedContent: d.EncryptedDataContent,
+				Nonce:            d.EncryptedDataNonce,
+			},
+			EncryptedHeader: models.EncryptedData{
+				EncryptedContent: d.EncryptedHeaderContent,
+				Nonce:            d.EncryptedHeaderNonce,
+			},
+			EncryptedKey: d.EncryptedKey,
+			CollectionID: collectionID,
+			Meta:         meta,
+			CreatedAt:    d.CreatedAt.Time,
+			UpdatedAt:    d.UpdatedAt.Time,
+			Format:       models.ItemFormat(d.Format),
+		}
+	}
+	return items, nil
+}
+
+func (db *ItemDB) GetCollectionTypesCounts(ctx context.Context, collectionID [16]byte) (map[models.ItemType]int32, error) {
+	dbCounts, err := db.q.GetCollectionTypesCounts(ctx, pgtype.UUID{Bytes: collectionID, Valid: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get counts of collection item types: %w", err)
+	}
+
+	res := make(map[models.ItemType]int32, len(dbCounts))
+	for _, t := range dbCounts {
+		res[models.ItemType(t.Type)] = int32(t.Count)
+	}
+	return res, nil
+}
+
+// GetItemCollection returns the collection of the item, zero for personal items.
+func (db *ItemDB) GetItemCollection(ctx context.Context, itemID [16]byte) ([16]byte, error) {
+	id, err := db.q.GetItemCollection(ctx, pgtype.UUID{Bytes: itemID, Valid: true})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return [16]byte{}, errs.ErrItemNotFound
+	}
+	if err != nil {
+		return [16]byte{}, fmt.Errorf("get item collection error: %w", err)
+	}
+	return id.Bytes, nil
+}
+
+func (db *ItemDB) DeleteCollectionItem(ctx context.Context, collectionID, itemID [16]byte) error {
+	n, err := db.q.DeleteCollectionItem(ctx, gen.DeleteCollectionItemParams{
+		ID:           pgtype.UUID{Bytes: itemID, Valid: true},
+		CollectionID: pgtype.UUID{Bytes: collectionID, Valid: true},
+	})
+	if err != nil {
+		return fmt.Errorf("delete collection item error: %w", err)
+	}
+	if n == 0 {
+		return errs.ErrItemNotFound
+	}
+	return nil
+}
+
+// TouchItem records a use of a personal item. It leaves updated_at alone,
+// using an item does not change it.
+func (db *ItemDB) TouchItem(ctx context.Context, login string, itemID [16]byte) error {
+	n, err := db.q.TouchItem(ctx, gen.TouchItemParams{
+		ID:        pgtype.UUID{Bytes: itemID, Valid: true},
+		UserLogin: login,
+	})
+	if err != nil {
+		return fmt.Errorf("touch item error: %w", err)
+	}
+	if n == 0 {
+		return errs.ErrItemNotFound
+	}
+	return nil
+}
+
+func (db *ItemDB) SetItemFavorite(ctx context.Context, login string, itemID [16]byte, favorite bool) error {
+	n, err := db.q.SetItemFavorite(ctx, gen.SetItemFavoriteParams{
+		ID:        pgtype.UUID{Bytes: itemID, Valid: true},
+		UserLogin: login,
+		Favorite:  favorite,
+	})
+	if err != nil {
+		return fmt.Errorf("set item favorite error: %w", err)
+	}
+	if n == 0 {
+		return errs.ErrItemNotFound
+	}
+	return nil
+}
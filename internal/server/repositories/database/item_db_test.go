@@ -3,6 +3,7 @@ package database
 import (
 	"context"
 	"fmt"
+	"gophkeeper/internal/errs"
 	gen "gophkeeper/internal/server/repositories/database/generated"
 	"gophkeeper/models"
 	"testing"
@@ -46,7 +47,7 @@ func TestItemDB_AddItem(t *testing.T) {
 			},
 			mockFn: func() {
 				mock.ExpectQuery("INSERT INTO items").
-					WithArgs("testuser", "test item", itemTypeModelsToPg("CREDENTIALS"), "encrypted_content", "test_nonce", []byte(`{"Map":null}`)).
+					WithArgs("testuser", "test item", itemTypeModelsToPg("CREDENTIALS"), "encrypted_content", "test_nonce", []byte(`{"Map":null}`), "", pgtype.UUID{}, int16(1), []string{}, pgtype.Timestamptz{}, "", "").
 					WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow("550e8400-e29b-41d4-a716-446655440000"))
 			},
 			wantErr: false,
@@ -65,7 +66,7 @@ func TestItemDB_AddItem(t *testing.T) {
 			},
 			mockFn: func() {
 				mock.ExpectQuery("INSERT INTO items").
-					WithArgs("testuser", "test item", itemTypeModelsToPg("CREDENTIALS"), "encrypted_content", "test_nonce", []byte(`{"Map":null}`)).
+					WithArgs("testuser", "test item", itemTypeModelsToPg("CREDENTIALS"), "encrypted_content", "test_nonce", []byte(`{"Map":null}`), "", pgtype.UUID{}, int16(1), []string{}, pgtype.Timestamptz{}, "", "").
 					WillReturnError(fmt.Errorf("foreign key constraint fails"))
 			},
 			wantErr: true,
@@ -115,16 +116,25 @@ func TestItemDB_GetAllUserItems(t *testing.T) {
 
 				rows := pgxmock.NewRows([]string{
 					"id", "name", "type", "encrypted_data_content",
-					"encrypted_data_nonce", "meta", "created_at", "updated_at",
+					"encrypted_data_nonce", "encrypted_header_content", "encrypted_header_nonce", "encrypted_key", "meta", "format", "search_tokens", "expires_at", "created_at", "updated_at", "favorite", "last_used_at", "use_count",
 				}).AddRow(
 					testUUID, // use pgtype.UUID
 					"test item",
 					"CREDENTIALS",
 					"encrypted_content",
 					"test_nonce",
+					"",
+					"",
+					"",
 					[]byte(`{"Map":null}`),
+					int16(1),
+					[]string{},
+					pgtype.Timestamptz{},
 					pgtype.Timestamp{Time: time.Now(), Valid: true},
 					pgtype.Timestamp{Time: time.Now(), Valid: true},
+					false,
+					pgtype.Timestamp{},
+					int32(0),
 				)
 				mock.ExpectQuery("SELECT.*FROM items").
 					WithArgs("testuser").
@@ -139,7 +149,7 @@ func TestItemDB_GetAllUserItems(t *testing.T) {
 			mockFn: func() {
 				rows := pgxmock.NewRows([]string{
 					"id", "name", "type", "encrypted_data_content",
-					"encrypted_data_nonce", "meta", "created_at", "updated_at",
+					"encrypted_data_nonce", "encrypted_header_content", "encrypted_header_nonce", "encrypted_key", "meta", "format", "search_tokens", "expires_at", "created_at", "updated_at", "favorite", "last_used_at", "use_count",
 				})
 				mock.ExpectQuery("SELECT.*FROM items").
 					WithArgs("emptyuser").
@@ -213,16 +223,25 @@ func TestItemDB_GetUserItemsWithType(t *testing.T) {
 				}
 				rows := pgxmock.NewRows([]string{
 					"id", "name", "type", "encrypted_data_content",
-					"encrypted_data_nonce", "meta", "created_at", "updated_at",
+					"encrypted_data_nonce", "encrypted_header_content", "encrypted_header_nonce", "encrypted_key", "meta", "format", "search_tokens", "expires_at", "created_at", "updated_at", "favorite", "last_used_at", "use_count",
 				}).AddRow(
 					testUUID,
 					"login item",
 					itemTypeModelsToPg(models.ItemTypeCREDENTIALS),
 					"encrypted_content",
 					"test_nonce",
+					"",
+					"",
+					"",
 					[]byte(`{"Map":null}`),
+					int16(1),
+					[]string{},
+					pgtype.Timestamptz{},
 					pgtype.Timestamp{Time: time.Now(), Valid: true},
 					pgtype.Timestamp{Time: time.Now(), Valid: true},
+					false,
+					pgtype.Timestamp{},
+					int32(0),
 				)
 				mock.ExpectQuery("SELECT.*FROM items.*WHERE.*type").
 					WithArgs("testuser", itemTypeModelsToPg(models.ItemTypeCREDENTIALS)).
@@ -238,7 +257,7 @@ func TestItemDB_GetUserItemsWithType(t *testing.T) {
 			mockFn: func() {
 				rows := pgxmock.NewRows([]string{
 					"id", "name", "type", "encrypted_data_content",
-					"encrypted_data_nonce", "meta", "created_at", "updated_at",
+					"encrypted_data_nonce", "encrypted_header_content", "encrypted_header_nonce", "encrypted_key", "meta", "format", "search_tokens", "expires_at", "created_at", "updated_at", "favorite", "last_used_at", "use_count",
 				})
 				mock.ExpectQuery("SELECT.*FROM items.*WHERE.*type").
 					WithArgs("testuser", itemTypeModelsToPg(models.ItemTypeBINARY)).
@@ -377,6 +396,12 @@ func TestItemDB_EditItem(t *testing.T) {
 						"new_encrypted_content",
 						"new_nonce",
 						[]byte(`{"Map":null}`),
+						"",
+						int16(1),
+						[]string{},
+						pgtype.Timestamptz{},
+						"",
+						"",
 					).
 					WillReturnResult(pgxmock.NewResult("UPDATE", 1))
 			},
@@ -403,6 +428,12 @@ func TestItemDB_EditItem(t *testing.T) {
 						"encrypted_content",
 						"test_nonce",
 						[]byte(`{"Map":null}`),
+						"",
+						int16(1),
+						[]string{},
+						pgtype.Timestamptz{},
+						"",
+						"",
 					).
 					WillReturnResult(pgxmock.NewResult("UPDATE", 0))
 			},
@@ -488,3 +519,149 @@ func TestItemDB_DeleteItem(t *testing.T) {
 		})
 	}
 }
+
+func TestItemDB_SearchUserItems(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	itemDB, err := NewItemDB(gen.New(mock), mock)
+	require.NoError(t, err)
+
+	itemUUID := pgtype.UUID{Bytes: [16]byte{0x01}, Valid: true}
+	tokens := []string{"t1", "t2"}
+	mock.ExpectQuery("i.search_tokens @> \\$2::text\\[\\]").
+		WithArgs("alice", tokens).
+		WillReturnRows(pgxmock.NewRows([]string{
+			"id", "name", "type", "encrypted_data_content", "encrypted_data_nonce", "encrypted_header_content", "encrypted_header_nonce",
+			"encrypted_key", "meta", "format", "search_tokens", "expires_at", "created_at", "updated_at", "favorite", "last_used_at", "use_count",
+		}).AddRow(itemUUID, "", gen.ItemTypeTEXT, "content", "nonce", "", "", "key",
+			[]byte(`{"Map":null}`), int16(2), []string{"t1", "t2", "t3"}, pgtype.Timestamptz{}, pgtype.Timestamp{Time: time.Now(), Valid: true}, pgtype.Timestamp{Time: time.Now(), Valid: true},
+			true, pgtype.Timestamp{Time: time.Now(), Valid: true}, int32(7)))
+
+	items, err := itemDB.SearchUserItems(context.Background(), "alice", tokens)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, "alice", items[0].UserLogin)
+	assert.Equal(t, models.ItemFormatV2, items[0].Format)
+	assert.Equal(t, []string{"t1", "t2", "t3"}, items[0].SearchTokens)
+	assert.True(t, items[0].Favorite)
+	assert.Equal(t, int32(7), items[0].UseCount)
+	assert.False(t, items[0].LastUsedAt.IsZero())
+
+	mock.ExpectQuery("i.search_tokens").WithArgs("alice", tokens).WillReturnError(fmt.Errorf("db error"))
+	_, err = itemDB.SearchUserItems(context.Background(), "alice", tokens)
+	assert.Error(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestItemDB_CollectionItems(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	itemDB, err := NewItemDB(gen.New(mock), mock)
+	require.NoError(t, err)
+
+	collectionID := [16]byte{0x0c, 0x01}
+	itemID := [16]byte{0x01}
+	collUUID := pgtype.UUID{Bytes: collectionID, Valid: true}
+	itemUUID := pgtype.UUID{Bytes: itemID, Valid: true}
+
+	mock.ExpectQuery("WHERE i.collection_id = \\$1 AND i.type = \\$2").
+		WithArgs(collUUID, gen.ItemTypeTEXT).
+		WillReturnRows(pgxmock.NewRows([]string{
+			"id", "user_login", "name", "type", "encrypted_data_content", "encrypted_data_nonce", "encrypted_header_content", "encrypted_header_nonce",
+			"encrypted_key", "meta", "format", "created_at", "updated_at",
+		}).AddRow(itemUUID, "bob", "runbook", gen.ItemTypeTEXT, "content", "nonce", "", "", "key",
+			[]byte(`{"Map":null}`), int16(1), pgtype.Timestamp{Time: time.Now(), Valid: true}, pgtype.Timestamp{Time: time.Now(), Valid: true}))
+
+	items, err := itemDB.GetCollectionItems(context.Background(), models.ItemTypeTEXT, collectionID)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, "bob", items[0].UserLogin)
+	assert.Equal(t, collectionID, items[0].CollectionID)
+
+	mock.ExpectQuery("SELECT collection_id").WithArgs(itemUUID).
+		WillReturnRows(pgxmock.NewRows([]string{"collection_id"}).AddRow(collUUID))
+	got, err := itemDB.GetItemCollection(context.Background(), itemID)
+	require.NoError(t, err)
+	assert.Equal(t, collectionID, got)
+
+	mock.ExpectExec("DELETE FROM items").WithArgs(itemUUID, collUUID).
+		WillReturnResult(pgxmock.NewResult("DELETE", 0))
+	assert.ErrorIs(t, itemDB.DeleteCollectionItem(context.Background(), collectionID, itemID), errs.ErrItemNotFound)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestItemDB_DeleteExpiredItems(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	itemDB, err := NewItemDB(gen.New(mock), mock)
+	require.NoError(t, err)
+
+	first := pgtype.UUID{Bytes: [16]byte{0x01}, Valid: true}
+	second := pgtype.UUID{Bytes: [16]byte{0x02}, Valid: true}
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery("DELETE FROM items").
+			WillReturnRows(pgxmock.NewRows([]string{"id", "user_login"}).
+				AddRow(first, "alice").
+				AddRow(second, "bob"))
+		mock.ExpectExec("INSERT INTO audit_events").WithArgs("alice", auditItemExpired, first).
+			WillReturnResult(pgxmock.NewResult("INSERT", 1))
+		mock.ExpectExec("INSERT INTO audit_events").WithArgs("bob", auditItemExpired, second).
+			WillReturnResult(pgxmock.NewResult("INSERT", 1))
+		mock.ExpectCommit()
+
+		n, err := itemDB.DeleteExpiredItems(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), n)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("rollback on audit error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery("DELETE FROM items").
+			WillReturnRows(pgxmock.NewRows([]string{"id", "user_login"}).AddRow(first, "alice"))
+		mock.ExpectExec("INSERT INTO audit_events").WithArgs("alice", auditItemExpired, first).WillReturnError(fmt.Errorf("db error"))
+		mock.ExpectRollback()
+
+		_, err := itemDB.DeleteExpiredItems(context.Background())
+		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestItemDB_ItemUsage(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	itemDB, err := NewItemDB(gen.New(mock), mock)
+	require.NoError(t, err)
+
+	id := pgtype.UUID{Bytes: [16]byte{0x01}, Valid: true}
+	mock.ExpectExec("UPDATE items\\s+SET last_used_at = NOW\\(\\), use_count = use_count \\+ 1").
+		WithArgs(id, "alice").
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	mock.ExpectExec("UPDATE items\\s+SET favorite").
+		WithArgs(id, "alice", true).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	mock.ExpectExec("UPDATE items").
+		WithArgs(id, "bob").
+		WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+	mock.ExpectExec("UPDATE items").
+		WithArgs(id, "bob", false).
+		WillReturnError(fmt.Errorf("db error"))
+
+	require.NoError(t, itemDB.TouchItem(context.Background(), "alice", id.Bytes))
+	require.NoError(t, itemDB.SetItemFavorite(context.Background(), "alice", id.Bytes, true))
+	assert.ErrorIs(t, itemDB.TouchItem(context.Background(), "bob", id.Bytes), errs.ErrItemNotFound)
+	assert.Error(t, itemDB.SetItemFavorite(context.Background(), "bob", id.Bytes, false))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
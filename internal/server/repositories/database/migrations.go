@@ -0,0 +1,147 @@
+package database
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"gophkeeper/internal/logger"
+	"gophkeeper/models"
+	"io/fs"
+	"sort"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+//go:embed schema/*.sql
+var schemaFS embed.FS
+
+const createMigrationsTable = `CREATE TABLE IF NOT EXISTS schema_migrations (
+    version VARCHAR(100) NOT NULL PRIMARY KEY,
+    applied_at TIMESTAMP NOT NULL DEFAULT NOW()
+)`
+
+// Scripts that are not idempotent are skipped when the objects they create
+// already exist, e.g. when the schema was loaded by the k8s init scripts.
+var bootstrapChecks = map[string]string{
+	"001_types.sql": "SELECT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'item_type')",
+	// Converting from UTC again would shift the expiry times.
+	"019_timestamptz.sql": "SELECT EXISTS (SELECT 1 FROM information_schema.columns WHERE table_name = 'items' AND column_name = 'expires_at' AND data_type = 'timestamp with time zone')",
+}
+
+func schemaVersions() ([]string, error) {
+	entries, err := fs.ReadDir(schemaFS, "schema")
+	if err != nil {
+		return nil, fmt.Errorf("read schema dir error: %w", err)
+	}
+
+	versions := make([]string, 0, len(entries))
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".sql") {
+			continue
+		}
+		versions = append(versions, e.Name())
+	}
+	sort.Strings(versions)
+	return versions, nil
+}
+
+func appliedMigrations(ctx context.Context, pool PoolInterface) (map[string]time.Time, error) {
+	if _, err := pool.Exec(ctx, createMigrationsTable); err != nil {
+		return nil, fmt.Errorf("create migrations table error: %w", err)
+	}
+
+	rows, err := pool.Query(ctx, "SELECT version, applied_at FROM schema_migrations")
+	if err != nil {
+		return nil, fmt.Errorf("get applied migrations error: %w", err)
+	}
+	defer rows.Close()
+
+	applied := make(map[string]time.Time)
+	for rows.Next() {
+		var version string
+		var appliedAt time.Time
+		if err := rows.Scan(&version, &appliedAt); err != nil {
+			return nil, fmt.Errorf("scan applied migration error: %w", err)
+		}
+		applied[version] = appliedAt
+	}
+	return applied, rows.Err()
+}
+
+func listMigrations(ctx context.Context, pool PoolInterface) ([]models.Migration, error) {
+	versions, err := schemaVersions()
+	if err != nil {
+		return nil, err
+	}
+
+	applied, err := appliedMigrations(ctx, pool)
+	if err != nil {
+		return nil, err
+	}
+
+	migrations := make([]models.Migration, len(versions))
+	for i, v := range versions {
+		appliedAt, ok := applied[v]
+		migrations[i] = models.Migration{
+			Version:   v,
+			Applied:   ok,
+			AppliedAt: appliedAt,
+		}
+	}
+	return migrations, nil
+}
+
+// migrate applies every schema script that is not recorded in schema_migrations yet
+// and returns the versions it applied.
+func migrate(ctx context.Context, pool PoolInterface) ([]string, error) {
+	migrations, err := listMigrations(ctx, pool)
+	if err != nil {
+		return nil, err
+	}
+
+	var done []string
+	for _, m := range migrations {
+		if m.Applied {
+			continue
+		}
+		if err := applyMigration(ctx, pool, m.Version); err != nil {
+			return done, fmt.Errorf("apply migration %s error: %w", m.Version, err)
+		}
+		logger.Log.Info("Migration applied", zap.String("version", m.Version))
+		done = append(done, m.Version)
+	}
+	return done, nil
+}
+
+func applyMigration(ctx context.Context, pool PoolInterface, version string) error {
+	skip := false
+	if check, ok := bootstrapChecks[version]; ok {
+		if err := pool.QueryRow(ctx, check).Scan(&skip); err != nil {
+			return fmt.Errorf("bootstrap check error: %w", err)
+		}
+	}
+
+	script, err := schemaFS.ReadFile("schema/" + version)
+	if err != nil {
+		return fmt.Errorf("read migration error: %w", err)
+	}
+
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin tx error: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if !skip {
+		if _, err := tx.Exec(ctx, string(script)); err != nil {
+			return err
+		}
+	}
+	if _, err := tx.Exec(ctx, "INSERT INTO schema_migrations (version) VALUES ($1)", version); err != nil {
+		return fmt.Errorf("record migration error: %w", err)
+	}
+
+	return tx.Commit(ctx)
+}
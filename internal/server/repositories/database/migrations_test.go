@@ -0,0 +1,136 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemaVersions(t *testing.T) {
+	versions, err := schemaVersions()
+	require.NoError(t, err)
+	require.NotEmpty(t, versions)
+
+	assert.Equal(t, "001_types.sql", versions[0])
+	assert.Equal(t, "002_tables.sql", versions[1])
+	assert.IsIncreasing(t, versions)
+}
+
+func expectAppliedMigrations(mock pgxmock.PgxPoolIface, versions ...string) {
+	mock.ExpectExec("CREATE TABLE IF NOT EXISTS schema_migrations").
+		WillReturnResult(pgxmock.NewResult("CREATE", 0))
+	rows := pgxmock.NewRows([]string{"version", "applied_at"})
+	for _, v := range versions {
+		rows.AddRow(v, time.Now())
+	}
+	mock.ExpectQuery("SELECT version, applied_at FROM schema_migrations").WillReturnRows(rows)
+}
+
+// expectBootstrapCheck expects the check of version, if it has one, to find
+// nothing.
+func expectBootstrapCheck(mock pgxmock.PgxPoolIface, version string) {
+	if _, ok := bootstrapChecks[version]; ok {
+		mock.ExpectQuery("SELECT EXISTS").WillReturnRows(pgxmock.NewRows([]string{"exists"}).AddRow(false))
+	}
+}
+
+func TestListMigrations(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	expectAppliedMigrations(mock, "001_types.sql")
+
+	migrations, err := listMigrations(context.Background(), mock)
+	require.NoError(t, err)
+
+	versions, err := schemaVersions()
+	require.NoError(t, err)
+	require.Len(t, migrations, len(versions))
+
+	assert.True(t, migrations[0].Applied)
+	assert.False(t, migrations[0].AppliedAt.IsZero())
+	for _, m := range migrations[1:] {
+		assert.False(t, m.Applied)
+	}
+}
+
+func TestMigrate(t *testing.T) {
+	versions, err := schemaVersions()
+	require.NoError(t, err)
+
+	t.Run("applies pending migrations", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		expectAppliedMigrations(mock, versions[:len(versions)-1]...)
+		last := versions[len(versions)-1]
+		expectBootstrapCheck(mock, last)
+		mock.ExpectBegin()
+		mock.ExpectExec(".+").WillReturnResult(pgxmock.NewResult("ALTER", 0))
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO schema_migrations")).
+			WithArgs(last).
+			WillReturnResult(pgxmock.NewResult("INSERT", 1))
+		mock.ExpectCommit()
+
+		applied, err := migrate(context.Background(), mock)
+		require.NoError(t, err)
+		assert.Equal(t, []string{last}, applied)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("nothing to apply", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		expectAppliedMigrations(mock, versions...)
+
+		applied, err := migrate(context.Background(), mock)
+		require.NoError(t, err)
+		assert.Empty(t, applied)
+	})
+
+	t.Run("bootstrap check skips existing types", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		expectAppliedMigrations(mock, versions[1:]...)
+		mock.ExpectQuery("SELECT EXISTS").WillReturnRows(pgxmock.NewRows([]string{"exists"}).AddRow(true))
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO schema_migrations")).
+			WithArgs("001_types.sql").
+			WillReturnResult(pgxmock.NewResult("INSERT", 1))
+		mock.ExpectCommit()
+
+		applied, err := migrate(context.Background(), mock)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"001_types.sql"}, applied)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("failed migration is rolled back", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		expectAppliedMigrations(mock, versions[:len(versions)-1]...)
+		expectBootstrapCheck(mock, versions[len(versions)-1])
+		mock.ExpectBegin()
+		mock.ExpectExec(".+").WillReturnError(fmt.Errorf("syntax error"))
+		mock.ExpectRollback()
+
+		applied, err := migrate(context.Background(), mock)
+		assert.Error(t, err)
+		assert.Empty(t, applied)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,492 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"gophkeeper/internal/errs"
+	"gophkeeper/models"
+
+	gen "gophkeeper/internal/server/repositories/database/generated"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type OrgDatabase interface {
+	CreateOrganization(ctx context.Context, org *models.Organization, coll *models.Collection) error
+	ListUserOrganizations(ctx context.Context, login string) ([]models.Organization, error)
+	GetMembership(ctx context.Context, orgID [16]byte, login string) (*models.Membership, error)
+	AddMembership(ctx context.Context, m *models.Membership) error
+	AcceptMembership(ctx context.Context, orgID [16]byte, login string) error
+	UpdateMembershipRole(ctx context.Context, orgID [16]byte, login string, role models.OrgRole) error
+	ListMemberships(ctx context.Context, orgID [16]byte) ([]models.Membership, error)
+	LeaveOrganization(ctx context.Context, orgID [16]byte, login string) error
+	RotateOrgKey(ctx context.Context, rot *models.OrgKeyRotation) error
+	CreateCollection(ctx context.Context, coll *models.Collection) error
+	ListCollections(ctx context.Context, orgID [16]byte) ([]models.Collection, error)
+	GetCollectionRole(ctx context.Context, collectionID [16]byte, login string) (models.OrgRole, error)
+}
+
+type OrgDB struct {
+	q    *gen.Queries
+	pool PoolInterface
+}
+
+var _ OrgDatabase = (*OrgDB)(nil)
+
+func NewOrgDB(q *gen.Queries, pool PoolInterface) (OrgDatabase, error) {
+	if pool == nil || q == nil {
+		return nil, errors.New("create org database error: pool or quaries is nil")
+	}
+	return &OrgDB{
+		q:    q,
+		pool: pool,
+	}, nil
+}
+
+func pgUUID(id [16]byte) pgtype.UUID {
+	return pgtype.UUID{Bytes: id, Valid: true}
+}
+
+func membershipStatus(accepted bool) gen.MembershipStatus {
+	if accepted {
+		return gen.MembershipStatusACTIVE
+	}
+	return gen.MembershipStatusINVITED
+}
+
+// CreateOrganization stores the organization with its creator as the owner
+// and, when coll is not nil, its first collection.
+func (db *OrgDB) CreateOrganization(ctx context.Context, org *models.Organization, coll *models.Collection) error {
+	tx, err := db.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin tx error: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	q := db.q.WithTx(tx)
+	row, err := q.CreateOrganization(ctx, gen.CreateOrganizationParams{
+		Name:      org.Name,
+		CreatedBy: org.CreatedBy,
+	})
+	if err != nil {
+		return fmt.Errorf("create organization error: %w", err)
+	}
+
+	if _, err := q.AddMembership(ctx, gen.AddMembershipParams{
+		OrgID:      row.ID,
+		Login:      org.CreatedBy,
+		Role:       gen.OrgRoleOWNER,
+		Status:     gen.MembershipStatusACTIVE,
+		WrappedKey: org.WrappedKey,
+		InvitedBy:  org.CreatedBy,
+	}); err != nil {
+		return fmt.Errorf("add owner membership error: %w", err)
+	}
+
+	if coll != nil {
+		collRow, err := q.CreateCollection(ctx, gen.CreateCollectionParams{
+			OrgID:        row.ID,
+			Name:         coll.Name,
+			EncryptedKey: coll.EncryptedKey,
+		})
+		if err != nil {
+			return fmt.Errorf("create collection error: %w", err)
+		}
+		coll.ID = collRow.ID.Bytes
+		coll.OrgID = row.ID.Bytes
+		coll.CreatedAt = collRow.CreatedAt.Time
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit tx error: %w", err)
+	}
+
+	org.ID = row.ID.Bytes
+	org.CreatedAt = row.CreatedAt.Time
+	org.Role = models.OrgRoleOWNER
+	org.Accepted = true
+	return nil
+}
+
+func (db *OrgDB) ListUserOrganizations(ctx context.Context, login string) ([]models.Organization, error) {
+	rows, err := db.q.ListUserOrganizations(ctx, login)
+	if err != nil {
+		return nil, fmt.Errorf("list user organizations error: %w", err)
+	}
+
+	orgs := make([]models.Organization, len(rows))
+	for i, r := range rows {
+		orgs[i] = models.Organization{
+			ID:             r.ID.Bytes,
+			Name:           r.Name,
+			CreatedBy:      r.CreatedBy,
+			Role:           models.OrgRole(r.Role),
+			Accepted:       r.Status == gen.MembershipStatusACTIVE,
+			WrappedKey:     r.WrappedKey,
+			CreatedAt:      r.CreatedAt.Time,
+			KeyRotationDue: r.KeyRotationDue,
+		}
+	}
+	return orgs, nil
+}
+
+func (db *OrgDB) GetMembership(ctx context.Context, orgID [16]byte, login string) (*models.Membership, error) {
+	m, err := db.q.GetMembership(ctx, gen.GetMembershipParams{
+		OrgID: pgUUID(orgID),
+		Login: login,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, errs.ErrNotOrgMember
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get membership error: %w", err)
+	}
+	return membershipPgToModels(m), nil
+}
+
+func (db *OrgDB) AddMembership(ctx context.Context, m *models.Membership) error {
+	n, err := db.q.AddMembership(ctx, gen.AddMembershipParams{
+		OrgID:      pgUUID(m.OrgID),
+		Login:      m.Login,
+		Role:       gen.OrgRole(m.Role),
+		Status:     membershipStatus(m.Accepted),
+		WrappedKey: m.WrappedKey,
+		InvitedBy:  m.InvitedBy,
+	})
+	if err != nil {
+		return fmt.Errorf("add membership error: %w", err)
+	}
+	if n == 0 {
+		return errs.ErrMemberAlreadyExists
+	}
+	return nil
+}
+
+func (db *OrgDB) AcceptMembership(ctx context.Context, orgID [16]byte, login string) error {
+	n, err := db.q.AcceptMembership(ctx, gen.AcceptMembershipParams{
+		OrgID: pgUUID(orgID),
+		Login: login,
+	})
+	if err != nil {
+		return fmt.Errorf("accept membership error: %w", err)
+	}
+	if n == 0 {
+		return errs.ErrInviteNotFound
+	}
+	return nil
+}
+
+func (db *OrgDB) UpdateMembershipRole(ctx context.Context, orgID [16]byte, login string, role models.OrgRole) error {
+	n, err := db.q.UpdateMembershipRole(ctx, gen.UpdateMembershipRoleParams{
+		OrgID: pgUUID(orgID),
+		Login: login,
+		Role:  gen.OrgRole(role),
+	})
+	if err != nil {
+		return fmt.Errorf("update membership role error: %w", err)
+	}
+	if n == 0 {
+		return errs.ErrNotOrgMember
+	}
+	return nil
+}
+
+func (db *OrgDB) ListMemberships(ctx context.Context, orgID [16]byte) ([]models.Membership, error) {
+	rows, err := db.q.ListMemberships(ctx, pgUUID(orgID))
+	if err != nil {
+		return nil, fmt.Errorf("list memberships error: %w", err)
+	}
+
+	members := make([]models.Membership, len(rows))
+	for i, r := range rows {
+		members[i] = *membershipPgToModels(r)
+	}
+	return members, nil
+}
+
+func membershipPgToModels(m gen.Membership) *models.Membership {
+	return &models.Membership{
+		OrgID:      m.OrgID.Bytes,
+		Login:      m.Login,
+		Role:       models.OrgRole(m.Role),
+		Accepted:   m.Status == gen.MembershipStatusACTIVE,
+		WrappedKey: m.WrappedKey,
+		InvitedBy:  m.InvitedBy,
+		CreatedAt:  m.CreatedAt.Time,
+	}
+}
+
+// LeaveOrganization deletes the membership of a member who leaves on their
+// own and marks the organization key for rotation, the member still knows it.
+func (db *OrgDB) LeaveOrganization(ctx context.Context, orgID [16]byte, login string) error {
+	tx, err := db.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin tx error: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	q := db.q.WithTx(tx)
+	n, err := q.DeleteMembership(ctx, gen.DeleteMembershipParams{
+		OrgID: pgUUID(orgID),
+		Login: login,
+	})
+	if err != nil {
+		return fmt.Errorf("delete membership error: %w", err)
+	}
+	if n == 0 {
+		return errs.ErrNotOrgMember
+	}
+	if err := q.SetOrgKeyRotationDue(ctx, gen.SetOrgKeyRotationDueParams{
+		ID:             pgUUID(orgID),
+		KeyRotationDue: true,
+	}); err != nil {
+		return fmt.Errorf("mark key rotation error: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit tx error: %w", err)
+	}
+	return nil
+}
+
+// RotateOrgKey removes rot.Login, when set, and stores the new organization
+// key, collection keys, re-encrypted items and re-sealed attachment infos in
+// one transaction. Every key has to be replaced, a rotation that misses a
+// member, collection, item or attachment is rejected.
+func (db *OrgDB) RotateOrgKey(ctx context.Context, rot *models.OrgKeyRotation) error {
+	orgID := pgUUID(rot.OrgID)
+
+	tx, err := db.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin tx error: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	q := db.q.WithTx(tx)
+	if rot.Login != "" {
+		n, err := q.DeleteMembership(ctx, gen.DeleteMembershipParams{
+			OrgID: orgID,
+			Login: rot.Login,
+		})
+		if err != nil {
+			return fmt.Errorf("delete membership error: %w", err)
+		}
+		if n == 0 {
+			return errs.ErrNotOrgMember
+		}
+	}
+
+	remaining, err := q.ListMemberships(ctx, orgID)
+	if err != nil {
+		return fmt.Errorf("list memberships error: %w", err)
+	}
+	if !sameMembers(remaining, rot.Members) {
+		return errs.ErrOrgKeysMismatch
+	}
+
+	collections, err := q.ListCollections(ctx, orgID)
+	if err != nil {
+		return fmt.Errorf("list collections error: %w", err)
+	}
+	if !sameCollections(collections, rot.Collections) {
+		return errs.ErrOrgKeysMismatch
+	}
+
+	items, err := q.ListOrgItems(ctx, orgID)
+	if err != nil {
+		return fmt.Errorf("list organization items error: %w", err)
+	}
+	if !sameOrgItems(items, rot.Items) {
+		return errs.ErrOrgKeysMismatch
+	}
+
+	attachments, err := q.ListOrgAttachments(ctx, orgID)
+	if err != nil {
+		return fmt.Errorf("list organization attachments error: %w", err)
+	}
+	if !sameOrgAttachments(attachments, rot.Attachments) {
+		return errs.ErrOrgKeysMismatch
+	}
+
+	for _, m := range rot.Members {
+		if _, err := q.UpdateMembershipKey(ctx, gen.UpdateMembershipKeyParams{
+			OrgID:      orgID,
+			Login:      m.Login,
+			WrappedKey: m.WrappedKey,
+		}); err != nil {
+			return fmt.Errorf("update membership key error: %w", err)
+		}
+	}
+	for _, c := range rot.Collections {
+		if _, err := q.UpdateCollectionKey(ctx, gen.UpdateCollectionKeyParams{
+			ID:           pgUUID(c.ID),
+			OrgID:        orgID,
+			EncryptedKey: c.EncryptedKey,
+		}); err != nil {
+			return fmt.Errorf("update collection key error: %w", err)
+		}
+	}
+	for _, item := range rot.Items {
+		meta, err := json.Marshal(item.Meta)
+		if err != nil {
+			return fmt.Errorf("marshal meta info error: %w", err)
+		}
+		if _, err := q.RekeyCollectionItem(ctx, gen.RekeyCollectionItemParams{
+			ID:                     pgUUID(item.ID),
+			CollectionID:           pgUUID(item.CollectionID),
+			EncryptedDataContent:   item.EncryptedData.EncryptedContent,
+			EncryptedDataNonce:     item.EncryptedData.Nonce,
+			EncryptedHeaderContent: item.EncryptedHeader.EncryptedContent,
+			EncryptedHeaderNonce:   item.EncryptedHeader.Nonce,
+			EncryptedKey:           item.EncryptedKey,
+			Format:                 itemFormatModelsToPg(item.Format),
+			Name:                   item.Name,
+			Meta:                   meta,
+		}); err != nil {
+			return fmt.Errorf("rekey collection item error: %w", err)
+		}
+	}
+	for _, a := range rot.Attachments {
+		if _, err := q.UpdateAttachmentInfo(ctx, gen.UpdateAttachmentInfoParams{
+			ID:                   pgUUID(a.ID),
+			ItemID:               pgUUID(a.ItemID),
+			EncryptedInfoContent: a.EncryptedInfo.EncryptedContent,
+			EncryptedInfoNonce:   a.EncryptedInfo.Nonce,
+		}); err != nil {
+			return fmt.Errorf("update attachment info error: %w", err)
+		}
+	}
+
+	if err := q.SetOrgKeyRotationDue(ctx, gen.SetOrgKeyRotationDueParams{ID: orgID}); err != nil {
+		return fmt.Errorf("clear key rotation error: %w", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit tx error: %w", err)
+	}
+	return nil
+}
+
+func sameMembers(stored []gen.Membership, rewrapped []models.Membership) bool {
+	if len(stored) != len(rewrapped) {
+		return false
+	}
+	logins := make(map[string]bool, len(stored))
+	for _, m := range stored {
+		logins[m.Login] = true
+	}
+	for _, m := range rewrapped {
+		if !logins[m.Login] {
+			return false
+		}
+		delete(logins, m.Login)
+	}
+	return true
+}
+
+func sameCollections(stored []gen.Collection, resealed []models.Collection) bool {
+	if len(stored) != len(resealed) {
+		return false
+	}
+	ids := make(map[[16]byte]bool, len(stored))
+	for _, c := range stored {
+		ids[c.ID.Bytes] = true
+	}
+	for _, c := range resealed {
+		if !ids[c.ID] {
+			return false
+		}
+		delete(ids, c.ID)
+	}
+	return true
+}
+
+func sameOrgItems(stored []gen.ListOrgItemsRow, rekeyed []models.EncryptedItem) bool {
+	if len(stored) != len(rekeyed) {
+		return false
+	}
+	collections := make(map[[16]byte][16]byte, len(stored))
+	for _, i := range stored {
+		collections[i.ID.Bytes] = i.CollectionID.Bytes
+	}
+	for _, i := range rekeyed {
+		coll, ok := collections[i.ID]
+		if !ok || coll != i.CollectionID {
+			return false
+		}
+		delete(collections, i.ID)
+	}
+	return true
+}
+
+func sameOrgAttachments(stored []gen.ListOrgAttachmentsRow, resealed []models.Attachment) bool {
+	if len(stored) != len(resealed) {
+		return false
+	}
+	items := make(map[[16]byte][16]byte, len(stored))
+	for _, a := range stored {
+		items[a.ID.Bytes] = a.ItemID.Bytes
+	}
+	for _, a := range resealed {
+		item, ok := items[a.ID]
+		if !ok || item != a.ItemID {
+			return false
+		}
+		delete(items, a.ID)
+	}
+	return true
+}
+
+func (db *OrgDB) CreateCollection(ctx context.Context, coll *models.Collection) error {
+	row, err := db.q.CreateCollection(ctx, gen.CreateCollectionParams{
+		OrgID:        pgUUID(coll.OrgID),
+		Name:         coll.Name,
+		EncryptedKey: coll.EncryptedKey,
+	})
+	if err != nil {
+		return fmt.Errorf("create collection error: %w", err)
+	}
+	coll.ID = row.ID.Bytes
+	coll.CreatedAt = row.CreatedAt.Time
+	return nil
+}
+
+func (db *OrgDB) ListCollections(ctx context.Context, orgID [16]byte) ([]models.Collection, error) {
+	rows, err := db.q.ListCollections(ctx, pgUUID(orgID))
+	if err != nil {
+		return nil, fmt.Errorf("list collections error: %w", err)
+	}
+
+	colls := make([]models.Collection, len(rows))
+	for i, r := range rows {
+		colls[i] = models.Collection{
+			ID:           r.ID.Bytes,
+			OrgID:        r.OrgID.Bytes,
+			Name:         r.Name,
+			EncryptedKey: r.EncryptedKey,
+			CreatedAt:    r.CreatedAt.Time,
+		}
+	}
+	return colls, nil
+}
+
+// GetCollectionRole returns the role of an active member in the organization
+// owning the collection. Pending invites grant no access.
+func (db *OrgDB) GetCollectionRole(ctx context.Context, collectionID [16]byte, login string) (models.OrgRole, error) {
+	row, err := db.q.GetCollectionRole(ctx, gen.GetCollectionRoleParams{
+		ID:    pgUUID(collectionID),
+		Login: login,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", errs.ErrNotOrgMember
+	}
+	if err != nil {
+		return "", fmt.Errorf("get collection role error: %w", err)
+	}
+	if row.Status != gen.MembershipStatusACTIVE {
+		return "", errs.ErrNotOrgMember
+	}
+	return models.OrgRole(row.Role), nil
+}
@@ -0,0 +1,286 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"gophkeeper/internal/errs"
+	gen "gophkeeper/internal/server/repositories/database/generated"
+	"gophkeeper/models"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pashagolub/pgxmock/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	orgTestID        = [16]byte{0x0a, 0x01}
+	orgTestCollectID = [16]byte{0x0c, 0x01}
+	orgTestItemID    = [16]byte{0x0d, 0x01}
+	orgTestAttachID  = [16]byte{0x0e, 0x01}
+)
+
+func newTestOrgDB(t *testing.T) (OrgDatabase, pgxmock.PgxPoolIface) {
+	t.Helper()
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	t.Cleanup(mock.Close)
+
+	orgDB, err := NewOrgDB(gen.New(mock), mock)
+	require.NoError(t, err)
+	return orgDB, mock
+}
+
+func TestNewOrgDB(t *testing.T) {
+	_, err := NewOrgDB(nil, nil)
+	assert.Error(t, err)
+}
+
+func TestOrgDB_CreateOrganization(t *testing.T) {
+	orgID := pgtype.UUID{Bytes: orgTestID, Valid: true}
+	now := pgtype.Timestamp{Time: time.Now(), Valid: true}
+
+	t.Run("success", func(t *testing.T) {
+		orgDB, mock := newTestOrgDB(t)
+		mock.ExpectBegin()
+		mock.ExpectQuery("INSERT INTO organizations").WithArgs("acme", "alice").
+			WillReturnRows(pgxmock.NewRows([]string{"id", "created_at"}).AddRow(orgID, now))
+		mock.ExpectExec("INSERT INTO memberships").
+			WithArgs(orgID, "alice", gen.OrgRoleOWNER, gen.MembershipStatusACTIVE, "wrapped", "alice").
+			WillReturnResult(pgxmock.NewResult("INSERT", 1))
+		mock.ExpectQuery("INSERT INTO collections").WithArgs(orgID, "Shared", "sealed").
+			WillReturnRows(pgxmock.NewRows([]string{"id", "created_at"}).
+				AddRow(pgtype.UUID{Bytes: orgTestCollectID, Valid: true}, now))
+		mock.ExpectCommit()
+
+		org := &models.Organization{Name: "acme", CreatedBy: "alice", WrappedKey: "wrapped"}
+		coll := &models.Collection{Name: "Shared", EncryptedKey: "sealed"}
+		require.NoError(t, orgDB.CreateOrganization(context.Background(), org, coll))
+		assert.Equal(t, orgTestID, org.ID)
+		assert.Equal(t, models.OrgRoleOWNER, org.Role)
+		assert.Equal(t, orgTestCollectID, coll.ID)
+		assert.Equal(t, orgTestID, coll.OrgID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("rollback on error", func(t *testing.T) {
+		orgDB, mock := newTestOrgDB(t)
+		mock.ExpectBegin()
+		mock.ExpectQuery("INSERT INTO organizations").WithArgs("acme", "alice").
+			WillReturnError(fmt.Errorf("db error"))
+		mock.ExpectRollback()
+
+		org := &models.Organization{Name: "acme", CreatedBy: "alice", WrappedKey: "wrapped"}
+		assert.Error(t, orgDB.CreateOrganization(context.Background(), org, nil))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestOrgDB_AddMembership(t *testing.T) {
+	orgDB, mock := newTestOrgDB(t)
+	mock.ExpectExec("INSERT INTO memberships").
+		WithArgs(pgtype.UUID{Bytes: orgTestID, Valid: true}, "bob", gen.OrgRoleEDITOR, gen.MembershipStatusINVITED, "bob_key", "alice").
+		WillReturnResult(pgxmock.NewResult("INSERT", 0))
+
+	err := orgDB.AddMembership(context.Background(), &models.Membership{
+		OrgID:      orgTestID,
+		Login:      "bob",
+		Role:       models.OrgRoleEDITOR,
+		WrappedKey: "bob_key",
+		InvitedBy:  "alice",
+	})
+	assert.ErrorIs(t, err, errs.ErrMemberAlreadyExists)
+}
+
+func TestOrgDB_AcceptMembership(t *testing.T) {
+	orgDB, mock := newTestOrgDB(t)
+	mock.ExpectExec("UPDATE memberships").WithArgs(pgtype.UUID{Bytes: orgTestID, Valid: true}, "bob").
+		WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+
+	assert.ErrorIs(t, orgDB.AcceptMembership(context.Background(), orgTestID, "bob"), errs.ErrInviteNotFound)
+}
+
+func TestOrgDB_GetCollectionRole(t *testing.T) {
+	collID := pgtype.UUID{Bytes: orgTestCollectID, Valid: true}
+	cols := []string{"role", "status"}
+
+	t.Run("active member", func(t *testing.T) {
+		orgDB, mock := newTestOrgDB(t)
+		mock.ExpectQuery("FROM collections c").WithArgs(collID, "bob").
+			WillReturnRows(pgxmock.NewRows(cols).AddRow(gen.OrgRoleEDITOR, gen.MembershipStatusACTIVE))
+
+		role, err := orgDB.GetCollectionRole(context.Background(), orgTestCollectID, "bob")
+		require.NoError(t, err)
+		assert.Equal(t, models.OrgRoleEDITOR, role)
+	})
+
+	t.Run("pending invite", func(t *testing.T) {
+		orgDB, mock := newTestOrgDB(t)
+		mock.ExpectQuery("FROM collections c").WithArgs(collID, "bob").
+			WillReturnRows(pgxmock.NewRows(cols).AddRow(gen.OrgRoleEDITOR, gen.MembershipStatusINVITED))
+
+		_, err := orgDB.GetCollectionRole(context.Background(), orgTestCollectID, "bob")
+		assert.ErrorIs(t, err, errs.ErrNotOrgMember)
+	})
+
+	t.Run("not a member", func(t *testing.T) {
+		orgDB, mock := newTestOrgDB(t)
+		mock.ExpectQuery("FROM collections c").WithArgs(collID, "eve").WillReturnError(pgx.ErrNoRows)
+
+		_, err := orgDB.GetCollectionRole(context.Background(), orgTestCollectID, "eve")
+		assert.ErrorIs(t, err, errs.ErrNotOrgMember)
+	})
+}
+
+func TestOrgDB_RotateOrgKey(t *testing.T) {
+	orgID := pgtype.UUID{Bytes: orgTestID, Valid: true}
+	collID := pgtype.UUID{Bytes: orgTestCollectID, Valid: true}
+	memberCols := []string{"org_id", "login", "role", "status", "wrapped_key", "invited_by", "created_at"}
+	collCols := []string{"id", "org_id", "name", "encrypted_key", "created_at"}
+	itemID := pgtype.UUID{Bytes: orgTestItemID, Valid: true}
+	attachID := pgtype.UUID{Bytes: orgTestAttachID, Valid: true}
+	newRotation := func() *models.OrgKeyRotation {
+		return &models.OrgKeyRotation{
+			OrgID:       orgTestID,
+			Login:       "bob",
+			Members:     []models.Membership{{OrgID: orgTestID, Login: "alice", WrappedKey: "alice_new"}},
+			Collections: []models.Collection{{ID: orgTestCollectID, OrgID: orgTestID, EncryptedKey: "coll_new"}},
+			Items: []models.EncryptedItem{{
+				ID:            orgTestItemID,
+				CollectionID:  orgTestCollectID,
+				EncryptedKey:  "item_key_new",
+				EncryptedData: models.EncryptedData{EncryptedContent: "item_new", Nonce: "item_nonce"},
+				Format:        models.ItemFormatV2,
+			}},
+			Attachments: []models.Attachment{{
+				ID:            orgTestAttachID,
+				ItemID:        orgTestItemID,
+				EncryptedInfo: models.EncryptedData{EncryptedContent: "info_new", Nonce: "info_nonce"},
+			}},
+		}
+	}
+	expectKeys := func(mock pgxmock.PgxPoolIface) {
+		mock.ExpectQuery("FROM memberships").WithArgs(orgID).
+			WillReturnRows(pgxmock.NewRows(memberCols).
+				AddRow(orgID, "alice", gen.OrgRoleOWNER, gen.MembershipStatusACTIVE, "alice_old", "alice", pgtype.Timestamp{}))
+		mock.ExpectQuery("FROM collections").WithArgs(orgID).
+			WillReturnRows(pgxmock.NewRows(collCols).AddRow(collID, orgID, "Shared", "coll_old", pgtype.Timestamp{}))
+	}
+	expectRekey := func(mock pgxmock.PgxPoolIface) {
+		mock.ExpectQuery("FROM items i").WithArgs(orgID).
+			WillReturnRows(pgxmock.NewRows([]string{"id", "collection_id"}).AddRow(itemID, collID))
+		mock.ExpectQuery("FROM item_attachments a").WithArgs(orgID).
+			WillReturnRows(pgxmock.NewRows([]string{"id", "item_id"}).AddRow(attachID, itemID))
+		mock.ExpectExec("UPDATE memberships").WithArgs(orgID, "alice", "alice_new").
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+		mock.ExpectExec("UPDATE collections").WithArgs(collID, orgID, "coll_new").
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+		mock.ExpectExec("UPDATE items").WithArgs(itemID, collID, "item_new", "item_nonce", "item_key_new", int16(2), "", []byte(`{"Map":null}`), "", "").
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+		mock.ExpectExec("UPDATE item_attachments").WithArgs(attachID, itemID, "info_new", "info_nonce").
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+		mock.ExpectExec("UPDATE organizations").WithArgs(orgID, false).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+		mock.ExpectCommit()
+	}
+
+	t.Run("success", func(t *testing.T) {
+		orgDB, mock := newTestOrgDB(t)
+		mock.ExpectBegin()
+		mock.ExpectExec("DELETE FROM memberships").WithArgs(orgID, "bob").
+			WillReturnResult(pgxmock.NewResult("DELETE", 1))
+		expectKeys(mock)
+		expectRekey(mock)
+
+		require.NoError(t, orgDB.RotateOrgKey(context.Background(), newRotation()))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("rotation without removal", func(t *testing.T) {
+		orgDB, mock := newTestOrgDB(t)
+		mock.ExpectBegin()
+		expectKeys(mock)
+		expectRekey(mock)
+
+		rot := newRotation()
+		rot.Login = ""
+		require.NoError(t, orgDB.RotateOrgKey(context.Background(), rot))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("items not re-keyed", func(t *testing.T) {
+		orgDB, mock := newTestOrgDB(t)
+		mock.ExpectBegin()
+		mock.ExpectExec("DELETE FROM memberships").WithArgs(orgID, "bob").
+			WillReturnResult(pgxmock.NewResult("DELETE", 1))
+		expectKeys(mock)
+		mock.ExpectQuery("FROM items i").WithArgs(orgID).
+			WillReturnRows(pgxmock.NewRows([]string{"id", "collection_id"}).
+				AddRow(itemID, collID).
+				AddRow(pgtype.UUID{Bytes: [16]byte{0x0d, 0x02}, Valid: true}, collID))
+		mock.ExpectRollback()
+
+		assert.ErrorIs(t, orgDB.RotateOrgKey(context.Background(), newRotation()), errs.ErrOrgKeysMismatch)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("member not found", func(t *testing.T) {
+		orgDB, mock := newTestOrgDB(t)
+		mock.ExpectBegin()
+		mock.ExpectExec("DELETE FROM memberships").WithArgs(orgID, "bob").
+			WillReturnResult(pgxmock.NewResult("DELETE", 0))
+		mock.ExpectRollback()
+
+		assert.ErrorIs(t, orgDB.RotateOrgKey(context.Background(), newRotation()), errs.ErrNotOrgMember)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("collection keys do not cover all collections", func(t *testing.T) {
+		orgDB, mock := newTestOrgDB(t)
+		mock.ExpectBegin()
+		mock.ExpectExec("DELETE FROM memberships").WithArgs(orgID, "bob").
+			WillReturnResult(pgxmock.NewResult("DELETE", 1))
+		mock.ExpectQuery("FROM memberships").WithArgs(orgID).
+			WillReturnRows(pgxmock.NewRows(memberCols).
+				AddRow(orgID, "alice", gen.OrgRoleOWNER, gen.MembershipStatusACTIVE, "alice_old", "alice", pgtype.Timestamp{}))
+		mock.ExpectQuery("FROM collections").WithArgs(orgID).
+			WillReturnRows(pgxmock.NewRows(collCols).
+				AddRow(collID, orgID, "Shared", "coll_old", pgtype.Timestamp{}).
+				AddRow(pgtype.UUID{Bytes: [16]byte{0x0c, 0x02}, Valid: true}, orgID, "Ops", "ops_old", pgtype.Timestamp{}))
+		mock.ExpectRollback()
+
+		assert.ErrorIs(t, orgDB.RotateOrgKey(context.Background(), newRotation()), errs.ErrOrgKeysMismatch)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestOrgDB_LeaveOrganization(t *testing.T) {
+	orgID := pgtype.UUID{Bytes: orgTestID, Valid: true}
+
+	t.Run("success", func(t *testing.T) {
+		orgDB, mock := newTestOrgDB(t)
+		mock.ExpectBegin()
+		mock.ExpectExec("DELETE FROM memberships").WithArgs(orgID, "bob").
+			WillReturnResult(pgxmock.NewResult("DELETE", 1))
+		mock.ExpectExec("UPDATE organizations").WithArgs(orgID, true).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+		mock.ExpectCommit()
+
+		require.NoError(t, orgDB.LeaveOrganization(context.Background(), orgTestID, "bob"))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("not a member", func(t *testing.T) {
+		orgDB, mock := newTestOrgDB(t)
+		mock.ExpectBegin()
+		mock.ExpectExec("DELETE FROM memberships").WithArgs(orgID, "bob").
+			WillReturnResult(pgxmock.NewResult("DELETE", 0))
+		mock.ExpectRollback()
+
+		assert.ErrorIs(t, orgDB.LeaveOrganization(context.Background(), orgTestID, "bob"), errs.ErrNotOrgMember)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -3,7 +3,7 @@ INSERT INTO users (login, password, salt)
 VALUES ($1, $2, $3);
 
 -- name: GetUser :one
-SELECT login, password, salt
+SELECT login, password, salt, locked, sessions_revoked_at, deleted_at, created_at
 FROM users
 WHERE login = $1;
 
@@ -14,12 +14,21 @@ SELECT
     i.type,
     i.encrypted_data_content,
     i.encrypted_data_nonce,
+    i.encrypted_header_content,
+    i.encrypted_header_nonce,
+    i.encrypted_key,
     i.meta,
+    i.format,
+    i.search_tokens,
+    i.expires_at,
     i.created_at,
-    i.updated_at
+    i.updated_at,
+    i.favorite,
+    i.last_used_at,
+    i.use_count
 FROM items i
-WHERE i.user_login = $1
-ORDER BY i.created_at DESC;
+WHERE i.user_login = $1 AND i.collection_id IS NULL AND (i.expires_at IS NULL OR i.expires_at > NOW())
+ORDER BY i.favorite DESC, i.last_used_at DESC NULLS LAST, i.created_at DESC;
 
 -- name: GetUserItemsWithType :many
 SELECT 
@@ -28,31 +37,521 @@ SELECT
     i.type,
     i.encrypted_data_content,
     i.encrypted_data_nonce,
+    i.encrypted_header_content,
+    i.encrypted_header_nonce,
+    i.encrypted_key,
     i.meta,
+    i.format,
+    i.search_tokens,
+    i.expires_at,
     i.created_at,
-    i.updated_at
+    i.updated_at,
+    i.favorite,
+    i.last_used_at,
+    i.use_count
 FROM items i
-WHERE i.user_login = $1 AND i.type = $2
-ORDER BY created_at DESC;
+WHERE i.user_login = $1 AND i.type = $2 AND i.collection_id IS NULL AND (i.expires_at IS NULL OR i.expires_at > NOW())
+ORDER BY i.favorite DESC, i.last_used_at DESC NULLS LAST, i.created_at DESC;
+
+-- name: SearchUserItems :many
+SELECT 
+    i.id,
+    i.name,
+    i.type,
+    i.encrypted_data_content,
+    i.encrypted_data_nonce,
+    i.encrypted_header_content,
+    i.encrypted_header_nonce,
+    i.encrypted_key,
+    i.meta,
+    i.format,
+    i.search_tokens,
+    i.expires_at,
+    i.created_at,
+    i.updated_at,
+    i.favorite,
+    i.last_used_at,
+    i.use_count
+FROM items i
+WHERE i.user_login = $1 AND i.collection_id IS NULL AND i.search_tokens @> sqlc.arg(tokens)::text[] AND (i.expires_at IS NULL OR i.expires_at > NOW())
+ORDER BY i.favorite DESC, i.last_used_at DESC NULLS LAST, i.created_at DESC;
 
 -- name: GetTypesCounts :many
 SELECT 
     type, 
     COUNT(*) as count
 FROM items
-WHERE user_login = $1
+WHERE user_login = $1 AND collection_id IS NULL AND (expires_at IS NULL OR expires_at > NOW())
 GROUP BY type;
 
 -- name: AddItem :one
-INSERT INTO items (user_login, name, type, encrypted_data_content, encrypted_data_nonce, meta)
-VALUES ($1, $2, $3, $4, $5, $6)
+INSERT INTO items (user_login, name, type, encrypted_data_content, encrypted_data_nonce, meta, encrypted_key, collection_id, format, search_tokens, expires_at, encrypted_header_content, encrypted_header_nonce)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
 RETURNING id;
 
 -- name: EditItem :exec
 UPDATE items
-SET name = $2, encrypted_data_content = $3, encrypted_data_nonce = $4, meta = $5, updated_at =  NOW()
+SET name = $2, encrypted_data_content = $3, encrypted_data_nonce = $4, meta = $5, encrypted_key = $6, format = $7, search_tokens = $8, expires_at = $9, encrypted_header_content = $10, encrypted_header_nonce = $11, updated_at =  NOW()
 WHERE id = $1;
 
 -- name: DeleteItem :exec
 DELETE FROM items
-WHERE user_login = $1 AND id = $2;
\ No newline at end of file
+WHERE user_login = $1 AND id = $2 AND collection_id IS NULL;
+
+-- name: DeleteExpiredItems :many
+DELETE FROM items
+WHERE expires_at IS NOT NULL AND expires_at <= NOW()
+RETURNING id, user_login;
+
+-- name: AddAuditEvent :exec
+INSERT INTO audit_events (login, action, item_id)
+VALUES ($1, $2, $3);
+
+-- name: ListUsersStats :many
+SELECT
+    u.login,
+    u.locked,
+    u.deleted_at,
+    u.created_at,
+    COUNT(i.id) as item_count,
+    COALESCE(SUM(octet_length(i.name) + octet_length(i.encrypted_data_content) + octet_length(i.encrypted_header_content) + COALESCE(octet_length(i.meta::text), 0)), 0)::BIGINT as storage_bytes
+FROM users u
+LEFT JOIN items i ON i.user_login = u.login
+GROUP BY u.login
+ORDER BY u.login;
+
+-- name: SetUserLocked :execrows
+UPDATE users
+SET locked = $2
+WHERE login = $1;
+
+-- name: MarkUserDeleted :execrows
+UPDATE users
+SET deleted_at = NOW(), locked = TRUE, sessions_revoked_at = NOW()
+WHERE login = $1 AND deleted_at IS NULL;
+
+-- name: RevokeUserSessions :execrows
+UPDATE users
+SET sessions_revoked_at = NOW()
+WHERE login = $1;
+
+-- name: RevokeAllSessions :execrows
+UPDATE users
+SET sessions_revoked_at = NOW();
+
+-- name: DeleteItemsOfDeletedUsers :execrows
+DELETE FROM items
+WHERE user_login IN (SELECT login FROM users WHERE deleted_at IS NOT NULL);
+
+-- name: DeleteDeletedUsers :execrows
+DELETE FROM users
+WHERE deleted_at IS NOT NULL;
+
+-- name: GetServerStats :one
+SELECT
+    (SELECT COUNT(*) FROM users) as users_count,
+    (SELECT COUNT(*) FROM users WHERE locked) as locked_users_count,
+    (SELECT COUNT(*) FROM users WHERE deleted_at IS NOT NULL) as deleted_users_count,
+    (SELECT COUNT(*) FROM items) as items_count,
+    (SELECT COALESCE(SUM(octet_length(name) + octet_length(encrypted_data_content) + octet_length(encrypted_header_content) + COALESCE(octet_length(meta::text), 0)), 0) FROM items)::BIGINT as storage_bytes;
+
+-- name: AddUserKeys :execrows
+INSERT INTO user_keys (login, public_key, encrypted_private_key)
+VALUES ($1, $2, $3)
+ON CONFLICT (login) DO NOTHING;
+
+-- name: GetUserKeys :one
+SELECT login, public_key, encrypted_private_key, created_at
+FROM user_keys
+WHERE login = $1;
+
+-- name: GetItemOwner :one
+SELECT user_login
+FROM items
+WHERE id = $1;
+
+-- name: UpsertItemShare :exec
+INSERT INTO item_shares (item_id, recipient_login, wrapped_key)
+VALUES ($1, $2, $3)
+ON CONFLICT (item_id, recipient_login) DO UPDATE SET wrapped_key = EXCLUDED.wrapped_key;
+
+-- name: ListItemShares :many
+SELECT item_id, recipient_login, wrapped_key, created_at
+FROM item_shares
+WHERE item_id = $1
+ORDER BY recipient_login;
+
+-- name: ListSharedWithUser :many
+SELECT
+    i.id,
+    i.user_login,
+    i.name,
+    i.type,
+    i.encrypted_data_content,
+    i.encrypted_data_nonce,
+    i.encrypted_header_content,
+    i.encrypted_header_nonce,
+    i.meta,
+    i.format,
+    i.created_at,
+    i.updated_at,
+    s.wrapped_key
+FROM item_shares s
+JOIN items i ON i.id = s.item_id
+WHERE s.recipient_login = $1 AND (i.expires_at IS NULL OR i.expires_at > NOW())
+ORDER BY i.created_at DESC;
+
+-- name: DeleteItemShare :execrows
+DELETE FROM item_shares
+WHERE item_id = $1 AND recipient_login = $2;
+
+-- name: UpdateItemShareKey :execrows
+UPDATE item_shares
+SET wrapped_key = $3
+WHERE item_id = $1 AND recipient_login = $2;
+
+-- name: RekeyItem :execrows
+UPDATE items
+SET encrypted_data_content = $3, encrypted_data_nonce = $4, encrypted_key = $5, format = $6, name = $7, meta = $8, search_tokens = $9, encrypted_header_content = $10, encrypted_header_nonce = $11, updated_at = NOW()
+WHERE id = $1 AND user_login = $2;
+
+-- name: CreateOrganization :one
+INSERT INTO organizations (name, created_by)
+VALUES ($1, $2)
+RETURNING id, created_at;
+
+-- name: AddMembership :execrows
+INSERT INTO memberships (org_id, login, role, status, wrapped_key, invited_by)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (org_id, login) DO NOTHING;
+
+-- name: GetMembership :one
+SELECT org_id, login, role, status, wrapped_key, invited_by, created_at
+FROM memberships
+WHERE org_id = $1 AND login = $2;
+
+-- name: ListMemberships :many
+SELECT org_id, login, role, status, wrapped_key, invited_by, created_at
+FROM memberships
+WHERE org_id = $1
+ORDER BY login;
+
+-- name: ListUserOrganizations :many
+SELECT
+    o.id,
+    o.name,
+    o.created_by,
+    o.created_at,
+    m.role,
+    m.status,
+    m.wrapped_key,
+    o.key_rotation_due
+FROM memberships m
+JOIN organizations o ON o.id = m.org_id
+WHERE m.login = $1
+ORDER BY o.name;
+
+-- name: AcceptMembership :execrows
+UPDATE memberships
+SET status = 'ACTIVE'
+WHERE org_id = $1 AND login = $2 AND status = 'INVITED';
+
+-- name: UpdateMembershipRole :execrows
+UPDATE memberships
+SET role = $3
+WHERE org_id = $1 AND login = $2;
+
+-- name: UpdateMembershipKey :execrows
+UPDATE memberships
+SET wrapped_key = $3
+WHERE org_id = $1 AND login = $2;
+
+-- name: DeleteMembership :execrows
+DELETE FROM memberships
+WHERE org_id = $1 AND login = $2;
+
+-- name: SetOrgKeyRotationDue :exec
+UPDATE organizations
+SET key_rotation_due = $2
+WHERE id = $1;
+
+-- name: ListOrgItems :many
+SELECT i.id, i.collection_id
+FROM items i
+JOIN collections c ON c.id = i.collection_id
+WHERE c.org_id = $1 AND (i.expires_at IS NULL OR i.expires_at > NOW());
+
+-- name: ListOrgAttachments :many
+SELECT a.id, a.item_id
+FROM item_attachments a
+JOIN items i ON i.id = a.item_id
+JOIN collections c ON c.id = i.collection_id
+WHERE c.org_id = $1 AND (i.expires_at IS NULL OR i.expires_at > NOW());
+
+-- name: RekeyCollectionItem :execrows
+UPDATE items
+SET encrypted_data_content = $3, encrypted_data_nonce = $4, encrypted_key = $5, format = $6, name = $7, meta = $8, encrypted_header_content = $9, encrypted_header_nonce = $10, updated_at = NOW()
+WHERE id = $1 AND collection_id = $2;
+
+-- name: CreateCollection :one
+INSERT INTO collections (org_id, name, encrypted_key)
+VALUES ($1, $2, $3)
+RETURNING id, created_at;
+
+-- name: ListCollections :many
+SELECT id, org_id, name, encrypted_key, created_at
+FROM collections
+WHERE org_id = $1
+ORDER BY name;
+
+-- name: UpdateCollectionKey :execrows
+UPDATE collections
+SET encrypted_key = $3
+WHERE id = $1 AND org_id = $2;
+
+-- name: GetCollectionRole :one
+SELECT m.role, m.status
+FROM collections c
+JOIN memberships m ON m.org_id = c.org_id
+WHERE c.id = $1 AND m.login = $2;
+
+-- name: GetItemCollection :one
+SELECT collection_id
+FROM items
+WHERE id = $1;
+
+-- name: GetCollectionItems :many
+SELECT
+    i.id,
+    i.user_login,
+    i.name,
+    i.type,
+    i.encrypted_data_content,
+    i.encrypted_data_nonce,
+    i.encrypted_header_content,
+    i.encrypted_header_nonce,
+    i.encrypted_key,
+    i.meta,
+    i.format,
+    i.created_at,
+    i.updated_at
+FROM items i
+WHERE i.collection_id = $1 AND (i.expires_at IS NULL OR i.expires_at > NOW())
+ORDER BY i.created_at DESC;
+
+-- name: GetCollectionItemsWithType :many
+SELECT
+    i.id,
+    i.user_login,
+    i.name,
+    i.type,
+    i.encrypted_data_content,
+    i.encrypted_data_nonce,
+    i.encrypted_header_content,
+    i.encrypted_header_nonce,
+    i.encrypted_key,
+    i.meta,
+    i.format,
+    i.created_at,
+    i.updated_at
+FROM items i
+WHERE i.collection_id = $1 AND i.type = $2 AND (i.expires_at IS NULL OR i.expires_at > NOW())
+ORDER BY i.created_at DESC;
+
+-- name: GetCollectionTypesCounts :many
+SELECT
+    type,
+    COUNT(*) as count
+FROM items
+WHERE collection_id = $1 AND (expires_at IS NULL OR expires_at > NOW())
+GROUP BY type;
+
+-- name: DeleteCollectionItem :execrows
+DELETE FROM items
+WHERE id = $1 AND collection_id = $2;
+
+-- name: UpsertEmergencyAccess :exec
+INSERT INTO emergency_access (grantor, grantee, access_type, wait_hours, wrapped_key)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (grantor, grantee) DO UPDATE
+SET access_type = EXCLUDED.access_type, wait_hours = EXCLUDED.wait_hours, wrapped_key = EXCLUDED.wrapped_key,
+    status = 'IDLE', requested_at = NULL;
+
+-- name: GetEmergencyAccess :one
+SELECT grantor, grantee, access_type, status, wait_hours, wrapped_key, requested_at, created_at
+FROM emergency_access
+WHERE grantor = $1 AND grantee = $2;
+
+-- name: ListEmergencyAccessByGrantor :many
+SELECT grantor, grantee, access_type, status, wait_hours, wrapped_key, requested_at, created_at
+FROM emergency_access
+WHERE grantor = $1
+ORDER BY grantee;
+
+-- name: ListEmergencyAccessByGrantee :many
+SELECT grantor, grantee, access_type, status, wait_hours, wrapped_key, requested_at, created_at
+FROM emergency_access
+WHERE grantee = $1
+ORDER BY grantor;
+
+-- name: RequestEmergencyAccess :execrows
+UPDATE emergency_access
+SET status = 'REQUESTED', requested_at = NOW()
+WHERE grantor = $1 AND grantee = $2 AND status = 'IDLE';
+
+-- name: ApproveEmergencyAccess :execrows
+UPDATE emergency_access
+SET status = 'APPROVED'
+WHERE grantor = $1 AND grantee = $2 AND status = 'REQUESTED';
+
+-- name: DenyEmergencyAccess :execrows
+UPDATE emergency_access
+SET status = 'IDLE', requested_at = NULL
+WHERE grantor = $1 AND grantee = $2 AND status = 'REQUESTED';
+
+-- name: DeleteEmergencyAccess :execrows
+DELETE FROM emergency_access
+WHERE grantor = $1 AND grantee = $2;
+
+-- name: DeleteEmergencyAccessOfGrantor :exec
+DELETE FROM emergency_access
+WHERE grantor = $1;
+
+-- name: UpdateUserPassword :execrows
+UPDATE users
+SET password = $2
+WHERE login = $1;
+
+-- name: UpdateUserPrivateKey :execrows
+UPDATE user_keys
+SET encrypted_private_key = $2
+WHERE login = $1;
+
+-- name: CreateSend :one
+INSERT INTO sends (owner_login, encrypted_data_content, encrypted_data_nonce, max_views, expires_at)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id;
+
+-- name: ConsumeSendView :one
+UPDATE sends
+SET views = views + 1
+WHERE id = $1 AND views < max_views AND expires_at > NOW()
+RETURNING id, owner_login, encrypted_data_content, encrypted_data_nonce, max_views, views, expires_at, created_at;
+
+-- name: DeleteSend :exec
+DELETE FROM sends
+WHERE id = $1;
+
+-- name: DeleteExpiredSends :execrows
+DELETE FROM sends
+WHERE expires_at <= NOW() OR views >= max_views;
+
+-- name: GetDevice :one
+SELECT id, user_login, name, os, public_key, approved, created_at, last_seen_at, last_seen_ip
+FROM devices
+WHERE user_login = $1 AND id = $2;
+
+-- name: UpsertDevice :one
+INSERT INTO devices (id, user_login, name, os, public_key, approved, last_seen_ip)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+ON CONFLICT (user_login, id) DO UPDATE
+SET name = EXCLUDED.name,
+    os = EXCLUDED.os,
+    public_key = COALESCE(devices.public_key, EXCLUDED.public_key),
+    approved = CASE
+        WHEN devices.public_key IS NULL AND EXCLUDED.public_key IS NOT NULL THEN EXCLUDED.approved
+        ELSE devices.approved
+    END,
+    last_seen_at = NOW(),
+    last_seen_ip = EXCLUDED.last_seen_ip
+RETURNING id, user_login, name, os, public_key, approved, created_at, last_seen_at, last_seen_ip;
+
+-- name: ListDevices :many
+SELECT id, user_login, name, os, public_key, approved, created_at, last_seen_at, last_seen_ip
+FROM devices
+WHERE user_login = $1
+ORDER BY last_seen_at DESC;
+
+-- name: DeleteDevice :execrows
+DELETE FROM devices
+WHERE user_login = $1 AND id = $2;
+
+-- name: ApproveDevice :execrows
+UPDATE devices
+SET approved = TRUE
+WHERE user_login = $1 AND id = $2;
+
+-- name: TouchDevice :exec
+UPDATE devices
+SET last_seen_at = NOW(), last_seen_ip = $3
+WHERE user_login = $1 AND id = $2;
+
+-- name: UseDeviceSignIn :execrows
+INSERT INTO device_sign_ins (user_login, device_id, signed_at)
+VALUES ($1, $2, $3)
+ON CONFLICT (user_login, device_id) DO UPDATE
+SET signed_at = EXCLUDED.signed_at
+WHERE device_sign_ins.signed_at < EXCLUDED.signed_at;
+
+-- name: GetDeviceApprovalRequired :one
+SELECT require_device_approval
+FROM users
+WHERE login = $1;
+
+-- name: SetDeviceApprovalRequired :execrows
+UPDATE users
+SET require_device_approval = $2
+WHERE login = $1;
+
+-- name: TouchItem :execrows
+UPDATE items
+SET last_used_at = NOW(), use_count = use_count + 1
+WHERE id = $1 AND user_login = $2 AND collection_id IS NULL;
+
+-- name: SetItemFavorite :execrows
+UPDATE items
+SET favorite = $3
+WHERE id = $1 AND user_login = $2 AND collection_id IS NULL;
+
+-- name: AddAttachment :one
+INSERT INTO item_attachments (item_id, encrypted_info_content, encrypted_info_nonce, size, content, nonce)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING id;
+
+-- name: ListAttachments :many
+SELECT id, item_id, encrypted_info_content, encrypted_info_nonce, size, created_at
+FROM item_attachments
+WHERE item_id = $1
+ORDER BY created_at;
+
+-- name: GetAttachment :one
+SELECT id, item_id, encrypted_info_content, encrypted_info_nonce, size, content, nonce, created_at
+FROM item_attachments
+WHERE id = $1 AND item_id = $2;
+
+-- name: UpdateAttachmentInfo :execrows
+UPDATE item_attachments
+SET encrypted_info_content = $3, encrypted_info_nonce = $4
+WHERE id = $1 AND item_id = $2;
+
+-- name: DeleteAttachment :execrows
+DELETE FROM item_attachments
+WHERE id = $1 AND item_id = $2;
+
+-- name: SaveTemplate :exec
+INSERT INTO item_templates (id, user_login, encrypted_data_content, encrypted_data_nonce)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (user_login, id) DO UPDATE
+SET encrypted_data_content = EXCLUDED.encrypted_data_content,
+    encrypted_data_nonce = EXCLUDED.encrypted_data_nonce,
+    updated_at = NOW();
+
+-- name: ListTemplates :many
+SELECT id, user_login, encrypted_data_content, encrypted_data_nonce, created_at, updated_at
+FROM item_templates
+WHERE user_login = $1
+ORDER BY created_at;
+
+-- name: DeleteTemplate :execrows
+DELETE FROM item_templates
+WHERE id = $1 AND user_login = $2;
@@ -0,0 +1,19 @@
+ALTER TABLE items ADD COLUMN IF NOT EXISTS encrypted_key TEXT NOT NULL DEFAULT '';
+
+CREATE TABLE IF NOT EXISTS user_keys (
+    login VARCHAR(50) NOT NULL PRIMARY KEY,
+    public_key BYTEA NOT NULL,
+    encrypted_private_key TEXT NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW(),
+    FOREIGN KEY (login) REFERENCES users(login) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS item_shares (
+    item_id UUID NOT NULL,
+    recipient_login VARCHAR(50) NOT NULL,
+    wrapped_key TEXT NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW(),
+    PRIMARY KEY (item_id, recipient_login),
+    FOREIGN KEY (item_id) REFERENCES items(id) ON DELETE CASCADE,
+    FOREIGN KEY (recipient_login) REFERENCES users(login) ON DELETE CASCADE
+);
@@ -0,0 +1,36 @@
+CREATE TYPE org_role AS ENUM ('OWNER', 'ADMIN', 'EDITOR', 'VIEWER');
+
+CREATE TYPE membership_status AS ENUM ('INVITED', 'ACTIVE');
+
+CREATE TABLE IF NOT EXISTS organizations (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(100) NOT NULL,
+    created_by VARCHAR(50) NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW(),
+    FOREIGN KEY (created_by) REFERENCES users(login) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS memberships (
+    org_id UUID NOT NULL,
+    login VARCHAR(50) NOT NULL,
+    role org_role NOT NULL,
+    status membership_status NOT NULL DEFAULT 'INVITED',
+    wrapped_key TEXT NOT NULL,
+    invited_by VARCHAR(50) NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW(),
+    PRIMARY KEY (org_id, login),
+    FOREIGN KEY (org_id) REFERENCES organizations(id) ON DELETE CASCADE,
+    FOREIGN KEY (login) REFERENCES users(login) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS collections (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    org_id UUID NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    encrypted_key TEXT NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW(),
+    FOREIGN KEY (org_id) REFERENCES organizations(id) ON DELETE CASCADE
+);
+
+ALTER TABLE items ADD COLUMN IF NOT EXISTS collection_id UUID REFERENCES collections(id) ON DELETE CASCADE;
+
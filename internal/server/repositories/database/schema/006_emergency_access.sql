@@ -0,0 +1,17 @@
+CREATE TYPE emergency_access_type AS ENUM ('VIEW', 'TAKEOVER');
+
+CREATE TYPE emergency_status AS ENUM ('IDLE', 'REQUESTED', 'APPROVED');
+
+CREATE TABLE IF NOT EXISTS emergency_access (
+    grantor VARCHAR(50) NOT NULL,
+    grantee VARCHAR(50) NOT NULL,
+    access_type emergency_access_type NOT NULL,
+    status emergency_status NOT NULL DEFAULT 'IDLE',
+    wait_hours INTEGER NOT NULL,
+    wrapped_key TEXT NOT NULL,
+    requested_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT NOW(),
+    PRIMARY KEY (grantor, grantee),
+    FOREIGN KEY (grantor) REFERENCES users(login) ON DELETE CASCADE,
+    FOREIGN KEY (grantee) REFERENCES users(login) ON DELETE CASCADE
+);
@@ -0,0 +1 @@
+ALTER TABLE items ADD COLUMN IF NOT EXISTS format SMALLINT NOT NULL DEFAULT 1;
@@ -0,0 +1,3 @@
+ALTER TABLE items ADD COLUMN IF NOT EXISTS search_tokens TEXT[] NOT NULL DEFAULT '{}';
+
+CREATE INDEX IF NOT EXISTS idx_items_search_tokens ON items USING GIN (search_tokens);
@@ -0,0 +1,11 @@
+ALTER TABLE items ADD COLUMN IF NOT EXISTS expires_at TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS idx_items_expires_at ON items (expires_at) WHERE expires_at IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS audit_events (
+    id BIGSERIAL PRIMARY KEY,
+    login VARCHAR(50) NOT NULL,
+    action VARCHAR(50) NOT NULL,
+    item_id UUID,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS sends (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    owner_login VARCHAR(50) NOT NULL,
+    encrypted_data_content TEXT NOT NULL,
+    encrypted_data_nonce VARCHAR(50) NOT NULL,
+    max_views INTEGER NOT NULL,
+    views INTEGER NOT NULL DEFAULT 0,
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW(),
+    FOREIGN KEY (owner_login) REFERENCES users(login) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_sends_expires_at ON sends (expires_at);
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS devices (
+    id UUID NOT NULL,
+    user_login VARCHAR(50) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    os VARCHAR(50) NOT NULL,
+    public_key BYTEA,
+    approved BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP DEFAULT NOW(),
+    last_seen_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    last_seen_ip VARCHAR(45) NOT NULL DEFAULT '',
+    PRIMARY KEY (user_login, id),
+    FOREIGN KEY (user_login) REFERENCES users(login) ON DELETE CASCADE
+);
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS require_device_approval BOOLEAN NOT NULL DEFAULT FALSE;
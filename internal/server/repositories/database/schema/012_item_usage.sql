@@ -0,0 +1,3 @@
+ALTER TABLE items ADD COLUMN IF NOT EXISTS favorite BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE items ADD COLUMN IF NOT EXISTS last_used_at TIMESTAMP;
+ALTER TABLE items ADD COLUMN IF NOT EXISTS use_count INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS item_attachments (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    item_id UUID NOT NULL,
+    encrypted_info_content TEXT NOT NULL,
+    encrypted_info_nonce VARCHAR(50) NOT NULL,
+    size BIGINT NOT NULL,
+    content BYTEA NOT NULL,
+    nonce BYTEA NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW(),
+    FOREIGN KEY (item_id) REFERENCES items(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_item_attachments_item_id ON item_attachments (item_id);
@@ -0,0 +1 @@
+ALTER TYPE item_type ADD VALUE IF NOT EXISTS 'SSH_KEY';
@@ -0,0 +1,12 @@
+ALTER TYPE item_type ADD VALUE IF NOT EXISTS 'CUSTOM';
+
+CREATE TABLE IF NOT EXISTS item_templates (
+    id UUID NOT NULL,
+    user_login VARCHAR(50) NOT NULL,
+    encrypted_data_content TEXT NOT NULL,
+    encrypted_data_nonce VARCHAR(50) NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW(),
+    PRIMARY KEY (user_login, id),
+    FOREIGN KEY (user_login) REFERENCES users(login) ON DELETE CASCADE
+);
@@ -0,0 +1,7 @@
+-- Times the server compares with its own clock keep their time zone. NOW()
+-- wrote the database local time, the server sent item and send expiry in UTC.
+ALTER TABLE users ALTER COLUMN sessions_revoked_at TYPE TIMESTAMPTZ;
+ALTER TABLE emergency_access ALTER COLUMN requested_at TYPE TIMESTAMPTZ;
+ALTER TABLE devices ALTER COLUMN last_seen_at TYPE TIMESTAMPTZ;
+ALTER TABLE items ALTER COLUMN expires_at TYPE TIMESTAMPTZ USING expires_at AT TIME ZONE 'UTC';
+ALTER TABLE sends ALTER COLUMN expires_at TYPE TIMESTAMPTZ USING expires_at AT TIME ZONE 'UTC';
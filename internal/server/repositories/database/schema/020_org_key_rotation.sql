@@ -0,0 +1,3 @@
+-- A member who leaves on their own still knows the organization key, the
+-- owner or an admin rotates it once this is set.
+ALTER TABLE organizations ADD COLUMN IF NOT EXISTS key_rotation_due BOOLEAN NOT NULL DEFAULT FALSE;
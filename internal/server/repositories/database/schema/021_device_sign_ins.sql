@@ -0,0 +1,10 @@
+-- The time the last sign in of each device was signed at. A sign in must be
+-- signed later than the one before, so a captured request cannot be sent
+-- again.
+CREATE TABLE IF NOT EXISTS device_sign_ins (
+    user_login VARCHAR(50) NOT NULL,
+    device_id UUID NOT NULL,
+    signed_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (user_login, device_id),
+    FOREIGN KEY (user_login) REFERENCES users(login) ON DELETE CASCADE
+);
@@ -0,0 +1,2 @@
+ALTER TABLE items ADD COLUMN IF NOT EXISTS encrypted_header_content TEXT NOT NULL DEFAULT '';
+ALTER TABLE items ADD COLUMN IF NOT EXISTS encrypted_header_nonce VARCHAR(50) NOT NULL DEFAULT '';
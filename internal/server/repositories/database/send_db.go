@@ -0,0 +1,102 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"gophkeeper/internal/errs"
+	"gophkeeper/models"
+
+	gen "gophkeeper/internal/server/repositories/database/generated"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type SendDatabase interface {
+	CreateSend(ctx context.Context, send *models.Send) ([16]byte, error)
+	OpenSend(ctx context.Context, id [16]byte) (*models.Send, error)
+	DeleteExpiredSends(ctx context.Context) (int64, error)
+}
+
+type SendDB struct {
+	q    *gen.Queries
+	pool PoolInterface
+}
+
+var _ SendDatabase = (*SendDB)(nil)
+
+func NewSendDB(q *gen.Queries, pool PoolInterface) (SendDatabase, error) {
+	if pool == nil || q == nil {
+		return nil, errors.New("create send database error: pool or quaries is nil")
+	}
+	return &SendDB{
+		q:    q,
+		pool: pool,
+	}, nil
+}
+
+func (db *SendDB) CreateSend(ctx context.Context, send *models.Send) ([16]byte, error) {
+	id, err := db.q.CreateSend(ctx, gen.CreateSendParams{
+		OwnerLogin:           send.OwnerLogin,
+		EncryptedDataContent: send.EncryptedData.EncryptedContent,
+		EncryptedDataNonce:   send.EncryptedData.Nonce,
+		MaxViews:             send.MaxViews,
+		ExpiresAt:            pgtype.Timestamptz{Time: send.ExpiresAt, Valid: true},
+	})
+	if err != nil {
+		return [16]byte{}, fmt.Errorf("create send error: %w", err)
+	}
+	return id.Bytes, nil
+}
+
+// OpenSend counts a view and returns the ciphertext. The send is deleted
+// in the same transaction when that was its last view.
+func (db *SendDB) OpenSend(ctx context.Context, id [16]byte) (*models.Send, error) {
+	tx, err := db.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx error: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	q := db.q.WithTx(tx)
+	pgID := pgtype.UUID{Bytes: id, Valid: true}
+	s, err := q.ConsumeSendView(ctx, pgID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, errs.ErrSendNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("consume send view error: %w", err)
+	}
+
+	if s.Views >= s.MaxViews {
+		if err := q.DeleteSend(ctx, pgID); err != nil {
+			return nil, fmt.Errorf("burn send error: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit tx error: %w", err)
+	}
+	return &models.Send{
+		ID:         s.ID.Bytes,
+		OwnerLogin: s.OwnerLogin,
+		EncryptedData: models.EncryptedData{
+			EncryptedContent: s.EncryptedDataContent,
+			Nonce:            s.EncryptedDataNonce,
+		},
+		MaxViews:  s.MaxViews,
+		Views:     s.Views,
+		ExpiresAt: s.ExpiresAt.Time,
+		CreatedAt: s.CreatedAt.Time,
+	}, nil
+}
+
+// DeleteExpiredSends removes sends that expired before reaching their view limit.
+func (db *SendDB) DeleteExpiredSends(ctx context.Context) (int64, error) {
+	n, err := db.q.DeleteExpiredSends(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("delete expired sends error: %w", err)
+	}
+	return n, nil
+}
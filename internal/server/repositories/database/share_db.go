@@ -0,0 +1,274 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"gophkeeper/internal/errs"
+	"gophkeeper/models"
+
+	gen "gophkeeper/internal/server/repositories/database/generated"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type ShareDatabase interface {
+	AddUserKeys(ctx context.Context, keys *models.UserKeys) error
+	GetUserKeys(ctx context.Context, login string) (*models.UserKeys, error)
+	GetItemOwner(ctx context.Context, itemID [16]byte) (string, error)
+	ShareItem(ctx context.Context, share *models.ItemShare) error
+	ListItemShares(ctx context.Context, itemID [16]byte) ([]models.ItemShare, error)
+	ListSharedWithUser(ctx context.Context, login string) ([]models.SharedItem, error)
+	RevokeShare(ctx context.Context, owner string, rev *models.ShareRevocation) error
+}
+
+type ShareDB struct {
+	q    *gen.Queries
+	pool PoolInterface
+}
+
+var _ ShareDatabase = (*ShareDB)(nil)
+
+func NewShareDB(q *gen.Queries, pool PoolInterface) (ShareDatabase, error) {
+	if pool == nil || q == nil {
+		return nil, errors.New("create share database error: pool or quaries is nil")
+	}
+	return &ShareDB{
+		q:    q,
+		pool: pool,
+	}, nil
+}
+
+func (db *ShareDB) AddUserKeys(ctx context.Context, keys *models.UserKeys) error {
+	n, err := db.q.AddUserKeys(ctx, gen.AddUserKeysParams{
+		Login:               keys.Login,
+		PublicKey:           keys.PublicKey,
+		EncryptedPrivateKey: keys.EncryptedPrivateKey,
+	})
+	if err != nil {
+		return fmt.Errorf("add user keys error: %w", err)
+	}
+	if n == 0 {
+		return errs.ErrKeysAlreadyExist
+	}
+	return nil
+}
+
+func (db *ShareDB) GetUserKeys(ctx context.Context, login string) (*models.UserKeys, error) {
+	keys, err := db.q.GetUserKeys(ctx, login)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, errs.ErrKeysNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get user keys error: %w", err)
+	}
+	return &models.UserKeys{
+		Login:               keys.Login,
+		PublicKey:           keys.PublicKey,
+		EncryptedPrivateKey: keys.EncryptedPrivateKey,
+	}, nil
+}
+
+func (db *ShareDB) GetItemOwner(ctx context.Context, itemID [16]byte) (string, error) {
+	owner, err := db.q.GetItemOwner(ctx, pgtype.UUID{Bytes: itemID, Valid: true})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", errs.ErrItemNotFound
+	}
+	if err != nil {
+		return "", fmt.Errorf("get item owner error: %w", err)
+	}
+	return owner, nil
+}
+
+func (db *ShareDB) ShareItem(ctx context.Context, share *models.ItemShare) error {
+	if err := db.q.UpsertItemShare(ctx, gen.UpsertItemShareParams{
+		ItemID:         pgtype.UUID{Bytes: share.ItemID, Valid: true},
+		RecipientLogin: share.RecipientLogin,
+		WrappedKey:     share.WrappedKey,
+	}); err != nil {
+		return fmt.Errorf("share item error: %w", err)
+	}
+	return nil
+}
+
+func (db *ShareDB) ListItemShares(ctx context.Context, itemID [16]byte) ([]models.ItemShare, error) {
+	rows, err := db.q.ListItemShares(ctx, pgtype.UUID{Bytes: itemID, Valid: true})
+	if err != nil {
+		return nil, fmt.Errorf("list item shares error: %w", err)
+	}
+
+	shares := make([]models.ItemShare, len(rows))
+	for i, r := range rows {
+		shares[i] = models.ItemShare{
+			ItemID:         r.ItemID.Bytes,
+			RecipientLogin: r.RecipientLogin,
+			WrappedKey:     r.WrappedKey,
+			CreatedAt:      r.CreatedAt.Time,
+		}
+	}
+	return shares, nil
+}
+
+func (db *ShareDB) ListSharedWithUser(ctx context.Context, login string) ([]models.SharedItem, error) {
+	rows, err := db.q.ListSharedWithUser(ctx, login)
+	if err != nil {
+		return nil, fmt.Errorf("list shared items error: %w", err)
+	}
+
+	items := make([]models.SharedItem, len(rows))
+	for i, r := range rows {
+		var meta models.Meta
+		if err := json.Unmarshal(r.Meta, &meta); err != nil {
+			return nil, fmt.Errorf("unmarshal meta info error: %w", err)
+		}
+
+		items[i] = models.SharedItem{
+			Item: models.EncryptedItem{
+				ID:        r.ID.Bytes,
+				UserLogin: r.UserLogin,
+				Name:      r.Name,
+				Type:      models.ItemType(r.Type),
+				EncryptedData: models.EncryptedData{
+					EncryptedContent: r.EncryptedDataContent,
+					Nonce:            r.EncryptedDataNonce,
+				},
+				EncryptedHeader: models.EncryptedData{
+					EncryptedContent: r.EncryptedHeaderContent,
+					Nonce:            r.EncryptedHeaderNonce,
+				},
+				Meta:      meta,
+				CreatedAt: r.CreatedAt.Time,
+				UpdatedAt: r.UpdatedAt.Time,
+				Format:    models.ItemFormat(r.Format),
+			},
+			WrappedKey: r.WrappedKey,
+		}
+	}
+	return items, nil
+}
+
+// RevokeShare removes the recipient, stores the item re-encrypted with a new
+// data key and replaces the wrapped keys of the remaining recipients and the
+// re-sealed attachment infos in one transaction.
+func (db *ShareDB) RevokeShare(ctx context.Context, owner string, rev *models.ShareRevocation) error {
+	itemID := pgtype.UUID{Bytes: rev.ItemID, Valid: true}
+
+	tx, err := db.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin tx error: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	q := db.q.WithTx(tx)
+	n, err := q.DeleteItemShare(ctx, gen.DeleteItemShareParams{
+		ItemID:         itemID,
+		RecipientLogin: rev.RecipientLogin,
+	})
+	if err != nil {
+		return fmt.Errorf("delete item share error: %w", err)
+	}
+	if n == 0 {
+		return errs.ErrShareNotFound
+	}
+
+	remaining, err := q.ListItemShares(ctx, itemID)
+	if err != nil {
+		return fmt.Errorf("list item shares error: %w", err)
+	}
+	if !sameRecipients(remaining, rev.Shares) {
+		return errs.ErrShareKeysMismatch
+	}
+
+	meta, err := json.Marshal(rev.Item.Meta)
+	if err != nil {
+		return fmt.Errorf("marshal meta info error: %w", err)
+	}
+	n, err = q.RekeyItem(ctx, gen.RekeyItemParams{
+		ID:                     itemID,
+		UserLogin:              owner,
+		EncryptedDataContent:   rev.Item.EncryptedData.EncryptedContent,
+		EncryptedDataNonce:     rev.Item.EncryptedData.Nonce,
+		EncryptedHeaderContent: rev.Item.EncryptedHeader.EncryptedContent,
+		EncryptedHeaderNonce:   rev.Item.EncryptedHeader.Nonce,
+		EncryptedKey:           rev.Item.EncryptedKey,
+		Format:                 itemFormatModelsToPg(rev.Item.Format),
+		Name:                   rev.Item.Name,
+		Meta:                   meta,
+		SearchTokens:           searchTokensModelsToPg(rev.Item.SearchTokens),
+	})
+	if err != nil {
+		return fmt.Errorf("rekey item error: %w", err)
+	}
+	if n == 0 {
+		return errs.ErrNotItemOwner
+	}
+
+	for _, s := range rev.Shares {
+		if _, err := q.UpdateItemShareKey(ctx, gen.UpdateItemShareKeyParams{
+			ItemID:         itemID,
+			RecipientLogin: s.RecipientLogin,
+			WrappedKey:     s.WrappedKey,
+		}); err != nil {
+			return fmt.Errorf("update share key error: %w", err)
+		}
+	}
+
+	attachments, err := q.ListAttachments(ctx, itemID)
+	if err != nil {
+		return fmt.Errorf("list attachments error: %w", err)
+	}
+	if !sameAttachments(attachments, rev.Attachments) {
+		return errs.ErrAttachmentsChanged
+	}
+	for _, a := range rev.Attachments {
+		if _, err := q.UpdateAttachmentInfo(ctx, gen.UpdateAttachmentInfoParams{
+			ID:                   pgtype.UUID{Bytes: a.ID, Valid: true},
+			ItemID:               itemID,
+			EncryptedInfoContent: a.EncryptedInfo.EncryptedContent,
+			EncryptedInfoNonce:   a.EncryptedInfo.Nonce,
+		}); err != nil {
+			return fmt.Errorf("update attachment info error: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit tx error: %w", err)
+	}
+	return nil
+}
+
+func sameRecipients(stored []gen.ItemShare, rewrapped []models.ItemShare) bool {
+	if len(stored) != len(rewrapped) {
+		return false
+	}
+	logins := make(map[string]bool, len(stored))
+	for _, s := range stored {
+		logins[s.RecipientLogin] = true
+	}
+	for _, s := range rewrapped {
+		if !logins[s.RecipientLogin] {
+			return false
+		}
+		delete(logins, s.RecipientLogin)
+	}
+	return true
+}
+
+func sameAttachments(stored []gen.ListAttachmentsRow, resealed []models.Attachment) bool {
+	if len(stored) != len(resealed) {
+		return false
+	}
+	ids := make(map[[16]byte]bool, len(stored))
+	for _, a := range stored {
+		ids[a.ID.Bytes] = true
+	}
+	for _, a := range resealed {
+		if !ids[a.ID] {
+			return false
+		}
+		delete(ids, a.ID)
+	}
+	return true
+}
@@ -0,0 +1,216 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"gophkeeper/internal/errs"
+	gen "gophkeeper/internal/server/repositories/database/generated"
+	"gophkeeper/models"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pashagolub/pgxmock/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var shareTestItemID = [16]byte{0x55, 0x0e, 0x84, 0x00, 0xe2, 0x9b, 0x41, 0xd4, 0xa7, 0x16, 0x44, 0x66, 0x55, 0x44, 0x00, 0x00}
+
+func newTestShareDB(t *testing.T) (ShareDatabase, pgxmock.PgxPoolIface) {
+	t.Helper()
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	t.Cleanup(mock.Close)
+
+	shareDB, err := NewShareDB(gen.New(mock), mock)
+	require.NoError(t, err)
+	return shareDB, mock
+}
+
+func TestNewShareDB(t *testing.T) {
+	_, err := NewShareDB(nil, nil)
+	assert.Error(t, err)
+}
+
+func TestShareDB_AddUserKeys(t *testing.T) {
+	keys := &models.UserKeys{Login: "alice", PublicKey: []byte("pub"), EncryptedPrivateKey: "priv"}
+
+	t.Run("success", func(t *testing.T) {
+		shareDB, mock := newTestShareDB(t)
+		mock.ExpectExec("INSERT INTO user_keys").WithArgs("alice", []byte("pub"), "priv").
+			WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+		assert.NoError(t, shareDB.AddUserKeys(context.Background(), keys))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("already exist", func(t *testing.T) {
+		shareDB, mock := newTestShareDB(t)
+		mock.ExpectExec("INSERT INTO user_keys").WithArgs("alice", []byte("pub"), "priv").
+			WillReturnResult(pgxmock.NewResult("INSERT", 0))
+
+		assert.ErrorIs(t, shareDB.AddUserKeys(context.Background(), keys), errs.ErrKeysAlreadyExist)
+	})
+}
+
+func TestShareDB_GetUserKeys(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		shareDB, mock := newTestShareDB(t)
+		mock.ExpectQuery("FROM user_keys").WithArgs("alice").
+			WillReturnRows(pgxmock.NewRows([]string{"login", "public_key", "encrypted_private_key", "created_at"}).
+				AddRow("alice", []byte("pub"), "priv", pgtype.Timestamp{Time: time.Now(), Valid: true}))
+
+		keys, err := shareDB.GetUserKeys(context.Background(), "alice")
+		require.NoError(t, err)
+		assert.Equal(t, []byte("pub"), keys.PublicKey)
+		assert.Equal(t, "priv", keys.EncryptedPrivateKey)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		shareDB, mock := newTestShareDB(t)
+		mock.ExpectQuery("FROM user_keys").WithArgs("bob").WillReturnError(pgx.ErrNoRows)
+
+		_, err := shareDB.GetUserKeys(context.Background(), "bob")
+		assert.ErrorIs(t, err, errs.ErrKeysNotFound)
+	})
+}
+
+func TestShareDB_GetItemOwner(t *testing.T) {
+	shareDB, mock := newTestShareDB(t)
+	mock.ExpectQuery("SELECT user_login").
+		WithArgs(pgtype.UUID{Bytes: shareTestItemID, Valid: true}).
+		WillReturnError(pgx.ErrNoRows)
+
+	_, err := shareDB.GetItemOwner(context.Background(), shareTestItemID)
+	assert.ErrorIs(t, err, errs.ErrItemNotFound)
+}
+
+func TestShareDB_ListSharedWithUser(t *testing.T) {
+	shareDB, mock := newTestShareDB(t)
+	mock.ExpectQuery("FROM item_shares s").WithArgs("bob").
+		WillReturnRows(pgxmock.NewRows([]string{
+			"id", "user_login", "name", "type", "encrypted_data_content",
+			"encrypted_data_nonce", "encrypted_header_content", "encrypted_header_nonce", "meta", "format", "created_at", "updated_at", "wrapped_key",
+		}).AddRow(
+			pgtype.UUID{Bytes: shareTestItemID, Valid: true},
+			"alice",
+			"shared item",
+			itemTypeModelsToPg(models.ItemTypeTEXT),
+			"content",
+			"nonce",
+			"",
+			"",
+			[]byte(`{"Map":null}`),
+			int16(1),
+			pgtype.Timestamp{Time: time.Now(), Valid: true},
+			pgtype.Timestamp{Time: time.Now(), Valid: true},
+			"wrapped",
+		))
+
+	items, err := shareDB.ListSharedWithUser(context.Background(), "bob")
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, "alice", items[0].Item.UserLogin)
+	assert.Equal(t, models.ItemTypeTEXT, items[0].Item.Type)
+	assert.Equal(t, "wrapped", items[0].WrappedKey)
+}
+
+func TestShareDB_RevokeShare(t *testing.T) {
+	itemID := pgtype.UUID{Bytes: shareTestItemID, Valid: true}
+	shareCols := []string{"item_id", "recipient_login", "wrapped_key", "created_at"}
+	attachmentCols := []string{"id", "item_id", "encrypted_info_content", "encrypted_info_nonce", "size", "created_at"}
+	attachmentID := pgtype.UUID{Bytes: [16]byte{9}, Valid: true}
+	newRevocation := func() *models.ShareRevocation {
+		return &models.ShareRevocation{
+			ItemID:         shareTestItemID,
+			RecipientLogin: "bob",
+			Item: models.EncryptedItem{
+				EncryptedData: models.EncryptedData{EncryptedContent: "new_content", Nonce: "new_nonce"},
+				EncryptedKey:  "new_key",
+			},
+			Shares: []models.ItemShare{{ItemID: shareTestItemID, RecipientLogin: "carol", WrappedKey: "carol_key"}},
+			Attachments: []models.Attachment{{
+				ID:            attachmentID.Bytes,
+				EncryptedInfo: models.EncryptedData{EncryptedContent: "new_info", Nonce: "new_info_nonce"},
+			}},
+		}
+	}
+	expectRekey := func(mock pgxmock.PgxPoolIface) {
+		mock.ExpectBegin()
+		mock.ExpectExec("DELETE FROM item_shares").WithArgs(itemID, "bob").
+			WillReturnResult(pgxmock.NewResult("DELETE", 1))
+		mock.ExpectQuery("FROM item_shares").WithArgs(itemID).
+			WillReturnRows(pgxmock.NewRows(shareCols).AddRow(itemID, "carol", "old_key", pgtype.Timestamp{}))
+		mock.ExpectExec("UPDATE items").WithArgs(itemID, "alice", "new_content", "new_nonce", "new_key", int16(1), "", []byte(`{"Map":null}`), []string{}, "", "").
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+		mock.ExpectExec("UPDATE item_shares").WithArgs(itemID, "carol", "carol_key").
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	}
+
+	t.Run("success", func(t *testing.T) {
+		shareDB, mock := newTestShareDB(t)
+		expectRekey(mock)
+		mock.ExpectQuery("FROM item_attachments").WithArgs(itemID).
+			WillReturnRows(pgxmock.NewRows(attachmentCols).AddRow(attachmentID, itemID, "old_info", "old_info_nonce", int64(3), pgtype.Timestamp{}))
+		mock.ExpectExec("UPDATE item_attachments").WithArgs(attachmentID, itemID, "new_info", "new_info_nonce").
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+		mock.ExpectCommit()
+
+		require.NoError(t, shareDB.RevokeShare(context.Background(), "alice", newRevocation()))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("attachment added meanwhile", func(t *testing.T) {
+		shareDB, mock := newTestShareDB(t)
+		expectRekey(mock)
+		mock.ExpectQuery("FROM item_attachments").WithArgs(itemID).
+			WillReturnRows(pgxmock.NewRows(attachmentCols).
+				AddRow(attachmentID, itemID, "old_info", "old_info_nonce", int64(3), pgtype.Timestamp{}).
+				AddRow(pgtype.UUID{Bytes: [16]byte{10}, Valid: true}, itemID, "info", "info_nonce", int64(3), pgtype.Timestamp{}))
+		mock.ExpectRollback()
+
+		err := shareDB.RevokeShare(context.Background(), "alice", newRevocation())
+		assert.ErrorIs(t, err, errs.ErrAttachmentsChanged)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("share not found", func(t *testing.T) {
+		shareDB, mock := newTestShareDB(t)
+		mock.ExpectBegin()
+		mock.ExpectExec("DELETE FROM item_shares").WithArgs(itemID, "bob").
+			WillReturnResult(pgxmock.NewResult("DELETE", 0))
+		mock.ExpectRollback()
+
+		err := shareDB.RevokeShare(context.Background(), "alice", newRevocation())
+		assert.ErrorIs(t, err, errs.ErrShareNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("rewrapped keys do not cover remaining recipients", func(t *testing.T) {
+		shareDB, mock := newTestShareDB(t)
+		mock.ExpectBegin()
+		mock.ExpectExec("DELETE FROM item_shares").WithArgs(itemID, "bob").
+			WillReturnResult(pgxmock.NewResult("DELETE", 1))
+		mock.ExpectQuery("FROM item_shares").WithArgs(itemID).
+			WillReturnRows(pgxmock.NewRows(shareCols).
+				AddRow(itemID, "carol", "old_key", pgtype.Timestamp{}).
+				AddRow(itemID, "dave", "old_key", pgtype.Timestamp{}))
+		mock.ExpectRollback()
+
+		err := shareDB.RevokeShare(context.Background(), "alice", newRevocation())
+		assert.ErrorIs(t, err, errs.ErrShareKeysMismatch)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("rollback on error", func(t *testing.T) {
+		shareDB, mock := newTestShareDB(t)
+		mock.ExpectBegin()
+		mock.ExpectExec("DELETE FROM item_shares").WithArgs(itemID, "bob").WillReturnError(fmt.Errorf("db error"))
+		mock.ExpectRollback()
+
+		assert.Error(t, shareDB.RevokeShare(context.Background(), "alice", newRevocation()))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
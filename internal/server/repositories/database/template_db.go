@@ -0,0 +1,86 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"gophkeeper/internal/errs"
+	"gophkeeper/models"
+
+	gen "gophkeeper/internal/server/repositories/database/generated"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type TemplateDatabase interface {
+	SaveTemplate(ctx context.Context, t *models.Template) error
+	ListTemplates(ctx context.Context, login string) ([]models.Template, error)
+	DeleteTemplate(ctx context.Context, login string, id [16]byte) error
+}
+
+type TemplateDB struct {
+	q    *gen.Queries
+	pool PoolInterface
+}
+
+var _ TemplateDatabase = (*TemplateDB)(nil)
+
+func NewTemplateDB(q *gen.Queries, pool PoolInterface) (TemplateDatabase, error) {
+	if pool == nil || q == nil {
+		return nil, errors.New("create template database error: pool or quaries is nil")
+	}
+	return &TemplateDB{
+		q:    q,
+		pool: pool,
+	}, nil
+}
+
+// SaveTemplate creates the template or replaces the one with the same id.
+func (db *TemplateDB) SaveTemplate(ctx context.Context, t *models.Template) error {
+	err := db.q.SaveTemplate(ctx, gen.SaveTemplateParams{
+		ID:                   pgtype.UUID{Bytes: t.ID, Valid: true},
+		UserLogin:            t.UserLogin,
+		EncryptedDataContent: t.EncryptedData.EncryptedContent,
+		EncryptedDataNonce:   t.EncryptedData.Nonce,
+	})
+	if err != nil {
+		return fmt.Errorf("save template error: %w", err)
+	}
+	return nil
+}
+
+func (db *TemplateDB) ListTemplates(ctx context.Context, login string) ([]models.Template, error) {
+	rows, err := db.q.ListTemplates(ctx, login)
+	if err != nil {
+		return nil, fmt.Errorf("list templates error: %w", err)
+	}
+
+	templates := make([]models.Template, len(rows))
+	for i, r := range rows {
+		templates[i] = models.Template{
+			ID:        r.ID.Bytes,
+			UserLogin: r.UserLogin,
+			EncryptedData: models.EncryptedData{
+				EncryptedContent: r.EncryptedDataContent,
+				Nonce:            r.EncryptedDataNonce,
+			},
+			CreatedAt: r.CreatedAt.Time,
+			UpdatedAt: r.UpdatedAt.Time,
+		}
+	}
+	return templates, nil
+}
+
+func (db *TemplateDB) DeleteTemplate(ctx context.Context, login string, id [16]byte) error {
+	n, err := db.q.DeleteTemplate(ctx, gen.DeleteTemplateParams{
+		ID:        pgtype.UUID{Bytes: id, Valid: true},
+		UserLogin: login,
+	})
+	if err != nil {
+		return fmt.Errorf("delete template error: %w", err)
+	}
+	if n == 0 {
+		return errs.ErrTemplateNotFound
+	}
+	return nil
+}
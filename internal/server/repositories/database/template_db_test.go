@@ -0,0 +1,81 @@
+package database
+
+import (
+	"context"
+	"gophkeeper/internal/errs"
+	gen "gophkeeper/internal/server/repositories/database/generated"
+	"gophkeeper/models"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pashagolub/pgxmock/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var templateTestID = [16]byte{0x7e, 0x01}
+
+func newTestTemplateDB(t *testing.T) (TemplateDatabase, pgxmock.PgxPoolIface) {
+	t.Helper()
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	t.Cleanup(mock.Close)
+
+	templateDB, err := NewTemplateDB(gen.New(mock), mock)
+	require.NoError(t, err)
+	return templateDB, mock
+}
+
+func TestNewTemplateDB(t *testing.T) {
+	_, err := NewTemplateDB(nil, nil)
+	assert.Error(t, err)
+}
+
+func TestTemplateDB_SaveTemplate(t *testing.T) {
+	templateDB, mock := newTestTemplateDB(t)
+	mock.ExpectExec("INSERT INTO item_templates .* ON CONFLICT").
+		WithArgs(pgtype.UUID{Bytes: templateTestID, Valid: true}, "alice", "enc", "nonce").
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+	err := templateDB.SaveTemplate(context.Background(), &models.Template{
+		ID:            templateTestID,
+		UserLogin:     "alice",
+		EncryptedData: models.EncryptedData{EncryptedContent: "enc", Nonce: "nonce"},
+	})
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTemplateDB_ListTemplates(t *testing.T) {
+	templateDB, mock := newTestTemplateDB(t)
+	now := time.Now()
+	mock.ExpectQuery("SELECT .* FROM item_templates").
+		WithArgs("alice").
+		WillReturnRows(pgxmock.NewRows([]string{
+			"id", "user_login", "encrypted_data_content", "encrypted_data_nonce", "created_at", "updated_at",
+		}).AddRow(
+			pgtype.UUID{Bytes: templateTestID, Valid: true}, "alice", "enc", "nonce",
+			pgtype.Timestamp{Time: now, Valid: true}, pgtype.Timestamp{Time: now, Valid: true},
+		))
+
+	templates, err := templateDB.ListTemplates(context.Background(), "alice")
+	require.NoError(t, err)
+	require.Len(t, templates, 1)
+	assert.Equal(t, templateTestID, templates[0].ID)
+	assert.Equal(t, "enc", templates[0].EncryptedData.EncryptedContent)
+	assert.Equal(t, now, templates[0].UpdatedAt)
+}
+
+func TestTemplateDB_DeleteTemplate(t *testing.T) {
+	templateDB, mock := newTestTemplateDB(t)
+	args := []any{pgtype.UUID{Bytes: templateTestID, Valid: true}, "alice"}
+	mock.ExpectExec("DELETE FROM item_templates").WithArgs(args...).
+		WillReturnResult(pgxmock.NewResult("DELETE", 1))
+	mock.ExpectExec("DELETE FROM item_templates").WithArgs(args...).
+		WillReturnResult(pgxmock.NewResult("DELETE", 0))
+
+	assert.NoError(t, templateDB.DeleteTemplate(context.Background(), "alice", templateTestID))
+	assert.ErrorIs(t, templateDB.DeleteTemplate(context.Background(), "alice", templateTestID), errs.ErrTemplateNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,50 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestQueryName(t *testing.T) {
+	assert.Equal(t, "GetAllUserItems", queryName("-- name: GetAllUserItems :many\nSELECT 1"))
+	assert.Equal(t, "COMMIT", queryName("commit"))
+	assert.Equal(t, "query", queryName("  "))
+}
+
+func TestQueryTracer(t *testing.T) {
+	mem := tracetest.NewInMemoryExporter()
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(mem)))
+	defer otel.SetTracerProvider(prev)
+
+	tracer := queryTracer{}
+	ctx := tracer.TraceQueryStart(context.Background(), nil, pgx.TraceQueryStartData{
+		SQL:  "-- name: GetUserByLogin :one\nSELECT * FROM users WHERE login = $1",
+		Args: []any{"alice"},
+	})
+	tracer.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{CommandTag: pgconn.NewCommandTag("SELECT 1")})
+
+	ctx = tracer.TraceQueryStart(context.Background(), nil, pgx.TraceQueryStartData{SQL: "DELETE FROM items"})
+	tracer.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{Err: errors.New("boom")})
+
+	spans := mem.GetSpans()
+	require.Len(t, spans, 2)
+	assert.Equal(t, "db GetUserByLogin", spans[0].Name)
+	assert.Contains(t, spans[0].Attributes, attribute.Int64("db.rows_affected", 1))
+	for _, kv := range spans[0].Attributes {
+		assert.NotEqual(t, "alice", kv.Value.Emit())
+	}
+	assert.Equal(t, "db DELETE", spans[1].Name)
+	assert.Equal(t, codes.Error, spans[1].Status.Code)
+}
@@ -47,8 +47,12 @@ func (db *UserDB) GetUser(ctx context.Context, login string) (*models.User, erro
 		return nil, fmt.Errorf("get user from db error: %w", err)
 	}
 	return &models.User{
-		Login:    user.Login,
-		Password: user.Password,
-		Salt:     user.Salt,
+		Login:             user.Login,
+		Password:          user.Password,
+		Salt:              user.Salt,
+		Locked:            user.Locked,
+		SessionsRevokedAt: user.SessionsRevokedAt.Time,
+		DeletedAt:         user.DeletedAt.Time,
+		CreatedAt:         user.CreatedAt.Time,
 	}, nil
 }
@@ -98,9 +98,9 @@ func TestUserDB_GetUser(t *testing.T) {
 			name:  "successful get user",
 			login: "existinguser",
 			mockFn: func() {
-				rows := pgxmock.NewRows([]string{"login", "password", "salt"}).
-					AddRow("existinguser", []byte("hashedpass"), "usersalt")
-				mock.ExpectQuery("SELECT login, password, salt FROM users").
+				rows := pgxmock.NewRows([]string{"login", "password", "salt", "locked", "sessions_revoked_at", "deleted_at", "created_at"}).
+					AddRow("existinguser", []byte("hashedpass"), "usersalt", false, nil, nil, nil)
+				mock.ExpectQuery("SELECT login, password, salt, locked, sessions_revoked_at, deleted_at, created_at FROM users").
 					WithArgs("existinguser").
 					WillReturnRows(rows)
 			},
@@ -115,7 +115,7 @@ func TestUserDB_GetUser(t *testing.T) {
 			name:  "user not found",
 			login: "notfound",
 			mockFn: func() {
-				mock.ExpectQuery("SELECT login, password, salt FROM users").
+				mock.ExpectQuery("SELECT login, password, salt, locked, sessions_revoked_at, deleted_at, created_at FROM users").
 					WithArgs("notfound").
 					WillReturnError(pgx.ErrNoRows)
 			},
@@ -126,7 +126,7 @@ func TestUserDB_GetUser(t *testing.T) {
 			name:  "database error",
 			login: "erroruser",
 			mockFn: func() {
-				mock.ExpectQuery("SELECT login, password, salt FROM users").
+				mock.ExpectQuery("SELECT login, password, salt, locked, sessions_revoked_at, deleted_at, created_at FROM users").
 					WithArgs("erroruser").
 					WillReturnError(fmt.Errorf("database connection failed"))
 			},
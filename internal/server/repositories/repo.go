@@ -2,14 +2,33 @@ package repositories
 
 import (
 	"gophkeeper/config"
+	"gophkeeper/internal/lifecycle"
 	"gophkeeper/internal/server/repositories/database"
 )
 
 type Storage interface {
 	database.Database
-	//Close() error
+	lifecycle.Component
+
+	// Close releases the connection pool, for one-off tools that do not
+	// run a lifecycle manager.
+	Close() error
 }
 
 func NewStorage(cfg config.DatabaseConfig) (Storage, error) {
-	return database.NewPGDB(cfg)
+	db, err := database.NewPGDB(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return db, nil
+}
+
+// OpenStorage connects to the storage without applying migrations.
+// Used by the admin tool, which runs migrations explicitly.
+func OpenStorage(cfg config.DatabaseConfig) (Storage, error) {
+	db, err := database.OpenPGDB(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return db, nil
 }
@@ -0,0 +1,146 @@
+package admin_service
+
+import (
+	"context"
+	"fmt"
+	"gophkeeper/internal/server/repositories"
+	"gophkeeper/models"
+)
+
+// KeyRotator replaces the server signing and encryption keys.
+type KeyRotator interface {
+	RotateJWTSecret() error
+	RotateRSAKeyPair() error
+}
+
+type purgeJob struct {
+	name string
+	run  func(ctx context.Context) (int64, error)
+}
+
+type AdminService struct {
+	repo   repositories.Storage
+	keys   KeyRotator
+	purges []purgeJob
+}
+
+func NewAdminService(repo repositories.Storage, keys KeyRotator) (*AdminService, error) {
+	as := &AdminService{repo: repo, keys: keys}
+	as.purges = []purgeJob{
+		{name: "deleted_users", run: repo.PurgeDeletedUsers},
+		{name: "expired_sends", run: repo.DeleteExpiredSends},
+	}
+	return as, nil
+}
+
+func (as *AdminService) ListUsers(ctx context.Context) ([]models.UserStats, error) {
+	users, err := as.repo.ListUsersStats(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list users error: %w", err)
+	}
+	return users, nil
+}
+
+func (as *AdminService) LockUser(ctx context.Context, login string) error {
+	if err := as.repo.SetUserLocked(ctx, login, true); err != nil {
+		return fmt.Errorf("lock user %s error: %w", login, err)
+	}
+	return nil
+}
+
+func (as *AdminService) UnlockUser(ctx context.Context, login string) error {
+	if err := as.repo.SetUserLocked(ctx, login, false); err != nil {
+		return fmt.Errorf("unlock user %s error: %w", login, err)
+	}
+	return nil
+}
+
+// DeleteUser marks the account as deleted. Its data is removed by Purge.
+func (as *AdminService) DeleteUser(ctx context.Context, login string) error {
+	if err := as.repo.MarkUserDeleted(ctx, login); err != nil {
+		return fmt.Errorf("delete user %s error: %w", login, err)
+	}
+	return nil
+}
+
+func (as *AdminService) RevokeSessions(ctx context.Context, login string) error {
+	if err := as.repo.RevokeUserSessions(ctx, login); err != nil {
+		return fmt.Errorf("revoke sessions of %s error: %w", login, err)
+	}
+	return nil
+}
+
+func (as *AdminService) RevokeAllSessions(ctx context.Context) (int64, error) {
+	n, err := as.repo.RevokeAllSessions(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("revoke all sessions error: %w", err)
+	}
+	return n, nil
+}
+
+// RotateJWTSecret writes a new signing secret and revokes every issued token.
+// The server picks up the new secret on restart.
+func (as *AdminService) RotateJWTSecret(ctx context.Context) error {
+	if err := as.keys.RotateJWTSecret(); err != nil {
+		return fmt.Errorf("rotate jwt secret error: %w", err)
+	}
+	if _, err := as.repo.RevokeAllSessions(ctx); err != nil {
+		return fmt.Errorf("revoke sessions after jwt rotation error: %w", err)
+	}
+	return nil
+}
+
+// RotateRSAKeys writes a new key pair. The server picks it up on restart.
+func (as *AdminService) RotateRSAKeys() error {
+	if err := as.keys.RotateRSAKeyPair(); err != nil {
+		return fmt.Errorf("rotate rsa keys error: %w", err)
+	}
+	return nil
+}
+
+func (as *AdminService) Migrations(ctx context.Context) ([]models.Migration, error) {
+	migrations, err := as.repo.ListMigrations(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list migrations error: %w", err)
+	}
+	return migrations, nil
+}
+
+func (as *AdminService) Migrate(ctx context.Context) ([]string, error) {
+	applied, err := as.repo.Migrate(ctx)
+	if err != nil {
+		return applied, fmt.Errorf("migrate error: %w", err)
+	}
+	return applied, nil
+}
+
+// Purge runs every cleanup job and reports how many rows each one removed.
+func (as *AdminService) Purge(ctx context.Context) ([]models.PurgeResult, error) {
+	results := make([]models.PurgeResult, 0, len(as.purges))
+	for _, job := range as.purges {
+		n, err := job.run(ctx)
+		if err != nil {
+			return results, fmt.Errorf("purge %s error: %w", job.name, err)
+		}
+		results = append(results, models.PurgeResult{Job: job.name, Removed: n})
+	}
+	return results, nil
+}
+
+func (as *AdminService) Stats(ctx context.Context) (*models.ServerStats, error) {
+	stats, err := as.repo.GetServerStats(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get stats error: %w", err)
+	}
+
+	migrations, err := as.Migrations(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range migrations {
+		if !m.Applied {
+			stats.PendingMigrations++
+		}
+	}
+	return stats, nil
+}
@@ -0,0 +1,275 @@
+package admin_service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"gophkeeper/internal/errs"
+	"gophkeeper/internal/server/repositories"
+	"gophkeeper/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// MockStorage implements admin methods of repositories.Storage for testing
+type MockStorage struct {
+	repositories.Storage
+
+	shouldFail  bool
+	locked      map[string]bool
+	deleted     []string
+	revoked     []string
+	revokedAll  int
+	purged      int64
+	sendsPurged int64
+	migrations  []models.Migration
+	stats       models.ServerStats
+	knownLogins map[string]bool
+}
+
+func (m *MockStorage) ListUsersStats(ctx context.Context) ([]models.UserStats, error) {
+	if m.shouldFail {
+		return nil, errors.New("storage error")
+	}
+	var users []models.UserStats
+	for login := range m.knownLogins {
+		users = append(users, models.UserStats{Login: login, Locked: m.locked[login]})
+	}
+	return users, nil
+}
+
+func (m *MockStorage) SetUserLocked(ctx context.Context, login string, locked bool) error {
+	if !m.knownLogins[login] {
+		return errs.ErrUserNotFound
+	}
+	if m.locked == nil {
+		m.locked = make(map[string]bool)
+	}
+	m.locked[login] = locked
+	return nil
+}
+
+func (m *MockStorage) MarkUserDeleted(ctx context.Context, login string) error {
+	if !m.knownLogins[login] {
+		return errs.ErrUserNotFound
+	}
+	m.deleted = append(m.deleted, login)
+	return nil
+}
+
+func (m *MockStorage) RevokeUserSessions(ctx context.Context, login string) error {
+	if !m.knownLogins[login] {
+		return errs.ErrUserNotFound
+	}
+	m.revoked = append(m.revoked, login)
+	return nil
+}
+
+func (m *MockStorage) RevokeAllSessions(ctx context.Context) (int64, error) {
+	if m.shouldFail {
+		return 0, errors.New("storage error")
+	}
+	m.revokedAll++
+	return int64(len(m.knownLogins)), nil
+}
+
+func (m *MockStorage) PurgeDeletedUsers(ctx context.Context) (int64, error) {
+	if m.shouldFail {
+		return 0, errors.New("storage error")
+	}
+	return m.purged, nil
+}
+
+func (m *MockStorage) DeleteExpiredSends(ctx context.Context) (int64, error) {
+	if m.shouldFail {
+		return 0, errors.New("storage error")
+	}
+	return m.sendsPurged, nil
+}
+
+func (m *MockStorage) GetServerStats(ctx context.Context) (*models.ServerStats, error) {
+	if m.shouldFail {
+		return nil, errors.New("storage error")
+	}
+	stats := m.stats
+	return &stats, nil
+}
+
+func (m *MockStorage) ListMigrations(ctx context.Context) ([]models.Migration, error) {
+	if m.shouldFail {
+		return nil, errors.New("storage error")
+	}
+	return m.migrations, nil
+}
+
+func (m *MockStorage) Migrate(ctx context.Context) ([]string, error) {
+	if m.shouldFail {
+		return nil, errors.New("storage error")
+	}
+	var applied []string
+	for i := range m.migrations {
+		if !m.migrations[i].Applied {
+			m.migrations[i].Applied = true
+			applied = append(applied, m.migrations[i].Version)
+		}
+	}
+	return applied, nil
+}
+
+type MockKeys struct {
+	shouldFail bool
+	jwt        int
+	rsa        int
+}
+
+func (k *MockKeys) RotateJWTSecret() error {
+	if k.shouldFail {
+		return errors.New("keys error")
+	}
+	k.jwt++
+	return nil
+}
+
+func (k *MockKeys) RotateRSAKeyPair() error {
+	if k.shouldFail {
+		return errors.New("keys error")
+	}
+	k.rsa++
+	return nil
+}
+
+func newTestService(t *testing.T, repo *MockStorage, keys *MockKeys) *AdminService {
+	t.Helper()
+	if repo.knownLogins == nil {
+		repo.knownLogins = map[string]bool{"alice": true, "bob": true}
+	}
+	as, err := NewAdminService(repo, keys)
+	require.NoError(t, err)
+	return as
+}
+
+func TestAdminService_ListUsers(t *testing.T) {
+	as := newTestService(t, &MockStorage{}, &MockKeys{})
+	users, err := as.ListUsers(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, users, 2)
+
+	as = newTestService(t, &MockStorage{shouldFail: true}, &MockKeys{})
+	_, err = as.ListUsers(context.Background())
+	assert.Error(t, err)
+}
+
+func TestAdminService_LockUnlock(t *testing.T) {
+	repo := &MockStorage{}
+	as := newTestService(t, repo, &MockKeys{})
+	ctx := context.Background()
+
+	require.NoError(t, as.LockUser(ctx, "alice"))
+	assert.True(t, repo.locked["alice"])
+
+	require.NoError(t, as.UnlockUser(ctx, "alice"))
+	assert.False(t, repo.locked["alice"])
+
+	err := as.LockUser(ctx, "nobody")
+	assert.ErrorIs(t, err, errs.ErrUserNotFound)
+}
+
+func TestAdminService_DeleteUser(t *testing.T) {
+	repo := &MockStorage{}
+	as := newTestService(t, repo, &MockKeys{})
+
+	require.NoError(t, as.DeleteUser(context.Background(), "bob"))
+	assert.Equal(t, []string{"bob"}, repo.deleted)
+
+	err := as.DeleteUser(context.Background(), "nobody")
+	assert.ErrorIs(t, err, errs.ErrUserNotFound)
+}
+
+func TestAdminService_RevokeSessions(t *testing.T) {
+	repo := &MockStorage{}
+	as := newTestService(t, repo, &MockKeys{})
+	ctx := context.Background()
+
+	require.NoError(t, as.RevokeSessions(ctx, "alice"))
+	assert.Equal(t, []string{"alice"}, repo.revoked)
+
+	n, err := as.RevokeAllSessions(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), n)
+	assert.Equal(t, 1, repo.revokedAll)
+}
+
+func TestAdminService_RotateKeys(t *testing.T) {
+	tests := []struct {
+		name      string
+		repoFail  bool
+		keysFail  bool
+		wantErr   bool
+		wantJWT   int
+		wantRevok int
+	}{
+		{name: "success", wantJWT: 1, wantRevok: 1},
+		{name: "rotation fails", keysFail: true, wantErr: true},
+		{name: "revoke fails", repoFail: true, wantErr: true, wantJWT: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &MockStorage{shouldFail: tt.repoFail}
+			keys := &MockKeys{shouldFail: tt.keysFail}
+			as := newTestService(t, repo, keys)
+
+			err := as.RotateJWTSecret(context.Background())
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantJWT, keys.jwt)
+			assert.Equal(t, tt.wantRevok, repo.revokedAll)
+		})
+	}
+
+	keys := &MockKeys{}
+	as := newTestService(t, &MockStorage{}, keys)
+	require.NoError(t, as.RotateRSAKeys())
+	assert.Equal(t, 1, keys.rsa)
+}
+
+func TestAdminService_Migrate(t *testing.T) {
+	repo := &MockStorage{migrations: []models.Migration{
+		{Version: "001_types.sql", Applied: true},
+		{Version: "002_tables.sql"},
+		{Version: "003_admin.sql"},
+	}}
+	as := newTestService(t, repo, &MockKeys{})
+	ctx := context.Background()
+
+	stats, err := as.Stats(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, stats.PendingMigrations)
+
+	applied, err := as.Migrate(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"002_tables.sql", "003_admin.sql"}, applied)
+
+	stats, err = as.Stats(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, stats.PendingMigrations)
+}
+
+func TestAdminService_Purge(t *testing.T) {
+	as := newTestService(t, &MockStorage{purged: 3, sendsPurged: 2}, &MockKeys{})
+	results, err := as.Purge(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []models.PurgeResult{
+		{Job: "deleted_users", Removed: 3},
+		{Job: "expired_sends", Removed: 2},
+	}, results)
+
+	as = newTestService(t, &MockStorage{shouldFail: true}, &MockKeys{})
+	_, err = as.Purge(context.Background())
+	assert.Error(t, err)
+}
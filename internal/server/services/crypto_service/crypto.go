@@ -100,6 +100,51 @@ func (cs *CryptoService) LoadRSAKeyPair() error {
 	return nil
 }
 
+// RotateRSAKeyPair generates a new RSA key pair and overwrites the key files
+// the server loads on start.
+func (cs *CryptoService) RotateRSAKeyPair() error {
+	pair, err := generateRSAKeyPair()
+	if err != nil {
+		return fmt.Errorf("generate rsa keys error: %w", err)
+	}
+
+	privateFile, err := activeKeyFilename([]string{"private.pem", privateKeyFilename})
+	if err != nil {
+		return err
+	}
+	publicFile, err := activeKeyFilename([]string{"public.pem", publicKeyFilename})
+	if err != nil {
+		return err
+	}
+
+	if err := saveKeyInFile(pair.getPrivateKeyPEM(), privateFile); err != nil {
+		return fmt.Errorf("write private key pem to file error: %w", err)
+	}
+	publicPEM, err := pair.getPublicKeyPEM()
+	if err != nil {
+		return fmt.Errorf("get public key PEM error: %w", err)
+	}
+	if err := saveKeyInFile(publicPEM, publicFile); err != nil {
+		return fmt.Errorf("write public key pem to file error: %w", err)
+	}
+	return nil
+}
+
+// activeKeyFilename returns the first non-empty key file from filenames,
+// falling back to the last one.
+func activeKeyFilename(filenames []string) (string, error) {
+	keysPath, err := getKeysPath()
+	if err != nil {
+		return "", fmt.Errorf("get keys path error: %w", err)
+	}
+	for _, f := range filenames {
+		if st, err := os.Stat(filepath.Join(keysPath, f)); err == nil && st.Size() > 0 {
+			return f, nil
+		}
+	}
+	return filenames[len(filenames)-1], nil
+}
+
 func generateRSAKeyPair() (*rsaKeyPair, error) {
 	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
 	if err != nil {
@@ -0,0 +1,88 @@
+package crypto_service
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const jwtSecretFilename = "jwt_secret.key"
+
+var ErrSecretKeyFromEnv = errors.New("SECRET_KEY is set in the environment, rotate it there")
+
+// LoadJWTSecret sets the secret used to sign JWT tokens.
+// SECRET_KEY from the environment has priority, otherwise the secret is read
+// from the keys dir and generated on the first start.
+func (cs *CryptoService) LoadJWTSecret() error {
+	if cs.cnfg.GetSecretKey() != "" {
+		return nil
+	}
+
+	secret, err := readJWTSecret()
+	if err != nil {
+		return fmt.Errorf("read jwt secret error: %w", err)
+	}
+	if secret == "" {
+		secret, err = writeJWTSecret()
+		if err != nil {
+			return fmt.Errorf("generate jwt secret error: %w", err)
+		}
+	}
+
+	return cs.cnfg.SetSecretKey(secret)
+}
+
+// RotateJWTSecret replaces the stored JWT secret. Tokens signed with the old
+// secret stop working once the server is restarted with the new one. A
+// secret in the config that is not the stored one was set by SECRET_KEY and
+// is rotated there.
+func (cs *CryptoService) RotateJWTSecret() error {
+	stored, err := readJWTSecret()
+	if err != nil {
+		return fmt.Errorf("read jwt secret error: %w", err)
+	}
+	if secret := cs.cnfg.GetSecretKey(); secret != "" && secret != stored {
+		return ErrSecretKeyFromEnv
+	}
+	if _, err := writeJWTSecret(); err != nil {
+		return fmt.Errorf("rotate jwt secret error: %w", err)
+	}
+	return nil
+}
+
+func readJWTSecret() (string, error) {
+	keysPath, err := getKeysPath()
+	if err != nil {
+		return "", fmt.Errorf("get keys path error: %w", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(keysPath, jwtSecretFilename))
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+func writeJWTSecret() (string, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", fmt.Errorf("generate random secret error: %w", err)
+	}
+	secret := base64.StdEncoding.EncodeToString(raw)
+
+	keysPath, err := getKeysPath()
+	if err != nil {
+		return "", fmt.Errorf("get keys path error: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(keysPath, jwtSecretFilename), []byte(secret), 0600); err != nil {
+		return "", fmt.Errorf("write jwt secret error: %w", err)
+	}
+	return secret, nil
+}
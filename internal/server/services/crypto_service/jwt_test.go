@@ -0,0 +1,95 @@
+package crypto_service
+
+import (
+	"crypto/rsa"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testCryptoConfig struct {
+	secret string
+}
+
+func (c *testCryptoConfig) SetPrivateKey(*rsa.PrivateKey) error { return nil }
+func (c *testCryptoConfig) SetPublicKeyPEM([]byte) error        { return nil }
+func (c *testCryptoConfig) SetSecretKey(s string) error         { c.secret = s; return nil }
+func (c *testCryptoConfig) GetSecretKey() string                { return c.secret }
+
+func TestLoadJWTSecret(t *testing.T) {
+	t.Run("secret from env has priority", func(t *testing.T) {
+		dir := t.TempDir()
+		t.Setenv("KEYS_DIR", dir)
+
+		cnfg := &testCryptoConfig{secret: "from-env"}
+		cs, _ := NewCryptoService(cnfg)
+		require.NoError(t, cs.LoadJWTSecret())
+
+		assert.Equal(t, "from-env", cnfg.secret)
+		assert.NoFileExists(t, filepath.Join(dir, jwtSecretFilename))
+	})
+
+	t.Run("generated on first start and reused", func(t *testing.T) {
+		dir := t.TempDir()
+		t.Setenv("KEYS_DIR", dir)
+
+		first := &testCryptoConfig{}
+		cs, _ := NewCryptoService(first)
+		require.NoError(t, cs.LoadJWTSecret())
+		assert.NotEmpty(t, first.secret)
+
+		info, err := os.Stat(filepath.Join(dir, jwtSecretFilename))
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+		second := &testCryptoConfig{}
+		cs, _ = NewCryptoService(second)
+		require.NoError(t, cs.LoadJWTSecret())
+		assert.Equal(t, first.secret, second.secret)
+	})
+}
+
+func TestRotateJWTSecret(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("KEYS_DIR", dir)
+
+	cnfg := &testCryptoConfig{}
+	cs, _ := NewCryptoService(cnfg)
+	require.NoError(t, cs.LoadJWTSecret())
+	old := cnfg.secret
+
+	require.NoError(t, cs.RotateJWTSecret())
+
+	rotated, err := readJWTSecret()
+	require.NoError(t, err)
+	assert.NotEmpty(t, rotated)
+	assert.NotEqual(t, old, rotated)
+
+	// The admin CLI does not load the stored secret.
+	cs, _ = NewCryptoService(&testCryptoConfig{})
+	require.NoError(t, cs.RotateJWTSecret())
+
+	cs, _ = NewCryptoService(&testCryptoConfig{secret: "from-env"})
+	assert.ErrorIs(t, cs.RotateJWTSecret(), ErrSecretKeyFromEnv)
+}
+
+func TestRotateRSAKeyPair(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("KEYS_DIR", dir)
+
+	cs, _ := NewCryptoService(&testCryptoConfig{})
+	require.NoError(t, cs.RotateRSAKeyPair())
+
+	firstPEM, err := os.ReadFile(filepath.Join(dir, privateKeyFilename))
+	require.NoError(t, err)
+	_, err = getPrivateKeyFromPEM(firstPEM)
+	require.NoError(t, err)
+
+	require.NoError(t, cs.RotateRSAKeyPair())
+	secondPEM, err := os.ReadFile(filepath.Join(dir, privateKeyFilename))
+	require.NoError(t, err)
+	assert.NotEqual(t, firstPEM, secondPEM)
+}
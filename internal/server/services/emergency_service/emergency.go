@@ -0,0 +1,161 @@
+package emergency_service
+
+import (
+	"context"
+	"fmt"
+	"gophkeeper/internal/errs"
+	"gophkeeper/internal/server/repositories"
+	"gophkeeper/models"
+	"time"
+)
+
+// PasswordHasher turns a password encrypted for the server into a stored hash.
+type PasswordHasher interface {
+	HashPassword(encryptedPassword string) ([]byte, error)
+}
+
+type EmergencyService struct {
+	repo   repositories.Storage
+	hasher PasswordHasher
+	now    func() time.Time
+}
+
+func NewEmergencyService(repo repositories.Storage, hasher PasswordHasher) (*EmergencyService, error) {
+	return &EmergencyService{
+		repo:   repo,
+		hasher: hasher,
+		now:    time.Now,
+	}, nil
+}
+
+// GrantAccess designates access.Grantee as a trusted contact of grantor.
+// Granting again replaces the settings and cancels a pending request.
+func (es *EmergencyService) GrantAccess(ctx context.Context, grantor string, access *models.EmergencyAccess) error {
+	switch {
+	case access.Grantee == "" || access.WrappedKey == "":
+		return errs.ErrRequiredArgumentIsMissing
+	case access.Grantee == grantor:
+		return errs.ErrEmergencyWithSelf
+	case !access.Type.IsValid():
+		return errs.ErrInvalidEmergencyAccessType
+	case access.WaitPeriod < time.Hour:
+		return errs.ErrInvalidWaitPeriod
+	}
+	if _, err := es.repo.GetUserKeys(ctx, access.Grantee); err != nil {
+		return err
+	}
+
+	access.Grantor = grantor
+	if err := es.repo.UpsertEmergencyAccess(ctx, access); err != nil {
+		return fmt.Errorf("grant emergency access to %s error: %w", access.Grantee, err)
+	}
+	return nil
+}
+
+func (es *EmergencyService) RevokeAccess(ctx context.Context, grantor, grantee string) error {
+	return es.repo.DeleteEmergencyAccess(ctx, grantor, grantee)
+}
+
+// ListAccess returns the contacts trusted by login and the users who trust
+// login. Wrapped keys of the latter are only handed out with the vault.
+func (es *EmergencyService) ListAccess(ctx context.Context, login string) (granted, trustedBy []models.EmergencyAccess, err error) {
+	granted, err = es.repo.ListEmergencyAccessByGrantor(ctx, login)
+	if err != nil {
+		return nil, nil, err
+	}
+	trustedBy, err = es.repo.ListEmergencyAccessByGrantee(ctx, login)
+	if err != nil {
+		return nil, nil, err
+	}
+	for i := range trustedBy {
+		trustedBy[i].WrappedKey = ""
+	}
+	return granted, trustedBy, nil
+}
+
+// RequestAccess starts the wait period of the grantee's access to grantor's vault.
+func (es *EmergencyService) RequestAccess(ctx context.Context, grantee, grantor string) error {
+	if _, err := es.repo.GetEmergencyAccess(ctx, grantor, grantee); err != nil {
+		return err
+	}
+	return es.repo.RequestEmergencyAccess(ctx, grantor, grantee)
+}
+
+// ApproveAccess lets the grantor skip the rest of the wait period.
+func (es *EmergencyService) ApproveAccess(ctx context.Context, grantor, grantee string) error {
+	if _, err := es.repo.GetEmergencyAccess(ctx, grantor, grantee); err != nil {
+		return err
+	}
+	return es.repo.ApproveEmergencyAccess(ctx, grantor, grantee)
+}
+
+func (es *EmergencyService) DenyAccess(ctx context.Context, grantor, grantee string) error {
+	if _, err := es.repo.GetEmergencyAccess(ctx, grantor, grantee); err != nil {
+		return err
+	}
+	return es.repo.DenyEmergencyAccess(ctx, grantor, grantee)
+}
+
+// GetVault returns grantor's personal vault to the grantee once access is granted.
+func (es *EmergencyService) GetVault(ctx context.Context, grantee, grantor string) (*models.EmergencyVault, error) {
+	access, err := es.accessible(ctx, grantee, grantor)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := es.repo.GetUser(ctx, grantor)
+	if err != nil {
+		return nil, fmt.Errorf("get grantor error: %w", err)
+	}
+	keys, err := es.repo.GetUserKeys(ctx, grantor)
+	if err != nil {
+		return nil, err
+	}
+	items, err := es.repo.GetAllUserItems(ctx, grantor)
+	if err != nil {
+		return nil, fmt.Errorf("get grantor items error: %w", err)
+	}
+	templates, err := es.repo.ListTemplates(ctx, grantor)
+	if err != nil {
+		return nil, fmt.Errorf("get grantor templates error: %w", err)
+	}
+
+	return &models.EmergencyVault{
+		Access:    *access,
+		Salt:      user.Salt,
+		Keys:      keys,
+		Items:     items,
+		Templates: templates,
+	}, nil
+}
+
+// Takeover sets a new password and master key for the grantor's account.
+func (es *EmergencyService) Takeover(ctx context.Context, grantee string, t *models.EmergencyTakeover) error {
+	access, err := es.accessible(ctx, grantee, t.Grantor)
+	if err != nil {
+		return err
+	}
+	if access.Type != models.EmergencyAccessTAKEOVER {
+		return errs.ErrEmergencyTakeoverNotAllowed
+	}
+
+	passHash, err := es.hasher.HashPassword(t.Password)
+	if err != nil {
+		return err
+	}
+	return es.repo.TakeoverAccount(ctx, t, passHash)
+}
+
+func (es *EmergencyService) accessible(ctx context.Context, grantee, grantor string) (*models.EmergencyAccess, error) {
+	access, err := es.repo.GetEmergencyAccess(ctx, grantor, grantee)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case access.Status == models.EmergencyStatusIDLE:
+		return nil, errs.ErrEmergencyNotRequested
+	case !access.IsAccessible(es.now()):
+		return nil, errs.ErrEmergencyAccessPending
+	}
+	return access, nil
+}
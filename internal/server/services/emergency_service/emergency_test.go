@@ -0,0 +1,253 @@
+package emergency_service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"gophkeeper/internal/errs"
+	"gophkeeper/internal/server/repositories"
+	"gophkeeper/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// MockStorage implements emergency access methods of repositories.Storage for testing
+type MockStorage struct {
+	repositories.Storage
+
+	keys     map[string]*models.UserKeys
+	access   map[[2]string]*models.EmergencyAccess
+	takeover *models.EmergencyTakeover
+	hash     []byte
+}
+
+func (m *MockStorage) GetUserKeys(ctx context.Context, login string) (*models.UserKeys, error) {
+	keys, ok := m.keys[login]
+	if !ok {
+		return nil, errs.ErrKeysNotFound
+	}
+	return keys, nil
+}
+
+func (m *MockStorage) GetUser(ctx context.Context, login string) (*models.User, error) {
+	return &models.User{Login: login, Salt: "salt-" + login}, nil
+}
+
+func (m *MockStorage) GetAllUserItems(ctx context.Context, login string) ([]models.EncryptedItem, error) {
+	return []models.EncryptedItem{{ID: [16]byte{1}, UserLogin: login}}, nil
+}
+
+func (m *MockStorage) ListTemplates(ctx context.Context, login string) ([]models.Template, error) {
+	return []models.Template{{ID: [16]byte{2}, UserLogin: login}}, nil
+}
+
+func (m *MockStorage) UpsertEmergencyAccess(ctx context.Context, access *models.EmergencyAccess) error {
+	a := *access
+	a.Status = models.EmergencyStatusIDLE
+	m.access[[2]string{access.Grantor, access.Grantee}] = &a
+	return nil
+}
+
+func (m *MockStorage) GetEmergencyAccess(ctx context.Context, grantor, grantee string) (*models.EmergencyAccess, error) {
+	a, ok := m.access[[2]string{grantor, grantee}]
+	if !ok {
+		return nil, errs.ErrEmergencyAccessNotFound
+	}
+	copied := *a
+	return &copied, nil
+}
+
+func (m *MockStorage) ListEmergencyAccessByGrantee(ctx context.Context, grantee string) ([]models.EmergencyAccess, error) {
+	var list []models.EmergencyAccess
+	for k, a := range m.access {
+		if k[1] == grantee {
+			list = append(list, *a)
+		}
+	}
+	return list, nil
+}
+
+func (m *MockStorage) ListEmergencyAccessByGrantor(ctx context.Context, grantor string) ([]models.EmergencyAccess, error) {
+	var list []models.EmergencyAccess
+	for k, a := range m.access {
+		if k[0] == grantor {
+			list = append(list, *a)
+		}
+	}
+	return list, nil
+}
+
+func (m *MockStorage) RequestEmergencyAccess(ctx context.Context, grantor, grantee string) error {
+	a := m.access[[2]string{grantor, grantee}]
+	if a.Status != models.EmergencyStatusIDLE {
+		return errs.ErrEmergencyAlreadyRequested
+	}
+	a.Status = models.EmergencyStatusREQUESTED
+	a.RequestedAt = testNow
+	return nil
+}
+
+func (m *MockStorage) ApproveEmergencyAccess(ctx context.Context, grantor, grantee string) error {
+	a := m.access[[2]string{grantor, grantee}]
+	if a.Status != models.EmergencyStatusREQUESTED {
+		return errs.ErrEmergencyNotRequested
+	}
+	a.Status = models.EmergencyStatusAPPROVED
+	return nil
+}
+
+func (m *MockStorage) DenyEmergencyAccess(ctx context.Context, grantor, grantee string) error {
+	a := m.access[[2]string{grantor, grantee}]
+	if a.Status != models.EmergencyStatusREQUESTED {
+		return errs.ErrEmergencyNotRequested
+	}
+	a.Status = models.EmergencyStatusIDLE
+	a.RequestedAt = time.Time{}
+	return nil
+}
+
+func (m *MockStorage) TakeoverAccount(ctx context.Context, t *models.EmergencyTakeover, passwordHash []byte) error {
+	m.takeover = t
+	m.hash = passwordHash
+	return nil
+}
+
+type mockHasher struct{}
+
+func (mockHasher) HashPassword(encryptedPassword string) ([]byte, error) {
+	return []byte("hash:" + encryptedPassword), nil
+}
+
+var testNow = time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+func newTestService(t *testing.T) (*EmergencyService, *MockStorage) {
+	t.Helper()
+	repo := &MockStorage{
+		keys: map[string]*models.UserKeys{
+			"alice": {Login: "alice", PublicKey: []byte("alice-pub"), EncryptedPrivateKey: "alice-priv"},
+			"bob":   {Login: "bob", PublicKey: []byte("bob-pub"), EncryptedPrivateKey: "bob-priv"},
+		},
+		access: map[[2]string]*models.EmergencyAccess{},
+	}
+	es, err := NewEmergencyService(repo, mockHasher{})
+	require.NoError(t, err)
+	es.now = func() time.Time { return testNow }
+	return es, repo
+}
+
+func grant(typ models.EmergencyAccessType) *models.EmergencyAccess {
+	return &models.EmergencyAccess{Grantee: "bob", Type: typ, WaitPeriod: 48 * time.Hour, WrappedKey: "wrapped"}
+}
+
+func TestEmergencyService_GrantAccess(t *testing.T) {
+	tests := []struct {
+		name    string
+		access  *models.EmergencyAccess
+		wantErr error
+	}{
+		{
+			name:   "success",
+			access: grant(models.EmergencyAccessVIEW),
+		},
+		{
+			name:    "grant to self",
+			access:  &models.EmergencyAccess{Grantee: "alice", Type: models.EmergencyAccessVIEW, WaitPeriod: time.Hour, WrappedKey: "wrapped"},
+			wantErr: errs.ErrEmergencyWithSelf,
+		},
+		{
+			name:    "invalid type",
+			access:  &models.EmergencyAccess{Grantee: "bob", Type: "ADMIN", WaitPeriod: time.Hour, WrappedKey: "wrapped"},
+			wantErr: errs.ErrInvalidEmergencyAccessType,
+		},
+		{
+			name:    "no wait period",
+			access:  &models.EmergencyAccess{Grantee: "bob", Type: models.EmergencyAccessVIEW, WrappedKey: "wrapped"},
+			wantErr: errs.ErrInvalidWaitPeriod,
+		},
+		{
+			name:    "missing key",
+			access:  &models.EmergencyAccess{Grantee: "bob", Type: models.EmergencyAccessVIEW, WaitPeriod: time.Hour},
+			wantErr: errs.ErrRequiredArgumentIsMissing,
+		},
+		{
+			name:    "grantee without keys",
+			access:  &models.EmergencyAccess{Grantee: "carol", Type: models.EmergencyAccessVIEW, WaitPeriod: time.Hour, WrappedKey: "wrapped"},
+			wantErr: errs.ErrKeysNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			es, repo := newTestService(t)
+
+			err := es.GrantAccess(context.Background(), "alice", tt.access)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Empty(t, repo.access)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, repo.access, 1)
+			}
+		})
+	}
+}
+
+func TestEmergencyService_WaitPeriod(t *testing.T) {
+	es, _ := newTestService(t)
+	ctx := context.Background()
+	require.NoError(t, es.GrantAccess(ctx, "alice", grant(models.EmergencyAccessVIEW)))
+
+	_, err := es.GetVault(ctx, "bob", "alice")
+	assert.ErrorIs(t, err, errs.ErrEmergencyNotRequested)
+
+	require.NoError(t, es.RequestAccess(ctx, "bob", "alice"))
+	assert.ErrorIs(t, es.RequestAccess(ctx, "bob", "alice"), errs.ErrEmergencyAlreadyRequested)
+
+	_, err = es.GetVault(ctx, "bob", "alice")
+	assert.ErrorIs(t, err, errs.ErrEmergencyAccessPending)
+
+	// The grantor denies, the contact has to request again.
+	require.NoError(t, es.DenyAccess(ctx, "alice", "bob"))
+	_, err = es.GetVault(ctx, "bob", "alice")
+	assert.ErrorIs(t, err, errs.ErrEmergencyNotRequested)
+
+	require.NoError(t, es.RequestAccess(ctx, "bob", "alice"))
+	es.now = func() time.Time { return testNow.Add(48 * time.Hour) }
+	vault, err := es.GetVault(ctx, "bob", "alice")
+	require.NoError(t, err)
+	assert.Equal(t, "salt-alice", vault.Salt)
+	assert.Equal(t, "wrapped", vault.Access.WrappedKey)
+	assert.Len(t, vault.Items, 1)
+	assert.Len(t, vault.Templates, 1)
+
+	_, trustedBy, err := es.ListAccess(ctx, "bob")
+	require.NoError(t, err)
+	require.Len(t, trustedBy, 1)
+	assert.Empty(t, trustedBy[0].WrappedKey)
+
+	_, err = es.GetVault(ctx, "carol", "alice")
+	assert.ErrorIs(t, err, errs.ErrEmergencyAccessNotFound)
+}
+
+func TestEmergencyService_Takeover(t *testing.T) {
+	es, repo := newTestService(t)
+	ctx := context.Background()
+	takeover := &models.EmergencyTakeover{Grantor: "alice", Password: "new-pass"}
+
+	require.NoError(t, es.GrantAccess(ctx, "alice", grant(models.EmergencyAccessVIEW)))
+	require.NoError(t, es.RequestAccess(ctx, "bob", "alice"))
+	require.NoError(t, es.ApproveAccess(ctx, "alice", "bob"))
+	assert.ErrorIs(t, es.Takeover(ctx, "bob", takeover), errs.ErrEmergencyTakeoverNotAllowed)
+	assert.Nil(t, repo.takeover)
+
+	require.NoError(t, es.GrantAccess(ctx, "alice", grant(models.EmergencyAccessTAKEOVER)))
+	require.NoError(t, es.RequestAccess(ctx, "bob", "alice"))
+	assert.ErrorIs(t, es.Takeover(ctx, "bob", takeover), errs.ErrEmergencyAccessPending)
+
+	require.NoError(t, es.ApproveAccess(ctx, "alice", "bob"))
+	require.NoError(t, es.Takeover(ctx, "bob", takeover))
+	assert.Equal(t, takeover, repo.takeover)
+	assert.Equal(t, []byte("hash:new-pass"), repo.hash)
+}
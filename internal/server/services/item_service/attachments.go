@@ -0,0 +1,85 @@
+package item_service
+
+import (
+	"context"
+	"gophkeeper/internal/errs"
+	"gophkeeper/internal/telemetry"
+	"gophkeeper/models"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// gcmTagSize is what encryption adds to the attachment content.
+const gcmTagSize = 16
+
+// AddAttachment stores an encrypted file of the item. Attachments of
+// collection items require a role that can write items.
+func (is *ItemService) AddAttachment(ctx context.Context, login string, a *models.Attachment) (_ [16]byte, err error) {
+	ctx, span := tracer.Start(ctx, "ItemService.AddAttachment", trace.WithAttributes(attribute.Int64("attachment.size", a.Size)))
+	defer telemetry.End(span, &err)
+
+	if len(a.Content) == 0 || len(a.Nonce) == 0 || a.EncryptedInfo.EncryptedContent == "" {
+		return [16]byte{}, errs.ErrRequiredArgumentIsMissing
+	}
+	if a.Size > models.MaxAttachmentSize || len(a.Content) > models.MaxAttachmentSize+gcmTagSize {
+		return [16]byte{}, errs.ErrAttachmentTooLarge
+	}
+	if err := is.checkItemAccess(ctx, login, a.ItemID, models.OrgRole.CanWriteItems); err != nil {
+		return [16]byte{}, err
+	}
+	return is.repo.AddAttachment(ctx, a)
+}
+
+// ListAttachments returns the attachments of the item without their content.
+func (is *ItemService) ListAttachments(ctx context.Context, login string, itemID [16]byte) (_ []models.Attachment, err error) {
+	ctx, span := tracer.Start(ctx, "ItemService.ListAttachments")
+	defer telemetry.End(span, &err)
+
+	if err := is.checkItemAccess(ctx, login, itemID, models.OrgRole.CanReadItems); err != nil {
+		return nil, err
+	}
+	return is.repo.ListAttachments(ctx, itemID)
+}
+
+func (is *ItemService) GetAttachment(ctx context.Context, login string, itemID, attachmentID [16]byte) (_ *models.Attachment, err error) {
+	ctx, span := tracer.Start(ctx, "ItemService.GetAttachment")
+	defer telemetry.End(span, &err)
+
+	if err := is.checkItemAccess(ctx, login, itemID, models.OrgRole.CanReadItems); err != nil {
+		return nil, err
+	}
+	return is.repo.GetAttachment(ctx, itemID, attachmentID)
+}
+
+func (is *ItemService) DeleteAttachment(ctx context.Context, login string, itemID, attachmentID [16]byte) (err error) {
+	ctx, span := tracer.Start(ctx, "ItemService.DeleteAttachment")
+	defer telemetry.End(span, &err)
+
+	if err := is.checkItemAccess(ctx, login, itemID, models.OrgRole.CanWriteItems); err != nil {
+		return err
+	}
+	return is.repo.DeleteAttachment(ctx, itemID, attachmentID)
+}
+
+// checkItemAccess allows the owner of a personal item and organization
+// members with an allowed role for a collection item. Items of other users
+// are reported as not found.
+func (is *ItemService) checkItemAccess(ctx context.Context, login string, itemID [16]byte, allowed func(models.OrgRole) bool) error {
+	collectionID, err := is.repo.GetItemCollection(ctx, itemID)
+	if err != nil {
+		return err
+	}
+	if collectionID != ([16]byte{}) {
+		return is.checkCollectionRole(ctx, login, collectionID, allowed)
+	}
+
+	owner, err := is.repo.GetItemOwner(ctx, itemID)
+	if err != nil {
+		return err
+	}
+	if owner != login {
+		return errs.ErrItemNotFound
+	}
+	return nil
+}
@@ -3,10 +3,18 @@ package item_service
 import (
 	"context"
 	"fmt"
+	"gophkeeper/internal/errs"
 	"gophkeeper/internal/server/repositories"
+	"gophkeeper/internal/telemetry"
 	"gophkeeper/models"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
+var tracer = telemetry.Tracer("gophkeeper/internal/server/services/item_service")
+
 type ItemService struct {
 	repo repositories.Storage
 }
@@ -15,9 +23,11 @@ func NewItemService(repo repositories.Storage) (*ItemService, error) {
 	return &ItemService{repo: repo}, nil
 }
 
-func (is *ItemService) GetUserItems(ctx context.Context, typ models.ItemType, login string) ([]models.EncryptedItem, error) {
+func (is *ItemService) GetUserItems(ctx context.Context, typ models.ItemType, login string) (_ []models.EncryptedItem, err error) {
+	ctx, span := tracer.Start(ctx, "ItemService.GetUserItems", trace.WithAttributes(attribute.String("item.type", string(typ))))
+	defer telemetry.End(span, &err)
+
 	var sl []models.EncryptedItem
-	var err error
 	if typ != models.ItemTypeUNSPECIFIED {
 		sl, err = is.repo.GetUserItemsWithType(ctx, typ, login)
 	} else {
@@ -27,9 +37,39 @@ func (is *ItemService) GetUserItems(ctx context.Context, typ models.ItemType, lo
 		return nil, fmt.Errorf("failed to get %s from db for %s: %w", typ, login, err)
 	}
 
+	sl = liveItems(sl, time.Now())
+	span.SetAttributes(attribute.Int("item.count", len(sl)))
 	return sl, nil
 }
 
+// SearchItems returns personal items whose blind index has all the tokens.
+// The tokens are opaque, the server only compares them.
+func (is *ItemService) SearchItems(ctx context.Context, login string, tokens []string) (_ []models.EncryptedItem, err error) {
+	ctx, span := tracer.Start(ctx, "ItemService.SearchItems", trace.WithAttributes(attribute.Int("search.tokens", len(tokens))))
+	defer telemetry.End(span, &err)
+
+	if len(tokens) == 0 {
+		return nil, errs.ErrRequiredArgumentIsMissing
+	}
+
+	items, err := is.repo.SearchUserItems(ctx, login, tokens)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search items from db for %s: %w", login, err)
+	}
+	return liveItems(items, time.Now()), nil
+}
+
+// liveItems drops items that expired but were not deleted by the reaper yet.
+func liveItems(items []models.EncryptedItem, now time.Time) []models.EncryptedItem {
+	live := make([]models.EncryptedItem, 0, len(items))
+	for _, item := range items {
+		if !item.Expired(now) {
+			live = append(live, item)
+		}
+	}
+	return live
+}
+
 func (is *ItemService) GetTypesCounts(ctx context.Context, login string) (map[models.ItemType]int32, error) {
 	typesCount, err := is.repo.GetTypesCounts(ctx, login)
 	if err != nil {
@@ -49,14 +89,105 @@ func (is *ItemService) GetTypesCounts(ctx context.Context, login string) (map[mo
 	return typesCount, nil
 }
 
-func (is *ItemService) AddItem(ctx context.Context, item *models.EncryptedItem) error {
+// GetCollectionItems returns items of an organization collection. Any active
+// member of the organization may read them.
+func (is *ItemService) GetCollectionItems(ctx context.Context, typ models.ItemType, login string, collectionID [16]byte) ([]models.EncryptedItem, error) {
+	if err := is.checkCollectionRole(ctx, login, collectionID, models.OrgRole.CanReadItems); err != nil {
+		return nil, err
+	}
+
+	items, err := is.repo.GetCollectionItems(ctx, typ, collectionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s of collection from db for %s: %w", typ, login, err)
+	}
+	return items, nil
+}
+
+func (is *ItemService) GetCollectionTypesCounts(ctx context.Context, login string, collectionID [16]byte) (map[models.ItemType]int32, error) {
+	if err := is.checkCollectionRole(ctx, login, collectionID, models.OrgRole.CanReadItems); err != nil {
+		return nil, err
+	}
+
+	typesCount, err := is.repo.GetCollectionTypesCounts(ctx, collectionID)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range models.ItemTypes {
+		if _, ok := typesCount[t]; !ok {
+			typesCount[t] = int32(0)
+		}
+	}
+	return typesCount, nil
+}
+
+// AddItem stores the item. Items added to a collection require a role that
+// can write items, item.UserLogin is the author.
+func (is *ItemService) AddItem(ctx context.Context, item *models.EncryptedItem) (err error) {
+	ctx, span := tracer.Start(ctx, "ItemService.AddItem", trace.WithAttributes(attribute.String("item.type", string(item.Type))))
+	defer telemetry.End(span, &err)
+
+	if item.CollectionID != ([16]byte{}) {
+		if err := is.checkCollectionRole(ctx, item.UserLogin, item.CollectionID, models.OrgRole.CanWriteItems); err != nil {
+			return err
+		}
+	}
 	return is.repo.AddItem(ctx, item)
 }
 
-func (is *ItemService) EditItem(ctx context.Context, item *models.EncryptedItem) error {
+// EditItem updates the item. The collection and the owner are taken from
+// the stored item, so a client cannot bypass the checks by omitting them.
+func (is *ItemService) EditItem(ctx context.Context, item *models.EncryptedItem) (err error) {
+	ctx, span := tracer.Start(ctx, "ItemService.EditItem", trace.WithAttributes(attribute.String("item.type", string(item.Type))))
+	defer telemetry.End(span, &err)
+
+	if err := is.checkItemAccess(ctx, item.UserLogin, item.ID, models.OrgRole.CanWriteItems); err != nil {
+		return err
+	}
 	return is.repo.EditItem(ctx, item)
 }
 
-func (is *ItemService) DeleteItem(ctx context.Context, login string, itemID [16]byte) error {
-	return is.repo.DeleteItem(ctx, login, itemID)
+func (is *ItemService) DeleteItem(ctx context.Context, login string, itemID [16]byte) (err error) {
+	ctx, span := tracer.Start(ctx, "ItemService.DeleteItem")
+	defer telemetry.End(span, &err)
+
+	collectionID, err := is.repo.GetItemCollection(ctx, itemID)
+	if err != nil {
+		return err
+	}
+	if collectionID == ([16]byte{}) {
+		return is.repo.DeleteItem(ctx, login, itemID)
+	}
+
+	if err := is.checkCollectionRole(ctx, login, collectionID, models.OrgRole.CanWriteItems); err != nil {
+		return err
+	}
+	return is.repo.DeleteCollectionItem(ctx, collectionID, itemID)
+}
+
+// TouchItem records that the owner opened a personal item. Usage of
+// collection items is not tracked, it would be shared by all members.
+func (is *ItemService) TouchItem(ctx context.Context, login string, itemID [16]byte) (err error) {
+	ctx, span := tracer.Start(ctx, "ItemService.TouchItem")
+	defer telemetry.End(span, &err)
+
+	return is.repo.TouchItem(ctx, login, itemID)
+}
+
+// SetItemFavorite pins a personal item to the top of the owner's list.
+func (is *ItemService) SetItemFavorite(ctx context.Context, login string, itemID [16]byte, favorite bool) (err error) {
+	ctx, span := tracer.Start(ctx, "ItemService.SetItemFavorite")
+	defer telemetry.End(span, &err)
+
+	return is.repo.SetItemFavorite(ctx, login, itemID, favorite)
+}
+
+func (is *ItemService) checkCollectionRole(ctx context.Context, login string, collectionID [16]byte, allowed func(models.OrgRole) bool) error {
+	role, err := is.repo.GetCollectionRole(ctx, collectionID, login)
+	if err != nil {
+		return err
+	}
+	if !allowed(role) {
+		return errs.ErrOrgPermissionDenied
+	}
+	return nil
 }
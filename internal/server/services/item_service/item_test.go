@@ -3,8 +3,11 @@ package item_service
 import (
 	"context"
 	"errors"
+	"slices"
 	"testing"
+	"time"
 
+	"gophkeeper/internal/errs"
 	"gophkeeper/models"
 
 	"github.com/stretchr/testify/assert"
@@ -15,6 +18,14 @@ type MockStorage struct {
 	shouldFail bool
 	items      []models.EncryptedItem
 	counts     map[models.ItemType]int32
+
+	collections map[[16]byte][16]byte
+	roles       map[string]models.OrgRole
+	deleted     [16]byte
+	expired     int64
+
+	attachments []models.Attachment
+	templates   []models.Template
 }
 
 func (m *MockStorage) SignUpUser(ctx context.Context, user *models.User) error { return nil }
@@ -42,6 +53,28 @@ func (m *MockStorage) GetUserItemsWithType(ctx context.Context, typ models.ItemT
 	return filtered, nil
 }
 
+func (m *MockStorage) SearchUserItems(ctx context.Context, login string, tokens []string) ([]models.EncryptedItem, error) {
+	if m.shouldFail {
+		return nil, errors.New("storage error")
+	}
+	var found []models.EncryptedItem
+	for _, item := range m.items {
+		if hasAllTokens(item.SearchTokens, tokens) {
+			found = append(found, item)
+		}
+	}
+	return found, nil
+}
+
+func hasAllTokens(have, want []string) bool {
+	for _, w := range want {
+		if !slices.Contains(have, w) {
+			return false
+		}
+	}
+	return true
+}
+
 func (m *MockStorage) AddItem(ctx context.Context, item *models.EncryptedItem) error {
 	if m.shouldFail {
 		return errors.New("storage error")
@@ -63,12 +96,256 @@ func (m *MockStorage) DeleteItem(ctx context.Context, login string, itemID [16]b
 	return nil
 }
 
+func (m *MockStorage) RegisterDevice(ctx context.Context, device *models.Device) (*models.Device, error) {
+	return device, nil
+}
+func (m *MockStorage) GetDevice(ctx context.Context, login string, id [16]byte) (*models.Device, error) {
+	return nil, nil
+}
+func (m *MockStorage) ListDevices(ctx context.Context, login string) ([]models.Device, error) {
+	return nil, nil
+}
+func (m *MockStorage) RemoveDevice(ctx context.Context, login string, id [16]byte) error  { return nil }
+func (m *MockStorage) ApproveDevice(ctx context.Context, login string, id [16]byte) error { return nil }
+func (m *MockStorage) TouchDevice(ctx context.Context, login string, id [16]byte, ip string) error {
+	return nil
+}
+func (m *MockStorage) UseDeviceSignIn(ctx context.Context, login string, id [16]byte, signedAt time.Time) (bool, error) {
+	return true, nil
+}
+func (m *MockStorage) GetDeviceApprovalRequired(ctx context.Context, login string) (bool, error) {
+	return false, nil
+}
+func (m *MockStorage) SetDeviceApprovalRequired(ctx context.Context, login string, required bool) error {
+	return nil
+}
+
+func (m *MockStorage) Start(ctx context.Context) error { return nil }
+func (m *MockStorage) Stop(ctx context.Context) error  { return nil }
+func (m *MockStorage) Close() error                    { return nil }
+
+func (m *MockStorage) DeleteExpiredItems(ctx context.Context) (int64, error) {
+	if m.shouldFail {
+		return 0, errors.New("storage error")
+	}
+	return m.expired, nil
+}
+
 func (m *MockStorage) GetTypesCounts(ctx context.Context, login string) (map[models.ItemType]int32, error) {
 	if m.shouldFail {
 		return nil, errors.New("storage error")
 	}
 	return m.counts, nil
 }
+func (m *MockStorage) ListUsersStats(ctx context.Context) ([]models.UserStats, error) {
+	return nil, nil
+}
+func (m *MockStorage) SetUserLocked(ctx context.Context, login string, locked bool) error {
+	return nil
+}
+func (m *MockStorage) MarkUserDeleted(ctx context.Context, login string) error    { return nil }
+func (m *MockStorage) RevokeUserSessions(ctx context.Context, login string) error { return nil }
+func (m *MockStorage) RevokeAllSessions(ctx context.Context) (int64, error)       { return 0, nil }
+func (m *MockStorage) PurgeDeletedUsers(ctx context.Context) (int64, error)       { return 0, nil }
+func (m *MockStorage) GetServerStats(ctx context.Context) (*models.ServerStats, error) {
+	return nil, nil
+}
+func (m *MockStorage) ListMigrations(ctx context.Context) ([]models.Migration, error) {
+	return nil, nil
+}
+func (m *MockStorage) Migrate(ctx context.Context) ([]string, error)                { return nil, nil }
+func (m *MockStorage) AddUserKeys(ctx context.Context, keys *models.UserKeys) error { return nil }
+func (m *MockStorage) GetUserKeys(ctx context.Context, login string) (*models.UserKeys, error) {
+	return nil, nil
+}
+func (m *MockStorage) GetItemOwner(ctx context.Context, itemID [16]byte) (string, error) {
+	for _, item := range m.items {
+		if item.ID == itemID {
+			return item.UserLogin, nil
+		}
+	}
+	return "", errs.ErrItemNotFound
+}
+func (m *MockStorage) ShareItem(ctx context.Context, share *models.ItemShare) error { return nil }
+func (m *MockStorage) ListItemShares(ctx context.Context, itemID [16]byte) ([]models.ItemShare, error) {
+	return nil, nil
+}
+func (m *MockStorage) ListSharedWithUser(ctx context.Context, login string) ([]models.SharedItem, error) {
+	return nil, nil
+}
+func (m *MockStorage) RevokeShare(ctx context.Context, owner string, rev *models.ShareRevocation) error {
+	return nil
+}
+func (m *MockStorage) GetCollectionItems(ctx context.Context, typ models.ItemType, collectionID [16]byte) ([]models.EncryptedItem, error) {
+	return m.items, nil
+}
+func (m *MockStorage) GetCollectionTypesCounts(ctx context.Context, collectionID [16]byte) (map[models.ItemType]int32, error) {
+	return map[models.ItemType]int32{models.ItemTypeTEXT: 1}, nil
+}
+func (m *MockStorage) GetItemCollection(ctx context.Context, itemID [16]byte) ([16]byte, error) {
+	return m.collections[itemID], nil
+}
+func (m *MockStorage) DeleteCollectionItem(ctx context.Context, collectionID, itemID [16]byte) error {
+	m.deleted = itemID
+	return nil
+}
+func (m *MockStorage) TouchItem(ctx context.Context, login string, itemID [16]byte) error {
+	item := m.personalItem(login, itemID)
+	if item == nil {
+		return errs.ErrItemNotFound
+	}
+	item.LastUsedAt = time.Now()
+	item.UseCount++
+	return nil
+}
+func (m *MockStorage) SetItemFavorite(ctx context.Context, login string, itemID [16]byte, favorite bool) error {
+	item := m.personalItem(login, itemID)
+	if item == nil {
+		return errs.ErrItemNotFound
+	}
+	item.Favorite = favorite
+	return nil
+}
+func (m *MockStorage) AddAttachment(ctx context.Context, a *models.Attachment) ([16]byte, error) {
+	a.ID = [16]byte{byte(len(m.attachments) + 1)}
+	m.attachments = append(m.attachments, *a)
+	return a.ID, nil
+}
+func (m *MockStorage) ListAttachments(ctx context.Context, itemID [16]byte) ([]models.Attachment, error) {
+	var list []models.Attachment
+	for _, a := range m.attachments {
+		if a.ItemID == itemID {
+			a.Content, a.Nonce = nil, nil
+			list = append(list, a)
+		}
+	}
+	return list, nil
+}
+func (m *MockStorage) GetAttachment(ctx context.Context, itemID, attachmentID [16]byte) (*models.Attachment, error) {
+	for _, a := range m.attachments {
+		if a.ItemID == itemID && a.ID == attachmentID {
+			return &a, nil
+		}
+	}
+	return nil, errs.ErrAttachmentNotFound
+}
+func (m *MockStorage) DeleteAttachment(ctx context.Context, itemID, attachmentID [16]byte) error {
+	for i, a := range m.attachments {
+		if a.ItemID == itemID && a.ID == attachmentID {
+			m.attachments = append(m.attachments[:i], m.attachments[i+1:]...)
+			return nil
+		}
+	}
+	return errs.ErrAttachmentNotFound
+}
+func (m *MockStorage) SaveTemplate(ctx context.Context, t *models.Template) error {
+	for i := range m.templates {
+		if m.templates[i].UserLogin == t.UserLogin && m.templates[i].ID == t.ID {
+			m.templates[i] = *t
+			return nil
+		}
+	}
+	m.templates = append(m.templates, *t)
+	return nil
+}
+func (m *MockStorage) ListTemplates(ctx context.Context, login string) ([]models.Template, error) {
+	var list []models.Template
+	for _, t := range m.templates {
+		if t.UserLogin == login {
+			list = append(list, t)
+		}
+	}
+	return list, nil
+}
+func (m *MockStorage) DeleteTemplate(ctx context.Context, login string, id [16]byte) error {
+	for i, t := range m.templates {
+		if t.UserLogin == login && t.ID == id {
+			m.templates = append(m.templates[:i], m.templates[i+1:]...)
+			return nil
+		}
+	}
+	return errs.ErrTemplateNotFound
+}
+func (m *MockStorage) personalItem(login string, itemID [16]byte) *models.EncryptedItem {
+	for i := range m.items {
+		if m.items[i].ID == itemID && m.items[i].UserLogin == login && m.items[i].CollectionID == [16]byte{} {
+			return &m.items[i]
+		}
+	}
+	return nil
+}
+func (m *MockStorage) CreateOrganization(ctx context.Context, org *models.Organization, coll *models.Collection) error {
+	return nil
+}
+func (m *MockStorage) ListUserOrganizations(ctx context.Context, login string) ([]models.Organization, error) {
+	return nil, nil
+}
+func (m *MockStorage) GetMembership(ctx context.Context, orgID [16]byte, login string) (*models.Membership, error) {
+	return nil, nil
+}
+func (m *MockStorage) AddMembership(ctx context.Context, ms *models.Membership) error { return nil }
+func (m *MockStorage) AcceptMembership(ctx context.Context, orgID [16]byte, login string) error {
+	return nil
+}
+func (m *MockStorage) UpdateMembershipRole(ctx context.Context, orgID [16]byte, login string, role models.OrgRole) error {
+	return nil
+}
+func (m *MockStorage) ListMemberships(ctx context.Context, orgID [16]byte) ([]models.Membership, error) {
+	return nil, nil
+}
+func (m *MockStorage) LeaveOrganization(ctx context.Context, orgID [16]byte, login string) error {
+	return nil
+}
+func (m *MockStorage) RotateOrgKey(ctx context.Context, rot *models.OrgKeyRotation) error { return nil }
+func (m *MockStorage) CreateCollection(ctx context.Context, coll *models.Collection) error {
+	return nil
+}
+func (m *MockStorage) ListCollections(ctx context.Context, orgID [16]byte) ([]models.Collection, error) {
+	return nil, nil
+}
+func (m *MockStorage) GetCollectionRole(ctx context.Context, collectionID [16]byte, login string) (models.OrgRole, error) {
+	role, ok := m.roles[login]
+	if !ok {
+		return "", errs.ErrNotOrgMember
+	}
+	return role, nil
+}
+func (m *MockStorage) UpsertEmergencyAccess(ctx context.Context, access *models.EmergencyAccess) error {
+	return nil
+}
+func (m *MockStorage) GetEmergencyAccess(ctx context.Context, grantor, grantee string) (*models.EmergencyAccess, error) {
+	return nil, nil
+}
+func (m *MockStorage) ListEmergencyAccessByGrantor(ctx context.Context, grantor string) ([]models.EmergencyAccess, error) {
+	return nil, nil
+}
+func (m *MockStorage) ListEmergencyAccessByGrantee(ctx context.Context, grantee string) ([]models.EmergencyAccess, error) {
+	return nil, nil
+}
+func (m *MockStorage) RequestEmergencyAccess(ctx context.Context, grantor, grantee string) error {
+	return nil
+}
+func (m *MockStorage) ApproveEmergencyAccess(ctx context.Context, grantor, grantee string) error {
+	return nil
+}
+func (m *MockStorage) DenyEmergencyAccess(ctx context.Context, grantor, grantee string) error {
+	return nil
+}
+func (m *MockStorage) DeleteEmergencyAccess(ctx context.Context, grantor, grantee string) error {
+	return nil
+}
+func (m *MockStorage) TakeoverAccount(ctx context.Context, t *models.EmergencyTakeover, passwordHash []byte) error {
+	return nil
+}
+func (m *MockStorage) CreateSend(ctx context.Context, send *models.Send) ([16]byte, error) {
+	return [16]byte{}, nil
+}
+func (m *MockStorage) OpenSend(ctx context.Context, id [16]byte) (*models.Send, error) {
+	return nil, nil
+}
+func (m *MockStorage) DeleteExpiredSends(ctx context.Context) (int64, error) {
+	return 0, nil
+}
 
 func TestNewItemService(t *testing.T) {
 	repo := &MockStorage{}
@@ -137,6 +414,96 @@ func TestItemService_GetUserItems(t *testing.T) {
 	}
 }
 
+func TestItemService_GetUserItems_HidesExpired(t *testing.T) {
+	now := time.Now()
+	repo := &MockStorage{items: []models.EncryptedItem{
+		{Name: "forever", Type: models.ItemTypeTEXT},
+		{Name: "later", Type: models.ItemTypeTEXT, ExpiresAt: now.Add(time.Hour)},
+		{Name: "gone", Type: models.ItemTypeTEXT, ExpiresAt: now.Add(-time.Second)},
+	}}
+	service, _ := NewItemService(repo)
+
+	items, err := service.GetUserItems(context.Background(), models.ItemTypeUNSPECIFIED, "alice")
+	assert.NoError(t, err)
+	assert.Len(t, items, 2)
+	for _, item := range items {
+		assert.NotEqual(t, "gone", item.Name)
+	}
+	assert.Len(t, repo.items, 3)
+}
+
+func TestItemService_ReapExpiredItems(t *testing.T) {
+	service, _ := NewItemService(&MockStorage{expired: 2})
+	assert.Equal(t, int64(2), service.ReapExpiredItems(context.Background()))
+
+	service, _ = NewItemService(&MockStorage{shouldFail: true})
+	assert.Equal(t, int64(0), service.ReapExpiredItems(context.Background()))
+}
+
+func TestItemService_RunReaper_StopsOnCancel(t *testing.T) {
+	service, _ := NewItemService(&MockStorage{expired: 1})
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		service.RunReaper(ctx, time.Millisecond)
+		close(done)
+	}()
+
+	time.Sleep(5 * time.Millisecond)
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("reaper did not stop")
+	}
+}
+
+func TestReaper_StartStop(t *testing.T) {
+	service, _ := NewItemService(&MockStorage{expired: 1})
+	r := service.NewReaper(time.Millisecond)
+	assert.NoError(t, r.Stop(context.Background()))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	assert.NoError(t, r.Start(ctx))
+	cancel()
+	time.Sleep(5 * time.Millisecond)
+	select {
+	case <-r.done:
+		t.Fatal("reaper stopped with the start context")
+	default:
+	}
+
+	stopCtx, stopCancel := context.WithTimeout(context.Background(), time.Second)
+	defer stopCancel()
+	assert.NoError(t, r.Stop(stopCtx))
+}
+
+func TestItemService_SearchItems(t *testing.T) {
+	repo := &MockStorage{items: []models.EncryptedItem{
+		{ID: [16]byte{1}, SearchTokens: []string{"bank", "work"}},
+		{ID: [16]byte{2}, SearchTokens: []string{"bank"}},
+		{ID: [16]byte{3}, SearchTokens: []string{"mail"}},
+	}}
+	service, err := NewItemService(repo)
+	assert.NoError(t, err)
+
+	items, err := service.SearchItems(context.Background(), "user", []string{"bank"})
+	assert.NoError(t, err)
+	assert.Len(t, items, 2)
+
+	items, err = service.SearchItems(context.Background(), "user", []string{"bank", "work"})
+	assert.NoError(t, err)
+	assert.Len(t, items, 1)
+	assert.Equal(t, [16]byte{1}, items[0].ID)
+
+	_, err = service.SearchItems(context.Background(), "user", nil)
+	assert.ErrorIs(t, err, errs.ErrRequiredArgumentIsMissing)
+
+	repo.shouldFail = true
+	_, err = service.SearchItems(context.Background(), "user", []string{"bank"})
+	assert.Error(t, err)
+}
+
 func TestItemService_GetTypesCounts(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -233,16 +600,20 @@ func TestItemService_EditItem(t *testing.T) {
 		{
 			name: "successful edit",
 			item: &models.EncryptedItem{
-				Name: "edited item",
-				Type: models.ItemTypeCREDENTIALS,
+				ID:        [16]byte{1},
+				UserLogin: "alice",
+				Name:      "edited item",
+				Type:      models.ItemTypeCREDENTIALS,
 			},
 			wantErr: false,
 		},
 		{
 			name: "storage error",
 			item: &models.EncryptedItem{
-				Name: "edited item",
-				Type: models.ItemTypeCREDENTIALS,
+				ID:        [16]byte{1},
+				UserLogin: "alice",
+				Name:      "edited item",
+				Type:      models.ItemTypeCREDENTIALS,
 			},
 			wantErr: true,
 		},
@@ -250,7 +621,10 @@ func TestItemService_EditItem(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			mockRepo := &MockStorage{shouldFail: tt.wantErr}
+			mockRepo := &MockStorage{
+				shouldFail: tt.wantErr,
+				items:      []models.EncryptedItem{{ID: [16]byte{1}, UserLogin: "alice"}},
+			}
 			service, err := NewItemService(mockRepo)
 			assert.NoError(t, err)
 
@@ -265,6 +639,25 @@ func TestItemService_EditItem(t *testing.T) {
 	}
 }
 
+func TestItemService_EditItem_OtherUser(t *testing.T) {
+	mockRepo := &MockStorage{
+		items: []models.EncryptedItem{{ID: [16]byte{1}, UserLogin: "alice", Name: "mail"}},
+	}
+	service, err := NewItemService(mockRepo)
+	assert.NoError(t, err)
+
+	err = service.EditItem(context.Background(), &models.EncryptedItem{
+		ID:           [16]byte{1},
+		UserLogin:    "bob",
+		Name:         "taken over",
+		EncryptedKey: "bob's key",
+	})
+	assert.ErrorIs(t, err, errs.ErrItemNotFound)
+
+	err = service.EditItem(context.Background(), &models.EncryptedItem{ID: [16]byte{2}, UserLogin: "bob"})
+	assert.ErrorIs(t, err, errs.ErrItemNotFound)
+}
+
 func TestItemService_DeleteItem(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -302,3 +695,151 @@ func TestItemService_DeleteItem(t *testing.T) {
 		})
 	}
 }
+
+func TestItemService_CollectionRoles(t *testing.T) {
+	collectionID := [16]byte{9}
+	itemID := [16]byte{1}
+	repo := &MockStorage{
+		items:       []models.EncryptedItem{{Name: "db password", CollectionID: collectionID}},
+		collections: map[[16]byte][16]byte{itemID: collectionID},
+		roles: map[string]models.OrgRole{
+			"owner":  models.OrgRoleOWNER,
+			"editor": models.OrgRoleEDITOR,
+			"viewer": models.OrgRoleVIEWER,
+		},
+	}
+	service, err := NewItemService(repo)
+	assert.NoError(t, err)
+	ctx := context.Background()
+
+	items, err := service.GetCollectionItems(ctx, models.ItemTypeUNSPECIFIED, "viewer", collectionID)
+	assert.NoError(t, err)
+	assert.Len(t, items, 1)
+	_, err = service.GetCollectionItems(ctx, models.ItemTypeUNSPECIFIED, "stranger", collectionID)
+	assert.ErrorIs(t, err, errs.ErrNotOrgMember)
+
+	counts, err := service.GetCollectionTypesCounts(ctx, "viewer", collectionID)
+	assert.NoError(t, err)
+	assert.Len(t, counts, len(models.ItemTypes))
+
+	newItem := func(login string) *models.EncryptedItem {
+		return &models.EncryptedItem{ID: itemID, UserLogin: login, Name: "db password", CollectionID: collectionID}
+	}
+	assert.ErrorIs(t, service.AddItem(ctx, newItem("viewer")), errs.ErrOrgPermissionDenied)
+	assert.NoError(t, service.AddItem(ctx, newItem("editor")))
+
+	// The stored collection is checked even if the request omits it.
+	edit := newItem("viewer")
+	edit.CollectionID = [16]byte{}
+	assert.ErrorIs(t, service.EditItem(ctx, edit), errs.ErrOrgPermissionDenied)
+	assert.NoError(t, service.EditItem(ctx, newItem("editor")))
+
+	assert.ErrorIs(t, service.DeleteItem(ctx, "viewer", itemID), errs.ErrOrgPermissionDenied)
+	assert.Equal(t, [16]byte{}, repo.deleted)
+	assert.NoError(t, service.DeleteItem(ctx, "owner", itemID))
+	assert.Equal(t, itemID, repo.deleted)
+}
+
+func TestItemService_ItemUsage(t *testing.T) {
+	itemID := [16]byte{1}
+	repo := &MockStorage{
+		items: []models.EncryptedItem{{ID: itemID, UserLogin: "alice", Name: "mail"}},
+	}
+	service, err := NewItemService(repo)
+	assert.NoError(t, err)
+	ctx := context.Background()
+
+	assert.NoError(t, service.TouchItem(ctx, "alice", itemID))
+	assert.NoError(t, service.TouchItem(ctx, "alice", itemID))
+	assert.Equal(t, int32(2), repo.items[0].UseCount)
+	assert.False(t, repo.items[0].LastUsedAt.IsZero())
+
+	assert.NoError(t, service.SetItemFavorite(ctx, "alice", itemID, true))
+	assert.True(t, repo.items[0].Favorite)
+
+	assert.ErrorIs(t, service.TouchItem(ctx, "bob", itemID), errs.ErrItemNotFound)
+	assert.ErrorIs(t, service.SetItemFavorite(ctx, "bob", itemID, true), errs.ErrItemNotFound)
+}
+
+func TestItemService_Attachments(t *testing.T) {
+	itemID := [16]byte{1}
+	collectionItemID := [16]byte{2}
+	repo := &MockStorage{
+		items: []models.EncryptedItem{
+			{ID: itemID, UserLogin: "alice", Name: "mail"},
+			{ID: collectionItemID, UserLogin: "alice", Name: "db", CollectionID: [16]byte{9}},
+		},
+		collections: map[[16]byte][16]byte{collectionItemID: {9}},
+		roles:       map[string]models.OrgRole{"viewer": models.OrgRoleVIEWER, "editor": models.OrgRoleEDITOR},
+	}
+	service, err := NewItemService(repo)
+	assert.NoError(t, err)
+	ctx := context.Background()
+
+	newAttachment := func(itemID [16]byte) *models.Attachment {
+		return &models.Attachment{
+			ItemID:        itemID,
+			EncryptedInfo: models.EncryptedData{EncryptedContent: "info", Nonce: "n"},
+			Size:          3,
+			Content:       []byte("enc"),
+			Nonce:         []byte("nonce"),
+		}
+	}
+
+	id, err := service.AddAttachment(ctx, "alice", newAttachment(itemID))
+	assert.NoError(t, err)
+	_, err = service.AddAttachment(ctx, "bob", newAttachment(itemID))
+	assert.ErrorIs(t, err, errs.ErrItemNotFound)
+
+	large := newAttachment(itemID)
+	large.Size = models.MaxAttachmentSize + 1
+	_, err = service.AddAttachment(ctx, "alice", large)
+	assert.ErrorIs(t, err, errs.ErrAttachmentTooLarge)
+	_, err = service.AddAttachment(ctx, "alice", &models.Attachment{ItemID: itemID})
+	assert.ErrorIs(t, err, errs.ErrRequiredArgumentIsMissing)
+
+	list, err := service.ListAttachments(ctx, "alice", itemID)
+	assert.NoError(t, err)
+	assert.Len(t, list, 1)
+	a, err := service.GetAttachment(ctx, "alice", itemID, id)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("enc"), a.Content)
+	_, err = service.GetAttachment(ctx, "bob", itemID, id)
+	assert.ErrorIs(t, err, errs.ErrItemNotFound)
+
+	// Collection attachments follow the organization roles.
+	_, err = service.AddAttachment(ctx, "viewer", newAttachment(collectionItemID))
+	assert.ErrorIs(t, err, errs.ErrOrgPermissionDenied)
+	collID, err := service.AddAttachment(ctx, "editor", newAttachment(collectionItemID))
+	assert.NoError(t, err)
+	_, err = service.GetAttachment(ctx, "viewer", collectionItemID, collID)
+	assert.NoError(t, err)
+	assert.ErrorIs(t, service.DeleteAttachment(ctx, "viewer", collectionItemID, collID), errs.ErrOrgPermissionDenied)
+
+	assert.NoError(t, service.DeleteAttachment(ctx, "alice", itemID, id))
+	assert.ErrorIs(t, service.DeleteAttachment(ctx, "alice", itemID, id), errs.ErrAttachmentNotFound)
+}
+
+func TestItemService_Templates(t *testing.T) {
+	repo := &MockStorage{}
+	service, err := NewItemService(repo)
+	assert.NoError(t, err)
+	ctx := context.Background()
+
+	tmpl := &models.Template{ID: [16]byte{1}, EncryptedData: models.EncryptedData{EncryptedContent: "enc", Nonce: "n"}}
+	assert.NoError(t, service.SaveTemplate(ctx, "alice", tmpl))
+	assert.ErrorIs(t, service.SaveTemplate(ctx, "alice", &models.Template{ID: [16]byte{2}}), errs.ErrRequiredArgumentIsMissing)
+
+	// Saving again replaces the template.
+	assert.NoError(t, service.SaveTemplate(ctx, "alice", &models.Template{ID: [16]byte{1}, EncryptedData: models.EncryptedData{EncryptedContent: "v2", Nonce: "n"}}))
+	list, err := service.ListTemplates(ctx, "alice")
+	assert.NoError(t, err)
+	assert.Len(t, list, 1)
+	assert.Equal(t, "v2", list[0].EncryptedData.EncryptedContent)
+
+	list, err = service.ListTemplates(ctx, "bob")
+	assert.NoError(t, err)
+	assert.Empty(t, list)
+	assert.ErrorIs(t, service.DeleteTemplate(ctx, "bob", [16]byte{1}), errs.ErrTemplateNotFound)
+	assert.NoError(t, service.DeleteTemplate(ctx, "alice", [16]byte{1}))
+}
@@ -0,0 +1,76 @@
+package item_service
+
+import (
+	"context"
+	"gophkeeper/internal/logger"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// RunReaper deletes expired items every interval until ctx is done.
+func (is *ItemService) RunReaper(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			is.ReapExpiredItems(ctx)
+		}
+	}
+}
+
+// ReapExpiredItems deletes items past their expiry once.
+func (is *ItemService) ReapExpiredItems(ctx context.Context) int64 {
+	n, err := is.repo.DeleteExpiredItems(ctx)
+	if err != nil {
+		logger.Log.Error("Delete expired items error", zap.Error(err))
+		return 0
+	}
+	if n > 0 {
+		logger.Log.Info("Expired items deleted", zap.Int64("count", n))
+	}
+	return n
+}
+
+// Reaper runs RunReaper in the background as a lifecycle component.
+type Reaper struct {
+	is       *ItemService
+	interval time.Duration
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewReaper returns a reaper that deletes expired items every interval once
+// started.
+func (is *ItemService) NewReaper(interval time.Duration) *Reaper {
+	return &Reaper{is: is, interval: interval}
+}
+
+func (r *Reaper) Start(ctx context.Context) error {
+	ctx, r.cancel = context.WithCancel(context.WithoutCancel(ctx))
+	r.done = make(chan struct{})
+	go func() {
+		defer close(r.done)
+		r.is.RunReaper(ctx, r.interval)
+	}()
+	return nil
+}
+
+// Stop cancels a pass in progress and waits for the reaper to return.
+func (r *Reaper) Stop(ctx context.Context) error {
+	if r.cancel == nil {
+		return nil
+	}
+	r.cancel()
+	select {
+	case <-r.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,35 @@
+package item_service
+
+import (
+	"context"
+	"gophkeeper/internal/errs"
+	"gophkeeper/internal/telemetry"
+	"gophkeeper/models"
+)
+
+// SaveTemplate stores an item template of the user. Templates are
+// encrypted by the client, the server only keeps them per login.
+func (is *ItemService) SaveTemplate(ctx context.Context, login string, t *models.Template) (err error) {
+	ctx, span := tracer.Start(ctx, "ItemService.SaveTemplate")
+	defer telemetry.End(span, &err)
+
+	if t.ID == ([16]byte{}) || t.EncryptedData.EncryptedContent == "" || t.EncryptedData.Nonce == "" {
+		return errs.ErrRequiredArgumentIsMissing
+	}
+	t.UserLogin = login
+	return is.repo.SaveTemplate(ctx, t)
+}
+
+func (is *ItemService) ListTemplates(ctx context.Context, login string) (_ []models.Template, err error) {
+	ctx, span := tracer.Start(ctx, "ItemService.ListTemplates")
+	defer telemetry.End(span, &err)
+
+	return is.repo.ListTemplates(ctx, login)
+}
+
+func (is *ItemService) DeleteTemplate(ctx context.Context, login string, id [16]byte) (err error) {
+	ctx, span := tracer.Start(ctx, "ItemService.DeleteTemplate")
+	defer telemetry.End(span, &err)
+
+	return is.repo.DeleteTemplate(ctx, login, id)
+}
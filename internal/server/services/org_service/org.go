@@ -0,0 +1,186 @@
+package org_service
+
+import (
+	"context"
+	"fmt"
+	"gophkeeper/internal/errs"
+	"gophkeeper/internal/server/repositories"
+	"gophkeeper/models"
+)
+
+type OrgService struct {
+	repo repositories.Storage
+}
+
+func NewOrgService(repo repositories.Storage) (*OrgService, error) {
+	return &OrgService{repo: repo}, nil
+}
+
+// CreateOrg creates an organization owned by login. coll is an optional first
+// collection, its key must be sealed with the organization key.
+func (ors *OrgService) CreateOrg(ctx context.Context, login string, org *models.Organization, coll *models.Collection) error {
+	if org.Name == "" || org.WrappedKey == "" {
+		return errs.ErrRequiredArgumentIsMissing
+	}
+	if coll != nil && (coll.Name == "" || coll.EncryptedKey == "") {
+		return errs.ErrRequiredArgumentIsMissing
+	}
+
+	org.CreatedBy = login
+	if err := ors.repo.CreateOrganization(ctx, org, coll); err != nil {
+		return fmt.Errorf("create organization %s error: %w", org.Name, err)
+	}
+	return nil
+}
+
+func (ors *OrgService) ListOrgs(ctx context.Context, login string) ([]models.Organization, error) {
+	orgs, err := ors.repo.ListUserOrganizations(ctx, login)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get organizations of %s: %w", login, err)
+	}
+	return orgs, nil
+}
+
+// InviteMember adds a pending membership. The invite already carries the
+// organization key wrapped for the invitee. Only the owner can invite admins.
+func (ors *OrgService) InviteMember(ctx context.Context, actor string, m *models.Membership) error {
+	if m.Login == "" || m.WrappedKey == "" {
+		return errs.ErrRequiredArgumentIsMissing
+	}
+	if !m.Role.IsValid() || m.Role == models.OrgRoleOWNER {
+		return errs.ErrInvalidOrgRole
+	}
+
+	role, err := ors.activeRole(ctx, m.OrgID, actor)
+	if err != nil {
+		return err
+	}
+	if !role.CanManageMembers() || (m.Role == models.OrgRoleADMIN && role != models.OrgRoleOWNER) {
+		return errs.ErrOrgPermissionDenied
+	}
+	if _, err := ors.repo.GetUserKeys(ctx, m.Login); err != nil {
+		return err
+	}
+
+	m.InvitedBy = actor
+	m.Accepted = false
+	return ors.repo.AddMembership(ctx, m)
+}
+
+func (ors *OrgService) AcceptInvite(ctx context.Context, login string, orgID [16]byte) error {
+	return ors.repo.AcceptMembership(ctx, orgID, login)
+}
+
+// RemoveMember removes a member and rotates the organization key. Removing
+// others requires a managing role and removing admins requires the owner. The
+// owner cannot be removed. A member leaving on their own only deletes the
+// membership, the rotation they send is ignored since they know the new key,
+// and the owner or an admin has to rotate the key with RotateOrgKey.
+func (ors *OrgService) RemoveMember(ctx context.Context, actor string, rot *models.OrgKeyRotation) error {
+	target, err := ors.repo.GetMembership(ctx, rot.OrgID, rot.Login)
+	if err != nil {
+		return err
+	}
+	if target.Role == models.OrgRoleOWNER {
+		return errs.ErrOwnerImmutable
+	}
+
+	role, err := ors.activeRole(ctx, rot.OrgID, actor)
+	if err != nil {
+		return err
+	}
+	if actor == rot.Login {
+		return ors.repo.LeaveOrganization(ctx, rot.OrgID, actor)
+	}
+	if !role.CanManageMembers() || (target.Role == models.OrgRoleADMIN && role != models.OrgRoleOWNER) {
+		return errs.ErrOrgPermissionDenied
+	}
+
+	return ors.repo.RotateOrgKey(ctx, rot)
+}
+
+// RotateOrgKey replaces the organization key without removing anyone, after
+// a member left on their own. It requires a managing role.
+func (ors *OrgService) RotateOrgKey(ctx context.Context, actor string, rot *models.OrgKeyRotation) error {
+	role, err := ors.activeRole(ctx, rot.OrgID, actor)
+	if err != nil {
+		return err
+	}
+	if !role.CanManageMembers() {
+		return errs.ErrOrgPermissionDenied
+	}
+
+	rot.Login = ""
+	return ors.repo.RotateOrgKey(ctx, rot)
+}
+
+// ChangeRole changes the role of a member. Granting or taking away the admin
+// role requires the owner, ownership cannot be transferred this way.
+func (ors *OrgService) ChangeRole(ctx context.Context, actor string, orgID [16]byte, login string, newRole models.OrgRole) error {
+	if !newRole.IsValid() || newRole == models.OrgRoleOWNER {
+		return errs.ErrInvalidOrgRole
+	}
+
+	role, err := ors.activeRole(ctx, orgID, actor)
+	if err != nil {
+		return err
+	}
+	if !role.CanManageMembers() {
+		return errs.ErrOrgPermissionDenied
+	}
+
+	target, err := ors.repo.GetMembership(ctx, orgID, login)
+	if err != nil {
+		return err
+	}
+	if target.Role == models.OrgRoleOWNER {
+		return errs.ErrOwnerImmutable
+	}
+	if (newRole == models.OrgRoleADMIN || target.Role == models.OrgRoleADMIN) && role != models.OrgRoleOWNER {
+		return errs.ErrOrgPermissionDenied
+	}
+
+	return ors.repo.UpdateMembershipRole(ctx, orgID, login, newRole)
+}
+
+func (ors *OrgService) ListMembers(ctx context.Context, actor string, orgID [16]byte) ([]models.Membership, error) {
+	if _, err := ors.activeRole(ctx, orgID, actor); err != nil {
+		return nil, err
+	}
+	return ors.repo.ListMemberships(ctx, orgID)
+}
+
+func (ors *OrgService) CreateCollection(ctx context.Context, actor string, coll *models.Collection) error {
+	if coll.Name == "" || coll.EncryptedKey == "" {
+		return errs.ErrRequiredArgumentIsMissing
+	}
+
+	role, err := ors.activeRole(ctx, coll.OrgID, actor)
+	if err != nil {
+		return err
+	}
+	if !role.CanManageMembers() {
+		return errs.ErrOrgPermissionDenied
+	}
+	return ors.repo.CreateCollection(ctx, coll)
+}
+
+func (ors *OrgService) ListCollections(ctx context.Context, actor string, orgID [16]byte) ([]models.Collection, error) {
+	if _, err := ors.activeRole(ctx, orgID, actor); err != nil {
+		return nil, err
+	}
+	return ors.repo.ListCollections(ctx, orgID)
+}
+
+// activeRole returns the role of login in the organization. Pending invites
+// grant no rights.
+func (ors *OrgService) activeRole(ctx context.Context, orgID [16]byte, login string) (models.OrgRole, error) {
+	m, err := ors.repo.GetMembership(ctx, orgID, login)
+	if err != nil {
+		return "", err
+	}
+	if !m.Accepted {
+		return "", errs.ErrNotOrgMember
+	}
+	return m.Role, nil
+}
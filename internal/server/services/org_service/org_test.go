@@ -0,0 +1,235 @@
+package org_service
+
+import (
+	"context"
+	"testing"
+
+	"gophkeeper/internal/errs"
+	"gophkeeper/internal/server/repositories"
+	"gophkeeper/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testOrgID = [16]byte{1}
+
+// MockStorage implements organization methods of repositories.Storage for testing
+type MockStorage struct {
+	repositories.Storage
+
+	members map[string]*models.Membership
+	keys    map[string]bool
+	rotated *models.OrgKeyRotation
+	left    string
+}
+
+func (m *MockStorage) GetMembership(ctx context.Context, orgID [16]byte, login string) (*models.Membership, error) {
+	member, ok := m.members[login]
+	if !ok {
+		return nil, errs.ErrNotOrgMember
+	}
+	return member, nil
+}
+
+func (m *MockStorage) AddMembership(ctx context.Context, ms *models.Membership) error {
+	if _, ok := m.members[ms.Login]; ok {
+		return errs.ErrMemberAlreadyExists
+	}
+	m.members[ms.Login] = ms
+	return nil
+}
+
+func (m *MockStorage) UpdateMembershipRole(ctx context.Context, orgID [16]byte, login string, role models.OrgRole) error {
+	m.members[login].Role = role
+	return nil
+}
+
+func (m *MockStorage) RotateOrgKey(ctx context.Context, rot *models.OrgKeyRotation) error {
+	m.rotated = rot
+	delete(m.members, rot.Login)
+	return nil
+}
+
+func (m *MockStorage) LeaveOrganization(ctx context.Context, orgID [16]byte, login string) error {
+	m.left = login
+	delete(m.members, login)
+	return nil
+}
+
+func (m *MockStorage) GetUserKeys(ctx context.Context, login string) (*models.UserKeys, error) {
+	if !m.keys[login] {
+		return nil, errs.ErrKeysNotFound
+	}
+	return &models.UserKeys{Login: login}, nil
+}
+
+func newTestService(t *testing.T) (*OrgService, *MockStorage) {
+	t.Helper()
+	member := func(login string, role models.OrgRole, accepted bool) *models.Membership {
+		return &models.Membership{OrgID: testOrgID, Login: login, Role: role, Accepted: accepted}
+	}
+	repo := &MockStorage{
+		members: map[string]*models.Membership{
+			"owner":   member("owner", models.OrgRoleOWNER, true),
+			"admin":   member("admin", models.OrgRoleADMIN, true),
+			"editor":  member("editor", models.OrgRoleEDITOR, true),
+			"viewer":  member("viewer", models.OrgRoleVIEWER, true),
+			"invited": member("invited", models.OrgRoleEDITOR, false),
+		},
+		keys: map[string]bool{"dave": true, "editor": true},
+	}
+	ors, err := NewOrgService(repo)
+	require.NoError(t, err)
+	return ors, repo
+}
+
+func TestOrgService_CreateOrg(t *testing.T) {
+	ors, _ := newTestService(t)
+
+	err := ors.CreateOrg(context.Background(), "owner", &models.Organization{Name: "acme"}, nil)
+	assert.ErrorIs(t, err, errs.ErrRequiredArgumentIsMissing)
+
+	err = ors.CreateOrg(context.Background(), "owner", &models.Organization{Name: "acme", WrappedKey: "key"}, &models.Collection{Name: "Shared"})
+	assert.ErrorIs(t, err, errs.ErrRequiredArgumentIsMissing)
+}
+
+func TestOrgService_InviteMember(t *testing.T) {
+	tests := []struct {
+		name    string
+		actor   string
+		login   string
+		role    models.OrgRole
+		wantErr error
+	}{
+		{name: "admin invites editor", actor: "admin", login: "dave", role: models.OrgRoleEDITOR},
+		{name: "owner invites admin", actor: "owner", login: "dave", role: models.OrgRoleADMIN},
+		{name: "admin cannot invite admin", actor: "admin", login: "dave", role: models.OrgRoleADMIN, wantErr: errs.ErrOrgPermissionDenied},
+		{name: "editor cannot invite", actor: "editor", login: "dave", role: models.OrgRoleVIEWER, wantErr: errs.ErrOrgPermissionDenied},
+		{name: "pending invite grants nothing", actor: "invited", login: "dave", role: models.OrgRoleVIEWER, wantErr: errs.ErrNotOrgMember},
+		{name: "owner role cannot be granted", actor: "owner", login: "dave", role: models.OrgRoleOWNER, wantErr: errs.ErrInvalidOrgRole},
+		{name: "invitee without keys", actor: "owner", login: "frank", role: models.OrgRoleVIEWER, wantErr: errs.ErrKeysNotFound},
+		{name: "already a member", actor: "owner", login: "editor", role: models.OrgRoleVIEWER, wantErr: errs.ErrMemberAlreadyExists},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ors, repo := newTestService(t)
+
+			err := ors.InviteMember(context.Background(), tt.actor, &models.Membership{
+				OrgID:      testOrgID,
+				Login:      tt.login,
+				Role:       tt.role,
+				WrappedKey: "wrapped",
+			})
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.actor, repo.members[tt.login].InvitedBy)
+			assert.False(t, repo.members[tt.login].Accepted)
+		})
+	}
+}
+
+func TestOrgService_ChangeRole(t *testing.T) {
+	tests := []struct {
+		name    string
+		actor   string
+		login   string
+		role    models.OrgRole
+		wantErr error
+	}{
+		{name: "admin promotes viewer", actor: "admin", login: "viewer", role: models.OrgRoleEDITOR},
+		{name: "owner demotes admin", actor: "owner", login: "admin", role: models.OrgRoleEDITOR},
+		{name: "admin cannot grant admin", actor: "admin", login: "viewer", role: models.OrgRoleADMIN, wantErr: errs.ErrOrgPermissionDenied},
+		{name: "owner cannot be demoted", actor: "admin", login: "owner", role: models.OrgRoleEDITOR, wantErr: errs.ErrOwnerImmutable},
+		{name: "editor cannot change roles", actor: "editor", login: "viewer", role: models.OrgRoleEDITOR, wantErr: errs.ErrOrgPermissionDenied},
+		{name: "unknown role", actor: "owner", login: "viewer", role: "GUEST", wantErr: errs.ErrInvalidOrgRole},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ors, repo := newTestService(t)
+
+			err := ors.ChangeRole(context.Background(), tt.actor, testOrgID, tt.login, tt.role)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.role, repo.members[tt.login].Role)
+		})
+	}
+}
+
+func TestOrgService_RemoveMember(t *testing.T) {
+	tests := []struct {
+		name    string
+		actor   string
+		login   string
+		leaves  bool
+		wantErr error
+	}{
+		{name: "admin removes editor", actor: "admin", login: "editor"},
+		{name: "viewer leaves", actor: "viewer", login: "viewer", leaves: true},
+		{name: "admin leaves", actor: "admin", login: "admin", leaves: true},
+		{name: "owner cannot be removed", actor: "admin", login: "owner", wantErr: errs.ErrOwnerImmutable},
+		{name: "editor cannot remove others", actor: "editor", login: "viewer", wantErr: errs.ErrOrgPermissionDenied},
+		{name: "unknown member", actor: "owner", login: "dave", wantErr: errs.ErrNotOrgMember},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ors, repo := newTestService(t)
+
+			rot := &models.OrgKeyRotation{OrgID: testOrgID, Login: tt.login}
+			err := ors.RemoveMember(context.Background(), tt.actor, rot)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, repo.rotated)
+				return
+			}
+			require.NoError(t, err)
+			if tt.leaves {
+				// The leaving member knows the new key, only the membership goes.
+				assert.Equal(t, tt.login, repo.left)
+				assert.Nil(t, repo.rotated)
+				return
+			}
+			assert.Equal(t, rot, repo.rotated)
+		})
+	}
+}
+
+func TestOrgService_RotateOrgKey(t *testing.T) {
+	tests := []struct {
+		name    string
+		actor   string
+		wantErr error
+	}{
+		{name: "owner", actor: "owner"},
+		{name: "admin", actor: "admin"},
+		{name: "editor", actor: "editor", wantErr: errs.ErrOrgPermissionDenied},
+		{name: "pending invite", actor: "invited", wantErr: errs.ErrNotOrgMember},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ors, repo := newTestService(t)
+
+			rot := &models.OrgKeyRotation{OrgID: testOrgID, Login: "viewer"}
+			err := ors.RotateOrgKey(context.Background(), tt.actor, rot)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, repo.rotated)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, repo.rotated)
+			assert.Empty(t, repo.rotated.Login, "rotation never removes a member")
+			assert.Contains(t, repo.members, "viewer")
+		})
+	}
+}
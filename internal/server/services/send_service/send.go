@@ -0,0 +1,52 @@
+package send_service
+
+import (
+	"context"
+	"fmt"
+	"gophkeeper/internal/errs"
+	"gophkeeper/internal/server/repositories"
+	"gophkeeper/models"
+	"time"
+)
+
+const (
+	maxSendViews = 100
+	maxSendTTL   = 30 * 24 * time.Hour
+)
+
+type SendService struct {
+	repo repositories.Storage
+	now  func() time.Time
+}
+
+func NewSendService(repo repositories.Storage) (*SendService, error) {
+	return &SendService{
+		repo: repo,
+		now:  time.Now,
+	}, nil
+}
+
+// CreateSend stores the ciphertext of a one-time secret owned by login.
+func (ss *SendService) CreateSend(ctx context.Context, login string, send *models.Send) ([16]byte, error) {
+	now := ss.now()
+	switch {
+	case send.EncryptedData.EncryptedContent == "" || send.EncryptedData.Nonce == "":
+		return [16]byte{}, errs.ErrRequiredArgumentIsMissing
+	case send.MaxViews < 1 || send.MaxViews > maxSendViews:
+		return [16]byte{}, errs.ErrInvalidSendViews
+	case !send.ExpiresAt.After(now) || send.ExpiresAt.Sub(now) > maxSendTTL:
+		return [16]byte{}, errs.ErrInvalidSendExpiry
+	}
+
+	send.OwnerLogin = login
+	id, err := ss.repo.CreateSend(ctx, send)
+	if err != nil {
+		return [16]byte{}, fmt.Errorf("create send for %s error: %w", login, err)
+	}
+	return id, nil
+}
+
+// OpenSend spends one view of the send. It is burned after the last one.
+func (ss *SendService) OpenSend(ctx context.Context, id [16]byte) (*models.Send, error) {
+	return ss.repo.OpenSend(ctx, id)
+}
@@ -0,0 +1,98 @@
+package send_service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"gophkeeper/internal/errs"
+	"gophkeeper/internal/server/repositories"
+	"gophkeeper/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// MockStorage keeps sends in memory and burns them like the database.
+type MockStorage struct {
+	repositories.Storage
+
+	sends map[[16]byte]*models.Send
+	next  byte
+}
+
+func (m *MockStorage) CreateSend(ctx context.Context, send *models.Send) ([16]byte, error) {
+	if m.sends == nil {
+		m.sends = make(map[[16]byte]*models.Send)
+	}
+	m.next++
+	id := [16]byte{m.next}
+	stored := *send
+	stored.ID = id
+	m.sends[id] = &stored
+	return id, nil
+}
+
+func (m *MockStorage) OpenSend(ctx context.Context, id [16]byte) (*models.Send, error) {
+	send, ok := m.sends[id]
+	if !ok {
+		return nil, errs.ErrSendNotFound
+	}
+	send.Views++
+	if send.ViewsLeft() == 0 {
+		delete(m.sends, id)
+	}
+	opened := *send
+	return &opened, nil
+}
+
+func newSend(views int32, ttl time.Duration) *models.Send {
+	return &models.Send{
+		EncryptedData: models.EncryptedData{EncryptedContent: "content", Nonce: "nonce"},
+		MaxViews:      views,
+		ExpiresAt:     time.Now().Add(ttl),
+	}
+}
+
+func TestSendService_CreateSend_Validation(t *testing.T) {
+	ss, err := NewSendService(&MockStorage{})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	_, err = ss.CreateSend(ctx, "alice", &models.Send{MaxViews: 1, ExpiresAt: time.Now().Add(time.Hour)})
+	assert.ErrorIs(t, err, errs.ErrRequiredArgumentIsMissing)
+
+	_, err = ss.CreateSend(ctx, "alice", newSend(0, time.Hour))
+	assert.ErrorIs(t, err, errs.ErrInvalidSendViews)
+
+	_, err = ss.CreateSend(ctx, "alice", newSend(101, time.Hour))
+	assert.ErrorIs(t, err, errs.ErrInvalidSendViews)
+
+	_, err = ss.CreateSend(ctx, "alice", newSend(1, -time.Minute))
+	assert.ErrorIs(t, err, errs.ErrInvalidSendExpiry)
+
+	_, err = ss.CreateSend(ctx, "alice", newSend(1, 31*24*time.Hour))
+	assert.ErrorIs(t, err, errs.ErrInvalidSendExpiry)
+}
+
+func TestSendService_OpenSend_BurnsAfterLastView(t *testing.T) {
+	repo := &MockStorage{}
+	ss, err := NewSendService(repo)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	id, err := ss.CreateSend(ctx, "alice", newSend(2, time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, "alice", repo.sends[id].OwnerLogin)
+
+	send, err := ss.OpenSend(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), send.ViewsLeft())
+
+	send, err = ss.OpenSend(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, int32(0), send.ViewsLeft())
+
+	_, err = ss.OpenSend(ctx, id)
+	assert.ErrorIs(t, err, errs.ErrSendNotFound)
+}
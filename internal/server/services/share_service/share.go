@@ -0,0 +1,87 @@
+package share_service
+
+import (
+	"context"
+	"fmt"
+	"gophkeeper/internal/errs"
+	"gophkeeper/internal/server/repositories"
+	"gophkeeper/models"
+)
+
+type ShareService struct {
+	repo repositories.Storage
+}
+
+func NewShareService(repo repositories.Storage) (*ShareService, error) {
+	return &ShareService{repo: repo}, nil
+}
+
+func (ss *ShareService) SetUserKeys(ctx context.Context, keys *models.UserKeys) error {
+	if len(keys.PublicKey) == 0 || keys.EncryptedPrivateKey == "" {
+		return errs.ErrRequiredArgumentIsMissing
+	}
+	return ss.repo.AddUserKeys(ctx, keys)
+}
+
+func (ss *ShareService) GetUserKeys(ctx context.Context, login string) (*models.UserKeys, error) {
+	return ss.repo.GetUserKeys(ctx, login)
+}
+
+// GetPublicKey returns only the public part of the user's key pair.
+func (ss *ShareService) GetPublicKey(ctx context.Context, login string) ([]byte, error) {
+	keys, err := ss.repo.GetUserKeys(ctx, login)
+	if err != nil {
+		return nil, err
+	}
+	return keys.PublicKey, nil
+}
+
+func (ss *ShareService) ShareItem(ctx context.Context, owner string, share *models.ItemShare) error {
+	if share.RecipientLogin == owner {
+		return errs.ErrShareWithSelf
+	}
+	if err := ss.checkOwner(ctx, owner, share.ItemID); err != nil {
+		return err
+	}
+	if _, err := ss.repo.GetUserKeys(ctx, share.RecipientLogin); err != nil {
+		return err
+	}
+
+	if err := ss.repo.ShareItem(ctx, share); err != nil {
+		return fmt.Errorf("share item with %s error: %w", share.RecipientLogin, err)
+	}
+	return nil
+}
+
+func (ss *ShareService) ListItemShares(ctx context.Context, owner string, itemID [16]byte) ([]models.ItemShare, error) {
+	if err := ss.checkOwner(ctx, owner, itemID); err != nil {
+		return nil, err
+	}
+	return ss.repo.ListItemShares(ctx, itemID)
+}
+
+func (ss *ShareService) ListSharedWithMe(ctx context.Context, login string) ([]models.SharedItem, error) {
+	items, err := ss.repo.ListSharedWithUser(ctx, login)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get items shared with %s: %w", login, err)
+	}
+	return items, nil
+}
+
+func (ss *ShareService) RevokeShare(ctx context.Context, owner string, rev *models.ShareRevocation) error {
+	if err := ss.checkOwner(ctx, owner, rev.ItemID); err != nil {
+		return err
+	}
+	return ss.repo.RevokeShare(ctx, owner, rev)
+}
+
+func (ss *ShareService) checkOwner(ctx context.Context, login string, itemID [16]byte) error {
+	owner, err := ss.repo.GetItemOwner(ctx, itemID)
+	if err != nil {
+		return err
+	}
+	if owner != login {
+		return errs.ErrNotItemOwner
+	}
+	return nil
+}
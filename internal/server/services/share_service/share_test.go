@@ -0,0 +1,166 @@
+package share_service
+
+import (
+	"context"
+	"testing"
+
+	"gophkeeper/internal/errs"
+	"gophkeeper/internal/server/repositories"
+	"gophkeeper/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// MockStorage implements sharing methods of repositories.Storage for testing
+type MockStorage struct {
+	repositories.Storage
+
+	keys    map[string]*models.UserKeys
+	owners  map[[16]byte]string
+	shares  []models.ItemShare
+	revoked *models.ShareRevocation
+}
+
+func (m *MockStorage) AddUserKeys(ctx context.Context, keys *models.UserKeys) error {
+	if _, ok := m.keys[keys.Login]; ok {
+		return errs.ErrKeysAlreadyExist
+	}
+	m.keys[keys.Login] = keys
+	return nil
+}
+
+func (m *MockStorage) GetUserKeys(ctx context.Context, login string) (*models.UserKeys, error) {
+	keys, ok := m.keys[login]
+	if !ok {
+		return nil, errs.ErrKeysNotFound
+	}
+	return keys, nil
+}
+
+func (m *MockStorage) GetItemOwner(ctx context.Context, itemID [16]byte) (string, error) {
+	owner, ok := m.owners[itemID]
+	if !ok {
+		return "", errs.ErrItemNotFound
+	}
+	return owner, nil
+}
+
+func (m *MockStorage) ShareItem(ctx context.Context, share *models.ItemShare) error {
+	m.shares = append(m.shares, *share)
+	return nil
+}
+
+func (m *MockStorage) ListItemShares(ctx context.Context, itemID [16]byte) ([]models.ItemShare, error) {
+	return m.shares, nil
+}
+
+func (m *MockStorage) RevokeShare(ctx context.Context, owner string, rev *models.ShareRevocation) error {
+	m.revoked = rev
+	return nil
+}
+
+var testItemID = [16]byte{1}
+
+func newTestService(t *testing.T) (*ShareService, *MockStorage) {
+	t.Helper()
+	repo := &MockStorage{
+		keys: map[string]*models.UserKeys{
+			"alice": {Login: "alice", PublicKey: []byte("alice-pub"), EncryptedPrivateKey: "alice-priv"},
+			"bob":   {Login: "bob", PublicKey: []byte("bob-pub"), EncryptedPrivateKey: "bob-priv"},
+		},
+		owners: map[[16]byte]string{testItemID: "alice"},
+	}
+	ss, err := NewShareService(repo)
+	require.NoError(t, err)
+	return ss, repo
+}
+
+func TestShareService_SetUserKeys(t *testing.T) {
+	ss, _ := newTestService(t)
+
+	err := ss.SetUserKeys(context.Background(), &models.UserKeys{Login: "carol"})
+	assert.ErrorIs(t, err, errs.ErrRequiredArgumentIsMissing)
+
+	err = ss.SetUserKeys(context.Background(), &models.UserKeys{Login: "carol", PublicKey: []byte("pub"), EncryptedPrivateKey: "priv"})
+	assert.NoError(t, err)
+
+	pub, err := ss.GetPublicKey(context.Background(), "carol")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("pub"), pub)
+}
+
+func TestShareService_ShareItem(t *testing.T) {
+	tests := []struct {
+		name    string
+		caller  string
+		share   models.ItemShare
+		wantErr error
+	}{
+		{
+			name:   "success",
+			caller: "alice",
+			share:  models.ItemShare{ItemID: testItemID, RecipientLogin: "bob", WrappedKey: "wrapped"},
+		},
+		{
+			name:    "share with self",
+			caller:  "alice",
+			share:   models.ItemShare{ItemID: testItemID, RecipientLogin: "alice", WrappedKey: "wrapped"},
+			wantErr: errs.ErrShareWithSelf,
+		},
+		{
+			name:    "not owner",
+			caller:  "bob",
+			share:   models.ItemShare{ItemID: testItemID, RecipientLogin: "carol", WrappedKey: "wrapped"},
+			wantErr: errs.ErrNotItemOwner,
+		},
+		{
+			name:    "unknown item",
+			caller:  "alice",
+			share:   models.ItemShare{ItemID: [16]byte{2}, RecipientLogin: "bob", WrappedKey: "wrapped"},
+			wantErr: errs.ErrItemNotFound,
+		},
+		{
+			name:    "recipient without keys",
+			caller:  "alice",
+			share:   models.ItemShare{ItemID: testItemID, RecipientLogin: "carol", WrappedKey: "wrapped"},
+			wantErr: errs.ErrKeysNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ss, repo := newTestService(t)
+
+			err := ss.ShareItem(context.Background(), tt.caller, &tt.share)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Empty(t, repo.shares)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, repo.shares, 1)
+			}
+		})
+	}
+}
+
+func TestShareService_RevokeShare(t *testing.T) {
+	ss, repo := newTestService(t)
+	rev := &models.ShareRevocation{ItemID: testItemID, RecipientLogin: "bob"}
+
+	assert.ErrorIs(t, ss.RevokeShare(context.Background(), "bob", rev), errs.ErrNotItemOwner)
+	assert.Nil(t, repo.revoked)
+
+	require.NoError(t, ss.RevokeShare(context.Background(), "alice", rev))
+	assert.Equal(t, rev, repo.revoked)
+}
+
+func TestShareService_ListItemShares(t *testing.T) {
+	ss, _ := newTestService(t)
+
+	_, err := ss.ListItemShares(context.Background(), "bob", testItemID)
+	assert.ErrorIs(t, err, errs.ErrNotItemOwner)
+
+	_, err = ss.ListItemShares(context.Background(), "alice", testItemID)
+	assert.NoError(t, err)
+}
@@ -1,16 +1,24 @@
 package user_service
 
 import (
+	"encoding/hex"
 	"fmt"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 )
 
-func (us *UserService) generateToken(login string) (string, error) {
+// generateToken issues a token for login. A token bound to a device stops
+// working once the device is removed.
+func (us *UserService) generateToken(login string, deviceID [16]byte) (string, error) {
+	now := time.Now()
 	claims := jwt.MapClaims{
 		"login": login,
-		"exp":   time.Now().Add(7 * time.Hour * 24).Unix(),
+		"iat":   now.Unix(),
+		"exp":   now.Add(7 * time.Hour * 24).Unix(),
+	}
+	if deviceID != [16]byte{} {
+		claims["device_id"] = hex.EncodeToString(deviceID[:])
 	}
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 
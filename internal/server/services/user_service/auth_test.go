@@ -40,7 +40,7 @@ func TestGenerateToken(t *testing.T) {
 			require.NoError(t, err)
 			userService, err := NewUserService(cnfg, &MockStorage{})
 			require.NoError(t, err)
-			token, err := userService.generateToken(tt.login)
+			token, err := userService.generateToken(tt.login, [16]byte{})
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -77,7 +77,7 @@ func TestGenerateToken_Format(t *testing.T) {
 	require.NoError(t, err)
 	userService, err := NewUserService(cnfg, &MockStorage{})
 	require.NoError(t, err)
-	token, err := userService.generateToken("testuser")
+	token, err := userService.generateToken("testuser", [16]byte{})
 	require.NoError(t, err)
 	require.NotEmpty(t, token)
 
@@ -98,8 +98,8 @@ func TestGenerateToken_DifferentLogins(t *testing.T) {
 	require.NoError(t, err)
 	userService, err := NewUserService(cnfg, &MockStorage{})
 	require.NoError(t, err)
-	token1, err1 := userService.generateToken(login1)
-	token2, err2 := userService.generateToken(login2)
+	token1, err1 := userService.generateToken(login1, [16]byte{})
+	token2, err2 := userService.generateToken(login2, [16]byte{})
 
 	assert.NoError(t, err1)
 	assert.NoError(t, err2)
@@ -116,9 +116,9 @@ func TestGenerateToken_MultipleCallsSameUser(t *testing.T) {
 	require.NoError(t, err)
 	userService, err := NewUserService(cnfg, &MockStorage{})
 	require.NoError(t, err)
-	token1, err1 := userService.generateToken(login)
+	token1, err1 := userService.generateToken(login, [16]byte{})
 	time.Sleep(time.Second)
-	token2, err2 := userService.generateToken(login)
+	token2, err2 := userService.generateToken(login, [16]byte{})
 
 	assert.NoError(t, err1)
 	assert.NoError(t, err2)
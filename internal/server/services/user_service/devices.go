@@ -0,0 +1,181 @@
+package user_service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"gophkeeper/internal/errs"
+	"gophkeeper/internal/logger"
+	"gophkeeper/models"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// deviceSeenInterval limits how often a busy device updates its last
+	// seen time.
+	deviceSeenInterval = time.Minute
+
+	// deviceSignInWindow is how far the signing time of a sign in may be
+	// from the server clock.
+	deviceSignInWindow = 2 * time.Minute
+
+	maxDeviceNameLen = 100
+	maxDeviceOSLen   = 50
+)
+
+// registerDevice records the device the user signs in from and returns its
+// ID for the token. A device that already has a public key must sign the
+// request with it, at a recent time later than its last sign in, so a
+// captured request cannot be sent again. Binding the first key to a known
+// device needs approval again, or anyone with the password could take over
+// an approved device without a key. Sign ins without a device are allowed
+// only while the user does not require device approval.
+func (us *UserService) registerDevice(ctx context.Context, login, encryptedPassword string, in *models.DeviceSignIn, trusted bool) ([16]byte, error) {
+	required, err := us.repo.GetDeviceApprovalRequired(ctx, login)
+	if err != nil {
+		return [16]byte{}, fmt.Errorf("get device approval error: %w", err)
+	}
+	if in == nil {
+		if required && !trusted {
+			return [16]byte{}, errs.ErrDeviceNotApproved
+		}
+		return [16]byte{}, nil
+	}
+
+	device := in.Device
+	if device.ID == [16]byte{} {
+		return [16]byte{}, errs.ErrRequiredArgumentIsMissing
+	}
+
+	publicKey := device.PublicKey
+	stored, err := us.repo.GetDevice(ctx, login, device.ID)
+	switch {
+	case err == nil:
+		if len(stored.PublicKey) > 0 {
+			publicKey = stored.PublicKey
+		}
+	case !errors.Is(err, errs.ErrDeviceNotFound):
+		return [16]byte{}, fmt.Errorf("get device error: %w", err)
+	}
+	if len(publicKey) > 0 {
+		signer := models.Device{PublicKey: publicKey}
+		if !signer.VerifySignature(models.DeviceSignInMessage(login, device.ID, encryptedPassword, in.SignedAt), in.Signature) {
+			return [16]byte{}, errs.ErrInvalidDeviceSignature
+		}
+		if time.Since(in.SignedAt).Abs() > deviceSignInWindow {
+			return [16]byte{}, errs.ErrDeviceSignInReplayed
+		}
+		used, err := us.repo.UseDeviceSignIn(ctx, login, device.ID, in.SignedAt)
+		if err != nil {
+			return [16]byte{}, fmt.Errorf("use device sign in error: %w", err)
+		}
+		if !used {
+			return [16]byte{}, errs.ErrDeviceSignInReplayed
+		}
+	}
+
+	device.Login = login
+	device.Name = truncate(strings.TrimSpace(device.Name), maxDeviceNameLen)
+	device.OS = truncate(strings.TrimSpace(device.OS), maxDeviceOSLen)
+	device.Approved = trusted || !required
+	registered, err := us.repo.RegisterDevice(ctx, &device)
+	if err != nil {
+		return [16]byte{}, fmt.Errorf("register device error: %w", err)
+	}
+	if !registered.Approved {
+		logger.Log.Info("Device is waiting for approval", zap.String("user", login), zap.String("device", registered.Name))
+		return [16]byte{}, errs.ErrDeviceNotApproved
+	}
+	return registered.ID, nil
+}
+
+// validateDevice rejects tokens of removed devices and keeps the last seen
+// time and address of the device fresh.
+func (us *UserService) validateDevice(ctx context.Context, session models.Session) error {
+	device, err := us.repo.GetDevice(ctx, session.Login, session.DeviceID)
+	if errors.Is(err, errs.ErrDeviceNotFound) {
+		return errs.ErrSessionRevoked
+	}
+	if err != nil {
+		return err
+	}
+	if !device.Approved {
+		return errs.ErrDeviceNotApproved
+	}
+
+	if time.Since(device.LastSeenAt) > deviceSeenInterval || device.LastSeenIP != session.IP {
+		if err := us.repo.TouchDevice(ctx, session.Login, session.DeviceID, session.IP); err != nil {
+			logger.Log.Warn("Touch device error", zap.Error(err))
+		}
+	}
+	return nil
+}
+
+// ListDevices returns the devices of the user with current marked, and
+// whether new devices need approval.
+func (us *UserService) ListDevices(ctx context.Context, login string, current [16]byte) ([]models.Device, bool, error) {
+	devices, err := us.repo.ListDevices(ctx, login)
+	if err != nil {
+		return nil, false, err
+	}
+	for i := range devices {
+		devices[i].Current = devices[i].ID == current
+	}
+	required, err := us.repo.GetDeviceApprovalRequired(ctx, login)
+	if err != nil {
+		return nil, false, err
+	}
+	return devices, required, nil
+}
+
+// RemoveDevice signs the device out. Its tokens stop working on their next
+// request.
+func (us *UserService) RemoveDevice(ctx context.Context, login string, id [16]byte) error {
+	return us.repo.RemoveDevice(ctx, login, id)
+}
+
+// ApproveDevice lets a device waiting for approval sign in. Only an approved
+// device can approve others.
+func (us *UserService) ApproveDevice(ctx context.Context, login string, caller, id [16]byte) error {
+	if err := us.requireTrustedDevice(ctx, login, caller); err != nil {
+		return err
+	}
+	return us.repo.ApproveDevice(ctx, login, id)
+}
+
+// SetDeviceApproval turns approval of new devices on or off. It is changed
+// from an approved device, so turning it on never locks the user out.
+func (us *UserService) SetDeviceApproval(ctx context.Context, login string, caller [16]byte, required bool) error {
+	if err := us.requireTrustedDevice(ctx, login, caller); err != nil {
+		return err
+	}
+	return us.repo.SetDeviceApprovalRequired(ctx, login, required)
+}
+
+func (us *UserService) requireTrustedDevice(ctx context.Context, login string, caller [16]byte) error {
+	if caller == [16]byte{} {
+		return errs.ErrDeviceNotTrusted
+	}
+	device, err := us.repo.GetDevice(ctx, login, caller)
+	if errors.Is(err, errs.ErrDeviceNotFound) {
+		return errs.ErrDeviceNotTrusted
+	}
+	if err != nil {
+		return err
+	}
+	if !device.Approved {
+		return errs.ErrDeviceNotTrusted
+	}
+	return nil
+}
+
+func truncate(s string, n int) string {
+	r := []rune(s)
+	if len(r) > n {
+		return string(r[:n])
+	}
+	return s
+}
@@ -0,0 +1,161 @@
+package user_service
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"gophkeeper/internal/errs"
+	"gophkeeper/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestDevice(t *testing.T, id byte, login, password string) (*models.DeviceSignIn, ed25519.PrivateKey) {
+	t.Helper()
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	device := &models.DeviceSignIn{Device: models.Device{ID: [16]byte{id}, Name: "laptop", OS: "linux", PublicKey: pub}}
+	signTestDevice(device, priv, login, password, time.Now())
+	return device, priv
+}
+
+// signTestDevice signs device for another sign in made at signedAt.
+func signTestDevice(device *models.DeviceSignIn, priv ed25519.PrivateKey, login, password string, signedAt time.Time) {
+	device.SignedAt = signedAt
+	device.Signature = ed25519.Sign(priv, models.DeviceSignInMessage(login, device.Device.ID, password, signedAt))
+}
+
+func TestUserService_RegisterDevice(t *testing.T) {
+	ctx := context.Background()
+	repo := &MockStorage{}
+	us := &UserService{repo: repo}
+
+	id, err := us.registerDevice(ctx, "alice", "pw", nil, false)
+	require.NoError(t, err)
+	assert.Equal(t, [16]byte{}, id)
+
+	device, priv := newTestDevice(t, 1, "alice", "pw")
+	id, err = us.registerDevice(ctx, "alice", "pw", device, false)
+	require.NoError(t, err)
+	assert.Equal(t, [16]byte{1}, id)
+	assert.True(t, repo.devices[id].Approved)
+
+	// The stored key wins over the one in the request.
+	_, otherPriv, _ := ed25519.GenerateKey(rand.Reader)
+	forged := &models.DeviceSignIn{Device: models.Device{ID: [16]byte{1}, PublicKey: otherPriv.Public().(ed25519.PublicKey)}}
+	signTestDevice(forged, otherPriv, "alice", "pw2", time.Now())
+	_, err = us.registerDevice(ctx, "alice", "pw2", forged, false)
+	assert.ErrorIs(t, err, errs.ErrInvalidDeviceSignature)
+
+	// A signature is bound to the request it was made for.
+	_, err = us.registerDevice(ctx, "alice", "pw2", device, false)
+	assert.ErrorIs(t, err, errs.ErrInvalidDeviceSignature)
+
+	signTestDevice(device, priv, "alice", "pw2", time.Now())
+	_, err = us.registerDevice(ctx, "alice", "pw2", device, false)
+	assert.NoError(t, err)
+
+	// A sign in is taken once, and only when it is recent.
+	_, err = us.registerDevice(ctx, "alice", "pw2", device, false)
+	assert.ErrorIs(t, err, errs.ErrDeviceSignInReplayed)
+	signTestDevice(device, priv, "alice", "pw2", time.Now().Add(-time.Hour))
+	_, err = us.registerDevice(ctx, "alice", "pw2", device, false)
+	assert.ErrorIs(t, err, errs.ErrDeviceSignInReplayed)
+	signTestDevice(device, priv, "alice", "pw2", time.Now().Add(time.Hour))
+	_, err = us.registerDevice(ctx, "alice", "pw2", device, false)
+	assert.ErrorIs(t, err, errs.ErrDeviceSignInReplayed)
+
+	_, err = us.registerDevice(ctx, "alice", "pw", &models.DeviceSignIn{}, false)
+	assert.ErrorIs(t, err, errs.ErrRequiredArgumentIsMissing)
+}
+
+func TestUserService_DeviceApproval(t *testing.T) {
+	ctx := context.Background()
+	repo := &MockStorage{}
+	us := &UserService{repo: repo}
+
+	trusted, _ := newTestDevice(t, 1, "alice", "pw")
+	_, err := us.registerDevice(ctx, "alice", "pw", trusted, true)
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, us.SetDeviceApproval(ctx, "alice", [16]byte{}, true), errs.ErrDeviceNotTrusted)
+	require.NoError(t, us.SetDeviceApproval(ctx, "alice", [16]byte{1}, true))
+
+	_, err = us.registerDevice(ctx, "alice", "pw", nil, false)
+	assert.ErrorIs(t, err, errs.ErrDeviceNotApproved)
+
+	laptop, laptopPriv := newTestDevice(t, 2, "alice", "pw")
+	_, err = us.registerDevice(ctx, "alice", "pw", laptop, false)
+	assert.ErrorIs(t, err, errs.ErrDeviceNotApproved)
+	assert.False(t, repo.devices[[16]byte{2}].Approved)
+
+	assert.ErrorIs(t, us.ApproveDevice(ctx, "alice", [16]byte{2}, [16]byte{2}), errs.ErrDeviceNotTrusted)
+	require.NoError(t, us.ApproveDevice(ctx, "alice", [16]byte{1}, [16]byte{2}))
+
+	signTestDevice(laptop, laptopPriv, "alice", "pw", time.Now())
+	id, err := us.registerDevice(ctx, "alice", "pw", laptop, false)
+	require.NoError(t, err)
+	assert.Equal(t, [16]byte{2}, id)
+
+	devices, required, err := us.ListDevices(ctx, "alice", [16]byte{2})
+	require.NoError(t, err)
+	assert.True(t, required)
+	require.Len(t, devices, 2)
+	for _, d := range devices {
+		assert.Equal(t, d.ID == [16]byte{2}, d.Current)
+	}
+}
+
+func TestUserService_DeviceApproval_FirstKey(t *testing.T) {
+	ctx := context.Background()
+	repo := &MockStorage{}
+	us := &UserService{repo: repo}
+
+	// An older client registered the device without a key.
+	keyless := &models.DeviceSignIn{Device: models.Device{ID: [16]byte{1}, Name: "laptop"}}
+	_, err := us.registerDevice(ctx, "alice", "pw", keyless, true)
+	require.NoError(t, err)
+	trusted, _ := newTestDevice(t, 2, "alice", "pw")
+	_, err = us.registerDevice(ctx, "alice", "pw", trusted, true)
+	require.NoError(t, err)
+	require.NoError(t, us.SetDeviceApproval(ctx, "alice", [16]byte{2}, true))
+
+	// Whoever binds the first key to it waits for approval again.
+	takeover, takeoverPriv := newTestDevice(t, 1, "alice", "pw")
+	_, err = us.registerDevice(ctx, "alice", "pw", takeover, false)
+	assert.ErrorIs(t, err, errs.ErrDeviceNotApproved)
+	assert.False(t, repo.devices[[16]byte{1}].Approved)
+
+	require.NoError(t, us.ApproveDevice(ctx, "alice", [16]byte{2}, [16]byte{1}))
+	signTestDevice(takeover, takeoverPriv, "alice", "pw", time.Now())
+	id, err := us.registerDevice(ctx, "alice", "pw", takeover, false)
+	require.NoError(t, err)
+	assert.Equal(t, [16]byte{1}, id)
+}
+
+func TestUserService_ValidateSession_Device(t *testing.T) {
+	ctx := context.Background()
+	repo := &MockStorage{
+		users: map[string]*models.User{"alice": {Login: "alice"}},
+		devices: map[[16]byte]*models.Device{
+			{1}: {ID: [16]byte{1}, Login: "alice", Approved: true, LastSeenAt: time.Now(), LastSeenIP: "10.0.0.1"},
+		},
+	}
+	us := &UserService{repo: repo}
+
+	session := models.Session{Login: "alice", DeviceID: [16]byte{1}, IssuedAt: time.Now(), IP: "10.0.0.1"}
+	require.NoError(t, us.ValidateSession(ctx, session))
+	assert.Equal(t, 0, repo.touched)
+
+	session.IP = "10.0.0.2"
+	require.NoError(t, us.ValidateSession(ctx, session))
+	assert.Equal(t, 1, repo.touched)
+	assert.Equal(t, "10.0.0.2", repo.devices[[16]byte{1}].LastSeenIP)
+
+	require.NoError(t, us.RemoveDevice(ctx, "alice", [16]byte{1}))
+	assert.ErrorIs(t, us.ValidateSession(ctx, session), errs.ErrSessionRevoked)
+	assert.ErrorIs(t, us.RemoveDevice(ctx, "alice", [16]byte{1}), errs.ErrDeviceNotFound)
+}
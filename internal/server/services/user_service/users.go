@@ -13,11 +13,15 @@ import (
 	"gophkeeper/internal/hash"
 	"gophkeeper/internal/server/repositories"
 	"gophkeeper/internal/server/services/crypto_service"
+	"gophkeeper/internal/telemetry"
 	"gophkeeper/models"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 )
 
+var tracer = telemetry.Tracer("gophkeeper/internal/server/services/user_service")
+
 type UserService struct {
 	cnfg config.ServerServicesConfig
 	repo repositories.Storage
@@ -39,7 +43,12 @@ func (us *UserService) GetUser(ctx context.Context, user *models.User) (*models.
 	return nil, fmt.Errorf("get user error: %w", err)
 }
 
-func (us *UserService) SignUpUser(ctx context.Context, login, encryptedPassword string) (token string, salt string, err error) {
+// SignUpUser registers the user. The device the user signed up from is
+// trusted from the start.
+func (us *UserService) SignUpUser(ctx context.Context, login, encryptedPassword string, device *models.DeviceSignIn) (token string, salt string, err error) {
+	ctx, span := tracer.Start(ctx, "UserService.SignUpUser")
+	defer telemetry.End(span, &err)
+
 	_, err = us.GetUser(ctx, &models.User{Login: login})
 	switch {
 	case err == nil:
@@ -48,14 +57,9 @@ func (us *UserService) SignUpUser(ctx context.Context, login, encryptedPassword
 		return "", "", fmt.Errorf("sign up user error: %w", err)
 	}
 
-	decryptedPassword, err := decryptPassword(encryptedPassword, us.cnfg.GetPrivateKey())
-	if err != nil {
-		return "", "", fmt.Errorf("failed to decrypt password: %w", err)
-	}
-
-	passHash, err := hash.GetHash(decryptedPassword)
+	passHash, err := us.HashPassword(encryptedPassword)
 	if err != nil {
-		return "", "", fmt.Errorf("generate password hash error: %w", err)
+		return "", "", err
 	}
 
 	salt, err = crypto_service.GenerateSalt()
@@ -72,7 +76,12 @@ func (us *UserService) SignUpUser(ctx context.Context, login, encryptedPassword
 		return "", "", fmt.Errorf("sign up user in db error: %w", err)
 	}
 
-	token, err = us.generateToken(login)
+	deviceID, err := us.registerDevice(ctx, login, encryptedPassword, device, true)
+	if err != nil {
+		return "", "", fmt.Errorf("register device error: %w", err)
+	}
+
+	token, err = us.generateToken(login, deviceID)
 	if err != nil {
 		return "", "", fmt.Errorf("generate token after sign up user error: %w", err)
 	}
@@ -80,13 +89,23 @@ func (us *UserService) SignUpUser(ctx context.Context, login, encryptedPassword
 	return token, salt, nil
 }
 
-func (us *UserService) SignInUser(ctx context.Context, login, encryptedPassword string) (token string, salt string, err error) {
+// SignInUser checks the credentials and registers the device. When the user
+// requires device approval, a new device gets no token until a trusted
+// device approves it.
+func (us *UserService) SignInUser(ctx context.Context, login, encryptedPassword string, device *models.DeviceSignIn) (token string, salt string, err error) {
+	ctx, span := tracer.Start(ctx, "UserService.SignInUser")
+	defer telemetry.End(span, &err)
+
 	user, err := us.GetUser(ctx, &models.User{Login: login})
 	switch {
 	case errors.Is(err, errs.ErrUserNotFound):
 		return "", "", err
 	case err != nil && !errors.Is(err, errs.ErrUserNotFound):
 		return "", "", fmt.Errorf("sign in user error: %w", err)
+	case user.IsDeleted():
+		return "", "", errs.ErrUserNotFound
+	case user.Locked:
+		return "", "", errs.ErrUserLocked
 	}
 
 	decryptedPassword, err := decryptPassword(encryptedPassword, us.cnfg.GetPrivateKey())
@@ -94,11 +113,19 @@ func (us *UserService) SignInUser(ctx context.Context, login, encryptedPassword
 		return "", "", fmt.Errorf("failed to decrypt password: %w", err)
 	}
 
-	if !hash.VerifyHash(decryptedPassword, user.Password) {
+	_, hashSpan := tracer.Start(ctx, "hash.VerifyHash")
+	ok := hash.VerifyHash(decryptedPassword, user.Password)
+	hashSpan.End()
+	if !ok {
 		return "", "", errs.ErrIncorrectCredentials
 	}
 
-	token, err = us.generateToken(login)
+	deviceID, err := us.registerDevice(ctx, login, encryptedPassword, device, false)
+	if err != nil {
+		return "", "", err
+	}
+
+	token, err = us.generateToken(login, deviceID)
 	if err != nil {
 		return "", "", fmt.Errorf("generate token after sign up user error: %w", err)
 	}
@@ -106,10 +133,24 @@ func (us *UserService) SignInUser(ctx context.Context, login, encryptedPassword
 	return token, user.Salt, nil
 }
 
+// HashPassword decrypts a password encrypted with the server public key and hashes it for storage.
+func (us *UserService) HashPassword(encryptedPassword string) ([]byte, error) {
+	decryptedPassword, err := decryptPassword(encryptedPassword, us.cnfg.GetPrivateKey())
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt password: %w", err)
+	}
+
+	passHash, err := hash.GetHash(decryptedPassword)
+	if err != nil {
+		return nil, fmt.Errorf("generate password hash error: %w", err)
+	}
+	return passHash, nil
+}
+
 func decryptPassword(encryptedPassword string, pk *rsa.PrivateKey) ([]byte, error) {
 	encryptedBytes, err := base64.StdEncoding.DecodeString(encryptedPassword)
 	if err != nil {
-		return nil, fmt.Errorf("decode password from base64 error: %v", err)
+		return nil, fmt.Errorf("%w: decode base64 error: %v", errs.ErrInvalidPasswordEncoding, err)
 	}
 
 	decryptedPassword, err := rsa.DecryptOAEP(
@@ -121,8 +162,42 @@ func decryptPassword(encryptedPassword string, pk *rsa.PrivateKey) ([]byte, erro
 	)
 
 	if err != nil {
-		return nil, fmt.Errorf("failed to decrypt password: %w", err)
+		return nil, fmt.Errorf("%w: %v", errs.ErrInvalidPasswordEncoding, err)
 	}
 
 	return decryptedPassword, nil
 }
+
+// isRevoked reports whether a token issued at issuedAt was revoked at
+// revokedAt. iat has whole seconds, so tokens of the second the sessions were
+// revoked in are revoked too: a token from before the revoke must not slip
+// through, and signing in again works from the next second.
+func isRevoked(issuedAt, revokedAt time.Time) bool {
+	if revokedAt.IsZero() {
+		return false
+	}
+	return issuedAt.Before(revokedAt.Truncate(time.Second).Add(time.Second))
+}
+
+// ValidateSession checks that a token still belongs to an active account
+// and, for a token bound to a device, that the device was not removed.
+func (us *UserService) ValidateSession(ctx context.Context, session models.Session) error {
+	user, err := us.GetUser(ctx, &models.User{Login: session.Login})
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case user.IsDeleted():
+		return errs.ErrUserNotFound
+	case user.Locked:
+		return errs.ErrUserLocked
+	case isRevoked(session.IssuedAt, user.SessionsRevokedAt):
+		return errs.ErrSessionRevoked
+	}
+
+	if session.HasDevice() {
+		return us.validateDevice(ctx, session)
+	}
+	return nil
+}
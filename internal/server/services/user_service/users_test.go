@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"gophkeeper/config"
 	"gophkeeper/internal/errs"
@@ -17,6 +18,11 @@ import (
 type MockStorage struct {
 	shouldFail bool
 	users      map[string]*models.User
+
+	devices          map[[16]byte]*models.Device
+	approvalRequired bool
+	touched          int
+	signedAt         map[[16]byte]time.Time
 }
 
 func (m *MockStorage) SignUpUser(ctx context.Context, user *models.User) error {
@@ -50,14 +56,236 @@ func (m *MockStorage) GetAllUserItems(ctx context.Context, login string) ([]mode
 func (m *MockStorage) GetUserItemsWithType(ctx context.Context, typ models.ItemType, login string) ([]models.EncryptedItem, error) {
 	return nil, nil
 }
+func (m *MockStorage) SearchUserItems(ctx context.Context, login string, tokens []string) ([]models.EncryptedItem, error) {
+	return nil, nil
+}
 func (m *MockStorage) AddItem(ctx context.Context, item *models.EncryptedItem) error  { return nil }
 func (m *MockStorage) EditItem(ctx context.Context, item *models.EncryptedItem) error { return nil }
 func (m *MockStorage) DeleteItem(ctx context.Context, login string, itemID [16]byte) error {
 	return nil
 }
+func (m *MockStorage) RegisterDevice(ctx context.Context, device *models.Device) (*models.Device, error) {
+	if m.devices == nil {
+		m.devices = make(map[[16]byte]*models.Device)
+	}
+	if stored, ok := m.devices[device.ID]; ok {
+		if len(stored.PublicKey) == 0 && len(device.PublicKey) > 0 {
+			stored.PublicKey = device.PublicKey
+			stored.Approved = device.Approved
+		}
+		stored.Name = device.Name
+		stored.LastSeenIP = device.LastSeenIP
+		return stored, nil
+	}
+	stored := *device
+	m.devices[device.ID] = &stored
+	return &stored, nil
+}
+func (m *MockStorage) GetDevice(ctx context.Context, login string, id [16]byte) (*models.Device, error) {
+	device, ok := m.devices[id]
+	if !ok || device.Login != login {
+		return nil, errs.ErrDeviceNotFound
+	}
+	return device, nil
+}
+func (m *MockStorage) ListDevices(ctx context.Context, login string) ([]models.Device, error) {
+	var devices []models.Device
+	for _, d := range m.devices {
+		if d.Login == login {
+			devices = append(devices, *d)
+		}
+	}
+	return devices, nil
+}
+func (m *MockStorage) RemoveDevice(ctx context.Context, login string, id [16]byte) error {
+	if _, err := m.GetDevice(ctx, login, id); err != nil {
+		return err
+	}
+	delete(m.devices, id)
+	return nil
+}
+func (m *MockStorage) ApproveDevice(ctx context.Context, login string, id [16]byte) error {
+	device, err := m.GetDevice(ctx, login, id)
+	if err != nil {
+		return err
+	}
+	device.Approved = true
+	return nil
+}
+func (m *MockStorage) TouchDevice(ctx context.Context, login string, id [16]byte, ip string) error {
+	m.touched++
+	m.devices[id].LastSeenAt = time.Now()
+	m.devices[id].LastSeenIP = ip
+	return nil
+}
+func (m *MockStorage) UseDeviceSignIn(ctx context.Context, login string, id [16]byte, signedAt time.Time) (bool, error) {
+	if last, ok := m.signedAt[id]; ok && !last.Before(signedAt) {
+		return false, nil
+	}
+	if m.signedAt == nil {
+		m.signedAt = make(map[[16]byte]time.Time)
+	}
+	m.signedAt[id] = signedAt
+	return true, nil
+}
+func (m *MockStorage) GetDeviceApprovalRequired(ctx context.Context, login string) (bool, error) {
+	return m.approvalRequired, nil
+}
+func (m *MockStorage) SetDeviceApprovalRequired(ctx context.Context, login string, required bool) error {
+	m.approvalRequired = required
+	return nil
+}
+
+func (m *MockStorage) Start(ctx context.Context) error { return nil }
+func (m *MockStorage) Stop(ctx context.Context) error  { return nil }
+func (m *MockStorage) Close() error                    { return nil }
+func (m *MockStorage) DeleteExpiredItems(ctx context.Context) (int64, error) {
+	return 0, nil
+}
 func (m *MockStorage) GetTypesCounts(ctx context.Context, login string) (map[models.ItemType]int32, error) {
 	return nil, nil
 }
+func (m *MockStorage) ListUsersStats(ctx context.Context) ([]models.UserStats, error) {
+	return nil, nil
+}
+func (m *MockStorage) SetUserLocked(ctx context.Context, login string, locked bool) error {
+	return nil
+}
+func (m *MockStorage) MarkUserDeleted(ctx context.Context, login string) error    { return nil }
+func (m *MockStorage) RevokeUserSessions(ctx context.Context, login string) error { return nil }
+func (m *MockStorage) RevokeAllSessions(ctx context.Context) (int64, error)       { return 0, nil }
+func (m *MockStorage) PurgeDeletedUsers(ctx context.Context) (int64, error)       { return 0, nil }
+func (m *MockStorage) GetServerStats(ctx context.Context) (*models.ServerStats, error) {
+	return nil, nil
+}
+func (m *MockStorage) ListMigrations(ctx context.Context) ([]models.Migration, error) {
+	return nil, nil
+}
+func (m *MockStorage) Migrate(ctx context.Context) ([]string, error)                { return nil, nil }
+func (m *MockStorage) AddUserKeys(ctx context.Context, keys *models.UserKeys) error { return nil }
+func (m *MockStorage) GetUserKeys(ctx context.Context, login string) (*models.UserKeys, error) {
+	return nil, nil
+}
+func (m *MockStorage) GetItemOwner(ctx context.Context, itemID [16]byte) (string, error) {
+	return "", nil
+}
+func (m *MockStorage) ShareItem(ctx context.Context, share *models.ItemShare) error { return nil }
+func (m *MockStorage) ListItemShares(ctx context.Context, itemID [16]byte) ([]models.ItemShare, error) {
+	return nil, nil
+}
+func (m *MockStorage) ListSharedWithUser(ctx context.Context, login string) ([]models.SharedItem, error) {
+	return nil, nil
+}
+func (m *MockStorage) RevokeShare(ctx context.Context, owner string, rev *models.ShareRevocation) error {
+	return nil
+}
+func (m *MockStorage) GetCollectionItems(ctx context.Context, typ models.ItemType, collectionID [16]byte) ([]models.EncryptedItem, error) {
+	return nil, nil
+}
+func (m *MockStorage) GetCollectionTypesCounts(ctx context.Context, collectionID [16]byte) (map[models.ItemType]int32, error) {
+	return nil, nil
+}
+func (m *MockStorage) GetItemCollection(ctx context.Context, itemID [16]byte) ([16]byte, error) {
+	return [16]byte{}, nil
+}
+func (m *MockStorage) DeleteCollectionItem(ctx context.Context, collectionID, itemID [16]byte) error {
+	return nil
+}
+func (m *MockStorage) TouchItem(ctx context.Context, login string, itemID [16]byte) error {
+	return nil
+}
+func (m *MockStorage) SetItemFavorite(ctx context.Context, login string, itemID [16]byte, favorite bool) error {
+	return nil
+}
+func (m *MockStorage) AddAttachment(ctx context.Context, a *models.Attachment) ([16]byte, error) {
+	return [16]byte{}, nil
+}
+func (m *MockStorage) ListAttachments(ctx context.Context, itemID [16]byte) ([]models.Attachment, error) {
+	return nil, nil
+}
+func (m *MockStorage) GetAttachment(ctx context.Context, itemID, attachmentID [16]byte) (*models.Attachment, error) {
+	return nil, nil
+}
+func (m *MockStorage) DeleteAttachment(ctx context.Context, itemID, attachmentID [16]byte) error {
+	return nil
+}
+func (m *MockStorage) SaveTemplate(ctx context.Context, t *models.Template) error {
+	return nil
+}
+func (m *MockStorage) ListTemplates(ctx context.Context, login string) ([]models.Template, error) {
+	return nil, nil
+}
+func (m *MockStorage) DeleteTemplate(ctx context.Context, login string, id [16]byte) error {
+	return nil
+}
+func (m *MockStorage) CreateOrganization(ctx context.Context, org *models.Organization, coll *models.Collection) error {
+	return nil
+}
+func (m *MockStorage) ListUserOrganizations(ctx context.Context, login string) ([]models.Organization, error) {
+	return nil, nil
+}
+func (m *MockStorage) GetMembership(ctx context.Context, orgID [16]byte, login string) (*models.Membership, error) {
+	return nil, nil
+}
+func (m *MockStorage) AddMembership(ctx context.Context, ms *models.Membership) error { return nil }
+func (m *MockStorage) AcceptMembership(ctx context.Context, orgID [16]byte, login string) error {
+	return nil
+}
+func (m *MockStorage) UpdateMembershipRole(ctx context.Context, orgID [16]byte, login string, role models.OrgRole) error {
+	return nil
+}
+func (m *MockStorage) ListMemberships(ctx context.Context, orgID [16]byte) ([]models.Membership, error) {
+	return nil, nil
+}
+func (m *MockStorage) LeaveOrganization(ctx context.Context, orgID [16]byte, login string) error {
+	return nil
+}
+func (m *MockStorage) RotateOrgKey(ctx context.Context, rot *models.OrgKeyRotation) error { return nil }
+func (m *MockStorage) CreateCollection(ctx context.Context, coll *models.Collection) error {
+	return nil
+}
+func (m *MockStorage) ListCollections(ctx context.Context, orgID [16]byte) ([]models.Collection, error) {
+	return nil, nil
+}
+func (m *MockStorage) GetCollectionRole(ctx context.Context, collectionID [16]byte, login string) (models.OrgRole, error) {
+	return "", nil
+}
+func (m *MockStorage) UpsertEmergencyAccess(ctx context.Context, access *models.EmergencyAccess) error {
+	return nil
+}
+func (m *MockStorage) GetEmergencyAccess(ctx context.Context, grantor, grantee string) (*models.EmergencyAccess, error) {
+	return nil, nil
+}
+func (m *MockStorage) ListEmergencyAccessByGrantor(ctx context.Context, grantor string) ([]models.EmergencyAccess, error) {
+	return nil, nil
+}
+func (m *MockStorage) ListEmergencyAccessByGrantee(ctx context.Context, grantee string) ([]models.EmergencyAccess, error) {
+	return nil, nil
+}
+func (m *MockStorage) RequestEmergencyAccess(ctx context.Context, grantor, grantee string) error {
+	return nil
+}
+func (m *MockStorage) ApproveEmergencyAccess(ctx context.Context, grantor, grantee string) error {
+	return nil
+}
+func (m *MockStorage) DenyEmergencyAccess(ctx context.Context, grantor, grantee string) error {
+	return nil
+}
+func (m *MockStorage) DeleteEmergencyAccess(ctx context.Context, grantor, grantee string) error {
+	return nil
+}
+func (m *MockStorage) TakeoverAccount(ctx context.Context, t *models.EmergencyTakeover, passwordHash []byte) error {
+	return nil
+}
+func (m *MockStorage) CreateSend(ctx context.Context, send *models.Send) ([16]byte, error) {
+	return [16]byte{}, nil
+}
+func (m *MockStorage) OpenSend(ctx context.Context, id [16]byte) (*models.Send, error) {
+	return nil, nil
+}
+func (m *MockStorage) DeleteExpiredSends(ctx context.Context) (int64, error) {
+	return 0, nil
+}
 
 func TestNewUserService(t *testing.T) {
 	cnfg, err := config.NewServerConfig()
@@ -178,7 +406,7 @@ func TestUserService_SignUpUser_ValidationOnly(t *testing.T) {
 			service, err := NewUserService(cnfg, mockRepo)
 			assert.NoError(t, err)
 
-			token, salt, err := service.SignUpUser(context.Background(), tt.login, tt.encryptedPassword)
+			token, salt, err := service.SignUpUser(context.Background(), tt.login, tt.encryptedPassword, nil)
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -226,6 +454,26 @@ func TestUserService_SignInUser_ValidationOnly(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name:              "locked user",
+			login:             "testuser",
+			encryptedPassword: "dummy_encrypted",
+			setup: func(m *MockStorage) {
+				m.users = map[string]*models.User{"testuser": {Login: "testuser", Locked: true}}
+			},
+			wantErr:         true,
+			expectedErrType: errs.ErrUserLocked,
+		},
+		{
+			name:              "deleted user",
+			login:             "testuser",
+			encryptedPassword: "dummy_encrypted",
+			setup: func(m *MockStorage) {
+				m.users = map[string]*models.User{"testuser": {Login: "testuser", DeletedAt: time.Now()}}
+			},
+			wantErr:         true,
+			expectedErrType: errs.ErrUserNotFound,
+		},
 	}
 
 	for _, tt := range tests {
@@ -237,7 +485,7 @@ func TestUserService_SignInUser_ValidationOnly(t *testing.T) {
 			service, err := NewUserService(cnfg, mockRepo)
 			assert.NoError(t, err)
 
-			token, salt, err := service.SignInUser(context.Background(), tt.login, tt.encryptedPassword)
+			token, salt, err := service.SignInUser(context.Background(), tt.login, tt.encryptedPassword, nil)
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -259,3 +507,43 @@ func TestUserService_SignInUser_ValidationOnly(t *testing.T) {
 func TestDecryptPassword_InvalidInput(t *testing.T) {
 	t.Skip("Skipping decryptPassword tests - requires RSA private key configuration")
 }
+
+func TestUserService_ValidateSession(t *testing.T) {
+	revokedAt := time.Now().Add(-time.Hour).Truncate(time.Second).Add(500 * time.Millisecond)
+	repo := &MockStorage{users: map[string]*models.User{
+		"active":  {Login: "active"},
+		"locked":  {Login: "locked", Locked: true},
+		"deleted": {Login: "deleted", DeletedAt: time.Now()},
+		"revoked": {Login: "revoked", SessionsRevokedAt: revokedAt},
+	}}
+	us := &UserService{repo: repo}
+
+	tests := []struct {
+		name     string
+		login    string
+		issuedAt time.Time
+		wantErr  error
+	}{
+		{name: "active user", login: "active", issuedAt: time.Now()},
+		{name: "unknown user", login: "nobody", issuedAt: time.Now(), wantErr: errs.ErrUserNotFound},
+		{name: "locked user", login: "locked", issuedAt: time.Now(), wantErr: errs.ErrUserLocked},
+		{name: "deleted user", login: "deleted", issuedAt: time.Now(), wantErr: errs.ErrUserNotFound},
+		{name: "token before revoke", login: "revoked", issuedAt: revokedAt.Add(-time.Minute), wantErr: errs.ErrSessionRevoked},
+		{name: "token without iat", login: "revoked", wantErr: errs.ErrSessionRevoked},
+		{name: "token after revoke", login: "revoked", issuedAt: revokedAt.Add(time.Minute)},
+		// iat is in whole seconds, the revoke time is not.
+		{name: "token of the revoke second", login: "revoked", issuedAt: revokedAt.Truncate(time.Second), wantErr: errs.ErrSessionRevoked},
+		{name: "token of the next second", login: "revoked", issuedAt: revokedAt.Truncate(time.Second).Add(time.Second)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := us.ValidateSession(context.Background(), models.Session{Login: tt.login, IssuedAt: tt.issuedAt})
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,158 @@
+// Package sshkeys generates and imports the SSH keys kept in SSH_KEY items.
+package sshkeys
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"gophkeeper/models"
+	"strings"
+
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	TypeEd25519 = "ed25519"
+	TypeRSA     = "rsa"
+
+	// DefaultRSABits is used when no RSA key size is given.
+	DefaultRSABits = 3072
+	minRSABits     = 2048
+)
+
+var (
+	// ErrPassphraseRequired is returned by Import for encrypted keys when
+	// no passphrase was given, the caller should ask for one.
+	ErrPassphraseRequired = errors.New("private key is protected by a passphrase")
+	ErrWrongPassphrase    = errors.New("incorrect passphrase")
+	ErrUnsupportedKeyType = errors.New("unsupported key type, use ed25519 or rsa")
+	ErrNoPrivateKey       = errors.New("no private key found")
+)
+
+// Generate creates a new key pair. bits is only used for RSA keys, zero
+// means DefaultRSABits.
+func Generate(keyType string, bits int, comment string) (*models.SSHKey, error) {
+	var key crypto.PrivateKey
+	switch strings.ToLower(keyType) {
+	case TypeEd25519, "":
+		_, priv, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, fmt.Errorf("generate ed25519 key error: %w", err)
+		}
+		key = priv
+	case TypeRSA:
+		if bits == 0 {
+			bits = DefaultRSABits
+		}
+		if bits < minRSABits {
+			return nil, fmt.Errorf("rsa keys need at least %d bits", minRSABits)
+		}
+		priv, err := rsa.GenerateKey(rand.Reader, bits)
+		if err != nil {
+			return nil, fmt.Errorf("generate rsa key error: %w", err)
+		}
+		key = priv
+	default:
+		return nil, ErrUnsupportedKeyType
+	}
+	return fromPrivateKey(key, comment)
+}
+
+// Import reads an OpenSSH, PKCS#1, PKCS#8 or SEC1 private key. Encrypted
+// keys are decrypted with the passphrase and stored without one, the vault
+// encryption protects them.
+func Import(pemBytes, passphrase []byte, comment string) (*models.SSHKey, error) {
+	if block, _ := pem.Decode(pemBytes); block == nil {
+		return nil, ErrNoPrivateKey
+	}
+
+	var key any
+	var err error
+	if len(passphrase) > 0 {
+		key, err = ssh.ParseRawPrivateKeyWithPassphrase(pemBytes, passphrase)
+	} else {
+		key, err = ssh.ParseRawPrivateKey(pemBytes)
+	}
+	var missing *ssh.PassphraseMissingError
+	switch {
+	case errors.As(err, &missing):
+		return nil, ErrPassphraseRequired
+	case errors.Is(err, x509.IncorrectPasswordError) || (err != nil && len(passphrase) > 0 && strings.Contains(err.Error(), "decrypt")):
+		return nil, ErrWrongPassphrase
+	case err != nil:
+		return nil, fmt.Errorf("parse private key error: %w", err)
+	}
+
+	// OpenSSH ed25519 keys are parsed into a pointer.
+	if k, ok := key.(*ed25519.PrivateKey); ok {
+		key = *k
+	}
+	return fromPrivateKey(key, comment)
+}
+
+// Signer returns the signer of a stored key.
+func Signer(key *models.SSHKey) (ssh.Signer, error) {
+	signer, err := ssh.ParsePrivateKey([]byte(key.PrivateKey))
+	if err != nil {
+		return nil, fmt.Errorf("parse private key error: %w", err)
+	}
+	return signer, nil
+}
+
+// PrivateKey returns the raw private key of a stored key.
+func PrivateKey(key *models.SSHKey) (crypto.PrivateKey, error) {
+	raw, err := ssh.ParseRawPrivateKey([]byte(key.PrivateKey))
+	if err != nil {
+		return nil, fmt.Errorf("parse private key error: %w", err)
+	}
+	if k, ok := raw.(*ed25519.PrivateKey); ok {
+		raw = *k
+	}
+	return raw, nil
+}
+
+// Export returns the private key in OpenSSH format, encrypted with the
+// passphrase when one is given.
+func Export(key *models.SSHKey, passphrase []byte) ([]byte, error) {
+	if len(passphrase) == 0 {
+		return []byte(key.PrivateKey), nil
+	}
+	raw, err := PrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+	block, err := ssh.MarshalPrivateKeyWithPassphrase(raw, key.Comment, passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("marshal private key error: %w", err)
+	}
+	return pem.EncodeToMemory(block), nil
+}
+
+func fromPrivateKey(key crypto.PrivateKey, comment string) (*models.SSHKey, error) {
+	signer, err := ssh.NewSignerFromKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("unsupported private key: %w", err)
+	}
+	block, err := ssh.MarshalPrivateKey(key, comment)
+	if err != nil {
+		return nil, fmt.Errorf("marshal private key error: %w", err)
+	}
+
+	pub := signer.PublicKey()
+	authorized := strings.TrimSuffix(string(ssh.MarshalAuthorizedKey(pub)), "\n")
+	if comment != "" {
+		authorized += " " + comment
+	}
+	return &models.SSHKey{
+		PrivateKey:  string(pem.EncodeToMemory(block)),
+		PublicKey:   authorized,
+		Fingerprint: ssh.FingerprintSHA256(pub),
+		Comment:     comment,
+		KeyType:     pub.Type(),
+	}, nil
+}
@@ -0,0 +1,77 @@
+package sshkeys
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+)
+
+func TestGenerate(t *testing.T) {
+	key, err := Generate(TypeEd25519, 0, "alice@laptop")
+	require.NoError(t, err)
+	assert.Equal(t, ssh.KeyAlgoED25519, key.KeyType)
+	assert.True(t, strings.HasPrefix(key.PublicKey, "ssh-ed25519 "))
+	assert.True(t, strings.HasSuffix(key.PublicKey, " alice@laptop"))
+	assert.True(t, strings.HasPrefix(key.Fingerprint, "SHA256:"))
+	assert.Contains(t, key.PrivateKey, "OPENSSH PRIVATE KEY")
+
+	signer, err := Signer(key)
+	require.NoError(t, err)
+	assert.Equal(t, key.Fingerprint, ssh.FingerprintSHA256(signer.PublicKey()))
+
+	rsaKey, err := Generate(TypeRSA, 2048, "")
+	require.NoError(t, err)
+	assert.Equal(t, ssh.KeyAlgoRSA, rsaKey.KeyType)
+
+	_, err = Generate(TypeRSA, 1024, "")
+	assert.Error(t, err)
+	_, err = Generate("dsa", 0, "")
+	assert.ErrorIs(t, err, ErrUnsupportedKeyType)
+}
+
+func TestImport_Passphrase(t *testing.T) {
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	block, err := ssh.MarshalPrivateKeyWithPassphrase(priv, "work", []byte("s3cret"))
+	require.NoError(t, err)
+	encrypted := pem.EncodeToMemory(block)
+
+	_, err = Import(encrypted, nil, "work")
+	assert.ErrorIs(t, err, ErrPassphraseRequired)
+	_, err = Import(encrypted, []byte("wrong"), "work")
+	assert.ErrorIs(t, err, ErrWrongPassphrase)
+
+	key, err := Import(encrypted, []byte("s3cret"), "work")
+	require.NoError(t, err)
+	pub, err := ssh.NewPublicKey(priv.Public())
+	require.NoError(t, err)
+	assert.Equal(t, ssh.FingerprintSHA256(pub), key.Fingerprint)
+
+	// The vault keeps the key without the passphrase.
+	_, err = ssh.ParseRawPrivateKey([]byte(key.PrivateKey))
+	assert.NoError(t, err)
+
+	_, err = Import([]byte("not a key"), nil, "")
+	assert.ErrorIs(t, err, ErrNoPrivateKey)
+}
+
+func TestExport(t *testing.T) {
+	key, err := Generate(TypeEd25519, 0, "deploy")
+	require.NoError(t, err)
+
+	plain, err := Export(key, nil)
+	require.NoError(t, err)
+	assert.Equal(t, key.PrivateKey, string(plain))
+
+	protected, err := Export(key, []byte("pass"))
+	require.NoError(t, err)
+	again, err := Import(protected, []byte("pass"), "deploy")
+	require.NoError(t, err)
+	assert.Equal(t, key.Fingerprint, again.Fingerprint)
+}
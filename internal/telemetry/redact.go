@@ -0,0 +1,73 @@
+package telemetry
+
+import (
+	"context"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// sensitiveKeyParts are substrings of attribute keys that are never
+// exported, whatever instrumentation set them.
+var sensitiveKeyParts = []string{
+	"password",
+	"passphrase",
+	"secret",
+	"token",
+	"key",
+	"salt",
+	"nonce",
+	"content",
+	"authorization",
+	"cookie",
+	"db.query.parameter",
+}
+
+// IsSensitive reports whether an attribute with key must not be recorded.
+func IsSensitive(key string) bool {
+	key = strings.ToLower(key)
+	for _, part := range sensitiveKeyParts {
+		if strings.Contains(key, part) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactingExporter drops sensitive attributes from spans and their events
+// before handing them to the next exporter.
+type redactingExporter struct {
+	next sdktrace.SpanExporter
+}
+
+func (e *redactingExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	clean := make([]sdktrace.ReadOnlySpan, len(spans))
+	for i, s := range spans {
+		stub := tracetest.SpanStubFromReadOnlySpan(s)
+		stub.Attributes = redact(stub.Attributes)
+		for j := range stub.Events {
+			stub.Events[j].Attributes = redact(stub.Events[j].Attributes)
+		}
+		for j := range stub.Links {
+			stub.Links[j].Attributes = redact(stub.Links[j].Attributes)
+		}
+		clean[i] = stub.Snapshot()
+	}
+	return e.next.ExportSpans(ctx, clean)
+}
+
+func (e *redactingExporter) Shutdown(ctx context.Context) error {
+	return e.next.Shutdown(ctx)
+}
+
+func redact(attrs []attribute.KeyValue) []attribute.KeyValue {
+	clean := attrs[:0:0]
+	for _, kv := range attrs {
+		if !IsSensitive(string(kv.Key)) {
+			clean = append(clean, kv)
+		}
+	}
+	return clean
+}
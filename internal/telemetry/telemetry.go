@@ -0,0 +1,82 @@
+// Package telemetry sets up OpenTelemetry tracing for the agent and server
+package telemetry
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Stdout is the trace file value that writes spans to standard output.
+const Stdout = "stdout"
+
+// Init installs the W3C trace context propagator and, when traceFile is set,
+// a tracer provider that writes spans as JSON lines to it. Without a trace
+// file the global no-op provider stays in place, but trace context is still
+// passed on. The returned function flushes and closes the exporter.
+// Should be used once when app starts
+func Init(traceFile, service string) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+	if traceFile == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	var w io.Writer = os.Stdout
+	var closer io.Closer
+	if traceFile != Stdout {
+		f, err := os.OpenFile(traceFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+		if err != nil {
+			return nil, fmt.Errorf("open trace file error: %w", err)
+		}
+		w, closer = f, f
+	}
+
+	exp, err := stdouttrace.New(stdouttrace.WithWriter(w))
+	if err != nil {
+		return nil, fmt.Errorf("create trace exporter error: %w", err)
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(&redactingExporter{next: exp}),
+		sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", service))),
+	)
+	otel.SetTracerProvider(tp)
+
+	return func(ctx context.Context) error {
+		err := tp.Shutdown(ctx)
+		if closer != nil {
+			if cerr := closer.Close(); err == nil {
+				err = cerr
+			}
+		}
+		return err
+	}, nil
+}
+
+// Tracer returns a tracer of the global provider, so spans follow Init.
+func Tracer(name string) trace.Tracer {
+	return otel.Tracer(name)
+}
+
+// End records *err on the span, if any, and ends it. Use it deferred with a
+// named error result.
+func End(span trace.Span, err *error) {
+	if err != nil && *err != nil {
+		span.RecordError(*err)
+		span.SetStatus(codes.Error, (*err).Error())
+	}
+	span.End()
+}
@@ -0,0 +1,88 @@
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestIsSensitive(t *testing.T) {
+	for _, key := range []string{"user.password", "Authorization", "item.encrypted_content", "master_key", "jwt.token", "salt"} {
+		assert.True(t, IsSensitive(key), key)
+	}
+	for _, key := range []string{"rpc.method", "db.operation.name", "item.type", "item.count"} {
+		assert.False(t, IsSensitive(key), key)
+	}
+}
+
+func TestRedactingExporter(t *testing.T) {
+	mem := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(&redactingExporter{next: mem}))
+
+	_, span := tp.Tracer("test").Start(context.Background(), "op",
+		trace.WithAttributes(attribute.String("item.type", "TEXT"), attribute.String("user.password", "hunter2")))
+	span.AddEvent("login", trace.WithAttributes(attribute.String("token", "jwt"), attribute.Int("attempt", 1)))
+	span.End()
+
+	spans := mem.GetSpans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, []attribute.KeyValue{attribute.String("item.type", "TEXT")}, spans[0].Attributes)
+	require.Len(t, spans[0].Events, 1)
+	assert.Equal(t, []attribute.KeyValue{attribute.Int("attempt", 1)}, spans[0].Events[0].Attributes)
+}
+
+func TestEnd(t *testing.T) {
+	mem := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(mem))
+
+	_, span := tp.Tracer("test").Start(context.Background(), "ok")
+	var err error
+	End(span, &err)
+
+	_, span = tp.Tracer("test").Start(context.Background(), "failed")
+	err = errors.New("boom")
+	End(span, &err)
+
+	spans := mem.GetSpans()
+	require.Len(t, spans, 2)
+	assert.Equal(t, codes.Unset, spans[0].Status.Code)
+	assert.Equal(t, codes.Error, spans[1].Status.Code)
+	assert.Equal(t, "boom", spans[1].Status.Description)
+}
+
+func TestInit_File(t *testing.T) {
+	prev := otel.GetTracerProvider()
+	defer otel.SetTracerProvider(prev)
+
+	path := filepath.Join(t.TempDir(), "traces.json")
+	shutdown, err := Init(path, "test-service")
+	require.NoError(t, err)
+
+	_, span := Tracer("test").Start(context.Background(), "save item",
+		trace.WithAttributes(attribute.String("master_password", "hunter2")))
+	span.End()
+	require.NoError(t, shutdown(context.Background()))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "save item")
+	assert.Contains(t, string(data), "test-service")
+	assert.NotContains(t, string(data), "hunter2")
+}
+
+func TestInit_Disabled(t *testing.T) {
+	shutdown, err := Init("", "test-service")
+	require.NoError(t, err)
+	assert.NoError(t, shutdown(context.Background()))
+}
@@ -0,0 +1,249 @@
+package totp
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"gophkeeper/models"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+var (
+	ErrUnsupportedURI = errors.New("expected an otpauth://totp/ or otpauth-migration:// URI")
+	ErrNoEntries      = errors.New("export holds no TOTP entries")
+)
+
+// Parse reads an otpauth:// URI, a Google Authenticator export or a bare
+// base32 secret. An export can hold several entries.
+func Parse(s string) ([]models.TOTP, error) {
+	s = strings.TrimSpace(s)
+	switch {
+	case strings.HasPrefix(s, "otpauth-migration://"):
+		return ParseMigration(s)
+	case strings.HasPrefix(s, "otpauth://"):
+		t, err := ParseURI(s)
+		if err != nil {
+			return nil, err
+		}
+		return []models.TOTP{*t}, nil
+	}
+
+	t := models.TOTP{Secret: s}
+	if err := Normalize(&t); err != nil {
+		return nil, err
+	}
+	return []models.TOTP{t}, nil
+}
+
+// ParseURI reads an otpauth://totp/Issuer:account?secret=... key URI.
+func ParseURI(uri string) (*models.TOTP, error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return nil, fmt.Errorf("parse uri error: %w", err)
+	}
+	if u.Scheme != "otpauth" || u.Host != "totp" {
+		return nil, ErrUnsupportedURI
+	}
+
+	q := u.Query()
+	t := &models.TOTP{
+		Secret:    q.Get("secret"),
+		Issuer:    q.Get("issuer"),
+		Algorithm: q.Get("algorithm"),
+	}
+	t.Issuer, t.Account = splitLabel(strings.TrimPrefix(u.Path, "/"), t.Issuer)
+
+	if v := q.Get("digits"); v != "" {
+		if t.Digits, err = strconv.Atoi(v); err != nil {
+			return nil, ErrInvalidDigits
+		}
+	}
+	if v := q.Get("period"); v != "" {
+		if t.Period, err = strconv.Atoi(v); err != nil || t.Period == 0 {
+			return nil, ErrInvalidPeriod
+		}
+	}
+	if err := Normalize(t); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// URI formats the parameters as an otpauth:// key URI.
+func URI(t *models.TOTP) string {
+	label := t.Account
+	if t.Issuer != "" {
+		label = t.Issuer + ":" + t.Account
+	}
+	q := url.Values{}
+	q.Set("secret", t.Secret)
+	if t.Issuer != "" {
+		q.Set("issuer", t.Issuer)
+	}
+	q.Set("algorithm", t.Algorithm)
+	q.Set("digits", strconv.Itoa(t.Digits))
+	q.Set("period", strconv.Itoa(t.Period))
+	return (&url.URL{Scheme: "otpauth", Host: "totp", Path: "/" + label, RawQuery: q.Encode()}).String()
+}
+
+// splitLabel splits an "Issuer:account" label. The issuer parameter wins
+// over the label prefix.
+func splitLabel(label, issuer string) (string, string) {
+	prefix, account, ok := strings.Cut(label, ":")
+	if !ok {
+		return issuer, strings.TrimSpace(label)
+	}
+	if issuer == "" {
+		issuer = strings.TrimSpace(prefix)
+	}
+	return issuer, strings.TrimSpace(account)
+}
+
+// Google Authenticator migration payload, see the MigrationPayload message
+// of the app. Only the fields needed here are decoded.
+const (
+	migrationOtpParameters = 1
+
+	otpSecret    = 1
+	otpName      = 2
+	otpIssuer    = 3
+	otpAlgorithm = 4
+	otpDigits    = 5
+	otpType      = 6
+
+	otpTypeTOTP = 2
+)
+
+// migrationAlgorithms maps the Algorithm enum of the export. MD5 and values
+// added later are kept by name so Normalize rejects them rather than
+// computing SHA1 codes that never match.
+var migrationAlgorithms = map[uint64]string{
+	0: "",
+	1: AlgorithmSHA1,
+	2: AlgorithmSHA256,
+	3: AlgorithmSHA512,
+	4: "MD5",
+}
+
+// ParseMigration reads an otpauth-migration://offline?data=... export of
+// Google Authenticator. Counter based (HOTP) entries are skipped.
+func ParseMigration(uri string) ([]models.TOTP, error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return nil, fmt.Errorf("parse uri error: %w", err)
+	}
+	if u.Scheme != "otpauth-migration" {
+		return nil, ErrUnsupportedURI
+	}
+	// Exports copied from a QR code often leave the base64 data unescaped,
+	// and the query decoding turns its + into spaces.
+	data := strings.ReplaceAll(u.Query().Get("data"), " ", "+")
+	payload, err := base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		if payload, err = base64.RawStdEncoding.DecodeString(data); err != nil {
+			return nil, fmt.Errorf("decode export data error: %w", err)
+		}
+	}
+
+	var entries []models.TOTP
+	err = consumeFields(payload, func(num protowire.Number, typ protowire.Type, b []byte) (int, error) {
+		if num != migrationOtpParameters || typ != protowire.BytesType {
+			return protowire.ConsumeFieldValue(num, typ, b), nil
+		}
+		v, n := protowire.ConsumeBytes(b)
+		if n < 0 {
+			return n, nil
+		}
+		t, ok, err := parseOtpParameters(v)
+		if err != nil {
+			return 0, err
+		}
+		if ok {
+			entries = append(entries, *t)
+		}
+		return n, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, ErrNoEntries
+	}
+	return entries, nil
+}
+
+func parseOtpParameters(b []byte) (*models.TOTP, bool, error) {
+	t := &models.TOTP{}
+	var name string
+	var kind uint64
+	err := consumeFields(b, func(num protowire.Number, typ protowire.Type, b []byte) (int, error) {
+		switch {
+		case typ == protowire.BytesType && (num == otpSecret || num == otpName || num == otpIssuer):
+			v, n := protowire.ConsumeBytes(b)
+			switch num {
+			case otpSecret:
+				t.Secret = b32.EncodeToString(v)
+			case otpName:
+				name = string(v)
+			case otpIssuer:
+				t.Issuer = string(v)
+			}
+			return n, nil
+		case typ == protowire.VarintType && (num == otpAlgorithm || num == otpDigits || num == otpType):
+			v, n := protowire.ConsumeVarint(b)
+			switch num {
+			case otpAlgorithm:
+				algorithm, ok := migrationAlgorithms[v]
+				if !ok {
+					algorithm = fmt.Sprintf("unknown (%d)", v)
+				}
+				t.Algorithm = algorithm
+			case otpDigits:
+				if v == 2 {
+					t.Digits = 8
+				}
+			case otpType:
+				kind = v
+			}
+			return n, nil
+		}
+		return protowire.ConsumeFieldValue(num, typ, b), nil
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	if kind != otpTypeTOTP {
+		return nil, false, nil
+	}
+
+	t.Issuer, t.Account = splitLabel(name, t.Issuer)
+	if err := Normalize(t); err != nil {
+		return nil, false, fmt.Errorf("entry %s: %w", name, err)
+	}
+	return t, true, nil
+}
+
+// consumeFields walks the fields of a protobuf message, fn consumes the
+// value and returns its length.
+func consumeFields(b []byte, fn func(protowire.Number, protowire.Type, []byte) (int, error)) error {
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return fmt.Errorf("malformed export data: %w", protowire.ParseError(n))
+		}
+		b = b[n:]
+		n, err := fn(num, typ, b)
+		if err != nil {
+			return err
+		}
+		if n < 0 {
+			return fmt.Errorf("malformed export data: %w", protowire.ParseError(n))
+		}
+		b = b[n:]
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+// Package totp generates RFC 6238 one-time passwords and imports them from
+// otpauth:// URIs and Google Authenticator migration exports.
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"gophkeeper/models"
+	"hash"
+	"strings"
+	"time"
+)
+
+const (
+	AlgorithmSHA1   = "SHA1"
+	AlgorithmSHA256 = "SHA256"
+	AlgorithmSHA512 = "SHA512"
+
+	DefaultDigits = 6
+	DefaultPeriod = 30
+)
+
+var (
+	ErrInvalidSecret    = errors.New("secret is not valid base32")
+	ErrInvalidAlgorithm = errors.New("algorithm must be SHA1, SHA256 or SHA512")
+	ErrInvalidDigits    = errors.New("digits must be between 6 and 8")
+	ErrInvalidPeriod    = errors.New("period must be positive")
+)
+
+var b32 = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// Normalize fills in defaults and checks the parameters. The secret is
+// stored upper case without spaces and padding.
+func Normalize(t *models.TOTP) error {
+	t.Secret = strings.TrimRight(strings.ToUpper(strings.ReplaceAll(t.Secret, " ", "")), "=")
+	if t.Secret == "" {
+		return ErrInvalidSecret
+	}
+	if _, err := b32.DecodeString(t.Secret); err != nil {
+		return ErrInvalidSecret
+	}
+
+	t.Algorithm = strings.ToUpper(t.Algorithm)
+	switch t.Algorithm {
+	case "":
+		t.Algorithm = AlgorithmSHA1
+	case AlgorithmSHA1, AlgorithmSHA256, AlgorithmSHA512:
+	default:
+		return ErrInvalidAlgorithm
+	}
+
+	if t.Digits == 0 {
+		t.Digits = DefaultDigits
+	}
+	if t.Digits < 6 || t.Digits > 8 {
+		return ErrInvalidDigits
+	}
+	if t.Period == 0 {
+		t.Period = DefaultPeriod
+	}
+	if t.Period < 0 {
+		return ErrInvalidPeriod
+	}
+	return nil
+}
+
+// Code returns the code for the time step containing now.
+func Code(t *models.TOTP, now time.Time) (string, error) {
+	params := *t
+	if err := Normalize(&params); err != nil {
+		return "", err
+	}
+	key, _ := b32.DecodeString(params.Secret)
+
+	var newHash func() hash.Hash
+	switch params.Algorithm {
+	case AlgorithmSHA256:
+		newHash = sha256.New
+	case AlgorithmSHA512:
+		newHash = sha512.New
+	default:
+		newHash = sha1.New
+	}
+
+	var counter [8]byte
+	binary.BigEndian.PutUint64(counter[:], uint64(now.Unix()/int64(params.Period)))
+	mac := hmac.New(newHash, key)
+	mac.Write(counter[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	mod := uint32(1)
+	for range params.Digits {
+		mod *= 10
+	}
+	return fmt.Sprintf("%0*d", params.Digits, value%mod), nil
+}
+
+// Remaining returns how long the code for now stays valid.
+func Remaining(t *models.TOTP, now time.Time) time.Duration {
+	period := int64(t.Period)
+	if period <= 0 {
+		period = DefaultPeriod
+	}
+	left := period - now.Unix()%period
+	return time.Duration(left) * time.Second
+}
+
+// Label is the name an imported entry is saved under.
+func Label(t *models.TOTP) string {
+	switch {
+	case t.Issuer != "" && t.Account != "":
+		return fmt.Sprintf("%s (%s)", t.Issuer, t.Account)
+	case t.Issuer != "":
+		return t.Issuer
+	case t.Account != "":
+		return t.Account
+	}
+	return "TOTP"
+}
@@ -0,0 +1,168 @@
+package totp
+
+import (
+	"encoding/base64"
+	"gophkeeper/models"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+func TestCode_RFC6238(t *testing.T) {
+	secret := func(s string, n int) string {
+		return b32.EncodeToString([]byte(strings.Repeat(s, 4)[:n]))
+	}
+	for _, tc := range []struct {
+		algorithm string
+		secret    string
+		unix      int64
+		want      string
+	}{
+		{AlgorithmSHA1, secret("12345678901234567890", 20), 59, "94287082"},
+		{AlgorithmSHA1, secret("12345678901234567890", 20), 1111111109, "07081804"},
+		{AlgorithmSHA1, secret("12345678901234567890", 20), 20000000000, "65353130"},
+		{AlgorithmSHA256, secret("12345678901234567890", 32), 59, "46119246"},
+		{AlgorithmSHA512, secret("12345678901234567890", 64), 59, "90693936"},
+	} {
+		code, err := Code(&models.TOTP{Secret: tc.secret, Algorithm: tc.algorithm, Digits: 8}, time.Unix(tc.unix, 0))
+		require.NoError(t, err)
+		assert.Equal(t, tc.want, code, "%s at %d", tc.algorithm, tc.unix)
+	}
+}
+
+func TestCode_Invalid(t *testing.T) {
+	_, err := Code(&models.TOTP{Secret: "not base32!"}, time.Now())
+	assert.ErrorIs(t, err, ErrInvalidSecret)
+	_, err = Code(&models.TOTP{Secret: "JBSWY3DPEHPK3PXP", Algorithm: "MD5"}, time.Now())
+	assert.ErrorIs(t, err, ErrInvalidAlgorithm)
+	_, err = Code(&models.TOTP{Secret: "JBSWY3DPEHPK3PXP", Digits: 4}, time.Now())
+	assert.ErrorIs(t, err, ErrInvalidDigits)
+}
+
+func TestRemaining(t *testing.T) {
+	key := &models.TOTP{Period: 30}
+	assert.Equal(t, 30*time.Second, Remaining(key, time.Unix(60, 0)))
+	assert.Equal(t, 1*time.Second, Remaining(key, time.Unix(89, 0)))
+}
+
+func TestParseURI(t *testing.T) {
+	key, err := ParseURI("otpauth://totp/ACME%20Co:john@example.com?secret=jbsw y3dpehpk3pxp&issuer=ACME%20Co&algorithm=SHA256&digits=8&period=60")
+	require.NoError(t, err)
+	assert.Equal(t, &models.TOTP{
+		Secret:    "JBSWY3DPEHPK3PXP",
+		Issuer:    "ACME Co",
+		Account:   "john@example.com",
+		Algorithm: AlgorithmSHA256,
+		Digits:    8,
+		Period:    60,
+	}, key)
+	assert.Equal(t, "ACME Co (john@example.com)", Label(key))
+
+	again, err := ParseURI(URI(key))
+	require.NoError(t, err)
+	assert.Equal(t, key, again)
+
+	key, err = ParseURI("otpauth://totp/alice?secret=JBSWY3DPEHPK3PXP")
+	require.NoError(t, err)
+	assert.Equal(t, "alice", key.Account)
+	assert.Equal(t, AlgorithmSHA1, key.Algorithm)
+	assert.Equal(t, DefaultDigits, key.Digits)
+	assert.Equal(t, DefaultPeriod, key.Period)
+
+	_, err = ParseURI("otpauth://hotp/alice?secret=JBSWY3DPEHPK3PXP&counter=1")
+	assert.ErrorIs(t, err, ErrUnsupportedURI)
+	_, err = ParseURI("otpauth://totp/alice")
+	assert.ErrorIs(t, err, ErrInvalidSecret)
+}
+
+func otpParameters(secret []byte, name, issuer string, algorithm, digits, typ uint64) []byte {
+	var b []byte
+	b = protowire.AppendTag(b, otpSecret, protowire.BytesType)
+	b = protowire.AppendBytes(b, secret)
+	b = protowire.AppendTag(b, otpName, protowire.BytesType)
+	b = protowire.AppendString(b, name)
+	b = protowire.AppendTag(b, otpIssuer, protowire.BytesType)
+	b = protowire.AppendString(b, issuer)
+	b = protowire.AppendTag(b, otpAlgorithm, protowire.VarintType)
+	b = protowire.AppendVarint(b, algorithm)
+	b = protowire.AppendTag(b, otpDigits, protowire.VarintType)
+	b = protowire.AppendVarint(b, digits)
+	b = protowire.AppendTag(b, otpType, protowire.VarintType)
+	b = protowire.AppendVarint(b, typ)
+	return b
+}
+
+func TestParseMigration(t *testing.T) {
+	var payload []byte
+	for _, p := range [][]byte{
+		otpParameters([]byte("hello!\xde\xad\xbe\xef"), "GitHub:octocat", "GitHub", 1, 1, 2),
+		otpParameters([]byte("counter-based"), "bank", "", 1, 1, 1),
+		otpParameters([]byte("0123456789"), "mail@example.com", "", 2, 2, 2),
+	} {
+		payload = protowire.AppendTag(payload, migrationOtpParameters, protowire.BytesType)
+		payload = protowire.AppendBytes(payload, p)
+	}
+	// version and batch fields are skipped
+	payload = protowire.AppendTag(payload, 2, protowire.VarintType)
+	payload = protowire.AppendVarint(payload, 1)
+
+	uri := "otpauth-migration://offline?data=" + url.QueryEscape(base64.StdEncoding.EncodeToString(payload))
+	entries, err := Parse(uri)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+
+	assert.Equal(t, "GitHub", entries[0].Issuer)
+	assert.Equal(t, "octocat", entries[0].Account)
+	assert.Equal(t, b32.EncodeToString([]byte("hello!\xde\xad\xbe\xef")), entries[0].Secret)
+	assert.Equal(t, 6, entries[0].Digits)
+
+	assert.Equal(t, "", entries[1].Issuer)
+	assert.Equal(t, "mail@example.com", entries[1].Account)
+	assert.Equal(t, AlgorithmSHA256, entries[1].Algorithm)
+	assert.Equal(t, 8, entries[1].Digits)
+
+	// The data pasted unescaped keeps its +.
+	secret := []byte("xx\xfb\xef\xbe\xfb\xef\xbe")
+	plus := protowire.AppendTag(nil, migrationOtpParameters, protowire.BytesType)
+	plus = protowire.AppendBytes(plus, otpParameters(secret, "plus", "", 1, 1, 2))
+	data := base64.StdEncoding.EncodeToString(plus)
+	require.Contains(t, data, "+")
+	entries, err = ParseMigration("otpauth-migration://offline?data=" + data)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, b32.EncodeToString(secret), entries[0].Secret)
+
+	// MD5 is not computed as SHA1, counter based entries are skipped first.
+	for _, hotp := range []bool{false, true} {
+		typ := uint64(2)
+		if hotp {
+			typ = 1
+		}
+		md5 := protowire.AppendTag(nil, migrationOtpParameters, protowire.BytesType)
+		md5 = protowire.AppendBytes(md5, otpParameters([]byte("0123456789"), "legacy", "", 4, 1, typ))
+		_, err = ParseMigration("otpauth-migration://offline?data=" + url.QueryEscape(base64.StdEncoding.EncodeToString(md5)))
+		if hotp {
+			assert.ErrorIs(t, err, ErrNoEntries)
+		} else {
+			assert.ErrorIs(t, err, ErrInvalidAlgorithm)
+		}
+	}
+
+	_, err = ParseMigration("otpauth-migration://offline?data=" + url.QueryEscape(base64.StdEncoding.EncodeToString([]byte{0x0a, 0x05})))
+	assert.Error(t, err)
+	_, err = ParseMigration("otpauth-migration://offline?data=")
+	assert.ErrorIs(t, err, ErrNoEntries)
+}
+
+func TestParse_Secret(t *testing.T) {
+	entries, err := Parse("jbsw y3dp ehpk 3pxp")
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "JBSWY3DPEHPK3PXP", entries[0].Secret)
+	assert.Equal(t, "TOTP", Label(&entries[0]))
+}
@@ -0,0 +1,32 @@
+package models
+
+import "time"
+
+type UserStats struct {
+	Login        string    `json:"login"`
+	Locked       bool      `json:"locked"`
+	Deleted      bool      `json:"deleted"`
+	CreatedAt    time.Time `json:"created_at"`
+	ItemCount    int64     `json:"item_count"`
+	StorageBytes int64     `json:"storage_bytes"`
+}
+
+type ServerStats struct {
+	UsersCount        int64 `json:"users_count"`
+	LockedUsersCount  int64 `json:"locked_users_count"`
+	DeletedUsersCount int64 `json:"deleted_users_count"`
+	ItemsCount        int64 `json:"items_count"`
+	StorageBytes      int64 `json:"storage_bytes"`
+	PendingMigrations int   `json:"pending_migrations"`
+}
+
+type Migration struct {
+	Version   string    `json:"version"`
+	Applied   bool      `json:"applied"`
+	AppliedAt time.Time `json:"applied_at"`
+}
+
+type PurgeResult struct {
+	Job     string `json:"job"`
+	Removed int64  `json:"removed"`
+}
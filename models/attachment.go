@@ -0,0 +1,59 @@
+package models
+
+import (
+	pb "gophkeeper/internal/protos/items"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// MaxAttachmentSize limits the plaintext of an attachment, the encrypted
+// upload has to fit into a single gRPC message.
+const MaxAttachmentSize = 3 << 20
+
+// Attachment is an encrypted file of an item. EncryptedInfo is encrypted with
+// the item data key and holds the filename, MIME type and the attachment key
+// the file in Content is encrypted with, so re-keying the item only re-seals
+// EncryptedInfo. Size is the plaintext size, Content is only set when the
+// attachment is uploaded or downloaded.
+type Attachment struct {
+	ID            [16]byte
+	ItemID        [16]byte
+	EncryptedInfo EncryptedData
+	Size          int64
+	Content       []byte
+	Nonce         []byte
+	CreatedAt     time.Time
+}
+
+// AttachmentInfo is the plaintext of Attachment.EncryptedInfo.
+type AttachmentInfo struct {
+	Filename string `json:"filename"`
+	MimeType string `json:"mime_type"`
+	Key      []byte `json:"key"`
+}
+
+func (a *Attachment) ToPb() *pb.Attachment {
+	return &pb.Attachment{
+		Id:            a.ID[:],
+		ItemId:        a.ItemID[:],
+		EncryptedInfo: a.EncryptedInfo.ToPb(),
+		Size:          a.Size,
+		CreatedAt:     timestamppb.New(a.CreatedAt),
+	}
+}
+
+func AttachmentPbToModels(in *pb.Attachment) *Attachment {
+	a := &Attachment{
+		ID:     ItemIdPbToModels(in.Id),
+		ItemID: ItemIdPbToModels(in.ItemId),
+		Size:   in.Size,
+	}
+	if in.EncryptedInfo != nil {
+		a.EncryptedInfo = EncryptedDataPbToModel(in.EncryptedInfo)
+	}
+	if in.CreatedAt != nil {
+		a.CreatedAt = in.CreatedAt.AsTime()
+	}
+	return a
+}
@@ -0,0 +1,323 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ConnectionKind is what a connection item connects to.
+type ConnectionKind string
+
+const (
+	ConnectionPostgres ConnectionKind = "postgres"
+	ConnectionMySQL    ConnectionKind = "mysql"
+	ConnectionHTTP     ConnectionKind = "http"
+)
+
+var ConnectionKinds = []ConnectionKind{ConnectionPostgres, ConnectionMySQL, ConnectionHTTP}
+
+// Connection renderings.
+const (
+	FormatDSN = "dsn"
+	FormatURL = "url"
+	FormatEnv = "env"
+)
+
+var ConnectionFormats = []string{FormatDSN, FormatURL, FormatEnv}
+
+var (
+	postgresTLSModes = []string{"disable", "allow", "prefer", "require", "verify-ca", "verify-full"}
+	mysqlTLSModes    = []string{"true", "false", "skip-verify", "preferred"}
+)
+
+var ErrNoDSN = errors.New("http connections have no DSN, use the url format")
+
+var _ Data = (*Connection)(nil)
+
+// Connection is what is needed to reach a database or an API. Databases
+// use Host to TLSMode, APIs use BaseURL and Token. Port 0 is the default
+// port of the kind.
+type Connection struct {
+	Kind     ConnectionKind
+	Host     string
+	Port     int
+	Database string
+	User     string
+	Password string
+	TLSMode  string
+	BaseURL  string
+	Token    string
+}
+
+func (c Connection) GetType() ItemType {
+	return ItemTypeCONNECTION
+}
+
+func isDatabase(d Data) bool { return d.(*Connection).Kind != ConnectionHTTP }
+
+func isKind(kind ConnectionKind) func(Data) bool {
+	return func(d Data) bool { return d.(*Connection).Kind == kind }
+}
+
+// connectionFields describe the form of CONNECTION items. The kind is
+// picked before and decides which fields apply.
+var connectionFields = []FieldInfo{
+	{
+		Label: "Host", Type: FieldText, Required: true, Applies: isDatabase,
+		Get: func(d Data) string { return d.(*Connection).Host },
+		Set: func(d Data, v string) { d.(*Connection).Host = v },
+	},
+	{
+		Label: "Port", Type: FieldNumber, Applies: isDatabase,
+		Get:      func(d Data) string { return d.(*Connection).port() },
+		Set:      func(d Data, v string) { d.(*Connection).Port, _ = strconv.Atoi(v) },
+		Validate: validateIntRange(1, 65535),
+	},
+	{
+		Label: "Database", Type: FieldText, Applies: isDatabase,
+		Get: func(d Data) string { return d.(*Connection).Database },
+		Set: func(d Data, v string) { d.(*Connection).Database = v },
+	},
+	{
+		Label: "User", Type: FieldText, Applies: isDatabase,
+		Get: func(d Data) string { return d.(*Connection).User },
+		Set: func(d Data, v string) { d.(*Connection).User = v },
+	},
+	{
+		Label: "Password", Type: FieldHidden, Applies: isDatabase,
+		Get: func(d Data) string { return d.(*Connection).Password },
+		Set: func(d Data, v string) { d.(*Connection).Password = v },
+	},
+	{
+		Label: "TLS mode (disable, require, verify-full...)", Type: FieldText, Applies: isKind(ConnectionPostgres),
+		Get: func(d Data) string { return d.(*Connection).TLSMode },
+		Set: func(d Data, v string) { d.(*Connection).TLSMode = v },
+	},
+	{
+		Label: "TLS mode (true, false, skip-verify, preferred)", Type: FieldText, Applies: isKind(ConnectionMySQL),
+		Get: func(d Data) string { return d.(*Connection).TLSMode },
+		Set: func(d Data, v string) { d.(*Connection).TLSMode = v },
+	},
+	{
+		Label: "Base URL", Type: FieldURL, Required: true, Applies: isKind(ConnectionHTTP),
+		Get: func(d Data) string { return d.(*Connection).BaseURL },
+		Set: func(d Data, v string) { d.(*Connection).BaseURL = v },
+	},
+	{
+		Label: "Token", Type: FieldHidden, Applies: isKind(ConnectionHTTP),
+		Get: func(d Data) string { return d.(*Connection).Token },
+		Set: func(d Data, v string) { d.(*Connection).Token = v },
+	},
+}
+
+func (c *Connection) Validate() error {
+	switch c.Kind {
+	case ConnectionPostgres, ConnectionMySQL:
+		if strings.TrimSpace(c.Host) == "" {
+			return errors.New("host is required")
+		}
+		if c.Port < 0 || c.Port > 65535 {
+			return errors.New("port must be between 1 and 65535")
+		}
+		modes := postgresTLSModes
+		if c.Kind == ConnectionMySQL {
+			modes = mysqlTLSModes
+		}
+		if c.TLSMode != "" && !slices.Contains(modes, c.TLSMode) {
+			return fmt.Errorf("TLS mode must be one of %s", strings.Join(modes, ", "))
+		}
+	case ConnectionHTTP:
+		u, err := url.ParseRequestURI(c.BaseURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return errors.New("base URL must be like https://api.example.com")
+		}
+	default:
+		return fmt.Errorf("unknown connection kind %q", c.Kind)
+	}
+	return nil
+}
+
+// ConnectionField is a named value of a connection, Secret ones are not
+// shown until revealed.
+type ConnectionField struct {
+	Name   string
+	Value  string
+	Secret bool
+}
+
+// Fields returns the set fields of the connection in a fixed order. Names
+// are what Field accepts.
+func (c *Connection) Fields() []ConnectionField {
+	var all []ConnectionField
+	if c.Kind == ConnectionHTTP {
+		all = []ConnectionField{
+			{Name: "url", Value: c.BaseURL},
+			{Name: "token", Value: c.Token, Secret: true},
+		}
+	} else {
+		all = []ConnectionField{
+			{Name: "host", Value: c.Host},
+			{Name: "port", Value: c.port()},
+			{Name: "database", Value: c.Database},
+			{Name: "user", Value: c.User},
+			{Name: "password", Value: c.Password, Secret: true},
+			{Name: "tls", Value: c.TLSMode},
+		}
+	}
+	return slices.DeleteFunc(all, func(f ConnectionField) bool { return f.Value == "" })
+}
+
+// Field returns the value of the named field.
+func (c *Connection) Field(name string) (string, error) {
+	for _, f := range c.Fields() {
+		if strings.EqualFold(f.Name, name) {
+			return f.Value, nil
+		}
+	}
+	return "", fmt.Errorf("%s connection has no %s field", c.Kind, name)
+}
+
+// Render returns the connection in one of ConnectionFormats.
+func (c *Connection) Render(format string) (string, error) {
+	switch format {
+	case FormatDSN:
+		return c.DSN()
+	case FormatURL:
+		return c.URL(), nil
+	case FormatEnv:
+		return strings.Join(c.Env(), "\n"), nil
+	default:
+		return "", fmt.Errorf("unknown format %q, use one of %s", format, strings.Join(ConnectionFormats, ", "))
+	}
+}
+
+// DSN returns a libpq keyword string for Postgres and a Go MySQL driver
+// DSN for MySQL.
+func (c *Connection) DSN() (string, error) {
+	switch c.Kind {
+	case ConnectionPostgres:
+		var parts []string
+		for _, kv := range [][2]string{
+			{"host", c.Host},
+			{"port", c.port()},
+			{"dbname", c.Database},
+			{"user", c.User},
+			{"password", c.Password},
+			{"sslmode", c.TLSMode},
+		} {
+			if kv[1] != "" {
+				parts = append(parts, kv[0]+"="+quoteLibpq(kv[1]))
+			}
+		}
+		return strings.Join(parts, " "), nil
+	case ConnectionMySQL:
+		dsn := ""
+		if c.User != "" {
+			dsn = c.User
+			if c.Password != "" {
+				dsn += ":" + c.Password
+			}
+			dsn += "@"
+		}
+		port := c.port()
+		if port == "" {
+			port = "3306"
+		}
+		dsn += fmt.Sprintf("tcp(%s)/%s", net.JoinHostPort(c.Host, port), c.Database)
+		if c.TLSMode != "" {
+			dsn += "?tls=" + url.QueryEscape(c.TLSMode)
+		}
+		return dsn, nil
+	default:
+		return "", ErrNoDSN
+	}
+}
+
+// URL returns a postgres:// or mysql:// URL, or the base URL of an API.
+func (c *Connection) URL() string {
+	if c.Kind == ConnectionHTTP {
+		return c.BaseURL
+	}
+
+	u := url.URL{Scheme: string(c.Kind), Host: c.Host}
+	if port := c.port(); port != "" {
+		u.Host = net.JoinHostPort(c.Host, port)
+	}
+	if c.Password != "" {
+		u.User = url.UserPassword(c.User, c.Password)
+	} else if c.User != "" {
+		u.User = url.User(c.User)
+	}
+	if c.Database != "" {
+		u.Path = "/" + c.Database
+	}
+	if c.TLSMode != "" {
+		param := "sslmode"
+		if c.Kind == ConnectionMySQL {
+			param = "tls"
+		}
+		u.RawQuery = url.Values{param: {c.TLSMode}}.Encode()
+	}
+	return u.String()
+}
+
+// Env returns the connection as KEY=value pairs read by the usual clients,
+// psql for Postgres and mysql for MySQL.
+func (c *Connection) Env() []string {
+	var vars [][2]string
+	switch c.Kind {
+	case ConnectionPostgres:
+		vars = [][2]string{
+			{"PGHOST", c.Host},
+			{"PGPORT", c.port()},
+			{"PGDATABASE", c.Database},
+			{"PGUSER", c.User},
+			{"PGPASSWORD", c.Password},
+			{"PGSSLMODE", c.TLSMode},
+		}
+	case ConnectionMySQL:
+		vars = [][2]string{
+			{"MYSQL_HOST", c.Host},
+			{"MYSQL_TCP_PORT", c.port()},
+			{"MYSQL_DATABASE", c.Database},
+			{"MYSQL_USER", c.User},
+			{"MYSQL_PWD", c.Password},
+		}
+	case ConnectionHTTP:
+		vars = [][2]string{
+			{"API_URL", c.BaseURL},
+			{"API_TOKEN", c.Token},
+		}
+	}
+
+	var env []string
+	for _, kv := range vars {
+		if kv[1] != "" {
+			env = append(env, kv[0]+"="+kv[1])
+		}
+	}
+	return env
+}
+
+func (c *Connection) port() string {
+	if c.Port == 0 {
+		return ""
+	}
+	return strconv.Itoa(c.Port)
+}
+
+// quoteLibpq quotes a keyword value when it is empty or has spaces, quotes
+// or backslashes.
+func quoteLibpq(value string) string {
+	if value != "" && !strings.ContainsAny(value, " '\\") {
+		return value
+	}
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	value = strings.ReplaceAll(value, `'`, `\'`)
+	return "'" + value + "'"
+}
@@ -0,0 +1,203 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	pb "gophkeeper/internal/protos/items"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// FieldType is the kind of value a custom field holds. It decides how the
+// field is entered and checked, values are always kept as strings.
+type FieldType string
+
+const (
+	FieldText      FieldType = "text"
+	FieldHidden    FieldType = "hidden"
+	FieldNumber    FieldType = "number"
+	FieldDate      FieldType = "date"
+	FieldURL       FieldType = "url"
+	FieldMultiline FieldType = "multiline"
+	FieldBoolean   FieldType = "boolean"
+)
+
+var FieldTypes = []FieldType{FieldText, FieldHidden, FieldNumber, FieldDate, FieldURL, FieldMultiline, FieldBoolean}
+
+// FieldDateLayout is the format of date field values.
+const FieldDateLayout = "2006-01-02"
+
+var (
+	ErrTemplateNameEmpty  = errors.New("template name is empty")
+	ErrTemplateNoFields   = errors.New("template has no fields")
+	ErrFieldNameEmpty     = errors.New("field name is empty")
+	ErrFieldNameDuplicate = errors.New("field names must be unique")
+)
+
+// Validate checks that value fits the field type. Empty values are valid,
+// required fields are checked by the template.
+func (t FieldType) Validate(value string) error {
+	if value == "" {
+		return nil
+	}
+	switch t {
+	case FieldText, FieldHidden:
+		if strings.ContainsAny(value, "\r\n") {
+			return errors.New("use a multiline field for several lines")
+		}
+	case FieldMultiline:
+	case FieldNumber:
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return errors.New("must be a number")
+		}
+	case FieldDate:
+		if _, err := time.Parse(FieldDateLayout, value); err != nil {
+			return errors.New("must be a date in YYYY-MM-DD format")
+		}
+	case FieldURL:
+		u, err := url.ParseRequestURI(value)
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			return errors.New("must be a URL like https://example.com")
+		}
+	case FieldBoolean:
+		if value != "true" && value != "false" {
+			return errors.New("must be true or false")
+		}
+	default:
+		return fmt.Errorf("unknown field type %q", t)
+	}
+	return nil
+}
+
+// CustomField is a named, typed value of a custom item.
+type CustomField struct {
+	Name  string
+	Type  FieldType
+	Value string
+}
+
+var _ Data = (*Custom)(nil)
+
+// Custom is an item built from a user-defined template. The fields are
+// copied from the template when the item is created, so editing or
+// deleting the template does not change existing items.
+type Custom struct {
+	TemplateID [16]byte
+	Template   string
+	Fields     []CustomField
+}
+
+func (c Custom) GetType() ItemType {
+	return ItemTypeCUSTOM
+}
+
+// Validate checks every field value against its type.
+func (c *Custom) Validate() error {
+	for _, f := range c.Fields {
+		if err := f.Type.Validate(f.Value); err != nil {
+			return fmt.Errorf("%s: %w", f.Name, err)
+		}
+	}
+	return nil
+}
+
+// TemplateField describes a field of the items made from a template.
+type TemplateField struct {
+	Name     string
+	Type     FieldType
+	Required bool
+}
+
+// ItemTemplate is a decrypted user-defined template.
+type ItemTemplate struct {
+	ID     [16]byte
+	Name   string
+	Fields []TemplateField
+
+	CreatedAt time.Time `json:"-"`
+	UpdatedAt time.Time `json:"-"`
+}
+
+func (t *ItemTemplate) Validate() error {
+	if strings.TrimSpace(t.Name) == "" {
+		return ErrTemplateNameEmpty
+	}
+	if len(t.Fields) == 0 {
+		return ErrTemplateNoFields
+	}
+	seen := make(map[string]bool, len(t.Fields))
+	for _, f := range t.Fields {
+		name := strings.ToLower(strings.TrimSpace(f.Name))
+		if name == "" {
+			return ErrFieldNameEmpty
+		}
+		if seen[name] {
+			return fmt.Errorf("%s: %w", f.Name, ErrFieldNameDuplicate)
+		}
+		seen[name] = true
+		if !slices.Contains(FieldTypes, f.Type) {
+			return fmt.Errorf("%s: unknown field type %q", f.Name, f.Type)
+		}
+	}
+	return nil
+}
+
+// NewItemData returns empty item data with the fields of the template.
+func (t *ItemTemplate) NewItemData() *Custom {
+	c := &Custom{
+		TemplateID: t.ID,
+		Template:   t.Name,
+		Fields:     make([]CustomField, len(t.Fields)),
+	}
+	for i, f := range t.Fields {
+		c.Fields[i] = CustomField{Name: f.Name, Type: f.Type}
+	}
+	return c
+}
+
+// Required reports whether the template requires a value for the field.
+func (t *ItemTemplate) Required(name string) bool {
+	for _, f := range t.Fields {
+		if f.Name == name {
+			return f.Required
+		}
+	}
+	return false
+}
+
+// Template is a template as the server stores it, encrypted with the
+// master key of its owner. An emergency takeover re-seals it with the new one.
+type Template struct {
+	ID            [16]byte
+	UserLogin     string
+	EncryptedData EncryptedData
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+func (t *Template) ToPb() *pb.ItemTemplate {
+	return &pb.ItemTemplate{
+		Id:            t.ID[:],
+		EncryptedData: t.EncryptedData.ToPb(),
+		CreatedAt:     timestamppb.New(t.CreatedAt),
+		UpdatedAt:     timestamppb.New(t.UpdatedAt),
+	}
+}
+
+func TemplatePbToModels(t *pb.ItemTemplate) *Template {
+	template := &Template{
+		ID:        ItemIdPbToModels(t.Id),
+		CreatedAt: t.CreatedAt.AsTime(),
+		UpdatedAt: t.UpdatedAt.AsTime(),
+	}
+	if t.EncryptedData != nil {
+		template.EncryptedData = EncryptedDataPbToModel(t.EncryptedData)
+	}
+	return template
+}
@@ -0,0 +1,78 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFieldType_Validate(t *testing.T) {
+	for _, tc := range []struct {
+		typ     FieldType
+		value   string
+		wantErr bool
+	}{
+		{FieldText, "hello", false},
+		{FieldText, "two\nlines", true},
+		{FieldHidden, "s3cret", false},
+		{FieldMultiline, "two\nlines", false},
+		{FieldNumber, "-12.5", false},
+		{FieldNumber, "twelve", true},
+		{FieldDate, "2026-02-28", false},
+		{FieldDate, "28.02.2026", true},
+		{FieldURL, "https://example.com/login", false},
+		{FieldURL, "example.com", true},
+		{FieldBoolean, "true", false},
+		{FieldBoolean, "yes", true},
+		{FieldType("color"), "red", true},
+		{FieldNumber, "", false},
+	} {
+		err := tc.typ.Validate(tc.value)
+		assert.Equal(t, tc.wantErr, err != nil, "%s %q", tc.typ, tc.value)
+	}
+}
+
+func TestItemTemplate_Validate(t *testing.T) {
+	tmpl := &ItemTemplate{Name: "Wi-Fi", Fields: []TemplateField{
+		{Name: "SSID", Type: FieldText, Required: true},
+		{Name: "Password", Type: FieldHidden},
+	}}
+	assert.NoError(t, tmpl.Validate())
+
+	assert.ErrorIs(t, (&ItemTemplate{Fields: tmpl.Fields}).Validate(), ErrTemplateNameEmpty)
+	assert.ErrorIs(t, (&ItemTemplate{Name: "Wi-Fi"}).Validate(), ErrTemplateNoFields)
+	assert.ErrorIs(t, (&ItemTemplate{Name: "x", Fields: []TemplateField{{Name: " ", Type: FieldText}}}).Validate(), ErrFieldNameEmpty)
+	assert.ErrorIs(t, (&ItemTemplate{Name: "x", Fields: []TemplateField{
+		{Name: "PIN", Type: FieldText}, {Name: "pin", Type: FieldNumber},
+	}}).Validate(), ErrFieldNameDuplicate)
+	assert.Error(t, (&ItemTemplate{Name: "x", Fields: []TemplateField{{Name: "a", Type: "color"}}}).Validate())
+}
+
+func TestItemTemplate_NewItemData(t *testing.T) {
+	tmpl := &ItemTemplate{ID: [16]byte{1}, Name: "Wi-Fi", Fields: []TemplateField{
+		{Name: "SSID", Type: FieldText, Required: true},
+		{Name: "Port", Type: FieldNumber},
+	}}
+	data := tmpl.NewItemData()
+	assert.Equal(t, ItemTypeCUSTOM, data.GetType())
+	assert.Equal(t, tmpl.ID, data.TemplateID)
+	require.Len(t, data.Fields, 2)
+	assert.Equal(t, CustomField{Name: "Port", Type: FieldNumber}, data.Fields[1])
+
+	assert.True(t, tmpl.Required("SSID"))
+	assert.False(t, tmpl.Required("Port"))
+	assert.False(t, tmpl.Required("missing"))
+
+	data.Fields[1].Value = "eighty"
+	assert.Error(t, data.Validate())
+	data.Fields[1].Value = "80"
+	assert.NoError(t, data.Validate())
+}
+
+func TestTemplate_Pb(t *testing.T) {
+	tmpl := &Template{ID: [16]byte{7}, EncryptedData: EncryptedData{EncryptedContent: "enc", Nonce: "n"}}
+	got := TemplatePbToModels(tmpl.ToPb())
+	assert.Equal(t, tmpl.ID, got.ID)
+	assert.Equal(t, tmpl.EncryptedData, got.EncryptedData)
+}
@@ -0,0 +1,151 @@
+package models
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	pb "gophkeeper/internal/protos/users"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Device is an agent install signed in to an account. The agent generates
+// the ID and an ed25519 key pair once; the server keeps the public key from
+// the first sign in and requires later sign ins with that ID to be signed
+// by it, so a copied device ID cannot pass for an approved device.
+type Device struct {
+	ID         [16]byte
+	Login      string
+	Name       string
+	OS         string
+	PublicKey  []byte
+	Approved   bool
+	CreatedAt  time.Time
+	LastSeenAt time.Time
+	LastSeenIP string
+
+	// Current marks the device the request came from.
+	Current bool
+}
+
+// DeviceSignIn is the device part of a sign in request. SignedAt is signed
+// too, the server takes it only once and only when it is recent.
+type DeviceSignIn struct {
+	Device    Device
+	Signature []byte
+	SignedAt  time.Time
+}
+
+// Session is what the token of a request says about its caller. DeviceID is
+// zero for tokens issued without a device.
+type Session struct {
+	Login    string
+	DeviceID [16]byte
+	IssuedAt time.Time
+	IP       string
+}
+
+func (s *Session) HasDevice() bool {
+	return s.DeviceID != [16]byte{}
+}
+
+// DeviceSignInMessage is what a device signs when it signs in. It binds the
+// signature to the password exactly as sent and to the time it was signed
+// at, so it cannot be moved to another sign in or sent again later.
+func DeviceSignInMessage(login string, deviceID [16]byte, encryptedPassword string, signedAt time.Time) []byte {
+	h := sha256.New()
+	h.Write([]byte("gophkeeper device sign in\x00"))
+	h.Write([]byte(login))
+	h.Write([]byte{0})
+	h.Write(deviceID[:])
+	h.Write(binary.BigEndian.AppendUint64(nil, uint64(signedAt.UnixNano())))
+	h.Write([]byte(encryptedPassword))
+	return h.Sum(nil)
+}
+
+// VerifySignature checks a sign in signature against the device public key.
+func (d *Device) VerifySignature(message, signature []byte) bool {
+	if len(d.PublicKey) != ed25519.PublicKeySize {
+		return false
+	}
+	return ed25519.Verify(ed25519.PublicKey(d.PublicKey), message, signature)
+}
+
+// Fingerprint is a short form of the public key to compare devices by eye
+// before approving one.
+func (d *Device) Fingerprint() string {
+	if len(d.PublicKey) == 0 {
+		return ""
+	}
+	sum := sha256.Sum256(d.PublicKey)
+	return hex.EncodeToString(sum[:8])
+}
+
+func (d *Device) ToPb() *pb.Device {
+	device := &pb.Device{
+		Id:         d.ID[:],
+		Name:       d.Name,
+		Os:         d.OS,
+		PublicKey:  d.PublicKey,
+		Approved:   d.Approved,
+		LastSeenIp: d.LastSeenIP,
+		Current:    d.Current,
+	}
+	if !d.CreatedAt.IsZero() {
+		device.CreatedAt = timestamppb.New(d.CreatedAt)
+	}
+	if !d.LastSeenAt.IsZero() {
+		device.LastSeenAt = timestamppb.New(d.LastSeenAt)
+	}
+	return device
+}
+
+func DevicePbToModels(d *pb.Device) *Device {
+	device := &Device{
+		ID:         ItemIdPbToModels(d.Id),
+		Name:       d.Name,
+		OS:         d.Os,
+		PublicKey:  d.PublicKey,
+		Approved:   d.Approved,
+		LastSeenIP: d.LastSeenIp,
+		Current:    d.Current,
+	}
+	if d.CreatedAt != nil {
+		device.CreatedAt = d.CreatedAt.AsTime()
+	}
+	if d.LastSeenAt != nil {
+		device.LastSeenAt = d.LastSeenAt.AsTime()
+	}
+	return device
+}
+
+func (s *DeviceSignIn) ToPb() *pb.DeviceSignIn {
+	if s == nil {
+		return nil
+	}
+	signIn := &pb.DeviceSignIn{
+		Device:    s.Device.ToPb(),
+		Signature: s.Signature,
+	}
+	if !s.SignedAt.IsZero() {
+		signIn.SignedAt = timestamppb.New(s.SignedAt)
+	}
+	return signIn
+}
+
+// DeviceSignInPbToModels returns nil for requests without a device.
+func DeviceSignInPbToModels(s *pb.DeviceSignIn) *DeviceSignIn {
+	if s.GetDevice() == nil {
+		return nil
+	}
+	signIn := &DeviceSignIn{
+		Device:    *DevicePbToModels(s.Device),
+		Signature: s.Signature,
+	}
+	if s.SignedAt != nil {
+		signIn.SignedAt = s.SignedAt.AsTime()
+	}
+	return signIn
+}
@@ -0,0 +1,160 @@
+package models
+
+import (
+	pb "gophkeeper/internal/protos/items"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type EmergencyAccessType string
+
+const (
+	EmergencyAccessVIEW     EmergencyAccessType = "VIEW"
+	EmergencyAccessTAKEOVER EmergencyAccessType = "TAKEOVER"
+)
+
+func (t EmergencyAccessType) IsValid() bool {
+	return t == EmergencyAccessVIEW || t == EmergencyAccessTAKEOVER
+}
+
+type EmergencyStatus string
+
+const (
+	EmergencyStatusIDLE      EmergencyStatus = "IDLE"
+	EmergencyStatusREQUESTED EmergencyStatus = "REQUESTED"
+	EmergencyStatusAPPROVED  EmergencyStatus = "APPROVED"
+)
+
+// EmergencyAccess lets the grantee reach the grantor's vault. WrappedKey is
+// the grantor's master key wrapped with the grantee's public key in advance;
+// the server hands it out only after the grantor approves a request or the
+// wait period passes without a denial.
+type EmergencyAccess struct {
+	Grantor     string
+	Grantee     string
+	Type        EmergencyAccessType
+	Status      EmergencyStatus
+	WaitPeriod  time.Duration
+	WrappedKey  string
+	RequestedAt time.Time
+	CreatedAt   time.Time
+}
+
+// AccessibleAt returns when a pending request is granted automatically.
+func (e *EmergencyAccess) AccessibleAt() time.Time {
+	return e.RequestedAt.Add(e.WaitPeriod)
+}
+
+func (e *EmergencyAccess) IsAccessible(now time.Time) bool {
+	switch e.Status {
+	case EmergencyStatusAPPROVED:
+		return true
+	case EmergencyStatusREQUESTED:
+		return !now.Before(e.AccessibleAt())
+	}
+	return false
+}
+
+// EmergencyVault is what the grantee gets once access is granted: the
+// grantor's salt and key pair for a takeover, the personal items and the
+// templates.
+type EmergencyVault struct {
+	Access    EmergencyAccess
+	Salt      string
+	Keys      *UserKeys
+	Items     []EncryptedItem
+	Templates []Template
+}
+
+// EmergencyTakeover resets the grantor's account. Password is the new login
+// password encrypted for the server, EncryptedPrivateKey, Items and
+// Templates are re-sealed with the master key derived from the new master
+// password.
+type EmergencyTakeover struct {
+	Grantor             string
+	Password            string
+	EncryptedPrivateKey string
+	Items               []EncryptedItem
+	Templates           []Template
+}
+
+func (t EmergencyAccessType) ToPb() pb.EmergencyAccessType {
+	switch t {
+	case EmergencyAccessVIEW:
+		return pb.EmergencyAccessType_EMERGENCY_ACCESS_TYPE_VIEW
+	case EmergencyAccessTAKEOVER:
+		return pb.EmergencyAccessType_EMERGENCY_ACCESS_TYPE_TAKEOVER
+	default:
+		return pb.EmergencyAccessType_EMERGENCY_ACCESS_TYPE_UNSPECIFIED
+	}
+}
+
+func EmergencyAccessTypePbToModels(t pb.EmergencyAccessType) EmergencyAccessType {
+	switch t {
+	case pb.EmergencyAccessType_EMERGENCY_ACCESS_TYPE_VIEW:
+		return EmergencyAccessVIEW
+	case pb.EmergencyAccessType_EMERGENCY_ACCESS_TYPE_TAKEOVER:
+		return EmergencyAccessTAKEOVER
+	default:
+		return ""
+	}
+}
+
+func (s EmergencyStatus) ToPb() pb.EmergencyStatus {
+	switch s {
+	case EmergencyStatusIDLE:
+		return pb.EmergencyStatus_EMERGENCY_STATUS_IDLE
+	case EmergencyStatusREQUESTED:
+		return pb.EmergencyStatus_EMERGENCY_STATUS_REQUESTED
+	case EmergencyStatusAPPROVED:
+		return pb.EmergencyStatus_EMERGENCY_STATUS_APPROVED
+	default:
+		return pb.EmergencyStatus_EMERGENCY_STATUS_UNSPECIFIED
+	}
+}
+
+func EmergencyStatusPbToModels(s pb.EmergencyStatus) EmergencyStatus {
+	switch s {
+	case pb.EmergencyStatus_EMERGENCY_STATUS_IDLE:
+		return EmergencyStatusIDLE
+	case pb.EmergencyStatus_EMERGENCY_STATUS_REQUESTED:
+		return EmergencyStatusREQUESTED
+	case pb.EmergencyStatus_EMERGENCY_STATUS_APPROVED:
+		return EmergencyStatusAPPROVED
+	default:
+		return ""
+	}
+}
+
+func (e *EmergencyAccess) ToPb() *pb.EmergencyAccess {
+	access := &pb.EmergencyAccess{
+		Grantor:    e.Grantor,
+		Grantee:    e.Grantee,
+		Type:       e.Type.ToPb(),
+		Status:     e.Status.ToPb(),
+		WaitHours:  uint32(e.WaitPeriod / time.Hour),
+		WrappedKey: e.WrappedKey,
+		CreatedAt:  timestamppb.New(e.CreatedAt),
+	}
+	if !e.RequestedAt.IsZero() {
+		access.RequestedAt = timestamppb.New(e.RequestedAt)
+	}
+	return access
+}
+
+func EmergencyAccessPbToModels(e *pb.EmergencyAccess) *EmergencyAccess {
+	access := &EmergencyAccess{
+		Grantor:    e.Grantor,
+		Grantee:    e.Grantee,
+		Type:       EmergencyAccessTypePbToModels(e.Type),
+		Status:     EmergencyStatusPbToModels(e.Status),
+		WaitPeriod: time.Duration(e.WaitHours) * time.Hour,
+		WrappedKey: e.WrappedKey,
+		CreatedAt:  e.CreatedAt.AsTime(),
+	}
+	if e.RequestedAt != nil {
+		access.RequestedAt = e.RequestedAt.AsTime()
+	}
+	return access
+}
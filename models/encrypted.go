@@ -8,9 +8,55 @@ type EncryptedItem struct {
 	Name          string
 	Type          ItemType
 	EncryptedData EncryptedData
+	EncryptedKey  string
+	CollectionID  [16]byte
 	Meta          Meta
 	CreatedAt     time.Time
 	UpdatedAt     time.Time
+
+	// Format tells where the name and metadata live. V2 items keep them in
+	// EncryptedData, V3 items in EncryptedHeader, Name and Meta are empty on
+	// the wire.
+	Format ItemFormat
+
+	// EncryptedHeader holds the name and metadata of V3 items, sealed apart
+	// from the data so a list of items opens only the headers.
+	EncryptedHeader EncryptedData
+
+	// SearchTokens are keyed blind index tokens of the name, tags and URL
+	// host. The server matches them for equality only.
+	SearchTokens []string
+
+	// ExpiresAt is when the server deletes the item, zero means never.
+	ExpiresAt time.Time
+
+	// Favorite, LastUsedAt and UseCount order the item list. They are kept
+	// by the server outside the encrypted data and set by their own RPCs,
+	// never by an edit.
+	Favorite   bool
+	LastUsedAt time.Time
+	UseCount   int32
+}
+
+// Expired reports whether the item is past its expiry at now.
+func (i *EncryptedItem) Expired(now time.Time) bool {
+	return !i.ExpiresAt.IsZero() && !now.Before(i.ExpiresAt)
+}
+
+type ItemFormat int16
+
+const (
+	ItemFormatV1 ItemFormat = 1
+	ItemFormatV2 ItemFormat = 2
+	ItemFormatV3 ItemFormat = 3
+)
+
+// FormatPbToModels maps the wire format, unset means items from older clients.
+func FormatPbToModels(f uint32) ItemFormat {
+	if f == 0 {
+		return ItemFormatV1
+	}
+	return ItemFormat(f)
 }
 
 type EncryptedData struct {
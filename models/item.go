@@ -1,8 +1,15 @@
 package models
 
 import (
+	"encoding/base32"
+	"errors"
 	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
 	"time"
+
+	pb "gophkeeper/internal/protos/items"
 )
 
 type ItemType string
@@ -17,9 +24,24 @@ const (
 	ItemTypeTEXT        ItemType = "TEXT"
 	ItemTypeBINARY      ItemType = "BINARY"
 	ItemTypeCARD        ItemType = "CARD"
+	ItemTypeSSHKEY      ItemType = "SSH_KEY"
+	ItemTypeTOTP        ItemType = "TOTP"
+	ItemTypeCUSTOM      ItemType = "CUSTOM"
+	ItemTypeIDENTITY    ItemType = "IDENTITY"
+	ItemTypeCONNECTION  ItemType = "CONNECTION"
 )
 
-var ItemTypes []ItemType = []ItemType{ItemTypeCREDENTIALS, ItemTypeTEXT, ItemTypeBINARY, ItemTypeCARD}
+func init() {
+	RegisterType(TypeInfo{Type: ItemTypeCREDENTIALS, Pb: pb.ItemType_ITEM_TYPE_CREDENTIALS, Name: "Credentials", New: func() Data { return &Credentials{} }, Fields: credentialsFields})
+	RegisterType(TypeInfo{Type: ItemTypeTEXT, Pb: pb.ItemType_ITEM_TYPE_TEXT, Name: "Text", New: func() Data { return &Text{} }, Fields: textFields})
+	RegisterType(TypeInfo{Type: ItemTypeBINARY, Pb: pb.ItemType_ITEM_TYPE_BINARY, Name: "Binary", New: func() Data { return &Binary{} }, Fields: binaryFields})
+	RegisterType(TypeInfo{Type: ItemTypeCARD, Pb: pb.ItemType_ITEM_TYPE_CARD, Name: "Credit Card", New: func() Data { return &Card{} }, Fields: cardFields})
+	RegisterType(TypeInfo{Type: ItemTypeSSHKEY, Pb: pb.ItemType_ITEM_TYPE_SSH_KEY, Name: "SSH Key", New: func() Data { return &SSHKey{} }, Fields: sshKeyFields})
+	RegisterType(TypeInfo{Type: ItemTypeTOTP, Pb: pb.ItemType_ITEM_TYPE_TOTP, Name: "Authenticator (TOTP)", New: func() Data { return &TOTP{} }, Fields: totpFields})
+	RegisterType(TypeInfo{Type: ItemTypeCUSTOM, Pb: pb.ItemType_ITEM_TYPE_CUSTOM, Name: "Custom (from template)", New: func() Data { return &Custom{} }})
+	RegisterType(TypeInfo{Type: ItemTypeIDENTITY, Pb: pb.ItemType_ITEM_TYPE_IDENTITY, Name: "Identity document", New: func() Data { return &Identity{} }, Fields: identityFields})
+	RegisterType(TypeInfo{Type: ItemTypeCONNECTION, Pb: pb.ItemType_ITEM_TYPE_CONNECTION, Name: "Connection (database, API)", New: func() Data { return &Connection{} }, Fields: connectionFields})
+}
 
 type Item struct {
 	ID        [16]byte
@@ -30,6 +52,17 @@ type Item struct {
 	Meta      Meta
 	CreatedAt time.Time
 	UpdatedAt time.Time
+
+	// EncryptedKey is the item data key wrapped with the owner's master key.
+	// Empty for items encrypted with the master key directly.
+	EncryptedKey string
+
+	// CollectionID is set for organization items. Their data key is sealed
+	// with the collection key instead of the master key.
+	CollectionID [16]byte
+
+	// ExpiresAt is when the item self-destructs, zero means never.
+	ExpiresAt time.Time
 }
 
 type Meta struct {
@@ -51,6 +84,19 @@ func (c Credentials) GetType() ItemType {
 	return ItemTypeCREDENTIALS
 }
 
+var credentialsFields = []FieldInfo{
+	{
+		Label: "Login", Type: FieldText, Required: true,
+		Get: func(d Data) string { return d.(*Credentials).Login },
+		Set: func(d Data, v string) { d.(*Credentials).Login = v },
+	},
+	{
+		Label: "Password", Type: FieldHidden,
+		Get: func(d Data) string { return d.(*Credentials).Password },
+		Set: func(d Data, v string) { d.(*Credentials).Password = v },
+	},
+}
+
 var _ Data = (*Text)(nil)
 
 type Text struct {
@@ -61,6 +107,14 @@ func (t Text) GetType() ItemType {
 	return ItemTypeTEXT
 }
 
+var textFields = []FieldInfo{
+	{
+		Label: "Content", Type: FieldMultiline, Required: true,
+		Get: func(d Data) string { return d.(*Text).Content },
+		Set: func(d Data, v string) { d.(*Text).Content = v },
+	},
+}
+
 var _ Data = (*Binary)(nil)
 
 type Binary struct {
@@ -71,6 +125,14 @@ func (b Binary) GetType() ItemType {
 	return ItemTypeBINARY
 }
 
+var binaryFields = []FieldInfo{
+	{
+		Label: "Content", Type: FieldMultiline, Required: true,
+		Get: func(d Data) string { return string(d.(*Binary).Content) },
+		Set: func(d Data, v string) { d.(*Binary).Content = []byte(v) },
+	},
+}
+
 var _ Data = (*Card)(nil)
 
 type Card struct {
@@ -84,17 +146,291 @@ func (c Card) GetType() ItemType {
 	return ItemTypeCARD
 }
 
+var cardFields = []FieldInfo{
+	{
+		Label: "Number", Type: FieldText, Required: true,
+		Get:      func(d Data) string { return d.(*Card).Number },
+		Set:      func(d Data, v string) { d.(*Card).Number = v },
+		Validate: validateCardNumber,
+	},
+	{
+		Label: "Expiry", Type: FieldText, Required: true,
+		Get:      func(d Data) string { return d.(*Card).ExpiryDate },
+		Set:      func(d Data, v string) { d.(*Card).ExpiryDate = v },
+		Validate: validateCardExpiry,
+	},
+	{
+		Label: "CVV", Type: FieldHidden, Required: true,
+		Get:      func(d Data) string { return d.(*Card).SecurityCode },
+		Set:      func(d Data, v string) { d.(*Card).SecurityCode = v },
+		Validate: validateCVV,
+	},
+	{
+		Label: "Cardholder", Type: FieldText, Required: true,
+		Get: func(d Data) string { return d.(*Card).CardholderName },
+		Set: func(d Data, v string) { d.(*Card).CardholderName = v },
+	},
+}
+
+var (
+	digitsRe       = regexp.MustCompile(`^\d+$`)
+	cardExpiryRe   = regexp.MustCompile(`^(\d{2})/\d{2}$`)
+	securityCodeRe = regexp.MustCompile(`^\d{3}$`)
+)
+
+// validateCardNumber accepts 16 or 18 digits, spaces and dashes between
+// them are ignored.
+func validateCardNumber(number string) error {
+	cleaned := strings.ReplaceAll(strings.ReplaceAll(number, " ", ""), "-", "")
+	if !digitsRe.MatchString(cleaned) {
+		return errors.New("must contain only digits")
+	}
+	if len(cleaned) != 16 && len(cleaned) != 18 {
+		return errors.New("must be 16 or 18 digits")
+	}
+	return nil
+}
+
+func validateCardExpiry(expiry string) error {
+	m := cardExpiryRe.FindStringSubmatch(expiry)
+	if m == nil {
+		return errors.New("must be in MM/YY format")
+	}
+	if m[1] < "01" || m[1] > "12" {
+		return errors.New("month must be between 01 and 12")
+	}
+	return nil
+}
+
+func validateCVV(cvv string) error {
+	if !securityCodeRe.MatchString(cvv) {
+		return errors.New("must be exactly 3 digits")
+	}
+	return nil
+}
+
+var _ Data = (*SSHKey)(nil)
+
+// SSHKey is an SSH key pair. PrivateKey is an unencrypted OpenSSH PEM block,
+// the vault encryption protects it. PublicKey is in authorized_keys format.
+type SSHKey struct {
+	PrivateKey  string
+	PublicKey   string
+	Fingerprint string
+	Comment     string
+	KeyType     string
+}
+
+func (k SSHKey) GetType() ItemType {
+	return ItemTypeSSHKEY
+}
+
+// sshKeyFields describe what can be changed of an SSH key. The keys are
+// generated or loaded from a file and kept as they are.
+var sshKeyFields = []FieldInfo{
+	{
+		Label: "Comment", Type: FieldText,
+		Get: func(d Data) string { return d.(*SSHKey).Comment },
+		Set: func(d Data, v string) { d.(*SSHKey).Comment = v },
+	},
+}
+
+var _ Data = (*TOTP)(nil)
+
+// TOTP is a time-based one-time password generator. Secret is base32
+// encoded, Algorithm is SHA1, SHA256 or SHA512.
+type TOTP struct {
+	Secret    string
+	Issuer    string
+	Account   string
+	Algorithm string
+	Digits    int
+	Period    int
+}
+
+func (t TOTP) GetType() ItemType {
+	return ItemTypeTOTP
+}
+
+// totpFields describe the parameters of a TOTP item. Empty algorithm,
+// digits and period mean the defaults.
+var totpFields = []FieldInfo{
+	{
+		Label: "Issuer", Type: FieldText,
+		Get: func(d Data) string { return d.(*TOTP).Issuer },
+		Set: func(d Data, v string) { d.(*TOTP).Issuer = v },
+	},
+	{
+		Label: "Account", Type: FieldText,
+		Get: func(d Data) string { return d.(*TOTP).Account },
+		Set: func(d Data, v string) { d.(*TOTP).Account = v },
+	},
+	{
+		Label: "Secret", Type: FieldHidden, Required: true,
+		Get:      func(d Data) string { return d.(*TOTP).Secret },
+		Set:      func(d Data, v string) { d.(*TOTP).Secret = normalizeTOTPSecret(v) },
+		Validate: validateTOTPSecret,
+	},
+	{
+		Label: "Algorithm (SHA1, SHA256, SHA512)", Type: FieldText,
+		Get:      func(d Data) string { return d.(*TOTP).Algorithm },
+		Set:      func(d Data, v string) { d.(*TOTP).Algorithm = strings.ToUpper(v) },
+		Validate: validateTOTPAlgorithm,
+	},
+	{
+		Label: "Digits", Type: FieldNumber,
+		Get:      func(d Data) string { return formatInt(d.(*TOTP).Digits) },
+		Set:      func(d Data, v string) { d.(*TOTP).Digits, _ = strconv.Atoi(v) },
+		Validate: validateIntRange(6, 8),
+	},
+	{
+		Label: "Period", Type: FieldNumber,
+		Get:      func(d Data) string { return formatInt(d.(*TOTP).Period) },
+		Set:      func(d Data, v string) { d.(*TOTP).Period, _ = strconv.Atoi(v) },
+		Validate: validatePositiveInt,
+	},
+}
+
+var totpSecretEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// normalizeTOTPSecret returns the secret upper case without spaces and
+// padding, the way it is stored.
+func normalizeTOTPSecret(secret string) string {
+	return strings.TrimRight(strings.ToUpper(strings.ReplaceAll(secret, " ", "")), "=")
+}
+
+func validateTOTPSecret(secret string) error {
+	if _, err := totpSecretEncoding.DecodeString(normalizeTOTPSecret(secret)); err != nil {
+		return errors.New("must be base32")
+	}
+	return nil
+}
+
+func validateTOTPAlgorithm(algorithm string) error {
+	switch strings.ToUpper(algorithm) {
+	case "SHA1", "SHA256", "SHA512":
+		return nil
+	}
+	return errors.New("must be SHA1, SHA256 or SHA512")
+}
+
+var _ Data = (*Identity)(nil)
+
+// Identity is a passport, driver license or ID card. Dates are in
+// IdentityDateLayout. The scans are attachments of the item, FrontImage and
+// BackImage hold their ids.
+type Identity struct {
+	DocumentType string
+	Number       string
+	FullName     string
+	Country      string
+	IssueDate    string
+	ExpiryDate   string
+	FrontImage   [16]byte
+	BackImage    [16]byte
+}
+
+// IdentityDateLayout is the format of identity document dates.
+const IdentityDateLayout = "2006-01-02"
+
+func (i Identity) GetType() ItemType {
+	return ItemTypeIDENTITY
+}
+
+// identityFields describe the form of IDENTITY items. The images are
+// attached separately.
+var identityFields = []FieldInfo{
+	{
+		Label: "Document type", Type: FieldText, Required: true,
+		Get: func(d Data) string { return d.(*Identity).DocumentType },
+		Set: func(d Data, v string) { d.(*Identity).DocumentType = v },
+	},
+	{
+		Label: "Number", Type: FieldHidden, Required: true,
+		Get: func(d Data) string { return d.(*Identity).Number },
+		Set: func(d Data, v string) { d.(*Identity).Number = v },
+	},
+	{
+		Label: "Full name", Type: FieldText,
+		Get: func(d Data) string { return d.(*Identity).FullName },
+		Set: func(d Data, v string) { d.(*Identity).FullName = v },
+	},
+	{
+		Label: "Country", Type: FieldText,
+		Get: func(d Data) string { return d.(*Identity).Country },
+		Set: func(d Data, v string) { d.(*Identity).Country = v },
+	},
+	{
+		Label: "Issue date", Type: FieldDate,
+		Get: func(d Data) string { return d.(*Identity).IssueDate },
+		Set: func(d Data, v string) { d.(*Identity).IssueDate = v },
+	},
+	{
+		Label: "Expiry date", Type: FieldDate,
+		Get: func(d Data) string { return d.(*Identity).ExpiryDate },
+		Set: func(d Data, v string) { d.(*Identity).ExpiryDate = v },
+	},
+}
+
+// Validate checks that the document expires after it was issued.
+func (i *Identity) Validate() error {
+	if i.IssueDate == "" || i.ExpiryDate == "" {
+		return nil
+	}
+	issuedAt, err := time.Parse(IdentityDateLayout, i.IssueDate)
+	if err != nil {
+		return errors.New("issue date must be in YYYY-MM-DD format")
+	}
+	expiresAt, err := time.Parse(IdentityDateLayout, i.ExpiryDate)
+	if err != nil {
+		return errors.New("expiry date must be in YYYY-MM-DD format")
+	}
+	if !expiresAt.After(issuedAt) {
+		return errors.New("expiry date must be after the issue date")
+	}
+	return nil
+}
+
+// Expired reports whether the document expired before now. Documents
+// without an expiry date never expire.
+func (i Identity) Expired(now time.Time) bool {
+	expiry, err := time.Parse(IdentityDateLayout, i.ExpiryDate)
+	if err != nil {
+		return false
+	}
+	return !now.Before(expiry.AddDate(0, 0, 1))
+}
+
+// validateIntRange returns a check of whole numbers between min and max.
+func validateIntRange(min, max int) func(string) error {
+	return func(value string) error {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < min || n > max {
+			return fmt.Errorf("must be a whole number between %d and %d", min, max)
+		}
+		return nil
+	}
+}
+
+func validatePositiveInt(value string) error {
+	if n, err := strconv.Atoi(value); err != nil || n <= 0 {
+		return errors.New("must be a positive whole number")
+	}
+	return nil
+}
+
+// formatInt returns n as a field value, zero is empty.
+func formatInt(n int) string {
+	if n == 0 {
+		return ""
+	}
+	return strconv.Itoa(n)
+}
+
 func (t ItemType) CreateDataByType() (Data, error) {
-	switch t {
-	case ItemTypeCREDENTIALS:
-		return &Credentials{}, nil
-	case ItemTypeTEXT:
-		return &Text{}, nil
-	case ItemTypeBINARY:
-		return &Binary{}, nil
-	case ItemTypeCARD:
-		return &Card{}, nil
-	default:
+	info, ok := LookupType(t)
+	if !ok {
 		return nil, fmt.Errorf("unknown item type: %s", t)
 	}
+	return info.New(), nil
 }
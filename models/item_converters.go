@@ -7,7 +7,7 @@ import (
 )
 
 func EncryptedItemPbToModels(i *pb.EncryptedItem) *EncryptedItem {
-	return &EncryptedItem{
+	item := &EncryptedItem{
 		ID:            ItemIdPbToModels(i.Id),
 		UserLogin:     i.UserLogin,
 		Name:          i.Name,
@@ -16,7 +16,23 @@ func EncryptedItemPbToModels(i *pb.EncryptedItem) *EncryptedItem {
 		Meta:          Meta{Map: i.Meta},
 		CreatedAt:     i.CreatedAt.AsTime(),
 		UpdatedAt:     i.UpdatedAt.AsTime(),
+		EncryptedKey:  i.EncryptedKey,
+		CollectionID:  ItemIdPbToModels(i.CollectionId),
+		Format:        FormatPbToModels(i.Format),
+		SearchTokens:  i.SearchTokens,
+		Favorite:      i.Favorite,
+		UseCount:      i.UseCount,
 	}
+	if i.EncryptedHeader != nil {
+		item.EncryptedHeader = EncryptedDataPbToModel(i.EncryptedHeader)
+	}
+	if i.ExpiresAt != nil {
+		item.ExpiresAt = i.ExpiresAt.AsTime()
+	}
+	if i.LastUsedAt != nil {
+		item.LastUsedAt = i.LastUsedAt.AsTime()
+	}
+	return item
 }
 
 func ItemIdPbToModels(idPb []byte) [16]byte {
@@ -35,6 +51,23 @@ func (i *EncryptedItem) ToPb() (*pb.EncryptedItem, error) {
 		Meta:          i.Meta.Map,
 		CreatedAt:     timestamppb.New(i.CreatedAt),
 		UpdatedAt:     timestamppb.New(i.UpdatedAt),
+		EncryptedKey:  i.EncryptedKey,
+		Format:        uint32(i.Format),
+		SearchTokens:  i.SearchTokens,
+		Favorite:      i.Favorite,
+		UseCount:      i.UseCount,
+	}
+	if i.CollectionID != ([16]byte{}) {
+		item.CollectionId = i.CollectionID[:]
+	}
+	if i.EncryptedHeader != (EncryptedData{}) {
+		item.EncryptedHeader = i.EncryptedHeader.ToPb()
+	}
+	if !i.ExpiresAt.IsZero() {
+		item.ExpiresAt = timestamppb.New(i.ExpiresAt)
+	}
+	if !i.LastUsedAt.IsZero() {
+		item.LastUsedAt = timestamppb.New(i.LastUsedAt)
 	}
 
 	return &item, nil
@@ -55,35 +88,15 @@ func EncryptedDataPbToModel(ed *pb.EncryptedData) EncryptedData {
 }
 
 func ItemTypePbToModel(t pb.ItemType) ItemType {
-	switch t {
-	case pb.ItemType_ITEM_TYPE_UNSPECIFIED:
-		return ItemTypeUNSPECIFIED
-	case pb.ItemType_ITEM_TYPE_CREDENTIALS:
-		return ItemTypeCREDENTIALS
-	case pb.ItemType_ITEM_TYPE_TEXT:
-		return ItemTypeTEXT
-	case pb.ItemType_ITEM_TYPE_BINARY:
-		return ItemTypeBINARY
-	case pb.ItemType_ITEM_TYPE_CARD:
-		return ItemTypeCARD
-	default:
-		return ItemTypeUNSPECIFIED
+	if typ, ok := itemTypeByPb(t); ok {
+		return typ
 	}
+	return ItemTypeUNSPECIFIED
 }
 
 func (t *ItemType) ToPb() pb.ItemType {
-	switch *t {
-	case ItemTypeUNSPECIFIED:
-		return pb.ItemType_ITEM_TYPE_UNSPECIFIED
-	case ItemTypeCREDENTIALS:
-		return pb.ItemType_ITEM_TYPE_CREDENTIALS
-	case ItemTypeTEXT:
-		return pb.ItemType_ITEM_TYPE_TEXT
-	case ItemTypeBINARY:
-		return pb.ItemType_ITEM_TYPE_BINARY
-	case ItemTypeCARD:
-		return pb.ItemType_ITEM_TYPE_CARD
-	default:
-		return pb.ItemType_ITEM_TYPE_UNSPECIFIED
+	if info, ok := LookupType(*t); ok {
+		return info.Pb
 	}
+	return pb.ItemType_ITEM_TYPE_UNSPECIFIED
 }
@@ -39,6 +39,25 @@ func TestEncryptedItemPbToModels(t *testing.T) {
 	assert.Equal(t, map[string]string{"key": "value"}, result.Meta.Map)
 	assert.Equal(t, now.Unix(), result.CreatedAt.Unix())
 	assert.Equal(t, now.Unix(), result.UpdatedAt.Unix())
+	assert.Equal(t, ItemFormatV1, result.Format)
+
+	pbItem.Format = uint32(ItemFormatV2)
+	result = EncryptedItemPbToModels(pbItem)
+	assert.Equal(t, ItemFormatV2, result.Format)
+	back, err := result.ToPb()
+	require.NoError(t, err)
+	assert.Equal(t, uint32(ItemFormatV2), back.Format)
+	assert.True(t, result.ExpiresAt.IsZero())
+	assert.Nil(t, back.ExpiresAt)
+
+	pbItem.ExpiresAt = timestamppb.New(now.Add(time.Hour))
+	result = EncryptedItemPbToModels(pbItem)
+	assert.Equal(t, now.Add(time.Hour).Unix(), result.ExpiresAt.Unix())
+	assert.False(t, result.Expired(now))
+	assert.True(t, result.Expired(now.Add(2*time.Hour)))
+	back, err = result.ToPb()
+	require.NoError(t, err)
+	assert.Equal(t, now.Add(time.Hour).Unix(), back.ExpiresAt.AsTime().Unix())
 }
 
 func TestItemIdPbToModels(t *testing.T) {
@@ -164,6 +183,31 @@ func TestItemTypePbToModel(t *testing.T) {
 			input:    pb.ItemType_ITEM_TYPE_CARD,
 			expected: ItemTypeCARD,
 		},
+		{
+			name:     "ssh key",
+			input:    pb.ItemType_ITEM_TYPE_SSH_KEY,
+			expected: ItemTypeSSHKEY,
+		},
+		{
+			name:     "totp",
+			input:    pb.ItemType_ITEM_TYPE_TOTP,
+			expected: ItemTypeTOTP,
+		},
+		{
+			name:     "custom",
+			input:    pb.ItemType_ITEM_TYPE_CUSTOM,
+			expected: ItemTypeCUSTOM,
+		},
+		{
+			name:     "identity",
+			input:    pb.ItemType_ITEM_TYPE_IDENTITY,
+			expected: ItemTypeIDENTITY,
+		},
+		{
+			name:     "connection",
+			input:    pb.ItemType_ITEM_TYPE_CONNECTION,
+			expected: ItemTypeCONNECTION,
+		},
 		{
 			name:     "unknown value",
 			input:    pb.ItemType(999),
@@ -210,6 +254,31 @@ func TestItemType_ToPb(t *testing.T) {
 			input:    ItemTypeCARD,
 			expected: pb.ItemType_ITEM_TYPE_CARD,
 		},
+		{
+			name:     "ssh key",
+			input:    ItemTypeSSHKEY,
+			expected: pb.ItemType_ITEM_TYPE_SSH_KEY,
+		},
+		{
+			name:     "totp",
+			input:    ItemTypeTOTP,
+			expected: pb.ItemType_ITEM_TYPE_TOTP,
+		},
+		{
+			name:     "custom",
+			input:    ItemTypeCUSTOM,
+			expected: pb.ItemType_ITEM_TYPE_CUSTOM,
+		},
+		{
+			name:     "identity",
+			input:    ItemTypeIDENTITY,
+			expected: pb.ItemType_ITEM_TYPE_IDENTITY,
+		},
+		{
+			name:     "connection",
+			input:    ItemTypeCONNECTION,
+			expected: pb.ItemType_ITEM_TYPE_CONNECTION,
+		},
 		{
 			name:     "unknown value",
 			input:    ItemType("UNKNOWN"),
@@ -2,6 +2,7 @@ package models
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -124,6 +125,36 @@ func TestItemType_CreateDataByType(t *testing.T) {
 			expectedType: ItemTypeCARD,
 			wantErr:      false,
 		},
+		{
+			name:         "create ssh key",
+			itemType:     ItemTypeSSHKEY,
+			expectedType: ItemTypeSSHKEY,
+			wantErr:      false,
+		},
+		{
+			name:         "create totp",
+			itemType:     ItemTypeTOTP,
+			expectedType: ItemTypeTOTP,
+			wantErr:      false,
+		},
+		{
+			name:         "create custom",
+			itemType:     ItemTypeCUSTOM,
+			expectedType: ItemTypeCUSTOM,
+			wantErr:      false,
+		},
+		{
+			name:         "create identity",
+			itemType:     ItemTypeIDENTITY,
+			expectedType: ItemTypeIDENTITY,
+			wantErr:      false,
+		},
+		{
+			name:         "create connection",
+			itemType:     ItemTypeCONNECTION,
+			expectedType: ItemTypeCONNECTION,
+			wantErr:      false,
+		},
 		{
 			name:     "unknown type",
 			itemType: ItemType("UNKNOWN"),
@@ -186,6 +217,41 @@ func TestItemType_CreateDataByType_Types(t *testing.T) {
 		_, ok := data.(*Card)
 		assert.True(t, ok)
 	})
+
+	t.Run("ssh key returns SSHKey struct", func(t *testing.T) {
+		data, err := ItemTypeSSHKEY.CreateDataByType()
+		require.NoError(t, err)
+		_, ok := data.(*SSHKey)
+		assert.True(t, ok)
+	})
+
+	t.Run("totp returns TOTP struct", func(t *testing.T) {
+		data, err := ItemTypeTOTP.CreateDataByType()
+		require.NoError(t, err)
+		_, ok := data.(*TOTP)
+		assert.True(t, ok)
+	})
+
+	t.Run("custom returns Custom struct", func(t *testing.T) {
+		data, err := ItemTypeCUSTOM.CreateDataByType()
+		require.NoError(t, err)
+		_, ok := data.(*Custom)
+		assert.True(t, ok)
+	})
+
+	t.Run("identity returns Identity struct", func(t *testing.T) {
+		data, err := ItemTypeIDENTITY.CreateDataByType()
+		require.NoError(t, err)
+		_, ok := data.(*Identity)
+		assert.True(t, ok)
+	})
+
+	t.Run("connection returns Connection struct", func(t *testing.T) {
+		data, err := ItemTypeCONNECTION.CreateDataByType()
+		require.NoError(t, err)
+		_, ok := data.(*Connection)
+		assert.True(t, ok)
+	})
 }
 
 func TestItemTypes_Constant(t *testing.T) {
@@ -195,6 +261,11 @@ func TestItemTypes_Constant(t *testing.T) {
 		ItemTypeTEXT,
 		ItemTypeBINARY,
 		ItemTypeCARD,
+		ItemTypeSSHKEY,
+		ItemTypeTOTP,
+		ItemTypeCUSTOM,
+		ItemTypeIDENTITY,
+		ItemTypeCONNECTION,
 	}
 
 	assert.Len(t, ItemTypes, len(expectedTypes))
@@ -263,3 +334,104 @@ func TestDataStructures_DefaultValues(t *testing.T) {
 		assert.Equal(t, ItemTypeCARD, card.GetType())
 	})
 }
+
+func TestIdentity_Expired(t *testing.T) {
+	id := Identity{ExpiryDate: "2030-06-15"}
+	assert.False(t, id.Expired(time.Date(2030, 6, 15, 23, 0, 0, 0, time.UTC)))
+	assert.True(t, id.Expired(time.Date(2030, 6, 16, 0, 0, 0, 0, time.UTC)))
+	assert.False(t, Identity{}.Expired(time.Now()))
+}
+
+func TestIdentity_Validate(t *testing.T) {
+	assert.NoError(t, (&Identity{}).Validate())
+	assert.NoError(t, (&Identity{IssueDate: "2020-01-01"}).Validate())
+	assert.NoError(t, (&Identity{IssueDate: "2020-01-01", ExpiryDate: "2030-01-01"}).Validate())
+	assert.Error(t, (&Identity{IssueDate: "2030-01-01", ExpiryDate: "2020-01-01"}).Validate())
+	assert.Error(t, (&Identity{IssueDate: "2020-01-01", ExpiryDate: "2020-01-01"}).Validate())
+	assert.Error(t, (&Identity{IssueDate: "01/2020", ExpiryDate: "2030-01-01"}).Validate())
+}
+
+func TestValidateCardNumber_Valid(t *testing.T) {
+	// Test valid 16-digit card
+	err := validateCardNumber("1234567890123456")
+	assert.NoError(t, err)
+
+	// Test valid 18-digit card
+	err = validateCardNumber("123456789012345678")
+	assert.NoError(t, err)
+
+	// Test with spaces and dashes
+	err = validateCardNumber("1234 5678 9012 3456")
+	assert.NoError(t, err)
+
+	err = validateCardNumber("1234-5678-9012-3456")
+	assert.NoError(t, err)
+}
+
+func TestValidateCardNumber_Invalid(t *testing.T) {
+	// Test invalid length
+	err := validateCardNumber("12345")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "must be 16 or 18 digits")
+
+	// Test non-numeric
+	err = validateCardNumber("abcd5678901234567")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "must contain only digits")
+
+	// Test empty
+	err = validateCardNumber("")
+	assert.Error(t, err)
+}
+
+func TestValidateCardExpiry_Valid(t *testing.T) {
+	err := validateCardExpiry("12/25")
+	assert.NoError(t, err)
+
+	err = validateCardExpiry("01/30")
+	assert.NoError(t, err)
+}
+
+func TestValidateCardExpiry_Invalid(t *testing.T) {
+	// Test wrong format
+	err := validateCardExpiry("1225")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "MM/YY format")
+
+	err = validateCardExpiry("12-25")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "MM/YY format")
+
+	// Test invalid month
+	err = validateCardExpiry("13/25")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "month must be between 01 and 12")
+
+	err = validateCardExpiry("00/25")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "month must be between 01 and 12")
+}
+
+func TestValidateCVV_Valid(t *testing.T) {
+	err := validateCVV("123")
+	assert.NoError(t, err)
+
+	err = validateCVV("000")
+	assert.NoError(t, err)
+}
+
+func TestValidateCVV_Invalid(t *testing.T) {
+	// Test wrong length
+	err := validateCVV("12")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "exactly 3 digits")
+
+	err = validateCVV("1234")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "exactly 3 digits")
+
+	// Test non-numeric
+	err = validateCVV("12a")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "exactly 3 digits")
+}
@@ -0,0 +1,253 @@
+package models
+
+import (
+	pb "gophkeeper/internal/protos/orgs"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type OrgRole string
+
+func (r OrgRole) String() string {
+	return string(r)
+}
+
+const (
+	OrgRoleOWNER  OrgRole = "OWNER"
+	OrgRoleADMIN  OrgRole = "ADMIN"
+	OrgRoleEDITOR OrgRole = "EDITOR"
+	OrgRoleVIEWER OrgRole = "VIEWER"
+)
+
+// OrgRoles lists the roles from the most to the least privileged.
+var OrgRoles []OrgRole = []OrgRole{OrgRoleOWNER, OrgRoleADMIN, OrgRoleEDITOR, OrgRoleVIEWER}
+
+func (r OrgRole) IsValid() bool {
+	switch r {
+	case OrgRoleOWNER, OrgRoleADMIN, OrgRoleEDITOR, OrgRoleVIEWER:
+		return true
+	}
+	return false
+}
+
+// CanManageMembers reports whether the role may invite, remove members and
+// change their roles.
+func (r OrgRole) CanManageMembers() bool {
+	return r == OrgRoleOWNER || r == OrgRoleADMIN
+}
+
+// CanWriteItems reports whether the role may add, edit and delete collection items.
+func (r OrgRole) CanWriteItems() bool {
+	return r.CanManageMembers() || r == OrgRoleEDITOR
+}
+
+func (r OrgRole) CanReadItems() bool {
+	return r.IsValid()
+}
+
+// Organization is an organization as seen by one of its members.
+// WrappedKey is the organization key wrapped with the member's public key.
+type Organization struct {
+	ID         [16]byte
+	Name       string
+	CreatedBy  string
+	Role       OrgRole
+	Accepted   bool
+	WrappedKey string
+	CreatedAt  time.Time
+
+	// KeyRotationDue is set when a member left on their own, the owner or an
+	// admin has to rotate the organization key.
+	KeyRotationDue bool
+}
+
+// Membership links a user to an organization. Invited users get the
+// organization key in advance and become active after accepting the invite.
+type Membership struct {
+	OrgID      [16]byte
+	Login      string
+	Role       OrgRole
+	Accepted   bool
+	WrappedKey string
+	InvitedBy  string
+	CreatedAt  time.Time
+}
+
+// Collection groups organization items. EncryptedKey is the collection key
+// sealed with the organization key, item data keys are sealed with the collection key.
+type Collection struct {
+	ID           [16]byte
+	OrgID        [16]byte
+	Name         string
+	EncryptedKey string
+	CreatedAt    time.Time
+}
+
+// OrgKeyRotation removes a member, when Login is set, and replaces the
+// organization key. Members holds the new key wrapped for every remaining
+// member and Collections new collection keys sealed with the new
+// organization key. Items holds every collection item re-encrypted with a new
+// data key sealed with its new collection key and Attachments their
+// attachment infos re-sealed with the new data keys.
+type OrgKeyRotation struct {
+	OrgID       [16]byte
+	Login       string
+	Members     []Membership
+	Collections []Collection
+	Items       []EncryptedItem
+	Attachments []Attachment
+}
+
+func (r OrgRole) ToPb() pb.OrgRole {
+	switch r {
+	case OrgRoleOWNER:
+		return pb.OrgRole_ORG_ROLE_OWNER
+	case OrgRoleADMIN:
+		return pb.OrgRole_ORG_ROLE_ADMIN
+	case OrgRoleEDITOR:
+		return pb.OrgRole_ORG_ROLE_EDITOR
+	case OrgRoleVIEWER:
+		return pb.OrgRole_ORG_ROLE_VIEWER
+	default:
+		return pb.OrgRole_ORG_ROLE_UNSPECIFIED
+	}
+}
+
+func OrgRolePbToModels(r pb.OrgRole) OrgRole {
+	switch r {
+	case pb.OrgRole_ORG_ROLE_OWNER:
+		return OrgRoleOWNER
+	case pb.OrgRole_ORG_ROLE_ADMIN:
+		return OrgRoleADMIN
+	case pb.OrgRole_ORG_ROLE_EDITOR:
+		return OrgRoleEDITOR
+	case pb.OrgRole_ORG_ROLE_VIEWER:
+		return OrgRoleVIEWER
+	default:
+		return ""
+	}
+}
+
+func (o *Organization) ToPb() *pb.Organization {
+	return &pb.Organization{
+		Id:             o.ID[:],
+		Name:           o.Name,
+		CreatedBy:      o.CreatedBy,
+		Role:           o.Role.ToPb(),
+		Accepted:       o.Accepted,
+		WrappedKey:     o.WrappedKey,
+		CreatedAt:      timestamppb.New(o.CreatedAt),
+		KeyRotationDue: o.KeyRotationDue,
+	}
+}
+
+func OrganizationPbToModels(o *pb.Organization) *Organization {
+	return &Organization{
+		ID:             ItemIdPbToModels(o.Id),
+		Name:           o.Name,
+		CreatedBy:      o.CreatedBy,
+		Role:           OrgRolePbToModels(o.Role),
+		Accepted:       o.Accepted,
+		WrappedKey:     o.WrappedKey,
+		CreatedAt:      o.CreatedAt.AsTime(),
+		KeyRotationDue: o.KeyRotationDue,
+	}
+}
+
+func (m *Membership) ToPb() *pb.Membership {
+	return &pb.Membership{
+		OrgId:      m.OrgID[:],
+		Login:      m.Login,
+		Role:       m.Role.ToPb(),
+		Accepted:   m.Accepted,
+		WrappedKey: m.WrappedKey,
+		InvitedBy:  m.InvitedBy,
+		CreatedAt:  timestamppb.New(m.CreatedAt),
+	}
+}
+
+func MembershipPbToModels(m *pb.Membership) *Membership {
+	return &Membership{
+		OrgID:      ItemIdPbToModels(m.OrgId),
+		Login:      m.Login,
+		Role:       OrgRolePbToModels(m.Role),
+		Accepted:   m.Accepted,
+		WrappedKey: m.WrappedKey,
+		InvitedBy:  m.InvitedBy,
+		CreatedAt:  m.CreatedAt.AsTime(),
+	}
+}
+
+func (c *Collection) ToPb() *pb.Collection {
+	return &pb.Collection{
+		Id:           c.ID[:],
+		OrgId:        c.OrgID[:],
+		Name:         c.Name,
+		EncryptedKey: c.EncryptedKey,
+		CreatedAt:    timestamppb.New(c.CreatedAt),
+	}
+}
+
+func CollectionPbToModels(c *pb.Collection) *Collection {
+	return &Collection{
+		ID:           ItemIdPbToModels(c.Id),
+		OrgID:        ItemIdPbToModels(c.OrgId),
+		Name:         c.Name,
+		EncryptedKey: c.EncryptedKey,
+		CreatedAt:    c.CreatedAt.AsTime(),
+	}
+}
+
+// RekeyedItemToPb keeps only what a key rotation changes, rotated items are
+// always in the V3 format. Agents from before V3 send no header, their items
+// are in the V2 format.
+func RekeyedItemToPb(item *EncryptedItem) *pb.RekeyedItem {
+	return &pb.RekeyedItem{
+		Id:                     item.ID[:],
+		CollectionId:           item.CollectionID[:],
+		EncryptedKey:           item.EncryptedKey,
+		EncryptedDataContent:   item.EncryptedData.EncryptedContent,
+		EncryptedDataNonce:     item.EncryptedData.Nonce,
+		EncryptedHeaderContent: item.EncryptedHeader.EncryptedContent,
+		EncryptedHeaderNonce:   item.EncryptedHeader.Nonce,
+	}
+}
+
+func RekeyedItemPbToModels(in *pb.RekeyedItem) *EncryptedItem {
+	item := &EncryptedItem{
+		ID:           ItemIdPbToModels(in.Id),
+		CollectionID: ItemIdPbToModels(in.CollectionId),
+		EncryptedKey: in.EncryptedKey,
+		EncryptedData: EncryptedData{
+			EncryptedContent: in.EncryptedDataContent,
+			Nonce:            in.EncryptedDataNonce,
+		},
+		Format: ItemFormatV2,
+	}
+	if in.EncryptedHeaderContent != "" {
+		item.EncryptedHeader = EncryptedData{EncryptedContent: in.EncryptedHeaderContent, Nonce: in.EncryptedHeaderNonce}
+		item.Format = ItemFormatV3
+	}
+	return item
+}
+
+func RekeyedAttachmentToPb(a *Attachment) *pb.RekeyedAttachment {
+	return &pb.RekeyedAttachment{
+		Id:                   a.ID[:],
+		ItemId:               a.ItemID[:],
+		EncryptedInfoContent: a.EncryptedInfo.EncryptedContent,
+		EncryptedInfoNonce:   a.EncryptedInfo.Nonce,
+	}
+}
+
+func RekeyedAttachmentPbToModels(in *pb.RekeyedAttachment) *Attachment {
+	return &Attachment{
+		ID:     ItemIdPbToModels(in.Id),
+		ItemID: ItemIdPbToModels(in.ItemId),
+		EncryptedInfo: EncryptedData{
+			EncryptedContent: in.EncryptedInfoContent,
+			Nonce:            in.EncryptedInfoNonce,
+		},
+	}
+}
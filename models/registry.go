@@ -0,0 +1,205 @@
+package models
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	pb "gophkeeper/internal/protos/items"
+)
+
+// TypeInfo describes an item type. Registering it is what makes the type
+// known to the converters, the item encryption and the generic screens of
+// the agent. The type still needs its value in the proto and SQL enums.
+type TypeInfo struct {
+	Type ItemType
+	Pb   pb.ItemType
+
+	// Name is shown to the user.
+	Name string
+
+	// Version is the version of the JSON encoding of the data. Bump it
+	// when the encoding changes so older agents refuse newer items instead
+	// of dropping fields.
+	Version int
+
+	// New returns empty data of the type.
+	New func() Data
+
+	// Fields describe the data field by field. The agent enters, checks
+	// and shows the data through them.
+	Fields []FieldInfo
+}
+
+// FieldInfo describes a field of item data. Hidden fields are secrets that
+// are masked until revealed.
+type FieldInfo struct {
+	Label    string
+	Type     FieldType
+	Required bool
+
+	Get func(Data) string
+	Set func(Data, string)
+
+	// Validate checks the value beyond its type, it may be nil.
+	Validate func(string) error
+
+	// Applies reports whether the field is used by data, nil means it
+	// always is. It lets one type describe several kinds of data.
+	Applies func(Data) bool
+}
+
+// Secret reports whether the field is masked until revealed.
+func (f FieldInfo) Secret() bool {
+	return f.Type == FieldHidden
+}
+
+// FieldsOf returns the fields that apply to data.
+func (info TypeInfo) FieldsOf(data Data) []FieldInfo {
+	fields := make([]FieldInfo, 0, len(info.Fields))
+	for _, f := range info.Fields {
+		if f.Applies == nil || f.Applies(data) {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+// Validator is implemented by data that checks itself as a whole.
+type Validator interface {
+	Validate() error
+}
+
+var (
+	registry = map[ItemType]TypeInfo{}
+
+	// ItemTypes lists the registered types in registration order.
+	ItemTypes []ItemType
+)
+
+// RegisterType adds an item type. It panics when the type or its proto
+// value is registered twice.
+func RegisterType(info TypeInfo) {
+	if _, ok := registry[info.Type]; ok {
+		panic(fmt.Sprintf("models: item type %s registered twice", info.Type))
+	}
+	for _, other := range registry {
+		if other.Pb == info.Pb {
+			panic(fmt.Sprintf("models: proto value of %s already used by %s", info.Type, other.Type))
+		}
+	}
+	if info.Version == 0 {
+		info.Version = 1
+	}
+	registry[info.Type] = info
+	ItemTypes = append(ItemTypes, info.Type)
+}
+
+// LookupType returns the registered description of t.
+func LookupType(t ItemType) (TypeInfo, bool) {
+	info, ok := registry[t]
+	return info, ok
+}
+
+// ValidateData checks every described field of data and then the data as
+// a whole.
+func ValidateData(data Data) error {
+	info, ok := LookupType(data.GetType())
+	if !ok {
+		return fmt.Errorf("unknown item type: %s", data.GetType())
+	}
+	for _, f := range info.FieldsOf(data) {
+		if err := f.Check(f.Get(data)); err != nil {
+			return err
+		}
+	}
+	if v, ok := data.(Validator); ok {
+		return v.Validate()
+	}
+	return nil
+}
+
+// Check validates a value of the field.
+func (f FieldInfo) Check(value string) error {
+	if strings.TrimSpace(value) == "" {
+		if f.Required {
+			return fmt.Errorf("%s is required", f.Label)
+		}
+		return nil
+	}
+	if err := f.Type.Validate(value); err != nil {
+		return fmt.Errorf("%s %w", f.Label, err)
+	}
+	if f.Validate != nil {
+		if err := f.Validate(value); err != nil {
+			return fmt.Errorf("%s %w", f.Label, err)
+		}
+	}
+	return nil
+}
+
+// Template returns the fields that apply to data as a template, so the
+// agent can enter them with the CUSTOM item form.
+func (info TypeInfo) Template(data Data) ItemTemplate {
+	fields := info.FieldsOf(data)
+	tmpl := ItemTemplate{Name: info.Name, Fields: make([]TemplateField, len(fields))}
+	for i, f := range fields {
+		tmpl.Fields[i] = TemplateField{Name: f.Label, Type: f.Type, Required: f.Required}
+	}
+	return tmpl
+}
+
+// Form returns the described fields of data with their values.
+func (info TypeInfo) Form(data Data) *Custom {
+	tmpl := info.Template(data)
+	form := tmpl.NewItemData()
+	for i, f := range info.FieldsOf(data) {
+		form.Fields[i].Value = f.Get(data)
+	}
+	return form
+}
+
+// ApplyForm copies the values of a form made by Form to data if they are
+// valid. Data that is not described by fields is kept.
+func (info TypeInfo) ApplyForm(data Data, form *Custom) error {
+	fields := info.FieldsOf(data)
+	if len(form.Fields) != len(fields) {
+		return fmt.Errorf("%s form has %d fields, want %d", info.Type, len(form.Fields), len(fields))
+	}
+
+	// Check the values before they are set, fields that convert them
+	// cannot hold what does not parse. Then check a copy, so data is left
+	// alone when the values do not fit together.
+	check := CloneData(data)
+	for i, f := range fields {
+		if err := f.Check(form.Fields[i].Value); err != nil {
+			return err
+		}
+		f.Set(check, form.Fields[i].Value)
+	}
+	if err := ValidateData(check); err != nil {
+		return err
+	}
+
+	for i, f := range fields {
+		f.Set(data, form.Fields[i].Value)
+	}
+	return nil
+}
+
+// CloneData returns a shallow copy of data, which must be a pointer to a
+// struct like all item data.
+func CloneData(data Data) Data {
+	clone := reflect.New(reflect.TypeOf(data).Elem())
+	clone.Elem().Set(reflect.ValueOf(data).Elem())
+	return clone.Interface().(Data)
+}
+
+func itemTypeByPb(t pb.ItemType) (ItemType, bool) {
+	for _, typ := range ItemTypes {
+		if registry[typ].Pb == t {
+			return typ, true
+		}
+	}
+	return "", false
+}
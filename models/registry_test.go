@@ -0,0 +1,124 @@
+package models
+
+import (
+	"testing"
+
+	pb "gophkeeper/internal/protos/items"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegisterType_Duplicates(t *testing.T) {
+	assert.Panics(t, func() {
+		RegisterType(TypeInfo{Type: ItemTypeTEXT, Pb: pb.ItemType(100), New: func() Data { return &Text{} }})
+	})
+	assert.Panics(t, func() {
+		RegisterType(TypeInfo{Type: ItemType("NOTE"), Pb: pb.ItemType_ITEM_TYPE_TEXT, New: func() Data { return &Text{} }})
+	})
+	_, ok := LookupType(ItemType("NOTE"))
+	assert.False(t, ok)
+}
+
+func TestRegistry_Types(t *testing.T) {
+	for _, typ := range ItemTypes {
+		info, ok := LookupType(typ)
+		require.True(t, ok, typ)
+		assert.NotEmpty(t, info.Name, typ)
+		assert.Equal(t, 1, info.Version, typ)
+		assert.Equal(t, typ, info.New().GetType(), typ)
+
+		pbType := typ.ToPb()
+		assert.NotEqual(t, pb.ItemType_ITEM_TYPE_UNSPECIFIED, pbType, typ)
+		assert.Equal(t, typ, ItemTypePbToModel(pbType), typ)
+	}
+}
+
+func TestValidateData(t *testing.T) {
+	assert.NoError(t, ValidateData(&Text{Content: "x"}))
+	assert.NoError(t, ValidateData(&Identity{DocumentType: "passport", Number: "X1"}))
+	assert.ErrorContains(t, ValidateData(&Identity{DocumentType: "passport"}), "Number is required")
+	assert.ErrorContains(t, ValidateData(&Identity{DocumentType: "passport", Number: "X1", IssueDate: "2020-13-01"}), "Issue date must be a date")
+	assert.ErrorContains(t, ValidateData(&Identity{
+		DocumentType: "passport",
+		Number:       "X1",
+		IssueDate:    "2030-01-01",
+		ExpiryDate:   "2020-01-01",
+	}), "after the issue date")
+	assert.ErrorContains(t, ValidateData(&Connection{Kind: ConnectionPostgres}), "Host is required")
+	assert.ErrorContains(t, ValidateData(&Connection{Kind: ConnectionHTTP}), "Base URL is required")
+	assert.ErrorContains(t, ValidateData(&Card{Number: "4111", ExpiryDate: "12/30", SecurityCode: "123", CardholderName: "A"}), "Number must be 16 or 18 digits")
+	assert.NoError(t, ValidateData(&TOTP{Secret: "JBSWY3DPEHPK3PXP"}))
+	assert.ErrorContains(t, ValidateData(&TOTP{Secret: "JBSWY3DPEHPK3PXP", Digits: 9}), "Digits must be a whole number between 6 and 8")
+	assert.ErrorContains(t, ValidateData(&TOTP{Secret: "not base32!"}), "Secret must be base32")
+}
+
+func TestRegistry_Fields(t *testing.T) {
+	for _, typ := range ItemTypes {
+		info, _ := LookupType(typ)
+		if typ == ItemTypeCUSTOM {
+			assert.Empty(t, info.Fields)
+			continue
+		}
+		assert.NotEmpty(t, info.Fields, typ)
+	}
+}
+
+func TestTypeInfo_Form(t *testing.T) {
+	info, ok := LookupType(ItemTypeIDENTITY)
+	require.True(t, ok)
+
+	data := &Identity{DocumentType: "passport", Number: "X1", FrontImage: [16]byte{1}}
+	form := info.Form(data)
+	require.Len(t, form.Fields, len(info.Fields))
+	assert.Equal(t, FieldHidden, form.Fields[1].Type)
+	assert.Equal(t, "X1", form.Fields[1].Value)
+	tmpl := info.Template(data)
+	assert.True(t, tmpl.Required("Document type"))
+
+	form.Fields[1].Value = ""
+	assert.Error(t, info.ApplyForm(data, form))
+	assert.Equal(t, "X1", data.Number)
+
+	form.Fields[1].Value = "X2"
+	form.Fields[3].Value = "NL"
+	require.NoError(t, info.ApplyForm(data, form))
+	assert.Equal(t, &Identity{DocumentType: "passport", Number: "X2", Country: "NL", FrontImage: [16]byte{1}}, data)
+}
+
+func TestTypeInfo_FormOfKind(t *testing.T) {
+	info, ok := LookupType(ItemTypeCONNECTION)
+	require.True(t, ok)
+
+	http := &Connection{Kind: ConnectionHTTP, BaseURL: "https://api.example.com"}
+	form := info.Form(http)
+	require.Len(t, form.Fields, 2)
+	assert.Equal(t, "Base URL", form.Fields[0].Name)
+	assert.Equal(t, "https://api.example.com", form.Fields[0].Value)
+
+	db := &Connection{Kind: ConnectionMySQL, Host: "localhost", Port: 3307}
+	form = info.Form(db)
+	require.Len(t, form.Fields, 6)
+	assert.Equal(t, "3307", form.Fields[1].Value)
+	assert.Contains(t, form.Fields[5].Name, "skip-verify")
+
+	form.Fields[1].Value = "x"
+	assert.ErrorContains(t, info.ApplyForm(db, form), "Port must be a number")
+	form.Fields[1].Value = "70000"
+	assert.ErrorContains(t, info.ApplyForm(db, form), "Port must be a whole number between 1 and 65535")
+	assert.Equal(t, 3307, db.Port)
+
+	form.Fields[1].Value = ""
+	form.Fields[5].Value = "require"
+	assert.ErrorContains(t, info.ApplyForm(db, form), "TLS mode")
+	form.Fields[5].Value = "true"
+	require.NoError(t, info.ApplyForm(db, form))
+	assert.Equal(t, &Connection{Kind: ConnectionMySQL, Host: "localhost", TLSMode: "true"}, db)
+}
+
+func TestCloneData(t *testing.T) {
+	data := &Card{Number: "4111"}
+	clone := CloneData(data).(*Card)
+	clone.Number = "5500"
+	assert.Equal(t, "4111", data.Number)
+}
@@ -0,0 +1,49 @@
+package models
+
+import (
+	pb "gophkeeper/internal/protos/items"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Send is a one-time secret for people without an account. The key travels
+// in the URL fragment, so the server only ever sees the ciphertext.
+type Send struct {
+	ID            [16]byte
+	OwnerLogin    string
+	EncryptedData EncryptedData
+	MaxViews      int32
+	Views         int32
+	ExpiresAt     time.Time
+	CreatedAt     time.Time
+}
+
+// ViewsLeft is how many more times the send can be opened.
+func (s *Send) ViewsLeft() int32 {
+	if s.Views >= s.MaxViews {
+		return 0
+	}
+	return s.MaxViews - s.Views
+}
+
+func (s *Send) ToCreatePb() *pb.CreateSendRequest {
+	return &pb.CreateSendRequest{
+		EncryptedData: s.EncryptedData.ToPb(),
+		MaxViews:      uint32(s.MaxViews),
+		ExpiresAt:     timestamppb.New(s.ExpiresAt),
+	}
+}
+
+func SendPbToModels(in *pb.CreateSendRequest) *Send {
+	send := &Send{
+		MaxViews: int32(in.MaxViews),
+	}
+	if in.EncryptedData != nil {
+		send.EncryptedData = EncryptedDataPbToModel(in.EncryptedData)
+	}
+	if in.ExpiresAt != nil {
+		send.ExpiresAt = in.ExpiresAt.AsTime()
+	}
+	return send
+}
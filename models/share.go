@@ -0,0 +1,94 @@
+package models
+
+import (
+	pb "gophkeeper/internal/protos/items"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// UserKeys is the X25519 key pair used to share items. The private key is
+// encrypted on the agent with the owner's master key.
+type UserKeys struct {
+	Login               string
+	PublicKey           []byte
+	EncryptedPrivateKey string
+}
+
+// ItemShare grants a recipient access to an item via the item data key
+// wrapped with the recipient's public key.
+type ItemShare struct {
+	ItemID         [16]byte
+	RecipientLogin string
+	WrappedKey     string
+	CreatedAt      time.Time
+}
+
+// SharedItem is an item shared with the current user. Item.UserLogin is the owner.
+type SharedItem struct {
+	Item       EncryptedItem
+	WrappedKey string
+}
+
+// ShareRevocation removes a recipient and rotates the item data key so the
+// removed recipient cannot decrypt later edits. Item holds the data
+// re-encrypted with the new key and Shares the new key wrapped for every
+// remaining recipient. Attachments holds the EncryptedInfo of every item
+// attachment re-sealed with the new key, their content is left as is.
+type ShareRevocation struct {
+	ItemID         [16]byte
+	RecipientLogin string
+	Item           EncryptedItem
+	Shares         []ItemShare
+	Attachments    []Attachment
+}
+
+func (k *UserKeys) ToPb() *pb.UserKeys {
+	return &pb.UserKeys{
+		PublicKey:           k.PublicKey,
+		EncryptedPrivateKey: k.EncryptedPrivateKey,
+	}
+}
+
+func UserKeysPbToModels(k *pb.UserKeys) *UserKeys {
+	return &UserKeys{
+		PublicKey:           k.PublicKey,
+		EncryptedPrivateKey: k.EncryptedPrivateKey,
+	}
+}
+
+func (s *ItemShare) ToPb() *pb.ItemShare {
+	return &pb.ItemShare{
+		ItemId:         s.ItemID[:],
+		RecipientLogin: s.RecipientLogin,
+		WrappedKey:     s.WrappedKey,
+		CreatedAt:      timestamppb.New(s.CreatedAt),
+	}
+}
+
+func ItemSharePbToModels(s *pb.ItemShare) *ItemShare {
+	return &ItemShare{
+		ItemID:         ItemIdPbToModels(s.ItemId),
+		RecipientLogin: s.RecipientLogin,
+		WrappedKey:     s.WrappedKey,
+		CreatedAt:      s.CreatedAt.AsTime(),
+	}
+}
+
+func (s *SharedItem) ToPb() (*pb.SharedItem, error) {
+	item, err := s.Item.ToPb()
+	if err != nil {
+		return nil, err
+	}
+	return &pb.SharedItem{
+		Item:       item,
+		WrappedKey: s.WrappedKey,
+	}, nil
+}
+
+func SharedItemPbToModels(s *pb.SharedItem) *SharedItem {
+	return &SharedItem{
+		Item:       *EncryptedItemPbToModels(s.Item),
+		WrappedKey: s.WrappedKey,
+	}
+}
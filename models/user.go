@@ -1,7 +1,17 @@
 package models
 
+import "time"
+
 type User struct {
-	Login    string
-	Password []byte
-	Salt     string
+	Login             string
+	Password          []byte
+	Salt              string
+	Locked            bool
+	SessionsRevokedAt time.Time
+	DeletedAt         time.Time
+	CreatedAt         time.Time
+}
+
+func (u *User) IsDeleted() bool {
+	return !u.DeletedAt.IsZero()
 }